@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newBisectCmd creates the sustainable send rate bisect subcommand
+func newBisectCmd() *ffcli.Command {
+	var (
+		cfg       = &internal.Config{}
+		bisectCfg = &internal.BisectConfig{}
+
+		fs = flag.NewFlagSet("bisect", flag.ExitOnError)
+	)
+
+	// Each measurement window is a run of the same configuration
+	registerFlags(fs, cfg)
+
+	fs.Float64Var(
+		&bisectCfg.MinTPS,
+		"min-tps",
+		10,
+		"the lowest probed send rate (tx/s)",
+	)
+
+	fs.Float64Var(
+		&bisectCfg.MaxTPS,
+		"max-tps",
+		1000,
+		"the highest probed send rate (tx/s)",
+	)
+
+	fs.StringVar(
+		&bisectCfg.Criterion,
+		"criterion",
+		"failed_ratio<0.01",
+		"the comma separated conditions each window needs to satisfy "+
+			"(failed_ratio, p50_latency, p95_latency, p99_latency, tps)",
+	)
+
+	fs.DurationVar(
+		&bisectCfg.Window,
+		"window",
+		30*time.Second,
+		"the length of each fixed-rate measurement window",
+	)
+
+	fs.Float64Var(
+		&bisectCfg.Tolerance,
+		"tolerance",
+		10,
+		"the send rate precision (tx/s) the search stops at",
+	)
+
+	fs.Uint64Var(
+		&bisectCfg.MaxIterations,
+		"max-iterations",
+		10,
+		"the maximum number of measurement windows, including the rate bounds",
+	)
+
+	return &ffcli.Command{
+		Name:       "bisect",
+		ShortUsage: "bisect -min-tps <rate> -max-tps <rate> -criterion <conditions> -output <path> [flags]",
+		ShortHelp:  "Binary-searches the highest sustainable send rate",
+		LongHelp: "Runs short fixed-rate measurement windows, evaluates the criterion on the results of each, " +
+			"and binary-searches the highest send rate that still satisfies it. The bisect trace and " +
+			"the sustainable rate are written out to the output path",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if err := bisectCfg.Validate(); err != nil {
+				return fmt.Errorf("invalid bisect configuration, %w", err)
+			}
+
+			applyTxIndexRequirement(fs, cfg)
+
+			return internal.Bisect(cfg, bisectCfg, execMain)
+		},
+	}
+}
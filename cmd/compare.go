@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gnolang/supernova/internal/compare"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+var (
+	errMissingResults = errors.New("both the baseline and candidate results are required")
+	errRegression     = errors.New("likely regression detected")
+)
+
+// newCompareCmd creates the baseline comparison subcommand
+func newCompareCmd() *ffcli.Command {
+	var (
+		baseline  string
+		candidate string
+		gate      string
+
+		fs = flag.NewFlagSet("compare", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&baseline,
+		"baseline",
+		"",
+		"comma separated baseline results files, run manifests, or directories of results files",
+	)
+
+	fs.StringVar(
+		&candidate,
+		"candidate",
+		"",
+		"comma separated candidate results files, run manifests, or directories of results files",
+	)
+
+	fs.StringVar(
+		&gate,
+		"gate",
+		compare.DefaultGateMetric,
+		"the metric whose likely regression fails the comparison (empty disables gating)",
+	)
+
+	return &ffcli.Command{
+		Name:       "compare",
+		ShortUsage: "compare -baseline <paths> -candidate <paths>",
+		ShortHelp:  "Compares candidate run results against a baseline",
+		LongHelp: "Compares candidate run results against a baseline. " +
+			"With multiple runs on both sides, each metric difference is tested for significance. " +
+			"A likely regression of the gating metric (steady-state TPS by default) fails the comparison",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if baseline == "" || candidate == "" {
+				return errMissingResults
+			}
+
+			baselineResults, err := compare.LoadResults(strings.Split(baseline, ","))
+			if err != nil {
+				return fmt.Errorf("unable to load baseline results, %w", err)
+			}
+
+			candidateResults, err := compare.LoadResults(strings.Split(candidate, ","))
+			if err != nil {
+				return fmt.Errorf("unable to load candidate results, %w", err)
+			}
+
+			report := compare.Compare(baselineResults, candidateResults)
+			report.Display(os.Stdout)
+
+			if gate == "" {
+				return nil
+			}
+
+			gated := report.Gate(gate)
+			if gated == nil {
+				fmt.Printf("\n⚠️ The gating metric %s is missing from the results, the comparison is not gated\n", gate)
+
+				return nil
+			}
+
+			if report.Regressed(gate) {
+				return fmt.Errorf("%w: %s", errRegression, gated.Name)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newEstimateCmd creates the offline run cost estimation subcommand
+func newEstimateCmd() *ffcli.Command {
+	var (
+		cfg = &internal.EstimateConfig{}
+		fs  = flag.NewFlagSet("estimate", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&cfg.Mode,
+		"mode",
+		runtime.RealmDeployment.String(),
+		fmt.Sprintf(
+			"the mode for the estimated run. Possible modes: [%s, %s, %s, %s, %s]",
+			runtime.RealmDeployment.String(), runtime.PackageDeployment.String(), runtime.RealmCall.String(),
+			runtime.Mint.String(), runtime.CustomScenario.String(),
+		),
+	)
+
+	fs.Uint64Var(
+		&cfg.Transactions,
+		"transactions",
+		100,
+		"the total number of transactions to be emitted",
+	)
+
+	fs.Uint64Var(
+		&cfg.SubAccounts,
+		"sub-accounts",
+		10,
+		"the number of sub-accounts that will send out transactions",
+	)
+
+	fs.Uint64Var(
+		&cfg.FundingBatch,
+		"funding-batch-size",
+		distributor.DefaultFundingBatchSize,
+		"the maximum number of sub-account transfers in a single funding transaction",
+	)
+
+	fs.Uint64Var(
+		&cfg.PrimingCalls,
+		"priming-calls",
+		5,
+		"the number of unmeasured calls priming the target realm, for REALM_CALL",
+	)
+
+	fs.StringVar(
+		&cfg.MintRealm,
+		"mint-realm",
+		"",
+		"the existing realm targeted by MINT, in which case no mint realm is deployed",
+	)
+
+	fs.Uint64Var(
+		&cfg.StorageDeposit,
+		"storage-deposit",
+		0,
+		"the storage deposit paid by each package deployment transaction",
+	)
+
+	fs.StringVar(
+		&cfg.StorageDepositDenom,
+		"storage-deposit-denom",
+		common.Denomination,
+		"the denomination of the storage deposit",
+	)
+
+	fs.Uint64Var(
+		&cfg.GasFee,
+		"gas-fee",
+		uint64(common.DefaultGasFee.Amount),
+		"the fee (in ugnot) paid by each transaction, unless overridden for its message type",
+	)
+
+	fs.StringVar(
+		&cfg.Fees,
+		"fees",
+		"",
+		"the JSON file of gas wanted and gas fee overrides per message type (send, call, add_package, run)",
+	)
+
+	fs.Uint64Var(
+		&cfg.VMCost,
+		"vm-cost",
+		uint64(common.InitialTxCost.Amount),
+		"the fixed cost (in ugnot) the node charges for each run transaction",
+	)
+
+	return &ffcli.Command{
+		Name:       "estimate",
+		ShortUsage: "estimate [flags]",
+		ShortHelp:  "Estimates the distributor balance required for a run",
+		LongHelp: "Runs the cost model of the run offline, without any node access, " +
+			"and prints the required distributor balance with its breakdown",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			return internal.Estimate(cfg)
+		},
+	}
+}
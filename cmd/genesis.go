@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newGenesisCmd creates the genesis balances subcommand
+func newGenesisCmd() *ffcli.Command {
+	var (
+		cfg    = &internal.GenesisConfig{}
+		output string
+
+		fs = flag.NewFlagSet("genesis-balances", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&cfg.Mnemonic,
+		"mnemonic",
+		"",
+		"the mnemonic used to derive the distributor and sub-accounts",
+	)
+
+	fs.Uint64Var(
+		&cfg.SubAccounts,
+		"sub-accounts",
+		10,
+		"the number of sub-accounts funded in genesis",
+	)
+
+	fs.Uint64Var(
+		&cfg.SubAccountOffset,
+		"sub-account-offset",
+		1,
+		"the mnemonic derivation index of the first sub-account",
+	)
+
+	fs.Uint64Var(
+		&cfg.DistributorIndex,
+		"distributor-index",
+		0,
+		"the mnemonic derivation index of the distributor (funding) account",
+	)
+
+	fs.StringVar(
+		&cfg.Amount,
+		"amount",
+		"10000000000ugnot",
+		"the genesis funds of each sub-account (ugnot if no denomination is specified)",
+	)
+
+	fs.StringVar(
+		&cfg.DistributorAmount,
+		"distributor-amount",
+		"",
+		"the genesis funds of the distributor (the sub-account amount if empty)",
+	)
+
+	fs.StringVar(
+		&output,
+		"output",
+		"",
+		"the output path for the genesis balances (stdout if empty)",
+	)
+
+	return &ffcli.Command{
+		Name:       "genesis-balances",
+		ShortUsage: "genesis-balances -mnemonic <mnemonic> [flags]",
+		ShortHelp:  "Generates the genesis balances for a genesis funded run",
+		LongHelp: "Generates the genesis balances snippet that funds the distributor and sub-accounts, " +
+			"for inclusion in the node genesis. Use the snippet with -assume-genesis-funded to skip the distribution",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			var w io.Writer = os.Stdout
+
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("unable to create genesis balances file, %w", err)
+				}
+
+				defer f.Close()
+
+				w = f
+			}
+
+			return internal.GenerateGenesisBalances(cfg, w)
+		},
+	}
+}
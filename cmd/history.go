@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gnolang/supernova/internal/history"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+const historyDateFormat = "2006-01-02"
+
+var (
+	errMissingRunID = errors.New("the run ID is required")
+	errInvalidDate  = errors.New("invalid date, expected YYYY-MM-DD or RFC3339")
+)
+
+type historyCfg struct {
+	db   string
+	json bool
+
+	mode    string
+	chainID string
+	label   string
+	since   string
+	until   string
+}
+
+// registerFlags registers the common history flags
+func (c *historyCfg) registerFlags(fs *flag.FlagSet, withFilters bool) {
+	fs.StringVar(
+		&c.db,
+		"history-db",
+		defaultHistory,
+		"the local run history database",
+	)
+
+	fs.BoolVar(
+		&c.json,
+		"json",
+		false,
+		"flag indicating if the output is JSON, instead of a table",
+	)
+
+	if !withFilters {
+		return
+	}
+
+	fs.StringVar(&c.mode, "mode", "", "the run mode filter")
+	fs.StringVar(&c.chainID, "chain-id", "", "the chain ID filter")
+	fs.StringVar(&c.label, "label", "", "the run label filter")
+	fs.StringVar(&c.since, "since", "", "the earliest run date (YYYY-MM-DD or RFC3339), inclusive")
+	fs.StringVar(&c.until, "until", "", "the latest run date (YYYY-MM-DD or RFC3339), inclusive")
+}
+
+// filter returns the history filter from the flags
+func (c *historyCfg) filter() (history.Filter, error) {
+	f := history.Filter{
+		Mode:    c.mode,
+		ChainID: c.chainID,
+		Label:   c.label,
+	}
+
+	var err error
+
+	if c.since != "" {
+		if f.Since, _, err = parseHistoryDate(c.since); err != nil {
+			return f, err
+		}
+	}
+
+	if c.until != "" {
+		until, dateOnly, err := parseHistoryDate(c.until)
+		if err != nil {
+			return f, err
+		}
+
+		// Dates are inclusive of the entire day
+		if dateOnly {
+			until = until.Add(24 * time.Hour)
+		} else {
+			until = until.Add(time.Nanosecond)
+		}
+
+		f.Until = until
+	}
+
+	return f, nil
+}
+
+// parseHistoryDate parses the date filter, and
+// returns if it was a date without a time of day
+func parseHistoryDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(historyDateFormat, value); err == nil {
+		return t, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s", errInvalidDate, value)
+	}
+
+	return t, false, nil
+}
+
+// newHistoryCmd creates the run history subcommand
+func newHistoryCmd() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "history",
+		ShortUsage: "history <list|show|trend> [flags]",
+		ShortHelp:  "Queries the local run history",
+		LongHelp: "Queries the local run history, indexed by runs with -history. " +
+			"Runs can be filtered by mode, chain ID, label and date range",
+		FlagSet: flag.NewFlagSet("history", flag.ExitOnError),
+		Subcommands: []*ffcli.Command{
+			newHistoryListCmd(),
+			newHistoryShowCmd(),
+			newHistoryTrendCmd(),
+		},
+		Exec: func(_ context.Context, _ []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// newHistoryListCmd creates the run history list subcommand
+func newHistoryListCmd() *ffcli.Command {
+	var (
+		cfg = &historyCfg{}
+		fs  = flag.NewFlagSet("list", flag.ExitOnError)
+	)
+
+	cfg.registerFlags(fs, true)
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "history list [flags]",
+		ShortHelp:  "Lists the runs in the history",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, _ []string) error {
+			entries, err := queryHistory(cfg)
+			if err != nil {
+				return err
+			}
+
+			if cfg.json {
+				return printJSON(entries)
+			}
+
+			history.DisplayEntries(os.Stdout, entries)
+
+			return nil
+		},
+	}
+}
+
+// newHistoryShowCmd creates the run history show subcommand
+func newHistoryShowCmd() *ffcli.Command {
+	var (
+		cfg = &historyCfg{}
+		fs  = flag.NewFlagSet("show", flag.ExitOnError)
+	)
+
+	cfg.registerFlags(fs, false)
+
+	return &ffcli.Command{
+		Name:       "show",
+		ShortUsage: "history show [flags] <run-id>",
+		ShortHelp:  "Shows a single run from the history",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) != 1 {
+				return errMissingRunID
+			}
+
+			db, err := history.Open(cfg.db)
+			if err != nil {
+				return err
+			}
+
+			defer db.Close()
+
+			entry, err := db.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			if cfg.json {
+				return printJSON(entry)
+			}
+
+			history.DisplayEntry(os.Stdout, entry)
+			checkHistoryResults(entry)
+
+			return nil
+		},
+	}
+}
+
+// checkHistoryResults checks the results file of the history entry
+// is still in place, and internally consistent
+func checkHistoryResults(entry *history.Entry) {
+	if entry.Results == "" {
+		return
+	}
+
+	r, err := entry.LoadResults()
+	if err != nil {
+		fmt.Printf("\n⚠️ Unable to load the run results, %v\n", err)
+
+		return
+	}
+
+	if err := r.Validate(); err != nil {
+		fmt.Printf("\n⚠️ The run results (schema version %d) failed validation, %v\n", r.Version(), err)
+	}
+}
+
+// newHistoryTrendCmd creates the run history trend subcommand
+func newHistoryTrendCmd() *ffcli.Command {
+	var (
+		cfg = &historyCfg{}
+		fs  = flag.NewFlagSet("trend", flag.ExitOnError)
+	)
+
+	cfg.registerFlags(fs, true)
+
+	return &ffcli.Command{
+		Name:       "trend",
+		ShortUsage: "history trend [flags]",
+		ShortHelp:  "Shows the TPS trend of the runs in the history",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, _ []string) error {
+			entries, err := queryHistory(cfg)
+			if err != nil {
+				return err
+			}
+
+			trend := history.NewTrend(entries)
+
+			if cfg.json {
+				return printJSON(trend)
+			}
+
+			trend.Display(os.Stdout)
+
+			return nil
+		},
+	}
+}
+
+// queryHistory returns the history entries matching the flag filters
+func queryHistory(cfg *historyCfg) ([]*history.Entry, error) {
+	filter, err := cfg.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := history.Open(cfg.db)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	return db.Query(filter)
+}
+
+// printJSON writes the indented JSON representation of the value to stdout
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("unable to encode JSON, %w", err)
+	}
+
+	return nil
+}
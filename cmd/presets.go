@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/gnolang/supernova/internal/preset"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newPresetsCmd creates the workload presets subcommand
+func newPresetsCmd() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "presets",
+		ShortUsage: "presets <list> [flags]",
+		ShortHelp:  "Lists the bundled workload presets",
+		LongHelp: "Lists the bundled workload presets, selected with -preset. " +
+			"Explicitly set flags override the preset values",
+		FlagSet: flag.NewFlagSet("presets", flag.ExitOnError),
+		Subcommands: []*ffcli.Command{
+			newPresetsListCmd(),
+		},
+		Exec: func(_ context.Context, _ []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// newPresetsListCmd creates the workload presets list subcommand
+func newPresetsListCmd() *ffcli.Command {
+	var (
+		asJSON bool
+
+		fs = flag.NewFlagSet("list", flag.ExitOnError)
+	)
+
+	fs.BoolVar(&asJSON, "json", false, "flag indicating if the output is JSON, instead of a table")
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "presets list [flags]",
+		ShortHelp:  "Lists the presets, with their flag values",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if asJSON {
+				return printJSON(preset.List())
+			}
+
+			preset.DisplayPresets(os.Stdout, preset.List())
+
+			return nil
+		},
+	}
+}
+
+// applyPreset layers the preset values under the explicitly set flags, if any
+func applyPreset(fs *flag.FlagSet, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	p, err := preset.Get(name)
+	if err != nil {
+		return err
+	}
+
+	return p.Apply(fs)
+}
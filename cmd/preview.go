@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newPreviewCmd creates the transaction preview subcommand
+func newPreviewCmd() *ffcli.Command {
+	var (
+		cfg = &internal.PreviewConfig{}
+		fs  = flag.NewFlagSet("preview", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&cfg.Mode,
+		"mode",
+		runtime.RealmDeployment.String(),
+		fmt.Sprintf(
+			"the mode for the previewed transactions. Possible modes: [%s, %s, %s, %s, %s]",
+			runtime.RealmDeployment.String(), runtime.PackageDeployment.String(), runtime.RealmCall.String(),
+			runtime.Mint.String(), runtime.CustomScenario.String(),
+		),
+	)
+
+	fs.StringVar(
+		&cfg.Scenario,
+		"scenario",
+		"",
+		"the workload scenario file (YAML or JSON) the previewed transactions are constructed from, for SCENARIO",
+	)
+
+	fs.Uint64Var(
+		&cfg.Count,
+		"count",
+		3,
+		"the number of previewed transactions",
+	)
+
+	fs.StringVar(
+		&cfg.Mnemonic,
+		"mnemonic",
+		"",
+		"the mnemonic used to derive the sub-account (a random one is used if empty)",
+	)
+
+	fs.Uint64Var(
+		&cfg.SubAccountOffset,
+		"sub-account-offset",
+		1,
+		"the mnemonic derivation index of the previewed sub-account",
+	)
+
+	fs.Uint64Var(
+		&cfg.StorageDeposit,
+		"storage-deposit",
+		0,
+		"the storage deposit (in ugnot) attached to each package deployment",
+	)
+
+	fs.BoolVar(
+		&cfg.Sign,
+		"sign",
+		false,
+		"flag indicating if the previewed transactions are signed",
+	)
+
+	fs.StringVar(
+		&cfg.ChainID,
+		"chain-id",
+		"dev",
+		"the chain ID used for signing the previewed transactions",
+	)
+
+	fs.BoolVar(
+		&cfg.EstimateGas,
+		"estimate-gas",
+		false,
+		"flag indicating if the gas is estimated using the node simulation endpoint (requires the URL)",
+	)
+
+	fs.StringVar(
+		&cfg.URL,
+		"url",
+		"",
+		"the JSON-RPC URL of the cluster, used for gas estimation",
+	)
+
+	return &ffcli.Command{
+		Name:       "preview",
+		ShortUsage: "preview [flags]",
+		ShortHelp:  "Previews the transactions constructed for a mode",
+		LongHelp:   "Constructs sample transactions for the selected mode, without broadcasting them",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			return internal.Preview(cfg)
+		},
+	}
+}
@@ -5,12 +5,33 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
 
 	"github.com/gnolang/supernova/internal"
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/estimator"
+	"github.com/gnolang/supernova/internal/inclusion"
+	"github.com/gnolang/supernova/internal/logfile"
+	"github.com/gnolang/supernova/internal/preflight"
+	"github.com/gnolang/supernova/internal/preset"
 	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/internal/state"
+	"github.com/gnolang/supernova/internal/statelock"
+	"github.com/gnolang/supernova/internal/status"
 	"github.com/peterbourgon/ff/v3/ffcli"
 )
 
+const (
+	defaultSpoolDir = ".supernova/spool"
+	defaultHistory  = ".supernova/history.db"
+	defaultLocks    = ".supernova/locks.json"
+)
+
 func main() {
 	var (
 		cfg = &internal.Config{}
@@ -25,8 +46,41 @@ func main() {
 		LongHelp:   "Starts the stress testing suite against a Gno TM2 cluster",
 		FlagSet:    fs,
 		Exec: func(_ context.Context, _ []string) error {
+			if err := applyDurationRun(fs, cfg.Duration); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			if err := applyPreset(fs, cfg.Preset); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			// The preset can select a duration run as well
+			if err := applyDurationRun(fs, cfg.Duration); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			if err := applyShardIndexEnv(fs, cfg.ShardCount); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			applyTxIndexRequirement(fs, cfg)
+
 			return execMain(cfg)
 		},
+		Subcommands: []*ffcli.Command{
+			newUploadCmd(),
+			newPreviewCmd(),
+			newEstimateCmd(),
+			newCompareCmd(),
+			newVerifyCmd(),
+			newHistoryCmd(),
+			newGenesisCmd(),
+			newStateCmd(),
+			newPresetsCmd(),
+			newSweepCmd(),
+			newValidateNodeCmd(),
+			newBisectCmd(),
+		},
 	}
 
 	if err := cmd.ParseAndRun(context.Background(), os.Args[1:]); err != nil {
@@ -36,13 +90,63 @@ func main() {
 	}
 }
 
+// urlsFlag is a string flag that can be repeated,
+// with the values joined into a comma separated list
+type urlsFlag struct {
+	urls *string
+}
+
+func (u urlsFlag) String() string {
+	if u.urls == nil {
+		return ""
+	}
+
+	return *u.urls
+}
+
+func (u urlsFlag) Set(value string) error {
+	if *u.urls != "" {
+		value = *u.urls + "," + value
+	}
+
+	*u.urls = value
+
+	return nil
+}
+
 // registerFlags registers the main configuration flags
 func registerFlags(fs *flag.FlagSet, c *internal.Config) {
-	fs.StringVar(
-		&c.URL,
+	fs.Var(
+		urlsFlag{urls: &c.URL},
 		"url",
+		"the JSON-RPC URL of the cluster. Repeated (or comma separated) URLs spread the broadcasts over the nodes, "+
+			"and the first URL is used for the funding and the results collection",
+	)
+
+	fs.BoolVar(
+		&c.NoHumanize,
+		"no-humanize",
+		false,
+		"flag indicating if the console summary shows the raw values (no digit grouping or humanized durations), "+
+			"for scripts scraping the summary. The saved results always keep the raw values",
+	)
+
+	fs.StringVar(
+		&c.Timezone,
+		"timezone",
+		"UTC",
+		"the display timezone of the summary timestamps (UTC, Local, or an IANA name like Europe/Berlin). "+
+			"The saved results are always in UTC",
+	)
+
+	fs.StringVar(
+		&c.Preset,
+		"preset",
 		"",
-		"the JSON-RPC URL of the cluster",
+		fmt.Sprintf(
+			"the named workload preset the unset flags default to, if any. Possible presets: %v (see presets list)",
+			preset.Names(),
+		),
 	)
 
 	fs.StringVar(
@@ -52,6 +156,20 @@ func registerFlags(fs *flag.FlagSet, c *internal.Config) {
 		"the chain ID of the Gno blockchain",
 	)
 
+	fs.StringVar(
+		&c.ProductionChains,
+		"production-chains",
+		internal.DefaultProductionChains,
+		"the comma separated production chain IDs, which are refused without -i-know-this-is-production",
+	)
+
+	fs.BoolVar(
+		&c.ConfirmedProduction,
+		"i-know-this-is-production",
+		false,
+		"flag indicating if the run is allowed against a production chain (the production spend cap still applies)",
+	)
+
 	fs.StringVar(
 		&c.Mnemonic,
 		"mnemonic",
@@ -64,11 +182,37 @@ func registerFlags(fs *flag.FlagSet, c *internal.Config) {
 		"mode",
 		runtime.RealmDeployment.String(),
 		fmt.Sprintf(
-			"the mode for the stress test. Possible modes: [%s, %s, %s]",
+			"the mode for the stress test. Possible modes: [%s, %s, %s, %s, %s, %s]",
 			runtime.RealmDeployment.String(), runtime.PackageDeployment.String(), runtime.RealmCall.String(),
+			runtime.Mint.String(), runtime.CustomScenario.String(), runtime.ProbeSize.String(),
 		),
 	)
 
+	fs.StringVar(
+		&c.ProbeMsg,
+		"probe-msg",
+		runtime.PackageDeployment.String(),
+		fmt.Sprintf(
+			"the message type padded by the %s mode. Possible types: [%s, %s, %s]",
+			runtime.ProbeSize.String(),
+			runtime.RealmDeployment.String(), runtime.PackageDeployment.String(), runtime.RealmCall.String(),
+		),
+	)
+
+	fs.Uint64Var(
+		&c.ProbeResolution,
+		"probe-resolution",
+		1024,
+		"the binary search resolution of the transaction size probe, in bytes",
+	)
+
+	fs.Int64Var(
+		&c.ProbeGasWanted,
+		"probe-gas-wanted",
+		10_000_000,
+		"the gas wanted of each probed transaction, which needs to cover the transaction size gas cost",
+	)
+
 	fs.StringVar(
 		&c.Output,
 		"output",
@@ -76,6 +220,13 @@ func registerFlags(fs *flag.FlagSet, c *internal.Config) {
 		"the output path for the results JSON",
 	)
 
+	fs.StringVar(
+		&c.Baseline,
+		"baseline",
+		"",
+		"the baseline results JSON (or run manifest) the headline metrics are annotated against, if any",
+	)
+
 	fs.Uint64Var(
 		&c.SubAccounts,
 		"sub-accounts",
@@ -83,6 +234,34 @@ func registerFlags(fs *flag.FlagSet, c *internal.Config) {
 		"the number of sub-accounts that will send out transactions",
 	)
 
+	fs.Uint64Var(
+		&c.DistributorIndex,
+		"distributor-index",
+		0,
+		"the mnemonic derivation index of the distributor (funding) account",
+	)
+
+	fs.Uint64Var(
+		&c.SubAccountOffset,
+		"sub-account-offset",
+		1,
+		"the mnemonic derivation index of the first sub-account",
+	)
+
+	fs.Uint64Var(
+		&c.ShardIndex,
+		"shard-index",
+		0,
+		fmt.Sprintf("the index of the run shard, read from %s if not set", internal.ShardIndexEnv),
+	)
+
+	fs.Uint64Var(
+		&c.ShardCount,
+		"shard-count",
+		1,
+		"the number of shards the sub-accounts and transactions are split between, each run executing one shard",
+	)
+
 	fs.Uint64Var(
 		&c.Transactions,
 		"transactions",
@@ -96,15 +275,876 @@ func registerFlags(fs *flag.FlagSet, c *internal.Config) {
 		20,
 		"the batch size of JSON-RPC transactions",
 	)
+
+	fs.Uint64Var(
+		&c.PrewarmConnections,
+		"prewarm-connections",
+		0,
+		"the number of connections pre-warmed before the measured dispatch (0 disables pre-warming)",
+	)
+
+	fs.Uint64Var(
+		&c.PrimingCalls,
+		"priming-calls",
+		5,
+		"the number of unmeasured calls priming the target realm before the measured dispatch, for REALM_CALL (0 disables priming)",
+	)
+
+	fs.Int64Var(
+		&c.GasWantedCall,
+		"gas-wanted-call",
+		runtime.DefaultCallGasWanted,
+		"the gas wanted of each realm call transaction, for REALM_CALL and SCENARIO",
+	)
+
+	fs.Int64Var(
+		&c.GasWantedDeploy,
+		"gas-wanted-deploy",
+		runtime.DefaultRealmGasWanted,
+		"the gas wanted of each realm deployment transaction, for REALM_DEPLOYMENT and the REALM_CALL / MINT predeployment",
+	)
+
+	fs.Int64Var(
+		&c.GasWantedPackage,
+		"gas-wanted-package",
+		runtime.DefaultPackageGasWanted,
+		"the gas wanted of each package deployment transaction, for PACKAGE_DEPLOYMENT",
+	)
+
+	fs.Int64Var(
+		&c.GasWantedMint,
+		"gas-wanted-mint",
+		runtime.DefaultMintGasWanted,
+		"the gas wanted of each token mint transaction, for MINT",
+	)
+
+	fs.StringVar(
+		&c.Fees,
+		"fees",
+		"",
+		"the JSON file of gas wanted and gas fee overrides per message type (send, call, add_package, run)",
+	)
+
+	fs.BoolVar(
+		&c.EstimateGas,
+		"estimate-gas",
+		false,
+		"flag indicating if the run gas wanted is estimated by simulating a run transaction on the node, "+
+			"instead of using the mode default",
+	)
+
+	fs.Float64Var(
+		&c.GasMargin,
+		"gas-margin",
+		estimator.DefaultMargin,
+		"the safety margin of the estimated gas wanted, as a fraction of the simulated gas used",
+	)
+
+	fs.StringVar(
+		&c.GasPrice,
+		"gas-price",
+		"",
+		"the gas price of the run transactions (e.g. 1ugnot/1000gas), the fixed gas fee is used if unset",
+	)
+
+	fs.StringVar(
+		&c.Signers,
+		"signers",
+		"",
+		"the JSON file of account signer backends (hd, keybase, http) by derivation index range",
+	)
+
+	fs.StringVar(
+		&c.MaxSpend,
+		"max-spend",
+		"",
+		"the cap on the cumulative distributor spend (funding transfers plus fees) of the invocation, "+
+			"in ugnot if no denomination is specified. Spends past the cap are stopped (uncapped if empty)",
+	)
+
+	fs.Float64Var(
+		&c.CompletionThreshold,
+		"completion-threshold",
+		1.0,
+		"the ratio of broadcast transactions that need to be committed before the collection finalizes",
+	)
+
+	fs.DurationVar(
+		&c.CompletionGrace,
+		"completion-grace",
+		30*time.Second,
+		"the period without newly committed transactions before the collection finalizes",
+	)
+
+	fs.Float64Var(
+		&c.StallFactor,
+		"stall-factor",
+		5,
+		"the multiple of the recent average block interval without a new block, "+
+			"reported as a possible chain halt (0 disables the detection)",
+	)
+
+	fs.Uint64Var(
+		&c.ProofSamples,
+		"proof-samples",
+		0,
+		"the number of committed transactions whose Merkle inclusion proofs are verified "+
+			"against the validator signed block headers (0 disables the verification)",
+	)
+
+	fs.StringVar(
+		&c.ProofStrategy,
+		"proof-strategy",
+		string(inclusion.StrategyRandom),
+		fmt.Sprintf(
+			"the committed transaction sampling strategy for the inclusion proofs. Possible strategies: %v",
+			inclusion.Strategies,
+		),
+	)
+
+	fs.BoolVar(
+		&c.StrictIntegrations,
+		"strict-integrations",
+		false,
+		"fail the run if any optional integration (history, upload, manifest, node metrics, backfill...) fails, "+
+			"instead of recording it in the degraded results section",
+	)
+
+	fs.Uint64Var(
+		&c.BackfillSample,
+		"backfill-sample",
+		0,
+		"the number of sampled transactions whose results are queried from the tx endpoint, "+
+			"for the blocks without block results (0 queries all of them)",
+	)
+
+	fs.Float64Var(
+		&c.BackfillRate,
+		"backfill-rate",
+		20,
+		"the maximum number of tx endpoint queries per second of the backfill (0 disables the backfill)",
+	)
+
+	fs.StringVar(
+		&c.BackfillJournal,
+		"backfill-journal",
+		"",
+		"the journal file of the backfilled transaction results, "+
+			"so an interrupted backfill resumes without querying them again",
+	)
+
+	fs.BoolVar(
+		&c.PipelinedFunding,
+		"pipelined-funding",
+		false,
+		"broadcast funding transactions without waiting for the previous ones to be committed",
+	)
+
+	fs.BoolVar(
+		&c.Sweep,
+		"sweep",
+		false,
+		"flag indicating if the leftover sub-account funds are returned to the distributor after the run",
+	)
+
+	fs.StringVar(
+		&c.ReuseResults,
+		"reuse-results",
+		"",
+		"the previous run results (or run manifest) seeding the sub-account states of the distribution, "+
+			"instead of fetching each sub-account",
+	)
+
+	fs.Uint64Var(
+		&c.ReuseSamples,
+		"reuse-samples",
+		10,
+		"the number of randomly sampled sub-accounts verified against the chain before reusing the previous run results",
+	)
+
+	fs.Uint64Var(
+		&c.FundingBatch,
+		"funding-batch-size",
+		distributor.DefaultFundingBatchSize,
+		"the maximum number of sub-account transfers in a single funding transaction (1 funds each account separately)",
+	)
+
+	fs.BoolVar(
+		&c.AssumeGenesisFunded,
+		"assume-genesis-funded",
+		false,
+		"flag indicating if the sub-accounts are funded in genesis (see genesis-balances), skipping the distribution",
+	)
+
+	fs.StringVar(
+		&c.FundingPlan,
+		"funding-plan",
+		"",
+		"the CSV file of (address, amount) funding transfers, executed instead of the sub-account top-ups",
+	)
+
+	fs.Uint64Var(
+		&c.StorageDeposit,
+		"storage-deposit",
+		0,
+		"the storage deposit paid by each package deployment transaction",
+	)
+
+	fs.StringVar(
+		&c.StorageDepositDenom,
+		"storage-deposit-denom",
+		common.Denomination,
+		"the denomination of the storage deposit, funded alongside the gas if different",
+	)
+
+	fs.Uint64Var(
+		&c.Seed,
+		"seed",
+		0,
+		"the seed for the transaction payload content, like the deployed package paths (0 uses the current time)",
+	)
+
+	fs.BoolVar(
+		&c.Reproducible,
+		"reproducible",
+		false,
+		"flag indicating if the constructed transactions need to be identical across runs with the same inputs (requires -seed)",
+	)
+
+	fs.BoolVar(
+		&c.VerifySignatures,
+		"verify-signatures",
+		false,
+		"flag indicating if the run transaction signatures are verified locally before broadcast "+
+			"(the funding transaction signatures are always verified)",
+	)
+
+	fs.StringVar(
+		&c.ResultsURL,
+		"results-url",
+		"",
+		"the URL the results are uploaded to at the end of the run, if any",
+	)
+
+	fs.StringVar(
+		&c.SpoolDir,
+		"spool-dir",
+		defaultSpoolDir,
+		"the local queue directory for results uploads",
+	)
+
+	fs.BoolVar(
+		&c.History,
+		"history",
+		false,
+		"flag indicating if the run summary is appended to the local run history (best-effort)",
+	)
+
+	fs.StringVar(
+		&c.HistoryDB,
+		"history-db",
+		defaultHistory,
+		"the local run history database",
+	)
+
+	fs.StringVar(
+		&c.Label,
+		"label",
+		"",
+		"the free-form run label, recorded in the results and run history",
+	)
+
+	fs.StringVar(
+		&c.StatePassword,
+		"state-password",
+		"",
+		fmt.Sprintf(
+			"the password for encrypting the state files, like the upload spool (falls back to $%s)",
+			state.PasswordEnv,
+		),
+	)
+
+	fs.StringVar(
+		&c.PendingTxPolicy,
+		"pending-tx-policy",
+		string(preflight.PendingWait),
+		fmt.Sprintf(
+			"the resolution policy for sub-accounts with pending mempool transactions. Possible policies: [%s, %s, %s]",
+			preflight.PendingWait, preflight.PendingBump, preflight.PendingExclude,
+		),
+	)
+
+	fs.DurationVar(
+		&c.PendingTxWait,
+		"pending-tx-wait",
+		time.Minute,
+		"the maximum wait for pending mempool transactions to drain, when using the wait policy",
+	)
+
+	fs.StringVar(
+		&c.ConstructionErrorPolicy,
+		"construction-error-policy",
+		string(runtime.ConstructionAbort),
+		fmt.Sprintf(
+			"the handling policy for transactions that fail to be constructed. Possible policies: [%s, %s, %s]",
+			runtime.ConstructionAbort, runtime.ConstructionSkip, runtime.ConstructionSubstitute,
+		),
+	)
+
+	fs.StringVar(
+		&c.CallArgument,
+		"call-arg",
+		runtime.DefaultCallArgument,
+		"the call argument template for REALM_CALL, with the {{.Index}} (transaction index) "+
+			"and {{.Corpus}} (argument corpus value) placeholders",
+	)
+
+	fs.StringVar(
+		&c.ArgCorpus,
+		"arg-corpus",
+		"",
+		"the argument corpus file the {{.Corpus}} placeholder samples from, one candidate value per line, if any",
+	)
+
+	fs.StringVar(
+		&c.CorpusMode,
+		"corpus-mode",
+		string(runtime.CorpusWithReplacement),
+		fmt.Sprintf(
+			"the argument corpus sampling mode. Possible modes: [%s, %s]",
+			runtime.CorpusWithReplacement, runtime.CorpusWithoutReplacement,
+		),
+	)
+
+	fs.StringVar(
+		&c.MintRealm,
+		"mint-realm",
+		"",
+		"the existing realm with a Mint(id, metadata) method targeted by MINT, instead of deploying a fresh mint realm",
+	)
+
+	fs.Uint64Var(
+		&c.MintMetadataSize,
+		"mint-metadata-size",
+		runtime.DefaultMintMetadataSize,
+		"the metadata size (in bytes) of each minted token, for MINT",
+	)
+
+	fs.StringVar(
+		&c.Scenario,
+		"scenario",
+		"",
+		"the workload scenario file (YAML or JSON) of weighted realm call templates, for SCENARIO",
+	)
+
+	fs.DurationVar(
+		&c.ReportInterval,
+		"report-interval",
+		0,
+		"the interval for writing intermediate results segments in the run directory next to the output file (0 disables segments)",
+	)
+
+	fs.DurationVar(
+		&c.StatusInterval,
+		"status-interval",
+		status.DefaultInterval,
+		"the interval for rewriting the run status file (status.json) in the run directory next to the output file (0 disables the status file)",
+	)
+
+	fs.BoolVar(
+		&c.TraceHTTP,
+		"trace-http",
+		false,
+		"flag indicating if the transaction broadcast requests should be traced (DNS, connect, TLS, TTFB timing)",
+	)
+
+	fs.BoolVar(
+		&c.GroupBatches,
+		"group-batches",
+		false,
+		"flag indicating if batches are grouped by transaction message type, interleaved by each type's workload share",
+	)
+
+	fs.StringVar(
+		&c.DispatchOrder,
+		"dispatch-order",
+		string(batcher.DispatchInterleaved),
+		fmt.Sprintf(
+			"the order the account transactions are dispatched in. Possible orders: [%s, %s]",
+			batcher.DispatchInterleaved, batcher.DispatchSequential,
+		),
+	)
+
+	fs.BoolVar(
+		&c.ForceBatch,
+		"force-batch",
+		false,
+		"flag indicating if batch requests are always used, even if the node rejects them (no single broadcast fallback)",
+	)
+
+	fs.DurationVar(
+		&c.MempoolSampleInterval,
+		"mempool-sample-interval",
+		0,
+		"the interval for sampling the mempool, to attribute the commit latency (0 disables attribution)",
+	)
+
+	fs.StringVar(
+		&c.NodeMetricsURL,
+		"node-metrics-url",
+		"",
+		"the Prometheus metrics endpoint of the node, scraped throughout the run (disabled if empty)",
+	)
+
+	fs.StringVar(
+		&c.NodeMetrics,
+		"node-metrics",
+		"process_cpu_seconds_total,process_resident_memory_bytes,tendermint_mempool_size,tendermint_consensus_rounds",
+		"the comma separated node metrics that are scraped",
+	)
+
+	fs.DurationVar(
+		&c.NodeMetricsInterval,
+		"node-metrics-interval",
+		time.Second,
+		"the interval for scraping the node metrics",
+	)
+
+	fs.DurationVar(
+		&c.ClientSampleInterval,
+		"client-sample-interval",
+		time.Second,
+		"the interval for sampling the supernova process, to detect client-side bottlenecks (0 disables sampling)",
+	)
+
+	fs.Float64Var(
+		&c.SendRate,
+		"send-rate",
+		0,
+		"the fixed send rate (tx/s) of the broadcasts, if any (0 sends as fast as possible)",
+	)
+
+	fs.Float64Var(
+		&c.SendRate,
+		"tps",
+		0,
+		"the target send rate (tx/s) of the broadcasts, an alias of -send-rate",
+	)
+
+	fs.DurationVar(
+		&c.Duration,
+		"duration",
+		0,
+		"the broadcast duration at the send rate, instead of a fixed number of transactions (0 disables the duration)",
+	)
+
+	fs.DurationVar(
+		&c.RecycleIdle,
+		"recycle-idle",
+		0,
+		"the period without commits after which a duration run sub-account is recycled from its on-chain sequence (0 disables recycling)",
+	)
+
+	fs.DurationVar(
+		&c.ProgressInterval,
+		"progress-interval",
+		0,
+		"the interval for printing the live broadcast progress (0 disables the live progress)",
+	)
+
+	fs.StringVar(
+		&c.MetricsAddr,
+		"metrics-addr",
+		"",
+		"the listen address of the live Prometheus /metrics endpoint, if any (e.g. localhost:9090)",
+	)
+
+	fs.DurationVar(
+		&c.LatencySLO,
+		"latency-slo",
+		0,
+		"the p95 commit latency bound the send rate is continuously adapted to, if any",
+	)
+
+	fs.DurationVar(
+		&c.SLOWindow,
+		"slo-window",
+		10*time.Second,
+		"the rolling commit latency window, and interval between send rate adjustments",
+	)
+
+	fs.Float64Var(
+		&c.SLOInitialRate,
+		"slo-initial-rate",
+		50,
+		"the starting send rate (tx/s) of the latency SLO controller",
+	)
+
+	fs.Float64Var(
+		&c.SLOIncreaseStep,
+		"slo-increase-step",
+		10,
+		"the send rate increase (tx/s) when the p95 commit latency is comfortably under the SLO",
+	)
+
+	fs.Float64Var(
+		&c.SLOBackoffFactor,
+		"slo-backoff-factor",
+		0.75,
+		"the send rate multiplier when the p95 commit latency violates the SLO",
+	)
+
+	fs.Float64Var(
+		&c.SLOHeadroom,
+		"slo-headroom",
+		0.2,
+		"the share of the SLO the p95 commit latency needs to be under, to increase the send rate",
+	)
+
+	fs.Float64Var(
+		&c.ReadRatio,
+		"read-ratio",
+		0,
+		"the number of read queries issued per broadcast transaction, concurrently with the broadcasts (0 disables reads)",
+	)
+
+	fs.StringVar(
+		&c.ReadQueries,
+		"read-queries",
+		"",
+		"the query set file the reads cycle through, one \"<path> <data>\" query per line (defaults to the run target)",
+	)
+
+	fs.BoolVar(
+		&c.ReadsCountAgainstTPS,
+		"reads-count-against-tps",
+		false,
+		"flag indicating if the reads share the send rate budget of the latency SLO controller",
+	)
+
+	fs.StringVar(
+		&c.ExcludeAccounts,
+		"exclude-accounts",
+		"",
+		"the comma separated sub-account indices or addresses that are never funded or used",
+	)
+
+	fs.StringVar(
+		&c.OnlyAccounts,
+		"only-accounts",
+		"",
+		"the comma separated sub-account indices or addresses that can be funded or used (all if empty)",
+	)
+
+	fs.StringVar(
+		&c.LockRegistry,
+		"lock-registry",
+		defaultLocks,
+		"the registry of the account index ranges in use by live runs (disabled if empty, see state unlock)",
+	)
+
+	fs.DurationVar(
+		&c.LockTTL,
+		"lock-ttl",
+		10*time.Minute,
+		"the expiry of the account locks of runs that stopped refreshing them (crashed runs)",
+	)
+
+	fs.BoolVar(
+		&c.ForceRange,
+		"force-range",
+		false,
+		"flag indicating if the run starts even if its account index ranges overlap a live run",
+	)
+
+	fs.DurationVar(
+		&c.StateLockTimeout,
+		"state-lock-timeout",
+		statelock.DefaultTimeout,
+		"the maximum wait for the shared state directory lock, held while the history and lock registry are updated",
+	)
+
+	fs.StringVar(
+		&c.LogFile,
+		"log-file",
+		"",
+		"the rolling log file the console output is copied to, relative paths are placed in the run directory (disabled if empty)",
+	)
+
+	fs.Uint64Var(
+		&c.LogMaxSize,
+		"log-max-size",
+		logfile.DefaultMaxSize,
+		"the log file size (in bytes) above which it is rotated (0 disables size rotation)",
+	)
+
+	fs.DurationVar(
+		&c.LogMaxAge,
+		"log-max-age",
+		0,
+		"the log file age above which it is rotated (0 disables age rotation)",
+	)
+
+	fs.Uint64Var(
+		&c.LogKeep,
+		"log-keep",
+		logfile.DefaultKeep,
+		"the number of rotated log files kept",
+	)
+
+	fs.StringVar(
+		&c.BroadcastURLs,
+		"broadcast-urls",
+		"",
+		"the comma separated JSON-RPC URLs the transactions are broadcast to (the cluster URL if empty)",
+	)
+
+	fs.StringVar(
+		&c.PrepareDump,
+		"prepare",
+		"",
+		"the path the signed transactions are dumped to, without broadcasting them (for a later -replay)",
+	)
+
+	fs.StringVar(
+		&c.ReplayDump,
+		"replay",
+		"",
+		"the path of the prepared transactions dump to broadcast, instead of constructing new transactions",
+	)
+
+	fs.BoolVar(
+		&c.ReSign,
+		"re-sign",
+		false,
+		"flag indicating if the replayed transactions of drifted accounts are re-signed with fresh nonces (requires the mnemonic)",
+	)
+
+	fs.BoolVar(
+		&c.AllowPartialReplay,
+		"allow-partial-replay",
+		false,
+		"flag indicating if only the transactions of the accounts that did not drift since the prepare are replayed",
+	)
+
+	fs.StringVar(
+		&c.EndpointAffinity,
+		"endpoint-affinity",
+		string(batcher.AffinityRoundRobin),
+		fmt.Sprintf(
+			"the strategy for assigning broadcasts to the broadcast URLs. Possible strategies: [%s, %s]",
+			batcher.AffinityRoundRobin, batcher.AffinityAccount,
+		),
+	)
+
+	fs.BoolVar(
+		&c.Embedded,
+		"embedded",
+		false,
+		"flag indicating if the run targets an in-process gnoland node, funding the distributor in genesis, "+
+			"instead of the URL (requires a build with -tags embedded)",
+	)
+}
+
+// applyShardIndexEnv sets the shard index of sharded runs from the environment
+// (the indexed Job completion index), if it was not explicitly set
+func applyShardIndexEnv(fs *flag.FlagSet, shardCount uint64) error {
+	index, ok := os.LookupEnv(internal.ShardIndexEnv)
+	if !ok || shardCount < 2 {
+		return nil
+	}
+
+	explicit := false
+
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "shard-index" {
+			explicit = true
+		}
+	})
+
+	if explicit {
+		return nil
+	}
+
+	if err := fs.Set("shard-index", index); err != nil {
+		return fmt.Errorf("unable to read the shard index from %s, %w", internal.ShardIndexEnv, err)
+	}
+
+	return nil
+}
+
+// applyDurationRun clears the default number of transactions of duration runs,
+// which generate the transactions until the deadline, if it was not explicitly set.
+// The cleared count is not overridden by the preset. It is applied again after the preset,
+// so the preset duration runs are cleared as well
+func applyDurationRun(fs *flag.FlagSet, duration time.Duration) error {
+	if duration == 0 {
+		return nil
+	}
+
+	explicit := false
+
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "transactions" {
+			explicit = true
+		}
+	})
+
+	if explicit {
+		return nil
+	}
+
+	return fs.Set("transactions", "0")
+}
+
+// txIndexFlags are the flags of the features that depend on the node tx indexer
+var txIndexFlags = map[string]struct{}{
+	"backfill-sample":  {},
+	"backfill-rate":    {},
+	"backfill-journal": {},
+}
+
+// applyTxIndexRequirement requires the node tx indexer,
+// if any of its dependent features was explicitly requested
+func applyTxIndexRequirement(fs *flag.FlagSet, cfg *internal.Config) {
+	fs.Visit(func(f *flag.Flag) {
+		if _, ok := txIndexFlags[f.Name]; ok {
+			cfg.RequireTxIndex = true
+		}
+	})
 }
 
 // execMain starts the stress test workflow (runs the pipeline)
 func execMain(cfg *internal.Config) error {
+	cfg.StatePassword = state.Password(cfg.StatePassword)
+
 	// Validate the configuration
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration, %w", err)
 	}
 
-	// Create and run the pipeline
-	return internal.NewPipeline(cfg).Execute()
+	pipeline := internal.NewPipeline(cfg)
+
+	// Copy the console output to the rolling log file, if any
+	logs, err := startLog(pipeline.LogPath(), cfg)
+	if err != nil {
+		return err
+	}
+
+	defer logs.close()
+
+	// Make sure the background components are stopped, and any
+	// validation artifacts are cleaned up if the run is aborted
+	var (
+		interrupt = make(chan os.Signal, 1)
+		done      = make(chan struct{})
+	)
+
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	defer func() {
+		signal.Stop(interrupt)
+		close(done)
+	}()
+
+	go func() {
+		select {
+		case <-interrupt:
+		case <-done:
+			return
+		}
+
+		_ = pipeline.Shutdown()
+
+		// The deferred lock releases are skipped by the exit
+		statelock.ReleaseAll()
+
+		cfg.Cleanup()
+		logs.close()
+		os.Exit(1)
+	}()
+
+	// Run the pipeline
+	if err := pipeline.Execute(); err != nil {
+		logs.fail(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// runLog is the rolling log file the console output is copied to
+type runLog struct {
+	w       *logfile.Writer
+	release func()
+	err     error // the run error, written out once the console output is drained
+}
+
+// startLog starts copying the console output to the rolling log file
+// at the given path. Runs without a log file have a nil run log
+func startLog(path string, cfg *internal.Config) (*runLog, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	w, err := logfile.Open(path, logfile.Rotation{
+		MaxSize: int64(cfg.LogMaxSize),
+		MaxAge:  cfg.LogMaxAge,
+		Keep:    int(cfg.LogKeep),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open log file, %w", err)
+	}
+
+	release, err := logfile.Capture(w)
+	if err != nil {
+		_ = w.Close()
+
+		return nil, fmt.Errorf("unable to capture console output, %w", err)
+	}
+
+	fmt.Printf("Logging to %s\n", w.Path())
+
+	return &runLog{
+		w:       w,
+		release: release,
+	}, nil
+}
+
+// fail records the run error, which main only prints to the standard error.
+// It is written out to the log once the console output is drained
+func (l *runLog) fail(err error) {
+	if l == nil {
+		return
+	}
+
+	l.err = err
+}
+
+// close stops copying the console output, and closes the log.
+// The run error, or the panic of a panicking run, is written out beforehand
+func (l *runLog) close() {
+	if l == nil {
+		return
+	}
+
+	r := recover()
+
+	l.release()
+
+	switch {
+	case r != nil:
+		l.w.LogPanic(r, debug.Stack())
+	case l.err != nil:
+		_, _ = fmt.Fprintf(l.w, "❌ %+v\n", l.err)
+	}
+
+	if err := l.w.Close(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "⚠️ Unable to close the log file, %v\n", err)
+	}
+
+	if r != nil {
+		panic(r)
+	}
 }
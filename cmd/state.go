@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gnolang/supernova/internal/locks"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+var errConflictingUnlock = errors.New("only one of -run-id and -all can be specified")
+
+// newStateCmd creates the local state subcommand
+func newStateCmd() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "state",
+		ShortUsage: "state <locks|unlock> [flags]",
+		ShortHelp:  "Manages the local run state",
+		LongHelp:   "Manages the local run state, such as the account index range locks of live runs",
+		FlagSet:    flag.NewFlagSet("state", flag.ExitOnError),
+		Subcommands: []*ffcli.Command{
+			newStateLocksCmd(),
+			newStateUnlockCmd(),
+		},
+		Exec: func(_ context.Context, _ []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// newStateLocksCmd creates the account lock list subcommand
+func newStateLocksCmd() *ffcli.Command {
+	var (
+		registry string
+		asJSON   bool
+
+		fs = flag.NewFlagSet("locks", flag.ExitOnError)
+	)
+
+	fs.StringVar(&registry, "lock-registry", defaultLocks, "the account lock registry")
+	fs.BoolVar(&asJSON, "json", false, "flag indicating if the output is JSON, instead of a table")
+
+	return &ffcli.Command{
+		Name:       "locks",
+		ShortUsage: "state locks [flags]",
+		ShortHelp:  "Lists the account index range locks",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, _ []string) error {
+			registered, err := locks.List(registry)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				return printJSON(registered)
+			}
+
+			locks.DisplayLocks(os.Stdout, registered)
+
+			return nil
+		},
+	}
+}
+
+// newStateUnlockCmd creates the account unlock subcommand
+func newStateUnlockCmd() *ffcli.Command {
+	var (
+		registry string
+		runID    string
+		all      bool
+
+		fs = flag.NewFlagSet("unlock", flag.ExitOnError)
+	)
+
+	fs.StringVar(&registry, "lock-registry", defaultLocks, "the account lock registry")
+	fs.StringVar(&runID, "run-id", "", "the run whose lock is cleared, even if it is live")
+	fs.BoolVar(&all, "all", false, "flag indicating if all locks are cleared, even the live ones")
+
+	return &ffcli.Command{
+		Name:       "unlock",
+		ShortUsage: "state unlock [flags]",
+		ShortHelp:  "Clears account index range locks",
+		LongHelp: "Clears the expired account locks of crashed runs. " +
+			"Live locks are only cleared with -run-id or -all",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if runID != "" && all {
+				return errConflictingUnlock
+			}
+
+			now := time.Now()
+
+			removed, err := locks.Unlock(registry, func(lock *locks.Lock) bool {
+				switch {
+				case all:
+					return true
+				case runID != "":
+					return lock.RunID == runID
+				default:
+					return lock.Expired(now)
+				}
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, lock := range removed {
+				fmt.Printf("Cleared the lock of run %s\n", lock.RunID)
+			}
+
+			fmt.Printf("✅ Cleared %d account locks\n", len(removed))
+
+			return nil
+		},
+	}
+}
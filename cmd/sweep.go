@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newSweepCmd creates the sub-account sweep subcommand
+func newSweepCmd() *ffcli.Command {
+	var (
+		cfg = &internal.SweepConfig{}
+		fs  = flag.NewFlagSet("sweep", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&cfg.URL,
+		"url",
+		"",
+		"the JSON-RPC URL of the cluster",
+	)
+
+	fs.StringVar(
+		&cfg.ChainID,
+		"chain-id",
+		"dev",
+		"the chain ID of the Gno blockchain",
+	)
+
+	fs.StringVar(
+		&cfg.Mnemonic,
+		"mnemonic",
+		"",
+		"the mnemonic used to derive the distributor and sub-accounts",
+	)
+
+	fs.Uint64Var(
+		&cfg.SubAccounts,
+		"sub-accounts",
+		10,
+		"the number of swept sub-accounts",
+	)
+
+	fs.Uint64Var(
+		&cfg.SubAccountOffset,
+		"sub-account-offset",
+		1,
+		"the mnemonic derivation index of the first sub-account",
+	)
+
+	fs.Uint64Var(
+		&cfg.DistributorIndex,
+		"distributor-index",
+		0,
+		"the mnemonic derivation index of the distributor (funding) account",
+	)
+
+	fs.StringVar(
+		&cfg.ExcludeAccounts,
+		"exclude-accounts",
+		"",
+		"the comma separated sub-account indices or addresses that are never swept",
+	)
+
+	fs.StringVar(
+		&cfg.OnlyAccounts,
+		"only-accounts",
+		"",
+		"the comma separated sub-account indices or addresses that can be swept (all if empty)",
+	)
+
+	return &ffcli.Command{
+		Name:       "sweep",
+		ShortUsage: "sweep -url <url> -mnemonic <mnemonic> [flags]",
+		ShortHelp:  "Returns the leftover sub-account funds to the distributor",
+		LongHelp: "Sweeps the balance of each sub-account above the transfer fee back to the distributor, " +
+			"so the funds left over by previous runs can fund new runs",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			return internal.Sweep(cfg)
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal/state"
+	"github.com/gnolang/supernova/internal/upload"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+type uploadCfg struct {
+	spoolDir      string
+	statePassword string
+}
+
+// newUploadCmd creates the results upload subcommand
+func newUploadCmd() *ffcli.Command {
+	var (
+		cfg = &uploadCfg{}
+		fs  = flag.NewFlagSet("upload", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&cfg.spoolDir,
+		"spool-dir",
+		defaultSpoolDir,
+		"the local queue directory for results uploads",
+	)
+
+	fs.StringVar(
+		&cfg.statePassword,
+		"state-password",
+		"",
+		fmt.Sprintf("the password for decrypting the spooled uploads (falls back to $%s)", state.PasswordEnv),
+	)
+
+	return &ffcli.Command{
+		Name:       "upload",
+		ShortUsage: "upload [flags]",
+		ShortHelp:  "Uploads previously failed results uploads",
+		LongHelp:   "Flushes the results upload queue, retrying any previously failed uploads",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, _ []string) error {
+			return execUpload(cfg)
+		},
+	}
+}
+
+// execUpload flushes the results upload queue
+func execUpload(cfg *uploadCfg) error {
+	fmt.Printf("\n📤 Flushing Results Uploads 📤\n\n")
+
+	spool := upload.NewSpool(
+		cfg.spoolDir,
+		upload.WithPassword(state.Password(cfg.statePassword)),
+	)
+
+	uploaded, err := upload.NewUploader(spool).Flush()
+	if err != nil {
+		return fmt.Errorf("unable to flush uploads, %w", err)
+	}
+
+	fmt.Printf("✅ Successfully uploaded %d runs\n", uploaded)
+
+	return nil
+}
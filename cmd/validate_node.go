@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// newValidateNodeCmd creates the node validation subcommand
+func newValidateNodeCmd() *ffcli.Command {
+	var (
+		cfg = &internal.NodeValidationConfig{}
+		fs  = flag.NewFlagSet("validate-node", flag.ExitOnError)
+	)
+
+	fs.StringVar(
+		&cfg.URL,
+		"url",
+		"",
+		"the JSON-RPC URL of the validated node",
+	)
+
+	fs.StringVar(
+		&cfg.ChainID,
+		"chain-id",
+		"dev",
+		"the chain ID of the Gno blockchain",
+	)
+
+	fs.StringVar(
+		&cfg.Mnemonic,
+		"mnemonic",
+		"",
+		"the mnemonic of the account broadcasting the no-op probe transaction, if any",
+	)
+
+	fs.Uint64Var(
+		&cfg.KeyIndex,
+		"key-index",
+		0,
+		"the mnemonic derivation index of the probe account",
+	)
+
+	fs.StringVar(
+		&cfg.Output,
+		"output",
+		"",
+		"the output path for the capability matrix JSON, if any",
+	)
+
+	return &ffcli.Command{
+		Name:       "validate-node",
+		ShortUsage: "validate-node -url <url> [flags]",
+		ShortHelp:  "Checks if the node is ready for runs",
+		LongHelp: "Exercises each dependency the runs have on the node (status, account query, simulation, " +
+			"broadcast, block and block results fetch, batch RPC, WebSocket), and prints a pass / fail matrix " +
+			"with the measured latencies and detected node capabilities",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration, %w", err)
+			}
+
+			return internal.ValidateNode(cfg)
+		},
+	}
+}
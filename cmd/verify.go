@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/gnolang/supernova/internal"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+var errMissingVerifyResults = errors.New("the results of the reproducible run are required")
+
+// newVerifyCmd creates the reproducibility verification subcommand
+func newVerifyCmd() *ffcli.Command {
+	var (
+		cfg     = &internal.Config{}
+		results string
+
+		fs = flag.NewFlagSet("verify-reproducibility", flag.ExitOnError)
+	)
+
+	// The transactions are re-constructed from the same configuration
+	registerFlags(fs, cfg)
+
+	fs.StringVar(
+		&results,
+		"results",
+		"",
+		"the results JSON (or run manifest) of the reproducible run",
+	)
+
+	return &ffcli.Command{
+		Name:       "verify-reproducibility",
+		ShortUsage: "verify-reproducibility -results <path> [flags]",
+		ShortHelp:  "Verifies a reproducible run constructs the same transactions",
+		LongHelp: "Re-constructs the transactions from the same configuration, without broadcasting them, " +
+			"and verifies the transaction set hash matches the one recorded in the run results",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			if results == "" {
+				return errMissingVerifyResults
+			}
+
+			return internal.VerifyReproducibility(cfg, results)
+		},
+	}
+}
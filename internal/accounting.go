@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+)
+
+// newAccountResults predicts the on-chain state of each run sub-account once
+// the run is over, from its state after the distribution, and the fees,
+// transfers and deposits of its committed run transactions
+func newAccountResults(
+	accounts []*gnoland.GnoAccount,
+	txs []*std.Tx,
+	committed map[string]time.Time,
+) ([]*collector.AccountResult, error) {
+	var (
+		results = make([]*collector.AccountResult, 0, len(accounts))
+		lookup  = make(map[string]*collector.AccountResult, len(accounts))
+		spent   = make(map[string]std.Coins, len(accounts))
+	)
+
+	for _, account := range accounts {
+		result := &collector.AccountResult{
+			Address:       account.GetAddress().String(),
+			AccountNumber: account.AccountNumber,
+			StartSequence: account.Sequence,
+			StartBalance:  account.Coins.String(),
+		}
+
+		results = append(results, result)
+		lookup[result.Address] = result
+	}
+
+	for _, tx := range txs {
+		signers := tx.GetSigners()
+		if len(signers) == 0 {
+			continue
+		}
+
+		result, ok := lookup[signers[0].String()]
+		if !ok {
+			continue
+		}
+
+		txBin, err := amino.Marshal(tx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal transaction, %w", err)
+		}
+
+		if _, ok := committed[string(types.Tx(txBin).Hash())]; !ok {
+			result.Lost++
+
+			continue
+		}
+
+		result.Committed++
+		spent[result.Address] = spent[result.Address].Add(txSpend(tx))
+	}
+
+	for index, result := range results {
+		var (
+			start = accounts[index].Coins
+			spend = spent[result.Address]
+		)
+
+		result.Spent = spend.String()
+		result.Sequence = result.StartSequence + uint64(result.Committed)
+
+		balance, ok := subtractCoins(start, spend)
+		if !ok || result.Lost > 0 {
+			continue
+		}
+
+		result.Predicted = true
+		result.Balance = balance.String()
+	}
+
+	return results, nil
+}
+
+// txSpend returns the funds the transaction takes from its signer:
+// the fee, and the sent coins and deposits of its messages.
+// Each VM message is additionally charged the fixed VM cost by the node
+func txSpend(tx *std.Tx) std.Coins {
+	var (
+		spend  = std.NewCoins(tx.Fee.GasFee)
+		vmCost = std.NewCoins(common.InitialTxCost)
+	)
+
+	for _, msg := range tx.Msgs {
+		switch m := msg.(type) {
+		case bank.MsgSend:
+			spend = spend.Add(m.Amount)
+		case vm.MsgCall:
+			spend = spend.Add(vmCost).Add(m.Send)
+		case vm.MsgAddPackage:
+			spend = spend.Add(vmCost).Add(m.Deposit)
+		}
+	}
+
+	return spend
+}
+
+// subtractCoins subtracts the spent coins from the balance, per denomination.
+// The subtraction fails if the spend exceeds the balance in any denomination
+func subtractCoins(balance, spend std.Coins) (std.Coins, bool) {
+	remaining := make([]std.Coin, 0, len(balance))
+
+	for _, coin := range spend {
+		if balance.AmountOf(coin.Denom) < coin.Amount {
+			return nil, false
+		}
+	}
+
+	for _, coin := range balance {
+		if amount := coin.Amount - spend.AmountOf(coin.Denom); amount > 0 {
+			remaining = append(remaining, std.NewCoin(coin.Denom, amount))
+		}
+	}
+
+	return std.NewCoins(remaining...), true
+}
+
+// loadPredictions loads the predicted sub-account states
+// from the account accounting of the previous run results
+func loadPredictions(path string) (distributor.Predictions, error) {
+	result, err := loadRunResult(path)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make(distributor.Predictions, len(result.Accounts))
+
+	for _, account := range result.Accounts {
+		if !account.Predicted {
+			continue
+		}
+
+		coins, err := std.ParseCoins(account.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse predicted balance of %s, %w", account.Address, err)
+		}
+
+		predictions[account.Address] = distributor.Prediction{
+			AccountNumber: account.AccountNumber,
+			Sequence:      account.Sequence,
+			Coins:         coins,
+		}
+	}
+
+	return predictions, nil
+}
+
+// predictionOption loads the sub-account state predictions of the previous
+// run, and seeds the distribution with them. The predictions are sampled
+// randomly across runs, unless the run is seeded
+func (p *Pipeline) predictionOption() (distributor.Option, error) {
+	predictions, err := loadPredictions(p.cfg.ReuseResults)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load previous run results, %w", err)
+	}
+
+	seed := int64(p.cfg.Seed)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	fmt.Printf("Loaded %d sub-account predictions from %s\n", len(predictions), p.cfg.ReuseResults)
+
+	return distributor.WithPredictions(predictions, int(p.cfg.ReuseSamples), seed), nil
+}
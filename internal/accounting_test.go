@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccounting_AccountResults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		balance  = int64(10 * common.InitialTxCost.Amount)
+		accounts = []*gnoland.GnoAccount{
+			{BaseAccount: *std.NewBaseAccount(
+				crypto.AddressFromPreimage([]byte("committed")),
+				std.NewCoins(std.NewCoin(common.Denomination, balance)),
+				nil,
+				1,
+				5,
+			)},
+			{BaseAccount: *std.NewBaseAccount(
+				crypto.AddressFromPreimage([]byte("lost")),
+				std.NewCoins(std.NewCoin(common.Denomination, balance)),
+				nil,
+				2,
+				0,
+			)},
+		}
+
+		committed = make(map[string]time.Time)
+		txs       = make([]*std.Tx, 0)
+	)
+
+	// newCallTx creates a realm call from the account, and marks it as committed
+	newCallTx := func(account *gnoland.GnoAccount, arg string, commit bool) {
+		tx := &std.Tx{
+			Msgs: []std.Msg{
+				vm.MsgCall{
+					Caller:  account.Address,
+					PkgPath: "gno.land/r/stress",
+					Func:    "SayHello",
+					Args:    []string{arg},
+				},
+			},
+			Fee: std.NewFee(100000, common.DefaultGasFee),
+		}
+
+		txBin, err := amino.Marshal(tx)
+		if err != nil {
+			t.Fatalf("unable to marshal tx, %v", err)
+		}
+
+		if commit {
+			committed[string(types.Tx(txBin).Hash())] = time.Now()
+		}
+
+		txs = append(txs, tx)
+	}
+
+	newCallTx(accounts[0], "a", true)
+	newCallTx(accounts[0], "b", true)
+	newCallTx(accounts[1], "a", true)
+	newCallTx(accounts[1], "b", false)
+
+	results, err := newAccountResults(accounts, txs, committed)
+	if err != nil {
+		t.Fatalf("unable to account for sub-accounts, %v", err)
+	}
+
+	if len(results) != len(accounts) {
+		t.Fatalf("invalid number of account results, %d", len(results))
+	}
+
+	// The committed calls are charged the fee and the fixed VM cost
+	spent := 2 * (common.DefaultGasFee.Amount + common.InitialTxCost.Amount)
+
+	assert.True(t, results[0].Predicted)
+	assert.Equal(t, 2, results[0].Committed)
+	assert.Equal(t, uint64(7), results[0].Sequence)
+	assert.Equal(t, std.NewCoins(std.NewCoin(common.Denomination, balance-spent)).String(), results[0].Balance)
+
+	// Accounts with lost transactions can't be predicted
+	assert.False(t, results[1].Predicted)
+	assert.Equal(t, 1, results[1].Committed)
+	assert.Equal(t, 1, results[1].Lost)
+	assert.Empty(t, results[1].Balance)
+}
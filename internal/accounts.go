@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/pkgs/crypto"
+)
+
+var (
+	errInvalidAccountFilter = errors.New("invalid account filter entry")
+	errExcludedDistributor  = errors.New("distributor account cannot be excluded")
+	errNoSubAccounts        = errors.New("no sub-accounts left after filtering")
+)
+
+// accountSet is a set of accounts, referenced
+// by their derivation index or address
+type accountSet struct {
+	indices   map[uint32]struct{}
+	addresses map[string]struct{}
+}
+
+// parseAccountSet parses the comma separated list of
+// account derivation indices or (bech32) addresses
+func parseAccountSet(list string) (*accountSet, error) {
+	set := &accountSet{
+		indices:   make(map[uint32]struct{}),
+		addresses: make(map[string]struct{}),
+	}
+
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// Check if the entry is a derivation index
+		if index, err := strconv.ParseUint(entry, 10, 32); err == nil {
+			set.indices[uint32(index)] = struct{}{}
+
+			continue
+		}
+
+		// Check if the entry is an address
+		address, err := crypto.AddressFromBech32(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidAccountFilter, entry)
+		}
+
+		set.addresses[address.String()] = struct{}{}
+	}
+
+	return set, nil
+}
+
+// isEmpty returns a flag indicating if the set has no entries
+func (s *accountSet) isEmpty() bool {
+	return len(s.indices) == 0 && len(s.addresses) == 0
+}
+
+// contains returns a flag indicating if the account is in the set
+func (s *accountSet) contains(index uint32, address crypto.Address) bool {
+	if _, ok := s.indices[index]; ok {
+		return true
+	}
+
+	_, ok := s.addresses[address.String()]
+
+	return ok
+}
+
+// accountFilter is the sub-account refusal and allow list
+type accountFilter struct {
+	exclude *accountSet // the accounts that are never used
+	only    *accountSet // the accounts that can be used, if any
+
+	distributorIndex uint32 // the derivation index of the distributor
+}
+
+// newAccountFilter creates a new account filter
+// from the comma separated exclude and only lists
+func newAccountFilter(exclude, only string, distributorIndex uint32) (*accountFilter, error) {
+	excludeSet, err := parseAccountSet(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excluded accounts, %w", err)
+	}
+
+	// Make sure the distributor is not excluded, by index.
+	// The address is checked once the account is derived
+	if _, ok := excludeSet.indices[distributorIndex]; ok {
+		return nil, errExcludedDistributor
+	}
+
+	onlySet, err := parseAccountSet(only)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowed accounts, %w", err)
+	}
+
+	return &accountFilter{
+		exclude:          excludeSet,
+		only:             onlySet,
+		distributorIndex: distributorIndex,
+	}, nil
+}
+
+// allows returns a flag indicating if the sub-account
+// can participate in the stress test run
+func (f *accountFilter) allows(index uint32, address crypto.Address) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.exclude.contains(index, address) {
+		return false
+	}
+
+	return f.only.isEmpty() || f.only.contains(index, address)
+}
+
+// checkDistributor verifies the distributor account is not excluded
+func (f *accountFilter) checkDistributor(address crypto.Address) error {
+	if f != nil && f.exclude.contains(f.distributorIndex, address) {
+		return errExcludedDistributor
+	}
+
+	return nil
+}
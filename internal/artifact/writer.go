@@ -0,0 +1,181 @@
+// Package artifact implements the run artifact writer, which moves the
+// artifact writes off the collection path, so a slow output disk never
+// backs up the block processing
+package artifact
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Result is the artifact writer summary of the run
+type Result struct {
+	Written int `json:"written"` // the number of written records
+}
+
+// record is a single queued artifact write
+type record struct {
+	kind  string
+	write func() error
+}
+
+// Writer executes the artifact writes on a single background goroutine,
+// in the order they were queued. The records (summaries, segments)
+// are never dropped, so the queue is unbounded.
+// A nil writer executes the writes right away
+type Writer struct {
+	mux    sync.Mutex
+	queue  []record
+	closed bool // flag indicating if the writer no longer accepts records
+
+	written int
+	err     error // the first write failure, if any
+
+	signal chan struct{}
+	done   chan struct{}
+
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+// NewWriter creates a new artifact writer
+func NewWriter() *Writer {
+	return &Writer{
+		signal: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts the background artifact writes
+func (w *Writer) Start() {
+	if w == nil {
+		return
+	}
+
+	w.startOnce.Do(func() {
+		go w.run()
+	})
+}
+
+// Write queues the record write, which is never dropped.
+// Records written after the writer is closed are written once the queue drains
+func (w *Writer) Write(kind string, write func() error) {
+	if w == nil {
+		_ = write()
+
+		return
+	}
+
+	w.mux.Lock()
+
+	if w.closed {
+		w.mux.Unlock()
+
+		// Keep the single writer, by waiting for the queue to drain
+		<-w.done
+
+		w.execute(record{kind: kind, write: write})
+
+		return
+	}
+
+	w.queue = append(w.queue, record{kind: kind, write: write})
+	w.mux.Unlock()
+
+	w.notify()
+}
+
+// Close stops accepting records, waits for the queued records
+// to be written, and returns the first write failure, if any
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+
+	w.closeOnce.Do(func() {
+		// Make sure the queue is drained, even if the writer never started
+		w.Start()
+
+		w.mux.Lock()
+		w.closed = true
+		w.mux.Unlock()
+
+		w.notify()
+
+		<-w.done
+	})
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	return w.err
+}
+
+// Result returns the artifact writer summary
+func (w *Writer) Result() *Result {
+	if w == nil {
+		return nil
+	}
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	return &Result{
+		Written: w.written,
+	}
+}
+
+// run writes out the queued records, until the writer is closed and drained
+func (w *Writer) run() {
+	defer close(w.done)
+
+	for {
+		w.mux.Lock()
+
+		var (
+			pending = w.queue
+			closed  = w.closed
+		)
+
+		w.queue = nil
+		w.mux.Unlock()
+
+		for _, r := range pending {
+			w.execute(r)
+		}
+
+		if closed && len(pending) == 0 {
+			return
+		}
+
+		if len(pending) == 0 {
+			<-w.signal
+		}
+	}
+}
+
+// execute writes out the single record
+func (w *Writer) execute(r record) {
+	err := r.write()
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if err != nil {
+		if w.err == nil {
+			w.err = fmt.Errorf("unable to write %s record, %w", r.kind, err)
+		}
+
+		return
+	}
+
+	w.written++
+}
+
+// notify wakes up the background writer, if it is waiting
+func (w *Writer) notify() {
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
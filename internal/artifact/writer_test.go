@@ -0,0 +1,143 @@
+package artifact
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records written in order", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			w       = NewWriter()
+			written = make([]int, 0)
+		)
+
+		w.Start()
+
+		for i := 0; i < 10; i++ {
+			i := i
+
+			w.Write("segment", func() error {
+				written = append(written, i)
+
+				return nil
+			})
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close writer, %v", err)
+		}
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, written)
+		assert.Equal(t, &Result{Written: 10}, w.Result())
+	})
+
+	t.Run("slow writes do not block the caller", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			w = NewWriter()
+
+			release  = make(chan struct{})
+			blocking sync.WaitGroup
+
+			segments = 0
+		)
+
+		w.Start()
+
+		// Stall the writer, as a slow disk would
+		blocking.Add(1)
+		w.Write("segment", func() error {
+			blocking.Done()
+			<-release
+
+			segments++
+
+			return nil
+		})
+
+		blocking.Wait()
+
+		// The records are queued while the writer is stalled
+		for i := 0; i < 5; i++ {
+			w.Write("segment", func() error {
+				segments++
+
+				return nil
+			})
+		}
+
+		close(release)
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close writer, %v", err)
+		}
+
+		assert.Equal(t, 6, segments)
+		assert.Equal(t, 6, w.Result().Written)
+	})
+
+	t.Run("write failures reported on close", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			errDisk = errors.New("disk full")
+			w       = NewWriter()
+			written = 0
+		)
+
+		w.Start()
+
+		w.Write("segment", func() error {
+			return errDisk
+		})
+
+		w.Write("segment", func() error {
+			written++
+
+			return nil
+		})
+
+		assert.ErrorIs(t, w.Close(), errDisk)
+
+		// The failure does not stop the following writes
+		assert.Equal(t, 1, written)
+
+		// Records written after the close are written right away
+		w.Write("segment", func() error {
+			written++
+
+			return nil
+		})
+
+		assert.Equal(t, 2, written)
+	})
+
+	t.Run("nil writer", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			w       *Writer
+			written = 0
+		)
+
+		w.Start()
+		w.Write("segment", func() error {
+			written++
+
+			return nil
+		})
+
+		assert.NoError(t, w.Close())
+		assert.Equal(t, 1, written)
+		assert.Nil(t, w.Result())
+	})
+}
@@ -4,25 +4,66 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/gnolang/gno/pkgs/amino"
 	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
 	"github.com/gnolang/gno/pkgs/std"
 	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/metrics"
 	"github.com/schollz/progressbar/v3"
 )
 
+var errUnboundedStream = errors.New("streamed broadcasts require a broadcast duration")
+
 // Batcher batches signed transactions
 // to the Gno Tendermint node
 type Batcher struct {
 	cli Client
+
+	order DispatchOrder // the order the transactions are dispatched in
+
+	groupByType bool // flag indicating if batches are grouped by message type
+	forceBatch  bool // flag indicating if the single broadcast fallback is disabled
+	fallback    bool // flag indicating if the batcher fell back to single broadcasts
+
+	starvation time.Duration // the time the dispatch loop spent idle between batches, outside the pacing waits
+
+	endpoints []Endpoint // the broadcast endpoints
+	affinity  Affinity   // the endpoint assignment strategy
+	router    *router    // the batch group endpoint router
+
+	pacer  Pacer  // the broadcast pacer, if any
+	reader Reader // the interleaved reader, if any
+
+	progress Progress // the broadcast progress report, if any
+	failure  Failure  // the failed broadcast report, if any
+
+	duration time.Duration // the broadcast duration, after which the remaining batches are not sent, if any
+
+	quiet bool // flag indicating if the per-chunk output is suppressed, while streaming
 }
 
 // NewBatcher creates a new Batcher instance
-func NewBatcher(cli Client) *Batcher {
-	return &Batcher{
-		cli: cli,
+func NewBatcher(cli Client, opts ...Option) *Batcher {
+	b := &Batcher{
+		cli:      cli,
+		order:    DispatchInterleaved,
+		affinity: AffinityRoundRobin,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	// Default to broadcasting to the cluster client
+	if len(b.endpoints) == 0 {
+		b.endpoints = []Endpoint{{Client: cli}}
 	}
+
+	b.router = newRouter(b.endpoints)
+
+	return b
 }
 
 // BatchTransactions batches provided transactions using the
@@ -38,16 +79,22 @@ func (b *Batcher) BatchTransactions(txs []*std.Tx, batchSize int) (*TxBatchResul
 
 	fmt.Printf("Latest block number: %d\n", latest)
 
+	// Order the transactions for dispatch
+	txs = orderTransactions(txs, b.order)
+
 	// Marshal the transactions
 	fmt.Printf("\nPreparing transactions...\n")
 
-	preparedTxs, err := prepareTransactions(txs)
+	preparedTxs, err := b.prepareTransactions(txs)
 	if err != nil {
 		return nil, fmt.Errorf("unable to batch transactions, %w", err)
 	}
 
+	// Group the transactions into batches
+	batches, batchTypes, batchGroups, accountGroups := b.planBatches(txs, preparedTxs, batchSize)
+
 	// Generate the batches
-	readyBatches, err := b.generateBatches(preparedTxs, batchSize)
+	readyBatches, err := b.generateBatches(batches, batchGroups)
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate batches, %w", err)
 	}
@@ -55,29 +102,417 @@ func (b *Batcher) BatchTransactions(txs []*std.Tx, batchSize int) (*TxBatchResul
 	// Execute the batch requests.
 	// Batch requests need to be sent out sequentially
 	// to preserve account sequence order
-	batchResults, err := sendBatches(readyBatches)
+	// All broadcast timestamps are read from a single monotonic clock,
+	// so they are ordered regardless of wall clock adjustments
+	state := b.newBroadcast(metrics.NewClock())
+
+	fmt.Printf("\nSending batches...\n")
+
+	bar := progressbar.Default(int64(len(readyBatches)), "batches sent")
+
+	sent, err := b.sendBatches(state, readyBatches, batches, batchTypes, batchGroups, bar)
 	if err != nil {
 		return nil, fmt.Errorf("unable to send batches, %w", err)
 	}
 
+	fmt.Printf("✅ Successfully sent %d batches\n", len(sent.batches))
+
+	return b.newBatchResult(latest, state.clock, txs, preparedTxs, sent, accountGroups)
+}
+
+// BatchStream broadcasts the transactions generated by the source on the fly,
+// in chunks of a batch per endpoint, until the broadcast duration elapses,
+// or the source runs dry. The next chunk is generated and signed while the current one
+// is sent, so the transactions are never constructed for the entire duration.
+// The broadcast chunks, including their unsent transactions, are part of the result
+func (b *Batcher) BatchStream(source Source, batchSize int) (*TxBatchResult, error) {
+	// The stream is bounded by the broadcast duration
+	if b.duration <= 0 {
+		return nil, errUnboundedStream
+	}
+
+	fmt.Printf("\n📦 Streaming Transactions 📦\n\n")
+
+	// Note the current latest block
+	latest, err := b.cli.GetLatestBlockHeight()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch latest block %w", err)
+	}
+
+	fmt.Printf("Latest block number: %d\n", latest)
+
+	var (
+		chunkSize = batchSize * len(b.endpoints)
+		chunks    = b.generateChunks(source, chunkSize)
+
+		state         *broadcast
+		txs           = make([]*std.Tx, 0)
+		preparedTxs   = make([][]byte, 0)
+		sent          = &sentBatches{}
+		accountGroups map[string]int
+	)
+
+	// The chunk generated ahead is dropped once the broadcast is over
+	defer chunks.stop()
+
+	// The chunks are planned and generated silently, under a single progress report
+	b.quiet = true
+	defer func() {
+		b.quiet = false
+	}()
+
+	fmt.Printf("\nSending batches, generated on the fly...\n")
+
+	bar := progressbar.Default(-1, "batches sent")
+
+	for state == nil || !state.expired() {
+		generated := <-chunks.ready
+		if generated.err != nil {
+			return nil, fmt.Errorf("unable to generate transactions, %w", generated.err)
+		}
+
+		chunk := generated.txs
+
+		if len(chunk) == 0 {
+			fmt.Printf("\n⚠️ Transaction source exhausted before the broadcast deadline\n")
+
+			break
+		}
+
+		// The broadcast (and its deadline) starts with the first generated chunk
+		if state == nil {
+			state = b.newBroadcast(metrics.NewClock())
+		}
+
+		chunk = orderTransactions(chunk, b.order)
+
+		preparedChunk, err := b.prepareTransactions(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("unable to batch transactions, %w", err)
+		}
+
+		batches, batchTypes, batchGroups, chunkGroups := b.planBatches(chunk, preparedChunk, batchSize)
+
+		readyBatches, err := b.generateBatches(batches, batchGroups)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate batches, %w", err)
+		}
+
+		chunkSent, err := b.sendBatches(state, readyBatches, batches, batchTypes, batchGroups, bar)
+		if err != nil {
+			return nil, fmt.Errorf("unable to send batches, %w", err)
+		}
+
+		txs = append(txs, chunk...)
+		preparedTxs = append(preparedTxs, preparedChunk...)
+		sent.add(chunkSent)
+
+		// The accounts are assigned to the same endpoint group in every chunk
+		for account, group := range chunkGroups {
+			if accountGroups == nil {
+				accountGroups = make(map[string]int)
+			}
+
+			accountGroups[account] = group
+		}
+	}
+
+	if state == nil {
+		state = b.newBroadcast(metrics.NewClock())
+	}
+
+	_ = bar.Finish()
+
+	fmt.Printf("\n✅ Successfully sent %d batches\n", len(sent.batches))
+
+	result, err := b.newBatchResult(latest, state.clock, txs, preparedTxs, sent, accountGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Generated = txs
+
+	return result, nil
+}
+
+// generatedChunk is a single generated chunk of a streamed broadcast
+type generatedChunk struct {
+	txs []*std.Tx
+	err error
+}
+
+// chunkStream generates the chunks of a streamed broadcast in the background,
+// a chunk ahead of the broadcast, so the signing overlaps the paced batches
+type chunkStream struct {
+	ready chan generatedChunk
+	done  chan struct{}
+}
+
+// generateChunks starts the background chunk generation, until the source
+// runs dry or fails, or the stream is stopped
+func (b *Batcher) generateChunks(source Source, chunkSize int) *chunkStream {
+	chunks := &chunkStream{
+		ready: make(chan generatedChunk),
+		done:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(chunks.ready)
+
+		for {
+			select {
+			case <-chunks.done:
+				return
+			default:
+			}
+
+			txs, err := source(chunkSize)
+
+			select {
+			case chunks.ready <- generatedChunk{txs: txs, err: err}:
+			case <-chunks.done:
+				return
+			}
+
+			if err != nil || len(txs) == 0 {
+				return
+			}
+		}
+	}()
+
+	return chunks
+}
+
+// stop stops the chunk generation, and waits for the source to return,
+// so it is never invoked once the broadcast is over
+func (c *chunkStream) stop() {
+	close(c.done)
+
+	// Drain the chunk generated ahead, until the generation is over
+	for range c.ready {
+	}
+}
+
+// newBatchResult parses the results of the sent batches,
+// and returns the batching result of the broadcast transactions
+func (b *Batcher) newBatchResult(
+	latest int64,
+	clock *metrics.Clock,
+	txs []*std.Tx,
+	preparedTxs [][]byte,
+	sent *sentBatches,
+	accountGroups map[string]int,
+) (*TxBatchResult, error) {
+	sentTxs := 0
+	for _, batch := range sent.batches {
+		sentTxs += len(batch)
+	}
+
 	// Parse the results
-	txHashes, err := parseBatchResults(batchResults, len(txs))
+	txHashes, err := parseBatchResults(sent.results, sentTxs)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse batch results, %w", err)
 	}
 
-	fmt.Printf("✅ Successfully sent %d txs in %d batches\n", len(txs), len(readyBatches))
+	fmt.Printf("✅ Successfully sent %d txs in %d batches\n", sentTxs, len(sent.batches))
 
-	return &TxBatchResult{
+	if unsent := len(txs) - sentTxs; unsent > 0 {
+		fmt.Printf("⚠️ Broadcast duration reached, %d txs were not sent\n", unsent)
+	}
+
+	result := &TxBatchResult{
 		TxHashes:   txHashes,
 		StartBlock: latest,
-	}, nil
+		Fallback:   b.fallback,
+		Timings:    make([]TxTiming, 0, sentTxs),
+		Clock:      clock.Anchor(),
+		Starvation: b.starvation,
+		Unsent:     len(txs) - sentTxs,
+
+		FailedEndpoints: b.router.failed(),
+	}
+
+	// Report the final endpoint of each account
+	if accountGroups != nil {
+		result.Assignments = make(map[string]string, len(accountGroups))
+
+		for account, group := range accountGroups {
+			_, endpoint := b.router.route(group)
+
+			result.Assignments[account] = endpoint.URL
+		}
+	}
+
+	// Each transaction shares the timing of its batch
+	for index, batch := range sent.batches {
+		for range batch {
+			result.Timings = append(result.Timings, sent.timings[index])
+		}
+	}
+
+	// Each transaction is accepted by the endpoint of its batch
+	if b.endpoints[0].URL != "" {
+		result.TxEndpoints = make([]string, 0, sentTxs)
+
+		for index, batch := range sent.batches {
+			for range batch {
+				result.TxEndpoints = append(result.TxEndpoints, b.endpoints[sent.endpoints[index]].URL)
+			}
+		}
+	}
+
+	// Report the dispatch window of each account
+	result.Dispatches = accountDispatches(preparedTxs, txs, txHashes, result.Timings)
+
+	// Report the batch latency for each message type
+	if b.groupByType {
+		result.Latencies = typeLatencies(sent.types, sent.timings)
+	}
+
+	return result, nil
+}
+
+// planBatches groups the prepared transactions into batches, and returns
+// the message type and endpoint group of each batch. With account affinity,
+// the endpoint group of each account is also returned
+func (b *Batcher) planBatches(
+	txs []*std.Tx,
+	preparedTxs [][]byte,
+	batchSize int,
+) ([][][]byte, []string, []int, map[string]int) {
+	numEndpoints := len(b.endpoints)
+
+	if b.affinity != AffinityAccount || numEndpoints < 2 {
+		batches, batchTypes := b.planGroupBatches(txs, preparedTxs, batchSize)
+
+		// Spread the batches evenly over the endpoints
+		batchGroups := make([]int, len(batches))
+		for index := range batchGroups {
+			batchGroups[index] = index % numEndpoints
+		}
+
+		return batches, batchTypes, batchGroups, nil
+	}
+
+	// Partition the transactions by the account endpoint group
+	var (
+		partitionTxs      = make([][]*std.Tx, numEndpoints)
+		partitionPrepared = make([][][]byte, numEndpoints)
+		accountGroups     = make(map[string]int)
+	)
+
+	for index, tx := range txs {
+		group := 0
+
+		if signer, ok := txSigner(tx); ok {
+			group = accountGroup(signer, numEndpoints)
+			accountGroups[signer] = group
+		}
+
+		partitionTxs[group] = append(partitionTxs[group], tx)
+		partitionPrepared[group] = append(partitionPrepared[group], preparedTxs[index])
+	}
+
+	var (
+		partitionBatches = make([][][][]byte, numEndpoints)
+		partitionTypes   = make([][]string, numEndpoints)
+		numBatches       = 0
+	)
+
+	for group := range partitionTxs {
+		if len(partitionTxs[group]) == 0 {
+			continue
+		}
+
+		partitionBatches[group], partitionTypes[group] = b.planGroupBatches(
+			partitionTxs[group],
+			partitionPrepared[group],
+			batchSize,
+		)
+
+		numBatches += len(partitionBatches[group])
+	}
+
+	// Interleave the partition batches, so the endpoints are loaded evenly
+	var (
+		batches     = make([][][]byte, 0, numBatches)
+		batchTypes  = make([]string, 0, numBatches)
+		batchGroups = make([]int, 0, numBatches)
+	)
+
+	for round := 0; len(batches) < numBatches; round++ {
+		for group := range partitionBatches {
+			if round >= len(partitionBatches[group]) {
+				continue
+			}
+
+			batches = append(batches, partitionBatches[group][round])
+			batchTypes = append(batchTypes, partitionTypes[group][round])
+			batchGroups = append(batchGroups, group)
+		}
+	}
+
+	b.logf("Assigned %d accounts to %d endpoints\n", len(accountGroups), numEndpoints)
+
+	return batches, batchTypes, batchGroups, accountGroups
+}
+
+// planGroupBatches groups the prepared transactions
+// into batches, and returns the message type of each batch
+func (b *Batcher) planGroupBatches(
+	txs []*std.Tx,
+	preparedTxs [][]byte,
+	batchSize int,
+) ([][][]byte, []string) {
+	if !b.groupByType {
+		batches := generateBatches(preparedTxs, batchSize)
+
+		return batches, make([]string, len(batches))
+	}
+
+	var (
+		plan       = planTypeBatches(txs, batchSize)
+		batches    = make([][][]byte, len(plan))
+		batchTypes = make([]string, len(plan))
+	)
+
+	for index, planned := range plan {
+		batch := make([][]byte, len(planned.indices))
+
+		for i, txIndex := range planned.indices {
+			batch[i] = preparedTxs[txIndex]
+		}
+
+		batches[index] = batch
+		batchTypes[index] = planned.msgType
+	}
+
+	b.logf("Grouped transactions into %d typed batches\n", len(batches))
+
+	return batches, batchTypes
+}
+
+// typeLatencies summarizes the batch latencies for each message type
+func typeLatencies(batchTypes []string, timings []TxTiming) map[string]*metrics.Distribution {
+	samples := make(map[string][]time.Duration)
+
+	for index, msgType := range batchTypes {
+		samples[msgType] = append(samples[msgType], timings[index].Accepted.Sub(timings[index].Sent))
+	}
+
+	distributions := make(map[string]*metrics.Distribution, len(samples))
+
+	for msgType, typeSamples := range samples {
+		distributions[msgType] = metrics.NewDistribution(typeSamples)
+	}
+
+	return distributions
 }
 
 // prepareTransactions marshals the transactions into amino binary
-func prepareTransactions(txs []*std.Tx) ([][]byte, error) {
+func (b *Batcher) prepareTransactions(txs []*std.Tx) ([][]byte, error) {
 	marshalledTxs := make([][]byte, len(txs))
-	bar := progressbar.Default(int64(len(txs)), "txs prepared")
+	bar := b.newBar(len(txs), "txs prepared")
 
 	for index, tx := range txs {
 		txBin, err := amino.Marshal(tx)
@@ -93,29 +528,37 @@ func prepareTransactions(txs []*std.Tx) ([][]byte, error) {
 	return marshalledTxs, nil
 }
 
-// generateBatches generates batches of transactions
-func (b *Batcher) generateBatches(txs [][]byte, batchSize int) ([]common.Batch, error) {
+// routedBatch is a batch request, created
+// for the endpoint the batch is routed to
+type routedBatch struct {
+	batch    common.Batch
+	endpoint int
+}
+
+// generateBatches generates batches of transactions,
+// on the client of the endpoint each batch is routed to
+func (b *Batcher) generateBatches(batches [][][]byte, batchGroups []int) ([]routedBatch, error) {
 	var (
-		batches      = generateBatches(txs, batchSize)
 		numBatches   = len(batches)
-		readyBatches = make([]common.Batch, numBatches)
+		readyBatches = make([]routedBatch, numBatches)
 	)
 
-	fmt.Printf("\nGenerating batches...\n")
+	b.logf("\nGenerating batches...\n")
 
-	bar := progressbar.Default(int64(numBatches), "batches generated")
+	bar := b.newBar(numBatches, "batches generated")
 
 	for index, batch := range batches {
-		cliBatch := b.cli.CreateBatch()
+		endpointIndex, endpoint := b.router.route(batchGroups[index])
 
-		for _, tx := range batch {
-			// Append the transaction
-			if err := cliBatch.AddTxBroadcast(tx); err != nil {
-				return nil, fmt.Errorf("unable to prepare transaction, %w", err)
-			}
+		cliBatch, err := createBatch(endpoint.Client, batch)
+		if err != nil {
+			return nil, err
 		}
 
-		readyBatches[index] = cliBatch
+		readyBatches[index] = routedBatch{
+			batch:    cliBatch,
+			endpoint: endpointIndex,
+		}
 
 		_ = bar.Add(1)
 	}
@@ -123,31 +566,256 @@ func (b *Batcher) generateBatches(txs [][]byte, batchSize int) ([]common.Batch,
 	return readyBatches, nil
 }
 
-// sendBatches sends the prepared batch requests
-func sendBatches(readyBatches []common.Batch) ([][]any, error) {
+// createBatch creates the batch request for the transactions
+func createBatch(cli Client, batch [][]byte) (common.Batch, error) {
+	cliBatch := cli.CreateBatch()
+
+	for _, tx := range batch {
+		// Append the transaction
+		if err := cliBatch.AddTxBroadcast(tx); err != nil {
+			return nil, fmt.Errorf("unable to prepare transaction, %w", err)
+		}
+	}
+
+	return cliBatch, nil
+}
+
+// broadcast is the state of a single broadcast, shared by its consecutive batch chunks
+type broadcast struct {
+	clock    *metrics.Clock
+	deadline time.Time // the broadcast deadline, if any
+	accepted time.Time // the time the previous batch was accepted
+
+	sent    int // the number of sent transactions
+	batches int // the number of sent batches
+}
+
+// newBroadcast starts a new broadcast, on the given clock.
+// With a broadcast duration, the deadline starts with the broadcast
+func (b *Batcher) newBroadcast(clock *metrics.Clock) *broadcast {
+	state := &broadcast{
+		clock: clock,
+	}
+
+	if b.duration > 0 {
+		state.deadline = clock.Now().Add(b.duration)
+	}
+
+	return state
+}
+
+// expired returns a flag indicating if the broadcast deadline has passed, if any
+func (s *broadcast) expired() bool {
+	return !s.deadline.IsZero() && !s.clock.Now().Before(s.deadline)
+}
+
+// sentBatches are the sent batches of a broadcast, with their outcome
+type sentBatches struct {
+	batches   [][][]byte // the transactions of each batch
+	types     []string   // the message type of each batch
+	results   [][]any    // the broadcast results of each batch
+	timings   []TxTiming // the timing of each batch
+	endpoints []int      // the accepting endpoint of each batch
+}
+
+// add appends the other sent batches
+func (s *sentBatches) add(other *sentBatches) {
+	s.batches = append(s.batches, other.batches...)
+	s.types = append(s.types, other.types...)
+	s.results = append(s.results, other.results...)
+	s.timings = append(s.timings, other.timings...)
+	s.endpoints = append(s.endpoints, other.endpoints...)
+}
+
+// sendBatches sends the prepared batch requests, and returns
+// the results, timing and accepting endpoint of each sent batch.
+// If the node rejects the first batch request, the batcher falls back
+// to broadcasting the transactions one by one, for the rest of the run.
+// If an endpoint fails, its batch groups are reassigned to a healthy endpoint.
+// With a broadcast duration, the batches past the deadline are not sent,
+// and only the sent batches are returned
+func (b *Batcher) sendBatches(
+	state *broadcast,
+	readyBatches []routedBatch,
+	batches [][][]byte,
+	batchTypes []string,
+	batchGroups []int,
+	bar *progressbar.ProgressBar,
+) (*sentBatches, error) {
 	var (
-		numBatches   = len(readyBatches)
-		batchResults = make([][]any, numBatches)
+		clock = state.clock
+
+		numBatches     = len(readyBatches)
+		batchResults   = make([][]any, numBatches)
+		batchTimings   = make([]TxTiming, numBatches)
+		batchEndpoints = make([]int, numBatches)
+		numSent        = numBatches
 	)
 
-	fmt.Printf("\nSending batches...\n")
+	for index := range readyBatches {
+		var (
+			start time.Time
+			paced time.Duration
+
+			batchResult []any
+			err         error
+		)
+
+		if b.pacer != nil {
+			waitStart := clock.Now()
+			b.pacer.Wait(len(batches[index]))
+			paced = clock.Now().Sub(waitStart)
+		}
+
+		// The pacing wait can cross the broadcast deadline
+		if state.expired() {
+			numSent = index
+
+			break
+		}
+
+		if b.reader != nil {
+			b.reader.Read(len(batches[index]))
+		}
+
+		// The time between batches, outside the pacing waits,
+		// is the dispatch loop waiting on the client itself
+		if !state.accepted.IsZero() {
+			if idle := clock.Now().Sub(state.accepted) - paced; idle > 0 {
+				b.starvation += idle
+			}
+		}
+
+		for {
+			endpointIndex, endpoint := b.router.route(batchGroups[index])
+			batchEndpoints[index] = endpointIndex
+
+			// Regenerate the batch if its group was reassigned
+			// to a different endpoint since it was generated
+			if readyBatches[index].endpoint != endpointIndex {
+				cliBatch, createErr := createBatch(endpoint.Client, batches[index])
+				if createErr != nil {
+					return nil, fmt.Errorf("unable to regenerate batch, %w", createErr)
+				}
+
+				readyBatches[index] = routedBatch{
+					batch:    cliBatch,
+					endpoint: endpointIndex,
+				}
+			}
 
-	bar := progressbar.Default(int64(numBatches), "batches sent")
+			start = clock.Now()
+			batchResult, err = b.executeBatch(endpoint.Client, readyBatches[index].batch, batches[index])
+
+			// Check if the batch requests are rejected altogether
+			if err != nil && state.batches == 0 && !b.forceBatch && !b.fallback && errors.Is(err, common.ErrBatchRejected) {
+				fmt.Printf(
+					"\n⚠️ Batch request rejected, falling back to single transaction broadcasts, %v\n",
+					err,
+				)
+
+				b.fallback = true
+
+				continue
+			}
+
+			if err != nil && b.failure != nil {
+				b.failure(err)
+			}
+
+			if err == nil || errors.Is(err, common.ErrBatchRejected) || !b.router.failover(endpointIndex) {
+				break
+			}
+
+			// Retry the batch on the endpoint the group was reassigned to
+			_, replacement := b.router.route(batchGroups[index])
+
+			fmt.Printf(
+				"\n⚠️ Endpoint %s failed, reassigning its broadcasts to %s, %v\n",
+				endpoint.URL,
+				replacement.URL,
+				err,
+			)
+		}
 
-	for index, readyBatch := range readyBatches {
-		batchResult, err := readyBatch.Execute()
 		if err != nil {
 			return nil, fmt.Errorf("unable to batch request, %w", err)
 		}
 
+		state.accepted = clock.Now()
+
 		batchResults[index] = batchResult
+		batchTimings[index] = TxTiming{
+			Sent:     start,
+			Accepted: state.accepted,
+		}
+
+		if b.pacer != nil {
+			b.pacer.Track(batches[index], start)
+		}
+
+		state.sent += len(batches[index])
+		state.batches++
+
+		if b.progress != nil {
+			b.progress(state.sent)
+		}
 
 		_ = bar.Add(1)
 	}
 
-	fmt.Printf("✅ Successfully sent %d batches\n", numBatches)
+	return &sentBatches{
+		batches:   batches[:numSent],
+		types:     batchTypes[:numSent],
+		results:   batchResults[:numSent],
+		timings:   batchTimings[:numSent],
+		endpoints: batchEndpoints[:numSent],
+	}, nil
+}
+
+// logf prints the batching output, unless it is suppressed while streaming
+func (b *Batcher) logf(format string, args ...any) {
+	if b.quiet {
+		return
+	}
+
+	fmt.Printf(format, args...)
+}
+
+// newBar creates the batching progress bar, silent while streaming
+func (b *Batcher) newBar(max int, description string) *progressbar.ProgressBar {
+	if b.quiet {
+		return progressbar.DefaultSilent(int64(max), description)
+	}
+
+	return progressbar.Default(int64(max), description)
+}
+
+// executeBatch executes the batch request, or broadcasts
+// the batch transactions one by one if the batcher fell back
+func (b *Batcher) executeBatch(cli Client, readyBatch common.Batch, batch [][]byte) ([]any, error) {
+	if b.fallback {
+		return broadcastSingle(cli, batch)
+	}
+
+	return readyBatch.Execute()
+}
+
+// broadcastSingle broadcasts the batch transactions one by one,
+// and returns the results in the same format as the batch request
+func broadcastSingle(cli Client, batch [][]byte) ([]any, error) {
+	results := make([]any, 0, len(batch))
+
+	for _, tx := range batch {
+		res, err := cli.BroadcastRawTransactionSync(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, res)
+	}
 
-	return batchResults, nil
+	return results, nil
 }
 
 // parseBatchResults extracts transaction hashes
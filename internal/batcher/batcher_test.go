@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"testing"
+	"time"
 
 	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
 	"github.com/gnolang/gno/pkgs/std"
@@ -56,6 +57,7 @@ func TestBatcher_BatchTransactions(t *testing.T) {
 
 		broadcastTxs = make([][]byte, 0)
 		currIndex    = 0
+		progress     = make([]int, 0)
 
 		mockBatch = &mockBatch{
 			addTxBroadcastFn: func(tx []byte) error {
@@ -85,7 +87,9 @@ func TestBatcher_BatchTransactions(t *testing.T) {
 	)
 
 	// Create the batcher
-	b := NewBatcher(mockClient)
+	b := NewBatcher(mockClient, WithProgress(func(sent int) {
+		progress = append(progress, sent)
+	}))
 
 	// Batch the transactions
 	res, err := b.BatchTransactions(txs, batchSize)
@@ -102,4 +106,260 @@ func TestBatcher_BatchTransactions(t *testing.T) {
 	for index, txHash := range txHashes {
 		assert.True(t, bytes.Equal(txHash, txHashes[index]))
 	}
+
+	// Make sure the progress is reported after each batch
+	assert.Equal(t, []int{20, 40, 60, 80, 100}, progress)
+
+	// Make sure the broadcast timestamps are ordered, and anchored
+	for index := 1; index < len(res.Timings); index++ {
+		assert.False(t, res.Timings[index].Sent.Before(res.Timings[index-1].Sent))
+		assert.False(t, res.Timings[index].Accepted.Before(res.Timings[index].Sent))
+	}
+
+	if res.Clock == nil {
+		t.Fatal("clock anchor not reported")
+	}
+
+	assert.False(t, res.Timings[0].Sent.Before(res.Clock.Wall))
+}
+
+func TestBatcher_Starvation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs    = 100
+		batchSize = 20
+		txs       = generateTestTransactions(numTxs)
+
+		pacing   = 30 * time.Millisecond
+		overhead = 5 * time.Millisecond
+
+		mockClient = &mockClient{
+			createBatchFn: func() common.Batch {
+				return &mockBatch{
+					executeFn: func() ([]interface{}, error) {
+						res := make([]any, batchSize)
+
+						for i := 0; i < batchSize; i++ {
+							res[i] = &core_types.ResultBroadcastTx{
+								Hash: []byte{byte(i)},
+							}
+						}
+
+						return res, nil
+					},
+				}
+			},
+		}
+	)
+
+	// The progress report stands in for the client-side work between batches
+	b := NewBatcher(
+		mockClient,
+		WithPacer(&mockPacer{
+			waitFn: func(_ int) {
+				time.Sleep(pacing)
+			},
+		}),
+		WithProgress(func(_ int) {
+			time.Sleep(overhead)
+		}),
+	)
+
+	res, err := b.BatchTransactions(txs, batchSize)
+	if err != nil {
+		t.Fatalf("unable to batch transactions, %v", err)
+	}
+
+	// Make sure the client-side work is counted, and the pacing waits are not
+	numGaps := time.Duration(numTxs/batchSize - 1)
+
+	assert.GreaterOrEqual(t, res.Starvation, numGaps*overhead)
+	assert.Less(t, res.Starvation, numGaps*pacing)
+}
+
+func TestBatcher_Duration(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs    = 100
+		batchSize = 10
+		txs       = generateTestTransactions(numTxs)
+
+		mockClient = &mockClient{
+			createBatchFn: func() common.Batch {
+				return &mockBatch{
+					executeFn: func() ([]interface{}, error) {
+						res := make([]any, batchSize)
+
+						for i := 0; i < batchSize; i++ {
+							res[i] = &core_types.ResultBroadcastTx{
+								Hash: []byte{byte(i)},
+							}
+						}
+
+						return res, nil
+					},
+				}
+			},
+		}
+	)
+
+	b := NewBatcher(
+		mockClient,
+		WithPacer(&mockPacer{
+			waitFn: func(_ int) {
+				time.Sleep(20 * time.Millisecond)
+			},
+		}),
+		WithDuration(100*time.Millisecond),
+	)
+
+	res, err := b.BatchTransactions(txs, batchSize)
+	if err != nil {
+		t.Fatalf("unable to batch transactions, %v", err)
+	}
+
+	// Make sure the batches past the deadline are left unsent
+	assert.Greater(t, res.Unsent, 0)
+	assert.Zero(t, res.Unsent%batchSize)
+	assert.Equal(t, numTxs, len(res.TxHashes)+res.Unsent)
+	assert.Len(t, res.Timings, len(res.TxHashes))
+}
+
+func TestBatcher_Stream(t *testing.T) {
+	t.Parallel()
+
+	var (
+		batchSize = 10
+		generated = 0
+		requests  = []int{}
+
+		mockClient = &mockClient{
+			createBatchFn: func() common.Batch {
+				return &mockBatch{
+					executeFn: func() ([]interface{}, error) {
+						res := make([]any, batchSize)
+
+						for i := 0; i < batchSize; i++ {
+							res[i] = &core_types.ResultBroadcastTx{
+								Hash: []byte{byte(i)},
+							}
+						}
+
+						return res, nil
+					},
+				}
+			},
+		}
+
+		source = func(transactions int) ([]*std.Tx, error) {
+			requests = append(requests, transactions)
+
+			txs := generateTestTransactions(transactions)
+			for _, tx := range txs {
+				tx.Memo = fmt.Sprintf("%s-%d", tx.Memo, generated)
+				generated++
+			}
+
+			return txs, nil
+		}
+	)
+
+	b := NewBatcher(
+		mockClient,
+		WithPacer(&mockPacer{
+			waitFn: func(_ int) {
+				time.Sleep(20 * time.Millisecond)
+			},
+		}),
+		WithDuration(100*time.Millisecond),
+	)
+
+	res, err := b.BatchStream(source, batchSize)
+	if err != nil {
+		t.Fatalf("unable to stream transactions, %v", err)
+	}
+
+	// Make sure the transactions are generated a batch at a time, until the deadline
+	assert.Greater(t, len(requests), 1)
+
+	for _, requested := range requests {
+		assert.Equal(t, batchSize, requested)
+	}
+
+	// At most the last chunk is left unsent past the deadline,
+	// and the chunk generated ahead of the broadcast is dropped
+	assert.LessOrEqual(t, res.Unsent, batchSize)
+	assert.LessOrEqual(t, generated-len(res.Generated), batchSize)
+	assert.Equal(t, len(res.Generated), len(res.TxHashes)+res.Unsent)
+	assert.Len(t, res.Timings, len(res.TxHashes))
+
+	// Streams are bounded by the broadcast duration
+	_, err = NewBatcher(mockClient).BatchStream(source, batchSize)
+	assert.ErrorIs(t, err, errUnboundedStream)
+}
+
+func TestBatcher_BatchFallback(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs    = 50
+		batchSize = 20
+		txs       = generateTestTransactions(numTxs)
+
+		errRejected = fmt.Errorf("%w, server returned 400 Bad Request", common.ErrBatchRejected)
+	)
+
+	// newMockClient creates a client that rejects all batch requests
+	newMockClient := func(broadcasts *int) *mockClient {
+		return &mockClient{
+			createBatchFn: func() common.Batch {
+				return &mockBatch{
+					executeFn: func() ([]interface{}, error) {
+						return nil, errRejected
+					},
+				}
+			},
+			broadcastRawTransactionSyncFn: func(_ []byte) (*core_types.ResultBroadcastTx, error) {
+				*broadcasts++
+
+				return &core_types.ResultBroadcastTx{
+					Hash: []byte(fmt.Sprintf("hash-%d", *broadcasts)),
+				}, nil
+			},
+		}
+	}
+
+	t.Run("single broadcast fallback", func(t *testing.T) {
+		t.Parallel()
+
+		broadcasts := 0
+
+		b := NewBatcher(newMockClient(&broadcasts))
+
+		res, err := b.BatchTransactions(txs, batchSize)
+		if err != nil {
+			t.Fatalf("unable to batch transactions, %v", err)
+		}
+
+		// Make sure all transactions were broadcast one by one
+		assert.True(t, res.Fallback)
+		assert.Equal(t, numTxs, broadcasts)
+		assert.Len(t, res.TxHashes, numTxs)
+	})
+
+	t.Run("forced batching", func(t *testing.T) {
+		t.Parallel()
+
+		broadcasts := 0
+
+		b := NewBatcher(newMockClient(&broadcasts), WithForceBatch())
+
+		res, err := b.BatchTransactions(txs, batchSize)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrBatchRejected)
+		assert.Equal(t, 0, broadcasts)
+	})
 }
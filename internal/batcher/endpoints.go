@@ -0,0 +1,111 @@
+package batcher
+
+import (
+	"hash/fnv"
+)
+
+// Affinity is the strategy for assigning broadcasts to endpoints
+type Affinity string
+
+const (
+	// AffinityRoundRobin spreads the batches evenly over the endpoints
+	AffinityRoundRobin Affinity = "round-robin"
+
+	// AffinityAccount sends all transactions of an account to the same endpoint
+	AffinityAccount Affinity = "account"
+)
+
+// IsAffinity checks if the endpoint affinity is valid
+func IsAffinity(affinity Affinity) bool {
+	return affinity == AffinityRoundRobin || affinity == AffinityAccount
+}
+
+// Endpoint is a node the transactions are broadcast to
+type Endpoint struct {
+	URL    string // the URL of the endpoint
+	Client Client // the client for the endpoint
+}
+
+// router routes batch groups to the endpoints.
+// Each group is routed to a single endpoint, and when an endpoint
+// fails, all of its groups are reassigned to the same healthy endpoint
+type router struct {
+	endpoints []Endpoint
+	healthy   []bool
+	routes    []int // the endpoint index for each group
+}
+
+// newRouter creates a new router, with
+// each group initially routed to its own endpoint
+func newRouter(endpoints []Endpoint) *router {
+	r := &router{
+		endpoints: endpoints,
+		healthy:   make([]bool, len(endpoints)),
+		routes:    make([]int, len(endpoints)),
+	}
+
+	for index := range endpoints {
+		r.healthy[index] = true
+		r.routes[index] = index
+	}
+
+	return r
+}
+
+// route returns the endpoint (and its index) the group is routed to
+func (r *router) route(group int) (int, Endpoint) {
+	index := r.routes[group]
+
+	return index, r.endpoints[index]
+}
+
+// failover marks the endpoint as failed, and reassigns its groups wholesale
+// to the next healthy endpoint. Returns false if there is no healthy endpoint left
+func (r *router) failover(failed int) bool {
+	r.healthy[failed] = false
+
+	replacement := -1
+
+	for offset := 1; offset < len(r.endpoints); offset++ {
+		candidate := (failed + offset) % len(r.endpoints)
+
+		if r.healthy[candidate] {
+			replacement = candidate
+
+			break
+		}
+	}
+
+	if replacement == -1 {
+		return false
+	}
+
+	for group, index := range r.routes {
+		if index == failed {
+			r.routes[group] = replacement
+		}
+	}
+
+	return true
+}
+
+// failed returns the URLs of the failed endpoints, if any
+func (r *router) failed() []string {
+	var failed []string
+
+	for index, healthy := range r.healthy {
+		if !healthy {
+			failed = append(failed, r.endpoints[index].URL)
+		}
+	}
+
+	return failed
+}
+
+// accountGroup hash-assigns the account to one of the groups
+func accountGroup(account string, groups int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(account))
+
+	return int(h.Sum32() % uint32(groups))
+}
@@ -0,0 +1,229 @@
+package batcher
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/amino"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRecordingEndpoints creates endpoints that record the received transactions.
+// The failing endpoints return an error on every batch request
+func newRecordingEndpoints(count int, failing map[int]bool) ([]Endpoint, [][][]byte) {
+	var (
+		endpoints = make([]Endpoint, count)
+		received  = make([][][]byte, count)
+	)
+
+	for i := 0; i < count; i++ {
+		index := i
+
+		endpoints[index] = Endpoint{
+			URL: fmt.Sprintf("http://node-%d:26657", index),
+			Client: &mockClient{
+				createBatchFn: func() common.Batch {
+					var batch [][]byte
+
+					return &mockBatch{
+						addTxBroadcastFn: func(tx []byte) error {
+							batch = append(batch, tx)
+
+							return nil
+						},
+						executeFn: func() ([]interface{}, error) {
+							if failing[index] {
+								return nil, errors.New("connection refused")
+							}
+
+							results := make([]interface{}, 0, len(batch))
+
+							for _, tx := range batch {
+								received[index] = append(received[index], tx)
+
+								results = append(results, &core_types.ResultBroadcastTx{
+									Hash: []byte(fmt.Sprintf("hash-%d-%d", index, len(received[index]))),
+								})
+							}
+
+							return results, nil
+						},
+					}
+				},
+			},
+		}
+	}
+
+	return endpoints, received
+}
+
+// txSigners maps the encoded transactions to their signers
+func txSigners(t *testing.T, txs []*std.Tx) map[string]string {
+	t.Helper()
+
+	signers := make(map[string]string, len(txs))
+
+	for _, tx := range txs {
+		encoded, err := amino.Marshal(tx)
+		if err != nil {
+			t.Fatalf("unable to marshal tx, %v", err)
+		}
+
+		signer, _ := txSigner(tx)
+		signers[string(encoded)] = signer
+	}
+
+	return signers
+}
+
+// endpointAccounts returns the endpoints each account's transactions were received by
+func endpointAccounts(
+	endpoints []Endpoint,
+	received [][][]byte,
+	signers map[string]string,
+) map[string]map[string]struct{} {
+	accounts := make(map[string]map[string]struct{})
+
+	for index, txs := range received {
+		for _, tx := range txs {
+			signer := signers[string(tx)]
+
+			if accounts[signer] == nil {
+				accounts[signer] = make(map[string]struct{})
+			}
+
+			accounts[signer][endpoints[index].URL] = struct{}{}
+		}
+	}
+
+	return accounts
+}
+
+func TestBatcher_Router(t *testing.T) {
+	t.Parallel()
+
+	endpoints, _ := newRecordingEndpoints(3, nil)
+
+	r := newRouter(endpoints)
+
+	// Fail the first endpoint, and make sure
+	// its group is reassigned to the next endpoint
+	assert.True(t, r.failover(0))
+
+	index, _ := r.route(0)
+	assert.Equal(t, 1, index)
+
+	// Fail the second endpoint, and make sure both
+	// groups are reassigned wholesale to the last endpoint
+	assert.True(t, r.failover(1))
+
+	for group := 0; group < 3; group++ {
+		index, _ := r.route(group)
+
+		assert.Equal(t, 2, index)
+	}
+
+	assert.Equal(t, []string{endpoints[0].URL, endpoints[1].URL}, r.failed())
+
+	// Make sure there is no failover once all endpoints fail
+	assert.False(t, r.failover(2))
+}
+
+func TestBatcher_EndpointAffinity(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs      = 90
+		numAccounts = 9
+		batchSize   = 4
+
+		txs     = generateMixedTransactions(numTxs, numAccounts)
+		signers = txSigners(t, txs)
+	)
+
+	t.Run("account affinity", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints, received := newRecordingEndpoints(3, nil)
+
+		b := NewBatcher(&mockClient{}, WithEndpoints(endpoints, AffinityAccount))
+
+		res, err := b.BatchTransactions(txs, batchSize)
+		if err != nil {
+			t.Fatalf("unable to batch transactions, %v", err)
+		}
+
+		assert.Len(t, res.TxHashes, numTxs)
+		assert.Len(t, res.Assignments, numAccounts)
+		assert.Empty(t, res.FailedEndpoints)
+
+		// Make sure each account was broadcast to a single endpoint,
+		// which matches the assignment table
+		for account, accountEndpoints := range endpointAccounts(endpoints, received, signers) {
+			if !assert.Len(t, accountEndpoints, 1) {
+				continue
+			}
+
+			assert.Contains(t, accountEndpoints, res.Assignments[account])
+		}
+	})
+
+	t.Run("wholesale failover", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints, received := newRecordingEndpoints(3, map[int]bool{0: true})
+
+		b := NewBatcher(&mockClient{}, WithEndpoints(endpoints, AffinityAccount))
+
+		res, err := b.BatchTransactions(txs, batchSize)
+		if err != nil {
+			t.Fatalf("unable to batch transactions, %v", err)
+		}
+
+		assert.Len(t, res.TxHashes, numTxs)
+		assert.Equal(t, []string{endpoints[0].URL}, res.FailedEndpoints)
+		assert.Empty(t, received[0])
+		assert.NotContains(t, res.TxEndpoints, endpoints[0].URL)
+
+		// Make sure the accounts of the failed endpoint were not split
+		for account, accountEndpoints := range endpointAccounts(endpoints, received, signers) {
+			if !assert.Len(t, accountEndpoints, 1) {
+				continue
+			}
+
+			assert.Contains(t, accountEndpoints, res.Assignments[account])
+			assert.NotEqual(t, endpoints[0].URL, res.Assignments[account])
+		}
+	})
+
+	t.Run("round-robin", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints, received := newRecordingEndpoints(3, nil)
+
+		b := NewBatcher(&mockClient{}, WithEndpoints(endpoints, AffinityRoundRobin))
+
+		res, err := b.BatchTransactions(txs, batchSize)
+		if err != nil {
+			t.Fatalf("unable to batch transactions, %v", err)
+		}
+
+		assert.Nil(t, res.Assignments)
+		assert.Len(t, res.TxEndpoints, numTxs)
+
+		accepted := make(map[string]int)
+		for _, url := range res.TxEndpoints {
+			accepted[url]++
+		}
+
+		// Make sure each tx is attributed to the endpoint that received it
+		for index, endpoint := range endpoints {
+			assert.NotEmpty(t, received[index])
+			assert.Equal(t, len(received[index]), accepted[endpoint.URL])
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package batcher
+
+import (
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+// plannedBatch is a batch of transaction indices
+// that share the same message type
+type plannedBatch struct {
+	msgType string
+	indices []int
+}
+
+// typeQueue is the ordered queue of pending
+// transactions of a single message type
+type typeQueue struct {
+	msgType string
+	indices []int // the pending tx indices, in the original order
+	total   int   // the total number of txs of this type
+	sent    int   // the number of txs of this type already planned
+}
+
+// txMsgType returns the message type of the transaction,
+// based on its first message
+func txMsgType(tx *std.Tx) string {
+	if len(tx.Msgs) == 0 {
+		return ""
+	}
+
+	return tx.Msgs[0].Type()
+}
+
+// txSigner returns the key of the transaction signer, if any
+func txSigner(tx *std.Tx) (string, bool) {
+	if len(tx.Msgs) == 0 {
+		return "", false
+	}
+
+	signers := tx.Msgs[0].GetSigners()
+	if len(signers) == 0 {
+		return "", false
+	}
+
+	return signers[0].String(), true
+}
+
+// planTypeBatches groups the transactions into batches of a single message type.
+// The type batches are interleaved so each type progresses proportionally
+// to its share of the workload, while preserving the per-account nonce order:
+// an account transaction is never planned before its preceding transactions
+func planTypeBatches(txs []*std.Tx, batchSize int) []plannedBatch {
+	var (
+		queues      = make([]*typeQueue, 0)
+		queueLookup = make(map[string]*typeQueue)
+
+		// position of each transaction in the account's transaction sequence
+		accountPos = make([]int, len(txs))
+
+		// number of planned transactions for each account
+		accountPlanned = make(map[string]int)
+	)
+
+	accountTotals := make(map[string]int)
+
+	for index, tx := range txs {
+		msgType := txMsgType(tx)
+
+		queue, ok := queueLookup[msgType]
+		if !ok {
+			queue = &typeQueue{
+				msgType: msgType,
+			}
+
+			queueLookup[msgType] = queue
+			queues = append(queues, queue)
+		}
+
+		queue.indices = append(queue.indices, index)
+		queue.total++
+
+		if signer, ok := txSigner(tx); ok {
+			accountPos[index] = accountTotals[signer]
+			accountTotals[signer]++
+		}
+	}
+
+	// isReady checks if all preceding account
+	// transactions have already been planned
+	isReady := func(index int) bool {
+		signer, ok := txSigner(txs[index])
+		if !ok {
+			return true
+		}
+
+		return accountPlanned[signer] == accountPos[index]
+	}
+
+	markPlanned := func(index int) {
+		if signer, ok := txSigner(txs[index]); ok {
+			accountPlanned[signer]++
+		}
+	}
+
+	batches := make([]plannedBatch, 0)
+
+	for {
+		// Pick the ready type queue that is the furthest behind,
+		// relative to its share of the workload
+		var next *typeQueue
+
+		for _, queue := range queues {
+			if len(queue.indices) == 0 || !isReady(queue.indices[0]) {
+				continue
+			}
+
+			if next == nil ||
+				float64(queue.sent)/float64(queue.total) < float64(next.sent)/float64(next.total) {
+				next = queue
+			}
+		}
+
+		if next == nil {
+			// All transactions are planned. The earliest pending
+			// transaction is always ready, so there is no deadlock
+			break
+		}
+
+		// Fill the batch with the ready prefix of the queue
+		batch := plannedBatch{
+			msgType: next.msgType,
+			indices: make([]int, 0, batchSize),
+		}
+
+		for len(next.indices) > 0 && len(batch.indices) < batchSize {
+			index := next.indices[0]
+			if !isReady(index) {
+				break
+			}
+
+			markPlanned(index)
+
+			batch.indices = append(batch.indices, index)
+			next.indices = next.indices[1:]
+		}
+
+		next.sent += len(batch.indices)
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
@@ -0,0 +1,96 @@
+package batcher
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateMixedTransactions generates transactions of alternating
+// message types, round-robin across the given number of accounts
+func generateMixedTransactions(count, accounts int) []*std.Tx {
+	txs := make([]*std.Tx, count)
+
+	for i := 0; i < count; i++ {
+		address := crypto.Address{byte(i % accounts)}
+
+		var msg std.Msg = bank.MsgSend{
+			FromAddress: address,
+		}
+
+		// Every third transaction is a call
+		if i%3 == 0 {
+			msg = vm.MsgCall{
+				Caller: address,
+			}
+		}
+
+		txs[i] = &std.Tx{
+			Msgs: []std.Msg{msg},
+		}
+	}
+
+	return txs
+}
+
+func TestBatcher_PlanTypeBatches(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs      = 90
+		numAccounts = 4
+		batchSize   = 5
+
+		txs  = generateMixedTransactions(numTxs, numAccounts)
+		plan = planTypeBatches(txs, batchSize)
+
+		planned    = make(map[int]struct{})
+		lastIndex  = make(map[string]int)
+		typeCounts = make(map[string]int)
+	)
+
+	for _, batch := range plan {
+		assert.LessOrEqual(t, len(batch.indices), batchSize)
+
+		for _, index := range batch.indices {
+			tx := txs[index]
+
+			// Make sure the batch is of a single message type
+			assert.Equal(t, batch.msgType, txMsgType(tx))
+
+			// Make sure the per-account order is preserved
+			signer, _ := txSigner(tx)
+
+			if last, ok := lastIndex[signer]; ok {
+				assert.Greater(t, index, last)
+			}
+
+			lastIndex[signer] = index
+
+			planned[index] = struct{}{}
+		}
+
+		typeCounts[batch.msgType] += len(batch.indices)
+	}
+
+	// Make sure each transaction is planned exactly once
+	assert.Len(t, planned, numTxs)
+	assert.Equal(t, numTxs/3, typeCounts[vm.MsgCall{}.Type()])
+	assert.Equal(t, numTxs-numTxs/3, typeCounts[bank.MsgSend{}.Type()])
+
+	// Make sure the type batches are interleaved,
+	// and not sent one type after the other
+	switches := 0
+
+	for i := 1; i < len(plan); i++ {
+		if plan[i].msgType != plan[i-1].msgType {
+			switches++
+		}
+	}
+
+	assert.Greater(t, switches, 1)
+}
@@ -1,17 +1,22 @@
 package batcher
 
 import (
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
 	"github.com/gnolang/supernova/internal/common"
 )
 
 type (
-	createBatchDelegate          func() common.Batch
-	getLatestBlockHeightDelegate func() (int64, error)
+	createBatchDelegate                 func() common.Batch
+	getLatestBlockHeightDelegate        func() (int64, error)
+	broadcastRawTransactionSyncDelegate func(tx []byte) (*core_types.ResultBroadcastTx, error)
 )
 
 type mockClient struct {
-	createBatchFn          createBatchDelegate
-	getLatestBlockHeightFn getLatestBlockHeightDelegate
+	createBatchFn                 createBatchDelegate
+	getLatestBlockHeightFn        getLatestBlockHeightDelegate
+	broadcastRawTransactionSyncFn broadcastRawTransactionSyncDelegate
 }
 
 func (m *mockClient) CreateBatch() common.Batch {
@@ -30,6 +35,14 @@ func (m *mockClient) GetLatestBlockHeight() (int64, error) {
 	return 0, nil
 }
 
+func (m *mockClient) BroadcastRawTransactionSync(tx []byte) (*core_types.ResultBroadcastTx, error) {
+	if m.broadcastRawTransactionSyncFn != nil {
+		return m.broadcastRawTransactionSyncFn(tx)
+	}
+
+	return nil, nil
+}
+
 type (
 	addTxBroadcastDelegate func(tx []byte) error
 	executeDelegate        func() ([]interface{}, error)
@@ -55,3 +68,25 @@ func (m *mockBatch) Execute() ([]interface{}, error) {
 
 	return nil, nil
 }
+
+type (
+	waitDelegate  func(txs int)
+	trackDelegate func(txs [][]byte, sent time.Time)
+)
+
+type mockPacer struct {
+	waitFn  waitDelegate
+	trackFn trackDelegate
+}
+
+func (m *mockPacer) Wait(txs int) {
+	if m.waitFn != nil {
+		m.waitFn(txs)
+	}
+}
+
+func (m *mockPacer) Track(txs [][]byte, sent time.Time) {
+	if m.trackFn != nil {
+		m.trackFn(txs, sent)
+	}
+}
@@ -0,0 +1,78 @@
+package batcher
+
+import "time"
+
+type Option func(b *Batcher)
+
+// WithTypeGrouping groups the batches by the transaction message type,
+// so a single batch never mixes transaction types. The type batches are
+// interleaved over time according to each type's share of the workload
+func WithTypeGrouping() Option {
+	return func(b *Batcher) {
+		b.groupByType = true
+	}
+}
+
+// WithForceBatch disables the fallback to single transaction
+// broadcasts when the node rejects the batch requests
+func WithForceBatch() Option {
+	return func(b *Batcher) {
+		b.forceBatch = true
+	}
+}
+
+// WithEndpoints broadcasts the transactions to the given endpoints,
+// assigned using the given affinity. Failed endpoints are failed over
+// to the remaining healthy endpoints
+func WithEndpoints(endpoints []Endpoint, affinity Affinity) Option {
+	return func(b *Batcher) {
+		b.endpoints = endpoints
+		b.affinity = affinity
+	}
+}
+
+// WithPacer paces the batch broadcasts using the given pacer,
+// instead of sending the batches as fast as possible
+func WithPacer(pacer Pacer) Option {
+	return func(b *Batcher) {
+		b.pacer = pacer
+	}
+}
+
+// WithReader schedules the reads of the given reader
+// for each batch, interleaving them with the broadcasts
+func WithReader(reader Reader) Option {
+	return func(b *Batcher) {
+		b.reader = reader
+	}
+}
+
+// WithDispatchOrder sets the order the transactions are dispatched in
+func WithDispatchOrder(order DispatchOrder) Option {
+	return func(b *Batcher) {
+		b.order = order
+	}
+}
+
+// WithProgress reports the broadcast progress after each batch
+func WithProgress(progress Progress) Option {
+	return func(b *Batcher) {
+		b.progress = progress
+	}
+}
+
+// WithFailureReport reports each failed broadcast request,
+// including the requests that are retried on a different endpoint
+func WithFailureReport(failure Failure) Option {
+	return func(b *Batcher) {
+		b.failure = failure
+	}
+}
+
+// WithDuration stops the broadcast once the given duration has
+// elapsed since the first batch, leaving the remaining batches unsent
+func WithDuration(duration time.Duration) Option {
+	return func(b *Batcher) {
+		b.duration = duration
+	}
+}
@@ -0,0 +1,122 @@
+package batcher
+
+import (
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// DispatchOrder is the order the transactions are dispatched in
+type DispatchOrder string
+
+const (
+	// DispatchInterleaved dispatches the account transactions in round-robin,
+	// so every account is active throughout the broadcast
+	DispatchInterleaved DispatchOrder = "interleaved"
+
+	// DispatchSequential dispatches all transactions of an account,
+	// before moving on to the next account
+	DispatchSequential DispatchOrder = "sequential"
+)
+
+// IsDispatchOrder checks if the dispatch order is valid
+func IsDispatchOrder(order DispatchOrder) bool {
+	return order == DispatchInterleaved || order == DispatchSequential
+}
+
+// orderTransactions orders the transactions for dispatch.
+// The per-account transaction order is always preserved,
+// so the account nonces are sent in sequence
+func orderTransactions(txs []*std.Tx, order DispatchOrder) []*std.Tx {
+	var (
+		queues = make([][]*std.Tx, 0)
+		lookup = make(map[string]int)
+	)
+
+	// Queue the transactions of each account, in the order
+	// the accounts first appear in
+	for _, tx := range txs {
+		signer, _ := txSigner(tx)
+
+		index, ok := lookup[signer]
+		if !ok {
+			index = len(queues)
+			lookup[signer] = index
+
+			queues = append(queues, nil)
+		}
+
+		queues[index] = append(queues[index], tx)
+	}
+
+	ordered := make([]*std.Tx, 0, len(txs))
+
+	if order == DispatchSequential {
+		for _, queue := range queues {
+			ordered = append(ordered, queue...)
+		}
+
+		return ordered
+	}
+
+	for round := 0; len(ordered) < len(txs); round++ {
+		for _, queue := range queues {
+			if round < len(queue) {
+				ordered = append(ordered, queue[round])
+			}
+		}
+	}
+
+	return ordered
+}
+
+// accountDispatches returns the dispatch window of each account,
+// using the sent time of the broadcast transactions
+func accountDispatches(
+	preparedTxs [][]byte,
+	txs []*std.Tx,
+	txHashes [][]byte,
+	timings []TxTiming,
+) map[string]*metrics.AccountDispatch {
+	// The broadcast results only hold the tx hashes
+	signers := make(map[string]string, len(txs))
+
+	for index, tx := range txs {
+		if signer, ok := txSigner(tx); ok {
+			signers[string(types.Tx(preparedTxs[index]).Hash())] = signer
+		}
+	}
+
+	dispatches := make(map[string]*metrics.AccountDispatch)
+
+	for index, hash := range txHashes {
+		signer, ok := signers[string(hash)]
+		if !ok {
+			continue
+		}
+
+		sent := timings[index].Sent
+
+		dispatch, ok := dispatches[signer]
+		if !ok {
+			dispatch = &metrics.AccountDispatch{
+				First: sent,
+				Last:  sent,
+			}
+
+			dispatches[signer] = dispatch
+		}
+
+		if sent.Before(dispatch.First) {
+			dispatch.First = sent
+		}
+
+		if sent.After(dispatch.Last) {
+			dispatch.Last = sent
+		}
+
+		dispatch.Txs++
+	}
+
+	return dispatches
+}
@@ -0,0 +1,108 @@
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateAccountTransactions generates the transactions of each account
+// back to back, with the account sequence in the memo
+func generateAccountTransactions(accounts, txsPerAccount int) []*std.Tx {
+	txs := make([]*std.Tx, 0, accounts*txsPerAccount)
+
+	for account := 0; account < accounts; account++ {
+		for i := 0; i < txsPerAccount; i++ {
+			txs = append(txs, &std.Tx{
+				Msgs: []std.Msg{
+					bank.MsgSend{
+						FromAddress: crypto.Address{byte(account)},
+					},
+				},
+				Memo: string(rune('0' + i)),
+			})
+		}
+	}
+
+	return txs
+}
+
+func TestBatcher_OrderTransactions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numAccounts   = 3
+		txsPerAccount = 4
+
+		txs = generateAccountTransactions(numAccounts, txsPerAccount)
+	)
+
+	t.Run("interleaved", func(t *testing.T) {
+		t.Parallel()
+
+		ordered := orderTransactions(txs, DispatchInterleaved)
+
+		assert.Len(t, ordered, len(txs))
+
+		// Make sure the accounts are dispatched in round-robin
+		for index, tx := range ordered {
+			signer, _ := txSigner(tx)
+
+			assert.Equal(t, crypto.Address{byte(index % numAccounts)}.String(), signer)
+			assert.Equal(t, string(rune('0'+index/numAccounts)), tx.Memo)
+		}
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		t.Parallel()
+
+		// Make sure the accounts are dispatched one after the other
+		ordered := orderTransactions(orderTransactions(txs, DispatchInterleaved), DispatchSequential)
+
+		assert.Equal(t, txs, ordered)
+	})
+}
+
+func TestBatcher_AccountDispatches(t *testing.T) {
+	t.Parallel()
+
+	var (
+		txs         = generateAccountTransactions(2, 2)
+		preparedTxs = make([][]byte, len(txs))
+		txHashes    = make([][]byte, len(txs))
+		timings     = make([]TxTiming, len(txs))
+
+		start = time.Now()
+	)
+
+	for index := range txs {
+		preparedTxs[index] = []byte{byte(index)}
+		txHashes[index] = types.Tx(preparedTxs[index]).Hash()
+		timings[index] = TxTiming{
+			Sent: start.Add(time.Duration(index) * time.Second),
+		}
+	}
+
+	dispatches := accountDispatches(preparedTxs, txs, txHashes, timings)
+
+	if !assert.Len(t, dispatches, 2) {
+		return
+	}
+
+	first := dispatches[crypto.Address{0}.String()]
+
+	assert.Equal(t, 2, first.Txs)
+	assert.Equal(t, start, first.First)
+	assert.Equal(t, start.Add(time.Second), first.Last)
+
+	second := dispatches[crypto.Address{1}.String()]
+
+	assert.Equal(t, 2, second.Txs)
+	assert.Equal(t, start.Add(2*time.Second), second.First)
+	assert.Equal(t, start.Add(3*time.Second), second.Last)
+}
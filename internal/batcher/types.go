@@ -1,16 +1,75 @@
 package batcher
 
 import (
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/std"
 	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/metrics"
 )
 
 type Client interface {
 	CreateBatch() common.Batch
 	GetLatestBlockHeight() (int64, error)
+	BroadcastRawTransactionSync(tx []byte) (*core_types.ResultBroadcastTx, error)
+}
+
+// Pacer paces the batch broadcasts
+type Pacer interface {
+	// Wait blocks until the given number of transactions can be sent
+	Wait(txs int)
+
+	// Track tracks the transactions, sent at the given time
+	Track(txs [][]byte, sent time.Time)
+}
+
+// Reader issues reads alongside the batch broadcasts
+type Reader interface {
+	// Read schedules the reads for the given number of broadcast transactions, without blocking
+	Read(txs int)
 }
 
+// Progress reports the total number of broadcast transactions, after each batch.
+// It is invoked on the broadcast path, so it should not block
+type Progress func(sent int)
+
+// Failure reports a failed broadcast request, before it is retried or
+// fails the run. It is invoked on the broadcast path, so it should not block
+type Failure func(err error)
+
+// Source generates and signs the next transactions of a streamed broadcast,
+// up to the given count. It is invoked sequentially, a chunk ahead of the broadcast,
+// and the broadcast ends early if it returns no transactions
+type Source func(transactions int) ([]*std.Tx, error)
+
 // TxBatchResult contains batching results
 type TxBatchResult struct {
 	TxHashes   [][]byte // the tx hashes
 	StartBlock int64    // the initial block for querying
+
+	Latencies map[string]*metrics.Distribution // the batch latency for each message type, if grouped
+	Fallback  bool                             // flag indicating if batching fell back to single broadcasts
+
+	Starvation time.Duration // the time the dispatch loop spent idle between batches, outside the pacing waits
+
+	Timings []TxTiming           // the broadcast timing of each tx, matching the tx hashes
+	Clock   *metrics.ClockAnchor // the anchor of the broadcast timestamps
+
+	Dispatches map[string]*metrics.AccountDispatch // the dispatch window of each account
+
+	Assignments     map[string]string // the endpoint URL of each account, if using account affinity
+	FailedEndpoints []string          // the endpoints that failed during the broadcast, if any
+
+	TxEndpoints []string // the endpoint URL that accepted each tx, matching the tx hashes, if using endpoints
+
+	Unsent int // the number of txs not sent before the broadcast deadline, if any
+
+	Generated []*std.Tx // the txs of the streamed broadcast chunks, including the unsent ones
+}
+
+// TxTiming is the broadcast timing of a single transaction
+type TxTiming struct {
+	Sent     time.Time // the time the broadcast request was sent
+	Accepted time.Time // the time the broadcast response was received
 }
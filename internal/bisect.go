@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/supernova/internal/bisect"
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+// bisectSampleInterval is the mempool sampling interval of the windows,
+// if the criterion needs the commit latency and the run does not sample the mempool
+const bisectSampleInterval = time.Second
+
+var errMissingBisectOutput = errors.New("output path required for the bisect results")
+
+// BisectConfig is the sustainable send rate bisect configuration
+type BisectConfig struct {
+	MinTPS        float64       // the lowest probed send rate (tx/s)
+	MaxTPS        float64       // the highest probed send rate (tx/s)
+	Criterion     string        // the comma separated pass / fail conditions of each window
+	Window        time.Duration // the length of each fixed-rate measurement window
+	Tolerance     float64       // the send rate precision (tx/s) the search stops at
+	MaxIterations uint64        // the maximum number of measurement windows
+
+	criterion bisect.Criterion // the parsed criterion
+}
+
+// Validate validates the bisect configuration
+func (cfg *BisectConfig) Validate() error {
+	criterion, err := bisect.ParseCriterion(cfg.Criterion)
+	if err != nil {
+		return err
+	}
+
+	cfg.criterion = criterion
+
+	return cfg.search().Validate()
+}
+
+// search returns the bisect search configuration
+func (cfg *BisectConfig) search() bisect.Config {
+	return bisect.Config{
+		MinTPS:        cfg.MinTPS,
+		MaxTPS:        cfg.MaxTPS,
+		Tolerance:     cfg.Tolerance,
+		MaxIterations: int(cfg.MaxIterations),
+		Window:        cfg.Window,
+	}
+}
+
+// Bisect binary-searches the highest send rate that satisfies the criterion,
+// by running fixed-rate measurement windows of the given run configuration.
+// Each window reuses the sub-accounts funded by the previous one, and is run with
+// the given executor. The bisect trace is written out to the run output path
+func Bisect(cfg *Config, bisectCfg *BisectConfig, execute func(*Config) error) error {
+	if cfg.Output == "" {
+		return errMissingBisectOutput
+	}
+
+	// The window results are only kept until they are summarized in the trace
+	dir, err := os.MkdirTemp("", "supernova-bisect")
+	if err != nil {
+		return fmt.Errorf("unable to create bisect directory, %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	var (
+		windows = 0
+		reuse   = cfg.ReuseResults
+	)
+
+	run := func(rate float64) (*collector.RunResult, error) {
+		windows++
+
+		window := bisectWindow(cfg, bisectCfg, rate, reuse)
+		window.Output = filepath.Join(dir, fmt.Sprintf("window-%d.json", windows))
+
+		if err := execute(window); err != nil {
+			return nil, err
+		}
+
+		result, err := loadRunResult(window.Output)
+		if err != nil {
+			return nil, err
+		}
+
+		// The next window is seeded from the latest sub-account states
+		reuse = window.Output
+
+		return result, nil
+	}
+
+	result, err := bisect.Search(bisectCfg.search(), bisectCfg.criterion, run)
+	if err != nil {
+		return err
+	}
+
+	displayBisect(result)
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal bisect results, %w", err)
+	}
+
+	if err := os.WriteFile(cfg.Output, data, 0o600); err != nil {
+		return fmt.Errorf("unable to write bisect results, %w", err)
+	}
+
+	fmt.Printf("\nBisect results saved at %s\n", cfg.Output)
+
+	return nil
+}
+
+// bisectWindow creates the run configuration of a single measurement window,
+// sending at the fixed rate for the window length
+func bisectWindow(cfg *Config, bisectCfg *BisectConfig, rate float64, reuse string) *Config {
+	window := *cfg
+
+	window.SendRate = rate
+	window.Transactions = durationTransactions(bisectCfg.Window, rate)
+	window.ReuseResults = reuse
+
+	// The latency conditions are evaluated on the mempool latency attribution
+	if bisectCfg.criterion.UsesLatency() && window.MempoolSampleInterval <= 0 {
+		window.MempoolSampleInterval = bisectSampleInterval
+	}
+
+	return &window
+}
+
+// displayBisect displays the bisect trace, and the sustainable send rate
+func displayBisect(result *bisect.Result) {
+	fmt.Printf("\n🔎 Bisect Results 🔎\n\n")
+	fmt.Printf("Criterion: %s\n\n", result.Criterion)
+
+	for _, step := range result.Trace {
+		verdict := "✅ pass"
+		if !step.Pass {
+			verdict = "❌ fail"
+		}
+
+		fmt.Printf("#%d %.0f tx/s %s\n", step.Iteration, step.Rate, verdict)
+	}
+
+	if !result.Found {
+		fmt.Printf("\n❌ No sustainable rate, %.0f tx/s already fails the criterion\n", result.MinTPS)
+
+		return
+	}
+
+	fmt.Printf("\n✅ Sustainable rate: %.0f tx/s\n", result.SustainableRate)
+
+	if !result.Converged {
+		fmt.Printf("⚠️ The search ran out of iterations before reaching the %.0f tx/s tolerance\n", result.Tolerance)
+	}
+}
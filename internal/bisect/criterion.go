@@ -0,0 +1,215 @@
+// Package bisect binary-searches the highest sustainable send rate,
+// for a pass / fail criterion evaluated on the run results
+package bisect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+var errInvalidCriterion = errors.New("invalid bisect criterion")
+
+const (
+	MetricFailedRatio = "failed_ratio" // the share of lost transactions
+	MetricP50Latency  = "p50_latency"  // the p50 commit latency (s)
+	MetricP95Latency  = "p95_latency"  // the p95 commit latency (s)
+	MetricP99Latency  = "p99_latency"  // the p99 commit latency (s)
+	MetricTPS         = "tps"          // the average TPS
+)
+
+// latencyMetrics are the metrics sourced from the commit latency attribution
+var latencyMetrics = map[string]bool{
+	MetricP50Latency: true,
+	MetricP95Latency: true,
+	MetricP99Latency: true,
+}
+
+// knownMetric returns a flag indicating if the metric can be evaluated
+func knownMetric(metric string) bool {
+	return metric == MetricFailedRatio || metric == MetricTPS || latencyMetrics[metric]
+}
+
+// operators are the supported condition operators.
+// The two-character operators are matched first
+var operators = []string{"<=", ">=", "<", ">"}
+
+// Condition is a single metric bound of the criterion
+type Condition struct {
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"` // latencies are in seconds
+}
+
+// holds returns a flag indicating if the value satisfies the condition
+func (c *Condition) holds(value float64) bool {
+	switch c.Operator {
+	case "<":
+		return value < c.Threshold
+	case "<=":
+		return value <= c.Threshold
+	case ">":
+		return value > c.Threshold
+	default:
+		return value >= c.Threshold
+	}
+}
+
+// String returns the condition in the criterion syntax
+func (c *Condition) String() string {
+	return fmt.Sprintf("%s%s%s", c.Metric, c.Operator, strconv.FormatFloat(c.Threshold, 'f', -1, 64))
+}
+
+// Criterion is the set of conditions a measurement window needs to satisfy to pass
+type Criterion []*Condition
+
+// ParseCriterion parses the comma separated conditions,
+// ex. "failed_ratio<0.01,p95_latency<5s"
+func ParseCriterion(raw string) (Criterion, error) {
+	criterion := make(Criterion, 0)
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		condition, err := parseCondition(part)
+		if err != nil {
+			return nil, err
+		}
+
+		criterion = append(criterion, condition)
+	}
+
+	if len(criterion) == 0 {
+		return nil, fmt.Errorf("%w: no conditions specified", errInvalidCriterion)
+	}
+
+	return criterion, nil
+}
+
+// parseCondition parses a single condition, ex. "p95_latency<5s"
+func parseCondition(raw string) (*Condition, error) {
+	for _, operator := range operators {
+		index := strings.Index(raw, operator)
+		if index < 0 {
+			continue
+		}
+
+		var (
+			metric    = strings.TrimSpace(raw[:index])
+			threshold = strings.TrimSpace(raw[index+len(operator):])
+		)
+
+		if !knownMetric(metric) {
+			return nil, fmt.Errorf("%w: unknown metric %q", errInvalidCriterion, metric)
+		}
+
+		value, err := parseThreshold(metric, threshold)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s threshold %q", errInvalidCriterion, metric, threshold)
+		}
+
+		return &Condition{
+			Metric:    metric,
+			Operator:  operator,
+			Threshold: value,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: no operator in %q", errInvalidCriterion, raw)
+}
+
+// parseThreshold parses the condition threshold.
+// Latency thresholds are durations (ex. 5s), or plain seconds
+func parseThreshold(metric, raw string) (float64, error) {
+	value, err := strconv.ParseFloat(raw, 64)
+	if err == nil || !latencyMetrics[metric] {
+		return value, err
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	return duration.Seconds(), nil
+}
+
+// UsesLatency returns a flag indicating if the criterion
+// needs the commit latency attribution (mempool sampling)
+func (c Criterion) UsesLatency() bool {
+	for _, condition := range c {
+		if latencyMetrics[condition.Metric] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the criterion in its parsed syntax
+func (c Criterion) String() string {
+	conditions := make([]string, 0, len(c))
+
+	for _, condition := range c {
+		conditions = append(conditions, condition.String())
+	}
+
+	return strings.Join(conditions, ",")
+}
+
+// Evaluate evaluates the criterion on the window metrics,
+// and returns the violated conditions, if any.
+// Metrics missing from the window violate their conditions
+func (c Criterion) Evaluate(metrics Metrics) []string {
+	violations := make([]string, 0)
+
+	for _, condition := range c {
+		value, ok := metrics[condition.Metric]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("%s unavailable", condition.Metric))
+
+			continue
+		}
+
+		if !condition.holds(value) {
+			violations = append(
+				violations,
+				fmt.Sprintf("%s=%s", condition.Metric, strconv.FormatFloat(value, 'f', -1, 64)),
+			)
+		}
+	}
+
+	return violations
+}
+
+// Metrics are the criterion metrics of a single measurement window
+type Metrics map[string]float64
+
+// NewMetrics extracts the criterion metrics from the run results.
+// The latencies are only available if the mempool was sampled
+func NewMetrics(result *collector.RunResult) Metrics {
+	metrics := Metrics{
+		MetricTPS: float64(result.AverageTPS),
+	}
+
+	if total := result.CommittedTxs + result.LostTxs; total > 0 {
+		metrics[MetricFailedRatio] = float64(result.LostTxs) / float64(total)
+	}
+
+	if result.Latency != nil && result.Latency.Commit != nil {
+		commit := result.Latency.Commit
+
+		metrics[MetricP50Latency] = commit.P50.Seconds()
+		metrics[MetricP95Latency] = commit.P95.Seconds()
+		metrics[MetricP99Latency] = commit.P99.Seconds()
+	}
+
+	return metrics
+}
@@ -0,0 +1,109 @@
+package bisect
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCriterion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid criterion", func(t *testing.T) {
+		t.Parallel()
+
+		criterion, err := ParseCriterion("failed_ratio<0.01, p95_latency<=5s,tps>=100,p99_latency>2500ms")
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			Criterion{
+				{Metric: MetricFailedRatio, Operator: "<", Threshold: 0.01},
+				{Metric: MetricP95Latency, Operator: "<=", Threshold: 5},
+				{Metric: MetricTPS, Operator: ">=", Threshold: 100},
+				{Metric: MetricP99Latency, Operator: ">", Threshold: 2.5},
+			},
+			criterion,
+		)
+
+		assert.True(t, criterion.UsesLatency())
+		assert.Equal(t, "failed_ratio<0.01,p95_latency<=5,tps>=100,p99_latency>2.5", criterion.String())
+	})
+
+	t.Run("invalid criterion", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name string
+			raw  string
+		}{
+			{"empty", " , "},
+			{"unknown metric", "gas_used<100"},
+			{"missing operator", "tps100"},
+			{"invalid threshold", "failed_ratio<5s"},
+			{"invalid latency", "p95_latency<fast"},
+		}
+
+		for _, testCase := range testTable {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := ParseCriterion(testCase.raw)
+				assert.True(t, errors.Is(err, errInvalidCriterion))
+			})
+		}
+	})
+}
+
+func TestCriterion_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	criterion, err := ParseCriterion("failed_ratio<0.01,p95_latency<5s")
+	require.NoError(t, err)
+
+	t.Run("passing window", func(t *testing.T) {
+		t.Parallel()
+
+		result := &collector.RunResult{
+			AverageTPS:   100,
+			CommittedTxs: 1000,
+			LostTxs:      5,
+			Latency: &metrics.LatencyAttribution{
+				Commit: &metrics.Distribution{
+					P50: time.Second,
+					P95: 3 * time.Second,
+					P99: 4 * time.Second,
+				},
+			},
+		}
+
+		windowMetrics := NewMetrics(result)
+
+		assert.Equal(t, 3.0, windowMetrics[MetricP95Latency])
+		assert.Empty(t, criterion.Evaluate(windowMetrics))
+	})
+
+	t.Run("failing window", func(t *testing.T) {
+		t.Parallel()
+
+		result := &collector.RunResult{
+			AverageTPS:   80,
+			CommittedTxs: 900,
+			LostTxs:      100,
+		}
+
+		// The latency is unavailable without the mempool sampling
+		assert.Equal(
+			t,
+			[]string{"failed_ratio=0.1", "p95_latency unavailable"},
+			criterion.Evaluate(NewMetrics(result)),
+		)
+	})
+}
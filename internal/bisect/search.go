@@ -0,0 +1,189 @@
+package bisect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+var errInvalidSearch = errors.New("invalid bisect search parameters specified")
+
+// Config is the bisect search configuration
+type Config struct {
+	MinTPS        float64       // the lowest probed send rate (tx/s)
+	MaxTPS        float64       // the highest probed send rate (tx/s)
+	Tolerance     float64       // the send rate precision (tx/s) the search stops at
+	MaxIterations int           // the maximum number of measurement windows
+	Window        time.Duration // the length of each measurement window
+}
+
+// Validate validates the bisect search configuration
+func (c Config) Validate() error {
+	if c.MinTPS <= 0 || c.MaxTPS <= c.MinTPS {
+		return fmt.Errorf("%w: the rates need to satisfy 0 < min < max", errInvalidSearch)
+	}
+
+	if c.Tolerance <= 0 {
+		return fmt.Errorf("%w: the tolerance needs to be positive", errInvalidSearch)
+	}
+
+	// The bounds are always probed
+	if c.MaxIterations < 2 {
+		return fmt.Errorf("%w: at least 2 iterations are required", errInvalidSearch)
+	}
+
+	if c.Window <= 0 {
+		return fmt.Errorf("%w: the window needs to be positive", errInvalidSearch)
+	}
+
+	return nil
+}
+
+// Runner runs a single measurement window at the given send rate (tx/s)
+type Runner func(rate float64) (*collector.RunResult, error)
+
+// Step is a single measurement window of the search
+type Step struct {
+	Iteration  int      `json:"iteration"`
+	Rate       float64  `json:"rate"`
+	Pass       bool     `json:"pass"`
+	Metrics    Metrics  `json:"metrics,omitempty"`
+	Violations []string `json:"violations,omitempty"`
+	RunID      string   `json:"runId,omitempty"`
+	Error      string   `json:"error,omitempty"` // the run error, counted as a failed window
+}
+
+// Result is the bisect search outcome, with the full trace of the measurement windows
+type Result struct {
+	Criterion     string        `json:"criterion"`
+	Conditions    Criterion     `json:"conditions"`
+	MinTPS        float64       `json:"minTPS"`
+	MaxTPS        float64       `json:"maxTPS"`
+	Tolerance     float64       `json:"tolerance"`
+	MaxIterations int           `json:"maxIterations"`
+	Window        time.Duration `json:"window"`
+
+	Trace []*Step `json:"trace"`
+
+	// Found is set if at least the lowest rate satisfied the criterion
+	Found bool `json:"found"`
+
+	// Converged is set if the search narrowed down to the tolerance,
+	// before running out of iterations
+	Converged bool `json:"converged"`
+
+	// SustainableRate is the highest probed rate that satisfied the criterion
+	SustainableRate float64 `json:"sustainableRate"`
+}
+
+// Search binary-searches the highest send rate that satisfies the criterion.
+// The bounds are probed first: a failing lowest rate has no sustainable rate,
+// and a passing highest rate is sustainable as is
+func Search(cfg Config, criterion Criterion, run Runner) (*Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Criterion:     criterion.String(),
+		Conditions:    criterion,
+		MinTPS:        cfg.MinTPS,
+		MaxTPS:        cfg.MaxTPS,
+		Tolerance:     cfg.Tolerance,
+		MaxIterations: cfg.MaxIterations,
+		Window:        cfg.Window,
+		Trace:         make([]*Step, 0, cfg.MaxIterations),
+	}
+
+	// The lowest rate is the baseline of the search,
+	// so its run errors are setup errors, and not findings
+	lowest, err := probe(result, criterion, run, cfg.MinTPS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run the lowest rate window, %w", err)
+	}
+
+	if !lowest.Pass {
+		return result, nil
+	}
+
+	result.Found = true
+	result.SustainableRate = cfg.MinTPS
+
+	highest, _ := probe(result, criterion, run, cfg.MaxTPS)
+	if highest.Pass {
+		result.Converged = true
+		result.SustainableRate = cfg.MaxTPS
+
+		return result, nil
+	}
+
+	var (
+		low  = cfg.MinTPS
+		high = cfg.MaxTPS
+	)
+
+	for high-low > cfg.Tolerance && len(result.Trace) < cfg.MaxIterations {
+		// The probed rates are whole tx/s
+		mid := math.Round((low + high) / 2)
+		if mid <= low || mid >= high {
+			break
+		}
+
+		step, _ := probe(result, criterion, run, mid)
+		if step.Pass {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	// Whole tx/s rates can't be narrowed down past 1 tx/s
+	result.SustainableRate = low
+	result.Converged = high-low <= cfg.Tolerance || high-low <= 1
+
+	return result, nil
+}
+
+// probe runs and evaluates a single measurement window, and appends it to the trace.
+// Run errors fail the window, and are returned alongside it
+func probe(result *Result, criterion Criterion, run Runner, rate float64) (*Step, error) {
+	step := &Step{
+		Iteration: len(result.Trace) + 1,
+		Rate:      rate,
+	}
+
+	result.Trace = append(result.Trace, step)
+
+	fmt.Printf("\n🔎 Bisect window %d at %.0f tx/s 🔎\n", step.Iteration, rate)
+
+	runResult, err := run(rate)
+	if err != nil {
+		step.Error = err.Error()
+
+		fmt.Printf("\n❌ Window %d at %.0f tx/s failed, %v\n", step.Iteration, rate, err)
+
+		return step, err
+	}
+
+	step.RunID = runResult.RunID
+	step.Metrics = NewMetrics(runResult)
+	step.Violations = criterion.Evaluate(step.Metrics)
+	step.Pass = len(step.Violations) == 0
+
+	if step.Pass {
+		fmt.Printf("\n✅ Window %d at %.0f tx/s passed\n", step.Iteration, rate)
+	} else {
+		fmt.Printf(
+			"\n❌ Window %d at %.0f tx/s failed (%s)\n",
+			step.Iteration,
+			rate,
+			strings.Join(step.Violations, ", "),
+		)
+	}
+
+	return step, nil
+}
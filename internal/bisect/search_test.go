@@ -0,0 +1,154 @@
+package bisect
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRunner creates a runner that loses transactions above the given rate
+func newTestRunner(sustainable float64, rates *[]float64) Runner {
+	return func(rate float64) (*collector.RunResult, error) {
+		*rates = append(*rates, rate)
+
+		result := &collector.RunResult{
+			AverageTPS:   int(rate),
+			CommittedTxs: 100,
+		}
+
+		if rate > sustainable {
+			result.LostTxs = 10
+		}
+
+		return result, nil
+	}
+}
+
+// newTestSearchConfig creates a search config for the 100-2000 tx/s range
+func newTestSearchConfig() Config {
+	return Config{
+		MinTPS:        100,
+		MaxTPS:        2000,
+		Tolerance:     50,
+		MaxIterations: 20,
+		Window:        10 * time.Second,
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	criterion, err := ParseCriterion("failed_ratio<0.01")
+	require.NoError(t, err)
+
+	t.Run("sustainable rate in range", func(t *testing.T) {
+		t.Parallel()
+
+		rates := make([]float64, 0)
+
+		result, err := Search(newTestSearchConfig(), criterion, newTestRunner(700, &rates))
+		require.NoError(t, err)
+
+		assert.Equal(t, []float64{100, 2000, 1050, 575, 813, 694, 754, 724}, rates)
+		assert.True(t, result.Found)
+		assert.True(t, result.Converged)
+		assert.Equal(t, 694.0, result.SustainableRate)
+
+		require.Len(t, result.Trace, len(rates))
+		assert.True(t, result.Trace[0].Pass)
+		assert.False(t, result.Trace[1].Pass)
+		assert.Equal(t, []string{"failed_ratio=0.09090909090909091"}, result.Trace[1].Violations)
+	})
+
+	t.Run("highest rate sustainable", func(t *testing.T) {
+		t.Parallel()
+
+		rates := make([]float64, 0)
+
+		result, err := Search(newTestSearchConfig(), criterion, newTestRunner(5000, &rates))
+		require.NoError(t, err)
+
+		assert.Equal(t, []float64{100, 2000}, rates)
+		assert.True(t, result.Found)
+		assert.Equal(t, 2000.0, result.SustainableRate)
+	})
+
+	t.Run("lowest rate unsustainable", func(t *testing.T) {
+		t.Parallel()
+
+		rates := make([]float64, 0)
+
+		result, err := Search(newTestSearchConfig(), criterion, newTestRunner(50, &rates))
+		require.NoError(t, err)
+
+		assert.Equal(t, []float64{100}, rates)
+		assert.False(t, result.Found)
+		assert.Zero(t, result.SustainableRate)
+	})
+
+	t.Run("iterations exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestSearchConfig()
+		cfg.MaxIterations = 4
+
+		rates := make([]float64, 0)
+
+		result, err := Search(cfg, criterion, newTestRunner(700, &rates))
+		require.NoError(t, err)
+
+		assert.Len(t, rates, 4)
+		assert.False(t, result.Converged)
+		assert.Equal(t, 575.0, result.SustainableRate)
+	})
+
+	t.Run("failed window run", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			runErr = errors.New("collector timed out")
+			rates  = make([]float64, 0)
+			runner = newTestRunner(5000, &rates)
+		)
+
+		result, err := Search(newTestSearchConfig(), criterion, func(rate float64) (*collector.RunResult, error) {
+			if rate > 1000 {
+				return nil, runErr
+			}
+
+			return runner(rate)
+		})
+		require.NoError(t, err)
+
+		// Window run errors above the lowest rate fail the window
+		assert.False(t, result.Trace[1].Pass)
+		assert.Equal(t, runErr.Error(), result.Trace[1].Error)
+		assert.Equal(t, 991.0, result.SustainableRate)
+	})
+
+	t.Run("failed lowest rate run", func(t *testing.T) {
+		t.Parallel()
+
+		runErr := errors.New("unable to fund accounts")
+
+		_, err := Search(newTestSearchConfig(), criterion, func(_ float64) (*collector.RunResult, error) {
+			return nil, runErr
+		})
+
+		assert.True(t, errors.Is(err, runErr))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestSearchConfig()
+		cfg.MaxTPS = cfg.MinTPS
+
+		_, err := Search(cfg, criterion, nil)
+		assert.True(t, errors.Is(err, errInvalidSearch))
+	})
+}
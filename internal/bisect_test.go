@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/bisect"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBisect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		output = filepath.Join(t.TempDir(), "bisect.json")
+		cfg    = &Config{
+			Output:       output,
+			Transactions: 100,
+			ReuseResults: "previous.json",
+		}
+		bisectCfg = &BisectConfig{
+			MinTPS:        100,
+			MaxTPS:        400,
+			Criterion:     "p95_latency<5s",
+			Window:        10 * time.Second,
+			Tolerance:     50,
+			MaxIterations: 10,
+		}
+
+		windows = make([]*Config, 0)
+	)
+
+	require.NoError(t, bisectCfg.Validate())
+
+	// The commit latency grows with the send rate, past 250 tx/s
+	execute := func(window *Config) error {
+		windows = append(windows, window)
+
+		latency := time.Second
+		if window.SendRate > 250 {
+			latency = 10 * time.Second
+		}
+
+		data, err := json.Marshal(&collector.RunResult{
+			RunID:        window.Output,
+			CommittedTxs: int(window.Transactions),
+			Latency: &metrics.LatencyAttribution{
+				Commit: &metrics.Distribution{P95: latency},
+			},
+		})
+		require.NoError(t, err)
+
+		return os.WriteFile(window.Output, data, 0o600)
+	}
+
+	require.NoError(t, Bisect(cfg, bisectCfg, execute))
+
+	// Each window sends at its rate for the window length,
+	// and reuses the sub-accounts of the previous one
+	require.Len(t, windows, 5)
+	assert.Equal(t, 100.0, windows[0].SendRate)
+	assert.Equal(t, uint64(1000), windows[0].Transactions)
+	assert.Equal(t, "previous.json", windows[0].ReuseResults)
+	assert.Equal(t, bisectSampleInterval, windows[0].MempoolSampleInterval)
+
+	for index := 1; index < len(windows); index++ {
+		assert.Equal(t, windows[index-1].Output, windows[index].ReuseResults)
+	}
+
+	// The trace is written out as the bisect results
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	var result bisect.Result
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.True(t, result.Found)
+	assert.Equal(t, 250.0, result.SustainableRate)
+	assert.Len(t, result.Trace, 5)
+}
@@ -0,0 +1,358 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/pkgs/amino"
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/state"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+var errUnsupportedPayload = errors.New("unsupported node API payload")
+
+// The node API payload shapes. The node version string is not a reliable
+// indicator (nodes months apart report the same one), so the payload
+// shape is detected from the response itself
+const (
+	// shapeAmino is the payload shape of the pinned gno nodes:
+	// the block carries its block meta, and the ABCI responses use the Go field names
+	shapeAmino = "amino"
+
+	// shapeSnakeCase is the block results shape of the newer gno nodes,
+	// where the ABCI responses use snake cased field names
+	shapeSnakeCase = "snake-case"
+
+	// shapeBlockID is the block shape of the nodes that replaced
+	// the block meta with the block ID
+	shapeBlockID = "block-id"
+)
+
+// fields is a decoded JSON object, with the raw field values
+type fields map[string]json.RawMessage
+
+// has returns true if the object has all the given fields
+func (f fields) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := f[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// blockDecoder decodes the block payload of a single node API shape
+type blockDecoder struct {
+	shape  string
+	detect func(result fields) bool
+	decode func(raw json.RawMessage) (*core_types.ResultBlock, error)
+}
+
+// blockResultsDecoder decodes the block results payload of a single node API shape.
+// The shape is detected from a single ABCI response of the results
+type blockResultsDecoder struct {
+	shape  string
+	detect func(response fields) bool
+	decode func(raw json.RawMessage) (*core_types.ResultBlockResults, error)
+}
+
+var (
+	blockDecoders = []blockDecoder{
+		{
+			shape: shapeAmino,
+			detect: func(result fields) bool {
+				return result.has("block_meta", "block")
+			},
+			decode: decodeAminoBlock,
+		},
+		{
+			shape: shapeBlockID,
+			detect: func(result fields) bool {
+				return result.has("block_id", "block")
+			},
+			decode: decodeBlockIDBlock,
+		},
+	}
+
+	blockResultsDecoders = []blockResultsDecoder{
+		{
+			shape: shapeAmino,
+			detect: func(response fields) bool {
+				base, ok := responseBase(response)
+
+				return ok && base.has("Error", "Log") && !response.has("gas_used")
+			},
+			decode: decodeAminoBlockResults,
+		},
+		{
+			shape: shapeSnakeCase,
+			detect: func(response fields) bool {
+				base, ok := responseBase(response)
+
+				return response.has("gas_used") || (ok && base.has("error", "log"))
+			},
+			decode: decodeSnakeCaseBlockResults,
+		},
+	}
+)
+
+// decodeBlock decodes the block payload, using the decoder of its shape.
+// The node version is only used for reporting unsupported payloads
+func decodeBlock(raw json.RawMessage, nodeVersion func() string) (*core_types.ResultBlock, error) {
+	var result fields
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal block, %w", err)
+	}
+
+	shapes := make([]string, 0, len(blockDecoders))
+
+	for _, decoder := range blockDecoders {
+		if decoder.detect(result) {
+			return decoder.decode(raw)
+		}
+
+		shapes = append(shapes, decoder.shape)
+	}
+
+	return nil, unsupportedPayload("block", nodeVersion(), shapes)
+}
+
+// decodeBlockResults decodes the block results payload, using the decoder of its shape.
+// The node version is only used for reporting unsupported payloads
+func decodeBlockResults(raw json.RawMessage, nodeVersion func() string) (*core_types.ResultBlockResults, error) {
+	var result struct {
+		Results struct {
+			DeliverTxs []fields `json:"deliver_tx"`
+			BeginBlock fields   `json:"begin_block"`
+		} `json:"results"`
+	}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal block results, %w", err)
+	}
+
+	// Empty blocks have no tx responses, but always have the begin block response
+	response := result.Results.BeginBlock
+	if len(result.Results.DeliverTxs) > 0 {
+		response = result.Results.DeliverTxs[0]
+	}
+
+	shapes := make([]string, 0, len(blockResultsDecoders))
+
+	for _, decoder := range blockResultsDecoders {
+		if decoder.detect(response) {
+			return decoder.decode(raw)
+		}
+
+		shapes = append(shapes, decoder.shape)
+	}
+
+	return nil, unsupportedPayload("block results", nodeVersion(), shapes)
+}
+
+// unsupportedPayload returns the error for a payload no decoder recognizes
+func unsupportedPayload(payload, nodeVersion string, shapes []string) error {
+	return fmt.Errorf(
+		"%w: %s of node version %s, supported payload shapes: %s",
+		errUnsupportedPayload,
+		payload,
+		nodeVersion,
+		strings.Join(shapes, ", "),
+	)
+}
+
+// responseBaseField returns the raw base of the ABCI response, if any
+func responseBaseField(response fields) (json.RawMessage, bool) {
+	for _, name := range []string{"ResponseBase", "response_base"} {
+		if raw, ok := response[name]; ok {
+			return raw, true
+		}
+	}
+
+	return nil, false
+}
+
+// responseBase returns the decoded base of the ABCI response, if any
+func responseBase(response fields) (fields, bool) {
+	raw, ok := responseBaseField(response)
+	if !ok {
+		return nil, false
+	}
+
+	var base fields
+	if err := json.Unmarshal(raw, &base); err != nil {
+		return nil, false
+	}
+
+	return base, true
+}
+
+func decodeAminoBlock(raw json.RawMessage) (*core_types.ResultBlock, error) {
+	var block core_types.ResultBlock
+	if err := amino.UnmarshalJSON(raw, &block); err != nil {
+		return nil, fmt.Errorf("unable to decode %s block, %w", shapeAmino, err)
+	}
+
+	return &block, nil
+}
+
+func decodeBlockIDBlock(raw json.RawMessage) (*core_types.ResultBlock, error) {
+	var result struct {
+		BlockID json.RawMessage `json:"block_id"`
+		Block   json.RawMessage `json:"block"`
+	}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unable to decode %s block, %w", shapeBlockID, err)
+	}
+
+	var (
+		blockID types.BlockID
+		block   types.Block
+	)
+
+	if err := amino.UnmarshalJSON(result.BlockID, &blockID); err != nil {
+		return nil, fmt.Errorf("unable to decode %s block ID, %w", shapeBlockID, err)
+	}
+
+	if err := amino.UnmarshalJSON(result.Block, &block); err != nil {
+		return nil, fmt.Errorf("unable to decode %s block, %w", shapeBlockID, err)
+	}
+
+	// The block meta is derived from the block itself
+	return &core_types.ResultBlock{
+		BlockMeta: &types.BlockMeta{
+			BlockID: blockID,
+			Header:  block.Header,
+		},
+		Block: &block,
+	}, nil
+}
+
+func decodeAminoBlockResults(raw json.RawMessage) (*core_types.ResultBlockResults, error) {
+	var results core_types.ResultBlockResults
+	if err := amino.UnmarshalJSON(raw, &results); err != nil {
+		return nil, fmt.Errorf("unable to decode %s block results, %w", shapeAmino, err)
+	}
+
+	return &results, nil
+}
+
+// snakeCaseResponse is a single snake cased ABCI tx response.
+// The response base is decoded separately, since its field name varies
+type snakeCaseResponse struct {
+	GasWanted jsonInt64 `json:"gas_wanted"`
+	GasUsed   jsonInt64 `json:"gas_used"`
+}
+
+// snakeCaseBase is the snake cased ABCI response base
+type snakeCaseBase struct {
+	Error json.RawMessage `json:"error"`
+	Data  []byte          `json:"data"`
+	Log   string          `json:"log"`
+	Info  string          `json:"info"`
+}
+
+func decodeSnakeCaseBlockResults(raw json.RawMessage) (*core_types.ResultBlockResults, error) {
+	var result struct {
+		Height  jsonInt64 `json:"height"`
+		Results struct {
+			DeliverTxs []json.RawMessage `json:"deliver_tx"`
+		} `json:"results"`
+	}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unable to decode %s block results, %w", shapeSnakeCase, err)
+	}
+
+	// Only the tx responses are normalized, the block responses are not used
+	deliverTxs := make([]abci.ResponseDeliverTx, 0, len(result.Results.DeliverTxs))
+
+	for _, rawResponse := range result.Results.DeliverTxs {
+		var (
+			response     snakeCaseResponse
+			base         snakeCaseBase
+			responseKeys fields
+		)
+
+		if err := json.Unmarshal(rawResponse, &response); err != nil {
+			return nil, fmt.Errorf("unable to decode %s tx response, %w", shapeSnakeCase, err)
+		}
+
+		if err := json.Unmarshal(rawResponse, &responseKeys); err != nil {
+			return nil, fmt.Errorf("unable to decode %s tx response, %w", shapeSnakeCase, err)
+		}
+
+		if rawBase, ok := responseBaseField(responseKeys); ok {
+			if err := json.Unmarshal(rawBase, &base); err != nil {
+				return nil, fmt.Errorf("unable to decode %s tx response base, %w", shapeSnakeCase, err)
+			}
+		}
+
+		deliverTx := abci.ResponseDeliverTx{
+			GasWanted: int64(response.GasWanted),
+			GasUsed:   int64(response.GasUsed),
+		}
+
+		deliverTx.Data = base.Data
+		deliverTx.Log = base.Log
+		deliverTx.Info = base.Info
+		deliverTx.Error = decodeABCIError(base.Error)
+
+		deliverTxs = append(deliverTxs, deliverTx)
+	}
+
+	return &core_types.ResultBlockResults{
+		Height: int64(result.Height),
+		Results: &state.ABCIResponses{
+			DeliverTxs: deliverTxs,
+		},
+	}, nil
+}
+
+// decodeABCIError decodes the ABCI response error, if any.
+// Errors of types unknown to the pinned node are kept as their raw JSON
+func decodeABCIError(raw json.RawMessage) abci.Error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	var typed struct {
+		Type string `json:"@type"`
+	}
+
+	// Only the registered std errors are handed to amino,
+	// which does not recover from unregistered type URLs
+	if err := json.Unmarshal(raw, &typed); err != nil ||
+		!std.Package.HasFullName(strings.TrimPrefix(typed.Type, "/")) {
+		return abci.StringError(raw)
+	}
+
+	var decoded abci.Error
+	if err := amino.UnmarshalJSON(raw, &decoded); err == nil && decoded != nil {
+		return decoded
+	}
+
+	return abci.StringError(raw)
+}
+
+// jsonInt64 is an integer encoded either as a JSON number, or as a string
+type jsonInt64 int64
+
+func (i *jsonInt64) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s, %w", data, err)
+	}
+
+	*i = jsonInt64(value)
+
+	return nil
+}
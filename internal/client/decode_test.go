@@ -0,0 +1,202 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rpctypes "github.com/gnolang/gno/pkgs/bft/rpc/lib/types"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+)
+
+// The golden fixtures of each supported node API payload shape.
+// The amino fixtures were captured from the pinned gno node
+const (
+	fixtureHeight  = 10
+	fixtureNumTxs  = 4
+	fixtureGasUsed = 274358
+	fixtureTime    = "2026-10-14T15:34:48.000264498Z"
+)
+
+// loadFixture loads the raw result of the golden JSON-RPC response
+func loadFixture(t *testing.T, shape, name string) json.RawMessage {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", shape, name))
+	if err != nil {
+		t.Fatalf("unable to read fixture, %v", err)
+	}
+
+	var response rpctypes.RPCResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("unable to unmarshal fixture, %v", err)
+	}
+
+	return response.Result
+}
+
+// testNodeVersion is the node version reported for unsupported payloads
+func testNodeVersion() string {
+	return "v1.2.3"
+}
+
+func TestDecode_Block(t *testing.T) {
+	t.Parallel()
+
+	expectedTime, err := time.Parse(time.RFC3339Nano, fixtureTime)
+	if err != nil {
+		t.Fatalf("unable to parse time, %v", err)
+	}
+
+	for _, shape := range []string{shapeAmino, shapeBlockID} {
+		shape := shape
+
+		t.Run(shape, func(t *testing.T) {
+			t.Parallel()
+
+			block, err := decodeBlock(loadFixture(t, shape, "block.json"), testNodeVersion)
+			if err != nil {
+				t.Fatalf("unable to decode block, %v", err)
+			}
+
+			assert.Equal(t, int64(fixtureHeight), block.Block.Height)
+			assert.Len(t, block.Block.Txs, fixtureNumTxs)
+			assert.True(t, expectedTime.Equal(block.Block.Time))
+
+			// The block meta is normalized for all shapes
+			assert.Equal(t, int64(fixtureHeight), block.BlockMeta.Header.Height)
+			assert.NotEmpty(t, block.BlockMeta.BlockID.Hash)
+		})
+	}
+
+	t.Run("unsupported shape", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := decodeBlock(json.RawMessage(`{"header":{},"txs":[]}`), testNodeVersion)
+
+		assert.ErrorIs(t, err, errUnsupportedPayload)
+		assert.ErrorContains(t, err, "node version v1.2.3")
+		assert.ErrorContains(t, err, "amino, block-id")
+	})
+}
+
+func TestDecode_BlockResults(t *testing.T) {
+	t.Parallel()
+
+	for _, shape := range []string{shapeAmino, shapeSnakeCase} {
+		shape := shape
+
+		t.Run(shape, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := decodeBlockResults(loadFixture(t, shape, "block_results.json"), testNodeVersion)
+			if err != nil {
+				t.Fatalf("unable to decode block results, %v", err)
+			}
+
+			assert.Equal(t, int64(fixtureHeight), results.Height)
+
+			if len(results.Results.DeliverTxs) != fixtureNumTxs {
+				t.Fatalf("invalid number of tx responses, %d", len(results.Results.DeliverTxs))
+			}
+
+			gasUsed := int64(0)
+
+			for _, deliverTx := range results.Results.DeliverTxs {
+				assert.Equal(t, int64(100000), deliverTx.GasWanted)
+
+				gasUsed += deliverTx.GasUsed
+			}
+
+			// The gas is never silently read as zero
+			assert.Equal(t, int64(fixtureGasUsed), gasUsed)
+		})
+	}
+
+	t.Run("snake-case errors", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := decodeBlockResults(loadFixture(t, shapeSnakeCase, "block_results.json"), testNodeVersion)
+		if err != nil {
+			t.Fatalf("unable to decode block results, %v", err)
+		}
+
+		assert.Nil(t, results.Results.DeliverTxs[0].Error)
+		assert.IsType(t, std.OutOfGasError{}, results.Results.DeliverTxs[3].Error)
+
+		// Errors of unknown types are kept
+		assert.NotNil(t, decodeABCIError(json.RawMessage(`{"@type":"/std.NewError","value":{}}`)))
+	})
+
+	t.Run("empty block", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := decodeBlockResults(
+			json.RawMessage(`{"height":"4","results":{"deliver_tx":null,"begin_block":{"ResponseBase":{"Error":null,"Log":""}}}}`),
+			testNodeVersion,
+		)
+		if err != nil {
+			t.Fatalf("unable to decode block results, %v", err)
+		}
+
+		assert.Equal(t, int64(4), results.Height)
+		assert.Empty(t, results.Results.DeliverTxs)
+	})
+
+	t.Run("unsupported shape", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := decodeBlockResults(
+			json.RawMessage(`{"height":"4","results":{"deliver_tx":[{"gasUsed":"5"}]}}`),
+			testNodeVersion,
+		)
+
+		assert.ErrorIs(t, err, errUnsupportedPayload)
+		assert.ErrorContains(t, err, "node version v1.2.3")
+		assert.ErrorContains(t, err, "amino, snake-case")
+	})
+}
+
+func TestHTTPClient_GetBlockGasUsed(t *testing.T) {
+	t.Parallel()
+
+	for _, shape := range []string{shapeAmino, shapeSnakeCase} {
+		shape := shape
+
+		t.Run(shape, func(t *testing.T) {
+			t.Parallel()
+
+			fixture, err := os.ReadFile(filepath.Join("testdata", shape, "block_results.json"))
+			if err != nil {
+				t.Fatalf("unable to read fixture, %v", err)
+			}
+
+			node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request rpctypes.RPCRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != "block_results" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				_, _ = w.Write(fixture)
+			}))
+			defer node.Close()
+
+			cli := NewHTTPClient(node.URL, 0)
+			defer cli.Close()
+
+			gasUsed, err := cli.GetBlockGasUsed(fixtureHeight)
+			if err != nil {
+				t.Fatalf("unable to fetch block gas used, %v", err)
+			}
+
+			assert.Equal(t, int64(fixtureGasUsed), gasUsed)
+		})
+	}
+}
@@ -1,14 +1,31 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
 
 	"github.com/gnolang/gno/gnoland"
 	"github.com/gnolang/gno/pkgs/amino"
 	"github.com/gnolang/gno/pkgs/bft/rpc/client"
 	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	rpcclient "github.com/gnolang/gno/pkgs/bft/rpc/lib/client"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/sdk"
 	"github.com/gnolang/gno/pkgs/std"
 	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+var (
+	// batchRejectedRegex matches the batch request errors caused by the
+	// node or proxy not accepting JSON-RPC batch arrays (client error status,
+	// or a response that is not a batch response array)
+	batchRejectedRegex = regexp.MustCompile(`returned 4\d\d|error unmarshalling rpc response`)
+
+	errTxUnsupported = errors.New("node connection does not serve the tx endpoint")
 )
 
 type Batch struct {
@@ -24,22 +41,169 @@ func (b *Batch) AddTxBroadcast(tx []byte) error {
 }
 
 func (b *Batch) Execute() ([]interface{}, error) {
-	return b.batch.Send()
+	results, err := b.batch.Send()
+	if err != nil && batchRejectedRegex.MatchString(err.Error()) {
+		return nil, fmt.Errorf("%w, %v", common.ErrBatchRejected, err)
+	}
+
+	return results, err
 }
 
 type HTTPClient struct {
-	conn *client.HTTP
+	conn   client.Client
+	raw    *rawClient                // the raw block payload client, nil for in-process nodes
+	tracer *metrics.TracingTransport // the request tracer, nil for in-process nodes
+
+	versionOnce sync.Once
+	version     string // the node version, for reporting unsupported payloads
+}
+
+// NewHTTPClient creates a new instance of the HTTP client.
+// The idle connection pool is sized to fit at least maxIdleConns
+// connections, so pre-warmed connections are not dropped before use
+func NewHTTPClient(url string, maxIdleConns int) *HTTPClient {
+	return NewPeerHTTPClient(url, maxIdleConns, nil)
 }
 
-// NewHTTPClient creates a new instance of the HTTP client
-func NewHTTPClient(url string) *HTTPClient {
+// NewPeerHTTPClient creates a new instance of the HTTP client for another URL of the cluster.
+// Its requests are traced with the given tracer, so they count towards the same metrics.
+// A nil tracer traces the requests separately
+func NewPeerHTTPClient(url string, maxIdleConns int, peer *metrics.TracingTransport) *HTTPClient {
+	httpClient := rpcclient.DefaultHTTPClient(url)
+
+	if transport, ok := httpClient.Transport.(*http.Transport); ok && maxIdleConns > 0 {
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConns
+	}
+
+	tracer := metrics.NewTracingTransport(httpClient.Transport)
+	if peer != nil {
+		tracer = peer.Fork(httpClient.Transport)
+	}
+
+	httpClient.Transport = tracer
+
 	return &HTTPClient{
-		conn: client.NewHTTP(url, ""),
+		conn:   client.NewHTTPWithClient(url, "", httpClient),
+		raw:    newRawClient(url, httpClient),
+		tracer: tracer,
+	}
+}
+
+// SetTracePhase sets the phase under which the request
+// timings are recorded. An empty phase disables request tracing
+func (h *HTTPClient) SetTracePhase(phase string) {
+	if h.tracer == nil {
+		return
+	}
+
+	h.tracer.SetPhase(phase)
+}
+
+// Tracer returns the request tracer of the client, nil for in-process nodes
+func (h *HTTPClient) Tracer() *metrics.TracingTransport {
+	return h.tracer
+}
+
+// RPCMetrics returns the request timing metrics of all traced phases
+func (h *HTTPClient) RPCMetrics() *metrics.RPCMetrics {
+	if h.tracer == nil {
+		return nil
+	}
+
+	return h.tracer.Metrics()
+}
+
+// CloseIdleConnections closes the connections left idle in the pool,
+// so later requests never reuse a socket the node has already dropped.
+// The client stays usable, and opens new connections as needed
+func (h *HTTPClient) CloseIdleConnections() {
+	if h.tracer == nil {
+		return
 	}
+
+	h.tracer.CloseIdleConnections()
+}
+
+// Close releases the idle connections kept by the client,
+// along with their background connection goroutines
+func (h *HTTPClient) Close() error {
+	h.CloseIdleConnections()
+
+	return nil
+}
+
+// Prewarm opens and exercises the given number of connections
+// by executing concurrent status queries against the node.
+// The opened connections are kept in the idle pool for later use
+func (h *HTTPClient) Prewarm(connections int) error {
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, connections)
+	)
+
+	for i := 0; i < connections; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := h.conn.Status(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	// Return the first error, if any
+	if err, ok := <-errs; ok {
+		return fmt.Errorf("unable to pre-warm connection, %w", err)
+	}
+
+	return nil
 }
 
 func (h *HTTPClient) CreateBatch() common.Batch {
-	return &Batch{batch: h.conn.NewBatch()}
+	conn, ok := h.conn.(*client.HTTP)
+	if !ok {
+		return &LocalBatch{conn: h.conn}
+	}
+
+	return &Batch{batch: conn.NewBatch()}
+}
+
+// PingBatch executes a JSON-RPC batch of status requests, verifying
+// the node (and any proxy in front of it) accepts batch requests
+func (h *HTTPClient) PingBatch() error {
+	conn, ok := h.conn.(*client.HTTP)
+	if !ok {
+		return nil
+	}
+
+	batch := conn.NewBatch()
+
+	for i := 0; i < 2; i++ {
+		if _, err := batch.Status(); err != nil {
+			return fmt.Errorf("unable to prepare status request, %w", err)
+		}
+	}
+
+	results, err := batch.Send()
+	if err != nil {
+		if batchRejectedRegex.MatchString(err.Error()) {
+			return fmt.Errorf("%w, %v", common.ErrBatchRejected, err)
+		}
+
+		return fmt.Errorf("unable to send batch, %w", err)
+	}
+
+	if len(results) != 2 {
+		return fmt.Errorf("%w, %d of 2 batch results returned", common.ErrBatchRejected, len(results))
+	}
+
+	return nil
 }
 
 func (h *HTTPClient) ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error) {
@@ -55,12 +219,84 @@ func (h *HTTPClient) GetLatestBlockHeight() (int64, error) {
 	return status.SyncInfo.LatestBlockHeight, nil
 }
 
+func (h *HTTPClient) GetStatus() (*core_types.ResultStatus, error) {
+	return h.conn.Status()
+}
+
+// GetBlock fetches the block at the given height (the latest if nil).
+// Remote blocks are decoded according to the node API payload shape
 func (h *HTTPClient) GetBlock(height *int64) (*core_types.ResultBlock, error) {
-	return h.conn.Block(height)
+	if h.raw == nil {
+		return h.conn.Block(height)
+	}
+
+	raw, err := h.raw.call("block", heightParams(height))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeBlock(raw, h.nodeVersion)
+}
+
+func (h *HTTPClient) GetCommit(height *int64) (*core_types.ResultCommit, error) {
+	return h.conn.Commit(height)
+}
+
+func (h *HTTPClient) GetValidators(height *int64) (*core_types.ResultValidators, error) {
+	return h.conn.Validators(height)
 }
 
+// GetBlockResults fetches the block results at the given height (the latest if nil).
+// Remote block results are decoded according to the node API payload shape
 func (h *HTTPClient) GetBlockResults(height *int64) (*core_types.ResultBlockResults, error) {
-	return h.conn.BlockResults(height)
+	if h.raw == nil {
+		return h.conn.BlockResults(height)
+	}
+
+	raw, err := h.raw.call("block_results", heightParams(height))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeBlockResults(raw, h.nodeVersion)
+}
+
+// nodeVersion returns the node version, fetched once
+func (h *HTTPClient) nodeVersion() string {
+	h.versionOnce.Do(func() {
+		h.version = "unknown"
+
+		if status, err := h.conn.Status(); err == nil && status.NodeInfo.Version != "" {
+			h.version = status.NodeInfo.Version
+		}
+	})
+
+	return h.version
+}
+
+// heightParams returns the request params for the given height
+func heightParams(height *int64) map[string]interface{} {
+	if height == nil {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{"height": *height}
+}
+
+// txConn is the node connection serving the tx endpoint,
+// which is not part of the general purpose client
+type txConn interface {
+	Tx(hash []byte, prove bool) (*core_types.ResultTx, error)
+}
+
+// GetTx fetches the delivery result of the committed transaction with the given hash
+func (h *HTTPClient) GetTx(hash []byte) (*core_types.ResultTx, error) {
+	conn, ok := h.conn.(txConn)
+	if !ok {
+		return nil, errTxUnsupported
+	}
+
+	return conn.Tx(hash, false)
 }
 
 func (h *HTTPClient) GetConsensusParams(height *int64) (*core_types.ResultConsensusParams, error) {
@@ -89,6 +325,66 @@ func (h *HTTPClient) BroadcastTransaction(tx *std.Tx) error {
 	return nil
 }
 
+// BroadcastTransactionSync broadcasts the transaction and waits
+// for it to pass the mempool checks (CheckTx), returning its hash
+func (h *HTTPClient) BroadcastTransactionSync(tx *std.Tx) ([]byte, error) {
+	marshalledTx, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	res, err := h.conn.BroadcastTxSync(marshalledTx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to broadcast transaction, %w", err)
+	}
+
+	if res.Error != nil {
+		return nil, fmt.Errorf("broadcast transaction check failed, %w", res.Error)
+	}
+
+	return res.Hash, nil
+}
+
+// BroadcastRawTransactionSync broadcasts the marshalled transaction
+// and waits for it to pass the mempool checks (CheckTx)
+func (h *HTTPClient) BroadcastRawTransactionSync(tx []byte) (*core_types.ResultBroadcastTx, error) {
+	res, err := h.conn.BroadcastTxSync(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to broadcast transaction, %w", err)
+	}
+
+	return res, nil
+}
+
+// SimulateTransaction simulates the transaction execution
+// on the node, and returns the gas used by the transaction
+func (h *HTTPClient) SimulateTransaction(tx *std.Tx) (int64, error) {
+	marshalledTx, err := amino.Marshal(tx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	queryResult, err := h.conn.ABCIQuery(".app/simulate", marshalledTx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to simulate transaction, %w", err)
+	}
+
+	if queryResult.Response.Error != nil {
+		return 0, fmt.Errorf("simulation query failed, %w", queryResult.Response.Error)
+	}
+
+	var result sdk.Result
+	if err := amino.Unmarshal(queryResult.Response.Value, &result); err != nil {
+		return 0, fmt.Errorf("unable to unmarshal simulation result, %w", err)
+	}
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("transaction simulation failed, %w", result.Error)
+	}
+
+	return result.GasUsed, nil
+}
+
 func (h *HTTPClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
 	queryResult, err := h.conn.ABCIQuery(
 		fmt.Sprintf("auth/accounts/%s", address),
@@ -112,7 +408,7 @@ func (h *HTTPClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
 }
 
 func (h *HTTPClient) GetBlockGasUsed(height int64) (int64, error) {
-	blockRes, err := h.conn.BlockResults(&height)
+	blockRes, err := h.GetBlockResults(&height)
 	if err != nil {
 		return 0, fmt.Errorf("unable to fetch block results, %w", err)
 	}
@@ -133,3 +429,12 @@ func (h *HTTPClient) GetBlockGasLimit(height int64) (int64, error) {
 
 	return consensusParams.ConsensusParams.Block.MaxGas, nil
 }
+
+func (h *HTTPClient) GetUnconfirmedTxs(limit int) ([]types.Tx, error) {
+	res, err := h.conn.UnconfirmedTxs(limit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch unconfirmed txs, %w", err)
+	}
+
+	return res.Txs, nil
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	rpctypes "github.com/gnolang/gno/pkgs/bft/rpc/lib/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatusNode creates a test node that holds the status requests until the given number
+// of them is in flight, so only concurrent requests are served. It records the remote
+// address of each request
+func newStatusNode(t *testing.T, concurrent int) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mux     sync.Mutex
+		remotes []string
+
+		arrived = make(chan struct{})
+	)
+
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		remotes = append(remotes, r.RemoteAddr)
+
+		if len(remotes) == concurrent {
+			close(arrived)
+		}
+		mux.Unlock()
+
+		select {
+		case <-arrived:
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		var request rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		// The minimal status response, for the request ID
+		response, _ := json.Marshal(rpctypes.RPCResponse{
+			JSONRPC: "2.0",
+			ID:      request.ID,
+			Result:  json.RawMessage("{}"),
+		})
+
+		_, _ = w.Write(response)
+	}))
+
+	return node, func() []string {
+		mux.Lock()
+		defer mux.Unlock()
+
+		return append([]string(nil), remotes...)
+	}
+}
+
+func TestHTTPClient_Prewarm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concurrent connections", func(t *testing.T) {
+		t.Parallel()
+
+		connections := 4
+
+		node, remotes := newStatusNode(t, connections)
+		defer node.Close()
+
+		cli := NewHTTPClient(node.URL, connections)
+		defer cli.Close()
+
+		require.NoError(t, cli.Prewarm(connections))
+
+		// Every request was in flight at the same time, each on its own connection
+		prewarmed := remotes()
+
+		unique := make(map[string]struct{}, len(prewarmed))
+		for _, remote := range prewarmed {
+			unique[remote] = struct{}{}
+		}
+
+		assert.Len(t, prewarmed, connections)
+		assert.Len(t, unique, connections)
+	})
+
+	t.Run("failed pre-warm", func(t *testing.T) {
+		t.Parallel()
+
+		node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer node.Close()
+
+		cli := NewHTTPClient(node.URL, 2)
+		defer cli.Close()
+
+		assert.ErrorContains(t, cli.Prewarm(2), "unable to pre-warm connection")
+	})
+
+	t.Run("excluded from the RPC metrics", func(t *testing.T) {
+		t.Parallel()
+
+		connections := 3
+
+		// The traced request is served after the pre-warm ones
+		node, remotes := newStatusNode(t, connections)
+		defer node.Close()
+
+		cli := NewHTTPClient(node.URL, connections)
+		defer cli.Close()
+
+		// The pre-warm runs before any trace phase is set
+		require.NoError(t, cli.Prewarm(connections))
+
+		assert.Empty(t, cli.RPCMetrics().Phases)
+
+		cli.SetTracePhase("broadcast")
+
+		_, err := cli.GetStatus()
+		require.NoError(t, err)
+
+		phase := cli.RPCMetrics().Phases["broadcast"]
+		require.NotNil(t, phase)
+
+		// Only the traced request is recorded, on a pre-warmed connection
+		assert.Len(t, remotes(), connections+1)
+		assert.Equal(t, 1, phase.Requests)
+		assert.Equal(t, 1, phase.ReusedConns)
+	})
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/pkgs/bft/rpc/client"
+	"github.com/gnolang/gno/pkgs/bft/types"
+)
+
+// LocalBatch broadcasts the batched transactions one by one,
+// since in-process nodes have no JSON-RPC batch requests
+type LocalBatch struct {
+	conn client.Client
+	txs  []types.Tx
+}
+
+func (b *LocalBatch) AddTxBroadcast(tx []byte) error {
+	b.txs = append(b.txs, tx)
+
+	return nil
+}
+
+func (b *LocalBatch) Execute() ([]interface{}, error) {
+	results := make([]interface{}, 0, len(b.txs))
+
+	for _, tx := range b.txs {
+		res, err := b.conn.BroadcastTxSync(tx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to broadcast transaction, %w", err)
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// NewLocalClient creates a new client backed by the given in-process
+// node connection, without going through HTTP.
+// The requests are not traced, since there is no transport
+func NewLocalClient(conn client.Client) *HTTPClient {
+	return &HTTPClient{
+		conn: conn,
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	rpctypes "github.com/gnolang/gno/pkgs/bft/rpc/lib/types"
+)
+
+// rawRequestID is the JSON-RPC request ID of the raw requests
+const rawRequestID = rpctypes.JSONRPCStringID("supernova")
+
+// rawClient executes JSON-RPC requests, and returns the raw results,
+// so the payload shape can be detected before it is decoded
+type rawClient struct {
+	address string
+	client  *http.Client
+}
+
+// newRawClient creates a new raw client for the given remote,
+// sharing the HTTP client (and its request tracing) of the node connection
+func newRawClient(remote string, client *http.Client) *rawClient {
+	// Plain TCP remotes are served over HTTP
+	if strings.HasPrefix(remote, "tcp://") {
+		remote = "http://" + strings.TrimPrefix(remote, "tcp://")
+	}
+
+	return &rawClient{
+		address: remote,
+		client:  client,
+	}
+}
+
+// call executes the JSON-RPC request, and returns its raw result
+func (r *rawClient) call(method string, params map[string]interface{}) (json.RawMessage, error) {
+	request, err := rpctypes.MapToRequest(rawRequestID, method, params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request, %w", err)
+	}
+
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request, %w", err)
+	}
+
+	httpResponse, err := r.client.Post(r.address, "text/json", bytes.NewReader(requestBytes))
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request, %w", err)
+	}
+
+	defer httpResponse.Body.Close() //nolint:errcheck // the body is fully read
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return nil, fmt.Errorf("server at %s returned %s", r.address, httpResponse.Status)
+	}
+
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response, %w", err)
+	}
+
+	var response rpctypes.RPCResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal rpc response, %w", err)
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("response error, %w", response.Error)
+	}
+
+	return response.Result, nil
+}
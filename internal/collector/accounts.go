@@ -0,0 +1,23 @@
+package collector
+
+// AccountResult is the balance accounting of a single sub-account over the run,
+// predicting its on-chain state once the run is over
+type AccountResult struct {
+	Address       string `json:"address"`
+	AccountNumber uint64 `json:"accountNumber"`
+
+	StartSequence uint64 `json:"startSequence"` // the sequence after the distribution
+	StartBalance  string `json:"startBalance"`  // the balance after the distribution
+
+	Committed int    `json:"committed"` // the committed run transactions
+	Lost      int    `json:"lost"`      // the run transactions never observed committed
+	Spent     string `json:"spent"`     // the fees, transfers and deposits of the committed transactions
+
+	Recycles int `json:"recycles,omitempty"` // the idle recycles of the duration run sub-account
+
+	// The predicted on-chain state once the run is over. Lost transactions
+	// can still be committed later, so accounts with any have no prediction
+	Predicted bool   `json:"predicted"`
+	Sequence  uint64 `json:"sequence"`
+	Balance   string `json:"balance"`
+}
@@ -0,0 +1,370 @@
+package collector
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/schollz/progressbar/v3"
+)
+
+// The sources of the block gas used, from the most precise
+const (
+	GasSourceBlockResults = "block-results" // the block results of the node
+	GasSourceTxBackfill   = "tx-backfill"   // the results of every run transaction in the block
+	GasSourceTxSample     = "tx-sample"     // extrapolated from the results of the sampled run transactions
+	GasSourceUnavailable  = "unavailable"   // neither the block results, nor the tx results were available
+)
+
+// resultCodeOK is the result code of the successfully delivered transactions
+const resultCodeOK = "ok"
+
+// maxBackfillFailures is the number of consecutive failed tx queries
+// after which the tx endpoint is considered unavailable
+const maxBackfillFailures = 10
+
+// gasSourceRanks ranks the gas used sources by precision, from the most precise
+var gasSourceRanks = map[string]int{
+	GasSourceBlockResults: 0,
+	GasSourceTxBackfill:   1,
+	GasSourceTxSample:     2,
+	GasSourceUnavailable:  3,
+}
+
+type TxClient interface {
+	GetTx(hash []byte) (*core_types.ResultTx, error)
+}
+
+// BackfillResult is the per-tx backfill of the blocks without results
+type BackfillResult struct {
+	Source  string         `json:"source"`            // the gas used source of the backfill (tx-backfill or tx-sample)
+	Matched int            `json:"matched"`           // the run transactions in the blocks without results
+	Queried int            `json:"queried"`           // the run transactions with backfilled results, including the resumed ones
+	Resumed int            `json:"resumed"`           // the run transactions restored from the backfill journal
+	Failed  int            `json:"failed"`            // the failed tx queries
+	Aborted bool           `json:"aborted,omitempty"` // flag indicating if the tx endpoint was unavailable
+	Codes   map[string]int `json:"codes,omitempty"`   // the number of backfilled transactions per result code
+}
+
+// backfillEntry is a single backfilled tx result, as journaled
+type backfillEntry struct {
+	Hash    string `json:"hash"` // the hex encoded tx hash
+	Height  int64  `json:"height"`
+	Code    string `json:"code"`
+	GasUsed int64  `json:"gasUsed"`
+}
+
+// backfillTx is a run transaction committed in a block without results
+type backfillTx struct {
+	hash   string
+	height int64
+}
+
+// Backfiller backfills the gas used and result codes of the blocks
+// observed without block results, by querying the results of their
+// run transactions from the tx endpoint of the node
+type Backfiller struct {
+	cli TxClient
+
+	sample   int           // the number of sampled transactions, all if 0
+	interval time.Duration // the minimum interval between tx queries
+	journal  string        // the backfill journal path, for resuming, if any
+}
+
+// NewBackfiller creates a new tx results backfiller, querying at most
+// rate transactions per second. The transactions already in the journal,
+// if any, are not queried again, and the new results are appended to it
+func NewBackfiller(cli TxClient, sample int, rate float64, journal string) *Backfiller {
+	return &Backfiller{
+		cli:      cli,
+		sample:   sample,
+		interval: time.Duration(float64(time.Second) / rate),
+		journal:  journal,
+	}
+}
+
+// Backfill backfills the run result blocks without results, using the commit
+// heights of the run transactions. The result is nil if every block had results
+func (b *Backfiller) Backfill(result *RunResult, heights map[string]int64) (*BackfillResult, error) {
+	var (
+		missing = make(map[int64]int) // height -> run transactions committed in the block
+		txs     = make([]backfillTx, 0)
+	)
+
+	for _, block := range result.Blocks {
+		if block.GasSource == GasSourceUnavailable {
+			missing[block.Number] = 0
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	for hash, height := range heights {
+		if _, ok := missing[height]; !ok {
+			continue
+		}
+
+		missing[height]++
+		txs = append(txs, backfillTx{hash: hash, height: height})
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].height != txs[j].height {
+			return txs[i].height < txs[j].height
+		}
+
+		return txs[i].hash < txs[j].hash
+	})
+
+	backfill := &BackfillResult{
+		Source:  GasSourceTxBackfill,
+		Matched: len(txs),
+		Codes:   make(map[string]int),
+	}
+
+	selected := sampleBackfill(txs, b.sample)
+	if len(selected) < len(txs) {
+		backfill.Source = GasSourceTxSample
+	}
+
+	entries, err := b.query(selected, backfill)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBackfill(result.Blocks, entries, missing)
+
+	result.GasSource = runGasSource(result.Blocks)
+
+	return backfill, nil
+}
+
+// query queries the results of the selected transactions, rate limited,
+// skipping (and counting) the ones restored from the journal
+func (b *Backfiller) query(txs []backfillTx, backfill *BackfillResult) ([]backfillEntry, error) {
+	journaled, err := loadJournal(b.journal)
+	if err != nil {
+		return nil, err
+	}
+
+	var journal *os.File
+
+	if b.journal != "" {
+		journal, err = os.OpenFile(b.journal, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open backfill journal, %w", err)
+		}
+
+		defer journal.Close()
+	}
+
+	fmt.Printf("\n🔎 Backfilling %d tx results 🔎\n\n", len(txs))
+
+	var (
+		entries  = make([]backfillEntry, 0, len(txs))
+		failures = 0
+		bar      = progressbar.Default(int64(len(txs)), "txs backfilled")
+		ticker   = time.NewTicker(b.interval)
+	)
+
+	defer ticker.Stop()
+
+	for _, tx := range txs {
+		txHash := hex.EncodeToString([]byte(tx.hash))
+
+		if entry, ok := journaled[txHash]; ok {
+			entries = append(entries, entry)
+			backfill.Resumed++
+			backfill.Codes[entry.Code]++
+
+			_ = bar.Add(1)
+
+			continue
+		}
+
+		<-ticker.C
+
+		res, err := b.cli.GetTx([]byte(tx.hash))
+		if err != nil {
+			backfill.Failed++
+			failures++
+
+			if failures >= maxBackfillFailures {
+				fmt.Printf("\n⚠️ Tx endpoint unavailable, backfill aborted: %v\n", err)
+
+				backfill.Aborted = true
+
+				break
+			}
+
+			continue
+		}
+
+		failures = 0
+
+		entry := backfillEntry{
+			Hash:    txHash,
+			Height:  tx.height,
+			Code:    resultCode(res.TxResult),
+			GasUsed: res.TxResult.GasUsed,
+		}
+
+		if journal != nil {
+			if err := writeJournalEntry(journal, entry); err != nil {
+				return nil, err
+			}
+		}
+
+		entries = append(entries, entry)
+		backfill.Codes[entry.Code]++
+
+		_ = bar.Add(1)
+	}
+
+	backfill.Queried = len(entries)
+
+	return entries, nil
+}
+
+// mergeBackfill merges the backfilled gas used into the blocks without results.
+// Completely backfilled blocks use the summed gas used of their run transactions,
+// and partially backfilled blocks the mean gas used times their run transactions
+func mergeBackfill(blocks []*BlockResult, entries []backfillEntry, missing map[int64]int) {
+	var (
+		gasUsed = make(map[int64]int64, len(missing))
+		counts  = make(map[int64]int, len(missing))
+	)
+
+	for _, entry := range entries {
+		gasUsed[entry.Height] += entry.GasUsed
+		counts[entry.Height]++
+	}
+
+	for _, block := range blocks {
+		matched, ok := missing[block.Number]
+		if !ok || counts[block.Number] == 0 {
+			continue
+		}
+
+		if count := counts[block.Number]; count < matched {
+			mean := float64(gasUsed[block.Number]) / float64(count)
+
+			block.GasUsed = int64(math.Round(mean * float64(matched)))
+			block.GasSource = GasSourceTxSample
+
+			continue
+		}
+
+		block.GasUsed = gasUsed[block.Number]
+		block.GasSource = GasSourceTxBackfill
+	}
+}
+
+// sampleBackfill returns the evenly spaced sample of the transactions,
+// or all of them if the sample covers them
+func sampleBackfill(txs []backfillTx, sample int) []backfillTx {
+	if sample <= 0 || sample >= len(txs) {
+		return txs
+	}
+
+	var (
+		sampled = make([]backfillTx, 0, sample)
+		stride  = float64(len(txs)) / float64(sample)
+	)
+
+	for i := 0; i < sample; i++ {
+		sampled = append(sampled, txs[int(float64(i)*stride)])
+	}
+
+	return sampled
+}
+
+// runGasSource returns the least precise gas used source of the blocks
+func runGasSource(blocks []*BlockResult) string {
+	source := GasSourceBlockResults
+
+	for _, block := range blocks {
+		blockSource := block.GasSource
+		if blockSource == "" {
+			blockSource = GasSourceBlockResults
+		}
+
+		if gasSourceRanks[blockSource] > gasSourceRanks[source] {
+			source = blockSource
+		}
+	}
+
+	return source
+}
+
+// resultCode returns the result code of the delivered transaction,
+// as the type of its delivery error
+func resultCode(deliverTx abci.ResponseDeliverTx) string {
+	if deliverTx.Error == nil {
+		return resultCodeOK
+	}
+
+	return fmt.Sprintf("%T", deliverTx.Error)
+}
+
+// loadJournal loads the backfilled tx results of the journal, by hex tx hash.
+// Missing journals are empty, and malformed (partially written) entries are skipped
+func loadJournal(path string) (map[string]backfillEntry, error) {
+	entries := make(map[string]backfillEntry)
+
+	if path == "" {
+		return entries, nil
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open backfill journal, %w", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var entry backfillEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Hash == "" {
+			continue
+		}
+
+		entries[entry.Hash] = entry
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read backfill journal, %w", err)
+	}
+
+	return entries, nil
+}
+
+// writeJournalEntry appends the backfilled tx result to the journal
+func writeJournalEntry(journal *os.File, entry backfillEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to encode backfill entry, %w", err)
+	}
+
+	if _, err := journal.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write backfill journal, %w", err)
+	}
+
+	return nil
+}
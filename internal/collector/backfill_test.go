@@ -0,0 +1,184 @@
+package collector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBackfillResult creates a run result with a block with results (1),
+// and two blocks without results (2, 3), with the commit heights of their txs
+func newBackfillResult() (*RunResult, map[string]int64) {
+	result := &RunResult{
+		Blocks: []*BlockResult{
+			{Number: 1, GasUsed: 500},
+			{Number: 2, GasSource: GasSourceUnavailable},
+			{Number: 3, GasSource: GasSourceUnavailable},
+		},
+	}
+
+	heights := map[string]int64{
+		"tx-1a": 1,
+		"tx-2a": 2,
+		"tx-2b": 2,
+		"tx-3a": 3,
+		"tx-3b": 3,
+		"tx-3c": 3,
+		"tx-3d": 3,
+	}
+
+	return result, heights
+}
+
+// newTxResponder returns the tx results with 100 gas used,
+// and a failed delivery for the "b" transactions
+func newTxResponder(queries *int32) getTxDelegate {
+	return func(hash []byte) (*core_types.ResultTx, error) {
+		atomic.AddInt32(queries, 1)
+
+		res := &core_types.ResultTx{Hash: hash}
+		res.TxResult.GasUsed = 100
+
+		if strings.HasSuffix(string(hash), "b") {
+			res.TxResult.Error = abci.StringError("out of gas")
+		}
+
+		return res, nil
+	}
+}
+
+func TestBackfiller_Backfill(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all transactions", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			queries       int32
+			result, txs   = newBackfillResult()
+			backfiller    = NewBackfiller(&mockTxClient{getTxFn: newTxResponder(&queries)}, 0, 1000, "")
+			backfill, err = backfiller.Backfill(result, txs)
+		)
+
+		if err != nil {
+			t.Fatalf("unable to backfill, %v", err)
+		}
+
+		assert.EqualValues(t, 6, queries)
+		assert.Equal(t, GasSourceTxBackfill, backfill.Source)
+		assert.Equal(t, 6, backfill.Matched)
+		assert.Equal(t, 6, backfill.Queried)
+		assert.Equal(t, map[string]int{resultCodeOK: 4, "abci.StringError": 2}, backfill.Codes)
+
+		// The block with results is left as is
+		assert.Equal(t, int64(500), result.Blocks[0].GasUsed)
+		assert.Empty(t, result.Blocks[0].GasSource)
+
+		assert.Equal(t, int64(200), result.Blocks[1].GasUsed)
+		assert.Equal(t, int64(400), result.Blocks[2].GasUsed)
+		assert.Equal(t, GasSourceTxBackfill, result.Blocks[2].GasSource)
+		assert.Equal(t, GasSourceTxBackfill, result.GasSource)
+	})
+
+	t.Run("sampled transactions", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			queries       int32
+			result, txs   = newBackfillResult()
+			backfiller    = NewBackfiller(&mockTxClient{getTxFn: newTxResponder(&queries)}, 3, 1000, "")
+			backfill, err = backfiller.Backfill(result, txs)
+		)
+
+		if err != nil {
+			t.Fatalf("unable to backfill, %v", err)
+		}
+
+		assert.EqualValues(t, 3, queries)
+		assert.Equal(t, GasSourceTxSample, backfill.Source)
+		assert.Equal(t, GasSourceTxSample, result.GasSource)
+
+		// The sampled block gas used is extrapolated to every run transaction
+		assert.Equal(t, int64(200), result.Blocks[1].GasUsed)
+		assert.Equal(t, int64(400), result.Blocks[2].GasUsed)
+		assert.Equal(t, GasSourceTxSample, result.Blocks[2].GasSource)
+	})
+
+	t.Run("tx endpoint unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			result, txs = newBackfillResult()
+			cli         = &mockTxClient{
+				getTxFn: func([]byte) (*core_types.ResultTx, error) {
+					return nil, errors.New("tx endpoint disabled")
+				},
+			}
+		)
+
+		backfill, err := NewBackfiller(cli, 0, 1000, "").Backfill(result, txs)
+		if err != nil {
+			t.Fatalf("unable to backfill, %v", err)
+		}
+
+		assert.Equal(t, 6, backfill.Failed)
+		assert.Zero(t, backfill.Queried)
+		assert.Equal(t, GasSourceUnavailable, result.GasSource)
+	})
+
+	t.Run("resumed from the journal", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			queries int32
+			journal = filepath.Join(t.TempDir(), "backfill.jsonl")
+			cli     = &mockTxClient{getTxFn: newTxResponder(&queries)}
+		)
+
+		result, txs := newBackfillResult()
+
+		if _, err := NewBackfiller(cli, 0, 1000, journal).Backfill(result, txs); err != nil {
+			t.Fatalf("unable to backfill, %v", err)
+		}
+
+		// Simulate an interrupted journal write
+		file, err := os.OpenFile(journal, os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			t.Fatalf("unable to open journal, %v", err)
+		}
+
+		_, _ = file.WriteString(`{"hash": "7478`)
+		_ = file.Close()
+
+		result, txs = newBackfillResult()
+
+		backfill, err := NewBackfiller(cli, 0, 1000, journal).Backfill(result, txs)
+		if err != nil {
+			t.Fatalf("unable to backfill, %v", err)
+		}
+
+		// Only the first backfill queried the tx endpoint
+		assert.EqualValues(t, 6, queries)
+		assert.Equal(t, 6, backfill.Resumed)
+		assert.Equal(t, 6, backfill.Queried)
+		assert.Equal(t, int64(400), result.Blocks[2].GasUsed)
+	})
+
+	t.Run("every block has results", func(t *testing.T) {
+		t.Parallel()
+
+		result := &RunResult{Blocks: []*BlockResult{{Number: 1}}}
+
+		backfill, err := NewBackfiller(&mockTxClient{}, 0, 1000, "").Backfill(result, map[string]int64{"tx": 1})
+
+		assert.NoError(t, err)
+		assert.Nil(t, backfill)
+	})
+}
@@ -0,0 +1,46 @@
+package collector
+
+import "time"
+
+// CeilingResult is the theoretical TPS ceiling of the run, if every block
+// was filled with run transactions up to the block gas limit.
+// Blocks are filled by the gas wanted, not the gas used
+type CeilingResult struct {
+	GasWanted     int64         `json:"gasWanted"`     // the gas wanted of each run transaction
+	BlockGasLimit int64         `json:"blockGasLimit"` // the block gas limit
+	TxsPerBlock   int64         `json:"txsPerBlock"`   // the run transactions that fit a single block
+	BlockInterval time.Duration `json:"blockInterval"` // the average interval of the collected blocks
+	TPS           float64       `json:"tps"`
+}
+
+// NewCeilingResult computes the theoretical TPS ceiling for the given
+// run transaction gas wanted, from the collected blocks.
+// The ceiling is unknown (nil) without a block gas limit, or at least 2 blocks
+func NewCeilingResult(blocks []*BlockResult, gasWanted int64) *CeilingResult {
+	if len(blocks) < 2 || gasWanted <= 0 {
+		return nil
+	}
+
+	var gasLimit int64
+
+	for _, block := range blocks {
+		if block.GasLimit > gasLimit {
+			gasLimit = block.GasLimit
+		}
+	}
+
+	interval := blocks[len(blocks)-1].Time.Sub(blocks[0].Time) / time.Duration(len(blocks)-1)
+	if gasLimit <= 0 || interval <= 0 {
+		return nil
+	}
+
+	txsPerBlock := gasLimit / gasWanted
+
+	return &CeilingResult{
+		GasWanted:     gasWanted,
+		BlockGasLimit: gasLimit,
+		TxsPerBlock:   txsPerBlock,
+		BlockInterval: interval,
+		TPS:           float64(txsPerBlock) / interval.Seconds(),
+	}
+}
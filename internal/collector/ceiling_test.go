@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCeiling_NewCeilingResult(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ceiling by gas wanted", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := generateBlocks(start, 10, 10, 10, 10, 10)
+		for _, block := range blocks {
+			block.GasLimit = 10_000_000
+		}
+
+		var (
+			call   = NewCeilingResult(blocks, 100_000)
+			deploy = NewCeilingResult(blocks, 165_000)
+		)
+
+		if call == nil || deploy == nil {
+			t.Fatalf("ceiling not computed")
+		}
+
+		assert.Equal(t, int64(100), call.TxsPerBlock)
+		assert.Equal(t, time.Second, call.BlockInterval)
+		assert.InDelta(t, 100, call.TPS, 1e-9)
+
+		// A lower gas wanted fits more transactions in a block
+		assert.Equal(t, int64(60), deploy.TxsPerBlock)
+		assert.Greater(t, call.TPS, deploy.TPS)
+	})
+
+	t.Run("unknown ceiling", func(t *testing.T) {
+		t.Parallel()
+
+		// No block gas limit
+		assert.Nil(t, NewCeilingResult(generateBlocks(start, 10, 10), 100_000))
+
+		// Not enough blocks for the block interval
+		blocks := generateBlocks(start, 10)
+		blocks[0].GasLimit = 10_000_000
+
+		assert.Nil(t, NewCeilingResult(blocks, 100_000))
+	})
+}
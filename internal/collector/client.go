@@ -0,0 +1,115 @@
+package collector
+
+import (
+	goruntime "runtime"
+	"sync"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// ClientSampler periodically samples the resource usage of the supernova
+// process itself (CPU, goroutines, GC pauses), so a run limited by the client
+// machine is not mistaken for a node limit
+type ClientSampler struct {
+	interval time.Duration
+	cpus     int
+
+	mux     sync.Mutex
+	samples []*metrics.ClientSample
+
+	lastCPU     time.Duration // the process CPU time at the previous sample
+	lastSampled time.Time     // the time of the previous sample
+	cpuErr      error         // the process CPU time error, if unsupported
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewClientSampler creates a new client resource sampler,
+// that samples the process at the given interval
+func NewClientSampler(interval time.Duration) *ClientSampler {
+	return &ClientSampler{
+		interval: interval,
+		cpus:     goruntime.NumCPU(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts sampling the process in the background
+func (s *ClientSampler) Start() {
+	// The first sample is relative to the start
+	s.lastCPU, s.cpuErr = processCPUTime()
+	s.lastSampled = time.Now()
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.sample()
+			}
+		}
+	}()
+}
+
+// Stop stops the process sampling. It is safe to call multiple times
+func (s *ClientSampler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	<-s.done
+}
+
+// Result stops the process sampling, and returns the sampled timeline,
+// with the bottleneck heuristics evaluated over the broadcast window
+func (s *ClientSampler) Result(broadcastStart, broadcastEnd time.Time, starvation time.Duration) *metrics.ClientMetrics {
+	s.Stop()
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return metrics.NewClientMetrics(s.interval, s.cpus, s.samples, broadcastStart, broadcastEnd, starvation)
+}
+
+// sample records a single process resource sample
+func (s *ClientSampler) sample() {
+	var stats goruntime.MemStats
+
+	goruntime.ReadMemStats(&stats)
+
+	sample := &metrics.ClientSample{
+		Time:         time.Now(),
+		Goroutines:   goruntime.NumGoroutine(),
+		GCPauseTotal: time.Duration(stats.PauseTotalNs),
+		GCCycles:     stats.NumGC,
+	}
+
+	// The CPU usage is the share of the available cores used since the previous sample
+	if s.cpuErr == nil {
+		if cpuTime, err := processCPUTime(); err == nil {
+			if elapsed := sample.Time.Sub(s.lastSampled); elapsed > 0 {
+				usage := float64(cpuTime-s.lastCPU) / float64(elapsed*time.Duration(s.cpus)) * 100
+				sample.CPU = &usage
+			}
+
+			s.lastCPU = cpuTime
+		}
+	}
+
+	s.lastSampled = sample.Time
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.samples = append(s.samples, sample)
+}
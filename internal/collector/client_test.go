@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientSampler_Sample(t *testing.T) {
+	t.Parallel()
+
+	sampler := NewClientSampler(10 * time.Millisecond)
+
+	start := time.Now()
+	sampler.Start()
+
+	// Wait for a few samples
+	time.Sleep(100 * time.Millisecond)
+
+	client := sampler.Result(start, time.Now(), 0)
+
+	if !assert.GreaterOrEqual(t, len(client.Samples), 3) {
+		return
+	}
+
+	for _, sample := range client.Samples {
+		assert.Positive(t, sample.Goroutines)
+	}
+
+	assert.Positive(t, client.CPUs)
+	assert.Positive(t, client.PeakGoroutines)
+
+	// Make sure stopping again is safe
+	sampler.Stop()
+}
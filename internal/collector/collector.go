@@ -11,7 +11,10 @@ import (
 )
 
 var (
-	errTimeout = errors.New("collector timed out")
+	errTimeout  = errors.New("collector timed out")
+	errDeadline = errors.New("no transactions committed before the run deadline")
+	errNoTxs    = errors.New("no transactions to collect")
+	errNoBlocks = errors.New("no blocks with the run transactions were collected")
 )
 
 // Collector is the transaction / block stat
@@ -21,15 +24,38 @@ var (
 type Collector struct {
 	cli Client
 
-	requestTimeout time.Duration
+	requestTimeout      time.Duration
+	completionThreshold float64       // the ratio of txs required to finalize
+	graceWindow         time.Duration // the no-match window before finalizing
+
+	deadline        time.Duration // the collection deadline, since the run start, if any
+	deadlineReached bool          // flag indicating if the latest run was finalized by the deadline
+
+	reportInterval time.Duration // the interval for intermediate results segments
+	segmentWriter  SegmentWriter // the writer for intermediate results segments, if any
+
+	stallFactor  float64      // the multiple of the block interval considered a stall, if any
+	statusClient StatusClient // the node status client for stall captures, if any
+
+	progress Progress // the collection progress report, if any
+
+	commitTimes   map[string]time.Time // tx hash -> commit block time, of the latest run
+	commitHeights map[string]int64     // tx hash -> commit block height, of the latest run
 }
 
 // NewCollector creates a new instance of the collector
-func NewCollector(cli Client) *Collector {
-	return &Collector{
-		cli:            cli,
-		requestTimeout: time.Second * 2,
+func NewCollector(cli Client, opts ...Option) *Collector {
+	c := &Collector{
+		cli:                 cli,
+		requestTimeout:      time.Second * 2,
+		completionThreshold: 1,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // GetRunResult generates the run result for the passed in transaction hashes and start range
@@ -38,18 +64,32 @@ func (c *Collector) GetRunResult(
 	startBlock int64,
 	startTime time.Time,
 ) (*RunResult, error) {
+	if len(txHashes) == 0 {
+		return nil, errNoTxs
+	}
+
 	var (
 		blockResults = make([]*BlockResult, 0)
 		timeout      = time.After(5 * time.Minute)
 		start        = startBlock
 		txMap        = newTxLookup(txHashes)
 		processed    = 0
+		lastMatch    = time.Now()
+		required     = requiredTransactions(len(txHashes), c.completionThreshold)
+		segments     = newSegmenter(c.reportInterval, c.segmentWriter, startTime)
+		stalls       = newStallDetector(c.stallFactor, c.statusClient)
+		gasMissing   = 0
 	)
 
+	c.commitTimes = make(map[string]time.Time, len(txHashes))
+	c.commitHeights = make(map[string]int64, len(txHashes))
+	c.deadlineReached = false
+
 	fmt.Printf("\n📊 Collecting Results 📊\n\n")
 
 	bar := progressbar.Default(int64(len(txHashes)), "txs collected")
 
+collection:
 	for {
 		// Check if all original transactions
 		// were processed
@@ -57,15 +97,37 @@ func (c *Collector) GetRunResult(
 			break
 		}
 
+		// Check if enough transactions were processed,
+		// and no new ones have been observed for a while
+		if processed >= required && time.Since(lastMatch) >= c.graceWindow {
+			fmt.Printf(
+				"\nCompletion threshold reached, %d/%d txs observed\n",
+				processed,
+				len(txHashes),
+			)
+
+			break
+		}
+
 		select {
 		case <-timeout:
-			return nil, errTimeout
+			// Chain halts are findings of their own,
+			// so the partial results are kept
+			if !stalls.stalled() || len(blockResults) == 0 {
+				return nil, errTimeout
+			}
+
+			fmt.Printf("\n⚠️ Collection timed out during the block production stall\n")
+
+			break collection
 		case <-time.After(c.requestTimeout):
 			latest, err := c.cli.GetLatestBlockHeight()
 			if err != nil {
 				return nil, fmt.Errorf("unable to fetch latest block height, %w", err)
 			}
 
+			stalls.observe(latest, time.Now())
+
 			if latest < start {
 				// No need to parse older blocks
 				continue
@@ -79,6 +141,8 @@ func (c *Collector) GetRunResult(
 					return nil, fmt.Errorf("unable to fetch block, %w", err)
 				}
 
+				stalls.addBlock(block)
+
 				// Check if any of the block transactions are the ones
 				// sent out in the stress test
 				belong := txMap.anyBelong(block.Block.Txs)
@@ -87,12 +151,31 @@ func (c *Collector) GetRunResult(
 				}
 
 				processed += belong
+				lastMatch = time.Now()
 				_ = bar.Add(belong)
 
-				// Fetch the total gas used by transactions
+				if c.progress != nil {
+					c.progress(processed)
+				}
+
+				for _, txHash := range txMap.belonging(block.Block.Txs) {
+					c.commitTimes[txHash] = block.BlockMeta.Header.Time
+					c.commitHeights[txHash] = blockNum
+				}
+
+				// Fetch the total gas used by transactions.
+				// Blocks without results (pruned, or not served by the node)
+				// are kept, and their gas used is left for the backfill
+				gasSource := ""
+
 				blockGasUsed, err := c.cli.GetBlockGasUsed(blockNum)
 				if err != nil {
-					return nil, fmt.Errorf("unable to fetch block gas used, %w", err)
+					if gasMissing == 0 {
+						fmt.Printf("\n⚠️ Block results unavailable at height %d, %v\n", blockNum, err)
+					}
+
+					gasMissing++
+					gasSource = GasSourceUnavailable
 				}
 
 				// Fetch the block gas limit
@@ -101,30 +184,110 @@ func (c *Collector) GetRunResult(
 					return nil, fmt.Errorf("unable to fetch block gas limit, %w", err)
 				}
 
-				blockResults = append(blockResults, &BlockResult{
+				blockResult := &BlockResult{
 					Number:       blockNum,
 					Time:         block.BlockMeta.Header.Time,
 					Transactions: block.BlockMeta.Header.NumTxs,
 					GasUsed:      blockGasUsed,
 					GasLimit:     blockGasLimit,
-				})
+					GasSource:    gasSource,
+				}
+
+				blockResults = append(blockResults, blockResult)
+				segments.add(blockResult, belong)
 			}
 
 			// Update the iteration range
 			start = latest + 1
+
+			// Write out the intermediate results segment, if needed
+			if err := segments.maybeFlush(); err != nil {
+				return nil, err
+			}
+
+			// Finalize once the run deadline passes,
+			// the transactions not observed so far are lost
+			if c.deadline > 0 && processed < len(txHashes) && time.Since(startTime) >= c.deadline {
+				if len(blockResults) == 0 {
+					return nil, errDeadline
+				}
+
+				fmt.Printf(
+					"\nRun deadline reached, %d/%d txs observed\n",
+					processed,
+					len(txHashes),
+				)
+
+				c.deadlineReached = true
+
+				break collection
+			}
 		}
 	}
 
+	// Write out the final results segment, if any
+	if err := segments.flush(); err != nil {
+		return nil, err
+	}
+
+	if gasMissing > 0 {
+		fmt.Printf("\n⚠️ Block results were unavailable for %d blocks\n", gasMissing)
+	}
+
+	if len(blockResults) == 0 {
+		return nil, errNoBlocks
+	}
+
 	return &RunResult{
 		AverageTPS: calculateTPS(
 			startTime,
 			blockResults[len(blockResults)-1].Time,
-			len(txHashes),
+			processed,
 		),
-		Blocks: blockResults,
+		Blocks:              blockResults,
+		CompletionThreshold: c.completionThreshold,
+		CommittedTxs:        processed,
+		LostTxs:             len(txHashes) - processed,
+		Segments:            segments.segments,
+		Stalls:              stalls.finish(time.Now()),
+		GasSource:           runGasSource(blockResults),
 	}, nil
 }
 
+// CommitTimes returns the commit (block) time
+// of each transaction collected in the latest run
+func (c *Collector) CommitTimes() map[string]time.Time {
+	return c.commitTimes
+}
+
+// DeadlineReached returns if the latest run
+// collection was finalized by the run deadline
+func (c *Collector) DeadlineReached() bool {
+	return c.deadlineReached
+}
+
+// CommitHeights returns the commit (block) height
+// of each transaction collected in the latest run
+func (c *Collector) CommitHeights() map[string]int64 {
+	return c.commitHeights
+}
+
+// requiredTransactions returns the minimum number of transactions
+// that need to be committed to satisfy the completion threshold
+func requiredTransactions(total int, threshold float64) int {
+	required := int(math.Ceil(float64(total) * threshold))
+
+	if required < 1 {
+		return 1
+	}
+
+	if required > total {
+		return total
+	}
+
+	return required
+}
+
 // txLookup is a simple lookup map for transaction hashes
 type txLookup struct {
 	lookup map[string]struct{}
@@ -159,9 +322,29 @@ func (t *txLookup) anyBelong(txs types.Txs) int {
 	return belong
 }
 
+// belonging returns the hashes of the transactions
+// that have been found in the lookup map
+func (t *txLookup) belonging(txs types.Txs) []string {
+	hashes := make([]string, 0, len(txs))
+
+	for _, tx := range txs {
+		txHash := string(tx.Hash())
+
+		if _, ok := t.lookup[txHash]; ok {
+			hashes = append(hashes, txHash)
+		}
+	}
+
+	return hashes
+}
+
 // calculateTPS calculates the TPS for the sequence
 func calculateTPS(startBlock, endBlock time.Time, totalTx int) int {
 	diff := endBlock.Sub(startBlock).Seconds()
+	if diff <= 0 {
+		// All transactions were committed instantly
+		return totalTx
+	}
 
 	// ceil(numTxs / commit time)
 	return int(math.Ceil(float64(totalTx) / diff))
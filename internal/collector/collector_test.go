@@ -2,6 +2,7 @@ package collector
 
 import (
 	"crypto/rand"
+	"errors"
 	"testing"
 	"time"
 
@@ -124,3 +125,304 @@ func TestCollector_GetRunResults(t *testing.T) {
 		assert.Equal(t, int64(1), block.Transactions)
 	}
 }
+
+func TestCollector_CompletionThreshold(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs       = 100
+		committedTxs = 95
+		startTime    = time.Now()
+		txs          = generateRandomData(t, numTxs)
+		txHashes     = make([][]byte, numTxs)
+	)
+
+	for i := 0; i < numTxs; i++ {
+		txHashes[i] = tmhash.Sum(txs[i])
+	}
+
+	mockClient := &mockClient{
+		getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+			// Only the committed transactions end up in blocks
+			blockTxs := make([]types.Tx, 0, 1)
+			if *height <= int64(committedTxs) {
+				blockTxs = append(blockTxs, txs[*height-1])
+			}
+
+			return &core_types.ResultBlock{
+				BlockMeta: &types.BlockMeta{
+					Header: types.Header{
+						Height: *height,
+						Time:   startTime.Add(time.Duration(*height) * time.Second),
+						NumTxs: int64(len(blockTxs)),
+					},
+				},
+				Block: &types.Block{
+					Data: types.Data{
+						Txs: blockTxs,
+					},
+				},
+			}, nil
+		},
+		getLatestBlockHeightFn: func() (int64, error) {
+			return int64(numTxs), nil
+		},
+	}
+
+	// Create the collector
+	c := NewCollector(
+		mockClient,
+		WithCompletionThreshold(0.9, 0),
+	)
+	c.requestTimeout = time.Second * 0
+
+	// Collect the results
+	result, err := c.GetRunResult(txHashes, 1, startTime)
+	if err != nil {
+		t.Fatalf("unable to get run results, %v", err)
+	}
+
+	assert.Equal(t, 0.9, result.CompletionThreshold)
+	assert.Equal(t, committedTxs, result.CommittedTxs)
+	assert.Equal(t, numTxs-committedTxs, result.LostTxs)
+	assert.Len(t, result.Blocks, committedTxs)
+}
+
+func TestCollector_Deadline(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs   = 100
+		txs      = generateRandomData(t, numTxs)
+		txHashes = make([][]byte, numTxs)
+	)
+
+	for i := 0; i < numTxs; i++ {
+		txHashes[i] = tmhash.Sum(txs[i])
+	}
+
+	// newClient creates a client that only commits the given number of transactions
+	newClient := func(committedTxs int, startTime time.Time) *mockClient {
+		return &mockClient{
+			getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+				blockTxs := make([]types.Tx, 0, 1)
+				if *height <= int64(committedTxs) {
+					blockTxs = append(blockTxs, txs[*height-1])
+				}
+
+				return &core_types.ResultBlock{
+					BlockMeta: &types.BlockMeta{
+						Header: types.Header{
+							Height: *height,
+							Time:   startTime.Add(time.Duration(*height) * time.Second),
+							NumTxs: int64(len(blockTxs)),
+						},
+					},
+					Block: &types.Block{
+						Data: types.Data{
+							Txs: blockTxs,
+						},
+					},
+				}, nil
+			},
+			getLatestBlockHeightFn: func() (int64, error) {
+				return int64(numTxs), nil
+			},
+		}
+	}
+
+	t.Run("partial commits", func(t *testing.T) {
+		t.Parallel()
+
+		// The run started before the deadline
+		startTime := time.Now().Add(-time.Minute)
+
+		c := NewCollector(newClient(40, startTime), WithDeadline(30*time.Second))
+		c.requestTimeout = time.Second * 0
+
+		result, err := c.GetRunResult(txHashes, 1, startTime)
+		if err != nil {
+			t.Fatalf("unable to get run results, %v", err)
+		}
+
+		assert.True(t, c.DeadlineReached())
+		assert.Equal(t, 40, result.CommittedTxs)
+		assert.Equal(t, numTxs-40, result.LostTxs)
+	})
+
+	t.Run("no commits", func(t *testing.T) {
+		t.Parallel()
+
+		startTime := time.Now().Add(-time.Minute)
+
+		c := NewCollector(newClient(0, startTime), WithDeadline(30*time.Second))
+		c.requestTimeout = time.Second * 0
+
+		_, err := c.GetRunResult(txHashes, 1, startTime)
+		assert.ErrorIs(t, err, errDeadline)
+	})
+}
+
+func TestCollector_NoTransactions(t *testing.T) {
+	t.Parallel()
+
+	// All run transactions can be dropped during construction
+	c := NewCollector(&mockClient{})
+
+	_, err := c.GetRunResult(nil, 1, time.Now())
+	assert.ErrorIs(t, err, errNoTxs)
+}
+
+func TestCollector_MissingBlockResults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs    = 10
+		startTime = time.Now()
+		txs       = generateRandomData(t, numTxs)
+		txHashes  = make([][]byte, numTxs)
+	)
+
+	for i := 0; i < numTxs; i++ {
+		txHashes[i] = tmhash.Sum(txs[i])
+	}
+
+	mockClient := &mockClient{
+		getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+			return &core_types.ResultBlock{
+				BlockMeta: &types.BlockMeta{
+					Header: types.Header{
+						Height: *height,
+						Time:   startTime.Add(time.Duration(*height) * time.Second),
+						NumTxs: 1,
+					},
+				},
+				Block: &types.Block{
+					Data: types.Data{
+						Txs: []types.Tx{txs[*height-1]},
+					},
+				},
+			}, nil
+		},
+		getLatestBlockHeightFn: func() (int64, error) {
+			return int64(numTxs), nil
+		},
+		getBlockGasUsedFn: func(height int64) (int64, error) {
+			// The older block results are pruned
+			if height <= 5 {
+				return 0, errors.New("block results pruned")
+			}
+
+			return 100, nil
+		},
+	}
+
+	c := NewCollector(mockClient)
+	c.requestTimeout = time.Second * 0
+
+	// Make sure the collection is not failed by the missing results
+	result, err := c.GetRunResult(txHashes, 1, startTime)
+	if err != nil {
+		t.Fatalf("unable to get run results, %v", err)
+	}
+
+	assert.Equal(t, GasSourceUnavailable, result.GasSource)
+	assert.Len(t, result.Blocks, numTxs)
+
+	for _, block := range result.Blocks {
+		if block.Number <= 5 {
+			assert.Equal(t, GasSourceUnavailable, block.GasSource)
+
+			continue
+		}
+
+		assert.Empty(t, block.GasSource)
+		assert.Equal(t, int64(100), block.GasUsed)
+	}
+}
+
+func TestCollector_ResultsSegments(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTxs    = 100
+		step      = int64(10)
+		latest    = int64(0)
+		startTime = time.Now()
+		txs       = generateRandomData(t, numTxs)
+		txHashes  = make([][]byte, numTxs)
+
+		capturedSegments = make([]*SegmentResult, 0)
+	)
+
+	for i := 0; i < numTxs; i++ {
+		txHashes[i] = tmhash.Sum(txs[i])
+	}
+
+	mockClient := &mockClient{
+		getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+			return &core_types.ResultBlock{
+				BlockMeta: &types.BlockMeta{
+					Header: types.Header{
+						Height: *height,
+						Time:   startTime.Add(time.Duration(*height) * time.Second),
+						NumTxs: 1,
+					},
+				},
+				Block: &types.Block{
+					Data: types.Data{
+						Txs: []types.Tx{
+							txs[*height-1],
+						},
+					},
+				},
+			}, nil
+		},
+		getLatestBlockHeightFn: func() (int64, error) {
+			// The chain progresses a few blocks per query
+			latest += step
+
+			return latest, nil
+		},
+	}
+
+	// Create the collector
+	c := NewCollector(
+		mockClient,
+		WithResultsSegments(0, func(segment *SegmentResult) error {
+			capturedSegments = append(capturedSegments, segment)
+
+			return nil
+		}),
+	)
+	c.requestTimeout = time.Second * 0
+
+	// Collect the results
+	result, err := c.GetRunResult(txHashes, 1, startTime)
+	if err != nil {
+		t.Fatalf("unable to get run results, %v", err)
+	}
+
+	// Make sure the segments were written out
+	if len(capturedSegments) != numTxs/int(step) {
+		t.Fatalf("invalid number of segments, %d", len(capturedSegments))
+	}
+
+	for index, segment := range capturedSegments {
+		assert.Equal(t, index+1, segment.Index)
+		assert.Equal(t, int(step), segment.Transactions)
+		assert.Len(t, segment.Blocks, int(step))
+
+		// Make sure the segment windows are contiguous
+		if index > 0 {
+			assert.Equal(t, capturedSegments[index-1].EndTime, segment.StartTime)
+		}
+	}
+
+	// Make sure the final results contain the segment summaries
+	assert.Len(t, result.Segments, len(capturedSegments))
+
+	for _, segment := range result.Segments {
+		assert.Nil(t, segment.Blocks)
+	}
+}
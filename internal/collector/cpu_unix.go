@@ -0,0 +1,19 @@
+//go:build !windows
+
+package collector
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the CPU time (user and system) used by the process so far
+func processCPUTime() (time.Duration, error) {
+	var usage syscall.Rusage
+
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"time"
+)
+
+// processCPUTime is not supported on Windows
+func processCPUTime() (time.Duration, error) {
+	return 0, errors.New("unsupported platform")
+}
@@ -0,0 +1,56 @@
+package collector
+
+import "time"
+
+// DrainResult is the end-of-run mempool drain, the tail from the last
+// broadcast to the last observed commit of the run transactions
+type DrainResult struct {
+	Start    time.Time     `json:"start"` // when the last broadcast ended
+	End      time.Time     `json:"end"`   // the commit time of the last run transaction
+	Duration time.Duration `json:"duration"`
+
+	Blocks       int `json:"blocks"`       // the blocks committed during the drain
+	Transactions int `json:"transactions"` // the run transactions committed during the drain
+	Residual     int `json:"residual"`     // the run transactions never committed
+}
+
+// NewDrainResult computes the mempool drain of the run, from the collected blocks,
+// the run transaction commit times, and the broadcast end time
+func NewDrainResult(
+	blocks []*BlockResult,
+	commitTimes map[string]time.Time,
+	sendEnd time.Time,
+	residual int,
+) *DrainResult {
+	if sendEnd.IsZero() {
+		return nil
+	}
+
+	drain := &DrainResult{
+		Start:    sendEnd,
+		End:      sendEnd,
+		Residual: residual,
+	}
+
+	for _, commitTime := range commitTimes {
+		if !commitTime.After(sendEnd) {
+			continue
+		}
+
+		drain.Transactions++
+
+		if commitTime.After(drain.End) {
+			drain.End = commitTime
+		}
+	}
+
+	for _, block := range blocks {
+		if block.Time.After(sendEnd) && !block.Time.After(drain.End) {
+			drain.Blocks++
+		}
+	}
+
+	drain.Duration = drain.End.Sub(drain.Start)
+
+	return drain
+}
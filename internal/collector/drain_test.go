@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrain_NewDrainResult(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("drain tail", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			blocks  = generateBlocks(start, 10, 10, 10, 5, 2, 0)
+			sendEnd = start.Add(3 * time.Second)
+
+			commitTimes = map[string]time.Time{
+				"a": start.Add(2 * time.Second),
+				"b": start.Add(3 * time.Second),
+				"c": start.Add(4 * time.Second),
+				"d": start.Add(5 * time.Second),
+			}
+		)
+
+		drain := NewDrainResult(blocks, commitTimes, sendEnd, 3)
+		if drain == nil {
+			t.Fatalf("drain not computed")
+		}
+
+		// The empty block after the last commit is not part of the drain
+		assert.Equal(t, 2*time.Second, drain.Duration)
+		assert.Equal(t, 2, drain.Blocks)
+		assert.Equal(t, 2, drain.Transactions)
+		assert.Equal(t, 3, drain.Residual)
+	})
+
+	t.Run("no drain", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			blocks  = generateBlocks(start, 10, 10)
+			sendEnd = start.Add(5 * time.Second)
+
+			commitTimes = map[string]time.Time{
+				"a": start.Add(2 * time.Second),
+			}
+		)
+
+		drain := NewDrainResult(blocks, commitTimes, sendEnd, 0)
+		if drain == nil {
+			t.Fatalf("drain not computed")
+		}
+
+		assert.Equal(t, time.Duration(0), drain.Duration)
+		assert.Equal(t, 0, drain.Blocks)
+		assert.Equal(t, 0, drain.Transactions)
+	})
+
+	t.Run("unknown broadcast end", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, NewDrainResult(generateBlocks(start, 10), nil, time.Time{}, 0))
+	})
+}
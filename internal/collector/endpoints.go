@@ -0,0 +1,143 @@
+package collector
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// The lagging endpoint thresholds, against the best performing endpoint
+const (
+	// lagLatencyFactor is the median commit latency multiple above which an endpoint lags
+	lagLatencyFactor = 2
+
+	// lagCommitGap is the committed share gap above which an endpoint lags
+	lagCommitGap = 0.1
+)
+
+// EndpointSample is a single transaction accepted by a broadcast endpoint
+type EndpointSample struct {
+	URL       string        // the endpoint that accepted the transaction
+	Committed bool          // flag indicating if the transaction was committed
+	Latency   time.Duration // the commit latency, from the broadcast to the commit block time
+}
+
+// EndpointResult is the broadcast outcome of a single endpoint
+type EndpointResult struct {
+	URL          string `json:"url"`
+	Transactions int    `json:"numTransactions"` // the transactions accepted by the endpoint
+	Committed    int    `json:"committed"`       // the accepted transactions that were committed
+	Lost         int    `json:"lost"`            // the accepted transactions that were never committed
+
+	// CommitLatency is the commit latency of the committed transactions
+	CommitLatency *metrics.Distribution `json:"commitLatency,omitempty"`
+
+	Failed  bool `json:"failed,omitempty"`  // flag indicating if the endpoint failed during the broadcast
+	Lagging bool `json:"lagging,omitempty"` // flag indicating if the endpoint lags behind the best endpoint
+}
+
+// CommitRate returns the committed share of the accepted transactions
+func (e *EndpointResult) CommitRate() float64 {
+	if e.Transactions == 0 {
+		return 0
+	}
+
+	return float64(e.Committed) / float64(e.Transactions)
+}
+
+// NewEndpointResults summarizes the accepted transactions of each endpoint, sorted by URL.
+// An endpoint lags if its median commit latency is over twice the best median,
+// or if its committed share is more than 10 points under the best share
+func NewEndpointResults(samples []EndpointSample, failed []string) []*EndpointResult {
+	var (
+		byURL     = make(map[string]*EndpointResult)
+		latencies = make(map[string][]time.Duration)
+	)
+
+	for _, sample := range samples {
+		result, ok := byURL[sample.URL]
+		if !ok {
+			result = &EndpointResult{URL: sample.URL}
+			byURL[sample.URL] = result
+		}
+
+		result.Transactions++
+
+		if !sample.Committed {
+			result.Lost++
+
+			continue
+		}
+
+		result.Committed++
+		latencies[sample.URL] = append(latencies[sample.URL], sample.Latency)
+	}
+
+	// The failed endpoints are reported, even if they accepted nothing
+	for _, url := range failed {
+		result, ok := byURL[url]
+		if !ok {
+			result = &EndpointResult{URL: url}
+			byURL[url] = result
+		}
+
+		result.Failed = true
+	}
+
+	results := make([]*EndpointResult, 0, len(byURL))
+
+	for url, result := range byURL {
+		result.CommitLatency = metrics.NewDistribution(latencies[url])
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].URL < results[j].URL
+	})
+
+	markLagging(results)
+
+	return results
+}
+
+// markLagging flags the endpoints lagging behind the best endpoint.
+// A single endpoint has nothing to lag behind
+func markLagging(results []*EndpointResult) {
+	var (
+		bestLatency time.Duration
+		bestRate    float64
+		active      = 0
+	)
+
+	for _, result := range results {
+		if result.Transactions == 0 {
+			continue
+		}
+
+		active++
+
+		if rate := result.CommitRate(); rate > bestRate {
+			bestRate = rate
+		}
+
+		if latency := result.CommitLatency; latency != nil && (bestLatency == 0 || latency.P50 < bestLatency) {
+			bestLatency = latency.P50
+		}
+	}
+
+	if active < 2 {
+		return
+	}
+
+	for _, result := range results {
+		if result.Transactions == 0 {
+			continue
+		}
+
+		slow := result.CommitLatency != nil && bestLatency > 0 &&
+			result.CommitLatency.P50 > lagLatencyFactor*bestLatency
+
+		result.Lagging = slow || bestRate-result.CommitRate() > lagCommitGap
+	}
+}
@@ -0,0 +1,72 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints_NewEndpointResults(t *testing.T) {
+	t.Parallel()
+
+	// samples generates the endpoint samples, with the given number of lost transactions
+	samples := func(url string, count, lost int, latency time.Duration) []EndpointSample {
+		generated := make([]EndpointSample, 0, count)
+
+		for i := 0; i < count; i++ {
+			generated = append(generated, EndpointSample{
+				URL:       url,
+				Committed: i >= lost,
+				Latency:   latency,
+			})
+		}
+
+		return generated
+	}
+
+	t.Run("lagging endpoints", func(t *testing.T) {
+		t.Parallel()
+
+		var all []EndpointSample
+
+		all = append(all, samples("http://node-0:26657", 100, 0, time.Second)...)
+		all = append(all, samples("http://node-1:26657", 100, 0, 3*time.Second)...)
+		all = append(all, samples("http://node-2:26657", 100, 20, time.Second)...)
+
+		results := NewEndpointResults(all, nil)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, "http://node-0:26657", results[0].URL)
+		assert.Equal(t, 100, results[0].Committed)
+		assert.Equal(t, time.Second, results[0].CommitLatency.P50)
+		assert.False(t, results[0].Lagging)
+
+		// The slow commits lag behind the best median
+		assert.True(t, results[1].Lagging)
+
+		// The lost transactions lag behind the best committed share
+		assert.Equal(t, 20, results[2].Lost)
+		assert.InDelta(t, 0.8, results[2].CommitRate(), 0.001)
+		assert.True(t, results[2].Lagging)
+	})
+
+	t.Run("failed endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		results := NewEndpointResults(
+			samples("http://node-1:26657", 10, 0, time.Second),
+			[]string{"http://node-0:26657"},
+		)
+		require.Len(t, results, 2)
+
+		assert.True(t, results[0].Failed)
+		assert.Zero(t, results[0].Transactions)
+		assert.Nil(t, results[0].CommitLatency)
+
+		// A single active endpoint has nothing to lag behind
+		assert.False(t, results[0].Lagging)
+		assert.False(t, results[1].Lagging)
+	})
+}
@@ -0,0 +1,36 @@
+package collector
+
+// InclusionResult is the outcome of the inclusion proof sampling, the
+// client-side evidence that the reported commits really happened
+type InclusionResult struct {
+	Strategy  string `json:"strategy"`  // the sampling strategy
+	Requested int    `json:"requested"` // the requested number of samples
+	Verified  int    `json:"verified"`
+	Failed    int    `json:"failed"`
+
+	// Credible indicates all sampled inclusion proofs were verified.
+	// Any failure means the reported commits can't be trusted
+	Credible bool `json:"credible"`
+
+	Samples []*InclusionSample `json:"samples"`
+}
+
+// InclusionSample is a single sampled committed transaction,
+// with its Merkle inclusion proof and block header hashes
+type InclusionSample struct {
+	Hash   string `json:"hash"`
+	Height int64  `json:"height"`
+
+	// The Merkle inclusion proof of the transaction hash,
+	// against the block header data hash
+	Index    int      `json:"index"`
+	Total    int      `json:"total"`
+	LeafHash string   `json:"leafHash"`
+	Aunts    []string `json:"aunts"`
+
+	DataHash  string `json:"dataHash"`  // the block header data hash (transactions Merkle root)
+	BlockHash string `json:"blockHash"` // the block header hash, signed by the validators
+
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"` // the verification failure, if any
+}
@@ -0,0 +1,195 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// commitSample is a single observed transaction commit latency
+type commitSample struct {
+	observed time.Time
+	latency  time.Duration
+}
+
+// LatencyMonitor follows the chain while the transactions are broadcast,
+// and keeps the rolling commit latency of the tracked transactions.
+// The commit time is the block time reported by the node
+type LatencyMonitor struct {
+	cli      Client
+	interval time.Duration
+	window   time.Duration
+
+	mux     sync.Mutex
+	pending map[string]time.Time // tx hash -> send time
+	commits []commitSample
+	next    int64 // the next block to be inspected
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewLatencyMonitor creates a new commit latency monitor, that polls
+// the chain at the given interval, and keeps the commits in the given window
+func NewLatencyMonitor(cli Client, interval, window time.Duration) *LatencyMonitor {
+	return &LatencyMonitor{
+		cli:      cli,
+		interval: interval,
+		window:   window,
+		pending:  make(map[string]time.Time),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts following the chain in the background
+func (m *LatencyMonitor) Start() {
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			// Poll failures are retried on the next tick,
+			// the commits are picked up from the missed blocks
+			_ = m.poll()
+
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops following the chain. It is safe to call multiple times
+func (m *LatencyMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+
+	<-m.done
+}
+
+// Track tracks the commit latency of the given transactions, sent at the given time
+func (m *LatencyMonitor) Track(txs [][]byte, sent time.Time) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, tx := range txs {
+		m.pending[string(types.Tx(tx).Hash())] = sent
+	}
+}
+
+// P95 returns the p95 commit latency of the transactions
+// committed in the latest window, along with the sample count
+func (m *LatencyMonitor) P95() (time.Duration, int) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var (
+		cutoff  = time.Now().Add(-m.window)
+		samples = make([]time.Duration, 0, len(m.commits))
+		kept    = m.commits[:0]
+	)
+
+	for _, commit := range m.commits {
+		if commit.observed.Before(cutoff) {
+			continue
+		}
+
+		kept = append(kept, commit)
+		samples = append(samples, commit.latency)
+	}
+
+	m.commits = kept
+
+	distribution := metrics.NewDistribution(samples)
+	if distribution == nil {
+		return 0, 0
+	}
+
+	return distribution.P95, distribution.Count
+}
+
+// Overdue returns the number of uncommitted transactions older than the bound.
+// Overdue transactions are not part of the p95, since they never committed
+func (m *LatencyMonitor) Overdue(bound time.Duration) int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var (
+		cutoff  = time.Now().Add(-bound)
+		overdue = 0
+	)
+
+	for _, sent := range m.pending {
+		if sent.Before(cutoff) {
+			overdue++
+		}
+	}
+
+	return overdue
+}
+
+// poll inspects the blocks committed since the last poll
+func (m *LatencyMonitor) poll() error {
+	latest, err := m.cli.GetLatestBlockHeight()
+	if err != nil {
+		return err
+	}
+
+	// The monitor is started before any transaction is sent
+	if m.next == 0 {
+		m.next = latest + 1
+
+		return nil
+	}
+
+	for ; m.next <= latest; m.next++ {
+		height := m.next
+
+		block, err := m.cli.GetBlock(&height)
+		if err != nil {
+			return err
+		}
+
+		m.record(block.Block.Txs, block.BlockMeta.Header.Time)
+	}
+
+	return nil
+}
+
+// record records the commit latency of the tracked block transactions
+func (m *LatencyMonitor) record(txs []types.Tx, committed time.Time) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	now := time.Now()
+
+	for _, tx := range txs {
+		hash := string(tx.Hash())
+
+		sent, tracked := m.pending[hash]
+		if !tracked {
+			continue
+		}
+
+		delete(m.pending, hash)
+
+		latency := committed.Sub(sent)
+		if latency < 0 {
+			latency = 0
+		}
+
+		m.commits = append(m.commits, commitSample{
+			observed: now,
+			latency:  latency,
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyMonitor_Poll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sent   = time.Now()
+		latest = int64(10)
+
+		committed = types.Tx("committed")
+		stuck     = types.Tx("stuck")
+		foreign   = types.Tx("foreign")
+	)
+
+	mockClient := &mockClient{
+		getLatestBlockHeightFn: func() (int64, error) {
+			return latest, nil
+		},
+		getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+			assert.Equal(t, int64(11), *height)
+
+			return &core_types.ResultBlock{
+				BlockMeta: &types.BlockMeta{
+					Header: types.Header{
+						Height: *height,
+						Time:   sent.Add(2 * time.Second),
+					},
+				},
+				Block: &types.Block{
+					Data: types.Data{
+						Txs: []types.Tx{committed, foreign},
+					},
+				},
+			}, nil
+		},
+	}
+
+	m := NewLatencyMonitor(mockClient, time.Second, time.Minute)
+
+	// The first poll only notes the starting block
+	assert.NoError(t, m.poll())
+
+	m.Track([][]byte{committed, stuck}, sent)
+
+	latest = 11
+	assert.NoError(t, m.poll())
+
+	// Make sure only the tracked commit is sampled
+	p95, samples := m.P95()
+
+	assert.Equal(t, 1, samples)
+	assert.Equal(t, 2*time.Second, p95)
+
+	// Make sure the uncommitted transaction is overdue
+	assert.Equal(t, 0, m.Overdue(time.Hour))
+	assert.Equal(t, 1, m.Overdue(-time.Second))
+}
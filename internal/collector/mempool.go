@@ -0,0 +1,113 @@
+package collector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/bft/types"
+)
+
+// maxUnconfirmedTxs is the maximum number of unconfirmed
+// transactions the node returns in a single query
+const maxUnconfirmedTxs = 100
+
+type MempoolClient interface {
+	GetUnconfirmedTxs(limit int) ([]types.Tx, error)
+}
+
+// MempoolSampler periodically samples the node mempool,
+// and records when each transaction was last observed in it.
+// Only the first page of unconfirmed transactions is visible to the
+// sampler, so the residency of deep mempool queues is underestimated
+type MempoolSampler struct {
+	cli      MempoolClient
+	interval time.Duration
+
+	mux      sync.Mutex
+	lastSeen map[string]time.Time // tx hash -> last seen time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewMempoolSampler creates a new mempool sampler,
+// that samples the mempool at the given interval
+func NewMempoolSampler(cli MempoolClient, interval time.Duration) *MempoolSampler {
+	return &MempoolSampler{
+		cli:      cli,
+		interval: interval,
+		lastSeen: make(map[string]time.Time),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts sampling the mempool in the background.
+// If the node does not expose the unconfirmed transactions,
+// the sampler stops right away
+func (s *MempoolSampler) Start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for samples := 0; ; samples++ {
+			if err := s.sample(); err != nil && samples == 0 {
+				fmt.Printf("\n⚠️ Unable to sample the mempool, latency attribution disabled, %v\n", err)
+
+				s.mux.Lock()
+				s.lastSeen = nil
+				s.mux.Unlock()
+
+				return
+			}
+
+			// Later sample errors are transient, and are skipped
+
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the mempool sampling, and returns the
+// last seen time for each sampled transaction hash.
+// Returns nil if the mempool could not be sampled.
+// It is safe to call multiple times
+func (s *MempoolSampler) Stop() map[string]time.Time {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	<-s.done
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.lastSeen
+}
+
+// sample records the transactions currently in the mempool
+func (s *MempoolSampler) sample() error {
+	txs, err := s.cli.GetUnconfirmedTxs(maxUnconfirmedTxs)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, tx := range txs {
+		s.lastSeen[string(tx.Hash())] = now
+	}
+
+	return nil
+}
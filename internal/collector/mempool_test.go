@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMempoolSampler_Sample(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transactions sampled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			txs     = []types.Tx{[]byte("tx-1"), []byte("tx-2")}
+			samples atomic.Int32
+
+			mockClient = &mockMempoolClient{
+				getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+					// The second transaction leaves the mempool
+					// after the first sample
+					if samples.Add(1) > 1 {
+						return txs[:1], nil
+					}
+
+					return txs, nil
+				},
+			}
+		)
+
+		sampler := NewMempoolSampler(mockClient, 10*time.Millisecond)
+		sampler.Start()
+
+		time.Sleep(100 * time.Millisecond)
+
+		lastSeen := sampler.Stop()
+
+		if lastSeen == nil {
+			t.Fatal("mempool not sampled")
+		}
+
+		assert.Len(t, lastSeen, len(txs))
+
+		// Make sure the residency is tracked by the last observation
+		assert.True(
+			t,
+			lastSeen[string(txs[1].Hash())].Before(lastSeen[string(txs[0].Hash())]),
+		)
+
+		// Make sure the sampler can be stopped again
+		assert.Len(t, sampler.Stop(), len(txs))
+	})
+
+	t.Run("mempool not exposed", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockMempoolClient{
+			getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+				return nil, errors.New("method not found")
+			},
+		}
+
+		sampler := NewMempoolSampler(mockClient, 10*time.Millisecond)
+		sampler.Start()
+
+		assert.Nil(t, sampler.Stop())
+	})
+}
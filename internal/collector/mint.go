@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// mintTrendPoints is the number of points in the mint commit latency trend
+const mintTrendPoints = 10
+
+// MintSample is a single committed token mint
+type MintSample struct {
+	Bytes   int           // the estimated storage bytes written by the mint
+	Height  int64         // the commit block height
+	Latency time.Duration // the commit latency, from the broadcast to the commit block time
+}
+
+// MintResult is the storage growth of the token mint (MINT) run
+type MintResult struct {
+	Realm        string `json:"realm"`
+	MetadataSize int    `json:"metadataSize"` // the metadata size of each token, in bytes
+
+	Mints          int   `json:"mints"`          // the number of committed mints
+	EstimatedBytes int64 `json:"estimatedBytes"` // the storage bytes written by the committed mints, estimated from the arguments
+
+	// Trend is the commit latency against the cumulative mints,
+	// in the commit order, so storage related slowdowns are visible
+	Trend []*MintTrendPoint `json:"trend,omitempty"`
+
+	// Slowdown is the ratio of the last trend point median
+	// commit latency to the first one, if there are multiple points
+	Slowdown float64 `json:"slowdown,omitempty"`
+}
+
+// MintTrendPoint is the commit latency of a consecutive range of committed mints
+type MintTrendPoint struct {
+	CumulativeMints int                   `json:"cumulativeMints"` // the committed mints, up to the end of the point
+	CumulativeBytes int64                 `json:"cumulativeBytes"` // the estimated storage bytes, up to the end of the point
+	Latency         *metrics.Distribution `json:"latency"`         // the commit latency of the point mints
+}
+
+// NewMintResult summarizes the committed mints, splitting them
+// into equally sized trend points, in the commit order
+func NewMintResult(realm string, metadataSize int, samples []MintSample) *MintResult {
+	result := &MintResult{
+		Realm:        realm,
+		MetadataSize: metadataSize,
+		Mints:        len(samples),
+	}
+
+	if len(samples) == 0 {
+		return result
+	}
+
+	sorted := make([]MintSample, len(samples))
+	copy(sorted, samples)
+
+	// The mints within the same block keep their broadcast order
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+
+	pointSize := (len(sorted) + mintTrendPoints - 1) / mintTrendPoints
+
+	for start := 0; start < len(sorted); start += pointSize {
+		end := start + pointSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+
+		latencies := make([]time.Duration, 0, end-start)
+
+		for _, sample := range sorted[start:end] {
+			result.EstimatedBytes += int64(sample.Bytes)
+
+			latencies = append(latencies, sample.Latency)
+		}
+
+		result.Trend = append(result.Trend, &MintTrendPoint{
+			CumulativeMints: end,
+			CumulativeBytes: result.EstimatedBytes,
+			Latency:         metrics.NewDistribution(latencies),
+		})
+	}
+
+	var (
+		first = result.Trend[0].Latency.P50
+		last  = result.Trend[len(result.Trend)-1].Latency.P50
+	)
+
+	if len(result.Trend) > 1 && first > 0 {
+		result.Slowdown = float64(last) / float64(first)
+	}
+
+	return result
+}
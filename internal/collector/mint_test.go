@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMint_NewMintResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("latency trend", func(t *testing.T) {
+		t.Parallel()
+
+		samples := make([]MintSample, 0, 25)
+
+		// The commit latency grows with the realm storage
+		for i := 24; i >= 0; i-- {
+			samples = append(samples, MintSample{
+				Bytes:   100,
+				Height:  int64(i/5 + 1),
+				Latency: time.Duration(i/5+1) * time.Second,
+			})
+		}
+
+		result := NewMintResult("gno.land/r/demo/mint_1", 84, samples)
+
+		assert.Equal(t, 25, result.Mints)
+		assert.Equal(t, int64(2500), result.EstimatedBytes)
+
+		if len(result.Trend) != 9 {
+			t.Fatalf("invalid number of trend points, %d", len(result.Trend))
+		}
+
+		// The points are in the commit order
+		assert.Equal(t, 3, result.Trend[0].CumulativeMints)
+		assert.Equal(t, int64(300), result.Trend[0].CumulativeBytes)
+		assert.Equal(t, time.Second, result.Trend[0].Latency.P50)
+
+		last := result.Trend[len(result.Trend)-1]
+
+		assert.Equal(t, 25, last.CumulativeMints)
+		assert.Equal(t, int64(2500), last.CumulativeBytes)
+		assert.Equal(t, 5*time.Second, last.Latency.P50)
+
+		assert.Equal(t, 5.0, result.Slowdown)
+	})
+
+	t.Run("single trend point", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewMintResult("gno.land/r/demo/mint_1", 84, []MintSample{
+			{Bytes: 100, Height: 1, Latency: time.Second},
+		})
+
+		assert.Len(t, result.Trend, 1)
+		assert.Zero(t, result.Slowdown)
+	})
+
+	t.Run("no committed mints", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewMintResult("gno.land/r/demo/mint_1", 84, nil)
+
+		assert.Zero(t, result.Mints)
+		assert.Empty(t, result.Trend)
+	})
+}
@@ -1,6 +1,9 @@
 package collector
 
-import core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+import (
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+)
 
 type (
 	getBlockDelegate             func(height *int64) (*core_types.ResultBlock, error)
@@ -47,3 +50,45 @@ func (m *mockClient) GetLatestBlockHeight() (int64, error) {
 
 	return 0, nil
 }
+
+type getUnconfirmedTxsDelegate func(limit int) ([]types.Tx, error)
+
+type mockMempoolClient struct {
+	getUnconfirmedTxsFn getUnconfirmedTxsDelegate
+}
+
+func (m *mockMempoolClient) GetUnconfirmedTxs(limit int) ([]types.Tx, error) {
+	if m.getUnconfirmedTxsFn != nil {
+		return m.getUnconfirmedTxsFn(limit)
+	}
+
+	return nil, nil
+}
+
+type getStatusDelegate func() (*core_types.ResultStatus, error)
+
+type mockStatusClient struct {
+	getStatusFn getStatusDelegate
+}
+
+func (m *mockStatusClient) GetStatus() (*core_types.ResultStatus, error) {
+	if m.getStatusFn != nil {
+		return m.getStatusFn()
+	}
+
+	return nil, nil
+}
+
+type getTxDelegate func(hash []byte) (*core_types.ResultTx, error)
+
+type mockTxClient struct {
+	getTxFn getTxDelegate
+}
+
+func (m *mockTxClient) GetTx(hash []byte) (*core_types.ResultTx, error) {
+	if m.getTxFn != nil {
+		return m.getTxFn(hash)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// NodeScraper periodically scrapes the Prometheus metrics endpoint
+// exposed by the node. Scrape failures are recorded as timeline gaps,
+// and never affect the run
+type NodeScraper struct {
+	url      string
+	names    []string
+	interval time.Duration
+	client   *http.Client
+
+	mux     sync.Mutex
+	samples []*metrics.NodeSample
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewNodeScraper creates a new node metrics scraper, that scrapes
+// the given metrics from the Prometheus endpoint at the given interval
+func NewNodeScraper(url string, names []string, interval time.Duration) *NodeScraper {
+	return &NodeScraper{
+		url:      url,
+		names:    names,
+		interval: interval,
+		client: &http.Client{
+			// The scraper owns its transport, so its idle
+			// connections can be released once it stops
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+			// A slow endpoint should not stall the timeline
+			Timeout: interval,
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Start starts scraping the node metrics in the background
+func (s *NodeScraper) Start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.scrape()
+
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the node metrics scraping, and returns the scraped timeline.
+// It is safe to call multiple times
+func (s *NodeScraper) Stop() *metrics.NodeMetrics {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	<-s.done
+
+	s.client.CloseIdleConnections()
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return metrics.NewNodeMetrics(s.url, s.interval, s.samples)
+}
+
+// scrape records a single node metrics sample, or a gap if the scrape fails
+func (s *NodeScraper) scrape() {
+	sample := &metrics.NodeSample{
+		Time: time.Now(),
+	}
+
+	values, err := s.fetch()
+	if err != nil {
+		sample.Gap = true
+	} else {
+		sample.Values = values
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.samples = append(s.samples, sample)
+}
+
+// fetch fetches the metric values from the Prometheus endpoint
+func (s *NodeScraper) fetch() (map[string]float64, error) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scrape node metrics, %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to scrape node metrics, status %d", resp.StatusCode)
+	}
+
+	return metrics.ParsePrometheus(resp.Body, s.names)
+}
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeScraper_Scrape(t *testing.T) {
+	t.Parallel()
+
+	var scrapes atomic.Int32
+
+	// Every other scrape fails
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		count := scrapes.Add(1)
+
+		if count%2 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "tendermint_mempool_size %d\n", count*10)
+	}))
+	defer server.Close()
+
+	scraper := NewNodeScraper(server.URL, []string{"tendermint_mempool_size"}, 10*time.Millisecond)
+	scraper.Start()
+
+	// Wait for a few scrapes
+	deadline := time.Now().Add(5 * time.Second)
+	for scrapes.Load() < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	node := scraper.Stop()
+
+	if !assert.GreaterOrEqual(t, len(node.Samples), 4) {
+		return
+	}
+
+	// Make sure the failed scrapes are recorded as gaps
+	assert.False(t, node.Samples[0].Gap)
+	assert.True(t, node.Samples[1].Gap)
+	assert.GreaterOrEqual(t, node.Gaps, 2)
+
+	if assert.NotNil(t, node.PeakMempoolSize) {
+		assert.GreaterOrEqual(t, *node.PeakMempoolSize, float64(30))
+	}
+
+	// Make sure stopping again is safe
+	assert.Equal(t, len(node.Samples), len(scraper.Stop().Samples))
+}
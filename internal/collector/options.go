@@ -0,0 +1,53 @@
+package collector
+
+import "time"
+
+type Option func(c *Collector)
+
+// WithCompletionThreshold sets the ratio of broadcast transactions
+// that need to be observed as committed, before the collection can
+// finalize. The collection is finalized once no new transactions are
+// observed for the duration of the grace window
+func WithCompletionThreshold(threshold float64, graceWindow time.Duration) Option {
+	return func(c *Collector) {
+		c.completionThreshold = threshold
+		c.graceWindow = graceWindow
+	}
+}
+
+// WithDeadline finalizes the collection once the deadline has elapsed
+// since the run start, regardless of the number of observed transactions.
+// The transactions not observed by the deadline are counted as lost
+func WithDeadline(deadline time.Duration) Option {
+	return func(c *Collector) {
+		c.deadline = deadline
+	}
+}
+
+// WithResultsSegments enables time-sliced results, where an intermediate
+// results segment covering only its time window is finalized and written
+// out every report interval, while the collection continues
+func WithResultsSegments(interval time.Duration, writer SegmentWriter) Option {
+	return func(c *Collector) {
+		c.reportInterval = interval
+		c.segmentWriter = writer
+	}
+}
+
+// WithStallDetection enables the block production stall detection, where
+// no new height for the factor times the recent average block interval
+// is reported as a possible chain halt. The node status is captured
+// at the detection, if a status client is set
+func WithStallDetection(factor float64, status StatusClient) Option {
+	return func(c *Collector) {
+		c.stallFactor = factor
+		c.statusClient = status
+	}
+}
+
+// WithProgress reports the collection progress after each block with run transactions
+func WithProgress(progress Progress) Option {
+	return func(c *Collector) {
+		c.progress = progress
+	}
+}
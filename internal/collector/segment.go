@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"fmt"
+	"time"
+)
+
+// SegmentWriter persists a single finalized results segment
+type SegmentWriter func(segment *SegmentResult) error
+
+// Progress reports the total number of committed run transactions, after each
+// block with run transactions. It is invoked on the collection path, so it should not block
+type Progress func(committed int)
+
+// segmenter splits the collected block results into time-sliced segments.
+// Segments are only finalized in between block ranges, so no block's
+// transactions are ever split across segments
+type segmenter struct {
+	interval time.Duration
+	write    SegmentWriter
+
+	windowStart time.Time      // the start of the current segment window
+	lastFlush   time.Time      // the wall time of the last segment flush
+	blocks      []*BlockResult // the blocks of the current segment
+	txs         int            // the run transactions of the current segment
+
+	segments []*SegmentResult // the finalized segment summaries
+}
+
+// newSegmenter creates a new segmenter instance.
+// If no writer is specified, segmenting is disabled
+func newSegmenter(interval time.Duration, write SegmentWriter, startTime time.Time) *segmenter {
+	return &segmenter{
+		interval:    interval,
+		write:       write,
+		windowStart: startTime,
+		lastFlush:   time.Now(),
+	}
+}
+
+// add adds the block to the current segment
+func (s *segmenter) add(block *BlockResult, txs int) {
+	if s.write == nil {
+		return
+	}
+
+	s.blocks = append(s.blocks, block)
+	s.txs += txs
+}
+
+// maybeFlush finalizes the current segment, if the report interval elapsed
+func (s *segmenter) maybeFlush() error {
+	if s.write == nil || time.Since(s.lastFlush) < s.interval {
+		return nil
+	}
+
+	return s.flush()
+}
+
+// flush finalizes and writes out the current segment, if it contains any blocks
+func (s *segmenter) flush() error {
+	s.lastFlush = time.Now()
+
+	if s.write == nil || len(s.blocks) == 0 {
+		return nil
+	}
+
+	var (
+		windowEnd = s.blocks[len(s.blocks)-1].Time
+		segment   = &SegmentResult{
+			Index:        len(s.segments) + 1,
+			StartTime:    s.windowStart,
+			EndTime:      windowEnd,
+			Transactions: s.txs,
+			AverageTPS:   calculateTPS(s.windowStart, windowEnd, s.txs),
+			Blocks:       s.blocks,
+		}
+	)
+
+	if err := s.write(segment); err != nil {
+		return fmt.Errorf("unable to write results segment %d, %w", segment.Index, err)
+	}
+
+	// Only the summary is kept for the final results
+	summary := *segment
+	summary.Blocks = nil
+
+	s.segments = append(s.segments, &summary)
+
+	s.windowStart = windowEnd
+	s.blocks = nil
+	s.txs = 0
+
+	return nil
+}
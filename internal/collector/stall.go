@@ -0,0 +1,220 @@
+package collector
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+)
+
+// stallIntervalWindow is the number of recent blocks
+// the average block interval is calculated over
+const stallIntervalWindow = 20
+
+// StatusClient fetches the node status, captured when the chain stalls
+type StatusClient interface {
+	GetStatus() (*core_types.ResultStatus, error)
+}
+
+// StallResult is a single block production stall (possible chain halt),
+// observed during the collection
+type StallResult struct {
+	Height int64 `json:"height"` // the last height produced before the stall
+
+	Start    time.Time     `json:"start"`         // when the last height was first observed
+	End      time.Time     `json:"end,omitempty"` // when blocks resumed, or the collection ended
+	Duration time.Duration `json:"duration"`
+	Resumed  bool          `json:"resumed"` // flag indicating if blocks resumed before the collection ended
+
+	AverageInterval time.Duration `json:"averageInterval"` // the recent average block interval, at detection
+
+	LastBlock  *StallBlock      `json:"lastBlock,omitempty"`
+	NodeStatus *StallNodeStatus `json:"nodeStatus,omitempty"`
+}
+
+// StallBlock is the metadata of the last block before the stall
+type StallBlock struct {
+	Height   int64     `json:"height"`
+	Time     time.Time `json:"time"`
+	NumTxs   int64     `json:"numTxs"`
+	Proposer string    `json:"proposer"`
+	AppHash  string    `json:"appHash"`
+}
+
+// StallNodeStatus is the node status captured at the stall detection
+type StallNodeStatus struct {
+	Moniker           string    `json:"moniker"`
+	Network           string    `json:"network"`
+	LatestBlockHeight int64     `json:"latestBlockHeight"`
+	LatestBlockTime   time.Time `json:"latestBlockTime"`
+	CatchingUp        bool      `json:"catchingUp"`
+}
+
+// stallDetector detects block production stalls, when no new height is
+// observed for a multiple of the recent average block interval
+type stallDetector struct {
+	factor float64      // the multiple of the average block interval considered a stall
+	status StatusClient // the node status client, if any
+
+	times     []time.Time             // the recent block times
+	lastBlock *core_types.ResultBlock // the latest fetched block
+
+	height   int64     // the latest observed height
+	observed time.Time // when the latest height was first observed
+
+	current *StallResult   // the ongoing stall, if any
+	stalls  []*StallResult // all stalls, in order
+}
+
+// newStallDetector creates a new stall detector. A zero factor disables the detection
+func newStallDetector(factor float64, status StatusClient) *stallDetector {
+	return &stallDetector{
+		factor: factor,
+		status: status,
+	}
+}
+
+// addBlock records the fetched block, for the average block interval
+func (d *stallDetector) addBlock(block *core_types.ResultBlock) {
+	if d.factor <= 0 || block == nil {
+		return
+	}
+
+	d.lastBlock = block
+	d.times = append(d.times, block.BlockMeta.Header.Time)
+
+	if len(d.times) > stallIntervalWindow {
+		d.times = d.times[len(d.times)-stallIntervalWindow:]
+	}
+}
+
+// observe records the latest chain height, and detects
+// the start and the end of block production stalls
+func (d *stallDetector) observe(latest int64, now time.Time) {
+	if d.factor <= 0 {
+		return
+	}
+
+	if latest > d.height {
+		if d.current != nil {
+			d.resume(latest, now)
+		}
+
+		d.height = latest
+		d.observed = now
+
+		return
+	}
+
+	if d.current != nil {
+		d.current.Duration = now.Sub(d.current.Start)
+
+		return
+	}
+
+	average, ok := d.averageInterval()
+	if !ok {
+		return
+	}
+
+	threshold := time.Duration(d.factor * float64(average))
+	if now.Sub(d.observed) < threshold {
+		return
+	}
+
+	d.detect(average, now)
+}
+
+// stalled returns true if a stall is ongoing
+func (d *stallDetector) stalled() bool {
+	return d.current != nil
+}
+
+// finish ends the ongoing stall, if any, and returns all stalls
+func (d *stallDetector) finish(now time.Time) []*StallResult {
+	if d.current != nil {
+		d.current.End = now
+		d.current.Duration = now.Sub(d.current.Start)
+		d.current = nil
+	}
+
+	return d.stalls
+}
+
+// averageInterval returns the recent average block interval, if known
+func (d *stallDetector) averageInterval() (time.Duration, bool) {
+	if len(d.times) < 2 {
+		return 0, false
+	}
+
+	average := d.times[len(d.times)-1].Sub(d.times[0]) / time.Duration(len(d.times)-1)
+	if average <= 0 {
+		return 0, false
+	}
+
+	return average, true
+}
+
+// detect starts a new stall, capturing the node status and the last block
+func (d *stallDetector) detect(average time.Duration, now time.Time) {
+	stall := &StallResult{
+		Height:          d.height,
+		Start:           d.observed,
+		Duration:        now.Sub(d.observed),
+		AverageInterval: average,
+	}
+
+	fmt.Printf(
+		"\n⚠️ Possible chain halt at height %d, no new block for %s (%.1fx the average block interval of %s)\n",
+		d.height,
+		stall.Duration.Round(time.Millisecond),
+		float64(stall.Duration)/float64(average),
+		average.Round(time.Millisecond),
+	)
+
+	if d.lastBlock != nil {
+		header := d.lastBlock.BlockMeta.Header
+
+		stall.LastBlock = &StallBlock{
+			Height:   header.Height,
+			Time:     header.Time,
+			NumTxs:   header.NumTxs,
+			Proposer: header.ProposerAddress.String(),
+			AppHash:  hex.EncodeToString(header.AppHash),
+		}
+	}
+
+	if d.status != nil {
+		status, err := d.status.GetStatus()
+		if err != nil {
+			fmt.Printf("⚠️ Unable to capture the node status, %v\n", err)
+		} else {
+			stall.NodeStatus = &StallNodeStatus{
+				Moniker:           status.NodeInfo.Moniker,
+				Network:           status.NodeInfo.Network,
+				LatestBlockHeight: status.SyncInfo.LatestBlockHeight,
+				LatestBlockTime:   status.SyncInfo.LatestBlockTime,
+				CatchingUp:        status.SyncInfo.CatchingUp,
+			}
+		}
+	}
+
+	d.current = stall
+	d.stalls = append(d.stalls, stall)
+}
+
+// resume ends the ongoing stall, once a new height is observed
+func (d *stallDetector) resume(latest int64, now time.Time) {
+	d.current.End = now
+	d.current.Duration = now.Sub(d.current.Start)
+	d.current.Resumed = true
+
+	fmt.Printf(
+		"\n✅ Block production resumed at height %d, after a %s stall\n",
+		latest,
+		d.current.Duration.Round(time.Millisecond),
+	)
+
+	d.current = nil
+}
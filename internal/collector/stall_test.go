@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateStallBlock generates a block at the given height and time
+func generateStallBlock(height int64, blockTime time.Time) *core_types.ResultBlock {
+	return &core_types.ResultBlock{
+		BlockMeta: &types.BlockMeta{
+			Header: types.Header{
+				Height: height,
+				Time:   blockTime,
+				NumTxs: 10,
+			},
+		},
+	}
+}
+
+func TestStallDetector_Observe(t *testing.T) {
+	t.Parallel()
+
+	var (
+		start = time.Now()
+
+		// observeBlocks observes the blocks produced every second
+		observeBlocks = func(d *stallDetector, from, to int64) {
+			for height := from; height <= to; height++ {
+				blockTime := start.Add(time.Duration(height) * time.Second)
+
+				d.addBlock(generateStallBlock(height, blockTime))
+				d.observe(height, blockTime)
+			}
+		}
+	)
+
+	t.Run("halt and resume", func(t *testing.T) {
+		t.Parallel()
+
+		status := &mockStatusClient{
+			getStatusFn: func() (*core_types.ResultStatus, error) {
+				return &core_types.ResultStatus{
+					SyncInfo: core_types.SyncInfo{
+						LatestBlockHeight: 5,
+						CatchingUp:        true,
+					},
+				}, nil
+			},
+		}
+
+		d := newStallDetector(3, status)
+
+		observeBlocks(d, 1, 5)
+
+		lastBlock := start.Add(5 * time.Second)
+
+		// Under the threshold, the chain is not stalled
+		d.observe(5, lastBlock.Add(2*time.Second))
+		assert.False(t, d.stalled())
+
+		// Over the threshold, the stall is detected
+		d.observe(5, lastBlock.Add(4*time.Second))
+
+		if !assert.True(t, d.stalled()) {
+			return
+		}
+
+		// The stall keeps counting until blocks resume
+		d.observe(5, lastBlock.Add(10*time.Second))
+		d.observe(6, lastBlock.Add(12*time.Second))
+
+		assert.False(t, d.stalled())
+
+		stalls := d.finish(lastBlock.Add(20 * time.Second))
+
+		if len(stalls) != 1 {
+			t.Fatalf("invalid number of stalls, %d", len(stalls))
+		}
+
+		stall := stalls[0]
+
+		assert.Equal(t, int64(5), stall.Height)
+		assert.True(t, stall.Resumed)
+		assert.Equal(t, 12*time.Second, stall.Duration)
+		assert.Equal(t, time.Second, stall.AverageInterval)
+
+		if assert.NotNil(t, stall.LastBlock) {
+			assert.Equal(t, int64(5), stall.LastBlock.Height)
+			assert.Equal(t, int64(10), stall.LastBlock.NumTxs)
+		}
+
+		if assert.NotNil(t, stall.NodeStatus) {
+			assert.True(t, stall.NodeStatus.CatchingUp)
+			assert.Equal(t, int64(5), stall.NodeStatus.LatestBlockHeight)
+		}
+	})
+
+	t.Run("not resumed", func(t *testing.T) {
+		t.Parallel()
+
+		status := &mockStatusClient{
+			getStatusFn: func() (*core_types.ResultStatus, error) {
+				return nil, errors.New("node unavailable")
+			},
+		}
+
+		d := newStallDetector(3, status)
+
+		observeBlocks(d, 1, 5)
+
+		lastBlock := start.Add(5 * time.Second)
+
+		d.observe(5, lastBlock.Add(4*time.Second))
+
+		stalls := d.finish(lastBlock.Add(30 * time.Second))
+
+		if len(stalls) != 1 {
+			t.Fatalf("invalid number of stalls, %d", len(stalls))
+		}
+
+		// The stall is counted until the collection ends
+		assert.False(t, stalls[0].Resumed)
+		assert.Equal(t, 30*time.Second, stalls[0].Duration)
+		assert.Nil(t, stalls[0].NodeStatus)
+	})
+
+	t.Run("unknown block interval", func(t *testing.T) {
+		t.Parallel()
+
+		d := newStallDetector(3, nil)
+
+		observeBlocks(d, 1, 1)
+
+		d.observe(1, start.Add(time.Minute))
+
+		assert.False(t, d.stalled())
+		assert.Empty(t, d.finish(start.Add(time.Minute)))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		d := newStallDetector(0, nil)
+
+		observeBlocks(d, 1, 5)
+
+		d.observe(5, start.Add(time.Hour))
+
+		assert.False(t, d.stalled())
+	})
+}
@@ -0,0 +1,231 @@
+package collector
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// peakWindow is the minimum duration of the peak sliding window
+	peakWindow = 5 * time.Second
+
+	// steadyTrim is the fraction of the commit span trimmed
+	// on each side (the ramp and the mempool drain tail)
+	steadyTrim = 0.2
+
+	// steadyRateTrim is the fraction of the per-block rates
+	// discarded on each side for the trimmed mean
+	steadyRateTrim = 0.1
+
+	// steadyMinBlocks is the minimum number of blocks for a steady state
+	steadyMinBlocks = 3
+)
+
+// SteadyStateHeuristic describes the steady-state detection, recorded in the results so it can be audited
+const SteadyStateHeuristic = "peak: the best sliding window of at least 5s of committed blocks " +
+	"(the whole commit span, if shorter); " +
+	"steady state: the blocks committed after the first 20% and before the last 20% of the commit span, " +
+	"and before the broadcast ended, with the mean of the per-block rates after discarding " +
+	"the highest and lowest 10% (requires at least 3 blocks); " +
+	"end-to-end: the committed run transactions over the time from the broadcast start to the last block. " +
+	"Peak and steady state rates count all block transactions"
+
+// ThroughputResult separates the run throughput into the
+// peak, steady-state and end-to-end figures
+type ThroughputResult struct {
+	Peak        *TPSWindow `json:"peak"`
+	SteadyState *TPSWindow `json:"steadyState,omitempty"` // nil if no steady state was detected
+	EndToEnd    *TPSWindow `json:"endToEnd"`
+
+	Heuristic string `json:"heuristic"`
+}
+
+// TPSWindow is the throughput over a single window of the run
+type TPSWindow struct {
+	TPS float64 `json:"tps"`
+
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	StartBlock int64     `json:"startBlock"`
+	EndBlock   int64     `json:"endBlock"`
+	Blocks     int       `json:"blocks"`
+}
+
+// blockInterval is the commit interval of a single block,
+// from the previous (collected) block, or the broadcast start
+type blockInterval struct {
+	block *BlockResult
+	start time.Time
+}
+
+// duration returns the interval duration
+func (b blockInterval) duration() time.Duration {
+	return b.block.Time.Sub(b.start)
+}
+
+// NewThroughputResult computes the throughput figures of the run,
+// from the collected blocks and the broadcast start and end times
+func NewThroughputResult(
+	blocks []*BlockResult,
+	committed int,
+	sendStart,
+	sendEnd time.Time,
+) *ThroughputResult {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	intervals := make([]blockInterval, 0, len(blocks))
+	previous := sendStart
+
+	for _, block := range blocks {
+		intervals = append(intervals, blockInterval{
+			block: block,
+			start: previous,
+		})
+
+		previous = block.Time
+	}
+
+	last := blocks[len(blocks)-1]
+
+	endToEnd := newTPSWindow(intervals)
+	endToEnd.TPS = rate(int64(committed), last.Time.Sub(sendStart))
+
+	return &ThroughputResult{
+		Peak:        peakTPS(intervals),
+		SteadyState: steadyStateTPS(intervals, sendEnd),
+		EndToEnd:    endToEnd,
+		Heuristic:   SteadyStateHeuristic,
+	}
+}
+
+// peakTPS returns the best sliding window of at least the peak window duration.
+// Commit spans shorter than the peak window are a single window
+func peakTPS(intervals []blockInterval) *TPSWindow {
+	var (
+		best  *TPSWindow
+		txs   int64
+		first = 0
+	)
+
+	for end := range intervals {
+		txs += intervals[end].block.Transactions
+
+		// Shrink the window from the start, as long as it stays long enough
+		for first < end {
+			next := intervals[first+1]
+			if intervals[end].block.Time.Sub(next.start) < peakWindow {
+				break
+			}
+
+			txs -= intervals[first].block.Transactions
+			first++
+		}
+
+		duration := intervals[end].block.Time.Sub(intervals[first].start)
+		if duration < peakWindow && end != len(intervals)-1 {
+			continue
+		}
+
+		tps := rate(txs, duration)
+		if best == nil || tps > best.TPS {
+			best = newTPSWindow(intervals[first : end+1])
+			best.TPS = tps
+		}
+	}
+
+	return best
+}
+
+// steadyStateTPS returns the steady-state window of the run, after trimming the ramp
+// and the drain tail, as the trimmed mean of the per-block rates.
+// Returns nil if the window is too short for a steady state
+func steadyStateTPS(intervals []blockInterval, sendEnd time.Time) *TPSWindow {
+	var (
+		spanStart = intervals[0].start
+		spanEnd   = intervals[len(intervals)-1].block.Time
+		span      = spanEnd.Sub(spanStart)
+
+		steadyStart = spanStart.Add(time.Duration(float64(span) * steadyTrim))
+		steadyEnd   = spanEnd.Add(-time.Duration(float64(span) * steadyTrim))
+	)
+
+	// Blocks committed after the broadcast ended only drain the mempool,
+	// so the drain window is never part of the steady state
+	if !sendEnd.IsZero() && sendEnd.Before(steadyEnd) {
+		steadyEnd = sendEnd
+	}
+
+	window := make([]blockInterval, 0, len(intervals))
+
+	for _, interval := range intervals {
+		if interval.start.Before(steadyStart) || interval.block.Time.After(steadyEnd) {
+			continue
+		}
+
+		if interval.duration() <= 0 {
+			continue
+		}
+
+		window = append(window, interval)
+	}
+
+	if len(window) < steadyMinBlocks {
+		return nil
+	}
+
+	rates := make([]float64, 0, len(window))
+	for _, interval := range window {
+		rates = append(rates, rate(interval.block.Transactions, interval.duration()))
+	}
+
+	steady := newTPSWindow(window)
+	steady.TPS = trimmedMean(rates, steadyRateTrim)
+
+	return steady
+}
+
+// trimmedMean returns the mean of the values, after
+// discarding the given fraction of values on each side
+func trimmedMean(values []float64, trim float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	discard := int(math.Floor(float64(len(sorted)) * trim))
+	sorted = sorted[discard : len(sorted)-discard]
+
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+
+	return sum / float64(len(sorted))
+}
+
+// newTPSWindow creates the (rate-less) window spanning the given intervals
+func newTPSWindow(intervals []blockInterval) *TPSWindow {
+	var (
+		first = intervals[0]
+		last  = intervals[len(intervals)-1]
+	)
+
+	return &TPSWindow{
+		Start:      first.start,
+		End:        last.block.Time,
+		StartBlock: first.block.Number,
+		EndBlock:   last.block.Number,
+		Blocks:     len(intervals),
+	}
+}
+
+// rate returns the transactions per second over the duration.
+// Instant commits count as a single second
+func rate(txs int64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return float64(txs)
+	}
+
+	return float64(txs) / duration.Seconds()
+}
@@ -0,0 +1,109 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generateBlocks generates 1s blocks with the given transaction counts
+func generateBlocks(start time.Time, counts ...int64) []*BlockResult {
+	blocks := make([]*BlockResult, 0, len(counts))
+
+	for index, count := range counts {
+		blocks = append(blocks, &BlockResult{
+			Number:       int64(index + 1),
+			Time:         start.Add(time.Duration(index+1) * time.Second),
+			Transactions: count,
+		})
+	}
+
+	return blocks
+}
+
+func TestThroughput_NewThroughputResult(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ramp, plateau and tail", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			// 2 ramp blocks, 16 plateau blocks (with a single outlier), 2 tail blocks
+			counts = []int64{
+				10, 50,
+				100, 100, 100, 100, 100, 100, 100, 100, 400, 100, 100, 100, 100, 100, 100, 100,
+				30, 5,
+			}
+			blocks  = generateBlocks(start, counts...)
+			sendEnd = start.Add(18 * time.Second)
+		)
+
+		committed := int64(0)
+		for _, count := range counts {
+			committed += count
+		}
+
+		result := NewThroughputResult(blocks, int(committed), start, sendEnd)
+		if result == nil {
+			t.Fatalf("throughput not computed")
+		}
+
+		assert.Equal(t, SteadyStateHeuristic, result.Heuristic)
+
+		// The peak window includes the outlier block
+		assert.InDelta(t, 160, result.Peak.TPS, 1e-9)
+		assert.Equal(t, peakWindow, result.Peak.End.Sub(result.Peak.Start))
+
+		// The outlier is trimmed from the steady state
+		if assert.NotNil(t, result.SteadyState) {
+			assert.InDelta(t, 100, result.SteadyState.TPS, 1e-9)
+			assert.Equal(t, int64(5), result.SteadyState.StartBlock)
+			assert.Equal(t, int64(16), result.SteadyState.EndBlock)
+		}
+
+		assert.InDelta(t, float64(committed)/20, result.EndToEnd.TPS, 1e-9)
+		assert.Equal(t, 20, result.EndToEnd.Blocks)
+	})
+
+	t.Run("short run", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := generateBlocks(start, 100, 200)
+
+		result := NewThroughputResult(blocks, 300, start, time.Time{})
+		if result == nil {
+			t.Fatalf("throughput not computed")
+		}
+
+		// The whole (short) commit span is the peak window
+		assert.InDelta(t, 150, result.Peak.TPS, 1e-9)
+		assert.Equal(t, 2, result.Peak.Blocks)
+		assert.Nil(t, result.SteadyState)
+	})
+
+	t.Run("drain only", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			blocks  = generateBlocks(start, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100)
+			sendEnd = start.Add(time.Second)
+		)
+
+		result := NewThroughputResult(blocks, 1000, start, sendEnd)
+		if result == nil {
+			t.Fatalf("throughput not computed")
+		}
+
+		// The blocks are all committed during the drain
+		assert.Nil(t, result.SteadyState)
+	})
+
+	t.Run("no blocks", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, NewThroughputResult(nil, 0, start, start))
+	})
+}
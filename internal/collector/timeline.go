@@ -0,0 +1,70 @@
+package collector
+
+// NormalizeTimeline converts the client wall times of the run timeline to UTC,
+// so the results join the node logs on the timestamp. The block times
+// reported by the node are kept unmodified, alongside them
+func (r *RunResult) NormalizeTimeline() {
+	r.StartTime = r.StartTime.UTC()
+	r.EndTime = r.EndTime.UTC()
+
+	for _, phase := range r.Phases {
+		phase.StartTime = phase.StartTime.UTC()
+		phase.EndTime = phase.EndTime.UTC()
+	}
+
+	// The segment windows end on the last block time
+	for _, segment := range r.Segments {
+		segment.StartTime = segment.StartTime.UTC()
+	}
+
+	for _, stall := range r.Stalls {
+		stall.Start = stall.Start.UTC()
+		stall.End = stall.End.UTC()
+	}
+
+	// The drain ends on the commit block time
+	if r.Drain != nil {
+		r.Drain.Start = r.Drain.Start.UTC()
+	}
+
+	// The throughput windows end on the last block time
+	if throughput := r.Throughput; throughput != nil {
+		for _, window := range []*TPSWindow{throughput.Peak, throughput.SteadyState, throughput.EndToEnd} {
+			if window != nil {
+				window.Start = window.Start.UTC()
+			}
+		}
+	}
+
+	if client := r.Client; client != nil {
+		client.BroadcastStart = client.BroadcastStart.UTC()
+		client.BroadcastEnd = client.BroadcastEnd.UTC()
+
+		for _, sample := range client.Samples {
+			sample.Time = sample.Time.UTC()
+		}
+	}
+
+	if node := r.Node; node != nil {
+		for _, sample := range node.Samples {
+			sample.Time = sample.Time.UTC()
+		}
+	}
+
+	if pacing := r.Pacing; pacing != nil {
+		for _, point := range pacing.Trajectory {
+			point.Time = point.Time.UTC()
+		}
+	}
+
+	if dispatch := r.Dispatch; dispatch != nil {
+		for _, account := range dispatch.Accounts {
+			account.First = account.First.UTC()
+			account.Last = account.Last.UTC()
+		}
+	}
+
+	if r.Clock != nil {
+		r.Clock.Wall = r.Clock.Wall.UTC()
+	}
+}
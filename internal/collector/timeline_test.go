@@ -0,0 +1,48 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunResult_NormalizeTimeline(t *testing.T) {
+	t.Parallel()
+
+	var (
+		zone      = time.FixedZone("CEST", 2*60*60)
+		local     = time.Date(2026, 10, 14, 14, 0, 0, 0, zone)
+		blockTime = time.Date(2026, 10, 14, 12, 0, 5, 0, zone)
+	)
+
+	result := &RunResult{
+		StartTime: local,
+		EndTime:   local.Add(time.Minute),
+		Blocks:    []*BlockResult{{Number: 1, Time: blockTime}},
+		Phases:    []*PhaseResult{{Name: "collect", StartTime: local, EndTime: local.Add(time.Second)}},
+		Segments:  []*SegmentResult{{StartTime: local, EndTime: blockTime}},
+		Drain:     &DrainResult{Start: local, End: blockTime},
+		Client: &metrics.ClientMetrics{
+			BroadcastStart: local,
+			Samples:        []*metrics.ClientSample{{Time: local}},
+		},
+	}
+
+	result.NormalizeTimeline()
+
+	// The client wall times are in UTC, for the same instants
+	assert.Equal(t, time.UTC, result.StartTime.Location())
+	assert.True(t, result.StartTime.Equal(local))
+	assert.Equal(t, "2026-10-14T12:00:00Z", result.StartTime.Format(time.RFC3339))
+	assert.Equal(t, time.UTC, result.Phases[0].EndTime.Location())
+	assert.Equal(t, time.UTC, result.Segments[0].StartTime.Location())
+	assert.Equal(t, time.UTC, result.Drain.Start.Location())
+	assert.Equal(t, time.UTC, result.Client.Samples[0].Time.Location())
+
+	// The node reported block times are kept unmodified
+	assert.Equal(t, zone, result.Blocks[0].Time.Location())
+	assert.Equal(t, zone, result.Segments[0].EndTime.Location())
+	assert.Equal(t, zone, result.Drain.End.Location())
+}
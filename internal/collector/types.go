@@ -4,6 +4,10 @@ import (
 	"time"
 
 	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/supernova/internal/artifact"
+	"github.com/gnolang/supernova/internal/feature"
+	"github.com/gnolang/supernova/internal/fees"
+	"github.com/gnolang/supernova/internal/metrics"
 )
 
 type Client interface {
@@ -13,10 +17,214 @@ type Client interface {
 	GetLatestBlockHeight() (int64, error)
 }
 
+// SchemaVersion is the current version of the run results schema.
+// Results without a version predate the versioning
+const SchemaVersion = 1
+
 // RunResult is the complete test-run result
 type RunResult struct {
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+
+	RunID      string         `json:"runId"`
+	Label      string         `json:"label,omitempty"`
+	Preset     string         `json:"preset,omitempty"`
+	Shard      *ShardResult   `json:"shard,omitempty"`
 	AverageTPS int            `json:"averageTPS"`
 	Blocks     []*BlockResult `json:"blocks"`
+	Phases     []*PhaseResult `json:"phases"`
+	Costs      *CostResult    `json:"costs,omitempty"`
+
+	// StartTime and EndTime are the wall-clock bounds of the run, in UTC
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	CompletionThreshold float64 `json:"completionThreshold"`
+	CommittedTxs        int     `json:"committedTransactions"`
+	LostTxs             int     `json:"lostTransactions"`
+
+	Segments []*SegmentResult `json:"segments,omitempty"`
+
+	// Stalls are the block production stalls (possible chain halts)
+	// observed during the collection
+	Stalls []*StallResult `json:"stalls,omitempty"`
+
+	// GasSource is the least precise source of the block gas used
+	// (block results, tx backfill, tx sample, or unavailable)
+	GasSource string `json:"gasSource,omitempty"`
+
+	// Backfill is the per-tx backfill of the blocks without results, if any
+	Backfill *BackfillResult `json:"backfill,omitempty"`
+
+	// Indexer is the node tx indexer availability, detected during the pre-flight
+	Indexer *IndexerResult `json:"indexer,omitempty"`
+
+	// Throughput separates the average TPS into the
+	// peak, steady-state and end-to-end figures
+	Throughput *ThroughputResult `json:"throughput,omitempty"`
+
+	// Drain is the end-of-run mempool drain, after the last broadcast
+	Drain *DrainResult `json:"drain,omitempty"`
+
+	// Inclusion is the inclusion proof verification of the
+	// sampled committed transactions, if any
+	Inclusion *InclusionResult `json:"inclusion,omitempty"`
+
+	// GasWanted is the gas wanted of the run transactions, so runs
+	// with different gas wanted values are not compared blindly
+	GasWanted *GasWantedResult `json:"gasWanted,omitempty"`
+
+	// Fees are the effective gas wanted and gas fees, per message type the run sends
+	Fees fees.Map `json:"fees,omitempty"`
+
+	// Signers are the signing outcomes of each signer backend, on runs with externally managed signers
+	Signers []*SignerResult `json:"signers,omitempty"`
+
+	// Ceiling is the theoretical TPS ceiling for the run transaction gas wanted
+	Ceiling *CeilingResult `json:"ceiling,omitempty"`
+
+	// Mint is the realm storage growth of the token mints (MINT), if any
+	Mint *MintResult `json:"mint,omitempty"`
+
+	// Client is the supernova process resource timeline, with the client-side bottleneck verdict
+	Client *metrics.ClientMetrics `json:"client,omitempty"`
+
+	RPC          *metrics.RPCMetrics              `json:"rpc,omitempty"`
+	BatchLatency map[string]*metrics.Distribution `json:"batchLatency,omitempty"`
+	Latency      *metrics.LatencyAttribution      `json:"latency,omitempty"`
+	Node         *metrics.NodeMetrics             `json:"node,omitempty"`
+	Pacing       *metrics.PacingResult            `json:"pacing,omitempty"`
+	Reads        *metrics.ReadResult              `json:"reads,omitempty"`
+	Dispatch     *metrics.DispatchResult          `json:"dispatch,omitempty"`
+	Clock        *metrics.ClockAnchor             `json:"clock,omitempty"` // the anchor of the broadcast timestamps
+
+	// Degraded are the failed optional integrations, that did not fail the run
+	Degraded []*feature.Failure `json:"degraded,omitempty"`
+
+	// Baseline is the baseline run the headline metrics are annotated against, if any
+	Baseline *BaselineResult `json:"baseline,omitempty"`
+
+	// BatchFallback indicates the node rejected batch requests,
+	// and the transactions were broadcast one by one
+	BatchFallback bool `json:"batchFallback"`
+
+	ExcludedAccounts []string `json:"excludedAccounts,omitempty"`
+
+	// ConstructionFailures are the transactions that failed to be
+	// constructed, and were skipped or substituted with a minimal transfer
+	ConstructionFailures []*ConstructionFailure `json:"constructionFailures,omitempty"`
+
+	// Accounts is the balance accounting of each run sub-account,
+	// used for seeding the distribution of a consecutive run
+	Accounts []*AccountResult `json:"accounts,omitempty"`
+
+	// TxSetHash is the content hash of the constructed
+	// transaction set, recorded for reproducible runs
+	Seed      uint64 `json:"seed,omitempty"`
+	TxSetHash string `json:"txSetHash,omitempty"`
+
+	// CorpusHash is the sha256 of the call argument corpus file, if any
+	CorpusHash string `json:"corpusHash,omitempty"`
+
+	// Scenario is the custom workload scenario (SCENARIO), with its template mix, if any
+	Scenario *ScenarioResult `json:"scenario,omitempty"`
+
+	// Sweep is the return of the leftover sub-account funds to the distributor, if swept
+	Sweep *SweepResult `json:"sweep,omitempty"`
+
+	// Duration is the outcome of a duration run, broadcast at the send rate until the deadline, if any
+	Duration *DurationResult `json:"duration,omitempty"`
+
+	// Progress is the live run progress at each sample interval,
+	// from the dispatch start to the end of the collection, if monitored
+	Progress []*ProgressSample `json:"progress,omitempty"`
+
+	// Embedded indicates the run targeted an in-process node,
+	// so the figures exclude the network and RPC overhead
+	Embedded bool `json:"embedded,omitempty"`
+
+	// Artifacts is the artifact writer summary
+	Artifacts *artifact.Result `json:"artifacts,omitempty"`
+
+	// EndpointAssignments maps each account to the endpoint
+	// its transactions were broadcast to, when using account affinity
+	EndpointAffinity    string            `json:"endpointAffinity,omitempty"`
+	EndpointAssignments map[string]string `json:"endpointAssignments,omitempty"`
+	FailedEndpoints     []string          `json:"failedEndpoints,omitempty"`
+
+	// Endpoints is the broadcast outcome of each endpoint, when broadcasting to endpoints
+	Endpoints []*EndpointResult `json:"endpoints,omitempty"`
+}
+
+// ConstructionFailure is a single transaction that failed to be constructed
+type ConstructionFailure struct {
+	Index  int    `json:"index"`
+	Policy string `json:"policy"` // skip / substitute
+	Error  string `json:"error"`
+}
+
+// ScenarioResult is the custom workload scenario of the run
+type ScenarioResult struct {
+	Name      string                    `json:"name,omitempty"`
+	Hash      string                    `json:"hash"` // the sha256 of the scenario file
+	Templates []*ScenarioTemplateResult `json:"templates"`
+}
+
+// DurationResult is the outcome of a duration run
+type DurationResult struct {
+	Duration time.Duration `json:"duration"` // the broadcast duration
+	SendRate float64       `json:"sendRate"` // the target send rate (tx/s)
+
+	Target    int `json:"target"`           // the transactions at the send rate, over the entire duration
+	Generated int `json:"generated"`        // the transactions generated during the broadcast
+	Sent      int `json:"sent"`             // the transactions sent before the deadline
+	Unsent    int `json:"unsent"`           // the generated transactions not sent before the deadline
+	TopUps    int `json:"topUps,omitempty"` // the sub-account top-up rounds during the broadcast
+
+	Recycles int `json:"recycles,omitempty"` // the idle sub-account recycles during the broadcast
+
+	// DeadlineReached indicates the collection was finalized by the run deadline,
+	// and the transactions not committed by then were counted as lost
+	DeadlineReached bool `json:"deadlineReached,omitempty"`
+}
+
+// ProgressSample is the live run progress at a single sample interval
+type ProgressSample struct {
+	Elapsed time.Duration `json:"elapsed"` // the time since the dispatch start
+
+	Sent      int `json:"sent"`      // the broadcast run transactions
+	Committed int `json:"committed"` // the committed run transactions
+	Failed    int `json:"failed"`    // the failed broadcast requests
+
+	SendRate   float64 `json:"sendRate"`   // the broadcast rate (tx/s) over the interval
+	CommitRate float64 `json:"commitRate"` // the commit rate (tx/s) over the interval
+}
+
+// ScenarioTemplateResult is the constructed transactions of a single scenario template
+type ScenarioTemplateResult struct {
+	Name         string `json:"name"`
+	PkgPath      string `json:"pkgPath"`
+	Func         string `json:"func"`
+	Weight       uint64 `json:"weight"`
+	Transactions int    `json:"numTransactions"`
+}
+
+// SegmentResult is the time-sliced test run result
+type SegmentResult struct {
+	Index        int            `json:"index"`
+	StartTime    time.Time      `json:"startTime"`
+	EndTime      time.Time      `json:"endTime"`
+	Transactions int            `json:"numTransactions"`
+	AverageTPS   int            `json:"averageTPS"`
+	Blocks       []*BlockResult `json:"blocks,omitempty"`
+}
+
+// PhaseResult is the duration breakdown of a single pipeline phase,
+// with its wall-clock bounds in UTC
+type PhaseResult struct {
+	Name      string        `json:"name"`
+	StartTime time.Time     `json:"startTime"`
+	EndTime   time.Time     `json:"endTime"`
+	Duration  time.Duration `json:"duration"`
 }
 
 // BlockResult is the single-block test run result
@@ -26,4 +234,131 @@ type BlockResult struct {
 	Transactions int64     `json:"numTransactions"`
 	GasUsed      int64     `json:"gasUsed"`
 	GasLimit     int64     `json:"gasLimit"`
+
+	// GasSource is the source of the gas used, if not the block results
+	GasSource string `json:"gasSource,omitempty"`
+}
+
+// GasWantedResult is the gas wanted of the run transactions, per runtime mode
+type GasWantedResult struct {
+	Mode      string `json:"mode"`
+	Run       int64  `json:"run"`                 // the gas wanted of the run (and priming) transactions
+	Predeploy int64  `json:"predeploy,omitempty"` // the gas wanted of the predeployment transactions, if any
+	Default   bool   `json:"default"`             // flag indicating if the mode default was used
+
+	Simulated int64   `json:"simulated,omitempty"` // the simulated gas used of a run transaction, if estimated
+	Margin    float64 `json:"margin,omitempty"`    // the safety margin of the estimate, over the simulated gas
+}
+
+// SignerResult is the signing outcome of a single signer backend
+type SignerResult struct {
+	Backend  string `json:"backend"`
+	Accounts int    `json:"accounts"` // the number of accounts signed by the backend
+	Signed   int    `json:"signed"`   // the number of successfully signed transactions
+	Failed   int    `json:"failed"`   // the number of failed signing requests
+}
+
+// IndexerResult is the node tx indexer availability, which the tx endpoint lookups depend on
+type IndexerResult struct {
+	Status   string   `json:"status"`             // enabled, disabled or unknown
+	Source   string   `json:"source"`             // the detection source (node status, tx probe)
+	Disabled []string `json:"disabled,omitempty"` // the dependent features disabled for the run
+}
+
+// CostResult is the cost breakdown of the stress test run
+type CostResult struct {
+	Denom          string `json:"denom"`
+	TxCost         int64  `json:"txCost"`         // the gas cost of a single run transaction
+	StorageDeposit int64  `json:"storageDeposit"` // the storage deposit of a single run transaction
+	AccountCost    int64  `json:"accountCost"`    // the funds required by a single sub-account
+
+	DepositDenom   string `json:"depositDenom,omitempty"`   // the storage deposit denomination, if not the gas one
+	AccountDeposit int64  `json:"accountDeposit,omitempty"` // the deposit denomination funds required by a single sub-account
+	TotalDeposits  int64  `json:"totalDeposits"`            // the storage deposits paid in the run
+	PrimingTxs     uint64 `json:"primingTxs"`               // the number of unmeasured priming transactions
+	PrimingCost    int64  `json:"primingCost"`              // the distributor funds spent on priming transactions
+	EstimatedTotal int64  `json:"estimatedTotal,omitempty"` // the distributor balance required if no sub-account is funded
+
+	PlannedTransfers int   `json:"plannedTransfers,omitempty"` // the number of funding plan transfers, if any
+	PlannedFunds     int64 `json:"plannedFunds,omitempty"`     // the total funds transferred by the funding plan
+
+	GenesisFunded bool `json:"genesisFunded,omitempty"` // flag indicating if the sub-accounts were funded in genesis
+
+	DistributorIndex   uint32 `json:"distributorIndex"`   // the derivation index of the distributor account
+	DistributorAddress string `json:"distributorAddress"` // the address of the distributor account
+
+	Spend *SpendResult `json:"spend,omitempty"` // the cumulative distributor spend of the invocation
+}
+
+// SweepResult is the return of the leftover sub-account funds to the distributor
+type SweepResult struct {
+	Denom     string `json:"denom"`
+	Recovered int64  `json:"recovered"`       // the funds returned to the distributor, net of the transfer fees
+	Coins     string `json:"coins,omitempty"` // all the returned coins, including other denominations
+
+	Swept   int `json:"swept"`   // the number of swept sub-accounts
+	Skipped int `json:"skipped"` // the number of sub-accounts without funds above the fee, or not on chain
+	Failed  int `json:"failed"`  // the number of sub-accounts that could not be fetched, or transferred from
+
+	Distributor string `json:"distributor"` // the address of the distributor the funds were returned to
+
+	Accounts []*SweepAccountResult `json:"accounts,omitempty"` // the funds recovered from each swept sub-account
+}
+
+// SweepAccountResult is the funds recovered from a single sub-account
+type SweepAccountResult struct {
+	Address   string `json:"address"`
+	Recovered int64  `json:"recovered"`       // the funds returned to the distributor, net of the transfer fee
+	Coins     string `json:"coins,omitempty"` // all the returned coins, including other denominations
+}
+
+// SpendResult is the cumulative distributor spend (funding transfers plus fees)
+// of the invocation, against the spend cap
+type SpendResult struct {
+	Cap    int64         `json:"cap,omitempty"`    // the spend cap, if any
+	Spent  int64         `json:"spent"`            // the cumulative spend
+	Capped bool          `json:"capped,omitempty"` // flag indicating if a spend was refused by the cap
+	Events []*SpendEvent `json:"events"`           // the recorded spends, in order
+}
+
+// SpendEvent is a single recorded distributor spend
+type SpendEvent struct {
+	Time       time.Time `json:"time"`
+	Site       string    `json:"site"`       // the spending site
+	Amount     int64     `json:"amount"`     // the spent funds
+	Cumulative int64     `json:"cumulative"` // the cumulative spend, including this one
+}
+
+// BaselineResult is the baseline run the results are annotated against, for provenance
+type BaselineResult struct {
+	RunID          string            `json:"runId"`
+	SchemaVersion  int               `json:"schemaVersion"`
+	SchemaMismatch bool              `json:"schemaMismatch,omitempty"`
+	Metrics        []*BaselineMetric `json:"metrics"`
+
+	// Skipped are the headline metrics that can't be compared,
+	// because they are missing from either run (or the baseline value is zero)
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// BaselineMetric is a single headline metric, against its baseline value
+type BaselineMetric struct {
+	Name           string  `json:"name"`
+	HigherIsBetter bool    `json:"higherIsBetter"`
+	Baseline       float64 `json:"baseline"`
+	Current        float64 `json:"current"`
+	DeltaPercent   float64 `json:"deltaPercent"`
+}
+
+// ShardResult identifies the shard of a sharded run, and its slice of the totals
+type ShardResult struct {
+	Index uint64 `json:"index"`
+	Count uint64 `json:"count"`
+
+	SubAccountOffset uint64 `json:"subAccountOffset"` // the derivation index of the first shard sub-account
+	SubAccounts      uint64 `json:"subAccounts"`      // the number of shard sub-accounts
+	Transactions     uint64 `json:"transactions"`     // the number of shard transactions
+
+	TotalAccounts uint64 `json:"totalAccounts"`     // the number of sub-accounts of all shards
+	TotalTxs      uint64 `json:"totalTransactions"` // the number of transactions of all shards
 }
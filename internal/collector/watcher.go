@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+)
+
+type WatcherClient interface {
+	GetBlock(height *int64) (*core_types.ResultBlock, error)
+	GetBlockResults(height *int64) (*core_types.ResultBlockResults, error)
+	GetLatestBlockHeight() (int64, error)
+}
+
+// CommitWatcher monitors the chain for the commitment
+// of specific transactions, and their delivery results
+type CommitWatcher struct {
+	cli WatcherClient
+
+	requestTimeout time.Duration
+	timeout        time.Duration
+}
+
+// NewCommitWatcher creates a new instance of the commit watcher
+func NewCommitWatcher(cli WatcherClient, timeout time.Duration) *CommitWatcher {
+	return &CommitWatcher{
+		cli:            cli,
+		requestTimeout: time.Second * 1,
+		timeout:        timeout,
+	}
+}
+
+// WaitForCommits waits for the given transactions to be committed, starting from the
+// specified block. The resulting map contains the delivery error (nil on success) for each
+// committed transaction, keyed by the transaction hash. Transactions that were not committed
+// before the watcher timed out are not present in the map
+func (w *CommitWatcher) WaitForCommits(txHashes [][]byte, startBlock int64) (map[string]error, error) {
+	var (
+		results = make(map[string]error, len(txHashes))
+		timeout = time.After(w.timeout)
+		start   = startBlock
+		txMap   = newTxLookup(txHashes)
+	)
+
+	for len(results) < len(txHashes) {
+		select {
+		case <-timeout:
+			return results, nil
+		case <-time.After(w.requestTimeout):
+			latest, err := w.cli.GetLatestBlockHeight()
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch latest block height, %w", err)
+			}
+
+			for blockNum := start; blockNum <= latest; blockNum++ {
+				block, err := w.cli.GetBlock(&blockNum)
+				if err != nil {
+					return nil, fmt.Errorf("unable to fetch block, %w", err)
+				}
+
+				if txMap.anyBelong(block.Block.Txs) == 0 {
+					continue
+				}
+
+				// Fetch the delivery results for the block
+				blockResults, err := w.cli.GetBlockResults(&blockNum)
+				if err != nil {
+					return nil, fmt.Errorf("unable to fetch block results, %w", err)
+				}
+
+				if len(blockResults.Results.DeliverTxs) != len(block.Block.Txs) {
+					return nil, errors.New("block results do not match block transactions")
+				}
+
+				for index, tx := range block.Block.Txs {
+					txHash := string(tx.Hash())
+
+					if _, ok := txMap.lookup[txHash]; !ok {
+						continue
+					}
+
+					var deliverErr error
+
+					if deliverTx := blockResults.Results.DeliverTxs[index]; deliverTx.IsErr() {
+						deliverErr = fmt.Errorf("transaction delivery failed, %w", deliverTx.Error)
+					}
+
+					results[txHash] = deliverErr
+				}
+			}
+
+			start = latest + 1
+		}
+	}
+
+	return results, nil
+}
@@ -8,11 +8,10 @@ const (
 	KeybasePrefix   = "stress-account-"
 )
 
-// TODO support estimating gas params
-// These are constants for now,
-// but should be fetched as estimations
-// from the Tendermint node once this functionality
-// is available.
+// These are the fixed fallbacks of the gas params.
+// The run gas wanted can be estimated on the node
+// (-estimate-gas), and the run fees derived from
+// the gas price (-gas-price) instead.
 //
 // Each package call / deployment
 // costs a fixed 1 GNOT
@@ -1,5 +1,11 @@
 package common
 
+import "errors"
+
+// ErrBatchRejected is returned when the node (or a proxy in front of it)
+// rejects the JSON-RPC batch request as a whole
+var ErrBatchRejected = errors.New("batch request rejected")
+
 // Batch is a common transaction batch
 type Batch interface {
 	// AddTxBroadcast adds the transaction broadcast to the batch
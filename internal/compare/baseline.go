@@ -0,0 +1,62 @@
+package compare
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+var errBaselineRuns = errors.New("the baseline needs to be a single run")
+
+// LoadBaseline loads the single run baseline from the given path,
+// which can be a results file or a run manifest
+func LoadBaseline(path string) (*collector.RunResult, error) {
+	results, err := LoadResults([]string{path})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != 1 {
+		return nil, fmt.Errorf("%w, found %d runs", errBaselineRuns, len(results))
+	}
+
+	return results[0], nil
+}
+
+// Annotate annotates the current run with the deltas of its headline metrics
+// against the baseline run. The metrics that can't be compared are skipped,
+// which is the case for baselines recorded with a different results schema
+func Annotate(baseline, current *collector.RunResult) *collector.BaselineResult {
+	var (
+		report = Compare([]*collector.RunResult{baseline}, []*collector.RunResult{current})
+		result = &collector.BaselineResult{
+			RunID:          baseline.RunID,
+			SchemaVersion:  baseline.SchemaVersion,
+			SchemaMismatch: baseline.SchemaVersion != current.SchemaVersion,
+			Metrics:        make([]*collector.BaselineMetric, 0, len(metrics)),
+		}
+	)
+
+	for _, m := range metrics {
+		comparison := report.metric(m.name)
+
+		// The relative change from a zero baseline is undefined
+		if comparison == nil || math.IsInf(comparison.DeltaPercent, 0) {
+			result.Skipped = append(result.Skipped, m.name)
+
+			continue
+		}
+
+		result.Metrics = append(result.Metrics, &collector.BaselineMetric{
+			Name:           m.name,
+			HigherIsBetter: m.higherIsBetter,
+			Baseline:       comparison.Baseline.Mean,
+			Current:        comparison.Candidate.Mean,
+			DeltaPercent:   comparison.DeltaPercent,
+		})
+	}
+
+	return result
+}
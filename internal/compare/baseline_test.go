@@ -0,0 +1,177 @@
+package compare
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/stretchr/testify/assert"
+)
+
+// findBaselineMetric finds the baseline metric by name
+func findBaselineMetric(t *testing.T, baseline *collector.BaselineResult, name string) *collector.BaselineMetric {
+	t.Helper()
+
+	for _, m := range baseline.Metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+
+	t.Fatalf("baseline metric %s not found", name)
+
+	return nil
+}
+
+func TestBaseline_Annotate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("headline deltas", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			baseline = &collector.RunResult{
+				SchemaVersion: collector.SchemaVersion,
+				RunID:         "baseline-run",
+				AverageTPS:    100,
+				CommittedTxs:  90,
+				LostTxs:       10,
+				Throughput: &collector.ThroughputResult{
+					Peak:        &collector.TPSWindow{TPS: 200},
+					SteadyState: &collector.TPSWindow{TPS: 120},
+				},
+			}
+			current = &collector.RunResult{
+				SchemaVersion: collector.SchemaVersion,
+				AverageTPS:    110,
+				CommittedTxs:  95,
+				LostTxs:       5,
+				Throughput: &collector.ThroughputResult{
+					Peak:        &collector.TPSWindow{TPS: 200},
+					SteadyState: &collector.TPSWindow{TPS: 90},
+				},
+			}
+		)
+
+		result := Annotate(baseline, current)
+
+		assert.Equal(t, "baseline-run", result.RunID)
+		assert.False(t, result.SchemaMismatch)
+
+		assert.InDelta(t, 10, findBaselineMetric(t, result, MetricAverageTPS).DeltaPercent, 1e-9)
+		assert.InDelta(t, 0, findBaselineMetric(t, result, MetricPeakTPS).DeltaPercent, 1e-9)
+		assert.InDelta(t, -25, findBaselineMetric(t, result, MetricSteadyStateTPS).DeltaPercent, 1e-9)
+
+		lost := findBaselineMetric(t, result, MetricLostRatio)
+
+		assert.False(t, lost.HigherIsBetter)
+		assert.InDelta(t, 0.1, lost.Baseline, 1e-9)
+		assert.InDelta(t, 0.05, lost.Current, 1e-9)
+
+		// The runs have no commit latency
+		assert.Equal(t, []string{MetricCommitLatencyP95}, result.Skipped)
+	})
+
+	t.Run("older schema baseline", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			baseline = &collector.RunResult{
+				AverageTPS:   100,
+				CommittedTxs: 100,
+			}
+			current = &collector.RunResult{
+				SchemaVersion: collector.SchemaVersion,
+				AverageTPS:    50,
+				CommittedTxs:  100,
+				Throughput: &collector.ThroughputResult{
+					Peak:        &collector.TPSWindow{TPS: 80},
+					SteadyState: &collector.TPSWindow{TPS: 60},
+				},
+			}
+		)
+
+		result := Annotate(baseline, current)
+
+		assert.True(t, result.SchemaMismatch)
+		assert.Equal(t, 0, result.SchemaVersion)
+
+		// Only the throughput figures missing from the baseline are skipped
+		assert.InDelta(t, -50, findBaselineMetric(t, result, MetricAverageTPS).DeltaPercent, 1e-9)
+		assert.Contains(t, result.Skipped, MetricSteadyStateTPS)
+		assert.Contains(t, result.Skipped, MetricPeakTPS)
+	})
+
+	t.Run("zero baseline", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			baseline = &collector.RunResult{
+				SchemaVersion: collector.SchemaVersion,
+				AverageTPS:    100,
+				CommittedTxs:  100,
+			}
+			current = &collector.RunResult{
+				SchemaVersion: collector.SchemaVersion,
+				AverageTPS:    100,
+				CommittedTxs:  90,
+				LostTxs:       10,
+			}
+		)
+
+		result := Annotate(baseline, current)
+
+		// The relative change from no lost transactions is undefined
+		assert.Contains(t, result.Skipped, MetricLostRatio)
+		assert.InDelta(t, 0, findBaselineMetric(t, result, MetricAverageTPS).DeltaPercent, 1e-9)
+	})
+}
+
+func TestBaseline_LoadBaseline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single run", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "baseline.json")
+
+		data, err := json.Marshal(&collector.RunResult{RunID: "baseline-run", AverageTPS: 100})
+		if err != nil {
+			t.Fatalf("unable to marshal results, %v", err)
+		}
+
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("unable to write results, %v", err)
+		}
+
+		baseline, err := LoadBaseline(path)
+		if err != nil {
+			t.Fatalf("unable to load baseline, %v", err)
+		}
+
+		assert.Equal(t, "baseline-run", baseline.RunID)
+	})
+
+	t.Run("multiple runs", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		for _, name := range []string{"a.json", "b.json"} {
+			data, err := json.Marshal(&collector.RunResult{AverageTPS: 100})
+			if err != nil {
+				t.Fatalf("unable to marshal results, %v", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+				t.Fatalf("unable to write results, %v", err)
+			}
+		}
+
+		_, err := LoadBaseline(dir)
+
+		assert.ErrorIs(t, err, errBaselineRuns)
+	})
+}
@@ -0,0 +1,369 @@
+// Package compare compares the results of a candidate run (or runs)
+// against a stored baseline, taking the run variance into account
+package compare
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/gnolang/supernova/results"
+)
+
+// significance is the p-value threshold for significant differences
+const significance = 0.05
+
+// Compared run result metrics
+const (
+	MetricSteadyStateTPS   = "steadyStateTPS"
+	MetricPeakTPS          = "peakTPS"
+	MetricAverageTPS       = "averageTPS"
+	MetricLostRatio        = "lostRatio"
+	MetricCommitLatencyP95 = "commitLatencyP95Ms"
+
+	// DefaultGateMetric is the default metric for regression gating.
+	// The steady-state TPS is independent of the run length, unlike the average TPS
+	DefaultGateMetric = MetricSteadyStateTPS
+)
+
+var errNoResults = errors.New("no results found")
+
+// Verdict is the outcome of a single metric comparison
+type Verdict string
+
+const (
+	// VerdictRegression is a significant change for the worse
+	VerdictRegression Verdict = "likely regression"
+
+	// VerdictImprovement is a significant change for the better
+	VerdictImprovement Verdict = "likely improvement"
+
+	// VerdictInconclusive is a change that is not significant
+	VerdictInconclusive Verdict = "inconclusive"
+
+	// VerdictDelta is a plain delta, with no variance data for a significance test
+	VerdictDelta Verdict = "delta only"
+)
+
+// Stat is the summary of a metric over multiple runs
+type Stat struct {
+	Runs   int     `json:"runs"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+}
+
+// MetricComparison is the comparison of a single metric
+type MetricComparison struct {
+	Name           string  `json:"name"`
+	HigherIsBetter bool    `json:"higherIsBetter"`
+	Baseline       Stat    `json:"baseline"`
+	Candidate      Stat    `json:"candidate"`
+	DeltaPercent   float64 `json:"deltaPercent"`
+	PValue         float64 `json:"pValue,omitempty"`
+	Verdict        Verdict `json:"verdict"`
+}
+
+// Report is the comparison report of the candidate against the baseline
+type Report struct {
+	Significance float64             `json:"significance"`
+	Metrics      []*MetricComparison `json:"metrics"`
+
+	// NoVariance is set when either side has a single run,
+	// so the comparison is a plain delta comparison
+	NoVariance bool `json:"noVariance"`
+}
+
+// metric is a comparable run result metric
+type metric struct {
+	name           string
+	higherIsBetter bool
+	extract        func(result *collector.RunResult) (float64, bool)
+}
+
+// metrics are the compared run result metrics
+var metrics = []metric{
+	{
+		name:           MetricSteadyStateTPS,
+		higherIsBetter: true,
+		extract: func(result *collector.RunResult) (float64, bool) {
+			if result.Throughput == nil || result.Throughput.SteadyState == nil {
+				return 0, false
+			}
+
+			return result.Throughput.SteadyState.TPS, true
+		},
+	},
+	{
+		name:           MetricPeakTPS,
+		higherIsBetter: true,
+		extract: func(result *collector.RunResult) (float64, bool) {
+			if result.Throughput == nil || result.Throughput.Peak == nil {
+				return 0, false
+			}
+
+			return result.Throughput.Peak.TPS, true
+		},
+	},
+	{
+		name:           MetricAverageTPS,
+		higherIsBetter: true,
+		extract: func(result *collector.RunResult) (float64, bool) {
+			return float64(result.AverageTPS), true
+		},
+	},
+	{
+		name:           MetricLostRatio,
+		higherIsBetter: false,
+		extract: func(result *collector.RunResult) (float64, bool) {
+			total := result.CommittedTxs + result.LostTxs
+			if total == 0 {
+				return 0, false
+			}
+
+			return float64(result.LostTxs) / float64(total), true
+		},
+	},
+	{
+		name:           MetricCommitLatencyP95,
+		higherIsBetter: false,
+		extract: func(result *collector.RunResult) (float64, bool) {
+			if result.Latency == nil || result.Latency.Commit == nil {
+				return 0, false
+			}
+
+			return float64(result.Latency.Commit.P95.Milliseconds()), true
+		},
+	},
+}
+
+// Compare compares the candidate runs against the baseline runs.
+// Metrics that are missing from either side are skipped
+func Compare(baseline, candidate []*collector.RunResult) *Report {
+	report := &Report{
+		Significance: significance,
+		Metrics:      make([]*MetricComparison, 0, len(metrics)),
+	}
+
+	for _, m := range metrics {
+		var (
+			baselineSample  = newSample(extractValues(baseline, m))
+			candidateSample = newSample(extractValues(candidate, m))
+		)
+
+		if baselineSample.n == 0 || candidateSample.n == 0 {
+			continue
+		}
+
+		comparison := &MetricComparison{
+			Name:           m.name,
+			HigherIsBetter: m.higherIsBetter,
+			Baseline:       toStat(baselineSample),
+			Candidate:      toStat(candidateSample),
+			DeltaPercent:   deltaPercent(baselineSample.mean, candidateSample.mean),
+			Verdict:        VerdictDelta,
+		}
+
+		// The significance test requires variance data on both sides
+		if baselineSample.n < 2 || candidateSample.n < 2 {
+			report.NoVariance = true
+			report.Metrics = append(report.Metrics, comparison)
+
+			continue
+		}
+
+		comparison.PValue = welchTTest(candidateSample, baselineSample)
+		comparison.Verdict = verdict(comparison)
+
+		report.Metrics = append(report.Metrics, comparison)
+	}
+
+	return report
+}
+
+// Gate returns the comparison of the gating metric. Results without a
+// steady-state TPS (for example, older results) fall back to the average TPS.
+// Returns nil if the metric is missing from the comparison
+func (r *Report) Gate(metric string) *MetricComparison {
+	if comparison := r.metric(metric); comparison != nil {
+		return comparison
+	}
+
+	if metric == MetricSteadyStateTPS {
+		return r.metric(MetricAverageTPS)
+	}
+
+	return nil
+}
+
+// Regressed returns true if the gating metric is a likely regression
+func (r *Report) Regressed(metric string) bool {
+	comparison := r.Gate(metric)
+
+	return comparison != nil && comparison.Verdict == VerdictRegression
+}
+
+// metric returns the comparison of the given metric, if any
+func (r *Report) metric(name string) *MetricComparison {
+	for _, comparison := range r.Metrics {
+		if comparison.Name == name {
+			return comparison
+		}
+	}
+
+	return nil
+}
+
+// verdict determines the verdict of the metric comparison
+func verdict(comparison *MetricComparison) Verdict {
+	if comparison.PValue >= significance {
+		return VerdictInconclusive
+	}
+
+	improved := comparison.Candidate.Mean > comparison.Baseline.Mean
+	if !comparison.HigherIsBetter {
+		improved = !improved
+	}
+
+	if improved {
+		return VerdictImprovement
+	}
+
+	return VerdictRegression
+}
+
+// extractValues extracts the metric values from the runs, if present
+func extractValues(results []*collector.RunResult, m metric) []float64 {
+	values := make([]float64, 0, len(results))
+
+	for _, result := range results {
+		if value, ok := m.extract(result); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// toStat converts the metric sample to a stat
+func toStat(s sample) Stat {
+	return Stat{
+		Runs:   s.n,
+		Mean:   s.mean,
+		StdDev: s.stddev,
+	}
+}
+
+// deltaPercent returns the relative change, in percent
+func deltaPercent(baseline, candidate float64) float64 {
+	if baseline == 0 {
+		if candidate == 0 {
+			return 0
+		}
+
+		return math.Inf(1)
+	}
+
+	return (candidate - baseline) / math.Abs(baseline) * 100
+}
+
+// LoadResults loads the run results from the given paths.
+// A path can be a single results file, a run manifest, or a directory
+// of results files (all JSON files in it, sorted by name). Directories
+// holding run manifests are resolved through the manifests instead
+func LoadResults(paths []string) ([]*collector.RunResult, error) {
+	results := make([]*collector.RunResult, 0, len(paths))
+
+	for _, path := range paths {
+		files, err := resultFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			result, err := loadResult(file)
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, errNoResults
+	}
+
+	return results, nil
+}
+
+// resultFiles returns the results files at the given path
+func resultFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat results path, %w", err)
+	}
+
+	if !info.IsDir() {
+		if manifest.IsManifest(path) {
+			return manifestResults([]string{path})
+		}
+
+		return []string{path}, nil
+	}
+
+	manifests, err := filepath.Glob(filepath.Join(path, manifest.FileName("*")))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list run manifests, %w", err)
+	}
+
+	if len(manifests) > 0 {
+		sort.Strings(manifests)
+
+		return manifestResults(manifests)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list results files, %w", err)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// manifestResults returns the results files linked by the run manifests
+func manifestResults(paths []string) ([]string, error) {
+	files := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		m, err := manifest.Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		results, err := m.Locate(manifest.TypeResults)
+		if err != nil {
+			return nil, fmt.Errorf("unable to locate results of run %s, %w", m.RunID, err)
+		}
+
+		files = append(files, results...)
+	}
+
+	return files, nil
+}
+
+// loadResult loads a single run result file
+func loadResult(path string) (*collector.RunResult, error) {
+	r, err := results.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Raw(), nil
+}
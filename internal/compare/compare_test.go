@@ -0,0 +1,299 @@
+package compare
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateResults generates run results with the given TPS values
+func generateResults(tps ...int) []*collector.RunResult {
+	results := make([]*collector.RunResult, 0, len(tps))
+
+	for _, value := range tps {
+		results = append(results, &collector.RunResult{
+			AverageTPS:   value,
+			CommittedTxs: 100,
+		})
+	}
+
+	return results
+}
+
+// findMetric finds the metric comparison by name
+func findMetric(t *testing.T, report *Report, name string) *MetricComparison {
+	t.Helper()
+
+	for _, m := range report.Metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+
+	t.Fatalf("metric %s not found", name)
+
+	return nil
+}
+
+func TestCompare_WelchTTest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identical samples", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSample([]float64{1, 2, 3})
+
+		assert.InDelta(t, 1, welchTTest(s, s), 1e-9)
+	})
+
+	t.Run("known p-value", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			a = newSample([]float64{19.8, 20.4, 19.6, 17.8, 18.5, 18.9, 18.3, 18.9, 19.5, 22.0})
+			b = newSample([]float64{28.2, 26.6, 20.1, 23.3, 25.2, 22.1, 17.7, 27.6, 20.6, 13.7})
+		)
+
+		// t = -2.074, df = 10.21
+		assert.InDelta(t, 0.0643, welchTTest(a, b), 1e-3)
+	})
+}
+
+func TestCompare_Compare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("likely regression", func(t *testing.T) {
+		t.Parallel()
+
+		report := Compare(
+			generateResults(100, 102, 98, 101),
+			generateResults(80, 81, 79, 82),
+		)
+
+		tps := findMetric(t, report, "averageTPS")
+
+		assert.False(t, report.NoVariance)
+		assert.Equal(t, VerdictRegression, tps.Verdict)
+		assert.Less(t, tps.PValue, significance)
+		assert.Equal(t, 4, tps.Baseline.Runs)
+	})
+
+	t.Run("likely improvement", func(t *testing.T) {
+		t.Parallel()
+
+		report := Compare(
+			generateResults(80, 81, 79, 82),
+			generateResults(100, 102, 98, 101),
+		)
+
+		assert.Equal(t, VerdictImprovement, findMetric(t, report, "averageTPS").Verdict)
+	})
+
+	t.Run("inconclusive", func(t *testing.T) {
+		t.Parallel()
+
+		report := Compare(
+			generateResults(100, 60, 140),
+			generateResults(105, 55, 150),
+		)
+
+		assert.Equal(t, VerdictInconclusive, findMetric(t, report, "averageTPS").Verdict)
+	})
+
+	t.Run("lower is better", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			baseline  = generateResults(100, 100, 100)
+			candidate = generateResults(100, 100, 100)
+		)
+
+		for i, result := range candidate {
+			result.LostTxs = 20 + i
+		}
+
+		report := Compare(baseline, candidate)
+
+		assert.Equal(t, VerdictRegression, findMetric(t, report, "lostRatio").Verdict)
+	})
+
+	t.Run("single run fallback", func(t *testing.T) {
+		t.Parallel()
+
+		report := Compare(
+			generateResults(100),
+			generateResults(80, 81),
+		)
+
+		tps := findMetric(t, report, "averageTPS")
+
+		assert.True(t, report.NoVariance)
+		assert.Equal(t, VerdictDelta, tps.Verdict)
+		assert.InDelta(t, -19.5, tps.DeltaPercent, 1e-9)
+	})
+
+	t.Run("missing metric skipped", func(t *testing.T) {
+		t.Parallel()
+
+		report := Compare(
+			generateResults(100, 101),
+			generateResults(100, 101),
+		)
+
+		for _, m := range report.Metrics {
+			assert.NotEqual(t, "commitLatencyP95Ms", m.Name)
+		}
+	})
+}
+
+// withSteadyState sets the steady-state TPS of the results
+func withSteadyState(results []*collector.RunResult, tps ...float64) []*collector.RunResult {
+	for index, result := range results {
+		result.Throughput = &collector.ThroughputResult{
+			SteadyState: &collector.TPSWindow{
+				TPS: tps[index],
+			},
+		}
+	}
+
+	return results
+}
+
+func TestCompare_Gate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("steady-state gating", func(t *testing.T) {
+		t.Parallel()
+
+		// The average TPS regresses (a longer drain tail),
+		// but the steady-state TPS does not
+		report := Compare(
+			withSteadyState(generateResults(100, 102, 98, 101), 150, 152, 148, 151),
+			withSteadyState(generateResults(80, 81, 79, 82), 151, 149, 150, 152),
+		)
+
+		gated := report.Gate(DefaultGateMetric)
+		if gated == nil {
+			t.Fatalf("gating metric not found")
+		}
+
+		assert.Equal(t, MetricSteadyStateTPS, gated.Name)
+		assert.False(t, report.Regressed(DefaultGateMetric))
+		assert.True(t, report.Regressed(MetricAverageTPS))
+	})
+
+	t.Run("average fallback", func(t *testing.T) {
+		t.Parallel()
+
+		report := Compare(
+			generateResults(100, 102, 98, 101),
+			generateResults(80, 81, 79, 82),
+		)
+
+		gated := report.Gate(DefaultGateMetric)
+		if gated == nil {
+			t.Fatalf("gating metric not found")
+		}
+
+		assert.Equal(t, MetricAverageTPS, gated.Name)
+		assert.True(t, report.Regressed(DefaultGateMetric))
+		assert.Nil(t, report.Gate("missing"))
+	})
+}
+
+func TestCompare_LoadResults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("files and directories", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		for i, result := range generateResults(10, 20, 30) {
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("unable to marshal result, %v", err)
+			}
+
+			name := filepath.Join(dir, string(rune('a'+i))+".json")
+			if err := os.WriteFile(name, data, 0o600); err != nil {
+				t.Fatalf("unable to write result, %v", err)
+			}
+		}
+
+		// Load the directory, and a single file from it
+		results, err := LoadResults([]string{dir, filepath.Join(dir, "a.json")})
+		if err != nil {
+			t.Fatalf("unable to load results, %v", err)
+		}
+
+		if !assert.Len(t, results, 4) {
+			return
+		}
+
+		assert.Equal(t, 10, results[0].AverageTPS)
+		assert.Equal(t, 30, results[2].AverageTPS)
+		assert.Equal(t, 10, results[3].AverageTPS)
+	})
+
+	t.Run("run manifests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir     = t.TempDir()
+			results = generateResults(10, 20)
+		)
+
+		for i, result := range results {
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("unable to marshal result, %v", err)
+			}
+
+			var (
+				runID = string(rune('a' + i))
+				name  = filepath.Join(dir, runID+".json")
+			)
+
+			if err := os.WriteFile(name, data, 0o600); err != nil {
+				t.Fatalf("unable to write result, %v", err)
+			}
+
+			artifact, err := manifest.NewArtifact(manifest.TypeResults, name)
+			if err != nil {
+				t.Fatalf("unable to create artifact, %v", err)
+			}
+
+			if err := manifest.Append(filepath.Join(dir, manifest.FileName(runID)), runID, artifact); err != nil {
+				t.Fatalf("unable to append artifact, %v", err)
+			}
+		}
+
+		// Load the directory (resolved through the manifests), and a single manifest
+		loaded, err := LoadResults([]string{dir, filepath.Join(dir, manifest.FileName("b"))})
+		if err != nil {
+			t.Fatalf("unable to load results, %v", err)
+		}
+
+		if !assert.Len(t, loaded, 3) {
+			return
+		}
+
+		assert.Equal(t, 10, loaded[0].AverageTPS)
+		assert.Equal(t, 20, loaded[1].AverageTPS)
+		assert.Equal(t, 20, loaded[2].AverageTPS)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadResults([]string{t.TempDir()})
+
+		assert.ErrorIs(t, err, errNoResults)
+	})
+}
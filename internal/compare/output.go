@@ -0,0 +1,53 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Display writes the comparison report to the given writer
+func (r *Report) Display(w io.Writer) {
+	fmt.Fprintln(w, "\n📊 Baseline Comparison 📊")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Metric\tBaseline\tCandidate\tDelta\tp-value\tVerdict")
+
+	for _, m := range r.Metrics {
+		pValue := "-"
+		if m.Verdict != VerdictDelta {
+			pValue = fmt.Sprintf("%.4f", m.PValue)
+		}
+
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%+.2f%%\t%s\t%s\n",
+			m.Name,
+			formatStat(m.Baseline),
+			formatStat(m.Candidate),
+			m.DeltaPercent,
+			pValue,
+			m.Verdict,
+		)
+	}
+
+	_ = tw.Flush()
+
+	if r.NoVariance {
+		fmt.Fprintln(
+			w,
+			"\n⚠️ Missing variance data: a single run was provided for at least one side, "+
+				"so only plain deltas are shown. Provide multiple runs for a significance test ⚠️",
+		)
+	}
+}
+
+// formatStat formats the metric stat for display
+func formatStat(s Stat) string {
+	if s.Runs < 2 {
+		return fmt.Sprintf("%.4g", s.Mean)
+	}
+
+	return fmt.Sprintf("%.4g ± %.2g (n=%d)", s.Mean, s.StdDev, s.Runs)
+}
@@ -0,0 +1,165 @@
+package compare
+
+import "math"
+
+// sample is the summary of a metric sample
+type sample struct {
+	n      int
+	mean   float64
+	stddev float64
+}
+
+// newSample summarizes the metric values
+func newSample(values []float64) sample {
+	s := sample{
+		n: len(values),
+	}
+
+	if s.n == 0 {
+		return s
+	}
+
+	for _, value := range values {
+		s.mean += value
+	}
+
+	s.mean /= float64(s.n)
+
+	if s.n < 2 {
+		return s
+	}
+
+	var variance float64
+	for _, value := range values {
+		variance += (value - s.mean) * (value - s.mean)
+	}
+
+	// Sample (unbiased) standard deviation
+	s.stddev = math.Sqrt(variance / float64(s.n-1))
+
+	return s
+}
+
+// welchTTest runs Welch's two-sample t-test,
+// and returns the two-tailed p-value
+func welchTTest(a, b sample) float64 {
+	var (
+		varA = a.stddev * a.stddev / float64(a.n)
+		varB = b.stddev * b.stddev / float64(b.n)
+	)
+
+	if varA+varB == 0 {
+		if a.mean == b.mean {
+			return 1
+		}
+
+		// No variance at all, any difference is significant
+		return 0
+	}
+
+	t := (a.mean - b.mean) / math.Sqrt(varA+varB)
+
+	// Welch–Satterthwaite degrees of freedom
+	df := (varA + varB) * (varA + varB) /
+		(varA*varA/float64(a.n-1) + varB*varB/float64(b.n-1))
+
+	// Two-tailed p-value from the Student's t distribution
+	return regularizedIncompleteBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// regularizedIncompleteBeta computes I_x(a, b), using
+// the continued fraction representation (Numerical Recipes)
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	if x >= 1 {
+		return 1
+	}
+
+	lgammaAB, _ := math.Lgamma(a + b)
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges faster for x < (a+1)/(a+b+2)
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction
+// for the incomplete beta function, using Lentz's method
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+
+	var (
+		qab = a + b
+		qap = a + 1
+		qam = a - 1
+
+		c = 1.0
+		d = 1 - qab*x/qap
+	)
+
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIterations; m++ {
+		var (
+			fm = float64(m)
+			m2 = 2 * fm
+		)
+
+		// Even step
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return h
+}
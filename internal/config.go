@@ -2,10 +2,26 @@ package internal
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"net"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/gnolang/gno/pkgs/crypto/bip39"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/compare"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/fees"
+	"github.com/gnolang/supernova/internal/inclusion"
+	"github.com/gnolang/supernova/internal/preflight"
+	"github.com/gnolang/supernova/internal/reads"
 	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/internal/signer"
 )
 
 var (
@@ -15,6 +31,67 @@ var (
 	errInvalidSubaccounts  = errors.New("invalid number of subaccounts specified")
 	errInvalidTransactions = errors.New("invalid number of transactions specified")
 	errInvalidBatchSize    = errors.New("invalid batch size specified")
+	errInvalidThreshold    = errors.New("invalid completion threshold specified")
+	errInvalidStallFactor  = errors.New("invalid stall factor specified")
+	errInvalidResultsURL   = errors.New("invalid results URL specified")
+	errInvalidPendingTx    = errors.New("invalid pending transaction policy specified")
+	errInvalidConstruction = errors.New("invalid construction error policy specified")
+	errMissingOutput       = errors.New("output path required for results segments")
+	errInvalidDistributor  = errors.New("invalid distributor index specified")
+	errDistributorOverlap  = errors.New("distributor index overlaps the sub-account range")
+	errInvalidBroadcastURL = errors.New("invalid broadcast URL specified")
+	errInvalidAffinity     = errors.New("invalid endpoint affinity specified")
+	errPrepareReplay       = errors.New("prepare and replay dumps are mutually exclusive")
+	errMissingReplay       = errors.New("replay dump required for re-signing or partial replays")
+	errInvalidNodeMetrics  = errors.New("invalid node metrics URL specified")
+	errInvalidScrapeRate   = errors.New("invalid node metrics interval specified")
+	errNotReproducible     = errors.New("configuration is not reproducible")
+	errInvalidSLO          = errors.New("invalid latency SLO controller parameters specified")
+	errInvalidDepositDenom = errors.New("invalid storage deposit denomination specified")
+	errGenesisPlan         = errors.New("genesis funded runs can't use a funding plan")
+	errInvalidReadRatio    = errors.New("invalid read ratio specified")
+	errReadBudget          = errors.New("reads can only count against the TPS with a latency SLO")
+	errInvalidLockTTL      = errors.New("invalid account lock TTL specified")
+	errInvalidDispatch     = errors.New("invalid dispatch order specified")
+	errInvalidCorpusMode   = errors.New("invalid argument corpus mode specified")
+	errCallArgMode         = errors.New("call arguments are only supported by REALM_CALL")
+	errUnusedCorpus        = errors.New("argument corpus set, but the call argument has no {{.Corpus}} placeholder")
+	errInvalidProbeMsg     = errors.New("invalid size probe message type specified")
+	errProbeDump           = errors.New("size probes can't prepare or replay dumps")
+	errInvalidProbeGas     = errors.New("invalid size probe gas wanted specified")
+	errMissingCorpus       = errors.New("call argument has a {{.Corpus}} placeholder, but no argument corpus is set")
+	errEmbeddedBroadcast   = errors.New("embedded runs can't broadcast to other URLs")
+	errInvalidMaxSpend     = errors.New("invalid distributor spend cap specified")
+	errInvalidGasWanted    = errors.New("invalid gas wanted specified")
+	errInvalidProofSampler = errors.New("invalid inclusion proof sampling strategy specified")
+	errInvalidFundingBatch = errors.New("invalid funding batch size specified")
+	errInvalidStatusRate   = errors.New("invalid status interval specified")
+	errInvalidReuseSamples = errors.New("invalid reuse sample count specified")
+	errMintMode            = errors.New("mint options are only supported by MINT")
+	errInvalidMintRealm    = errors.New("invalid mint realm path specified")
+	errInvalidStateLock    = errors.New("invalid state lock timeout specified")
+	errInvalidLogRotation  = errors.New("invalid log file rotation specified")
+	errInvalidClientSample = errors.New("invalid client sample interval specified")
+	errSignersResign       = errors.New("re-signed dumps can't use externally managed signers")
+	errInvalidBackfillRate = errors.New("invalid tx backfill rate specified")
+	errInvalidSendRate     = errors.New("invalid send rate specified")
+	errSendRateSLO         = errors.New("a fixed send rate can't be used with a latency SLO")
+	errInvalidTimezone     = errors.New("invalid display timezone specified")
+	errInvalidGasMargin    = errors.New("invalid gas estimation margin specified")
+	errInvalidGasPrice     = errors.New("invalid gas price specified, expected <amount>ugnot/<gas>gas")
+	errReplayGas           = errors.New("replayed dumps are already signed, and can't estimate or price the gas")
+	errScenarioMode        = errors.New("scenarios are only supported by SCENARIO")
+	errMultipleURLs        = errors.New("multiple cluster URLs and broadcast URLs are mutually exclusive")
+	errMissingScenario     = errors.New("scenario file required for the SCENARIO mode")
+	errInvalidDuration     = errors.New("invalid run duration specified")
+	errDurationRate        = errors.New("a run duration requires a send rate")
+	errDurationConflict    = errors.New("a run duration doesn't support dumps, shards, plans, reproducibility or probes")
+	errDurationTxCount     = errors.New("a run duration can't be used with a fixed number of transactions")
+	errInvalidRecycleIdle  = errors.New("invalid sub-account recycle idle period specified")
+	errRecycleDuration     = errors.New("sub-account recycling requires a run duration")
+	errInvalidProgress     = errors.New("invalid progress interval specified")
+	errInvalidMetricsAddr  = errors.New("invalid live metrics address specified, expected <host>:<port>")
+	errShardPlan           = errors.New("sharded runs don't fund sub-accounts, and can't use a funding plan")
 )
 
 var (
@@ -24,48 +101,832 @@ var (
 
 // Config is the central pipeline configuration
 type Config struct {
-	URL      string // the URL of the cluster
+	URL      string // the URL of the cluster, unused for embedded runs
 	ChainID  string // the chain ID of the cluster
 	Mnemonic string // the mnemonic for the keyring
 	Mode     string // the stress test mode
 	Output   string // output path for results JSON, if any
+	Baseline string // the baseline results the run metrics are annotated against, if any
+
+	ProductionChains    string // the comma separated production chain IDs, guarded by the safety interlock
+	ConfirmedProduction bool   // flag indicating if runs against production chains are explicitly allowed
 
 	SubAccounts  uint64 // the number of sub-accounts in the run
 	Transactions uint64 // the total number of transactions
 	BatchSize    uint64 // the maximum size of the batch
+
+	DistributorIndex uint64 // the derivation index of the distributor account
+	SubAccountOffset uint64 // the derivation index of the first sub-account
+
+	ShardIndex uint64 // the index of the run shard, in [0, ShardCount)
+	ShardCount uint64 // the number of shards the sub-accounts and transactions are split between
+
+	PrewarmConnections uint64 // the number of connections pre-warmed before the run
+	PrimingCalls       uint64 // the number of unmeasured realm priming calls (REALM_CALL)
+	MaxSpend           string // the cap on the cumulative distributor spend of the invocation, if any
+
+	GasWantedCall    int64 // the gas wanted of the realm calls (REALM_CALL), the mode default if 0
+	GasWantedDeploy  int64 // the gas wanted of the realm deployments (REALM_DEPLOYMENT, REALM_CALL / MINT predeploy)
+	GasWantedPackage int64 // the gas wanted of the package deployments (PACKAGE_DEPLOYMENT)
+	GasWantedMint    int64 // the gas wanted of the token mints (MINT), the mode default if 0
+
+	Fees string // the per message type fee overrides JSON file, if any
+
+	EstimateGas bool    // flag indicating if the run gas wanted is estimated by simulating a run transaction
+	GasMargin   float64 // the safety margin of the estimated gas wanted, as a fraction of the simulated gas used
+	GasPrice    string  // the gas price the run transaction fees are derived from, the fixed gas fee if unset
+
+	Signers string // the account signer backends JSON file, by derivation index range, if any
+
+	CompletionThreshold float64       // the ratio of committed txs required to finalize collection
+	CompletionGrace     time.Duration // the no-match window before finalizing collection
+	StallFactor         float64       // the multiple of the block interval without a new block reported as a stall
+
+	ProofSamples  uint64 // the number of committed txs whose inclusion proofs are verified, disabled if 0
+	ProofStrategy string // the committed tx sampling strategy for the inclusion proofs
+
+	BackfillSample  uint64  // the number of sampled txs backfilling the blocks without results, all if 0
+	BackfillRate    float64 // the maximum tx queries per second of the backfill, disabled if 0
+	BackfillJournal string  // the backfill journal file, for resuming an interrupted backfill, if any
+
+	// RequireTxIndex is set if the features depending on the node tx indexer were explicitly
+	// requested, so a disabled indexer fails the run instead of disabling them
+	RequireTxIndex bool
+
+	StrictIntegrations bool // flag indicating if failed optional integrations fail the run
+
+	PipelinedFunding bool   // flag indicating if funding txs are pipelined
+	Sweep            bool   // flag indicating if the leftover sub-account funds are swept after the run
+	FundingBatch     uint64 // the maximum number of transfers in a single funding tx
+	ReuseResults     string // the previous run results seeding the sub-account states, if any
+	ReuseSamples     uint64 // the number of predicted sub-accounts verified against the chain
+	FundingPlan      string // the funding plan CSV path, if any
+
+	AssumeGenesisFunded bool // flag indicating if the sub-accounts are funded in genesis (no distribution)
+
+	StorageDeposit      uint64 // the storage deposit for each package deployment
+	StorageDepositDenom string // the denomination of the storage deposit, defaults to ugnot
+
+	Seed         uint64 // the seed for the transaction payload content, if any
+	Reproducible bool   // flag indicating if the constructed transactions need to be reproducible
+
+	VerifySignatures bool // flag indicating if the run tx signatures are verified locally before broadcast
+
+	ResultsURL string // the URL the results are uploaded to, if any
+	SpoolDir   string // the local upload queue directory
+
+	History   bool   // flag indicating if the run summary is appended to the local run history
+	HistoryDB string // the local run history database
+	Label     string // the free-form run label, recorded in the results and history
+	Preset    string // the named workload preset the unset flags default to, recorded in the results
+
+	NoHumanize bool   // flag indicating if the console summary shows the raw values, instead of humanized ones
+	Timezone   string // the display timezone of the console summary timestamps (the results are always UTC)
+
+	StatePassword string // the password for encrypting the state files, if any
+
+	PendingTxPolicy string        // the resolution policy for accounts with pending mempool txs
+	PendingTxWait   time.Duration // the maximum wait for pending mempool txs to drain
+
+	ConstructionErrorPolicy string // the handling policy for txs that fail to be constructed (defaults to abort)
+
+	CallArgument string // the realm call argument template, if any
+	ArgCorpus    string // the argument corpus file sampled by the call argument, if any
+	CorpusMode   string // the argument corpus sampling mode (defaults to with-replacement)
+
+	MintRealm        string // the existing realm targeted by the mints (MINT), instead of a fresh deployment
+	MintMetadataSize uint64 // the metadata size of each minted token (MINT), the mode default if 0
+
+	Scenario string // the custom workload scenario file (SCENARIO), if any
+
+	ProbeMsg        string // the message type probed in the PROBE_SIZE mode
+	ProbeResolution uint64 // the size probe binary search resolution, in bytes
+	ProbeGasWanted  int64  // the gas wanted of each probed transaction
+
+	ReportInterval time.Duration // the interval for intermediate results segments, if any
+	StatusInterval time.Duration // the run status file rewrite interval, disabled if 0
+	TraceHTTP      bool          // flag indicating if the broadcast requests should be traced
+	GroupBatches   bool          // flag indicating if batches are grouped by message type
+	DispatchOrder  string        // the order the account transactions are dispatched in (defaults to interleaved)
+	ForceBatch     bool          // flag indicating if the single broadcast fallback is disabled
+
+	MempoolSampleInterval time.Duration // the mempool sampling interval for latency attribution, if any
+
+	NodeMetricsURL      string        // the Prometheus endpoint of the node, if any
+	NodeMetrics         string        // the comma separated node metrics that are scraped
+	NodeMetricsInterval time.Duration // the node metrics scrape interval
+
+	ClientSampleInterval time.Duration // the supernova process sampling interval, for the client bottleneck watchdog
+
+	SendRate float64 // the fixed send rate (tx/s) of the broadcasts, if any
+
+	Duration time.Duration // the broadcast duration at the send rate, instead of a fixed number of transactions, if any
+
+	RecycleIdle time.Duration // the idle period after which duration run sub-accounts are recycled, disabled if 0
+
+	ProgressInterval time.Duration // the live progress console report interval, disabled if 0
+	MetricsAddr      string        // the listen address of the live Prometheus metrics endpoint, if any
+
+	LatencySLO       time.Duration // the p95 commit latency bound the send rate is adapted to, if any
+	SLOWindow        time.Duration // the interval between send rate adjustments
+	SLOInitialRate   float64       // the starting send rate (tx/s)
+	SLOIncreaseStep  float64       // the send rate increase (tx/s) when comfortably under the SLO
+	SLOBackoffFactor float64       // the send rate multiplier when the SLO is violated
+	SLOHeadroom      float64       // the share of the SLO the p95 needs to be under, to increase the rate
+
+	ReadRatio            float64 // the reads issued per broadcast transaction, if any
+	ReadQueries          string  // the query set file the reads cycle through, if any
+	ReadsCountAgainstTPS bool    // flag indicating if the reads share the send rate budget of the broadcasts
+
+	ExcludeAccounts string // the comma separated sub-account indices or addresses never used
+	OnlyAccounts    string // the comma separated sub-account indices or addresses allowed, if any
+
+	LockRegistry string        // the registry of the account index ranges used by live runs, if any
+	LockTTL      time.Duration // the expiry of the run locks that stopped refreshing (crashed runs)
+	ForceRange   bool          // flag indicating if the run starts even if its accounts are locked
+
+	StateLockTimeout time.Duration // the maximum wait for the shared state directory lock (history, lock registry)
+
+	LogFile    string        // the rolling log file of the console output, relative to the run directory, if any
+	LogMaxSize uint64        // the log file size above which it is rotated, unbounded if 0
+	LogMaxAge  time.Duration // the log file age above which it is rotated, unbounded if 0
+	LogKeep    uint64        // the number of rotated log files kept
+
+	BroadcastURLs    string // the comma separated URLs the transactions are broadcast to, if any
+	EndpointAffinity string // the strategy for assigning broadcasts to the endpoints
+
+	Embedded bool // flag indicating if the run targets an in-process node, instead of the URL
+
+	PrepareDump        string // the path the prepared transactions are dumped to, if any
+	ReplayDump         string // the path of the prepared transactions to replay, if any
+	ReSign             bool   // flag indicating if drifted dump accounts are re-signed
+	AllowPartialReplay bool   // flag indicating if only the non-drifted dump accounts are replayed
+
+	probe     *outputProbe            // the output writability probe, if any
+	accounts  *accountFilter          // the parsed sub-account filter
+	endpoints []string                // the parsed broadcast URLs
+	plan      distributor.FundingPlan // the parsed funding plan, if any
+	fees      fees.Map                // the loaded fee overrides, if any
+	signers   signer.Config           // the loaded account signer ranges, if any
+	queries   []reads.Query           // the parsed read query set, if any
+	baseline  *collector.RunResult    // the loaded baseline results, if any
+	callArg   *runtime.CallArgument   // the parsed call argument template, if any
+	corpus    *runtime.Corpus         // the loaded argument corpus, if any
+	scenario  *runtime.Scenario       // the loaded workload scenario, if any
+	spendCap  int64                   // the parsed distributor spend cap (ugnot), if any
+	shard     *collector.ShardResult  // the applied shard slice, if sharded
+	chains    map[string]struct{}     // the parsed production chain IDs
+	location  *time.Location          // the loaded display timezone
+	gasPrice  *std.GasPrice           // the parsed run gas price, if any
 }
 
 // Validate validates the stress-test configuration
 func (cfg *Config) Validate() error {
-	// Make sure the URL is valid
-	if !urlRegex.MatchString(cfg.URL) {
+	// Multiple cluster URLs are the broadcast endpoints.
+	// The first URL stays the cluster URL, for funding and collecting the results
+	if urls := strings.Split(cfg.URL, ","); len(urls) > 1 {
+		if cfg.BroadcastURLs != "" {
+			return errMultipleURLs
+		}
+
+		cfg.URL = strings.TrimSpace(urls[0])
+		cfg.BroadcastURLs = strings.Join(urls, ",")
+	}
+
+	// Make sure the URL is valid.
+	// Embedded runs target the in-process node instead
+	if !cfg.Embedded && !urlRegex.MatchString(cfg.URL) {
 		return errInvalidURL
 	}
 
-	// Make sure the mnemonic is valid
-	if !bip39.IsMnemonicValid(cfg.Mnemonic) {
+	// Make sure the dump configuration is valid
+	if cfg.PrepareDump != "" && cfg.ReplayDump != "" {
+		return errPrepareReplay
+	}
+
+	if (cfg.ReSign || cfg.AllowPartialReplay) && cfg.ReplayDump == "" {
+		return errMissingReplay
+	}
+
+	// Re-signed dump accounts are always derived from the mnemonic
+	if cfg.ReSign && cfg.Signers != "" {
+		return errSignersResign
+	}
+
+	// Make sure the mnemonic is valid.
+	// Replays only need the mnemonic for re-signing
+	if (cfg.ReplayDump == "" || cfg.ReSign) && !bip39.IsMnemonicValid(cfg.Mnemonic) {
 		return errInvalidMnemonic
 	}
 
 	// Make sure the mode is valid
-	if !runtime.IsRuntime(runtime.Type(cfg.Mode)) {
+	if !runtime.IsRuntime(runtime.Type(cfg.Mode)) && runtime.Type(cfg.Mode) != runtime.ProbeSize {
 		return errInvalidMode
 	}
 
+	// Make sure the size probe is valid, if any
+	if runtime.Type(cfg.Mode) == runtime.ProbeSize {
+		if err := cfg.validateSizeProbe(); err != nil {
+			return err
+		}
+	}
+
 	// Make sure the number of subaccounts is valid
 	if cfg.SubAccounts < 1 {
 		return errInvalidSubaccounts
 	}
 
+	// Make sure the account derivation indices are valid
+	if cfg.DistributorIndex > math.MaxUint32 || cfg.SubAccountOffset+cfg.SubAccounts > math.MaxUint32 {
+		return errInvalidDistributor
+	}
+
+	// Make sure the distributor is not one of the sub-accounts
+	if cfg.DistributorIndex >= cfg.SubAccountOffset &&
+		cfg.DistributorIndex < cfg.SubAccountOffset+cfg.SubAccounts {
+		return errDistributorOverlap
+	}
+
+	// Duration runs generate the transactions on the fly, at the send rate until the deadline
+	if cfg.Duration != 0 {
+		if err := cfg.validateDuration(); err != nil {
+			return err
+		}
+	}
+
+	// Only the duration runs generate the transactions on the fly, so they can be reassigned
+	if cfg.RecycleIdle < 0 {
+		return errInvalidRecycleIdle
+	}
+
+	if cfg.RecycleIdle > 0 && cfg.Duration == 0 {
+		return errRecycleDuration
+	}
+
 	// Make sure the number of transactions is valid
-	if cfg.Transactions < 1 {
+	if cfg.Duration == 0 && cfg.Transactions < 1 {
 		return errInvalidTransactions
 	}
 
+	// Make sure the shard is valid, and narrow the run to its slice
+	if err := cfg.applyShard(); err != nil {
+		return err
+	}
+
 	// Make sure the batch size is valid
 	if cfg.BatchSize < 1 {
 		return errInvalidBatchSize
 	}
 
+	// Make sure the funding batch size is valid
+	if cfg.FundingBatch < 1 {
+		return errInvalidFundingBatch
+	}
+
+	// Make sure the reused results are verified
+	if cfg.ReuseResults != "" && cfg.ReuseSamples < 1 {
+		return errInvalidReuseSamples
+	}
+
+	// Make sure the completion threshold is valid
+	if cfg.CompletionThreshold <= 0 || cfg.CompletionThreshold > 1 {
+		return errInvalidThreshold
+	}
+
+	// Make sure the stall factor is valid, if any.
+	// Shorter stalls are the regular block interval variance
+	if cfg.StallFactor != 0 && cfg.StallFactor < 1 {
+		return errInvalidStallFactor
+	}
+
+	// Make sure the pending transaction policy is valid
+	if !preflight.IsPendingPolicy(preflight.PendingPolicy(cfg.PendingTxPolicy)) {
+		return errInvalidPendingTx
+	}
+
+	// Make sure the construction error policy is valid
+	if cfg.ConstructionErrorPolicy != "" &&
+		!runtime.IsConstructionPolicy(runtime.ConstructionPolicy(cfg.ConstructionErrorPolicy)) {
+		return errInvalidConstruction
+	}
+
+	// Make sure the call arguments are valid
+	if err := cfg.validateArguments(); err != nil {
+		return err
+	}
+
+	// Make sure the mint options are valid
+	if err := cfg.validateMint(); err != nil {
+		return err
+	}
+
+	// Make sure the workload scenario is valid, if any
+	if err := cfg.validateScenario(); err != nil {
+		return err
+	}
+
+	// Make sure the run is reproducible, if required
+	if cfg.Reproducible {
+		if err := cfg.validateReproducible(); err != nil {
+			return err
+		}
+	}
+
+	// Make sure the results segments can be saved
+	if cfg.ReportInterval > 0 && cfg.Output == "" {
+		return errMissingOutput
+	}
+
+	// Make sure the status interval is valid
+	if cfg.StatusInterval < 0 {
+		return errInvalidStatusRate
+	}
+
+	// Make sure the live progress reporting is valid, if any
+	if cfg.ProgressInterval < 0 {
+		return errInvalidProgress
+	}
+
+	if cfg.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.MetricsAddr); err != nil {
+			return errInvalidMetricsAddr
+		}
+	}
+
+	// Make sure the storage deposit denomination is valid, if any
+	if cfg.StorageDepositDenom != "" && !std.NewCoin(cfg.StorageDepositDenom, 0).IsValid() {
+		return errInvalidDepositDenom
+	}
+
+	// Make sure the baseline can be loaded, if any
+	if cfg.Baseline != "" {
+		baseline, err := compare.LoadBaseline(cfg.Baseline)
+		if err != nil {
+			return fmt.Errorf("invalid baseline, %w", err)
+		}
+
+		cfg.baseline = baseline
+	}
+
+	// Make sure the results URL is valid, if any
+	if cfg.ResultsURL != "" && !urlRegex.MatchString(cfg.ResultsURL) {
+		return errInvalidResultsURL
+	}
+
+	// Make sure the node metrics scraping is valid, if any
+	if cfg.NodeMetricsURL != "" {
+		if !urlRegex.MatchString(cfg.NodeMetricsURL) {
+			return errInvalidNodeMetrics
+		}
+
+		if cfg.NodeMetricsInterval <= 0 {
+			return errInvalidScrapeRate
+		}
+	}
+
+	// Make sure the client sampling is valid
+	if cfg.ClientSampleInterval < 0 {
+		return errInvalidClientSample
+	}
+
+	// Make sure the fixed send rate is valid, if any.
+	// The latency SLO controller adapts the rate on its own
+	if cfg.SendRate < 0 {
+		return errInvalidSendRate
+	}
+
+	if cfg.SendRate > 0 && cfg.LatencySLO > 0 {
+		return errSendRateSLO
+	}
+
+	// Make sure the latency SLO controller is valid, if any
+	if cfg.LatencySLO > 0 {
+		if err := cfg.validateSLO(); err != nil {
+			return err
+		}
+	}
+
+	// Make sure the interleaved reads are valid, if any
+	if err := cfg.validateReads(); err != nil {
+		return err
+	}
+
+	// Make sure the dispatch order is valid, if any
+	if cfg.DispatchOrder != "" && !batcher.IsDispatchOrder(batcher.DispatchOrder(cfg.DispatchOrder)) {
+		return errInvalidDispatch
+	}
+
+	// Make sure the broadcast endpoints are valid
+	if !batcher.IsAffinity(batcher.Affinity(cfg.EndpointAffinity)) {
+		return errInvalidAffinity
+	}
+
+	endpoints, err := parseBroadcastURLs(cfg.BroadcastURLs)
+	if err != nil {
+		return err
+	}
+
+	cfg.endpoints = endpoints
+
+	// The in-process node is the only embedded run endpoint
+	if cfg.Embedded && len(endpoints) > 0 {
+		return errEmbeddedBroadcast
+	}
+
+	// Make sure the inclusion proof sampling strategy is valid
+	if cfg.ProofSamples > 0 && !inclusion.Strategy(cfg.ProofStrategy).IsValid() {
+		return errInvalidProofSampler
+	}
+
+	// Make sure the tx backfill rate is valid
+	if cfg.BackfillRate < 0 {
+		return errInvalidBackfillRate
+	}
+
+	// Make sure the gas wanted values are valid
+	if cfg.GasWantedCall < 0 || cfg.GasWantedDeploy < 0 || cfg.GasWantedPackage < 0 || cfg.GasWantedMint < 0 {
+		return errInvalidGasWanted
+	}
+
+	// Make sure the gas estimation and pricing are valid
+	if err := cfg.validateGas(); err != nil {
+		return err
+	}
+
+	// Make sure the display timezone is valid
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("%w, %v", errInvalidTimezone, err)
+	}
+
+	cfg.location = location
+
+	// The production chains are guarded regardless of the run configuration
+	cfg.chains = parseChainIDs(cfg.ProductionChains)
+
+	// Make sure the spend cap is valid, if any.
+	// Only the gas denomination spend is tracked
+	if cfg.MaxSpend != "" {
+		spendCap, err := parseGenesisAmount(cfg.MaxSpend)
+		if err != nil || len(spendCap) != 1 || spendCap[0].Denom != common.Denomination {
+			return errInvalidMaxSpend
+		}
+
+		cfg.spendCap = spendCap[0].Amount
+	}
+
+	// Genesis funded runs have no distribution
+	if cfg.AssumeGenesisFunded && cfg.FundingPlan != "" {
+		return errGenesisPlan
+	}
+
+	// The shards share the distributor, so they can't fund concurrently
+	if cfg.ShardCount > 1 && cfg.FundingPlan != "" {
+		return errShardPlan
+	}
+
+	// Make sure the funding plan is valid, if any
+	if cfg.FundingPlan != "" {
+		plan, err := distributor.LoadFundingPlan(cfg.FundingPlan)
+		if err != nil {
+			return fmt.Errorf("invalid funding plan, %w", err)
+		}
+
+		cfg.plan = plan
+	}
+
+	// Make sure the fee overrides are valid, if any
+	if cfg.Fees != "" {
+		feeMap, err := fees.Load(cfg.Fees)
+		if err != nil {
+			return fmt.Errorf("invalid fee overrides, %w", err)
+		}
+
+		cfg.fees = feeMap
+	}
+
+	// Make sure the account signers are valid, if any
+	if cfg.Signers != "" {
+		signers, err := signer.LoadConfig(cfg.Signers)
+		if err != nil {
+			return fmt.Errorf("invalid account signers, %w", err)
+		}
+
+		cfg.signers = signers
+	}
+
+	// Make sure the account locks can expire
+	if cfg.LockRegistry != "" && cfg.LockTTL <= 0 {
+		return errInvalidLockTTL
+	}
+
+	// Make sure the state lock wait is valid
+	if cfg.StateLockTimeout < 0 {
+		return errInvalidStateLock
+	}
+
+	// Make sure the log file rotation is valid
+	if cfg.LogFile != "" && (cfg.LogMaxAge < 0 || cfg.LogMaxSize > math.MaxInt64) {
+		return errInvalidLogRotation
+	}
+
+	// Make sure the sub-account filters are valid
+	accounts, err := newAccountFilter(cfg.ExcludeAccounts, cfg.OnlyAccounts, uint32(cfg.DistributorIndex))
+	if err != nil {
+		return err
+	}
+
+	cfg.accounts = accounts
+
+	// Make sure the results can be written at the end of the run
+	if cfg.Output != "" {
+		// The run working directory is created next to the results
+		probe, err := newOutputProbe(cfg.Output, cfg.resultsSize()+cfg.runDirSize())
+		if err != nil {
+			return fmt.Errorf("invalid output path, %w", err)
+		}
+
+		cfg.probe = probe
+	}
+
+	return nil
+}
+
+// validateDuration makes sure the duration run is valid.
+// The send rate is the target rate of the entire duration
+func (cfg *Config) validateDuration() error {
+	if cfg.Duration < 0 {
+		return errInvalidDuration
+	}
+
+	if cfg.SendRate <= 0 {
+		return errDurationRate
+	}
+
+	// The transactions are generated until the deadline, instead
+	if cfg.Transactions != 0 {
+		return errDurationTxCount
+	}
+
+	// The transaction set (and its funding) is not known up front
+	if cfg.PrepareDump != "" || cfg.ReplayDump != "" || cfg.ShardCount > 1 || cfg.FundingPlan != "" ||
+		cfg.Reproducible || runtime.Type(cfg.Mode) == runtime.ProbeSize {
+		return errDurationConflict
+	}
+
 	return nil
 }
+
+// runTransactions returns the number of run transactions.
+// Duration runs generate them on the fly, at the send rate over the entire duration
+func (cfg *Config) runTransactions() uint64 {
+	if cfg.Duration > 0 {
+		return durationTransactions(cfg.Duration, cfg.SendRate)
+	}
+
+	return cfg.Transactions
+}
+
+// durationTransactions returns the number of transactions
+// broadcast over the duration, at the given send rate
+func durationTransactions(duration time.Duration, rate float64) uint64 {
+	return uint64(math.Ceil(duration.Seconds() * rate))
+}
+
+// validateSLO makes sure the latency SLO controller parameters are valid
+func (cfg *Config) validateSLO() error {
+	switch {
+	case cfg.SLOWindow <= 0:
+		return fmt.Errorf("%w: the window needs to be positive", errInvalidSLO)
+	case cfg.SLOInitialRate <= 0:
+		return fmt.Errorf("%w: the initial rate needs to be positive", errInvalidSLO)
+	case cfg.SLOIncreaseStep <= 0:
+		return fmt.Errorf("%w: the increase step needs to be positive", errInvalidSLO)
+	case cfg.SLOBackoffFactor <= 0 || cfg.SLOBackoffFactor >= 1:
+		return fmt.Errorf("%w: the backoff factor needs to be in (0, 1)", errInvalidSLO)
+	case cfg.SLOHeadroom < 0 || cfg.SLOHeadroom >= 1:
+		return fmt.Errorf("%w: the headroom needs to be in [0, 1)", errInvalidSLO)
+	}
+
+	return nil
+}
+
+// validateReads makes sure the interleaved reads are valid,
+// and loads the read query set, if any
+func (cfg *Config) validateReads() error {
+	if cfg.ReadRatio < 0 {
+		return errInvalidReadRatio
+	}
+
+	// The only send rate budget is the latency SLO controller
+	if cfg.ReadsCountAgainstTPS && cfg.LatencySLO <= 0 {
+		return errReadBudget
+	}
+
+	if cfg.ReadQueries == "" {
+		return nil
+	}
+
+	queries, err := reads.LoadQueries(cfg.ReadQueries)
+	if err != nil {
+		return fmt.Errorf("invalid read query set, %w", err)
+	}
+
+	cfg.queries = queries
+
+	return nil
+}
+
+// validateSizeProbe makes sure the transaction size probe is valid
+func (cfg *Config) validateSizeProbe() error {
+	// Scenarios mix multiple messages, so there is no single message to probe
+	if !runtime.IsRuntime(runtime.Type(cfg.ProbeMsg)) || runtime.Type(cfg.ProbeMsg) == runtime.CustomScenario {
+		return errInvalidProbeMsg
+	}
+
+	if cfg.PrepareDump != "" || cfg.ReplayDump != "" {
+		return errProbeDump
+	}
+
+	if cfg.ProbeGasWanted < 0 {
+		return errInvalidProbeGas
+	}
+
+	return nil
+}
+
+// validateMint makes sure the token mint options are valid
+func (cfg *Config) validateMint() error {
+	if cfg.MintRealm == "" {
+		return nil
+	}
+
+	if runtime.Type(cfg.Mode) != runtime.Mint {
+		return errMintMode
+	}
+
+	if !strings.HasPrefix(cfg.MintRealm, "gno.land/r/") {
+		return errInvalidMintRealm
+	}
+
+	return nil
+}
+
+// validateScenario makes sure the workload scenario is used by
+// the SCENARIO mode, and loads it
+func (cfg *Config) validateScenario() error {
+	if cfg.Scenario == "" {
+		if runtime.Type(cfg.Mode) == runtime.CustomScenario {
+			return errMissingScenario
+		}
+
+		return nil
+	}
+
+	if runtime.Type(cfg.Mode) != runtime.CustomScenario {
+		return errScenarioMode
+	}
+
+	scenario, err := runtime.LoadScenario(cfg.Scenario)
+	if err != nil {
+		return err
+	}
+
+	cfg.scenario = scenario
+
+	return nil
+}
+
+// validateArguments makes sure the realm call arguments are valid,
+// and loads the argument corpus, if any
+func (cfg *Config) validateArguments() error {
+	if cfg.CorpusMode != "" && !runtime.IsCorpusMode(runtime.CorpusMode(cfg.CorpusMode)) {
+		return errInvalidCorpusMode
+	}
+
+	if cfg.CallArgument == "" || cfg.CallArgument == runtime.DefaultCallArgument {
+		if cfg.ArgCorpus != "" {
+			return errUnusedCorpus
+		}
+
+		return nil
+	}
+
+	if runtime.Type(cfg.Mode) != runtime.RealmCall {
+		return errCallArgMode
+	}
+
+	callArg, err := runtime.ParseCallArgument(cfg.CallArgument)
+	if err != nil {
+		return fmt.Errorf("invalid call argument, %w", err)
+	}
+
+	cfg.callArg = callArg
+
+	if !callArg.UsesCorpus() {
+		if cfg.ArgCorpus != "" {
+			return errUnusedCorpus
+		}
+
+		return nil
+	}
+
+	if cfg.ArgCorpus == "" {
+		return errMissingCorpus
+	}
+
+	corpus, err := runtime.LoadCorpus(cfg.ArgCorpus)
+	if err != nil {
+		return fmt.Errorf("invalid argument corpus, %w", err)
+	}
+
+	cfg.corpus = corpus
+
+	return nil
+}
+
+// validateReproducible makes sure the configuration
+// constructs the same transactions for the same inputs
+func (cfg *Config) validateReproducible() error {
+	if cfg.Seed == 0 {
+		return fmt.Errorf(
+			"%w: an explicit -seed is required, otherwise the payload content depends on the current time",
+			errNotReproducible,
+		)
+	}
+
+	if cfg.ReplayDump != "" {
+		return fmt.Errorf(
+			"%w: -replay broadcasts a prepared dump, and does not construct transactions",
+			errNotReproducible,
+		)
+	}
+
+	if cfg.EstimateGas {
+		return fmt.Errorf(
+			"%w: -estimate-gas derives the gas wanted from the node simulation",
+			errNotReproducible,
+		)
+	}
+
+	if preflight.PendingPolicy(cfg.PendingTxPolicy) == preflight.PendingExclude {
+		return fmt.Errorf(
+			"%w: the %s pending transaction policy drops accounts based on the chain state",
+			errNotReproducible,
+			preflight.PendingExclude,
+		)
+	}
+
+	return nil
+}
+
+// validateGas validates the gas estimation and pricing,
+// and parses the gas price, if any
+func (cfg *Config) validateGas() error {
+	if cfg.GasMargin < 0 {
+		return errInvalidGasMargin
+	}
+
+	if cfg.ReplayDump != "" && (cfg.EstimateGas || cfg.GasPrice != "") {
+		return errReplayGas
+	}
+
+	if cfg.GasPrice == "" {
+		return nil
+	}
+
+	// The gas fees are paid in the gas denomination
+	price, err := std.ParseGasPrice(cfg.GasPrice)
+	if err != nil || price.Gas <= 0 || price.Price.Denom != common.Denomination {
+		return errInvalidGasPrice
+	}
+
+	cfg.gasPrice = &price
+
+	return nil
+}
+
+// parseBroadcastURLs parses the comma separated broadcast URLs
+func parseBroadcastURLs(list string) ([]string, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	urls := strings.Split(list, ",")
+
+	for index, url := range urls {
+		urls[index] = strings.TrimSpace(url)
+
+		if !urlRegex.MatchString(urls[index]) {
+			return nil, fmt.Errorf("%w: %q", errInvalidBroadcastURL, url)
+		}
+	}
+
+	return urls, nil
+}
+
+// Cleanup removes any temporary artifacts created
+// during validation. It is safe to call multiple times
+func (cfg *Config) Cleanup() {
+	cfg.probe.cleanup()
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate_Distributor(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name             string
+		distributorIndex uint64
+		subAccountOffset uint64
+		subAccounts      uint64
+		expectedErr      error
+	}{
+		{
+			"distributor below the sub-accounts",
+			0,
+			1,
+			3,
+			nil,
+		},
+		{
+			"distributor past the sub-accounts",
+			4,
+			1,
+			3,
+			nil,
+		},
+		{
+			"distributor inside the sub-accounts",
+			2,
+			1,
+			3,
+			errDistributorOverlap,
+		},
+		{
+			"distributor at the sub-account offset",
+			5,
+			5,
+			3,
+			errDistributorOverlap,
+		},
+		{
+			"distributor at the last sub-account",
+			3,
+			1,
+			3,
+			errDistributorOverlap,
+		},
+		{
+			"sub-account offset overlapping the default distributor",
+			0,
+			0,
+			1,
+			errDistributorOverlap,
+		},
+		{
+			"distributor index out of range",
+			math.MaxUint32 + 1,
+			1,
+			3,
+			errInvalidDistributor,
+		},
+		{
+			"sub-accounts out of range",
+			0,
+			math.MaxUint32,
+			3,
+			errInvalidDistributor,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testConfig(t, "http://127.0.0.1:26657")
+
+			cfg.DistributorIndex = testCase.distributorIndex
+			cfg.SubAccountOffset = testCase.subAccountOffset
+			cfg.SubAccounts = testCase.subAccounts
+
+			err := cfg.Validate()
+
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package internal
+
+import "syscall"
+
+// availableDiskSpace returns the number of bytes
+// available to unprivileged users in the given directory
+func availableDiskSpace(dir string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+
+	//nolint:unconvert // field types differ between platforms
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
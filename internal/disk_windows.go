@@ -0,0 +1,10 @@
+//go:build windows
+
+package internal
+
+import "errors"
+
+// availableDiskSpace is not supported on Windows
+func availableDiskSpace(_ string) (uint64, error) {
+	return 0, errors.New("unsupported platform")
+}
@@ -0,0 +1,170 @@
+package distributor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+// The distributor spending sites, recorded in the spend report
+const (
+	SpendPredeploy    = "predeploy"
+	SpendDistribution = "distribution"
+	SpendPlan         = "funding plan"
+	SpendPriming      = "priming"
+	SpendTopUp        = "top-up"
+)
+
+// ErrSpendCap is returned when a spend would exceed the spend cap
+var ErrSpendCap = errors.New("distributor spend cap reached")
+
+// Budget tracks the cumulative distributor spend (funding transfers plus fees)
+// across the whole invocation, and enforces the spend cap, if any.
+// Only the gas denomination is tracked.
+// Spends are recorded before the broadcast, so failed transactions still count.
+// Pipelined funding spends are checked before the broadcast, and recorded
+// once their commit outcome is known
+type Budget struct {
+	mux sync.Mutex
+
+	limit  int64                   // the spend cap, 0 if uncapped
+	spent  int64                   // the cumulative spend
+	events []*collector.SpendEvent // the recorded spends, in order
+	capped bool                    // flag indicating if a spend was refused
+}
+
+// NewBudget creates a new distributor budget, with the given spend cap.
+// A zero limit tracks the spend without a cap
+func NewBudget(limit int64) *Budget {
+	return &Budget{
+		limit:  limit,
+		events: make([]*collector.SpendEvent, 0),
+	}
+}
+
+// Spend records the spend at the given site, if it fits the spend cap.
+// Refused spends are not recorded, and return ErrSpendCap
+func (b *Budget) Spend(site string, amount int64) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if err := b.check(site, amount); err != nil {
+		return err
+	}
+
+	b.record(site, amount)
+
+	return nil
+}
+
+// Check returns ErrSpendCap if the spend at the given site
+// would exceed the spend cap, without recording it
+func (b *Budget) Check(site string, amount int64) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	return b.check(site, amount)
+}
+
+// Record records the spend at the given site, regardless of the spend cap,
+// for spends that were already paid after being checked against the cap
+func (b *Budget) Record(site string, amount int64) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.record(site, amount)
+}
+
+// check checks the spend against the spend cap
+func (b *Budget) check(site string, amount int64) error {
+	if b.limit <= 0 || b.spent+amount <= b.limit {
+		return nil
+	}
+
+	b.capped = true
+
+	return fmt.Errorf(
+		"%w, %s spend of %d %s exceeds the remaining %d %s",
+		ErrSpendCap,
+		site,
+		amount,
+		common.Denomination,
+		b.limit-b.spent,
+		common.Denomination,
+	)
+}
+
+// record records the spend
+func (b *Budget) record(site string, amount int64) {
+	b.spent += amount
+	b.events = append(b.events, &collector.SpendEvent{
+		Time:       time.Now(),
+		Site:       site,
+		Amount:     amount,
+		Cumulative: b.spent,
+	})
+}
+
+// Remaining returns the funds left under the spend cap,
+// and false if the spend is uncapped
+func (b *Budget) Remaining() (int64, bool) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.limit <= 0 {
+		return 0, false
+	}
+
+	return b.limit - b.spent, true
+}
+
+// SpendTx records the spend of the transaction at the given site
+func (b *Budget) SpendTx(site string, tx *std.Tx) error {
+	return b.Spend(site, TxSpend(tx))
+}
+
+// Report returns the spend report, against the cap
+func (b *Budget) Report() *collector.SpendResult {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	events := make([]*collector.SpendEvent, len(b.events))
+	copy(events, b.events)
+
+	return &collector.SpendResult{
+		Cap:    b.limit,
+		Spent:  b.spent,
+		Capped: b.capped,
+		Events: events,
+	}
+}
+
+// TxSpend returns the gas denomination funds the transaction spends,
+// as the fee, the transfers, the deposits and the call sends
+func TxSpend(tx *std.Tx) int64 {
+	var spend int64
+
+	if tx.Fee.GasFee.Denom == common.Denomination {
+		spend += tx.Fee.GasFee.Amount
+	}
+
+	for _, msg := range tx.Msgs {
+		switch m := msg.(type) {
+		case bank.MsgSend:
+			spend += m.Amount.AmountOf(common.Denomination)
+		case vm.MsgAddPackage:
+			spend += m.Deposit.AmountOf(common.Denomination)
+		case vm.MsgCall:
+			spend += m.Send.AmountOf(common.Denomination)
+		}
+	}
+
+	return spend
+}
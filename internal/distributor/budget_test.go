@@ -0,0 +1,170 @@
+package distributor
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget_Spend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("spend cap enforced", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBudget(100)
+
+		assert.NoError(t, b.Spend(SpendPredeploy, 40))
+		assert.NoError(t, b.Spend(SpendDistribution, 60))
+
+		// The cap is reached, so any further spend is refused
+		assert.ErrorIs(t, b.Spend(SpendPriming, 1), ErrSpendCap)
+
+		remaining, capped := b.Remaining()
+
+		assert.True(t, capped)
+		assert.Equal(t, int64(0), remaining)
+
+		report := b.Report()
+
+		assert.Equal(t, int64(100), report.Cap)
+		assert.Equal(t, int64(100), report.Spent)
+		assert.True(t, report.Capped)
+
+		// Refused spends are not recorded
+		if len(report.Events) != 2 {
+			t.Fatalf("invalid number of spend events, %d", len(report.Events))
+		}
+
+		assert.Equal(t, SpendPredeploy, report.Events[0].Site)
+		assert.Equal(t, int64(40), report.Events[0].Cumulative)
+		assert.Equal(t, int64(100), report.Events[1].Cumulative)
+	})
+
+	t.Run("checked spends recorded later", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBudget(100)
+
+		// Checked spends are not recorded
+		assert.NoError(t, b.Check(SpendDistribution, 80))
+		assert.Zero(t, b.Report().Spent)
+
+		b.Record(SpendDistribution, 80)
+
+		assert.ErrorIs(t, b.Check(SpendDistribution, 30), ErrSpendCap)
+
+		report := b.Report()
+
+		assert.Equal(t, int64(80), report.Spent)
+		assert.True(t, report.Capped)
+		assert.Len(t, report.Events, 1)
+	})
+
+	t.Run("uncapped", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBudget(0)
+
+		assert.NoError(t, b.Spend(SpendDistribution, 1_000_000_000))
+
+		_, capped := b.Remaining()
+
+		assert.False(t, capped)
+		assert.False(t, b.Report().Capped)
+	})
+}
+
+func TestBudget_TxSpend(t *testing.T) {
+	t.Parallel()
+
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				Amount: std.NewCoins(std.NewCoin(common.Denomination, 100), std.NewCoin("foo", 50)),
+			},
+			vm.MsgAddPackage{
+				Deposit: std.NewCoins(std.NewCoin(common.Denomination, 20)),
+			},
+			vm.MsgCall{
+				Send: std.NewCoins(std.NewCoin(common.Denomination, 3)),
+			},
+		},
+		Fee: std.NewFee(100000, common.DefaultGasFee),
+	}
+
+	// Only the gas denomination is tracked
+	assert.Equal(t, 123+common.DefaultGasFee.Amount, TxSpend(tx))
+}
+
+func TestDistributor_SpendCap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx        = uint64(100)
+		singleCost   = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))
+		transferCost = singleCost.AmountOf(common.Denomination) + common.DefaultGasFee.Amount
+
+		accounts = generateAccounts(t, 10)
+	)
+
+	mockClient := &mockClient{
+		getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+			coins := std.NewCoins()
+
+			// The distributor has funds for all accounts
+			if address == accounts[0].GetAddress().String() {
+				coins = std.NewCoins(std.NewCoin(common.Denomination, 100*transferCost))
+			}
+
+			for _, account := range accounts {
+				if account.GetAddress().String() == address {
+					return &gnoland.GnoAccount{
+						BaseAccount: *std.NewBaseAccount(account.GetAddress(), coins, nil, 0, 0),
+					}, nil
+				}
+			}
+
+			t.Fatal("invalid account requested")
+
+			return nil, nil
+		},
+	}
+
+	t.Run("partial funding", func(t *testing.T) {
+		t.Parallel()
+
+		budget := NewBudget(3*transferCost + transferCost/2)
+
+		d := NewDistributor(mockClient, &mockSigner{}, WithBudget(budget))
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		// The run goes on with the accounts funded before the cap
+		assert.Len(t, readyAccounts, 3)
+
+		report := budget.Report()
+
+		assert.True(t, report.Capped)
+		assert.Equal(t, 3*transferCost, report.Spent)
+		assert.Len(t, report.Events, 3)
+	})
+
+	t.Run("no account funded", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDistributor(mockClient, &mockSigner{}, WithBudget(NewBudget(transferCost-1)))
+
+		_, err := d.Distribute(accounts, numTx)
+
+		assert.ErrorIs(t, err, ErrSpendCap)
+	})
+}
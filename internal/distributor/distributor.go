@@ -4,23 +4,41 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gnolang/gno/gnoland"
 	"github.com/gnolang/gno/pkgs/crypto"
 	"github.com/gnolang/gno/pkgs/crypto/keys"
 	"github.com/gnolang/gno/pkgs/sdk/bank"
 	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
 	"github.com/gnolang/supernova/internal/common"
 	"github.com/schollz/progressbar/v3"
 )
 
+// DefaultFundingBatchSize is the suggested maximum number of
+// transfers in a single sub-account funding transaction
+const DefaultFundingBatchSize = 100
+
+// DefaultFundingGas is the default gas wanted of each funding transfer
+const DefaultFundingGas = 100000
+
+// maxFundingGas is the gas wanted a single funding transaction is bounded by (the gno.land block max gas),
+// so a funding batch always fits a block
+const maxFundingGas = 10_000_000
+
 var (
 	errInsufficientFunds = errors.New("insufficient distributor funds")
+	errFundingRefused    = errors.New("sub-accounts are short on funds, and funding is disabled")
 )
 
 type Client interface {
+	collector.WatcherClient
+
 	GetAccount(address string) (*gnoland.GnoAccount, error)
 	BroadcastTransaction(tx *std.Tx) error
+	BroadcastTransactionSync(tx *std.Tx) ([]byte, error)
 }
 
 type Signer interface {
@@ -32,17 +50,60 @@ type Signer interface {
 type Distributor struct {
 	cli    Client
 	signer Signer
+
+	pipelined     bool          // flag indicating if funding txs are pipelined
+	commitTimeout time.Duration // the commit timeout for pipelined funding txs
+	batchSize     int           // the maximum number of transfers in a single funding tx
+
+	storageDeposit std.Coin // the storage deposit paid by each run transaction
+	primingTxs     uint64   // the number of priming transactions paid by the distributor
+
+	runFee     std.Coin // the gas fee of each run transaction
+	primingFee std.Coin // the gas fee of each priming transaction
+	fundingFee std.Coin // the gas fee of each funding transfer (and sweep transaction)
+	fundingGas int64    // the gas wanted of each funding transfer
+
+	refused map[string]struct{} // the sub-accounts that are never funded
+	index   uint32              // the derivation index of the distributor account
+	plan    FundingPlan         // the pre-computed funding transfers, if any
+
+	genesisFunded  bool // flag indicating if the sub-accounts are funded in genesis
+	fundingRefused bool // flag indicating if short sub-accounts fail the distribution, instead of being funded
+
+	predictions       Predictions // the predicted sub-account states, from a previous run, if any
+	predictionSamples int         // the number of predicted sub-accounts verified against the chain
+	predictionSeed    int64       // the seed for sampling the predicted sub-accounts
+
+	budget *Budget // the invocation spend budget
+
+	costs *collector.CostResult // the cost report of the latest distribution
 }
 
 // NewDistributor creates a new instance of the distributor
 func NewDistributor(
 	cli Client,
 	signer Signer,
+	opts ...Option,
 ) *Distributor {
-	return &Distributor{
-		cli:    cli,
-		signer: signer,
+	d := &Distributor{
+		cli:            cli,
+		signer:         signer,
+		commitTimeout:  time.Minute * 2,
+		batchSize:      1,
+		storageDeposit: std.NewCoin(common.Denomination, 0),
+		runFee:         common.DefaultGasFee,
+		primingFee:     common.DefaultGasFee,
+		fundingFee:     common.DefaultGasFee,
+		fundingGas:     DefaultFundingGas,
+		refused:        make(map[string]struct{}),
+		budget:         NewBudget(0),
+	}
+
+	for _, opt := range opts {
+		opt(d)
 	}
+
+	return d
 }
 
 // Distribute distributes the funds from the base account
@@ -53,46 +114,185 @@ func (d *Distributor) Distribute(
 ) ([]*gnoland.GnoAccount, error) {
 	fmt.Printf("\n💸 Starting Fund Distribution 💸\n\n")
 
+	fmt.Printf(
+		"Distributor account is at index %d (%s)\n",
+		d.index,
+		accounts[0].GetAddress().String(),
+	)
+
+	// The distribution is funded by the same cost model as the offline estimate
+	estimate := d.estimateCosts(accounts, transactions)
+
+	primingCost := estimate.PrimingCost
+	primingAmount := primingCost.AmountOf(common.Denomination)
+
+	// The funding plan replaces the sub-account cost calculation
+	if len(d.plan) > 0 {
+		return d.distributePlan(accounts, transactions, primingCost)
+	}
+
 	// Calculate the base fees
-	subAccountCost := calculateRuntimeCosts(int64(transactions))
+	subAccountCost := estimate.AccountCost
+	fmt.Printf("Calculated sub-account cost as %s\n", subAccountCost)
+
 	fmt.Printf(
-		"Calculated sub-account cost as %d %s\n",
-		subAccountCost.Amount,
-		subAccountCost.Denom,
+		"Estimated the required distributor balance as %s, if no sub-account is funded\n",
+		estimate.Total,
 	)
 
+	d.costs = &collector.CostResult{
+		Denom:          common.Denomination,
+		TxCost:         estimate.TxCost.Amount,
+		StorageDeposit: d.storageDeposit.Amount,
+		AccountCost:    subAccountCost.AmountOf(common.Denomination),
+		TotalDeposits:  int64(transactions) * d.storageDeposit.Amount,
+		PrimingTxs:     d.primingTxs,
+		PrimingCost:    primingAmount,
+		EstimatedTotal: estimate.Total.AmountOf(common.Denomination),
+
+		DistributorIndex:   d.index,
+		DistributorAddress: accounts[0].GetAddress().String(),
+	}
+
+	// Deposits in a separate denomination are funded alongside the gas
+	if d.storageDeposit.IsPositive() && d.storageDeposit.Denom != common.Denomination {
+		d.costs.DepositDenom = d.storageDeposit.Denom
+		d.costs.AccountDeposit = subAccountCost.AmountOf(d.storageDeposit.Denom)
+	}
+
+	if d.primingTxs > 0 {
+		fmt.Printf(
+			"Reserved %d %s for %d priming transactions\n",
+			primingAmount,
+			common.Denomination,
+			d.primingTxs,
+		)
+	}
+
+	if d.storageDeposit.IsPositive() {
+		fmt.Printf(
+			"Storage deposits for the run total %d %s (%d %s per transaction)\n",
+			d.costs.TotalDeposits,
+			d.storageDeposit.Denom,
+			d.storageDeposit.Amount,
+			d.storageDeposit.Denom,
+		)
+	}
+
+	// Genesis funded sub-accounts skip the distribution entirely
+	if d.genesisFunded {
+		d.costs.GenesisFunded = true
+
+		return d.fetchGenesisAccounts(accounts, subAccountCost, primingCost)
+	}
+
 	// Fund the accounts
-	return d.fundAccounts(accounts, subAccountCost)
+	return d.fundAccounts(accounts, subAccountCost, primingCost)
+}
+
+// distributePlan executes the funding plan, and
+// produces the cost report for the planned transfers
+func (d *Distributor) distributePlan(
+	accounts []keys.Info,
+	transactions uint64,
+	primingCost std.Coins,
+) ([]*gnoland.GnoAccount, error) {
+	planned := d.plan.Total()
+
+	fmt.Printf(
+		"Using the funding plan of %d transfers, totaling %d %s\n",
+		len(d.plan),
+		planned.Amount,
+		planned.Denom,
+	)
+
+	d.costs = &collector.CostResult{
+		Denom:            common.Denomination,
+		TxCost:           d.runFee.Add(common.InitialTxCost).Amount,
+		StorageDeposit:   d.storageDeposit.Amount,
+		TotalDeposits:    int64(transactions) * d.storageDeposit.Amount,
+		PrimingTxs:       d.primingTxs,
+		PrimingCost:      primingCost.AmountOf(common.Denomination),
+		PlannedTransfers: len(d.plan),
+		PlannedFunds:     planned.Amount,
+
+		DistributorIndex:   d.index,
+		DistributorAddress: accounts[0].GetAddress().String(),
+	}
+
+	return d.fundPlan(accounts, primingCost)
+}
+
+// CostReport returns the cost report of the latest distribution, if any
+func (d *Distributor) CostReport() *collector.CostResult {
+	return d.costs
 }
 
 // calculateRuntimeCosts calculates the amount of funds
 // each account needs to have in order to participate in the
-// stress test run
-func calculateRuntimeCosts(totalTx int64) std.Coin {
-	// Cost of a single run transaction for the sub-account
-	// NOTE: Since there is no gas estimation support yet, this value
-	// is fixed, but it will change in the future once pricing estimations
-	// are added
-	baseTxCost := common.DefaultGasFee.Add(common.InitialTxCost)
+// stress test run. The storage deposit is paid by each
+// run transaction, in addition to the gas costs, and can
+// be in a different denomination than the gas
+func calculateRuntimeCosts(totalTx int64, storageDeposit std.Coin) std.Coins {
+	return EstimateCosts(CostParams{
+		Transactions:   uint64(totalTx),
+		StorageDeposit: storageDeposit,
+	}).AccountCost
+}
 
-	// Each account should have enough funds
-	// to execute the entire run
-	subAccountCost := std.Coin{
-		Denom:  common.Denomination,
-		Amount: totalTx * baseTxCost.Amount,
+// estimateCosts runs the cost model for the distribution,
+// over the sub-accounts that are not refused
+func (d *Distributor) estimateCosts(accounts []keys.Info, transactions uint64) *CostEstimate {
+	subAccounts := 0
+
+	for _, account := range accounts[1:] {
+		if _, refused := d.refused[account.GetAddress().String()]; !refused {
+			subAccounts++
+		}
 	}
 
-	return subAccountCost
+	return EstimateCosts(CostParams{
+		Transactions:   transactions,
+		SubAccounts:    uint64(subAccounts),
+		FundingBatch:   d.batchSize,
+		FundingGas:     d.fundingGas,
+		StorageDeposit: d.storageDeposit,
+		PrimingTxs:     d.primingTxs,
+		GasFee:         d.runFee,
+		PrimingFee:     d.primingFee,
+		FundingFee:     d.fundingFee,
+	})
 }
 
-// fundAccounts attempts to fund accounts that have missing funds,
-// and returns the accounts that can participate in the stress test
-func (d *Distributor) fundAccounts(accounts []keys.Info, singleRunCost std.Coin) ([]*gnoland.GnoAccount, error) {
-	type shortAccount struct {
-		address      crypto.Address
-		missingFunds std.Coin
+// calculateMissingFunds calculates the funds the balance is missing to cover
+// the required funds, per denomination. The result is empty if all denominations are covered
+func calculateMissingFunds(balance, required std.Coins) std.Coins {
+	missing := make([]std.Coin, 0, len(required))
+
+	for _, coin := range required {
+		if available := balance.AmountOf(coin.Denom); available < coin.Amount {
+			missing = append(missing, std.NewCoin(coin.Denom, coin.Amount-available))
+		}
 	}
 
+	return std.NewCoins(missing...)
+}
+
+// shortAccount is a sub-account that is missing
+// funds to participate in the stress test run
+type shortAccount struct {
+	address      crypto.Address
+	missingFunds std.Coins
+}
+
+// fundAccounts attempts to fund accounts that have missing funds,
+// and returns the accounts that can participate in the stress test.
+// The reserved cost is kept in the distributor account for later use
+func (d *Distributor) fundAccounts(
+	accounts []keys.Info,
+	singleRunCost std.Coins,
+	reservedCost std.Coins,
+) ([]*gnoland.GnoAccount, error) {
 	var (
 		// Accounts that are ready (funded) for the run
 		readyAccounts = make([]*gnoland.GnoAccount, 0, len(accounts))
@@ -101,24 +301,40 @@ func (d *Distributor) fundAccounts(accounts []keys.Info, singleRunCost std.Coin)
 		shortAccounts = make([]shortAccount, 0, len(accounts))
 	)
 
+	// Resolve the sub-account states, from the
+	// previous run predictions where they can be trusted
+	addresses := make([]string, 0, len(accounts)-1)
+	for _, account := range accounts[1:] {
+		addresses = append(addresses, account.GetAddress().String())
+	}
+
+	resolver, err := d.newAccountResolver(addresses)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if there are any accounts that need to be funded
 	// before the stress test starts
 	for _, account := range accounts[1:] {
+		// Make sure the account is not refused
+		if _, refused := d.refused[account.GetAddress().String()]; refused {
+			fmt.Printf("⚠️ Skipping refused sub-account %s\n", account.GetAddress().String())
+
+			continue
+		}
+
 		// Fetch the account balance
-		subAccount, err := d.cli.GetAccount(account.GetAddress().String())
+		subAccount, err := resolver.resolve(account.GetAddress().String())
 		if err != nil {
 			return nil, fmt.Errorf("unable to fetch sub-account, %w", err)
 		}
 
-		// Check if it has enough funds for the run
-		if subAccount.Coins.AmountOf(common.Denomination) < singleRunCost.Amount {
+		// Check if it has enough funds for the run, in every denomination
+		if missing := calculateMissingFunds(subAccount.Coins, singleRunCost); !missing.Empty() {
 			// Mark the account as needing a top-up
 			shortAccounts = append(shortAccounts, shortAccount{
-				address: account.GetAddress(),
-				missingFunds: std.Coin{
-					Denom:  common.Denomination,
-					Amount: singleRunCost.Amount - subAccount.Coins.AmountOf(common.Denomination),
-				},
+				address:      account.GetAddress(),
+				missingFunds: missing,
 			})
 
 			continue
@@ -128,6 +344,41 @@ func (d *Distributor) fundAccounts(accounts []keys.Info, singleRunCost std.Coin)
 		readyAccounts = append(readyAccounts, subAccount)
 	}
 
+	if len(d.predictions) > 0 {
+		fmt.Printf(
+			"Reused %d sub-account states from the previous run results, fetched %d from the chain\n",
+			resolver.reused,
+			resolver.fetched,
+		)
+	}
+
+	// Figure out how many accounts can actually be funded
+	distributor, err := d.cli.GetAccount(accounts[0].GetAddress().String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch distributor account, %w", err)
+	}
+
+	distributorBalance := distributor.Coins
+
+	// Make sure the reserved funds are kept in the distributor
+	if !reservedCost.Empty() {
+		if shortfall := calculateMissingFunds(distributorBalance, reservedCost); !shortfall.Empty() {
+			for _, coin := range shortfall {
+				fmt.Printf(
+					"❌ Distributor cannot cover the reserved %d %s, balance is %d %s\n",
+					reservedCost.AmountOf(coin.Denom),
+					coin.Denom,
+					distributorBalance.AmountOf(coin.Denom),
+					coin.Denom,
+				)
+			}
+
+			return nil, errInsufficientFunds
+		}
+
+		distributorBalance = distributorBalance.Sub(reservedCost)
+	}
+
 	// Check if funding is even necessary
 	if len(shortAccounts) == 0 {
 		// All accounts are already funded
@@ -136,67 +387,205 @@ func (d *Distributor) fundAccounts(accounts []keys.Info, singleRunCost std.Coin)
 		return readyAccounts, nil
 	}
 
+	// Make sure the short accounts are funded up front, if the distributor can't fund them
+	if d.fundingRefused {
+		return nil, fmt.Errorf("%w, %d sub-accounts need funding", errFundingRefused, len(shortAccounts))
+	}
+
+	// Report the sub-account shortfalls, per denomination
+	shortfalls := std.NewCoins()
+	for _, account := range shortAccounts {
+		shortfalls = shortfalls.Add(account.missingFunds)
+	}
+
+	for _, coin := range shortfalls {
+		fmt.Printf("Sub-accounts are short %d %s in total\n", coin.Amount, coin.Denom)
+	}
+
 	// Sort the short accounts so the ones with
 	// the lowest missing funds are funded first
 	sort.Slice(shortAccounts, func(i, j int) bool {
-		return shortAccounts[i].missingFunds.IsLT(shortAccounts[j].missingFunds)
+		return shortAccounts[i].missingFunds.AmountOf(common.Denomination) <
+			shortAccounts[j].missingFunds.AmountOf(common.Denomination)
 	})
 
-	// Figure out how many accounts can actually be funded
-	distributor, err := d.cli.GetAccount(accounts[0].GetAddress().String())
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch distributor account, %w", err)
-	}
-
-	distributorBalance := distributor.Coins
-	fundableIndex := 0
+	var (
+		fundableIndex = 0
+		balance       = distributorBalance
+	)
 
 	for _, account := range shortAccounts {
-		// The transfer cost is the single run cost (missing balance) + 1ugnot fee (fixed)
-		transferCost := std.NewCoins(common.DefaultGasFee.Add(account.missingFunds))
+		// The transfer cost is the single run cost (missing balance),
+		// with the funding fee of the transfer
+		transferCost := account.missingFunds.Add(std.NewCoins(d.fundingFee))
 
-		if distributorBalance.IsAllLT(transferCost) {
+		if !balance.IsAllGTE(transferCost) {
 			// Distributor does not have any more funds
-			// to cover the run cost
+			// to cover the run cost, even in a partial batch
 			break
 		}
 
 		fundableIndex++
 
-		distributorBalance.Sub(transferCost)
+		balance = balance.Sub(transferCost)
 	}
 
 	if fundableIndex == 0 {
 		// The distributor does not have funds to fund
-		// any account for the stress test
+		// any account for the stress test, in at least one denomination
+		transferCost := shortAccounts[0].missingFunds.Add(std.NewCoins(d.fundingFee))
+
+		for _, coin := range calculateMissingFunds(distributorBalance, transferCost) {
+			fmt.Printf(
+				"❌ Distributor cannot fund any account, short %d %s (balance is %d %s)\n",
+				coin.Amount,
+				coin.Denom,
+				distributorBalance.AmountOf(coin.Denom),
+				coin.Denom,
+			)
+		}
+
+		return nil, errInsufficientFunds
+	}
+
+	if fundableIndex < len(shortAccounts) {
 		fmt.Printf(
-			"❌ Distributor cannot fund any account, balance is %d %s\n",
-			distributorBalance.AmountOf(common.Denomination),
-			common.Denomination,
+			"⚠️ Distributor can only fund %d of %d short accounts\n",
+			fundableIndex,
+			len(shortAccounts),
 		)
+	}
 
-		return nil, errInsufficientFunds
+	shortAccounts = shortAccounts[:fundableIndex]
+
+	var fundedAccounts []*gnoland.GnoAccount
+
+	if d.pipelined {
+		fundedAccounts, err = d.fundPipelined(distributor, shortAccounts, singleRunCost)
+	} else {
+		fundedAccounts, err = d.fundSequentially(distributor, distributor.Sequence, shortAccounts)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
-	// Locally keep track of the nonce, so
-	// there is no need to re-fetch the account again
-	// before signing a future tx
-	nonce := distributor.Sequence
+	runAccounts := append(readyAccounts, fundedAccounts...)
 
-	fmt.Printf("Funding %d accounts...\n", len(shortAccounts))
+	// The spend cap can leave no account to run with
+	if len(runAccounts) == 0 {
+		return nil, fmt.Errorf("unable to fund any account, %w", ErrSpendCap)
+	}
+
+	return runAccounts, nil
+}
+
+// fundingBatches splits the short accounts into funding transaction batches
+func fundingBatches(shortAccounts []shortAccount, size int) [][]shortAccount {
+	batches := make([][]shortAccount, 0, fundingTxCount(len(shortAccounts), size))
+
+	for start := 0; start < len(shortAccounts); start += size {
+		end := start + size
+		if end > len(shortAccounts) {
+			end = len(shortAccounts)
+		}
+
+		batches = append(batches, shortAccounts[start:end])
+	}
+
+	return batches
+}
+
+// batchAddresses returns the comma separated addresses of the batch accounts
+func batchAddresses(batch []shortAccount) string {
+	addresses := make([]string, 0, len(batch))
+
+	for _, account := range batch {
+		addresses = append(addresses, account.address.String())
+	}
+
+	return strings.Join(addresses, ", ")
+}
+
+// fundingBatchSize returns the maximum number of transfers in a single funding transaction,
+// bounded so the funding transaction gas wanted fits a block
+func (d *Distributor) fundingBatchSize() int {
+	return boundedBatchSize(d.batchSize, d.fundingGas)
+}
+
+// boundedBatchSize bounds the funding batch size, so the gas wanted
+// of the batch transfers never exceeds the block max gas
+func boundedBatchSize(size int, gasPerTransfer int64) int {
+	bound := 1
+
+	if gasPerTransfer > 0 && maxFundingGas/gasPerTransfer > 1 {
+		bound = int(maxFundingGas / gasPerTransfer)
+	}
+
+	if size > bound {
+		return bound
+	}
+
+	return size
+}
+
+// fundingTxFee returns the fee of a funding transaction with the given number of transfers.
+// Both the gas wanted and the gas fee scale with the number of transfers
+func (d *Distributor) fundingTxFee(transfers int) std.Fee {
+	return std.NewFee(
+		d.fundingGas*int64(transfers),
+		std.NewCoin(d.fundingFee.Denom, d.fundingFee.Amount*int64(transfers)),
+	)
+}
+
+// newFundingTx generates an unsigned funding transaction for the batch of short
+// accounts, with a transfer for each account. The fee scales with the number of transfers
+func (d *Distributor) newFundingTx(distributor *gnoland.GnoAccount, batch []shortAccount) *std.Tx {
+	msgs := make([]std.Msg, 0, len(batch))
+
+	for _, account := range batch {
+		msgs = append(msgs, bank.MsgSend{
+			FromAddress: distributor.GetAddress(),
+			ToAddress:   account.address,
+			Amount:      account.missingFunds,
+		})
+	}
+
+	return &std.Tx{
+		Msgs: msgs,
+		Fee:  d.fundingTxFee(len(batch)),
+	}
+}
+
+// fundSequentially funds the short accounts batch by batch, waiting for each
+// funding transaction to be committed before sending the next one
+func (d *Distributor) fundSequentially(
+	distributor *gnoland.GnoAccount,
+	nonce uint64,
+	shortAccounts []shortAccount,
+) ([]*gnoland.GnoAccount, error) {
+	var (
+		fundedAccounts = make([]*gnoland.GnoAccount, 0, len(shortAccounts))
+		batches        = fundingBatches(shortAccounts, d.fundingBatchSize())
+	)
+
+	fmt.Printf("Funding %d accounts in %d transactions...\n", len(shortAccounts), len(batches))
 	bar := progressbar.Default(int64(len(shortAccounts)), "funding short accounts")
 
-	for _, account := range shortAccounts {
+	for _, batch := range batches {
 		// Generate the transaction
-		tx := &std.Tx{
-			Msgs: []std.Msg{
-				bank.MsgSend{
-					FromAddress: distributor.GetAddress(),
-					ToAddress:   account.address,
-					Amount:      std.NewCoins(account.missingFunds),
-				},
-			},
-			Fee: std.NewFee(100000, common.DefaultGasFee),
+		tx := d.newFundingTx(distributor, batch)
+
+		// Stop funding once the spend cap is reached,
+		// the run continues with the funded accounts
+		if err := d.budget.SpendTx(SpendDistribution, tx); err != nil {
+			fmt.Printf(
+				"\n⚠️ %v, %d accounts left unfunded\n",
+				err,
+				len(shortAccounts)-len(fundedAccounts),
+			)
+
+			break
 		}
 
 		// Sign the transaction
@@ -207,26 +596,34 @@ func (d *Distributor) fundAccounts(accounts []keys.Info, singleRunCost std.Coin)
 		// Update the local nonce
 		nonce++
 
-		// Broadcast the tx and wait for it to be committed
+		// Broadcast the tx and wait for it to be committed.
+		// Only the accounts of the earlier batches are funded
 		if err := d.cli.BroadcastTransaction(tx); err != nil {
-			return nil, fmt.Errorf("unable to broadcast tx with commit, %w", err)
+			return nil, fmt.Errorf(
+				"unable to broadcast tx with commit for the funding batch of %s (%d accounts funded before it), %w",
+				batchAddresses(batch),
+				len(fundedAccounts),
+				err,
+			)
 		}
 
-		// Since accounts can be uninitialized on the node, after the
-		// transfer they will have acquired a storage slot, and need
-		// to be re-fetched for their data (Sequence + Account Number)
-		nodeAccount, err := d.cli.GetAccount(account.address.String())
-		if err != nil {
-			return nil, fmt.Errorf("unable to fetch account, %w", err)
-		}
+		for _, account := range batch {
+			// Since accounts can be uninitialized on the node, after the
+			// transfer they will have acquired a storage slot, and need
+			// to be re-fetched for their data (Sequence + Account Number)
+			nodeAccount, err := d.cli.GetAccount(account.address.String())
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch account, %w", err)
+			}
 
-		// Mark the account as funded
-		readyAccounts = append(readyAccounts, nodeAccount)
+			// Mark the account as funded
+			fundedAccounts = append(fundedAccounts, nodeAccount)
 
-		_ = bar.Add(1)
+			_ = bar.Add(1)
+		}
 	}
 
-	fmt.Printf("✅ Successfully funded %d accounts\n", len(shortAccounts))
+	fmt.Printf("✅ Successfully funded %d accounts\n", len(fundedAccounts))
 
-	return readyAccounts, nil
+	return fundedAccounts, nil
 }
@@ -1,10 +1,13 @@
 package distributor
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
 	"github.com/gnolang/gno/pkgs/crypto/bip39"
 	"github.com/gnolang/gno/pkgs/crypto/keys"
 	"github.com/gnolang/gno/pkgs/sdk/bank"
@@ -64,7 +67,7 @@ func TestDistributor_Distribute(t *testing.T) {
 
 	var (
 		numTx      = uint64(1000)
-		singleCost = calculateRuntimeCosts(int64(numTx))
+		singleCost = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))[0]
 	)
 
 	getAccount := func(address string, accounts []keys.Info) keys.Info {
@@ -165,6 +168,57 @@ func TestDistributor_Distribute(t *testing.T) {
 		assert.ErrorIs(t, err, errInsufficientFunds)
 	})
 
+	t.Run("funding refused", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts = generateAccounts(t, 10)
+			short    = accounts[3].GetAddress().String()
+
+			mockClient = &mockClient{
+				getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+					acc := getAccount(address, accounts)
+					if acc == nil {
+						t.Fatal("invalid account requested")
+					}
+
+					balance := std.NewCoins(singleCost)
+					if address == short {
+						balance = std.NewCoins()
+					}
+
+					return &gnoland.GnoAccount{
+						BaseAccount: *std.NewBaseAccount(
+							acc.GetAddress(),
+							balance,
+							nil,
+							0,
+							0,
+						),
+					}, nil
+				},
+				broadcastTransactionFn: func(_ *std.Tx) error {
+					t.Fatal("funding transaction broadcast")
+
+					return nil
+				},
+			}
+		)
+
+		d := NewDistributor(
+			mockClient,
+			&mockSigner{},
+			WithoutFunding(),
+		)
+
+		// Make sure the short account fails the distribution, without any funding
+		readyAccounts, err := d.Distribute(accounts, numTx)
+
+		assert.Nil(t, readyAccounts)
+		assert.ErrorIs(t, err, errFundingRefused)
+		assert.ErrorContains(t, err, "1 sub-accounts need funding")
+	})
+
 	t.Run("fund all short accounts", func(t *testing.T) {
 		t.Parallel()
 
@@ -276,3 +330,518 @@ func TestDistributor_Distribute(t *testing.T) {
 		}
 	})
 }
+
+func TestDistributor_PrimingReservation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx      = uint64(100)
+		singleCost = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))[0]
+	)
+
+	// newMockClient creates a client where all
+	// accounts (including the distributor) have the single run cost
+	newMockClient := func(accounts []keys.Info) *mockClient {
+		return &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				for _, account := range accounts {
+					if address != account.GetAddress().String() {
+						continue
+					}
+
+					return &gnoland.GnoAccount{
+						BaseAccount: *std.NewBaseAccount(
+							account.GetAddress(),
+							std.NewCoins(singleCost),
+							nil,
+							0,
+							0,
+						),
+					}, nil
+				}
+
+				t.Fatal("invalid account requested")
+
+				return nil, nil
+			},
+		}
+	}
+
+	t.Run("priming cost reserved", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			primingTxs = numTx / 2
+			accounts   = generateAccounts(t, 5)
+		)
+
+		d := NewDistributor(
+			newMockClient(accounts),
+			&mockSigner{},
+			WithPrimingTransactions(primingTxs),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.Len(t, readyAccounts, len(accounts)-1)
+
+		// Make sure the priming cost is reported
+		costs := d.CostReport()
+
+		assert.Equal(t, primingTxs, costs.PrimingTxs)
+		assert.Equal(t, singleCost.Amount/2, costs.PrimingCost)
+	})
+
+	t.Run("priming cost not covered", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := generateAccounts(t, 5)
+
+		d := NewDistributor(
+			newMockClient(accounts),
+			&mockSigner{},
+			WithPrimingTransactions(numTx*2),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+
+		assert.Nil(t, readyAccounts)
+		assert.ErrorIs(t, err, errInsufficientFunds)
+	})
+}
+
+func TestDistributor_RefusedAccounts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx    = uint64(100)
+		accounts = generateAccounts(t, 10)
+		refused  = []crypto.Address{
+			accounts[1].GetAddress(),
+			accounts[2].GetAddress(),
+		}
+
+		capturedBroadcasts = make([]*std.Tx, 0)
+
+		mockClient = &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				for _, refusedAddress := range refused {
+					if address == refusedAddress.String() {
+						t.Fatal("refused account requested")
+					}
+				}
+
+				balance := std.NewCoin(common.Denomination, 0)
+
+				// The distributor has plenty of funds
+				if address == accounts[0].GetAddress().String() {
+					balance.Amount = int64(numTx) * common.InitialTxCost.Amount * int64(len(accounts))
+				}
+
+				addr, _ := crypto.AddressFromBech32(address)
+
+				return &gnoland.GnoAccount{
+					BaseAccount: *std.NewBaseAccount(
+						addr,
+						std.NewCoins(balance),
+						nil,
+						0,
+						0,
+					),
+				}, nil
+			},
+			broadcastTransactionFn: func(tx *std.Tx) error {
+				capturedBroadcasts = append(capturedBroadcasts, tx)
+
+				return nil
+			},
+		}
+	)
+
+	d := NewDistributor(
+		mockClient,
+		&mockSigner{},
+		WithRefusedAccounts(refused),
+	)
+
+	readyAccounts, err := d.Distribute(accounts, numTx)
+	if err != nil {
+		t.Fatalf("unable to distribute funds, %v", err)
+	}
+
+	// Make sure the refused accounts are not funded
+	assert.Len(t, readyAccounts, len(accounts)-1-len(refused))
+	assert.Len(t, capturedBroadcasts, len(accounts)-1-len(refused))
+
+	for _, tx := range capturedBroadcasts {
+		msg, ok := tx.Msgs[0].(bank.MsgSend)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		assert.NotContains(t, refused, msg.ToAddress)
+	}
+}
+
+func TestDistributor_CalculateRuntimeCosts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx   = int64(100)
+		deposit = std.NewCoin(common.Denomination, 500)
+		txCost  = common.DefaultGasFee.Add(common.InitialTxCost)
+	)
+
+	t.Run("no storage deposit", func(t *testing.T) {
+		t.Parallel()
+
+		cost := calculateRuntimeCosts(numTx, std.NewCoin(common.Denomination, 0))
+
+		assert.Equal(t, std.NewCoins(std.NewCoin(common.Denomination, numTx*txCost.Amount)), cost)
+	})
+
+	t.Run("storage deposit", func(t *testing.T) {
+		t.Parallel()
+
+		cost := calculateRuntimeCosts(numTx, deposit)
+
+		assert.Equal(t, std.NewCoins(std.NewCoin(common.Denomination, numTx*(txCost.Amount+deposit.Amount))), cost)
+	})
+
+	t.Run("storage deposit denomination", func(t *testing.T) {
+		t.Parallel()
+
+		cost := calculateRuntimeCosts(numTx, std.NewCoin("udeposit", 500))
+
+		assert.Equal(
+			t,
+			std.NewCoins(
+				std.NewCoin(common.Denomination, numTx*txCost.Amount),
+				std.NewCoin("udeposit", numTx*500),
+			),
+			cost,
+		)
+	})
+}
+
+func TestDistributor_MultiDenomination(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx        = uint64(100)
+		depositDenom = "udeposit"
+		deposit      = std.NewCoin(depositDenom, 10)
+
+		runCost  = calculateRuntimeCosts(int64(numTx), deposit)
+		gasCost  = std.NewCoin(common.Denomination, runCost.AmountOf(common.Denomination))
+		depoCost = std.NewCoin(depositDenom, runCost.AmountOf(depositDenom))
+	)
+
+	// newAccount creates a node account with the given balance
+	newAccount := func(address crypto.Address, balance std.Coins) *gnoland.GnoAccount {
+		return &gnoland.GnoAccount{
+			BaseAccount: *std.NewBaseAccount(address, balance, nil, 0, 0),
+		}
+	}
+
+	t.Run("all denominations required", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts = generateAccounts(t, 3)
+			balances = map[string]std.Coins{
+				// The distributor holds both denominations
+				accounts[0].GetAddress().String(): std.NewCoins(
+					std.NewCoin(common.Denomination, 10*gasCost.Amount),
+					std.NewCoin(depositDenom, 10*depoCost.Amount),
+				),
+				// The ready account holds both denominations
+				accounts[1].GetAddress().String(): std.NewCoins(gasCost, depoCost),
+				// The short account only holds the gas denomination
+				accounts[2].GetAddress().String(): std.NewCoins(gasCost),
+			}
+
+			transfers = make([]std.Coins, 0, 1)
+		)
+
+		mockClient := &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				addr, err := crypto.AddressFromBech32(address)
+				if err != nil {
+					t.Fatalf("invalid account requested, %v", err)
+				}
+
+				return newAccount(addr, balances[address]), nil
+			},
+			broadcastTransactionFn: func(tx *std.Tx) error {
+				for _, msg := range tx.Msgs {
+					send, ok := msg.(bank.MsgSend)
+					if !ok {
+						t.Fatal("invalid message type")
+					}
+
+					assert.Equal(t, accounts[2].GetAddress(), send.ToAddress)
+
+					transfers = append(transfers, send.Amount)
+					balances[send.ToAddress.String()] = balances[send.ToAddress.String()].Add(send.Amount)
+				}
+
+				return nil
+			},
+		}
+
+		d := NewDistributor(
+			mockClient,
+			&mockSigner{},
+			WithStorageDeposit(deposit),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.Len(t, readyAccounts, 2)
+
+		// Make sure only the missing denomination was sent
+		assert.Equal(t, []std.Coins{std.NewCoins(depoCost)}, transfers)
+
+		// Make sure the deposit denomination is part of the funding report
+		costs := d.CostReport()
+		if assert.NotNil(t, costs) {
+			assert.Equal(t, depositDenom, costs.DepositDenom)
+			assert.Equal(t, depoCost.Amount, costs.AccountDeposit)
+			assert.Equal(t, gasCost.Amount, costs.AccountCost)
+		}
+	})
+
+	t.Run("distributor short in a single denomination", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := generateAccounts(t, 3)
+
+		mockClient := &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				addr, err := crypto.AddressFromBech32(address)
+				if err != nil {
+					t.Fatalf("invalid account requested, %v", err)
+				}
+
+				// The distributor covers the gas, but not the deposits
+				if address == accounts[0].GetAddress().String() {
+					return newAccount(addr, std.NewCoins(std.NewCoin(common.Denomination, 10*gasCost.Amount))), nil
+				}
+
+				return newAccount(addr, std.NewCoins()), nil
+			},
+			broadcastTransactionFn: func(_ *std.Tx) error {
+				t.Fatal("no accounts should be funded")
+
+				return nil
+			},
+		}
+
+		d := NewDistributor(
+			mockClient,
+			&mockSigner{},
+			WithStorageDeposit(deposit),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+
+		assert.Nil(t, readyAccounts)
+		assert.ErrorIs(t, err, errInsufficientFunds)
+	})
+}
+
+func TestDistributor_BatchedFunding(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx      = uint64(100)
+		singleCost = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))[0]
+		accounts   = generateAccounts(t, 11)
+		fee        = common.DefaultGasFee.Amount
+	)
+
+	getShortAddresses := func(count int) []string {
+		addresses := make([]string, 0, count)
+
+		for _, account := range accounts[1 : count+1] {
+			addresses = append(addresses, account.GetAddress().String())
+		}
+
+		return addresses
+	}
+
+	t.Run("short accounts funded in batches", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain      = newMockChain(accounts[0], 10*singleCost.Amount+10*fee)
+			cli        = chain.client()
+			broadcast  = cli.broadcastTransactionFn
+			capturedTx = make([]*std.Tx, 0)
+		)
+
+		cli.broadcastTransactionFn = func(tx *std.Tx) error {
+			capturedTx = append(capturedTx, tx)
+
+			return broadcast(tx)
+		}
+
+		d := NewDistributor(cli, &mockSigner{}, WithFundingBatchSize(4))
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, getShortAddresses(10), getAddresses(readyAccounts))
+
+		// Make sure the 10 short accounts were funded with 3 txs (4 + 4 + 2)
+		if len(capturedTx) != 3 {
+			t.Fatalf("invalid number of funding txs, %d", len(capturedTx))
+		}
+
+		for index, expectedMsgs := range []int{4, 4, 2} {
+			tx := capturedTx[index]
+
+			assert.Len(t, tx.Msgs, expectedMsgs)
+			assert.Equal(t, int64(DefaultFundingGas*expectedMsgs), tx.Fee.GasWanted)
+			assert.Equal(t, std.NewCoin(common.Denomination, fee*int64(expectedMsgs)), tx.Fee.GasFee)
+		}
+	})
+
+	t.Run("batches fit the block max gas", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain      = newMockChain(accounts[0], 10*singleCost.Amount+10*fee)
+			cli        = chain.client()
+			broadcast  = cli.broadcastTransactionFn
+			capturedTx = make([]*std.Tx, 0)
+		)
+
+		cli.broadcastTransactionFn = func(tx *std.Tx) error {
+			capturedTx = append(capturedTx, tx)
+
+			return broadcast(tx)
+		}
+
+		// Only 3 transfers fit the block max gas
+		d := NewDistributor(
+			cli,
+			&mockSigner{},
+			WithFundingBatchSize(4),
+			WithFundingFee(maxFundingGas/3, common.DefaultGasFee),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, getShortAddresses(10), getAddresses(readyAccounts))
+
+		// Make sure the 10 short accounts were funded with 4 txs (3 + 3 + 3 + 1)
+		if len(capturedTx) != 4 {
+			t.Fatalf("invalid number of funding txs, %d", len(capturedTx))
+		}
+
+		for _, tx := range capturedTx {
+			assert.LessOrEqual(t, tx.Fee.GasWanted, int64(maxFundingGas))
+		}
+	})
+
+	t.Run("balance covers a partial batch", func(t *testing.T) {
+		t.Parallel()
+
+		// The balance covers the first batch, and 2 accounts of the second batch
+		chain := newMockChain(accounts[0], 6*singleCost.Amount+6*fee)
+
+		d := NewDistributor(chain.client(), &mockSigner{}, WithFundingBatchSize(4))
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, getShortAddresses(6), getAddresses(readyAccounts))
+		assert.Equal(t, 2, chain.commitBroadcasts)
+	})
+
+	t.Run("failing batch names its accounts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain     = newMockChain(accounts[0], 10*singleCost.Amount+10*fee)
+			cli       = chain.client()
+			broadcast = cli.broadcastTransactionFn
+			calls     = 0
+
+			errBroadcast = errors.New("broadcast failed")
+		)
+
+		cli.broadcastTransactionFn = func(tx *std.Tx) error {
+			calls++
+
+			if calls == 2 {
+				return errBroadcast
+			}
+
+			return broadcast(tx)
+		}
+
+		d := NewDistributor(cli, &mockSigner{}, WithFundingBatchSize(4))
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+
+		assert.Nil(t, readyAccounts)
+		assert.ErrorIs(t, err, errBroadcast)
+
+		// Make sure only the failing batch accounts are named
+		for index, address := range getShortAddresses(10) {
+			if index >= 4 && index < 8 {
+				assert.Contains(t, err.Error(), address)
+
+				continue
+			}
+
+			assert.NotContains(t, err.Error(), address)
+		}
+	})
+
+	t.Run("pipelined batch failure re-funds its accounts", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockChain(accounts[0], 10*singleCost.Amount+12*fee)
+		chain.failIndex = 1 // fails, and invalidates all later batches
+
+		d := NewDistributor(
+			chain.client(),
+			&mockSigner{},
+			WithPipelinedFunding(),
+			WithFundingBatchSize(2),
+		)
+		d.commitTimeout = time.Second * 2
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, getShortAddresses(10), getAddresses(readyAccounts))
+
+		// Make sure only the failed batch was re-funded, with a single tx
+		assert.Equal(t, 5, chain.syncBroadcasts)
+		assert.Equal(t, 1, chain.commitBroadcasts)
+
+		for _, address := range getShortAddresses(10) {
+			assert.Equal(t, singleCost.Amount, chain.balances[address])
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package distributor
+
+import (
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+// CostParams are the inputs of the run cost model.
+// Unset fees fall back to the fixed chain fees
+type CostParams struct {
+	Transactions uint64 // the number of run transactions, each sub-account covers all of them
+	SubAccounts  uint64 // the number of funded sub-accounts
+	FundingBatch int    // the maximum number of transfers in a single funding tx
+	FundingGas   int64  // the gas wanted of each funding transfer, bounding the funding batch
+
+	StorageDeposit   std.Coin // the storage deposit paid by each run transaction
+	PrimingTxs       uint64   // the number of priming transactions paid by the distributor
+	PredeployTxs     uint64   // the number of predeploy transactions paid by the distributor
+	PredeployDeposit std.Coin // the storage deposit paid by each predeploy transaction
+
+	GasFee       std.Coin // the fee of each run transaction
+	PrimingFee   std.Coin // the fee of each priming transaction, the run fee if unset
+	PredeployFee std.Coin // the fee of each predeploy transaction, the run fee if unset
+	FundingFee   std.Coin // the fee of each funding transfer, the run fee if unset
+	VMCost       std.Coin // the fixed cost the node charges for each run transaction
+}
+
+// CostEstimate is the distributor balance required by a run, with its breakdown.
+// The sub-accounts are assumed to be unfunded, so the estimate is the worst case
+type CostEstimate struct {
+	TxCost        std.Coin  // the gas cost of a single run transaction
+	AccountCost   std.Coins // the funds required by a single sub-account
+	AccountsCost  std.Coins // the funds required by all sub-accounts
+	FundingTxs    int       // the number of funding transactions
+	FundingFees   std.Coins // the fees of the funding transactions
+	PrimingCost   std.Coins // the funds reserved for the priming transactions
+	PredeployCost std.Coins // the funds spent on the predeploy transactions
+	Total         std.Coins // the total distributor balance required
+}
+
+// withDefaults returns the parameters, with the unset fees
+// set to the fixed chain fees
+func (p CostParams) withDefaults() CostParams {
+	if p.GasFee.Denom == "" {
+		p.GasFee = common.DefaultGasFee
+	}
+
+	if p.PrimingFee.Denom == "" {
+		p.PrimingFee = p.GasFee
+	}
+
+	if p.PredeployFee.Denom == "" {
+		p.PredeployFee = p.GasFee
+	}
+
+	if p.FundingFee.Denom == "" {
+		p.FundingFee = p.GasFee
+	}
+
+	if p.VMCost.Denom == "" {
+		p.VMCost = common.InitialTxCost
+	}
+
+	if p.StorageDeposit.Denom == "" {
+		p.StorageDeposit = std.NewCoin(common.Denomination, 0)
+	}
+
+	if p.PredeployDeposit.Denom == "" {
+		p.PredeployDeposit = std.NewCoin(common.Denomination, 0)
+	}
+
+	if p.FundingBatch < 1 {
+		p.FundingBatch = 1
+	}
+
+	if p.FundingGas < 1 {
+		p.FundingGas = DefaultFundingGas
+	}
+
+	p.FundingBatch = boundedBatchSize(p.FundingBatch, p.FundingGas)
+
+	return p
+}
+
+// EstimateCosts runs the cost model for the given parameters.
+// It is the same model the live distribution is funded by
+func EstimateCosts(params CostParams) *CostEstimate {
+	params = params.withDefaults()
+
+	var (
+		txCost          = params.GasFee.Add(params.VMCost)
+		primingTxCost   = params.PrimingFee.Add(params.VMCost)
+		predeployTxCost = params.PredeployFee.Add(params.VMCost)
+
+		accountCost = runCost(int64(params.Transactions), txCost, params.StorageDeposit)
+		fundingTxs  = fundingTxCount(int(params.SubAccounts), params.FundingBatch)
+	)
+
+	estimate := &CostEstimate{
+		TxCost:        txCost,
+		AccountCost:   accountCost,
+		AccountsCost:  multiplyCoins(accountCost, int64(params.SubAccounts)),
+		FundingTxs:    fundingTxs,
+		FundingFees:   multiplyCoins(std.NewCoins(params.FundingFee), int64(params.SubAccounts)),
+		PrimingCost:   runCost(int64(params.PrimingTxs), primingTxCost, std.NewCoin(common.Denomination, 0)),
+		PredeployCost: runCost(int64(params.PredeployTxs), predeployTxCost, params.PredeployDeposit),
+	}
+
+	estimate.Total = estimate.AccountsCost.
+		Add(estimate.FundingFees).
+		Add(estimate.PrimingCost).
+		Add(estimate.PredeployCost)
+
+	return estimate
+}
+
+// runCost returns the funds required to execute the given number of
+// transactions, each paying the transaction cost and the storage deposit
+func runCost(totalTx int64, txCost, storageDeposit std.Coin) std.Coins {
+	cost := std.NewCoins(std.NewCoin(txCost.Denom, totalTx*txCost.Amount))
+
+	if storageDeposit.IsPositive() {
+		cost = cost.Add(
+			std.NewCoins(std.NewCoin(storageDeposit.Denom, totalTx*storageDeposit.Amount)),
+		)
+	}
+
+	return cost
+}
+
+// multiplyCoins multiplies each of the coins by the given factor
+func multiplyCoins(coins std.Coins, factor int64) std.Coins {
+	multiplied := make([]std.Coin, 0, len(coins))
+
+	for _, coin := range coins {
+		multiplied = append(multiplied, std.NewCoin(coin.Denom, coin.Amount*factor))
+	}
+
+	return std.NewCoins(multiplied...)
+}
+
+// fundingTxCount returns the number of funding transactions
+// needed to fund the given number of accounts, in batches
+func fundingTxCount(accounts, batchSize int) int {
+	return (accounts + batchSize - 1) / batchSize
+}
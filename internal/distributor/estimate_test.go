@@ -0,0 +1,143 @@
+package distributor
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateCosts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("breakdown", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			deposit  = std.NewCoin("udeposit", 3)
+			estimate = EstimateCosts(CostParams{
+				Transactions:     100,
+				SubAccounts:      10,
+				FundingBatch:     4,
+				StorageDeposit:   deposit,
+				PrimingTxs:       2,
+				PredeployTxs:     1,
+				PredeployDeposit: std.NewCoin(common.Denomination, 7),
+			})
+
+			txCost = common.DefaultGasFee.Add(common.InitialTxCost).Amount
+		)
+
+		assert.Equal(t, txCost, estimate.TxCost.Amount)
+		assert.Equal(t, 100*txCost, estimate.AccountCost.AmountOf(common.Denomination))
+		assert.Equal(t, int64(300), estimate.AccountCost.AmountOf(deposit.Denom))
+		assert.Equal(t, 1000*txCost, estimate.AccountsCost.AmountOf(common.Denomination))
+
+		// 10 transfers in batches of 4, each transfer paying its fee
+		assert.Equal(t, 3, estimate.FundingTxs)
+		assert.Equal(t, 10*common.DefaultGasFee.Amount, estimate.FundingFees.AmountOf(common.Denomination))
+
+		assert.Equal(t, 2*txCost, estimate.PrimingCost.AmountOf(common.Denomination))
+		assert.Equal(t, txCost+7, estimate.PredeployCost.AmountOf(common.Denomination))
+
+		assert.Equal(
+			t,
+			1000*txCost+10*common.DefaultGasFee.Amount+2*txCost+txCost+7,
+			estimate.Total.AmountOf(common.Denomination),
+		)
+		assert.Equal(t, int64(3000), estimate.Total.AmountOf(deposit.Denom))
+	})
+
+	t.Run("fee overrides", func(t *testing.T) {
+		t.Parallel()
+
+		estimate := EstimateCosts(CostParams{
+			Transactions: 10,
+			SubAccounts:  2,
+			GasFee:       std.NewCoin(common.Denomination, 5),
+			VMCost:       std.NewCoin(common.Denomination, 0),
+		})
+
+		assert.Equal(t, int64(5), estimate.TxCost.Amount)
+		assert.Equal(t, 2, estimate.FundingTxs)
+		assert.Equal(t, int64(2*10*5+2*5), estimate.Total.AmountOf(common.Denomination))
+	})
+
+	t.Run("per transaction fees", func(t *testing.T) {
+		t.Parallel()
+
+		estimate := EstimateCosts(CostParams{
+			Transactions: 10,
+			SubAccounts:  2,
+			PrimingTxs:   1,
+			PredeployTxs: 1,
+			GasFee:       std.NewCoin(common.Denomination, 5),
+			PrimingFee:   std.NewCoin(common.Denomination, 6),
+			PredeployFee: std.NewCoin(common.Denomination, 7),
+			FundingFee:   std.NewCoin(common.Denomination, 2),
+			VMCost:       std.NewCoin(common.Denomination, 0),
+		})
+
+		assert.Equal(t, int64(2*10*5), estimate.AccountsCost.AmountOf(common.Denomination))
+		assert.Equal(t, int64(2*2), estimate.FundingFees.AmountOf(common.Denomination))
+		assert.Equal(t, int64(6), estimate.PrimingCost.AmountOf(common.Denomination))
+		assert.Equal(t, int64(7), estimate.PredeployCost.AmountOf(common.Denomination))
+	})
+
+	t.Run("estimate matches the distribution", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			numTx    = uint64(100)
+			accounts = generateAccounts(t, 10)
+
+			estimate = EstimateCosts(CostParams{
+				Transactions: numTx,
+				SubAccounts:  uint64(len(accounts) - 1),
+				FundingBatch: 4,
+				PrimingTxs:   2,
+			})
+		)
+
+		distribute := func(t *testing.T, balance int64) []*gnoland.GnoAccount {
+			t.Helper()
+
+			mockClient := &mockClient{
+				getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+					coins := std.NewCoins()
+					if address == accounts[0].GetAddress().String() {
+						coins = std.NewCoins(std.NewCoin(common.Denomination, balance))
+					}
+
+					return &gnoland.GnoAccount{
+						BaseAccount: *std.NewBaseAccount(accounts[0].GetAddress(), coins, nil, 0, 0),
+					}, nil
+				},
+			}
+
+			d := NewDistributor(
+				mockClient,
+				&mockSigner{},
+				WithFundingBatchSize(4),
+				WithPrimingTransactions(2),
+			)
+
+			runAccounts, err := d.Distribute(accounts, numTx)
+			if err != nil {
+				t.Fatalf("unable to distribute funds, %v", err)
+			}
+
+			assert.Equal(t, estimate.Total.AmountOf(common.Denomination), d.CostReport().EstimatedTotal)
+
+			return runAccounts
+		}
+
+		// The estimated balance funds every sub-account
+		assert.Len(t, distribute(t, estimate.Total.AmountOf(common.Denomination)), len(accounts)-1)
+
+		// Anything less leaves a sub-account unfunded
+		assert.Len(t, distribute(t, estimate.Total.AmountOf(common.Denomination)-1), len(accounts)-2)
+	})
+}
@@ -0,0 +1,108 @@
+package distributor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+const chainStartInterval = 500 * time.Millisecond
+
+var (
+	errChainNotStarted  = errors.New("chain did not produce its first block")
+	errNotGenesisFunded = errors.New("sub-accounts are not funded in genesis")
+)
+
+// fetchGenesisAccounts fetches the sub-accounts funded in the chain genesis,
+// once the node produces its first block. No funding transactions are sent,
+// so sub-accounts that can't cover the run cost fail the distribution
+func (d *Distributor) fetchGenesisAccounts(
+	accounts []keys.Info,
+	singleRunCost std.Coins,
+	reservedCost std.Coins,
+) ([]*gnoland.GnoAccount, error) {
+	fmt.Printf("Assuming the sub-accounts are funded in genesis, skipping distribution\n")
+
+	if err := d.waitForChainStart(); err != nil {
+		return nil, err
+	}
+
+	// The reserved funds are still paid by the distributor
+	if !reservedCost.Empty() {
+		distributor, err := d.cli.GetAccount(accounts[0].GetAddress().String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch distributor account, %w", err)
+		}
+
+		if shortfall := calculateMissingFunds(distributor.Coins, reservedCost); !shortfall.Empty() {
+			fmt.Printf("❌ Distributor cannot cover the reserved %s, short %s\n", reservedCost, shortfall)
+
+			return nil, errInsufficientFunds
+		}
+	}
+
+	var (
+		readyAccounts = make([]*gnoland.GnoAccount, 0, len(accounts)-1)
+		short         = 0
+	)
+
+	for _, account := range accounts[1:] {
+		if _, refused := d.refused[account.GetAddress().String()]; refused {
+			fmt.Printf("⚠️ Skipping refused sub-account %s\n", account.GetAddress().String())
+
+			continue
+		}
+
+		// The account numbers (and sequences) are taken from
+		// the first on-chain query, since genesis assigns them
+		subAccount, err := d.cli.GetAccount(account.GetAddress().String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch sub-account, %w", err)
+		}
+
+		if missing := calculateMissingFunds(subAccount.Coins, singleRunCost); !missing.Empty() {
+			fmt.Printf("❌ Sub-account %s is short %s\n", account.GetAddress().String(), missing)
+
+			short++
+
+			continue
+		}
+
+		readyAccounts = append(readyAccounts, subAccount)
+	}
+
+	if short > 0 {
+		return nil, fmt.Errorf("%w: %d sub-accounts are short", errNotGenesisFunded, short)
+	}
+
+	fmt.Printf("✅ All %d genesis funded sub-accounts are ready\n", len(readyAccounts))
+
+	return readyAccounts, nil
+}
+
+// waitForChainStart waits for the node to produce its first block,
+// since the genesis accounts are only queryable afterwards
+func (d *Distributor) waitForChainStart() error {
+	deadline := time.Now().Add(d.commitTimeout)
+
+	for {
+		height, err := d.cli.GetLatestBlockHeight()
+		if err == nil && height > 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("%w: %v", errChainNotStarted, err)
+			}
+
+			return errChainNotStarted
+		}
+
+		time.Sleep(chainStartInterval)
+	}
+}
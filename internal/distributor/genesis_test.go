@@ -0,0 +1,95 @@
+package distributor
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributor_GenesisFunding(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx      = uint64(100)
+		singleCost = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))[0]
+	)
+
+	newMockClient := func(balance std.Coin, broadcasts *int) *mockClient {
+		return &mockClient{
+			getLatestBlockHeightFn: func() (int64, error) {
+				return 1, nil
+			},
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				addr, err := crypto.AddressFromBech32(address)
+				if err != nil {
+					t.Fatalf("invalid account requested, %v", err)
+				}
+
+				return &gnoland.GnoAccount{
+					BaseAccount: *std.NewBaseAccount(addr, std.NewCoins(balance), nil, 10, 0),
+				}, nil
+			},
+			broadcastTransactionFn: func(_ *std.Tx) error {
+				*broadcasts++
+
+				return nil
+			},
+		}
+	}
+
+	t.Run("genesis funded accounts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts   = generateAccounts(t, 5)
+			broadcasts = 0
+		)
+
+		d := NewDistributor(
+			newMockClient(singleCost, &broadcasts),
+			&mockSigner{},
+			WithGenesisFunding(),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		// Make sure the sub-accounts are fetched, without funding
+		assert.Len(t, readyAccounts, len(accounts)-1)
+		assert.Equal(t, 0, broadcasts)
+		assert.True(t, d.CostReport().GenesisFunded)
+
+		// Make sure the account numbers come from the chain
+		for index, account := range accounts[1:] {
+			assert.Equal(t, account.GetAddress().String(), readyAccounts[index].GetAddress().String())
+			assert.Equal(t, uint64(10), readyAccounts[index].GetAccountNumber())
+		}
+	})
+
+	t.Run("short genesis accounts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts   = generateAccounts(t, 5)
+			broadcasts = 0
+		)
+
+		d := NewDistributor(
+			newMockClient(std.NewCoin(common.Denomination, 0), &broadcasts),
+			&mockSigner{},
+			WithGenesisFunding(),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+
+		assert.Nil(t, readyAccounts)
+		assert.ErrorIs(t, err, errNotGenesisFunded)
+		assert.Equal(t, 0, broadcasts)
+	})
+}
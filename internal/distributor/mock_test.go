@@ -2,17 +2,26 @@ package distributor
 
 import (
 	"github.com/gnolang/gno/gnoland"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
 	"github.com/gnolang/gno/pkgs/std"
 )
 
 type (
-	broadcastTransactionDelegate func(*std.Tx) error
-	getAccountDelegate           func(string) (*gnoland.GnoAccount, error)
+	broadcastTransactionDelegate     func(*std.Tx) error
+	broadcastTransactionSyncDelegate func(*std.Tx) ([]byte, error)
+	getAccountDelegate               func(string) (*gnoland.GnoAccount, error)
+	getBlockDelegate                 func(*int64) (*core_types.ResultBlock, error)
+	getBlockResultsDelegate          func(*int64) (*core_types.ResultBlockResults, error)
+	getLatestBlockHeightDelegate     func() (int64, error)
 )
 
 type mockClient struct {
-	broadcastTransactionFn broadcastTransactionDelegate
-	getAccountFn           getAccountDelegate
+	broadcastTransactionFn     broadcastTransactionDelegate
+	broadcastTransactionSyncFn broadcastTransactionSyncDelegate
+	getAccountFn               getAccountDelegate
+	getBlockFn                 getBlockDelegate
+	getBlockResultsFn          getBlockResultsDelegate
+	getLatestBlockHeightFn     getLatestBlockHeightDelegate
 }
 
 func (m *mockClient) BroadcastTransaction(tx *std.Tx) error {
@@ -23,6 +32,14 @@ func (m *mockClient) BroadcastTransaction(tx *std.Tx) error {
 	return nil
 }
 
+func (m *mockClient) BroadcastTransactionSync(tx *std.Tx) ([]byte, error) {
+	if m.broadcastTransactionSyncFn != nil {
+		return m.broadcastTransactionSyncFn(tx)
+	}
+
+	return nil, nil
+}
+
 func (m *mockClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
 	if m.getAccountFn != nil {
 		return m.getAccountFn(address)
@@ -31,6 +48,30 @@ func (m *mockClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
 	return nil, nil
 }
 
+func (m *mockClient) GetBlock(height *int64) (*core_types.ResultBlock, error) {
+	if m.getBlockFn != nil {
+		return m.getBlockFn(height)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetBlockResults(height *int64) (*core_types.ResultBlockResults, error) {
+	if m.getBlockResultsFn != nil {
+		return m.getBlockResultsFn(height)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetLatestBlockHeight() (int64, error) {
+	if m.getLatestBlockHeightFn != nil {
+		return m.getLatestBlockHeightFn()
+	}
+
+	return 0, nil
+}
+
 type signTxDelegate func(*std.Tx, *gnoland.GnoAccount, uint64, string) error
 
 type mockSigner struct {
@@ -0,0 +1,128 @@
+package distributor
+
+import (
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+type Option func(d *Distributor)
+
+// WithPipelinedFunding enables pipelined funding, where the distributor
+// signs and broadcasts the next funding transaction without waiting for
+// the previous one to be committed. Commitment is verified afterwards
+func WithPipelinedFunding() Option {
+	return func(d *Distributor) {
+		d.pipelined = true
+	}
+}
+
+// WithPrimingTransactions reserves the distributor funds needed
+// to execute the given number of unmeasured priming transactions
+func WithPrimingTransactions(transactions uint64) Option {
+	return func(d *Distributor) {
+		d.primingTxs = transactions
+	}
+}
+
+// WithStorageDeposit sets the storage deposit that each run
+// transaction pays, which is included in the sub-account funding costs.
+// The storage deposit is only paid by package deployment transactions
+func WithStorageDeposit(deposit std.Coin) Option {
+	return func(d *Distributor) {
+		d.storageDeposit = deposit
+	}
+}
+
+// WithRefusedAccounts sets the sub-accounts that are never
+// funded by the distributor, even if they are short on funds
+func WithRefusedAccounts(addresses []crypto.Address) Option {
+	return func(d *Distributor) {
+		for _, address := range addresses {
+			d.refused[address.String()] = struct{}{}
+		}
+	}
+}
+
+// WithDistributorIndex sets the derivation index
+// of the distributor account, for the funding report
+func WithDistributorIndex(index uint32) Option {
+	return func(d *Distributor) {
+		d.index = index
+	}
+}
+
+// WithFundingPlan sets the pre-computed funding transfers, which are executed
+// instead of calculating and funding the sub-account shortfalls
+func WithFundingPlan(plan FundingPlan) Option {
+	return func(d *Distributor) {
+		d.plan = plan
+	}
+}
+
+// WithGenesisFunding skips the distribution, assuming the sub-accounts
+// are funded in the chain genesis. The sub-accounts are only fetched
+func WithGenesisFunding() Option {
+	return func(d *Distributor) {
+		d.genesisFunded = true
+	}
+}
+
+// WithoutFunding makes the distribution fail if any sub-account is short on funds,
+// instead of funding it, for runs that can't sign with the distributor (sharded runs)
+func WithoutFunding() Option {
+	return func(d *Distributor) {
+		d.fundingRefused = true
+	}
+}
+
+// WithBudget sets the invocation budget the funding spends are recorded in,
+// and capped by. The distributor spend is tracked without a cap by default
+func WithBudget(budget *Budget) Option {
+	return func(d *Distributor) {
+		d.budget = budget
+	}
+}
+
+// WithFundingBatchSize sets the maximum number of transfers packed into a single
+// sub-account funding transaction, so funding N accounts takes ceil(N/size) commits.
+// Each account is funded in its own transaction by default
+func WithFundingBatchSize(size int) Option {
+	return func(d *Distributor) {
+		if size > 0 {
+			d.batchSize = size
+		}
+	}
+}
+
+// WithPredictions seeds the sub-account states from the predictions of a previous run,
+// instead of fetching each sub-account. The predictions are only used if the given number
+// of randomly sampled predicted sub-accounts match their on-chain state
+func WithPredictions(predictions Predictions, samples int, seed int64) Option {
+	return func(d *Distributor) {
+		d.predictions = predictions
+		d.predictionSamples = samples
+		d.predictionSeed = seed
+	}
+}
+
+// WithGasFees sets the gas fees of the run and priming transactions,
+// which are included in the sub-account and priming costs.
+// Both are the fixed chain fee by default
+func WithGasFees(run, priming std.Coin) Option {
+	return func(d *Distributor) {
+		d.runFee = run
+		d.primingFee = priming
+	}
+}
+
+// WithFundingFee sets the gas wanted of each funding transfer, and the gas fee
+// of each funding transaction. The sweep transfers are paid the same way
+func WithFundingFee(gasPerTransfer int64, fee std.Coin) Option {
+	return func(d *Distributor) {
+		if gasPerTransfer > 0 {
+			d.fundingGas = gasPerTransfer
+		}
+
+		d.fundingFee = fee
+	}
+}
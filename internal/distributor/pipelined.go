@@ -0,0 +1,229 @@
+package distributor
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/schollz/progressbar/v3"
+)
+
+// pendingFunding is a funding transaction that passed
+// the mempool checks, but is not necessarily committed
+type pendingFunding struct {
+	batch  []shortAccount
+	tx     *std.Tx
+	txHash []byte
+}
+
+// fundPipelined funds the short accounts by signing and broadcasting each batch funding
+// transaction without waiting for the previous one to be committed. Once all funding
+// transactions are broadcast, their commitment is verified and reconciled
+func (d *Distributor) fundPipelined(
+	distributor *gnoland.GnoAccount,
+	shortAccounts []shortAccount,
+	singleRunCost std.Coins,
+) ([]*gnoland.GnoAccount, error) {
+	// Note the current latest block
+	startBlock, err := d.cli.GetLatestBlockHeight()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch latest block, %w", err)
+	}
+
+	var (
+		batches = fundingBatches(shortAccounts, d.fundingBatchSize())
+		pending = make([]pendingFunding, 0, len(batches))
+		nonce   = distributor.Sequence
+
+		broadcast = 0 // the number of accounts with a broadcast funding tx
+
+		// The spend of the broadcast funding txs, recorded once they are committed,
+		// since the rejected and the lost funding txs never spend anything
+		inFlight int64
+	)
+
+	fmt.Printf("Funding %d accounts in %d transactions (pipelined)...\n", len(shortAccounts), len(batches))
+	bar := progressbar.Default(int64(len(shortAccounts)), "broadcasting funding txs")
+
+	for _, batch := range batches {
+		// Generate the transaction
+		tx := d.newFundingTx(distributor, batch)
+
+		spend := TxSpend(tx)
+
+		// Stop broadcasting once the spend cap is reached
+		if err := d.budget.Check(SpendDistribution, inFlight+spend); err != nil {
+			fmt.Printf("\n⚠️ %v, %d accounts left unfunded\n", err, len(shortAccounts)-broadcast)
+
+			break
+		}
+
+		// Sign the transaction
+		if err := d.signer.SignTx(tx, distributor, nonce, common.EncryptPassword); err != nil {
+			return nil, fmt.Errorf("unable to sign transaction, %w", err)
+		}
+
+		// Broadcast the tx, without waiting for it to be committed
+		txHash, err := d.cli.BroadcastTransactionSync(tx)
+		if err != nil {
+			// Any later funding transaction depends on this one
+			// (distributor sequence), so there is no point in broadcasting them
+			fmt.Printf("\n⚠️ Funding tx for %s was rejected, %v\n", batchAddresses(batch), err)
+
+			break
+		}
+
+		// Update the local nonce
+		nonce++
+
+		inFlight += spend
+
+		pending = append(pending, pendingFunding{
+			batch:  batch,
+			tx:     tx,
+			txHash: txHash,
+		})
+
+		broadcast += len(batch)
+
+		_ = bar.Add(len(batch))
+	}
+
+	// Wait for the broadcast funding transactions to be committed
+	txHashes := make([][]byte, len(pending))
+	for index, funding := range pending {
+		txHashes[index] = funding.txHash
+	}
+
+	fmt.Printf("Waiting for %d funding txs to be committed...\n", len(pending))
+
+	results, err := collector.NewCommitWatcher(d.cli, d.commitTimeout).WaitForCommits(txHashes, startBlock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wait for funding txs, %w", err)
+	}
+
+	d.recordFundingSpend(pending, results)
+
+	// Reconcile the funding results
+	fundedAccounts, unfundedAccounts, err := d.reconcileFunding(shortAccounts, pending, results, singleRunCost)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unfundedAccounts) == 0 {
+		fmt.Printf("✅ Successfully funded %d accounts\n", len(fundedAccounts))
+
+		return fundedAccounts, nil
+	}
+
+	// Fund the remaining accounts sequentially, using
+	// the up-to-date distributor account state
+	fmt.Printf("⚠️ %d accounts need to be re-funded\n", len(unfundedAccounts))
+
+	distributor, err = d.cli.GetAccount(distributor.GetAddress().String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch distributor account, %w", err)
+	}
+
+	refundedAccounts, err := d.fundSequentially(distributor, distributor.Sequence, unfundedAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(fundedAccounts, refundedAccounts...), nil
+}
+
+// recordFundingSpend records the spend of the committed funding transactions.
+// Failed deliveries only pay the fee, and the funding transactions that were never
+// committed spend nothing, so the accounts re-funded in their place are not counted twice
+func (d *Distributor) recordFundingSpend(pending []pendingFunding, results map[string]error) {
+	for _, funding := range pending {
+		deliverErr, committed := results[string(funding.txHash)]
+		if !committed {
+			continue
+		}
+
+		spend := TxSpend(funding.tx)
+
+		if deliverErr != nil {
+			spend = TxSpend(&std.Tx{Fee: funding.tx.Fee})
+		}
+
+		d.budget.Record(SpendDistribution, spend)
+	}
+}
+
+// reconcileFunding splits the short accounts into funded and unfunded accounts, based
+// on the pipelined funding results. Funding transactions are chained through the distributor
+// sequence, so the failure of a single funding transaction invalidates all the funding transactions
+// that were broadcast after it. Invalidated accounts are verified against the chain state,
+// and are considered unfunded if their balance does not cover the run cost
+func (d *Distributor) reconcileFunding(
+	shortAccounts []shortAccount,
+	pending []pendingFunding,
+	results map[string]error,
+	singleRunCost std.Coins,
+) ([]*gnoland.GnoAccount, []shortAccount, error) {
+	var (
+		fundedAccounts   = make([]*gnoland.GnoAccount, 0, len(shortAccounts))
+		unfundedAccounts = make([]shortAccount, 0)
+
+		invalidated = false
+	)
+
+	for index, account := range shortAccounts {
+		// Accounts are re-fetched regardless of the funding outcome,
+		// since their on-chain data (Sequence + Account Number) is needed
+		nodeAccount, err := d.cli.GetAccount(account.address.String())
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch account, %w", err)
+		}
+
+		// The short accounts are funded in consecutive batches
+		batchIndex := index / d.fundingBatchSize()
+
+		if !invalidated && batchIndex < len(pending) {
+			funding := pending[batchIndex]
+
+			deliverErr, committed := results[string(funding.txHash)]
+			if committed && deliverErr == nil {
+				// The funding transaction was successful
+				fundedAccounts = append(fundedAccounts, nodeAccount)
+
+				continue
+			}
+
+			if !committed {
+				deliverErr = fmt.Errorf("funding tx %X was not committed", funding.txHash)
+			}
+
+			// All later funding transactions depend on this one
+			invalidated = true
+
+			fmt.Printf(
+				"⚠️ Funding tx for %s failed, invalidating %d later funding txs, %v\n",
+				batchAddresses(funding.batch),
+				len(pending)-batchIndex-1,
+				deliverErr,
+			)
+		}
+
+		// The account state needs to be verified against the chain,
+		// in every denomination of the run cost
+		missing := calculateMissingFunds(nodeAccount.Coins, singleRunCost)
+		if missing.Empty() {
+			fundedAccounts = append(fundedAccounts, nodeAccount)
+
+			continue
+		}
+
+		unfundedAccounts = append(unfundedAccounts, shortAccount{
+			address:      account.address,
+			missingFunds: missing,
+		})
+	}
+
+	return fundedAccounts, unfundedAccounts, nil
+}
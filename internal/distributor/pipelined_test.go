@@ -0,0 +1,270 @@
+package distributor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/state"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockChain is a simple single-block chain simulation,
+// used for verifying the pipelined funding flow
+type mockChain struct {
+	mux sync.Mutex
+
+	balances map[string]int64
+	txs      []types.Tx
+	results  []abci.ResponseDeliverTx
+
+	rejectIndex int // the sync broadcast index rejected at CheckTx, if any
+	failIndex   int // the sync broadcast index failing at DeliverTx, if any
+	lostIndex   int // the sync broadcast index never committed, if any
+
+	syncBroadcasts   int
+	commitBroadcasts int
+}
+
+// newMockChain creates a new mock chain, where only
+// the distributor holds the given balance
+func newMockChain(distributor keys.Info, balance int64) *mockChain {
+	return &mockChain{
+		balances: map[string]int64{
+			distributor.GetAddress().String(): balance,
+		},
+		rejectIndex: -1,
+		failIndex:   -1,
+		lostIndex:   -1,
+	}
+}
+
+// transfer applies the funding transaction to the chain state
+func (m *mockChain) transfer(tx *std.Tx) {
+	for _, msg := range tx.Msgs {
+		send, _ := msg.(bank.MsgSend)
+
+		m.balances[send.ToAddress.String()] += send.Amount.AmountOf(common.Denomination)
+	}
+}
+
+func (m *mockChain) client() *mockClient {
+	return &mockClient{
+		getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+			m.mux.Lock()
+			defer m.mux.Unlock()
+
+			addr, err := crypto.AddressFromString(address)
+			if err != nil {
+				return nil, err
+			}
+
+			return &gnoland.GnoAccount{
+				BaseAccount: std.BaseAccount{
+					Address: addr,
+					Coins: std.NewCoins(std.Coin{
+						Denom:  common.Denomination,
+						Amount: m.balances[address],
+					}),
+				},
+			}, nil
+		},
+		broadcastTransactionFn: func(tx *std.Tx) error {
+			m.mux.Lock()
+			defer m.mux.Unlock()
+
+			m.commitBroadcasts++
+			m.transfer(tx)
+
+			return nil
+		},
+		broadcastTransactionSyncFn: func(tx *std.Tx) ([]byte, error) {
+			m.mux.Lock()
+			defer m.mux.Unlock()
+
+			index := m.syncBroadcasts
+			m.syncBroadcasts++
+
+			if index == m.rejectIndex {
+				return nil, errors.New("check tx failed")
+			}
+
+			txBin, err := amino.Marshal(tx)
+			if err != nil {
+				return nil, err
+			}
+
+			if index == m.lostIndex {
+				return types.Tx(txBin).Hash(), nil
+			}
+
+			deliverTx := abci.ResponseDeliverTx{}
+
+			if index == m.failIndex {
+				deliverTx.Error = abci.StringError("deliver tx failed")
+			} else {
+				m.transfer(tx)
+			}
+
+			m.txs = append(m.txs, txBin)
+			m.results = append(m.results, deliverTx)
+
+			return types.Tx(txBin).Hash(), nil
+		},
+		getLatestBlockHeightFn: func() (int64, error) {
+			return 1, nil
+		},
+		getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+			m.mux.Lock()
+			defer m.mux.Unlock()
+
+			return &core_types.ResultBlock{
+				Block: &types.Block{
+					Data: types.Data{
+						Txs: m.txs,
+					},
+				},
+			}, nil
+		},
+		getBlockResultsFn: func(height *int64) (*core_types.ResultBlockResults, error) {
+			m.mux.Lock()
+			defer m.mux.Unlock()
+
+			return &core_types.ResultBlockResults{
+				Height: *height,
+				Results: &state.ABCIResponses{
+					DeliverTxs: m.results,
+				},
+			}, nil
+		},
+	}
+}
+
+// getAddresses extracts the addresses of the given accounts
+func getAddresses(accounts []*gnoland.GnoAccount) []string {
+	addresses := make([]string, len(accounts))
+
+	for index, account := range accounts {
+		addresses[index] = account.GetAddress().String()
+	}
+
+	return addresses
+}
+
+func TestDistributor_DistributePipelined(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx      = uint64(100)
+		singleCost = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))[0]
+		accounts   = generateAccounts(t, 6)
+		balance    = int64(len(accounts)) * common.DefaultGasFee.Add(singleCost).Amount
+
+		// The spend of a single account funding tx
+		fundingSpend = common.DefaultGasFee.Add(singleCost).Amount
+
+		expectedAddresses = make([]string, 0, len(accounts)-1)
+	)
+
+	for _, account := range accounts[1:] {
+		expectedAddresses = append(expectedAddresses, account.GetAddress().String())
+	}
+
+	t.Run("all funding txs committed", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockChain(accounts[0], balance)
+
+		d := NewDistributor(chain.client(), &mockSigner{}, WithPipelinedFunding())
+		d.commitTimeout = time.Second * 2
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, expectedAddresses, getAddresses(readyAccounts))
+
+		// Make sure no funding tx waited for a commit
+		assert.Equal(t, len(accounts)-1, chain.syncBroadcasts)
+		assert.Equal(t, 0, chain.commitBroadcasts)
+	})
+
+	t.Run("delivery failure invalidates later funding txs", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockChain(accounts[0], balance)
+		chain.failIndex = 1 // fails, and invalidates all later txs
+		chain.lostIndex = 3 // invalidated, and never committed
+
+		budget := NewBudget(0)
+
+		d := NewDistributor(chain.client(), &mockSigner{}, WithPipelinedFunding(), WithBudget(budget))
+		d.commitTimeout = time.Second * 2
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, expectedAddresses, getAddresses(readyAccounts))
+
+		// Make sure only the unfunded accounts were re-funded
+		assert.Equal(t, len(accounts)-1, chain.syncBroadcasts)
+		assert.Equal(t, 2, chain.commitBroadcasts)
+
+		for _, address := range expectedAddresses {
+			assert.Equal(t, singleCost.Amount, chain.balances[address])
+		}
+
+		// The failed delivery only paid the fee, and the lost tx nothing,
+		// so the re-funded accounts are counted once
+		assert.Equal(
+			t,
+			int64(len(expectedAddresses))*fundingSpend+common.DefaultGasFee.Amount,
+			budget.Report().Spent,
+		)
+	})
+
+	t.Run("check failure stops the pipeline", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockChain(accounts[0], balance)
+		chain.rejectIndex = 2
+
+		budget := NewBudget(0)
+
+		d := NewDistributor(chain.client(), &mockSigner{}, WithPipelinedFunding(), WithBudget(budget))
+		d.commitTimeout = time.Second * 2
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.ElementsMatch(t, expectedAddresses, getAddresses(readyAccounts))
+
+		// Make sure no funding txs were broadcast after the rejected one,
+		// and that the remaining accounts were re-funded
+		assert.Equal(t, 3, chain.syncBroadcasts)
+		assert.Equal(t, 3, chain.commitBroadcasts)
+
+		for _, address := range expectedAddresses {
+			assert.Equal(t, singleCost.Amount, chain.balances[address])
+		}
+
+		// The rejected tx spent nothing, so each account is funded (and counted) once
+		assert.Equal(t, int64(len(expectedAddresses))*fundingSpend, budget.Report().Spent)
+	})
+}
@@ -0,0 +1,274 @@
+package distributor
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/schollz/progressbar/v3"
+)
+
+var (
+	errEmptyPlan        = errors.New("funding plan has no transfers")
+	errInvalidPlanRow   = errors.New("invalid funding plan row")
+	errInvalidAddress   = errors.New("invalid address")
+	errInvalidAmount    = errors.New("invalid amount")
+	errDuplicateAddress = errors.New("duplicate address")
+	errPlannedAccount   = errors.New("funding plan includes an account that cannot be funded")
+)
+
+// PlannedTransfer is a single funding plan transfer
+type PlannedTransfer struct {
+	Address crypto.Address // the recipient address
+	Amount  std.Coin       // the transferred amount
+	Line    int            // the plan file line, for error reporting
+}
+
+// FundingPlan is a pre-computed set of funding transfers,
+// executed instead of the sub-account shortfall funding
+type FundingPlan []PlannedTransfer
+
+// Total returns the total amount transferred by the plan
+func (p FundingPlan) Total() std.Coin {
+	total := std.NewCoin(common.Denomination, 0)
+
+	for _, transfer := range p {
+		total = total.Add(transfer.Amount)
+	}
+
+	return total
+}
+
+// LoadFundingPlan loads the funding plan CSV file at the given path
+func LoadFundingPlan(path string) (FundingPlan, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open funding plan, %w", err)
+	}
+
+	defer file.Close()
+
+	return ParseFundingPlan(file)
+}
+
+// ParseFundingPlan parses the funding plan CSV, where each row is an
+// (address, amount) pair. The amount is in ugnot, and the denomination
+// suffix is optional. An optional header row is skipped
+func ParseFundingPlan(r io.Reader) (FundingPlan, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var (
+		plan  = make(FundingPlan, 0)
+		lines = make(map[string]int)
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w, %v", errInvalidPlanRow, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		// Skip the header row, if any
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+
+		address, err := crypto.AddressFromBech32(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w %q", line, errInvalidAddress, record[0])
+		}
+
+		amount, err := strconv.ParseInt(
+			strings.TrimSuffix(strings.TrimSpace(record[1]), common.Denomination),
+			10,
+			64,
+		)
+		if err != nil || amount <= 0 {
+			return nil, fmt.Errorf("line %d: %w %q, expected a positive ugnot amount", line, errInvalidAmount, record[1])
+		}
+
+		if previous, exists := lines[address.String()]; exists {
+			return nil, fmt.Errorf("line %d: %w %s, already planned on line %d", line, errDuplicateAddress, address, previous)
+		}
+
+		lines[address.String()] = line
+
+		plan = append(plan, PlannedTransfer{
+			Address: address,
+			Amount:  std.NewCoin(common.Denomination, amount),
+			Line:    line,
+		})
+	}
+
+	if len(plan) == 0 {
+		return nil, errEmptyPlan
+	}
+
+	return plan, nil
+}
+
+// planBatches splits the plan transfers into funding transaction batches of the given size
+func planBatches(plan FundingPlan, size int) []FundingPlan {
+	batches := make([]FundingPlan, 0, fundingTxCount(len(plan), size))
+
+	for start := 0; start < len(plan); start += size {
+		end := start + size
+		if end > len(plan) {
+			end = len(plan)
+		}
+
+		batches = append(batches, plan[start:end])
+	}
+
+	return batches
+}
+
+// newPlanTx generates an unsigned funding transaction for the plan batch
+func (d *Distributor) newPlanTx(distributor *gnoland.GnoAccount, batch FundingPlan) *std.Tx {
+	msgs := make([]std.Msg, 0, len(batch))
+
+	for _, transfer := range batch {
+		msgs = append(msgs, bank.MsgSend{
+			FromAddress: distributor.GetAddress(),
+			ToAddress:   transfer.Address,
+			Amount:      std.NewCoins(transfer.Amount),
+		})
+	}
+
+	return &std.Tx{
+		Msgs: msgs,
+		Fee:  d.fundingTxFee(len(batch)),
+	}
+}
+
+// fundPlan executes the funding plan transfers, instead of funding the short
+// sub-accounts. The transfers are batched into multi-transfer transactions, and
+// the run sub-accounts are returned as-is once the plan is executed
+func (d *Distributor) fundPlan(
+	accounts []keys.Info,
+	reservedCost std.Coins,
+) ([]*gnoland.GnoAccount, error) {
+	distributorAddress := accounts[0].GetAddress().String()
+
+	// Make sure every planned address can be funded
+	for _, transfer := range d.plan {
+		address := transfer.Address.String()
+
+		if address == distributorAddress {
+			return nil, fmt.Errorf("line %d: %w, %s is the distributor", transfer.Line, errPlannedAccount, address)
+		}
+
+		if _, refused := d.refused[address]; refused {
+			return nil, fmt.Errorf("line %d: %w, %s is refused", transfer.Line, errPlannedAccount, address)
+		}
+	}
+
+	distributor, err := d.cli.GetAccount(distributorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch distributor account, %w", err)
+	}
+
+	// Make sure the distributor covers the plan, the fees and the reserved funds
+	var (
+		batches = planBatches(d.plan, d.fundingBatchSize())
+		fees    = std.NewCoin(common.Denomination, int64(len(d.plan))*d.fundingFee.Amount)
+		total   = std.NewCoins(d.plan.Total().Add(fees)).Add(reservedCost)
+	)
+
+	if !distributor.Coins.IsAllGTE(total) {
+		fmt.Printf(
+			"❌ Distributor cannot cover the funding plan of %d %s, balance is %d %s\n",
+			total.AmountOf(common.Denomination),
+			common.Denomination,
+			distributor.Coins.AmountOf(common.Denomination),
+			common.Denomination,
+		)
+
+		return nil, errInsufficientFunds
+	}
+
+	// A partially executed plan leaves the sub-accounts in an unknown state,
+	// so the whole plan needs to fit the spend cap
+	planned := d.plan.Total().Add(fees).Amount
+
+	if remaining, capped := d.budget.Remaining(); capped && planned > remaining {
+		fmt.Printf(
+			"❌ The funding plan of %d %s exceeds the remaining spend cap of %d %s\n",
+			planned,
+			common.Denomination,
+			remaining,
+			common.Denomination,
+		)
+
+		return nil, ErrSpendCap
+	}
+
+	fmt.Printf("Executing the funding plan of %d transfers...\n", len(d.plan))
+	bar := progressbar.Default(int64(len(d.plan)), "executing planned transfers")
+
+	nonce := distributor.Sequence
+
+	for _, batch := range batches {
+		// Generate the transaction
+		tx := d.newPlanTx(distributor, batch)
+
+		if err := d.budget.SpendTx(SpendPlan, tx); err != nil {
+			return nil, err
+		}
+
+		// Sign the transaction
+		if err := d.signer.SignTx(tx, distributor, nonce, common.EncryptPassword); err != nil {
+			return nil, fmt.Errorf("unable to sign transaction, %w", err)
+		}
+
+		// Update the local nonce
+		nonce++
+
+		// Broadcast the tx and wait for it to be committed
+		if err := d.cli.BroadcastTransaction(tx); err != nil {
+			return nil, fmt.Errorf("unable to broadcast tx with commit, %w", err)
+		}
+
+		_ = bar.Add(len(batch))
+	}
+
+	fmt.Printf("✅ Successfully executed %d planned transfers\n", len(d.plan))
+
+	// Fetch the up-to-date run sub-accounts
+	runAccounts := make([]*gnoland.GnoAccount, 0, len(accounts)-1)
+
+	for _, account := range accounts[1:] {
+		if _, refused := d.refused[account.GetAddress().String()]; refused {
+			fmt.Printf("⚠️ Skipping refused sub-account %s\n", account.GetAddress().String())
+
+			continue
+		}
+
+		nodeAccount, err := d.cli.GetAccount(account.GetAddress().String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch sub-account, %w", err)
+		}
+
+		runAccounts = append(runAccounts, nodeAccount)
+	}
+
+	return runAccounts, nil
+}
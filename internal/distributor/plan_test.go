@@ -0,0 +1,285 @@
+package distributor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFundingPlan_Parse(t *testing.T) {
+	t.Parallel()
+
+	accounts := generateAccounts(t, 2)
+
+	var (
+		first  = accounts[0].GetAddress().String()
+		second = accounts[1].GetAddress().String()
+	)
+
+	t.Run("valid plan", func(t *testing.T) {
+		t.Parallel()
+
+		plan, err := ParseFundingPlan(strings.NewReader(
+			fmt.Sprintf("address,amount\n%s,100\n%s, 250ugnot\n", first, second),
+		))
+		if err != nil {
+			t.Fatalf("unable to parse funding plan, %v", err)
+		}
+
+		if !assert.Len(t, plan, 2) {
+			return
+		}
+
+		assert.Equal(t, first, plan[0].Address.String())
+		assert.Equal(t, int64(100), plan[0].Amount.Amount)
+		assert.Equal(t, 2, plan[0].Line)
+
+		assert.Equal(t, second, plan[1].Address.String())
+		assert.Equal(t, int64(250), plan[1].Amount.Amount)
+		assert.Equal(t, 3, plan[1].Line)
+
+		assert.Equal(t, int64(350), plan.Total().Amount)
+	})
+
+	testTable := []struct {
+		name        string
+		plan        string
+		expectedErr error
+		line        string
+	}{
+		{
+			"malformed address",
+			fmt.Sprintf("%s,100\ng1invalid,100\n", first),
+			errInvalidAddress,
+			"line 2",
+		},
+		{
+			"zero amount",
+			fmt.Sprintf("%s,0\n", first),
+			errInvalidAmount,
+			"line 1",
+		},
+		{
+			"negative amount",
+			fmt.Sprintf("address,amount\n%s,-10\n", first),
+			errInvalidAmount,
+			"line 2",
+		},
+		{
+			"invalid amount",
+			fmt.Sprintf("%s,10gnot\n", first),
+			errInvalidAmount,
+			"line 1",
+		},
+		{
+			"duplicate address",
+			fmt.Sprintf("%s,100\n%s,100\n%s,200\n", first, second, first),
+			errDuplicateAddress,
+			"line 3",
+		},
+		{
+			"missing column",
+			fmt.Sprintf("%s,100\n%s\n", first, second),
+			errInvalidPlanRow,
+			"line 2",
+		},
+		{
+			"empty plan",
+			"address,amount\n",
+			errEmptyPlan,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := ParseFundingPlan(strings.NewReader(testCase.plan))
+
+			assert.Nil(t, plan)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+			assert.Contains(t, err.Error(), testCase.line)
+		})
+	}
+}
+
+func TestDistributor_FundingPlan(t *testing.T) {
+	t.Parallel()
+
+	newPlan := func(addresses []crypto.Address, amount int64) FundingPlan {
+		plan := make(FundingPlan, 0, len(addresses))
+
+		for index, address := range addresses {
+			plan = append(plan, PlannedTransfer{
+				Address: address,
+				Amount:  std.NewCoin(common.Denomination, amount),
+				Line:    index + 1,
+			})
+		}
+
+		return plan
+	}
+
+	t.Run("plan executed in batches", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batchSize  = 50
+			accounts   = generateAccounts(t, 3)
+			recipients = make([]crypto.Address, 0, batchSize+1)
+
+			amount    = int64(100)
+			transfers = make(map[string]int64)
+			txs       = 0
+		)
+
+		// The recipients are not necessarily run sub-accounts
+		for _, account := range generateAccounts(t, batchSize+1) {
+			recipients = append(recipients, account.GetAddress())
+		}
+
+		mockClient := &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				addr, err := crypto.AddressFromBech32(address)
+				if err != nil {
+					t.Fatalf("invalid account requested, %v", err)
+				}
+
+				// The sub-accounts hold no funds, which the plan ignores
+				coins := std.NewCoins()
+				if address == accounts[0].GetAddress().String() {
+					coins = std.NewCoins(std.NewCoin(common.Denomination, 1_000_000))
+				}
+
+				return &gnoland.GnoAccount{
+					BaseAccount: *std.NewBaseAccount(addr, coins, nil, 0, 0),
+				}, nil
+			},
+			broadcastTransactionFn: func(tx *std.Tx) error {
+				txs++
+
+				// The fee scales with the gas wanted of the batch transfers
+				assert.Equal(t, int64(len(tx.Msgs))*DefaultFundingGas, tx.Fee.GasWanted)
+				assert.Equal(t, int64(len(tx.Msgs))*common.DefaultGasFee.Amount, tx.Fee.GasFee.Amount)
+
+				for _, msg := range tx.Msgs {
+					send, ok := msg.(bank.MsgSend)
+					if !ok {
+						t.Fatal("invalid message type")
+					}
+
+					transfers[send.ToAddress.String()] += send.Amount.AmountOf(common.Denomination)
+				}
+
+				return nil
+			},
+		}
+
+		d := NewDistributor(
+			mockClient,
+			&mockSigner{},
+			WithFundingPlan(newPlan(recipients, amount)),
+			WithFundingBatchSize(batchSize),
+		)
+
+		runAccounts, err := d.Distribute(accounts, 1000)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		// Make sure the run sub-accounts are returned as-is
+		assert.Len(t, runAccounts, len(accounts)-1)
+
+		// Make sure every planned transfer was batched
+		assert.Equal(t, 2, txs)
+		assert.Len(t, transfers, len(recipients))
+
+		for _, recipient := range recipients {
+			assert.Equal(t, amount, transfers[recipient.String()])
+		}
+
+		// Make sure the plan is part of the funding report
+		costs := d.CostReport()
+		if assert.NotNil(t, costs) {
+			assert.Equal(t, len(recipients), costs.PlannedTransfers)
+			assert.Equal(t, amount*int64(len(recipients)), costs.PlannedFunds)
+			assert.Equal(t, int64(0), costs.AccountCost)
+		}
+	})
+
+	t.Run("insufficient distributor funds", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts = generateAccounts(t, 3)
+			balance  = int64(200)
+		)
+
+		mockClient := &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				addr, err := crypto.AddressFromBech32(address)
+				if err != nil {
+					t.Fatalf("invalid account requested, %v", err)
+				}
+
+				return &gnoland.GnoAccount{
+					BaseAccount: *std.NewBaseAccount(
+						addr,
+						std.NewCoins(std.NewCoin(common.Denomination, balance)),
+						nil,
+						0,
+						0,
+					),
+				}, nil
+			},
+			broadcastTransactionFn: func(_ *std.Tx) error {
+				t.Fatal("no transfers should be executed")
+
+				return nil
+			},
+		}
+
+		// The plan covers the balance exactly, but not the fee
+		d := NewDistributor(
+			mockClient,
+			&mockSigner{},
+			WithFundingPlan(newPlan(
+				[]crypto.Address{accounts[1].GetAddress(), accounts[2].GetAddress()},
+				balance/2,
+			)),
+		)
+
+		runAccounts, err := d.Distribute(accounts, 1000)
+
+		assert.Nil(t, runAccounts)
+		assert.ErrorIs(t, err, errInsufficientFunds)
+	})
+
+	t.Run("distributor in the plan", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := generateAccounts(t, 2)
+
+		d := NewDistributor(
+			&mockClient{},
+			&mockSigner{},
+			WithFundingPlan(newPlan([]crypto.Address{accounts[0].GetAddress()}, 100)),
+		)
+
+		runAccounts, err := d.Distribute(accounts, 1000)
+
+		assert.Nil(t, runAccounts)
+		assert.ErrorIs(t, err, errPlannedAccount)
+		assert.Contains(t, err.Error(), "line 1")
+	})
+}
@@ -0,0 +1,133 @@
+package distributor
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+// Prediction is the predicted on-chain state of a
+// sub-account, from the results of a previous run
+type Prediction struct {
+	AccountNumber uint64
+	Sequence      uint64
+	Coins         std.Coins
+}
+
+// Predictions are the predicted sub-account states (address -> state)
+type Predictions map[string]Prediction
+
+// accountResolver resolves the sub-account states for the distribution. The predictions
+// are only trusted if a random sample of the predicted sub-accounts matches the chain,
+// otherwise all sub-accounts are fetched from the chain
+type accountResolver struct {
+	cli Client
+
+	predictions Predictions
+	sampled     map[string]*gnoland.GnoAccount // the sampled sub-accounts, fetched from the chain
+	trusted     bool                           // flag indicating if the predictions matched the samples
+
+	reused  int // the number of sub-accounts resolved from the predictions
+	fetched int // the number of sub-accounts fetched from the chain
+}
+
+// newAccountResolver creates a new sub-account resolver, verifying
+// the predictions of the given sub-accounts against a random sample
+func (d *Distributor) newAccountResolver(addresses []string) (*accountResolver, error) {
+	r := &accountResolver{
+		cli:         d.cli,
+		predictions: d.predictions,
+		sampled:     make(map[string]*gnoland.GnoAccount),
+	}
+
+	// Only the predicted sub-accounts can be sampled
+	predicted := make([]string, 0, len(addresses))
+
+	for _, address := range addresses {
+		if _, ok := r.predictions[address]; ok {
+			predicted = append(predicted, address)
+		}
+	}
+
+	if len(predicted) == 0 {
+		return r, nil
+	}
+
+	// Order the sub-accounts, so the sampling only depends on the seed
+	sort.Strings(predicted)
+
+	samples := d.predictionSamples
+	if samples > len(predicted) {
+		samples = len(predicted)
+	}
+
+	r.trusted = true
+
+	for _, index := range rand.New(rand.NewSource(d.predictionSeed)).Perm(len(predicted))[:samples] { //nolint:gosec // not used for security
+		address := predicted[index]
+
+		account, err := r.cli.GetAccount(address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch sub-account, %w", err)
+		}
+
+		r.sampled[address] = account
+		r.fetched++
+
+		if !matchesPrediction(account, r.predictions[address]) {
+			fmt.Printf(
+				"⚠️ Sub-account %s does not match the previous run results, fetching all sub-accounts\n",
+				address,
+			)
+
+			r.trusted = false
+		}
+	}
+
+	return r, nil
+}
+
+// resolve returns the state of the sub-account
+func (r *accountResolver) resolve(address string) (*gnoland.GnoAccount, error) {
+	if account, ok := r.sampled[address]; ok {
+		return account, nil
+	}
+
+	if prediction, ok := r.predictions[address]; ok && r.trusted {
+		addr, err := crypto.AddressFromString(address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse predicted sub-account address, %w", err)
+		}
+
+		r.reused++
+
+		return &gnoland.GnoAccount{
+			BaseAccount: std.BaseAccount{
+				Address:       addr,
+				Coins:         prediction.Coins,
+				AccountNumber: prediction.AccountNumber,
+				Sequence:      prediction.Sequence,
+			},
+		}, nil
+	}
+
+	account, err := r.cli.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	r.fetched++
+
+	return account, nil
+}
+
+// matchesPrediction checks if the on-chain sub-account state matches the prediction
+func matchesPrediction(account *gnoland.GnoAccount, prediction Prediction) bool {
+	return account.AccountNumber == prediction.AccountNumber &&
+		account.Sequence == prediction.Sequence &&
+		account.Coins.String() == prediction.Coins.String()
+}
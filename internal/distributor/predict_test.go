@@ -0,0 +1,151 @@
+package distributor
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributor_Predictions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numTx      = uint64(100)
+		singleCost = calculateRuntimeCosts(int64(numTx), std.NewCoin(common.Denomination, 0))[0]
+		accounts   = generateAccounts(t, 6)
+	)
+
+	// newFundedChain creates a chain where all sub-accounts are already funded
+	newFundedChain := func() *mockChain {
+		chain := newMockChain(accounts[0], 10*singleCost.Amount)
+
+		for _, account := range accounts[1:] {
+			chain.balances[account.GetAddress().String()] = singleCost.Amount
+		}
+
+		return chain
+	}
+
+	// newPredictions creates predictions for the given sub-accounts, with the given balance
+	newPredictions := func(balance int64, count int) Predictions {
+		predictions := make(Predictions, count)
+
+		for _, account := range accounts[1 : count+1] {
+			predictions[account.GetAddress().String()] = Prediction{
+				Coins: std.NewCoins(std.NewCoin(common.Denomination, balance)),
+			}
+		}
+
+		return predictions
+	}
+
+	// countFetches counts the account fetches of the client, per address
+	countFetches := func(cli *mockClient) map[string]int {
+		var (
+			fetches  = make(map[string]int)
+			getAccFn = cli.getAccountFn
+		)
+
+		cli.getAccountFn = func(address string) (*gnoland.GnoAccount, error) {
+			fetches[address]++
+
+			return getAccFn(address)
+		}
+
+		return fetches
+	}
+
+	t.Run("matching samples are trusted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain   = newFundedChain()
+			cli     = chain.client()
+			fetches = countFetches(cli)
+		)
+
+		d := NewDistributor(
+			cli,
+			&mockSigner{},
+			WithPredictions(newPredictions(singleCost.Amount, 5), 2, 42),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.Len(t, readyAccounts, 5)
+		assert.Equal(t, 0, chain.commitBroadcasts)
+
+		// Only the distributor and the 2 samples are fetched
+		subAccountFetches := 0
+
+		for _, account := range accounts[1:] {
+			subAccountFetches += fetches[account.GetAddress().String()]
+		}
+
+		assert.Equal(t, 2, subAccountFetches)
+		assert.Equal(t, 1, fetches[accounts[0].GetAddress().String()])
+	})
+
+	t.Run("mismatching samples fall back to the chain", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain   = newMockChain(accounts[0], 10*singleCost.Amount)
+			cli     = chain.client()
+			fetches = countFetches(cli)
+		)
+
+		// The predictions claim the sub-accounts are funded, but they are not
+		d := NewDistributor(
+			cli,
+			&mockSigner{},
+			WithPredictions(newPredictions(singleCost.Amount, 5), 1, 42),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.Len(t, readyAccounts, 5)
+		assert.Equal(t, 5, chain.commitBroadcasts)
+
+		for _, account := range accounts[1:] {
+			assert.NotZero(t, fetches[account.GetAddress().String()])
+		}
+	})
+
+	t.Run("unpredicted sub-accounts are fetched", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain   = newFundedChain()
+			cli     = chain.client()
+			fetches = countFetches(cli)
+		)
+
+		// Only the first 2 sub-accounts are predicted, and both are sampled
+		d := NewDistributor(
+			cli,
+			&mockSigner{},
+			WithPredictions(newPredictions(singleCost.Amount, 2), 5, 42),
+		)
+
+		readyAccounts, err := d.Distribute(accounts, numTx)
+		if err != nil {
+			t.Fatalf("unable to distribute funds, %v", err)
+		}
+
+		assert.Len(t, readyAccounts, 5)
+
+		for _, account := range accounts[1:] {
+			assert.Equal(t, 1, fetches[account.GetAddress().String()])
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package distributor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+var errIncompleteSweep = errors.New("unable to sweep all sub-accounts")
+
+// Collect sweeps the leftover sub-account balances back to the distributor
+// (account 0 in the mnemonic), leaving each sub-account with no funds.
+// Each sub-account signs its own transfer of every coin in its balance, paying the fee
+// from its balance. Sub-accounts that can't be fetched or swept are reported, without aborting
+// the sweep, and the total recovered amount (in the fee denomination) is returned
+func (d *Distributor) Collect(accounts []keys.Info) (std.Coin, error) {
+	summary, err := d.CollectWithSummary(accounts)
+
+	return std.NewCoin(summary.Denom, summary.Recovered), err
+}
+
+// CollectWithSummary sweeps the leftover sub-account balances back to the distributor,
+// like Collect, and returns the sweep summary with the funds recovered from each sub-account
+func (d *Distributor) CollectWithSummary(accounts []keys.Info) (*collector.SweepResult, error) {
+	fmt.Printf("\n🧹 Sweeping Sub-Accounts 🧹\n\n")
+
+	var (
+		distributor = accounts[0].GetAddress()
+		recovered   = std.NewCoins()
+		swept       = make([]*collector.SweepAccountResult, 0, len(accounts)-1)
+
+		skipped  = 0
+		failures = make([]string, 0)
+	)
+
+	for _, account := range accounts[1:] {
+		address := account.GetAddress().String()
+
+		// Make sure the account is not refused
+		if _, refused := d.refused[address]; refused {
+			fmt.Printf("⚠️ Skipping refused sub-account %s\n", address)
+
+			skipped++
+
+			continue
+		}
+
+		// Accounts that were never funded are fetched with no balance,
+		// so a fetch error means the funds of the account are unknown
+		subAccount, err := d.cli.GetAccount(address)
+		if err != nil {
+			fmt.Printf("❌ Unable to fetch sub-account %s, %v\n", address, err)
+
+			failures = append(failures, fmt.Sprintf("unable to fetch %s, %v", address, err))
+
+			continue
+		}
+
+		// Make sure the balance covers the sweep fee, and leaves something to sweep
+		amount, ok := d.sweepAmount(subAccount.Coins)
+		if !ok {
+			fmt.Printf("Skipping sub-account %s, balance %s\n", address, subAccount.Coins)
+
+			skipped++
+
+			continue
+		}
+
+		if err := d.sweepAccount(subAccount, distributor, amount); err != nil {
+			fmt.Printf("❌ Unable to sweep sub-account %s, %v\n", address, err)
+
+			failures = append(failures, fmt.Sprintf("unable to sweep %s, %v", address, err))
+
+			continue
+		}
+
+		fmt.Printf("✅ Swept %s from %s\n", amount, address)
+
+		recovered = recovered.Add(amount)
+		swept = append(swept, &collector.SweepAccountResult{
+			Address:   address,
+			Recovered: amount.AmountOf(common.Denomination),
+			Coins:     amount.String(),
+		})
+	}
+
+	fmt.Printf(
+		"Recovered %s from %d sub-accounts to %s\n",
+		recovered,
+		len(swept),
+		distributor,
+	)
+
+	result := &collector.SweepResult{
+		Denom:       common.Denomination,
+		Recovered:   recovered.AmountOf(common.Denomination),
+		Coins:       recovered.String(),
+		Swept:       len(swept),
+		Skipped:     skipped,
+		Failed:      len(failures),
+		Distributor: distributor.String(),
+		Accounts:    swept,
+	}
+
+	if len(failures) > 0 {
+		return result, fmt.Errorf(
+			"%w, %d of %d sweeps failed: %s",
+			errIncompleteSweep,
+			len(failures),
+			len(swept)+len(failures),
+			strings.Join(failures, "; "),
+		)
+	}
+
+	return result, nil
+}
+
+// sweepAmount returns the coins swept from the balance, which are all of its coins,
+// minus the sweep fee. Balances that can't cover the fee, or hold nothing
+// above it, are not swept
+func (d *Distributor) sweepAmount(balance std.Coins) (std.Coins, bool) {
+	if balance.AmountOf(d.fundingFee.Denom) < d.fundingFee.Amount {
+		return nil, false
+	}
+
+	amount := std.NewCoins()
+
+	for _, coin := range balance {
+		if coin.Denom == d.fundingFee.Denom {
+			coin = std.NewCoin(coin.Denom, coin.Amount-d.fundingFee.Amount)
+		}
+
+		if coin.IsPositive() {
+			amount = amount.Add(std.NewCoins(coin))
+		}
+	}
+
+	return amount, !amount.Empty()
+}
+
+// sweepAccount transfers the amount from the sub-account to the distributor,
+// and waits for the transfer to be committed
+func (d *Distributor) sweepAccount(
+	subAccount *gnoland.GnoAccount,
+	distributor crypto.Address,
+	amount std.Coins,
+) error {
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: subAccount.GetAddress(),
+				ToAddress:   distributor,
+				Amount:      amount,
+			},
+		},
+		Fee: std.NewFee(d.fundingGas, d.fundingFee),
+	}
+
+	// Sign the transaction with the sub-account key
+	if err := d.signer.SignTx(tx, subAccount, subAccount.Sequence, common.EncryptPassword); err != nil {
+		return fmt.Errorf("unable to sign sweep transaction, %w", err)
+	}
+
+	// Broadcast the tx and wait for it to be committed
+	if err := d.cli.BroadcastTransaction(tx); err != nil {
+		return fmt.Errorf("unable to broadcast tx with commit, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package distributor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributor_Collect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = generateAccounts(t, 6)
+		fee      = common.DefaultGasFee.Amount
+
+		errFetch = errors.New("unable to fetch")
+	)
+
+	// newSweepAccount creates an on-chain sub-account with the given balance
+	newSweepAccount := func(address string, balance std.Coins) (*gnoland.GnoAccount, error) {
+		addr, err := crypto.AddressFromString(address)
+		if err != nil {
+			return nil, err
+		}
+
+		return &gnoland.GnoAccount{
+			BaseAccount: *std.NewBaseAccount(addr, balance, nil, 0, 3),
+		}, nil
+	}
+
+	// newSweepClient creates a client with the given sub-account balances.
+	// Accounts without a balance are not on chain, and are fetched empty
+	newSweepClient := func(balances map[string]int64, captured *[]*std.Tx) *mockClient {
+		return &mockClient{
+			getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+				balance, ok := balances[address]
+				if !ok {
+					return &gnoland.GnoAccount{}, nil
+				}
+
+				return newSweepAccount(address, std.NewCoins(std.NewCoin(common.Denomination, balance)))
+			},
+			broadcastTransactionFn: func(tx *std.Tx) error {
+				*captured = append(*captured, tx)
+
+				return nil
+			},
+		}
+	}
+
+	t.Run("leftover balances swept", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			captured = make([]*std.Tx, 0)
+			balances = map[string]int64{
+				accounts[1].GetAddress().String(): 1000,
+				accounts[2].GetAddress().String(): fee, // only covers the fee
+				accounts[3].GetAddress().String(): 0,
+				accounts[4].GetAddress().String(): 501,
+				// accounts[5] is not on chain
+			}
+		)
+
+		d := NewDistributor(newSweepClient(balances, &captured), &mockSigner{})
+
+		recovered, err := d.Collect(accounts)
+		if err != nil {
+			t.Fatalf("unable to collect funds, %v", err)
+		}
+
+		assert.Equal(t, std.NewCoin(common.Denomination, 1000-fee+501-fee), recovered)
+
+		// Make sure only the accounts above the fee were swept
+		if len(captured) != 2 {
+			t.Fatalf("invalid number of sweep txs, %d", len(captured))
+		}
+
+		for index, expected := range []int64{1000 - fee, 501 - fee} {
+			send, ok := captured[index].Msgs[0].(bank.MsgSend)
+			if !ok {
+				t.Fatalf("invalid sweep message type")
+			}
+
+			assert.Equal(t, accounts[0].GetAddress(), send.ToAddress)
+			assert.Equal(t, expected, send.Amount.AmountOf(common.Denomination))
+			assert.Equal(t, common.DefaultGasFee, captured[index].Fee.GasFee)
+		}
+	})
+
+	t.Run("failed sweep does not abort", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			captured = make([]*std.Tx, 0)
+			balances = map[string]int64{
+				accounts[1].GetAddress().String(): 1000,
+				accounts[2].GetAddress().String(): 2000,
+				accounts[3].GetAddress().String(): 3000,
+			}
+
+			errBroadcast = errors.New("broadcast failed")
+		)
+
+		cli := newSweepClient(balances, &captured)
+		cli.broadcastTransactionFn = func(tx *std.Tx) error {
+			send, _ := tx.Msgs[0].(bank.MsgSend)
+			if send.FromAddress == accounts[2].GetAddress() {
+				return errBroadcast
+			}
+
+			captured = append(captured, tx)
+
+			return nil
+		}
+
+		d := NewDistributor(cli, &mockSigner{})
+
+		recovered, err := d.Collect(accounts)
+
+		assert.ErrorIs(t, err, errIncompleteSweep)
+		assert.Equal(t, std.NewCoin(common.Denomination, 1000-fee+3000-fee), recovered)
+		assert.Len(t, captured, 2)
+	})
+
+	t.Run("fetch failure reported", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			captured = make([]*std.Tx, 0)
+			balances = map[string]int64{
+				accounts[1].GetAddress().String(): 1000,
+				accounts[3].GetAddress().String(): 3000,
+			}
+		)
+
+		cli := newSweepClient(balances, &captured)
+		getAccount := cli.getAccountFn
+		cli.getAccountFn = func(address string) (*gnoland.GnoAccount, error) {
+			if address == accounts[2].GetAddress().String() {
+				return nil, errFetch
+			}
+
+			return getAccount(address)
+		}
+
+		d := NewDistributor(cli, &mockSigner{})
+
+		summary, err := d.CollectWithSummary(accounts[:4])
+
+		// Make sure the unfetched account is a failure, not a skip
+		assert.ErrorIs(t, err, errIncompleteSweep)
+		assert.ErrorContains(t, err, errFetch.Error())
+		assert.ErrorContains(t, err, accounts[2].GetAddress().String())
+
+		assert.Equal(t, 1000-fee+3000-fee, summary.Recovered)
+		assert.Equal(t, 2, summary.Swept)
+		assert.Zero(t, summary.Skipped)
+		assert.Equal(t, 1, summary.Failed)
+	})
+
+	t.Run("every denomination swept", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			captured = make([]*std.Tx, 0)
+			balances = map[string]std.Coins{
+				accounts[1].GetAddress().String(): std.NewCoins(
+					std.NewCoin(common.Denomination, 1000),
+					std.NewCoin("foo", 50),
+				),
+				// the deposit coins are swept, even with only the fee left
+				accounts[2].GetAddress().String(): std.NewCoins(
+					std.NewCoin(common.Denomination, fee),
+					std.NewCoin("foo", 20),
+				),
+				// the deposit coins are stuck without the fee
+				accounts[3].GetAddress().String(): std.NewCoins(std.NewCoin("foo", 10)),
+			}
+		)
+
+		cli := newSweepClient(nil, &captured)
+		cli.getAccountFn = func(address string) (*gnoland.GnoAccount, error) {
+			return newSweepAccount(address, balances[address])
+		}
+
+		d := NewDistributor(cli, &mockSigner{})
+
+		summary, err := d.CollectWithSummary(accounts[:4])
+		if err != nil {
+			t.Fatalf("unable to collect funds, %v", err)
+		}
+
+		assert.Equal(t, 1000-fee, summary.Recovered)
+		assert.Equal(t, "70foo,999ugnot", summary.Coins)
+		assert.Equal(t, 2, summary.Swept)
+		assert.Equal(t, 1, summary.Skipped)
+
+		if len(captured) != 2 {
+			t.Fatalf("invalid number of sweep txs, %d", len(captured))
+		}
+
+		for index, expected := range []std.Coins{
+			std.NewCoins(std.NewCoin(common.Denomination, 1000-fee), std.NewCoin("foo", 50)),
+			std.NewCoins(std.NewCoin("foo", 20)),
+		} {
+			send, ok := captured[index].Msgs[0].(bank.MsgSend)
+			if !ok {
+				t.Fatalf("invalid sweep message type")
+			}
+
+			assert.Equal(t, expected, send.Amount)
+		}
+	})
+
+	t.Run("sweep summary", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			captured = make([]*std.Tx, 0)
+			balances = map[string]int64{
+				accounts[1].GetAddress().String(): 1000,
+				accounts[2].GetAddress().String(): fee, // only covers the fee
+				accounts[3].GetAddress().String(): 2000,
+			}
+		)
+
+		d := NewDistributor(newSweepClient(balances, &captured), &mockSigner{})
+
+		summary, err := d.CollectWithSummary(accounts[:4])
+		if err != nil {
+			t.Fatalf("unable to collect funds, %v", err)
+		}
+
+		assert.Equal(t, common.Denomination, summary.Denom)
+		assert.Equal(t, 1000-fee+2000-fee, summary.Recovered)
+		assert.Equal(t, 2, summary.Swept)
+		assert.Equal(t, 1, summary.Skipped)
+		assert.Equal(t, accounts[0].GetAddress().String(), summary.Distributor)
+
+		// Make sure the recovered funds are reported per sub-account
+		if len(summary.Accounts) != 2 {
+			t.Fatalf("invalid number of swept accounts, %d", len(summary.Accounts))
+		}
+
+		assert.Equal(t, accounts[1].GetAddress().String(), summary.Accounts[0].Address)
+		assert.Equal(t, 1000-fee, summary.Accounts[0].Recovered)
+		assert.Equal(t, accounts[3].GetAddress().String(), summary.Accounts[1].Address)
+		assert.Equal(t, 2000-fee, summary.Accounts[1].Recovered)
+	})
+}
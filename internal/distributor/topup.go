@@ -0,0 +1,77 @@
+package distributor
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+// TopUp funds each of the run sub-accounts for the given number of additional run transactions,
+// for runs that fund the sub-accounts incrementally (duration runs). The run transactions in flight
+// make the fetched balances stale, so the funds are sent regardless of the balances.
+// The funding transactions are committed before it returns, and the funds sent
+// to each sub-account are returned
+func (d *Distributor) TopUp(
+	distributor crypto.Address,
+	accounts []*gnoland.GnoAccount,
+	transactions uint64,
+) (std.Coins, error) {
+	var (
+		funds = EstimateCosts(CostParams{
+			Transactions:   transactions,
+			StorageDeposit: d.storageDeposit,
+			GasFee:         d.runFee,
+		}).AccountCost
+
+		shortAccounts = make([]shortAccount, 0, len(accounts))
+	)
+
+	for _, account := range accounts {
+		shortAccounts = append(shortAccounts, shortAccount{
+			address:      account.GetAddress(),
+			missingFunds: funds,
+		})
+	}
+
+	distributorAccount, err := d.cli.GetAccount(distributor.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch distributor account, %w", err)
+	}
+
+	// Make sure the distributor covers the entire top-up, with the funding fees
+	total := multiplyCoins(funds.Add(std.NewCoins(d.fundingFee)), int64(len(accounts)))
+
+	if missing := calculateMissingFunds(distributorAccount.Coins, total); !missing.Empty() {
+		return nil, fmt.Errorf(
+			"%w, short %s for the top-up of %d sub-accounts",
+			errInsufficientFunds,
+			missing,
+			len(accounts),
+		)
+	}
+
+	nonce := distributorAccount.Sequence
+
+	for _, batch := range fundingBatches(shortAccounts, d.fundingBatchSize()) {
+		tx := d.newFundingTx(distributorAccount, batch)
+
+		if err := d.budget.SpendTx(SpendTopUp, tx); err != nil {
+			return nil, err
+		}
+
+		if err := d.signer.SignTx(tx, distributorAccount, nonce, common.EncryptPassword); err != nil {
+			return nil, fmt.Errorf("unable to sign transaction, %w", err)
+		}
+
+		nonce++
+
+		if err := d.cli.BroadcastTransaction(tx); err != nil {
+			return nil, fmt.Errorf("unable to broadcast tx with commit for the top-up of %s, %w", batchAddresses(batch), err)
+		}
+	}
+
+	return funds, nil
+}
@@ -0,0 +1,120 @@
+package distributor
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributor_TopUp(t *testing.T) {
+	t.Parallel()
+
+	var (
+		keys         = generateAccounts(t, 4)
+		distributor  = keys[0].GetAddress()
+		transactions = uint64(10)
+
+		// The top-up covers the run transactions, with the VM cost
+		funds = std.NewCoins(
+			std.NewCoin(common.Denomination, 10*(common.DefaultGasFee.Amount+common.InitialTxCost.Amount)),
+		)
+	)
+
+	subAccounts := make([]*gnoland.GnoAccount, 0, len(keys)-1)
+	for _, key := range keys[1:] {
+		subAccounts = append(subAccounts, &gnoland.GnoAccount{
+			BaseAccount: *std.NewBaseAccount(key.GetAddress(), std.NewCoins(), nil, 0, 0),
+		})
+	}
+
+	// newTopUpClient creates a client with the given distributor balance
+	newTopUpClient := func(balance int64, captured *[]*std.Tx) *mockClient {
+		return &mockClient{
+			getAccountFn: func(_ string) (*gnoland.GnoAccount, error) {
+				return &gnoland.GnoAccount{
+					BaseAccount: *std.NewBaseAccount(
+						distributor,
+						std.NewCoins(std.NewCoin(common.Denomination, balance)),
+						nil,
+						0,
+						7,
+					),
+				}, nil
+			},
+			broadcastTransactionFn: func(tx *std.Tx) error {
+				*captured = append(*captured, tx)
+
+				return nil
+			},
+		}
+	}
+
+	t.Run("sub-accounts funded", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			captured = make([]*std.Tx, 0)
+			nonces   = make([]uint64, 0)
+
+			signer = &mockSigner{
+				signTxFn: func(_ *std.Tx, _ *gnoland.GnoAccount, nonce uint64, _ string) error {
+					nonces = append(nonces, nonce)
+
+					return nil
+				},
+			}
+		)
+
+		d := NewDistributor(newTopUpClient(1_000_000_000, &captured), signer, WithFundingBatchSize(2))
+
+		sent, err := d.TopUp(distributor, subAccounts, transactions)
+		require.NoError(t, err)
+
+		assert.Equal(t, funds, sent)
+
+		// Make sure every sub-account is sent the funds, in batches
+		require.Len(t, captured, 2)
+		assert.Equal(t, []uint64{7, 8}, nonces)
+
+		transfers := 0
+
+		for _, tx := range captured {
+			for _, msg := range tx.Msgs {
+				assert.Equal(t, funds, msg.(bank.MsgSend).Amount)
+
+				transfers++
+			}
+		}
+
+		assert.Equal(t, len(subAccounts), transfers)
+	})
+
+	t.Run("insufficient distributor funds", func(t *testing.T) {
+		t.Parallel()
+
+		captured := make([]*std.Tx, 0)
+
+		d := NewDistributor(newTopUpClient(100, &captured), &mockSigner{})
+
+		_, err := d.TopUp(distributor, subAccounts, transactions)
+		assert.ErrorIs(t, err, errInsufficientFunds)
+		assert.Empty(t, captured)
+	})
+
+	t.Run("spend cap reached", func(t *testing.T) {
+		t.Parallel()
+
+		captured := make([]*std.Tx, 0)
+
+		d := NewDistributor(newTopUpClient(1_000_000_000, &captured), &mockSigner{}, WithBudget(NewBudget(100)))
+
+		_, err := d.TopUp(distributor, subAccounts, transactions)
+		assert.ErrorIs(t, err, ErrSpendCap)
+		assert.Empty(t, captured)
+	})
+}
@@ -0,0 +1,116 @@
+package dump
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+type Client interface {
+	GetAccount(address string) (*gnoland.GnoAccount, error)
+}
+
+type Signer interface {
+	SignTx(tx *std.Tx, account *gnoland.GnoAccount, nonce uint64, passphrase string) error
+}
+
+// Drift is the sequence drift of a source account,
+// since the dump was prepared
+type Drift struct {
+	Account Account             // the account state at prepare time
+	Current *gnoland.GnoAccount // the current account state
+}
+
+// Delta returns the number of transactions the
+// account executed since the dump was prepared
+func (d Drift) Delta() int64 {
+	return int64(d.Current.Sequence) - int64(d.Account.Sequence)
+}
+
+// CheckDrift fetches the current sequences of the source accounts,
+// and returns the accounts that drifted from the recorded sequences
+func (d *Dump) CheckDrift(cli Client) ([]Drift, error) {
+	drifts := make([]Drift, 0)
+
+	for _, account := range d.Header.Accounts {
+		current, err := cli.GetAccount(account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch account %s, %w", account.Address, err)
+		}
+
+		if current.Sequence != account.Sequence {
+			drifts = append(drifts, Drift{
+				Account: account,
+				Current: current,
+			})
+		}
+	}
+
+	return drifts, nil
+}
+
+// Without returns the dump transactions that
+// are not signed by any of the drifted accounts
+func (d *Dump) Without(drifts []Drift) []*std.Tx {
+	drifted := make(map[string]struct{}, len(drifts))
+	for _, drift := range drifts {
+		drifted[drift.Account.Address] = struct{}{}
+	}
+
+	txs := make([]*std.Tx, 0, len(d.Txs))
+
+	for _, tx := range d.Txs {
+		if _, ok := drifted[txSigner(tx)]; ok {
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs
+}
+
+// Resign rebuilds the signatures of the drifted accounts' transactions,
+// using fresh nonces that start at each account's current sequence.
+// The signer needs to hold the keys of the drifted accounts
+func (d *Dump) Resign(signer Signer, drifts []Drift) error {
+	var (
+		accounts = make(map[string]*gnoland.GnoAccount, len(drifts))
+		nonces   = make(map[string]uint64, len(drifts))
+	)
+
+	for _, drift := range drifts {
+		accounts[drift.Account.Address] = drift.Current
+		nonces[drift.Account.Address] = drift.Current.Sequence
+	}
+
+	// The transactions are in the account nonce order
+	for _, tx := range d.Txs {
+		address := txSigner(tx)
+
+		account, ok := accounts[address]
+		if !ok {
+			continue
+		}
+
+		if err := signer.SignTx(tx, account, nonces[address], common.EncryptPassword); err != nil {
+			return fmt.Errorf("unable to re-sign transaction, %w", err)
+		}
+
+		nonces[address]++
+	}
+
+	return nil
+}
+
+// txSigner returns the address of the transaction signer, if any
+func txSigner(tx *std.Tx) string {
+	signers := tx.GetSigners()
+	if len(signers) == 0 {
+		return ""
+	}
+
+	return signers[0].String()
+}
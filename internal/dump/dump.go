@@ -0,0 +1,137 @@
+// Package dump implements the prepared transaction dumps, which hold
+// signed run transactions that can be replayed in a later run
+package dump
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/state"
+)
+
+// version is the current dump format version
+const version = 1
+
+var errUnsupportedVersion = errors.New("unsupported dump version")
+
+// Header is the dump metadata, recorded at prepare time
+type Header struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	ChainID   string    `json:"chainId"`
+	Mode      string    `json:"mode"`
+
+	// Height is the latest chain height at prepare time
+	Height int64 `json:"height"`
+
+	// Accounts are the source accounts of the transactions
+	Accounts []Account `json:"accounts"`
+}
+
+// Account is the state of a source account at prepare time
+type Account struct {
+	Address       string `json:"address"`
+	Index         uint32 `json:"index"` // the mnemonic derivation index
+	AccountNumber uint64 `json:"accountNumber"`
+	Sequence      uint64 `json:"sequence"` // the nonce of the first account transaction
+}
+
+// Dump is a prepared transaction dump
+type Dump struct {
+	Header Header
+	Txs    []*std.Tx
+}
+
+// file is the on-disk dump format.
+// The transactions are amino encoded
+type file struct {
+	Header       Header   `json:"header"`
+	Transactions [][]byte `json:"transactions"`
+}
+
+// New creates a new dump of the given transactions
+func New(chainID, mode string, height int64, accounts []Account, txs []*std.Tx) *Dump {
+	return &Dump{
+		Header: Header{
+			Version:   version,
+			CreatedAt: time.Now().UTC(),
+			ChainID:   chainID,
+			Mode:      mode,
+			Height:    height,
+			Accounts:  accounts,
+		},
+		Txs: txs,
+	}
+}
+
+// Save saves the dump to the given path,
+// encrypted if the password is set
+func Save(d *Dump, path, password string) error {
+	f := file{
+		Header:       d.Header,
+		Transactions: make([][]byte, 0, len(d.Txs)),
+	}
+
+	for _, tx := range d.Txs {
+		encoded, err := amino.Marshal(tx)
+		if err != nil {
+			return fmt.Errorf("unable to marshal transaction, %w", err)
+		}
+
+		f.Transactions = append(f.Transactions, encoded)
+	}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("unable to marshal dump, %w", err)
+	}
+
+	if err := state.WriteFile(path, data, password); err != nil {
+		return fmt.Errorf("unable to write dump, %w", err)
+	}
+
+	return nil
+}
+
+// Load loads the dump from the given path,
+// decrypting it if necessary
+func Load(path, password string) (*Dump, error) {
+	data, err := state.ReadFile(path, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dump, %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal dump, %w", err)
+	}
+
+	if f.Header.Version != version {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, f.Header.Version)
+	}
+
+	d := &Dump{
+		Header: f.Header,
+		Txs:    make([]*std.Tx, 0, len(f.Transactions)),
+	}
+
+	for _, encoded := range f.Transactions {
+		var tx std.Tx
+		if err := amino.Unmarshal(encoded, &tx); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal transaction, %w", err)
+		}
+
+		d.Txs = append(d.Txs, &tx)
+	}
+
+	return d, nil
+}
+
+// Age returns the time passed since the dump was prepared
+func (d *Dump) Age() time.Duration {
+	return time.Since(d.Header.CreatedAt)
+}
@@ -0,0 +1,206 @@
+package dump
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateDump generates a dump with the given number of
+// transactions for each account, in the account nonce order
+func generateDump(numAccounts, txsPerAccount int) *Dump {
+	var (
+		accounts = make([]Account, numAccounts)
+		txs      = make([]*std.Tx, 0, numAccounts*txsPerAccount)
+	)
+
+	for i := 0; i < numAccounts; i++ {
+		accounts[i] = Account{
+			Address:       crypto.Address{byte(i + 1)}.String(),
+			Index:         uint32(i + 1),
+			AccountNumber: uint64(i),
+			Sequence:      10,
+		}
+	}
+
+	for n := 0; n < txsPerAccount; n++ {
+		for i := 0; i < numAccounts; i++ {
+			txs = append(txs, &std.Tx{
+				Msgs: []std.Msg{
+					bank.MsgSend{
+						FromAddress: crypto.Address{byte(i + 1)},
+					},
+				},
+				Memo: fmt.Sprintf("tx-%d-%d", i, n),
+			})
+		}
+	}
+
+	return New("dev", "REALM_CALL", 100, accounts, txs)
+}
+
+// newDriftClient creates a client whose accounts are at the
+// dump sequence, except for the given drifted accounts
+func newDriftClient(d *Dump, drifted map[string]uint64) *mockClient {
+	return &mockClient{
+		getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+			for _, account := range d.Header.Accounts {
+				if account.Address != address {
+					continue
+				}
+
+				sequence := account.Sequence
+				if current, ok := drifted[address]; ok {
+					sequence = current
+				}
+
+				acc := &gnoland.GnoAccount{}
+				acc.Sequence = sequence
+
+				return acc, nil
+			}
+
+			return nil, fmt.Errorf("unknown account %s", address)
+		},
+	}
+}
+
+func TestDump_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		password string
+	}{
+		{
+			"unencrypted dump",
+			"",
+		},
+		{
+			"encrypted dump",
+			"password",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				d    = generateDump(3, 2)
+				path = filepath.Join(t.TempDir(), "dump.json")
+			)
+
+			if err := Save(d, path, testCase.password); err != nil {
+				t.Fatalf("unable to save dump, %v", err)
+			}
+
+			loaded, err := Load(path, testCase.password)
+			if err != nil {
+				t.Fatalf("unable to load dump, %v", err)
+			}
+
+			assert.Equal(t, d.Header.Height, loaded.Header.Height)
+			assert.Equal(t, d.Header.Accounts, loaded.Header.Accounts)
+			assert.True(t, d.Header.CreatedAt.Equal(loaded.Header.CreatedAt))
+
+			if !assert.Len(t, loaded.Txs, len(d.Txs)) {
+				return
+			}
+
+			for index, tx := range loaded.Txs {
+				assert.Equal(t, d.Txs[index].Memo, tx.Memo)
+				assert.Equal(t, d.Txs[index].GetSigners(), tx.GetSigners())
+			}
+		})
+	}
+
+	t.Run("missing password", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "dump.json")
+
+		if err := Save(generateDump(1, 1), path, "password"); err != nil {
+			t.Fatalf("unable to save dump, %v", err)
+		}
+
+		_, err := Load(path, "")
+
+		assert.ErrorIs(t, err, state.ErrPasswordRequired)
+	})
+}
+
+func TestDump_CheckDrift(t *testing.T) {
+	t.Parallel()
+
+	var (
+		d       = generateDump(3, 2)
+		drifted = d.Header.Accounts[1].Address
+	)
+
+	drifts, err := d.CheckDrift(newDriftClient(d, map[string]uint64{drifted: 13}))
+	if err != nil {
+		t.Fatalf("unable to check drift, %v", err)
+	}
+
+	if !assert.Len(t, drifts, 1) {
+		return
+	}
+
+	assert.Equal(t, drifted, drifts[0].Account.Address)
+	assert.Equal(t, int64(3), drifts[0].Delta())
+
+	t.Run("partial replay", func(t *testing.T) {
+		t.Parallel()
+
+		txs := d.Without(drifts)
+
+		assert.Len(t, txs, 4)
+
+		for _, tx := range txs {
+			assert.NotEqual(t, drifted, txSigner(tx))
+		}
+	})
+}
+
+func TestDump_Resign(t *testing.T) {
+	t.Parallel()
+
+	var (
+		d       = generateDump(3, 3)
+		drifted = d.Header.Accounts[0].Address
+		signed  = make(map[string][]uint64)
+	)
+
+	drifts, err := d.CheckDrift(newDriftClient(d, map[string]uint64{drifted: 20}))
+	if err != nil {
+		t.Fatalf("unable to check drift, %v", err)
+	}
+
+	s := &mockSigner{
+		signTxFn: func(tx *std.Tx, _ *gnoland.GnoAccount, nonce uint64, _ string) error {
+			signer := txSigner(tx)
+			signed[signer] = append(signed[signer], nonce)
+
+			return nil
+		},
+	}
+
+	if err := d.Resign(s, drifts); err != nil {
+		t.Fatalf("unable to re-sign dump, %v", err)
+	}
+
+	// Make sure only the drifted account was re-signed,
+	// with fresh nonces starting at the current sequence
+	assert.Len(t, signed, 1)
+	assert.Equal(t, []uint64{20, 21, 22}, signed[drifted])
+}
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/distributor"
+)
+
+var errNothingStreamed = errors.New("no transactions were sent before the run deadline")
+
+// durationFundingWindow is the broadcast time (at the send rate)
+// covered by a single sub-account funding round of a duration run
+const durationFundingWindow = time.Minute
+
+// durationStream generates the duration run transactions on the fly, and funds the
+// sub-accounts incrementally, a round at a time. The next round is funded in the background
+// once half of the current one is used, so the broadcast only waits for the top-up
+// if the funding falls behind the send rate
+type durationStream struct {
+	generate func(transactions uint64) ([]*std.Tx, error)
+	topUp    func(transactions uint64) (std.Coins, error) // the sub-account top-up, if any
+
+	accounts []*gnoland.GnoAccount
+
+	window uint64 // the run transactions of each sub-account funded in a single round
+	limit  uint64 // the run transactions of each sub-account funded over the entire duration
+	funded uint64 // the run transactions of each sub-account funded so far
+
+	generated int // the number of generated transactions
+	topUps    int // the number of completed top-up rounds
+
+	pending chan topUpResult // the in-flight top-up, if any
+
+	recycler *accountRecycler // the idle sub-account recycler, if any
+}
+
+// topUpResult is the outcome of a single sub-account top-up round
+type topUpResult struct {
+	transactions uint64    // the run transactions funded for each sub-account
+	funds        std.Coins // the funds sent to each sub-account
+	err          error
+}
+
+// newDurationStream creates a new duration run transaction stream,
+// for sub-accounts already funded for a single round
+func newDurationStream(
+	generate func(transactions uint64) ([]*std.Tx, error),
+	topUp func(transactions uint64) (std.Coins, error),
+	accounts []*gnoland.GnoAccount,
+	window,
+	limit uint64,
+) *durationStream {
+	return &durationStream{
+		generate: generate,
+		topUp:    topUp,
+		accounts: accounts,
+		window:   window,
+		limit:    limit,
+		funded:   window,
+	}
+}
+
+// next generates the next transactions, topping up the sub-accounts ahead of the broadcast.
+// It returns no transactions once the funded sub-account transactions run out
+func (s *durationStream) next(transactions int) ([]*std.Tx, error) {
+	// Apply the completed top-up, if any, without waiting for it
+	select {
+	case result := <-s.pending:
+		if err := s.apply(result); err != nil {
+			return nil, err
+		}
+	default:
+	}
+
+	// The sub-accounts sign the transactions in turns
+	used := accountShare(s.generated+transactions, len(s.accounts))
+
+	if s.pending == nil && s.topUp != nil && s.funded < s.limit && used+s.window/2 > s.funded {
+		s.startTopUp()
+	}
+
+	// Wait for the in-flight top-up, if the funded transactions would run out
+	if used > s.funded && s.pending != nil {
+		if err := s.apply(<-s.pending); err != nil {
+			return nil, err
+		}
+	}
+
+	if used > s.funded {
+		return nil, nil
+	}
+
+	// The recycled sub-accounts continue from their on-chain sequence in this chunk
+	if s.recycler != nil {
+		s.recycler.recycle(time.Now())
+	}
+
+	txs, err := s.generate(uint64(transactions))
+	if err != nil {
+		return nil, fmt.Errorf("unable to construct transactions, %w", err)
+	}
+
+	s.generated += transactions
+
+	return txs, nil
+}
+
+// close waits for the in-flight top-up, if any, so it does not outlive the broadcast
+func (s *durationStream) close() error {
+	if s.pending == nil {
+		return nil
+	}
+
+	return s.apply(<-s.pending)
+}
+
+// recycles returns the number of idle sub-account recycles during the broadcast
+func (s *durationStream) recycles() int {
+	if s.recycler == nil {
+		return 0
+	}
+
+	return s.recycler.total()
+}
+
+// applyRecycles records the idle recycles of each sub-account in its results,
+// so the chronically stalling sub-accounts can be identified
+func (s *durationStream) applyRecycles(results []*collector.AccountResult) {
+	if s == nil || s.recycler == nil {
+		return
+	}
+
+	for _, result := range results {
+		result.Recycles = s.recycler.recycles[result.Address]
+	}
+}
+
+// startTopUp funds the next round in the background, capped by the duration funding
+func (s *durationStream) startTopUp() {
+	transactions := s.window
+	if remaining := s.limit - s.funded; remaining < transactions {
+		transactions = remaining
+	}
+
+	pending := make(chan topUpResult, 1)
+	s.pending = pending
+
+	go func() {
+		funds, err := s.topUp(transactions)
+
+		pending <- topUpResult{
+			transactions: transactions,
+			funds:        funds,
+			err:          err,
+		}
+	}()
+}
+
+// apply applies the completed top-up. Refused top-ups (spend cap)
+// end the run funding, so the broadcast ends with the funded transactions
+func (s *durationStream) apply(result topUpResult) error {
+	s.pending = nil
+
+	if errors.Is(result.err, distributor.ErrSpendCap) {
+		fmt.Printf("\n⚠️ Sub-account top-up refused, the run ends with the funded transactions, %v\n", result.err)
+
+		s.limit = s.funded
+
+		return nil
+	}
+
+	if result.err != nil {
+		return fmt.Errorf("unable to top up sub-accounts, %w", result.err)
+	}
+
+	s.funded += result.transactions
+	s.topUps++
+
+	// The top-ups are part of the sub-account balances the spend is accounted against
+	for _, account := range s.accounts {
+		account.Coins = account.Coins.Add(result.funds)
+	}
+
+	return nil
+}
+
+// accountShare returns the number of transactions signed by each
+// of the sub-accounts, for the given number of run transactions
+func accountShare(transactions, accounts int) uint64 {
+	if accounts < 1 {
+		accounts = 1
+	}
+
+	return uint64((transactions + accounts - 1) / accounts)
+}
+
+// durationFunding returns the run transactions each sub-account is funded for, in a single round,
+// and over the entire duration. A round covers the funding window at the send rate, and at least
+// two broadcast chunks, so the background top-ups stay ahead of the broadcast.
+// Genesis funded sub-accounts are not topped up, so a single round covers the entire duration
+func (p *Pipeline) durationFunding(subAccounts int) (uint64, uint64) {
+	endpoints := len(p.cfg.endpoints)
+	if endpoints == 0 {
+		endpoints = 1
+	}
+
+	var (
+		// The last chunk can end past the duration, with the next one generated ahead
+		chunk = int(p.cfg.BatchSize) * endpoints
+		limit = accountShare(int(p.cfg.runTransactions())+2*chunk, subAccounts)
+
+		window = accountShare(int(durationTransactions(durationFundingWindow, p.cfg.SendRate)), subAccounts)
+	)
+
+	if minWindow := 2 * accountShare(chunk, subAccounts); window < minWindow {
+		window = minWindow
+	}
+
+	if window > limit || p.cfg.AssumeGenesisFunded {
+		window = limit
+	}
+
+	return window, limit
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreamAccounts creates the given number of unfunded stream sub-accounts
+func newStreamAccounts(count int) []*gnoland.GnoAccount {
+	accounts := make([]*gnoland.GnoAccount, 0, count)
+
+	for i := 0; i < count; i++ {
+		accounts = append(accounts, &gnoland.GnoAccount{
+			BaseAccount: *std.NewBaseAccount(
+				crypto.AddressFromPreimage([]byte{byte(i)}),
+				std.NewCoins(),
+				nil,
+				uint64(i),
+				0,
+			),
+		})
+	}
+
+	return accounts
+}
+
+// generateTxs generates the given number of empty transactions
+func generateTxs(transactions uint64) ([]*std.Tx, error) {
+	txs := make([]*std.Tx, 0, transactions)
+
+	for i := uint64(0); i < transactions; i++ {
+		txs = append(txs, &std.Tx{})
+	}
+
+	return txs, nil
+}
+
+func TestDurationStream_Next(t *testing.T) {
+	t.Parallel()
+
+	t.Run("topped up ahead of the broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts = newStreamAccounts(2)
+			funds    = std.NewCoins(std.NewCoin(common.Denomination, 100))
+
+			topUps []uint64
+		)
+
+		topUp := func(transactions uint64) (std.Coins, error) {
+			topUps = append(topUps, transactions)
+
+			return funds, nil
+		}
+
+		// Each sub-account is funded for 4 transactions a round, for 10 in total
+		stream := newDurationStream(generateTxs, topUp, accounts, 4, 10)
+
+		generated := 0
+
+		for {
+			txs, err := stream.next(4)
+			require.NoError(t, err)
+
+			if len(txs) == 0 {
+				break
+			}
+
+			generated += len(txs)
+
+			// The generated transactions never exceed the funded ones
+			assert.LessOrEqual(t, accountShare(generated, len(accounts)), stream.funded)
+		}
+
+		require.NoError(t, stream.close())
+
+		// The last round is capped by the duration funding
+		assert.Equal(t, []uint64{4, 2}, topUps)
+		assert.Equal(t, 2, stream.topUps)
+		assert.Equal(t, 20, generated)
+
+		// The top-ups are credited to the sub-accounts
+		for _, account := range accounts {
+			assert.Equal(t, int64(200), account.Coins.AmountOf(common.Denomination))
+		}
+	})
+
+	t.Run("spend cap reached", func(t *testing.T) {
+		t.Parallel()
+
+		topUp := func(uint64) (std.Coins, error) {
+			return nil, distributor.ErrSpendCap
+		}
+
+		stream := newDurationStream(generateTxs, topUp, newStreamAccounts(2), 4, 10)
+
+		generated := 0
+
+		for {
+			txs, err := stream.next(2)
+			require.NoError(t, err)
+
+			if len(txs) == 0 {
+				break
+			}
+
+			generated += len(txs)
+		}
+
+		// The broadcast ends with the funded transactions
+		assert.Equal(t, 8, generated)
+		assert.Zero(t, stream.topUps)
+	})
+
+	t.Run("failed top-up", func(t *testing.T) {
+		t.Parallel()
+
+		errTopUp := errors.New("top-up failed")
+
+		topUp := func(uint64) (std.Coins, error) {
+			return nil, errTopUp
+		}
+
+		stream := newDurationStream(generateTxs, topUp, newStreamAccounts(2), 4, 10)
+
+		var err error
+
+		for err == nil {
+			_, err = stream.next(2)
+		}
+
+		assert.ErrorIs(t, err, errTopUp)
+	})
+
+	t.Run("genesis funded", func(t *testing.T) {
+		t.Parallel()
+
+		stream := newDurationStream(generateTxs, nil, newStreamAccounts(2), 10, 10)
+
+		txs, err := stream.next(20)
+		require.NoError(t, err)
+		assert.Len(t, txs, 20)
+
+		// There are no top-ups past the funded transactions
+		txs, err = stream.next(2)
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+}
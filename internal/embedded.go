@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/supernova/internal/client"
+	"github.com/gnolang/supernova/internal/embedded"
+)
+
+// embeddedFunds are the genesis funds of each account funded
+// by the embedded node genesis
+const embeddedFunds = "1000000000000000ugnot"
+
+// startEmbedded starts the in-process node, and points the pipeline client at it.
+// The genesis funds the distributor, and the sub-accounts for genesis funded runs
+func (p *Pipeline) startEmbedded() error {
+	var (
+		kb      = keys.NewInMemory()
+		indices = []uint64{p.cfg.DistributorIndex}
+	)
+
+	if p.cfg.AssumeGenesisFunded {
+		for i := p.cfg.SubAccountOffset; i < p.cfg.SubAccountOffset+p.cfg.SubAccounts; i++ {
+			indices = append(indices, i)
+		}
+	}
+
+	balances := make([]string, 0, len(indices))
+
+	for _, index := range indices {
+		address, err := deriveGenesisAddress(kb, p.cfg.Mnemonic, index)
+		if err != nil {
+			return err
+		}
+
+		balances = append(balances, fmt.Sprintf("%s=%s", address, embeddedFunds))
+	}
+
+	node, err := embedded.Start(embedded.Config{
+		ChainID:  p.cfg.ChainID,
+		Balances: balances,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to start embedded node, %w", err)
+	}
+
+	p.lifecycle.Register("embedded node", node.Stop)
+
+	p.node = node
+	p.cli = client.NewLocalClient(node.Client())
+
+	fmt.Printf("🌱 Started the embedded node (chain ID %s)\n", p.cfg.ChainID)
+
+	return nil
+}
+
+// newClient creates a client for the given URL,
+// or for the in-process node on embedded runs
+func (p *Pipeline) newClient(url string, maxIdleConns int) *client.HTTPClient {
+	if p.node != nil {
+		return client.NewLocalClient(p.node.Client())
+	}
+
+	return client.NewHTTPClient(url, maxIdleConns)
+}
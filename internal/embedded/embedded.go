@@ -0,0 +1,15 @@
+// Package embedded runs the stress test target as an in-process gno.land node.
+// The node support is only compiled in with the embedded build tag,
+// since it pulls in the full node stack
+package embedded
+
+import "errors"
+
+// ErrNotBuilt is returned when the binary is built without the embedded node support
+var ErrNotBuilt = errors.New("embedded node support not built, rebuild with -tags embedded")
+
+// Config is the embedded node configuration
+type Config struct {
+	ChainID  string   // the chain ID of the node
+	Balances []string // the genesis balances, in the <address>=<coins> format
+}
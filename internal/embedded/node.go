@@ -0,0 +1,189 @@
+//go:build embedded
+
+package embedded
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	"github.com/gnolang/gno/pkgs/bft/config"
+	"github.com/gnolang/gno/pkgs/bft/node"
+	"github.com/gnolang/gno/pkgs/bft/privval"
+	"github.com/gnolang/gno/pkgs/bft/rpc/client"
+	bft "github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/log"
+)
+
+const (
+	nodeLogFile = "node.log"
+
+	// The first block commits the genesis state,
+	// which is not queryable before it
+	readyTimeout  = 30 * time.Second
+	readyInterval = 100 * time.Millisecond
+
+	// The block limits of the gno.land test genesis
+	maxTxBytes   = 1_000_000  // 1MB
+	maxDataBytes = 2_000_000  // 2MB
+	maxGas       = 10_000_000 // 10M gas
+	timeIotaMS   = 100        // 100ms
+)
+
+// Node is the in-process gno.land node
+type Node struct {
+	node    *node.Node
+	conn    *client.Local
+	rootDir string
+	logFile *os.File
+}
+
+// Start starts an in-process gno.land node, with a single local validator
+// and a test genesis holding the given balances.
+// The node config and data are kept in a temporary directory, removed on stop
+func Start(cfg Config) (*Node, error) {
+	rootDir, err := os.MkdirTemp("", "supernova-node-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create node directory, %w", err)
+	}
+
+	n, err := start(rootDir, cfg)
+	if err != nil {
+		_ = os.RemoveAll(rootDir)
+
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// start starts the node in the given root directory
+func start(rootDir string, cfg Config) (*Node, error) {
+	nodeCfg := config.LoadOrMakeConfigWithOptions(rootDir, func(c *config.Config) {
+		c.Consensus.CreateEmptyBlocks = false
+
+		// The node is only reachable in-process
+		c.RPC.ListenAddress = ""
+		c.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	})
+
+	priv := privval.LoadOrGenFilePV(nodeCfg.PrivValidatorKeyFile(), nodeCfg.PrivValidatorStateFile())
+
+	genesis := &bft.GenesisDoc{
+		GenesisTime: time.Now(),
+		ChainID:     cfg.ChainID,
+		ConsensusParams: abci.ConsensusParams{
+			Block: &abci.BlockParams{
+				MaxTxBytes:   maxTxBytes,
+				MaxDataBytes: maxDataBytes,
+				MaxGas:       maxGas,
+				TimeIotaMS:   timeIotaMS,
+			},
+		},
+		Validators: []bft.GenesisValidator{
+			{
+				Address: priv.GetPubKey().Address(),
+				PubKey:  priv.GetPubKey(),
+				Power:   10,
+				Name:    "supernova",
+			},
+		},
+		AppState: gnoland.GnoGenesisState{
+			Balances: cfg.Balances,
+		},
+	}
+
+	if err := genesis.SaveAs(filepath.Join(rootDir, nodeCfg.Genesis)); err != nil {
+		return nil, fmt.Errorf("unable to save genesis, %w", err)
+	}
+
+	logFile, err := os.Create(filepath.Join(rootDir, nodeLogFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create node log, %w", err)
+	}
+
+	logger := log.NewTMLogger(log.NewSyncWriter(logFile))
+
+	app, err := gnoland.NewApp(rootDir, false, logger)
+	if err != nil {
+		_ = logFile.Close()
+
+		return nil, fmt.Errorf("unable to create node app, %w", err)
+	}
+
+	nodeCfg.LocalApp = app
+
+	gnoNode, err := node.DefaultNewNode(nodeCfg, logger)
+	if err != nil {
+		_ = logFile.Close()
+
+		return nil, fmt.Errorf("unable to create node, %w", err)
+	}
+
+	if err := gnoNode.Start(); err != nil {
+		_ = logFile.Close()
+
+		return nil, fmt.Errorf("unable to start node, %w", err)
+	}
+
+	n := &Node{
+		node:    gnoNode,
+		conn:    client.NewLocal(gnoNode),
+		rootDir: rootDir,
+		logFile: logFile,
+	}
+
+	if err := n.waitReady(); err != nil {
+		_ = gnoNode.Stop()
+		_ = logFile.Close()
+
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// waitReady waits for the node to commit the first block
+func (n *Node) waitReady() error {
+	deadline := time.Now().Add(readyTimeout)
+
+	for {
+		status, err := n.conn.Status()
+		if err == nil && status.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node not ready after %s", readyTimeout)
+		}
+
+		time.Sleep(readyInterval)
+	}
+}
+
+// Client returns the in-process node connection
+func (n *Node) Client() client.Client {
+	return n.conn
+}
+
+// Stop stops the node, and removes its config and data
+func (n *Node) Stop() error {
+	if n.node.IsRunning() {
+		if err := n.node.Stop(); err != nil {
+			return fmt.Errorf("unable to stop node, %w", err)
+		}
+	}
+
+	n.node.Wait()
+
+	_ = n.logFile.Close()
+
+	if err := os.RemoveAll(n.rootDir); err != nil {
+		return fmt.Errorf("unable to remove node directory, %w", err)
+	}
+
+	return nil
+}
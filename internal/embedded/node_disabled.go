@@ -0,0 +1,23 @@
+//go:build !embedded
+
+package embedded
+
+import "github.com/gnolang/gno/pkgs/bft/rpc/client"
+
+// Node is the in-process gno.land node
+type Node struct{}
+
+// Start always fails, since the embedded node support is not built
+func Start(_ Config) (*Node, error) {
+	return nil, ErrNotBuilt
+}
+
+// Client returns the in-process node connection
+func (n *Node) Client() client.Client {
+	return nil
+}
+
+// Stop stops the node
+func (n *Node) Stop() error {
+	return nil
+}
@@ -0,0 +1,28 @@
+//go:build !embedded
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/gnolang/supernova/internal/embedded"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_EmbeddedNotBuilt(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		ChainID:  "dev",
+		Mnemonic: testMnemonic,
+		Embedded: true,
+
+		SubAccounts:         3,
+		AssumeGenesisFunded: true,
+	}
+
+	// Embedded runs don't need the node URL
+	assert.NotErrorIs(t, cfg.Validate(), errInvalidURL)
+
+	assert.ErrorIs(t, NewPipeline(cfg).Execute(), embedded.ErrNotBuilt)
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+// newEndpointResults breaks down the run transactions by the broadcast
+// endpoint that accepted them, so a lagging node stands out
+func newEndpointResults(
+	batchResult *batcher.TxBatchResult,
+	commitTimes map[string]time.Time,
+) []*collector.EndpointResult {
+	samples := make([]collector.EndpointSample, 0, len(batchResult.TxEndpoints))
+
+	for index, url := range batchResult.TxEndpoints {
+		sample := collector.EndpointSample{
+			URL: url,
+		}
+
+		if committed, ok := commitTimes[string(batchResult.TxHashes[index])]; ok {
+			sample.Committed = true
+
+			// The block time can predate the broadcast, as with the mints
+			if latency := committed.Sub(batchResult.Timings[index].Sent); latency > 0 {
+				sample.Latency = latency
+			}
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return collector.NewEndpointResults(samples, batchResult.FailedEndpoints)
+}
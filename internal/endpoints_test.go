@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpoints_NewEndpointResults(t *testing.T) {
+	t.Parallel()
+
+	sent := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	batchResult := &batcher.TxBatchResult{
+		TxHashes: [][]byte{[]byte("tx-0"), []byte("tx-1"), []byte("tx-2")},
+		Timings: []batcher.TxTiming{
+			{Sent: sent},
+			{Sent: sent},
+			{Sent: sent.Add(2 * time.Second)},
+		},
+		TxEndpoints:     []string{"http://node-0:26657", "http://node-1:26657", "http://node-1:26657"},
+		FailedEndpoints: []string{"http://node-2:26657"},
+	}
+
+	commitTimes := map[string]time.Time{
+		"tx-0": sent.Add(time.Second),
+		"tx-2": sent.Add(time.Second), // the block time predates the broadcast
+	}
+
+	results := newEndpointResults(batchResult, commitTimes)
+
+	if len(results) != 3 {
+		t.Fatalf("invalid number of endpoint results, %d", len(results))
+	}
+
+	assert.Equal(t, 1, results[0].Committed)
+	assert.Equal(t, time.Second, results[0].CommitLatency.P50)
+
+	assert.Equal(t, 2, results[1].Transactions)
+	assert.Equal(t, 1, results[1].Lost)
+	assert.Equal(t, time.Duration(0), results[1].CommitLatency.P50)
+
+	assert.True(t, results[2].Failed)
+}
+
+func TestEndpoints_PeerConnections(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chain = newMockChain(nil)
+		peers = []*mockChain{newMockChain(nil), newMockChain(nil)}
+	)
+
+	p := &Pipeline{
+		cfg: &Config{PrewarmConnections: 4},
+		cli: chain,
+	}
+
+	for _, peer := range peers {
+		p.peers = append(p.peers, peer)
+	}
+
+	// Make sure every endpoint is pre-warmed, and keeps its pre-warmed connections
+	assert.NoError(t, p.prewarmConnections())
+	p.trackPhase(phasePrewarm, time.Now())
+
+	// Make sure every endpoint tears down its idle connections after the phase
+	p.trackPhase(phaseBatch, time.Now())
+
+	for _, client := range append(peers, chain) {
+		assert.Equal(t, 1, client.prewarms)
+		assert.Equal(t, 1, client.idleCloses)
+	}
+}
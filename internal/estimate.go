@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/fees"
+	"github.com/gnolang/supernova/internal/runtime"
+)
+
+// ugnotPerGNOT is the number of ugnot in a single GNOT
+const ugnotPerGNOT = 1000000
+
+// EstimateConfig is the offline run cost estimation configuration
+type EstimateConfig struct {
+	Mode      string // the stress test mode
+	MintRealm string // the existing realm targeted by the mints (MINT), if any
+
+	SubAccounts  uint64 // the number of sub-accounts in the run
+	Transactions uint64 // the total number of transactions
+	FundingBatch uint64 // the maximum number of transfers in a single funding tx
+	PrimingCalls uint64 // the number of unmeasured realm priming calls (REALM_CALL)
+
+	StorageDeposit      uint64 // the storage deposit for each package deployment
+	StorageDepositDenom string // the denomination of the storage deposit, defaults to ugnot
+
+	GasFee uint64 // the fee (in ugnot) of each transaction
+	VMCost uint64 // the fixed cost (in ugnot) the node charges for each run transaction
+	Fees   string // the per message type fee overrides JSON file, if any
+
+	fees fees.Map // the loaded fee overrides, if any
+}
+
+// Validate validates the estimation configuration
+func (cfg *EstimateConfig) Validate() error {
+	// Make sure the mode is valid
+	if !runtime.IsRuntime(runtime.Type(cfg.Mode)) {
+		return errInvalidMode
+	}
+
+	// Make sure the number of subaccounts is valid
+	if cfg.SubAccounts < 1 {
+		return errInvalidSubaccounts
+	}
+
+	// Make sure the number of transactions is valid
+	if cfg.Transactions < 1 {
+		return errInvalidTransactions
+	}
+
+	// Make sure the funding batch size is valid
+	if cfg.FundingBatch < 1 {
+		return errInvalidFundingBatch
+	}
+
+	// Make sure the storage deposit denomination is valid, if any
+	if cfg.StorageDepositDenom != "" && !std.NewCoin(cfg.StorageDepositDenom, 0).IsValid() {
+		return errInvalidDepositDenom
+	}
+
+	// Make sure the mint realm is only used for mints
+	if cfg.MintRealm != "" && runtime.Type(cfg.Mode) != runtime.Mint {
+		return errMintMode
+	}
+
+	// Make sure the fee overrides are valid, if any
+	if cfg.Fees != "" {
+		feeMap, err := fees.Load(cfg.Fees)
+		if err != nil {
+			return fmt.Errorf("invalid fee overrides, %w", err)
+		}
+
+		cfg.fees = feeMap
+	}
+
+	return nil
+}
+
+// feeMsgType returns the fee override message type of the mode run transactions
+func feeMsgType(mode runtime.Type) string {
+	if mode == runtime.RealmCall || mode == runtime.Mint || mode == runtime.CustomScenario {
+		return fees.Call
+	}
+
+	return fees.AddPackage
+}
+
+// costParams returns the mode dependent inputs of the cost model.
+// The live run and the offline estimate share them, so they never disagree.
+// Each transaction pays the gas fee of its message type, or the given gas fee
+func costParams(
+	mode runtime.Type,
+	deposit std.Coin,
+	primingCalls uint64,
+	mintRealm string,
+	feeMap fees.Map,
+	gasFee std.Coin,
+) distributor.CostParams {
+	params := distributor.CostParams{
+		GasFee:       feeMap.GasFee(feeMsgType(mode), gasFee),
+		PrimingFee:   feeMap.GasFee(fees.Call, gasFee),
+		PredeployFee: feeMap.GasFee(fees.AddPackage, gasFee),
+		FundingFee:   feeMap.GasFee(fees.Send, gasFee),
+		FundingGas:   feeMap.GasWanted(fees.Send, distributor.DefaultFundingGas),
+	}
+
+	// Only package deployments pay the storage deposit
+	if mode == runtime.RealmDeployment || mode == runtime.PackageDeployment {
+		params.StorageDeposit = deposit
+	}
+
+	// The priming calls are paid by the distributor
+	if mode == runtime.RealmCall {
+		params.PrimingTxs = primingCalls
+	}
+
+	// The predeployed realm is paid by the distributor, unless it is targeted
+	if runtime.Predeploys(mode) && (mode != runtime.Mint || mintRealm == "") {
+		params.PredeployTxs = 1
+
+		if mode == runtime.RealmCall {
+			params.PredeployDeposit = deposit
+		}
+	}
+
+	return params
+}
+
+// Estimate runs the cost model of the configured run offline,
+// and prints out the required distributor balance, with its breakdown
+func Estimate(cfg *EstimateConfig) error {
+	fmt.Printf("\n🧮 Estimating Run Costs 🧮\n\n")
+
+	denom := cfg.StorageDepositDenom
+	if denom == "" {
+		denom = common.Denomination
+	}
+
+	params := costParams(
+		runtime.Type(cfg.Mode),
+		std.NewCoin(denom, int64(cfg.StorageDeposit)),
+		cfg.PrimingCalls,
+		cfg.MintRealm,
+		cfg.fees,
+		std.NewCoin(common.Denomination, int64(cfg.GasFee)),
+	)
+
+	params.Transactions = cfg.Transactions
+	params.SubAccounts = cfg.SubAccounts
+	params.FundingBatch = int(cfg.FundingBatch)
+	params.VMCost = std.NewCoin(common.Denomination, int64(cfg.VMCost))
+
+	writeEstimate(os.Stdout, cfg, distributor.EstimateCosts(params))
+
+	return nil
+}
+
+// writeEstimate writes the cost estimate breakdown, in raw and humanized units
+func writeEstimate(out io.Writer, cfg *EstimateConfig, estimate *distributor.CostEstimate) {
+	w := tabwriter.NewWriter(out, 10, 20, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "Cost\tAmount\tHumanized")
+
+	rows := []struct {
+		name  string
+		coins std.Coins
+	}{
+		{"Transaction cost", std.NewCoins(estimate.TxCost)},
+		{"Sub-account cost", estimate.AccountCost},
+		{fmt.Sprintf("Sub-accounts (%d)", cfg.SubAccounts), estimate.AccountsCost},
+		{fmt.Sprintf("Funding fees (%d txs)", estimate.FundingTxs), estimate.FundingFees},
+		{"Priming cost", estimate.PrimingCost},
+		{"Predeploy cost", estimate.PredeployCost},
+	}
+
+	for _, row := range rows {
+		// Costs the run does not pay are left out
+		if row.coins.Empty() {
+			continue
+		}
+
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s", row.name, row.coins.String(), humanizeCoins(row.coins)))
+	}
+
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf("Required balance\t%s\t%s", estimate.Total.String(), humanizeCoins(estimate.Total)),
+	)
+
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintf(out, "\nThe estimate assumes no sub-account is funded yet, the distributor only tops up the shortfalls\n")
+}
+
+// humanizeCoins formats the coins with grouped digits,
+// and the gas denomination in GNOT (1,234.5 GNOT)
+func humanizeCoins(coins std.Coins) string {
+	formatted := make([]string, 0, len(coins))
+
+	for _, coin := range coins {
+		if coin.Denom != common.Denomination {
+			formatted = append(formatted, fmt.Sprintf("%s %s", groupDigits(strconv.FormatInt(coin.Amount, 10)), coin.Denom))
+
+			continue
+		}
+
+		gnot := groupDigits(strconv.FormatInt(coin.Amount/ugnotPerGNOT, 10))
+		if fraction := strings.TrimRight(fmt.Sprintf("%06d", coin.Amount%ugnotPerGNOT), "0"); fraction != "" {
+			gnot += "." + fraction
+		}
+
+		formatted = append(formatted, fmt.Sprintf("%s GNOT", gnot))
+	}
+
+	return strings.Join(formatted, " + ")
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/fees"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate_CostParams(t *testing.T) {
+	t.Parallel()
+
+	deposit := std.NewCoin(common.Denomination, 10)
+
+	testTable := []struct {
+		name      string
+		mode      runtime.Type
+		mintRealm string
+
+		storageDeposit   int64
+		primingTxs       uint64
+		predeployTxs     uint64
+		predeployDeposit int64
+	}{
+		{"realm deployment", runtime.RealmDeployment, "", 10, 0, 0, 0},
+		{"package deployment", runtime.PackageDeployment, "", 10, 0, 0, 0},
+		{"realm call", runtime.RealmCall, "", 0, 5, 1, 10},
+		{"mint", runtime.Mint, "", 0, 0, 1, 0},
+		{"targeted mint", runtime.Mint, "gno.land/r/demo/mint", 0, 0, 0, 0},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := costParams(testCase.mode, deposit, 5, testCase.mintRealm, nil, common.DefaultGasFee)
+
+			assert.Equal(t, testCase.storageDeposit, params.StorageDeposit.Amount)
+			assert.Equal(t, testCase.primingTxs, params.PrimingTxs)
+			assert.Equal(t, testCase.predeployTxs, params.PredeployTxs)
+			assert.Equal(t, testCase.predeployDeposit, params.PredeployDeposit.Amount)
+		})
+	}
+}
+
+func TestEstimate_CostParamsFees(t *testing.T) {
+	t.Parallel()
+
+	var (
+		deposit = std.NewCoin(common.Denomination, 0)
+		gasFee  = std.NewCoin(common.Denomination, 3)
+		feeMap  = fees.Map{
+			fees.Send:       {GasFee: "2ugnot"},
+			fees.Call:       {GasFee: "5ugnot"},
+			fees.AddPackage: {GasFee: "7ugnot"},
+		}
+	)
+
+	// Each transaction pays the fee of its message type
+	params := costParams(runtime.RealmCall, deposit, 5, "", feeMap, gasFee)
+
+	assert.Equal(t, int64(5), params.GasFee.Amount)
+	assert.Equal(t, int64(5), params.PrimingFee.Amount)
+	assert.Equal(t, int64(7), params.PredeployFee.Amount)
+	assert.Equal(t, int64(2), params.FundingFee.Amount)
+
+	params = costParams(runtime.PackageDeployment, deposit, 5, "", feeMap, gasFee)
+
+	assert.Equal(t, int64(7), params.GasFee.Amount)
+
+	// Message types without an override pay the given fee
+	params = costParams(runtime.Mint, deposit, 5, "", fees.Map{fees.Call: {GasWanted: 1}}, gasFee)
+
+	assert.Equal(t, gasFee, params.GasFee)
+	assert.Equal(t, gasFee, params.FundingFee)
+}
+
+func TestEstimate_HumanizeCoins(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1,000.001 GNOT", humanizeCoins(std.NewCoins(std.NewCoin(common.Denomination, 1000001000))))
+	assert.Equal(t, "0.000001 GNOT", humanizeCoins(std.NewCoins(std.NewCoin(common.Denomination, 1))))
+	assert.Equal(
+		t,
+		"3,000 udep + 5 GNOT",
+		humanizeCoins(std.NewCoins(std.NewCoin("udep", 3000), std.NewCoin(common.Denomination, 5000000))),
+	)
+}
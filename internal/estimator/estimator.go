@@ -0,0 +1,81 @@
+// Package estimator estimates the gas of the run transactions,
+// by simulating representative transactions on the node
+package estimator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+// DefaultMargin is the default safety margin of the estimated gas wanted,
+// as a fraction of the simulated gas used
+const DefaultMargin = 0.2
+
+var errNoSamples = errors.New("no sample transactions to simulate")
+
+// Client simulates the transaction execution on the node
+type Client interface {
+	SimulateTransaction(tx *std.Tx) (int64, error)
+}
+
+// Estimate is the simulated gas estimate of the run transactions
+type Estimate struct {
+	Samples   int     // the number of simulated sample transactions
+	GasUsed   int64   // the highest simulated gas used of the samples
+	GasWanted int64   // the gas used, with the safety margin
+	Margin    float64 // the applied safety margin
+}
+
+// Estimator estimates the gas wanted of the run transactions,
+// with a safety margin on top of the simulated gas used
+type Estimator struct {
+	cli    Client
+	margin float64
+}
+
+// NewEstimator creates a new gas estimator, with the given safety margin
+func NewEstimator(cli Client, margin float64) *Estimator {
+	return &Estimator{
+		cli:    cli,
+		margin: margin,
+	}
+}
+
+// Estimate simulates the sample transactions, and returns
+// the gas wanted that covers the most expensive one
+func (e *Estimator) Estimate(samples []*std.Tx) (*Estimate, error) {
+	if len(samples) == 0 {
+		return nil, errNoSamples
+	}
+
+	var gasUsed int64
+
+	for index, tx := range samples {
+		used, err := e.cli.SimulateTransaction(tx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to simulate sample transaction %d, %w", index, err)
+		}
+
+		if used > gasUsed {
+			gasUsed = used
+		}
+	}
+
+	return &Estimate{
+		Samples:   len(samples),
+		GasUsed:   gasUsed,
+		GasWanted: int64(math.Ceil(float64(gasUsed) * (1 + e.margin))),
+		Margin:    e.margin,
+	}, nil
+}
+
+// Fee returns the gas fee of the gas wanted at the given gas price, rounded up
+// so the fee is never below the node minimum gas price
+func Fee(gasWanted int64, price std.GasPrice) std.Coin {
+	amount := (gasWanted*price.Price.Amount + price.Gas - 1) / price.Gas
+
+	return std.NewCoin(price.Price.Denom, amount)
+}
@@ -0,0 +1,85 @@
+package estimator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimator_Estimate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("highest gas used, with the margin", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			samples = []*std.Tx{
+				{Fee: std.NewFee(1, std.NewCoin("ugnot", 1))},
+				{Fee: std.NewFee(2, std.NewCoin("ugnot", 1))},
+			}
+
+			gasUsed = map[int64]int64{1: 80000, 2: 125000}
+
+			cli = &mockClient{
+				simulateTransactionFn: func(tx *std.Tx) (int64, error) {
+					return gasUsed[tx.Fee.GasWanted], nil
+				},
+			}
+		)
+
+		estimate, err := NewEstimator(cli, 0.2).Estimate(samples)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, estimate.Samples)
+		assert.Equal(t, int64(125000), estimate.GasUsed)
+		assert.Equal(t, int64(150000), estimate.GasWanted)
+	})
+
+	t.Run("failed simulation", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			simulateErr = errors.New("out of gas")
+			cli         = &mockClient{
+				simulateTransactionFn: func(_ *std.Tx) (int64, error) {
+					return 0, simulateErr
+				},
+			}
+		)
+
+		_, err := NewEstimator(cli, DefaultMargin).Estimate([]*std.Tx{{}})
+
+		assert.ErrorIs(t, err, simulateErr)
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewEstimator(&mockClient{}, DefaultMargin).Estimate(nil)
+
+		assert.ErrorIs(t, err, errNoSamples)
+	})
+}
+
+func TestFee(t *testing.T) {
+	t.Parallel()
+
+	price, err := std.ParseGasPrice("1ugnot/1000gas")
+	require.NoError(t, err)
+
+	testTable := []struct {
+		gasWanted int64
+		expected  int64
+	}{
+		{1000, 1},
+		{150000, 150},
+		{150001, 151},
+	}
+
+	for _, testCase := range testTable {
+		assert.Equal(t, std.NewCoin("ugnot", testCase.expected), Fee(testCase.gasWanted, price))
+	}
+}
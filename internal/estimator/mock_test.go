@@ -0,0 +1,17 @@
+package estimator
+
+import "github.com/gnolang/gno/pkgs/std"
+
+type simulateTransactionDelegate func(*std.Tx) (int64, error)
+
+type mockClient struct {
+	simulateTransactionFn simulateTransactionDelegate
+}
+
+func (m *mockClient) SimulateTransaction(tx *std.Tx) (int64, error) {
+	if m.simulateTransactionFn != nil {
+		return m.simulateTransactionFn(tx)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,156 @@
+// Package feature classifies the run integrations by criticality, so a failed
+// optional integration degrades the run, instead of failing the measurement
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrDegraded is the strict mode error of the runs with failed optional integrations
+var ErrDegraded = errors.New("optional integrations failed")
+
+// Criticality is the failure handling of an integration
+type Criticality int
+
+const (
+	// Optional integrations are degraded on failure, and the run continues
+	Optional Criticality = iota + 1
+
+	// Critical integrations fail the run
+	Critical
+)
+
+// String returns the criticality name
+func (c Criticality) String() string {
+	switch c {
+	case Optional:
+		return "optional"
+	case Critical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Failure is a single degraded (failed optional) integration
+type Failure struct {
+	Integration string `json:"integration"`
+	Error       string `json:"error"`
+}
+
+// Registry keeps the registered integrations, and the failures of the optional ones
+type Registry struct {
+	mux          sync.Mutex
+	integrations map[string]*Integration
+	failures     []*Failure
+}
+
+// NewRegistry creates a new empty integration registry
+func NewRegistry() *Registry {
+	return &Registry{
+		integrations: make(map[string]*Integration),
+	}
+}
+
+// Integration is a registered integration. Failures can only be reported
+// through the registration, so every integration has an explicit criticality
+type Integration struct {
+	name        string
+	criticality Criticality
+	registry    *Registry
+}
+
+// Register registers the integration with the given criticality.
+// Registering the same integration again returns the existing registration,
+// and fails if the criticality differs
+func (r *Registry) Register(name string, criticality Criticality) (*Integration, error) {
+	if criticality != Optional && criticality != Critical {
+		return nil, fmt.Errorf("invalid %s integration criticality %d", name, criticality)
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if existing, ok := r.integrations[name]; ok {
+		if existing.criticality != criticality {
+			return nil, fmt.Errorf(
+				"integration %s is already registered as %s",
+				name,
+				existing.criticality,
+			)
+		}
+
+		return existing, nil
+	}
+
+	integration := &Integration{
+		name:        name,
+		criticality: criticality,
+		registry:    r,
+	}
+
+	r.integrations[name] = integration
+
+	return integration, nil
+}
+
+// Failures returns the failures of the optional integrations, in reporting order
+func (r *Registry) Failures() []*Failure {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	failures := make([]*Failure, len(r.failures))
+	copy(failures, r.failures)
+
+	return failures
+}
+
+// Check returns the strict mode error, if any optional integration failed
+func (r *Registry) Check(strict bool) error {
+	failures := r.Failures()
+	if !strict || len(failures) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		names = append(names, failure.Integration)
+	}
+
+	return fmt.Errorf("%w: %s", ErrDegraded, strings.Join(names, ", "))
+}
+
+// Name returns the integration name
+func (i *Integration) Name() string {
+	return i.name
+}
+
+// Criticality returns the integration criticality
+func (i *Integration) Criticality() Criticality {
+	return i.criticality
+}
+
+// Fail reports the integration failure. Critical failures are returned as is,
+// and optional failures are recorded as degraded, with a nil error
+func (i *Integration) Fail(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if i.criticality == Critical {
+		return err
+	}
+
+	i.registry.mux.Lock()
+	defer i.registry.mux.Unlock()
+
+	i.registry.failures = append(i.registry.failures, &Failure{
+		Integration: i.name,
+		Error:       err.Error(),
+	})
+
+	return nil
+}
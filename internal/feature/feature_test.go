@@ -0,0 +1,81 @@
+package feature
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Fail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("optional failures degrade", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry()
+
+		upload, err := registry.Register("upload", Optional)
+		if err != nil {
+			t.Fatalf("unable to register integration, %v", err)
+		}
+
+		assert.NoError(t, upload.Fail(errors.New("connection refused")))
+		assert.NoError(t, upload.Fail(nil))
+
+		assert.Equal(
+			t,
+			[]*Failure{{Integration: "upload", Error: "connection refused"}},
+			registry.Failures(),
+		)
+
+		// The degraded run only fails in strict mode
+		assert.NoError(t, registry.Check(false))
+		assert.ErrorIs(t, registry.Check(true), ErrDegraded)
+		assert.ErrorContains(t, registry.Check(true), "upload")
+	})
+
+	t.Run("critical failures fail", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			registry = NewRegistry()
+			saveErr  = errors.New("disk full")
+		)
+
+		results, err := registry.Register("results", Critical)
+		if err != nil {
+			t.Fatalf("unable to register integration, %v", err)
+		}
+
+		assert.ErrorIs(t, results.Fail(saveErr), saveErr)
+		assert.Empty(t, registry.Failures())
+		assert.NoError(t, registry.Check(true))
+	})
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+
+	first, err := registry.Register("history", Optional)
+	if err != nil {
+		t.Fatalf("unable to register integration, %v", err)
+	}
+
+	// Re-registrations return the same integration
+	second, err := registry.Register("history", Optional)
+	if err != nil {
+		t.Fatalf("unable to register integration, %v", err)
+	}
+
+	assert.Same(t, first, second)
+
+	// The criticality can't change, or be left out
+	_, err = registry.Register("history", Critical)
+	assert.ErrorContains(t, err, "already registered as optional")
+
+	_, err = registry.Register("metrics", Criticality(0))
+	assert.Error(t, err)
+}
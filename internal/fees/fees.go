@@ -0,0 +1,158 @@
+// Package fees implements the per message type fee overrides,
+// for chains that do not charge the same fee for every message
+package fees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+// The message types of the fee overrides
+const (
+	Send       = "send"        // bank transfers (the sub-account funding)
+	Call       = "call"        // realm calls (REALM_CALL, MINT and the priming calls)
+	AddPackage = "add_package" // package deployments (REALM_DEPLOYMENT, PACKAGE_DEPLOYMENT and the predeployments)
+	Run        = "run"         // script runs, not emitted by any mode yet
+)
+
+// msgTypes are the known message types, in listing order
+var msgTypes = []string{Send, Call, AddPackage, Run}
+
+var (
+	errUnknownMsgType = errors.New("unknown fee message type")
+	errInvalidFee     = errors.New("invalid fee")
+)
+
+// Fee is the fee override of a single message type
+type Fee struct {
+	GasWanted int64  `json:"gasWanted,omitempty"` // the gas wanted, the default of the message type if 0
+	GasFee    string `json:"gasFee,omitempty"`    // the gas fee coin (ex. 5ugnot), the default fee if empty
+}
+
+// Map is the fee overrides, by message type
+type Map map[string]Fee
+
+// Load loads the fee overrides JSON file at the given path
+func Load(path string) (Map, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open fees file, %w", err)
+	}
+
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse parses the fee overrides JSON, an object of fees keyed by message type.
+// Unknown message types and fee fields fail the parse
+func Parse(r io.Reader) (Map, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var m Map
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("unable to decode fees, %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Validate makes sure every fee override is of a known message type, and valid
+func (m Map) Validate() error {
+	for _, msgType := range m.types() {
+		if !isMsgType(msgType) {
+			return fmt.Errorf(
+				"%w %q, expected one of: %s",
+				errUnknownMsgType,
+				msgType,
+				strings.Join(msgTypes, ", "),
+			)
+		}
+
+		fee := m[msgType]
+
+		if fee.GasWanted < 0 {
+			return fmt.Errorf("%w for %s, negative gas wanted %d", errInvalidFee, msgType, fee.GasWanted)
+		}
+
+		if fee.GasFee == "" {
+			continue
+		}
+
+		coin, err := std.ParseCoin(fee.GasFee)
+		if err != nil || !coin.IsValid() {
+			return fmt.Errorf("%w for %s, invalid gas fee %q", errInvalidFee, msgType, fee.GasFee)
+		}
+
+		// The gas is paid in the chain denomination
+		if coin.Denom != common.Denomination {
+			return fmt.Errorf("%w for %s, gas fee not in %s", errInvalidFee, msgType, common.Denomination)
+		}
+	}
+
+	return nil
+}
+
+// GasWanted returns the gas wanted of the message type,
+// or the fallback if it is not overridden
+func (m Map) GasWanted(msgType string, fallback int64) int64 {
+	if fee, ok := m[msgType]; ok && fee.GasWanted > 0 {
+		return fee.GasWanted
+	}
+
+	return fallback
+}
+
+// GasFee returns the gas fee of the message type,
+// or the fallback if it is not overridden
+func (m Map) GasFee(msgType string, fallback std.Coin) std.Coin {
+	fee, ok := m[msgType]
+	if !ok || fee.GasFee == "" {
+		return fallback
+	}
+
+	// The fees are validated when loaded
+	coin, err := std.ParseCoin(fee.GasFee)
+	if err != nil {
+		return fallback
+	}
+
+	return coin
+}
+
+// types returns the message types of the fee overrides, sorted
+func (m Map) types() []string {
+	types := make([]string, 0, len(m))
+
+	for msgType := range m {
+		types = append(types, msgType)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// isMsgType returns true if the message type is known
+func isMsgType(msgType string) bool {
+	for _, known := range msgTypes {
+		if known == msgType {
+			return true
+		}
+	}
+
+	return false
+}
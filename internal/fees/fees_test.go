@@ -0,0 +1,93 @@
+package fees
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFees_Parse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid fees", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := Parse(strings.NewReader(`{
+			"send": {"gasFee": "2ugnot"},
+			"call": {"gasWanted": 150000, "gasFee": "5ugnot"},
+			"add_package": {"gasWanted": 3000000}
+		}`))
+		if err != nil {
+			t.Fatalf("unable to parse fees, %v", err)
+		}
+
+		assert.Equal(t, int64(150000), m.GasWanted(Call, 1))
+		assert.Equal(t, int64(3000000), m.GasWanted(AddPackage, 1))
+		assert.Equal(t, std.NewCoin(common.Denomination, 5), m.GasFee(Call, common.DefaultGasFee))
+		assert.Equal(t, std.NewCoin(common.Denomination, 2), m.GasFee(Send, common.DefaultGasFee))
+
+		// Unset values fall back
+		assert.Equal(t, int64(1), m.GasWanted(Send, 1))
+		assert.Equal(t, common.DefaultGasFee, m.GasFee(AddPackage, common.DefaultGasFee))
+		assert.Equal(t, common.DefaultGasFee, m.GasFee(Run, common.DefaultGasFee))
+	})
+
+	t.Run("invalid fees", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name     string
+			raw      string
+			expected error
+		}{
+			{"unknown message type", `{"mixed": {"gasWanted": 1}}`, errUnknownMsgType},
+			{"negative gas wanted", `{"call": {"gasWanted": -1}}`, errInvalidFee},
+			{"invalid gas fee", `{"call": {"gasFee": "five"}}`, errInvalidFee},
+			{"foreign gas fee denomination", `{"call": {"gasFee": "5uatom"}}`, errInvalidFee},
+		}
+
+		for _, testCase := range testTable {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := Parse(strings.NewReader(testCase.raw))
+				assert.True(t, errors.Is(err, testCase.expected))
+			})
+		}
+	})
+
+	t.Run("unknown fee field", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Parse(strings.NewReader(`{"call": {"gasPrice": "1ugnot"}}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestFees_Load(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fees.json")
+	if err := os.WriteFile(path, []byte(`{"run": {"gasWanted": 500000}}`), 0o600); err != nil {
+		t.Fatalf("unable to write fees file, %v", err)
+	}
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("unable to load fees, %v", err)
+	}
+
+	assert.Equal(t, int64(500000), m.GasWanted(Run, 0))
+
+	// Missing files fail the load
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
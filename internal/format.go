@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// summaryFormat formats the numbers of the console summary.
+// Humanized values are only ever displayed, the saved results keep the raw values
+type summaryFormat struct {
+	humanize bool           // flag indicating if the values are humanized, instead of raw
+	location *time.Location // the display timezone of the timestamps, UTC if nil
+}
+
+// newSummaryFormat creates a new console summary format
+func newSummaryFormat(humanize bool) summaryFormat {
+	return summaryFormat{
+		humanize: humanize,
+	}
+}
+
+// in returns the format, displaying the timestamps in the given timezone
+func (f summaryFormat) in(location *time.Location) summaryFormat {
+	f.location = location
+
+	return f
+}
+
+// time formats the timestamp in RFC3339, in the display timezone.
+// The saved results always keep UTC
+func (f summaryFormat) time(t time.Time) string {
+	location := f.location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return t.In(location).Format(time.RFC3339)
+}
+
+// count formats the count (or amount), with grouped digits (1,234,567)
+func (f summaryFormat) count(value int64) string {
+	if !f.humanize {
+		return strconv.FormatInt(value, 10)
+	}
+
+	return groupDigits(strconv.FormatInt(value, 10))
+}
+
+// duration formats the duration, as whole units (12m 34s) from a minute up,
+// and with a precision matching the magnitude below it
+func (f summaryFormat) duration(d time.Duration) string {
+	if !f.humanize {
+		return d.String()
+	}
+
+	var (
+		sign      = ""
+		magnitude = d
+	)
+
+	if d < 0 {
+		sign = "-"
+		magnitude = -d
+	}
+
+	switch {
+	case magnitude >= time.Hour:
+		magnitude = magnitude.Round(time.Second)
+
+		return fmt.Sprintf(
+			"%s%dh %dm %ds",
+			sign,
+			magnitude/time.Hour,
+			(magnitude%time.Hour)/time.Minute,
+			(magnitude%time.Minute)/time.Second,
+		)
+	case magnitude >= time.Minute:
+		magnitude = magnitude.Round(time.Second)
+
+		return fmt.Sprintf("%s%dm %ds", sign, magnitude/time.Minute, (magnitude%time.Minute)/time.Second)
+	case magnitude >= time.Second:
+		return fmt.Sprintf("%s%.2fs", sign, magnitude.Seconds())
+	case magnitude >= time.Millisecond:
+		return fmt.Sprintf("%s%dms", sign, magnitude.Round(time.Millisecond)/time.Millisecond)
+	default:
+		return d.String()
+	}
+}
+
+// rate formats the rate, with a precision matching its magnitude
+func (f summaryFormat) rate(value float64) string {
+	if !f.humanize {
+		return strconv.FormatFloat(value, 'f', 1, 64)
+	}
+
+	switch {
+	case value >= 100 || value <= -100:
+		return groupDigits(strconv.FormatFloat(value, 'f', 0, 64))
+	case value >= 10 || value <= -10:
+		return strconv.FormatFloat(value, 'f', 1, 64)
+	default:
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+}
+
+// groupDigits groups the integer digits of the formatted number in thousands
+func groupDigits(number string) string {
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	integer, fraction := number, ""
+	if index := strings.IndexByte(number, '.'); index >= 0 {
+		integer, fraction = number[:index], number[index:]
+	}
+
+	var b strings.Builder
+
+	for index, digit := range integer {
+		if index > 0 && (len(integer)-index)%3 == 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteRune(digit)
+	}
+
+	return sign + b.String() + fraction
+}
@@ -0,0 +1,430 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/feature"
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryFormat_Humanize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		humanized = newSummaryFormat(true)
+		raw       = newSummaryFormat(false)
+	)
+
+	t.Run("counts", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			value    int64
+			expected string
+		}{
+			{0, "0"},
+			{999, "999"},
+			{1000, "1,000"},
+			{1234567, "1,234,567"},
+			{-1234567, "-1,234,567"},
+		}
+
+		for _, testCase := range testTable {
+			assert.Equal(t, testCase.expected, humanized.count(testCase.value))
+		}
+
+		assert.Equal(t, "1234567", raw.count(1234567))
+	})
+
+	t.Run("durations", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			value    time.Duration
+			expected string
+		}{
+			{850 * time.Microsecond, "850µs"},
+			{512 * time.Millisecond, "512ms"},
+			{1614 * time.Millisecond, "1.61s"},
+			{754383 * time.Millisecond, "12m 34s"},
+			{time.Hour + 2*time.Minute + 3*time.Second, "1h 2m 3s"},
+		}
+
+		for _, testCase := range testTable {
+			assert.Equal(t, testCase.expected, humanized.duration(testCase.value))
+		}
+
+		assert.Equal(t, "12m34.383s", raw.duration(754383*time.Millisecond))
+	})
+
+	t.Run("rates", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			value    float64
+			expected string
+		}{
+			{1.234, "1.23"},
+			{12.34, "12.3"},
+			{123.4, "123"},
+			{12345.6, "12,346"},
+		}
+
+		for _, testCase := range testTable {
+			assert.Equal(t, testCase.expected, humanized.rate(testCase.value))
+		}
+
+		assert.Equal(t, "12345.6", raw.rate(12345.6))
+	})
+}
+
+func TestSummaryFormat_Results(t *testing.T) {
+	t.Parallel()
+
+	result := &collector.RunResult{
+		AverageTPS:   1234567,
+		CommittedTxs: 2500000,
+		Phases: []*collector.PhaseResult{
+			{Name: phaseCollect, Duration: 754383 * time.Millisecond},
+		},
+		CompletionThreshold: 1,
+	}
+
+	t.Run("humanized summary", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		writeResults(&buf, result, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "TPS: 1,234,567")
+		assert.Contains(t, buf.String(), "Committed: 2,500,000")
+		assert.Contains(t, buf.String(), "12m 34s")
+	})
+
+	t.Run("raw summary", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		writeResults(&buf, result, newSummaryFormat(false))
+
+		assert.Contains(t, buf.String(), "TPS: 1234567")
+		assert.Contains(t, buf.String(), "12m34.383s")
+		assert.NotContains(t, buf.String(), "1,234,567")
+	})
+
+	t.Run("wall clock timeline", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf      bytes.Buffer
+			timeline = *result
+
+			start = time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+		)
+
+		timeline.StartTime = start
+		timeline.EndTime = start.Add(754383 * time.Millisecond)
+		timeline.Phases = []*collector.PhaseResult{
+			{
+				Name:      phaseCollect,
+				StartTime: start,
+				EndTime:   timeline.EndTime,
+				Duration:  754383 * time.Millisecond,
+			},
+		}
+
+		writeResults(&buf, &timeline, newSummaryFormat(true).in(time.FixedZone("CEST", 2*60*60)))
+
+		// The run start is the first summary line, in the display timezone
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, "Run started at 2026-10-14T14:00:00+02:00, took 12m 34s", lines[0])
+		assert.Contains(t, buf.String(), "2026-10-14T14:12:34+02:00")
+	})
+
+	t.Run("wall clock drift", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf     bytes.Buffer
+			drifted = *result
+		)
+
+		drifted.Clock = &metrics.ClockAnchor{Drift: -2 * time.Second}
+
+		writeResults(&buf, &drifted, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "The wall clock drifted -2.00s during the broadcast")
+	})
+
+	t.Run("client-side bottleneck", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf        bytes.Buffer
+			bottleneck = *result
+		)
+
+		bottleneck.Client = &metrics.ClientMetrics{
+			Suspected: true,
+			Evidence:  []string{"CPU usage averaged 97.5% of 8 cores during the broadcast"},
+		}
+
+		writeResults(&buf, &bottleneck, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "Client-side bottleneck suspected")
+		assert.Contains(t, buf.String(), "- CPU usage averaged 97.5% of 8 cores during the broadcast")
+	})
+
+	t.Run("signer backends", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf   bytes.Buffer
+			mixed = *result
+		)
+
+		mixed.Signers = []*collector.SignerResult{
+			{Backend: "hd", Accounts: 10, Signed: 1000},
+			{Backend: "wallet", Accounts: 5, Signed: 495, Failed: 5},
+		}
+
+		writeResults(&buf, &mixed, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "Signer")
+		assert.Contains(t, buf.String(), "wallet")
+		assert.Contains(t, buf.String(), "5 signing requests failed")
+	})
+
+	t.Run("endpoint breakdown", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf    bytes.Buffer
+			spread = *result
+		)
+
+		spread.Endpoints = []*collector.EndpointResult{
+			{
+				URL:           "http://node-0:26657",
+				Transactions:  5000,
+				Committed:     5000,
+				CommitLatency: &metrics.Distribution{P50: time.Second, P95: 2 * time.Second},
+			},
+			{
+				URL:           "http://node-1:26657",
+				Transactions:  5000,
+				Committed:     4000,
+				Lost:          1000,
+				CommitLatency: &metrics.Distribution{P50: 3 * time.Second, P95: 5 * time.Second},
+				Lagging:       true,
+			},
+		}
+
+		writeResults(&buf, &spread, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "⚠️ Endpoint http://node-1:26657 lags behind the other endpoints")
+		assert.Contains(t, buf.String(), "http://node-1:26657 (lagging)")
+		assert.Contains(t, buf.String(), "4,000")
+	})
+
+	t.Run("scenario template mix", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf   bytes.Buffer
+			mixed = *result
+		)
+
+		mixed.Scenario = &collector.ScenarioResult{
+			Name: "boards",
+			Templates: []*collector.ScenarioTemplateResult{
+				{Name: "post", PkgPath: "gno.land/r/demo/boards", Func: "CreatePost", Weight: 3, Transactions: 7500},
+				{Name: "vote", PkgPath: "gno.land/r/demo/boards", Func: "Vote", Weight: 1, Transactions: 2500},
+			},
+		}
+
+		writeResults(&buf, &mixed, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "Scenario: boards (2 templates)")
+		assert.Contains(t, buf.String(), "gno.land/r/demo/boards.CreatePost")
+		assert.Contains(t, buf.String(), "7,500")
+	})
+
+	t.Run("duration run", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf   bytes.Buffer
+			timed = *result
+		)
+
+		timed.Duration = &collector.DurationResult{
+			Duration:        10 * time.Minute,
+			SendRate:        200,
+			Target:          120000,
+			Generated:       118400,
+			Sent:            118000,
+			Unsent:          400,
+			TopUps:          9,
+			Recycles:        2,
+			DeadlineReached: true,
+		}
+
+		writeResults(&buf, &timed, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "Duration run: 10m 0s at 200 tx/s")
+		assert.Contains(t, buf.String(), "sent 118,000 of 120,000 txs")
+		assert.Contains(t, buf.String(), "topped up 9 times")
+		assert.Contains(t, buf.String(), "recycled 2 times")
+		assert.Contains(t, buf.String(), "400 of the 118,400 generated txs were not sent before the deadline")
+		assert.Contains(t, buf.String(), "finalized by the run deadline")
+	})
+
+	t.Run("swept sub-accounts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf   bytes.Buffer
+			swept = *result
+		)
+
+		swept.Sweep = &collector.SweepResult{
+			Denom:     "ugnot",
+			Recovered: 1_250_000,
+			Swept:     9,
+			Skipped:   1,
+		}
+
+		writeResults(&buf, &swept, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "1,250,000 ugnot (9 sub-accounts swept, 1 skipped, 0 failed)")
+		assert.NotContains(t, buf.String(), "were not swept")
+		assert.NotContains(t, buf.String(), "Recovered coins")
+
+		// Other swept denominations are reported as well
+		buf.Reset()
+
+		swept.Sweep.Coins = "300foo,1250000ugnot"
+
+		writeResults(&buf, &swept, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "300foo,1250000ugnot")
+	})
+
+	t.Run("estimated gas wanted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf       bytes.Buffer
+			estimated = *result
+		)
+
+		estimated.GasWanted = &collector.GasWantedResult{
+			Mode:      "REALM_CALL",
+			Run:       120000,
+			Simulated: 100000,
+			Margin:    0.2,
+		}
+
+		writeResults(&buf, &estimated, newSummaryFormat(true))
+
+		assert.Contains(
+			t,
+			buf.String(),
+			"Gas wanted: 120,000 per REALM_CALL transaction (estimated from 100,000 simulated, 20% margin)",
+		)
+	})
+
+	t.Run("backfilled gas stats", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf        bytes.Buffer
+			backfilled = *result
+		)
+
+		backfilled.GasSource = collector.GasSourceTxSample
+		backfilled.Blocks = []*collector.BlockResult{
+			{Number: 1, GasUsed: 5000, GasLimit: 10000, GasSource: collector.GasSourceTxSample},
+			{Number: 2, GasLimit: 10000, GasSource: collector.GasSourceUnavailable},
+		}
+		backfilled.Backfill = &collector.BackfillResult{
+			Source:  collector.GasSourceTxSample,
+			Matched: 200,
+			Queried: 20,
+			Codes:   map[string]int{"ok": 19, "std.OutOfGasError": 1},
+		}
+
+		writeResults(&buf, &backfilled, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "5,000 (tx-sample)")
+		assert.Contains(t, buf.String(), "n/a")
+		assert.Contains(t, buf.String(), "Gas stats from tx-sample, 20 of 200 txs backfilled")
+		assert.Contains(t, buf.String(), "std.OutOfGasError")
+	})
+
+	t.Run("degraded integrations", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf      bytes.Buffer
+			degraded = *result
+		)
+
+		degraded.Degraded = []*feature.Failure{
+			{Integration: "results upload", Error: "connection refused"},
+		}
+
+		writeResults(&buf, &degraded, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "Degraded: the results upload integration failed (connection refused)")
+	})
+
+	t.Run("disabled tx indexer features", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf       bytes.Buffer
+			unindexed = *result
+		)
+
+		unindexed.Indexer = &collector.IndexerResult{
+			Status:   "disabled",
+			Source:   "node status",
+			Disabled: []string{"tx backfill"},
+		}
+
+		writeResults(&buf, &unindexed, newSummaryFormat(true))
+
+		assert.Contains(t, buf.String(), "The node tx indexer is disabled (node status), the tx backfill was skipped")
+	})
+
+	t.Run("saved results keep raw values", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "results.json")
+
+		if err := saveResults(result, path); err != nil {
+			t.Fatalf("unable to save results, %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unable to read results, %v", err)
+		}
+
+		assert.Contains(t, string(content), `"averageTPS":1234567`)
+		assert.Contains(t, string(content), `"duration":754383000000`)
+		assert.NotContains(t, string(content), "1,234,567")
+		assert.NotContains(t, string(content), "12m 34s")
+	})
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/estimator"
+	"github.com/gnolang/supernova/internal/runtime"
+)
+
+// estimateGas estimates the gas wanted of the run transactions, by simulating
+// a representative run transaction of the distributor account on the node.
+// The simulation does not commit, so the sample is not part of the run.
+// Gas wanted set explicitly (flags, fee overrides) is kept as is
+func (p *Pipeline) estimateGas(txRuntime runtime.Runtime, accounts []keys.Info) error {
+	mode := runtime.Type(p.cfg.Mode)
+
+	if _, isDefault := p.gasWanted(mode); !isDefault {
+		fmt.Printf("\n⚠️ The run gas wanted is set explicitly, skipping the gas estimation\n")
+
+		return nil
+	}
+
+	fmt.Printf("\n⛽ Estimating Gas ⛽\n\n")
+
+	account, err := p.cli.GetAccount(accounts[0].GetAddress().String())
+	if err != nil {
+		return fmt.Errorf("unable to fetch distributor account, %w", err)
+	}
+
+	samples, err := txRuntime.ConstructTransactions([]*gnoland.GnoAccount{account}, 1)
+	if err != nil {
+		return fmt.Errorf("unable to construct sample transaction, %w", err)
+	}
+
+	estimate, err := estimator.NewEstimator(p.cli, p.cfg.GasMargin).Estimate(samples)
+	if err != nil {
+		return fmt.Errorf("unable to estimate gas, %w", err)
+	}
+
+	p.gasEstimate = estimate
+
+	// The run transactions are constructed once the sub-accounts are funded
+	gasWanted, _ := p.gasWanted(mode)
+	gasFee := p.gasFee(feeMsgType(mode))
+
+	txRuntime.SetRunFee(std.NewFee(gasWanted, gasFee))
+
+	fmt.Printf(
+		"✅ Simulated gas used %d, estimated gas wanted %d (%.0f%% margin), gas fee %s\n",
+		estimate.GasUsed,
+		gasWanted,
+		estimate.Margin*100,
+		gasFee.String(),
+	)
+
+	return nil
+}
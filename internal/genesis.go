@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/gnolang/gno/pkgs/crypto/bip39"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+var errInvalidAmount = errors.New("invalid genesis amount specified")
+
+// GenesisConfig is the genesis balances generation configuration
+type GenesisConfig struct {
+	Mnemonic string // the mnemonic for the keyring
+
+	SubAccounts      uint64 // the number of funded sub-accounts
+	SubAccountOffset uint64 // the derivation index of the first sub-account
+	DistributorIndex uint64 // the derivation index of the distributor account
+
+	Amount            string // the funds of each sub-account (ugnot if no denomination)
+	DistributorAmount string // the funds of the distributor (the sub-account amount if empty)
+}
+
+// Validate validates the genesis balances configuration
+func (cfg *GenesisConfig) Validate() error {
+	// Make sure the mnemonic is valid
+	if !bip39.IsMnemonicValid(cfg.Mnemonic) {
+		return errInvalidMnemonic
+	}
+
+	// Make sure the number of subaccounts is valid
+	if cfg.SubAccounts < 1 {
+		return errInvalidSubaccounts
+	}
+
+	// Make sure the account derivation indices are valid
+	if cfg.DistributorIndex > math.MaxUint32 || cfg.SubAccountOffset+cfg.SubAccounts > math.MaxUint32 {
+		return errInvalidDistributor
+	}
+
+	// Make sure the distributor is not one of the sub-accounts
+	if cfg.DistributorIndex >= cfg.SubAccountOffset &&
+		cfg.DistributorIndex < cfg.SubAccountOffset+cfg.SubAccounts {
+		return errDistributorOverlap
+	}
+
+	// Make sure the amounts are valid
+	if _, err := parseGenesisAmount(cfg.Amount); err != nil {
+		return err
+	}
+
+	if cfg.DistributorAmount != "" {
+		if _, err := parseGenesisAmount(cfg.DistributorAmount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseGenesisAmount parses the genesis funds.
+// Plain amounts are taken to be in ugnot
+func parseGenesisAmount(amount string) (std.Coins, error) {
+	if value, err := strconv.ParseInt(amount, 10, 64); err == nil {
+		if value < 1 {
+			return nil, errInvalidAmount
+		}
+
+		return std.NewCoins(std.NewCoin(common.Denomination, value)), nil
+	}
+
+	coins, err := std.ParseCoins(amount)
+	if err != nil || coins.Empty() {
+		return nil, errInvalidAmount
+	}
+
+	return coins, nil
+}
+
+// GenerateGenesisBalances writes out the genesis balances snippet
+// that funds the distributor and sub-accounts, in the gno.land
+// genesis balances format (<address>=<coins>)
+func GenerateGenesisBalances(cfg *GenesisConfig, w io.Writer) error {
+	amount, err := parseGenesisAmount(cfg.Amount)
+	if err != nil {
+		return err
+	}
+
+	distributorAmount := amount
+
+	if cfg.DistributorAmount != "" {
+		if distributorAmount, err = parseGenesisAmount(cfg.DistributorAmount); err != nil {
+			return err
+		}
+	}
+
+	kb := keys.NewInMemory()
+
+	deriveAddress := func(index uint64) (string, error) {
+		return deriveGenesisAddress(kb, cfg.Mnemonic, index)
+	}
+
+	if _, err := fmt.Fprintf(
+		w,
+		"# supernova genesis balances (%d sub-accounts, use with -assume-genesis-funded)\n",
+		cfg.SubAccounts,
+	); err != nil {
+		return fmt.Errorf("unable to write genesis balances, %w", err)
+	}
+
+	distributor, err := deriveAddress(cfg.DistributorIndex)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(
+		w,
+		"%s=%s # supernova distributor (index %d)\n",
+		distributor,
+		distributorAmount,
+		cfg.DistributorIndex,
+	); err != nil {
+		return fmt.Errorf("unable to write genesis balances, %w", err)
+	}
+
+	for i := cfg.SubAccountOffset; i < cfg.SubAccountOffset+cfg.SubAccounts; i++ {
+		address, err := deriveAddress(i)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s # supernova sub-account %d\n", address, amount, i); err != nil {
+			return fmt.Errorf("unable to write genesis balances, %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deriveGenesisAddress derives the address of the account
+// at the given index, using the keybase
+func deriveGenesisAddress(kb keys.Keybase, mnemonic string, index uint64) (string, error) {
+	info, err := kb.CreateAccount(
+		fmt.Sprintf("%s%d", common.KeybasePrefix, index),
+		mnemonic,
+		"",
+		common.EncryptPassword,
+		uint32(0),
+		uint32(index),
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to create account with keybase, %w", err)
+	}
+
+	return info.GetAddress().String(), nil
+}
@@ -0,0 +1,219 @@
+// Package history is the append-only local index of completed runs,
+// used for querying the run results over time
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/supernova/results"
+	bolt "go.etcd.io/bbolt"
+)
+
+const openTimeout = 5 * time.Second
+
+var (
+	runsBucket = []byte("runs") // time + run ID -> entry
+	idsBucket  = []byte("ids")  // run ID -> runs key
+
+	errDuplicateRun = errors.New("run is already in the history")
+	errRunNotFound  = errors.New("run not found in the history")
+
+	errNoResults       = errors.New("the run results were not saved")
+	errResultsMismatch = errors.New("results file holds a different run")
+)
+
+// Entry is the summary of a single completed run
+type Entry struct {
+	RunID   string    `json:"runId"`
+	Time    time.Time `json:"time"`
+	Mode    string    `json:"mode"`
+	ChainID string    `json:"chainId"`
+	Label   string    `json:"label,omitempty"`
+	Results string    `json:"results,omitempty"` // the results file, if saved
+
+	Transactions uint64 `json:"transactions"`
+	AverageTPS   int    `json:"averageTPS"`
+	CommittedTxs int    `json:"committedTransactions"`
+	LostTxs      int    `json:"lostTransactions"`
+}
+
+// Filter narrows down the history entries.
+// Empty fields match all entries
+type Filter struct {
+	Mode    string
+	ChainID string
+	Label   string
+
+	Since time.Time // inclusive
+	Until time.Time // exclusive
+}
+
+// Matches returns true if the entry matches the filter
+func (f Filter) Matches(e *Entry) bool {
+	switch {
+	case f.Mode != "" && e.Mode != f.Mode:
+		return false
+	case f.ChainID != "" && e.ChainID != f.ChainID:
+		return false
+	case f.Label != "" && e.Label != f.Label:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	default:
+		return true
+	}
+}
+
+// LoadResults loads the results file of the entry, and checks it was
+// not overwritten by a later run, saved to the same output path
+func (e *Entry) LoadResults() (*results.Results, error) {
+	if e.Results == "" {
+		return nil, errNoResults
+	}
+
+	r, err := results.Load(e.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.RunID() != e.RunID {
+		return nil, fmt.Errorf("%w: %s (%s)", errResultsMismatch, e.Results, r.RunID())
+	}
+
+	return r, nil
+}
+
+// DB is the run history database, backed by a single bbolt file
+type DB struct {
+	db *bolt.DB
+}
+
+// Open opens (or creates) the history database at the given path
+func Open(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create history directory, %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open history, %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{runsBucket, idsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("unable to initialize history, %w", err)
+	}
+
+	return &DB{
+		db: db,
+	}, nil
+}
+
+// Close closes the history database
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Append appends the run entry to the history.
+// Entries are never overwritten, so each run can only be appended once
+func (d *DB) Append(e *Entry) error {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to marshal history entry, %w", err)
+	}
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		ids := tx.Bucket(idsBucket)
+
+		if ids.Get([]byte(e.RunID)) != nil {
+			return fmt.Errorf("%w: %s", errDuplicateRun, e.RunID)
+		}
+
+		key := entryKey(e)
+
+		if err := tx.Bucket(runsBucket).Put(key, value); err != nil {
+			return fmt.Errorf("unable to write history entry, %w", err)
+		}
+
+		return ids.Put([]byte(e.RunID), key)
+	})
+}
+
+// Get returns the history entry for the given run
+func (d *DB) Get(runID string) (*Entry, error) {
+	var e *Entry
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket(idsBucket).Get([]byte(runID))
+		if key == nil {
+			return fmt.Errorf("%w: %s", errRunNotFound, runID)
+		}
+
+		var err error
+
+		e, err = decodeEntry(tx.Bucket(runsBucket).Get(key))
+
+		return err
+	})
+
+	return e, err
+}
+
+// Query returns the history entries matching the filter, ordered by time
+func (d *DB) Query(filter Filter) ([]*Entry, error) {
+	entries := make([]*Entry, 0)
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).ForEach(func(_, value []byte) error {
+			e, err := decodeEntry(value)
+			if err != nil {
+				return err
+			}
+
+			if filter.Matches(e) {
+				entries = append(entries, e)
+			}
+
+			return nil
+		})
+	})
+
+	return entries, err
+}
+
+// entryKey returns the time ordered key of the entry
+func entryKey(e *Entry) []byte {
+	key := make([]byte, 8, 8+len(e.RunID))
+
+	binary.BigEndian.PutUint64(key, uint64(e.Time.UnixNano()))
+
+	return append(key, e.RunID...)
+}
+
+// decodeEntry decodes the stored history entry
+func decodeEntry(value []byte) (*Entry, error) {
+	var e Entry
+	if err := json.Unmarshal(value, &e); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal history entry, %w", err)
+	}
+
+	return &e, nil
+}
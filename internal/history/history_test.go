@@ -0,0 +1,183 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestDB opens a history database in a temporary directory
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "history", "history.db"))
+	if err != nil {
+		t.Fatalf("unable to open history, %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
+
+// generateEntries generates daily history entries with the given TPS values
+func generateEntries(mode string, tps ...int) []*Entry {
+	var (
+		start   = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		entries = make([]*Entry, 0, len(tps))
+	)
+
+	for index, value := range tps {
+		entries = append(entries, &Entry{
+			RunID:      fmt.Sprintf("%s-%d", mode, index),
+			Time:       start.Add(time.Duration(index) * 24 * time.Hour),
+			Mode:       mode,
+			ChainID:    "dev",
+			AverageTPS: value,
+		})
+	}
+
+	return entries
+}
+
+func TestHistory_Append(t *testing.T) {
+	t.Parallel()
+
+	t.Run("query with filters", func(t *testing.T) {
+		t.Parallel()
+
+		db := openTestDB(t)
+
+		// Append the entries out of order
+		entries := append(generateEntries("REALM_CALL", 10, 20, 30), generateEntries("REALM_DEPLOYMENT", 5)...)
+		for _, index := range []int{2, 0, 3, 1} {
+			if err := db.Append(entries[index]); err != nil {
+				t.Fatalf("unable to append entry, %v", err)
+			}
+		}
+
+		all, err := db.Query(Filter{})
+		if err != nil {
+			t.Fatalf("unable to query history, %v", err)
+		}
+
+		if !assert.Len(t, all, 4) {
+			return
+		}
+
+		// Make sure the entries are ordered by time
+		for index := 1; index < len(all); index++ {
+			assert.False(t, all[index].Time.Before(all[index-1].Time))
+		}
+
+		calls, err := db.Query(Filter{
+			Mode:  "REALM_CALL",
+			Since: entries[1].Time,
+		})
+		if err != nil {
+			t.Fatalf("unable to query history, %v", err)
+		}
+
+		if !assert.Len(t, calls, 2) {
+			return
+		}
+
+		assert.Equal(t, "REALM_CALL-1", calls[0].RunID)
+		assert.Equal(t, "REALM_CALL-2", calls[1].RunID)
+
+		none, err := db.Query(Filter{ChainID: "test"})
+		if err != nil {
+			t.Fatalf("unable to query history, %v", err)
+		}
+
+		assert.Empty(t, none)
+	})
+
+	t.Run("append only", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			db    = openTestDB(t)
+			entry = generateEntries("REALM_CALL", 10)[0]
+		)
+
+		if err := db.Append(entry); err != nil {
+			t.Fatalf("unable to append entry, %v", err)
+		}
+
+		assert.ErrorIs(t, db.Append(entry), errDuplicateRun)
+
+		stored, err := db.Get(entry.RunID)
+		if err != nil {
+			t.Fatalf("unable to get entry, %v", err)
+		}
+
+		assert.Equal(t, entry.AverageTPS, stored.AverageTPS)
+
+		_, err = db.Get("missing")
+		assert.ErrorIs(t, err, errRunNotFound)
+	})
+}
+
+func TestHistory_Trend(t *testing.T) {
+	t.Parallel()
+
+	trend := NewTrend(generateEntries("REALM_CALL", 100, 110, 120, 130))
+
+	if !assert.Len(t, trend.Points, 4) {
+		return
+	}
+
+	assert.Equal(t, float64(0), trend.Points[0].ChangePercent)
+	assert.InDelta(t, 10, trend.Points[1].ChangePercent, 1e-9)
+	assert.InDelta(t, 115, trend.MeanTPS, 1e-9)
+	assert.InDelta(t, 30, trend.ChangePercent, 1e-9)
+	assert.InDelta(t, 10, trend.Slope, 1e-9)
+
+	assert.Empty(t, NewTrend(nil).Points)
+}
+
+func TestEntry_LoadResults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path    = filepath.Join(t.TempDir(), "results.json")
+		content = `{"schemaVersion": 1, "runId": "run-1", "committedTransactions": 10}`
+	)
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write results, %v", err)
+	}
+
+	t.Run("matching run", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := (&Entry{RunID: "run-1", Results: path}).LoadResults()
+		require.NoError(t, err)
+
+		assert.Equal(t, 10, r.CommittedTxs())
+	})
+
+	t.Run("overwritten results", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&Entry{RunID: "run-0", Results: path}).LoadResults()
+
+		assert.ErrorIs(t, err, errResultsMismatch)
+	})
+
+	t.Run("results not saved", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&Entry{RunID: "run-1"}).LoadResults()
+
+		assert.ErrorIs(t, err, errNoResults)
+	})
+}
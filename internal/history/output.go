@@ -0,0 +1,111 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// DisplayEntries writes the history entries table to the given writer
+func DisplayEntries(w io.Writer, entries []*Entry) {
+	fmt.Fprintln(w, "\n🗂️ Run History 🗂️")
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No runs found")
+
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Time\tRun ID\tMode\tChain ID\tLabel\tTransactions\tTPS\tLost")
+
+	for _, e := range entries {
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+			e.Time.Format(time.RFC3339),
+			e.RunID,
+			e.Mode,
+			e.ChainID,
+			orDash(e.Label),
+			e.Transactions,
+			e.AverageTPS,
+			e.LostTxs,
+		)
+	}
+
+	_ = tw.Flush()
+}
+
+// DisplayEntry writes the single history entry to the given writer
+func DisplayEntry(w io.Writer, e *Entry) {
+	fmt.Fprintf(w, "\n🗂️ Run %s 🗂️\n", e.RunID)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Time\t%s\n", e.Time.Format(time.RFC3339))
+	fmt.Fprintf(tw, "Mode\t%s\n", e.Mode)
+	fmt.Fprintf(tw, "Chain ID\t%s\n", e.ChainID)
+	fmt.Fprintf(tw, "Label\t%s\n", orDash(e.Label))
+	fmt.Fprintf(tw, "Transactions\t%d\n", e.Transactions)
+	fmt.Fprintf(tw, "TPS\t%d\n", e.AverageTPS)
+	fmt.Fprintf(tw, "Committed\t%d\n", e.CommittedTxs)
+	fmt.Fprintf(tw, "Lost\t%d\n", e.LostTxs)
+	fmt.Fprintf(tw, "Results\t%s\n", orDash(e.Results))
+
+	_ = tw.Flush()
+}
+
+// Display writes the trend table to the given writer
+func (t *Trend) Display(w io.Writer) {
+	fmt.Fprintln(w, "\n📈 TPS Trend 📈")
+
+	if len(t.Points) == 0 {
+		fmt.Fprintln(w, "No runs found")
+
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Time\tRun ID\tLabel\tTPS\tChange")
+
+	for index, point := range t.Points {
+		change := "-"
+		if index > 0 {
+			change = fmt.Sprintf("%+.2f%%", point.ChangePercent)
+		}
+
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%d\t%s\n",
+			point.Time.Format(time.RFC3339),
+			point.RunID,
+			orDash(point.Label),
+			point.TPS,
+			change,
+		)
+	}
+
+	_ = tw.Flush()
+
+	fmt.Fprintf(
+		w,
+		"\nRuns: %d, mean TPS: %.1f, overall change: %+.2f%%, slope: %+.2f TPS per run\n",
+		len(t.Points),
+		t.MeanTPS,
+		t.ChangePercent,
+		t.Slope,
+	)
+}
+
+// orDash returns the value, or a dash if it is empty
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
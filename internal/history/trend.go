@@ -0,0 +1,85 @@
+package history
+
+import (
+	"time"
+)
+
+// Trend is the TPS trend of the history entries, ordered by time
+type Trend struct {
+	Points []*TrendPoint `json:"points"`
+
+	MeanTPS       float64 `json:"meanTPS"`
+	ChangePercent float64 `json:"changePercent"` // the change from the first to the last run
+	Slope         float64 `json:"slope"`         // the least squares TPS change per run
+}
+
+// TrendPoint is a single run of the trend
+type TrendPoint struct {
+	RunID string    `json:"runId"`
+	Time  time.Time `json:"time"`
+	Label string    `json:"label,omitempty"`
+	TPS   int       `json:"tps"`
+
+	// ChangePercent is the TPS change from the previous run
+	ChangePercent float64 `json:"changePercent"`
+}
+
+// NewTrend computes the TPS trend of the (time ordered) entries
+func NewTrend(entries []*Entry) *Trend {
+	t := &Trend{
+		Points: make([]*TrendPoint, 0, len(entries)),
+	}
+
+	if len(entries) == 0 {
+		return t
+	}
+
+	var (
+		sumX  float64
+		sumY  float64
+		sumXY float64
+		sumXX float64
+	)
+
+	for index, e := range entries {
+		point := &TrendPoint{
+			RunID: e.RunID,
+			Time:  e.Time,
+			Label: e.Label,
+			TPS:   e.AverageTPS,
+		}
+
+		if index > 0 {
+			point.ChangePercent = changePercent(entries[index-1].AverageTPS, e.AverageTPS)
+		}
+
+		t.Points = append(t.Points, point)
+
+		x, y := float64(index), float64(e.AverageTPS)
+
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(entries))
+
+	t.MeanTPS = sumY / n
+	t.ChangePercent = changePercent(entries[0].AverageTPS, entries[len(entries)-1].AverageTPS)
+
+	if denominator := n*sumXX - sumX*sumX; denominator != 0 {
+		t.Slope = (n*sumXY - sumX*sumY) / denominator
+	}
+
+	return t
+}
+
+// changePercent returns the relative change from the previous to the current value
+func changePercent(previous, current int) float64 {
+	if previous == 0 {
+		return 0
+	}
+
+	return float64(current-previous) / float64(previous) * 100
+}
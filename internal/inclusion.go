@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/inclusion"
+)
+
+var errUnverifiedCommits = errors.New("inclusion proof verification failed, the reported commits are not credible")
+
+// verifyInclusion samples the committed run transactions (tx hash -> commit height),
+// and verifies their inclusion proofs against the validator signed block headers
+func (p *Pipeline) verifyInclusion(heights map[string]int64) *collector.InclusionResult {
+	var (
+		strategy = inclusion.Strategy(p.cfg.ProofStrategy)
+		seed     = int64(p.cfg.Seed)
+	)
+
+	// Random samples differ across runs, unless the run is seeded
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	fmt.Printf("\n🔏 Verifying Inclusion Proofs 🔏\n\n")
+
+	samples := inclusion.Sample(heights, int(p.cfg.ProofSamples), strategy, seed)
+
+	return inclusion.NewVerifier(p.cli, p.cfg.ChainID).Verify(samples, strategy, int(p.cfg.ProofSamples))
+}
@@ -0,0 +1,41 @@
+package inclusion
+
+import (
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+)
+
+type (
+	getBlockDelegate      func(*int64) (*core_types.ResultBlock, error)
+	getCommitDelegate     func(*int64) (*core_types.ResultCommit, error)
+	getValidatorsDelegate func(*int64) (*core_types.ResultValidators, error)
+)
+
+type mockClient struct {
+	getBlockFn      getBlockDelegate
+	getCommitFn     getCommitDelegate
+	getValidatorsFn getValidatorsDelegate
+}
+
+func (m *mockClient) GetBlock(height *int64) (*core_types.ResultBlock, error) {
+	if m.getBlockFn != nil {
+		return m.getBlockFn(height)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetCommit(height *int64) (*core_types.ResultCommit, error) {
+	if m.getCommitFn != nil {
+		return m.getCommitFn(height)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetValidators(height *int64) (*core_types.ResultValidators, error) {
+	if m.getValidatorsFn != nil {
+		return m.getValidatorsFn(height)
+	}
+
+	return nil, nil
+}
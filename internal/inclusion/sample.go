@@ -0,0 +1,93 @@
+package inclusion
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Strategy is the committed transaction sampling strategy
+type Strategy string
+
+const (
+	// StrategyRandom samples the committed transactions at random (seeded)
+	StrategyRandom Strategy = "random"
+
+	// StrategySpread samples the committed transactions evenly
+	// spaced over the commit order, from the first to the last block
+	StrategySpread Strategy = "spread"
+)
+
+// Strategies are the supported sampling strategies
+var Strategies = []Strategy{StrategyRandom, StrategySpread}
+
+// IsValid returns true if the strategy is supported
+func (s Strategy) IsValid() bool {
+	for _, strategy := range Strategies {
+		if s == strategy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Commit is a single committed transaction
+type Commit struct {
+	Hash   []byte
+	Height int64
+}
+
+// Sample samples up to k of the committed transactions (tx hash -> commit height),
+// in commit order. Random samples only depend on the seed
+func Sample(heights map[string]int64, k int, strategy Strategy, seed int64) []Commit {
+	commits := make([]Commit, 0, len(heights))
+
+	for hash, height := range heights {
+		commits = append(commits, Commit{
+			Hash:   []byte(hash),
+			Height: height,
+		})
+	}
+
+	// Order the commits, so the sampling is deterministic
+	sort.Slice(commits, func(i, j int) bool {
+		if commits[i].Height != commits[j].Height {
+			return commits[i].Height < commits[j].Height
+		}
+
+		return string(commits[i].Hash) < string(commits[j].Hash)
+	})
+
+	if k <= 0 {
+		return nil
+	}
+
+	if k >= len(commits) {
+		return commits
+	}
+
+	picked := make([]int, 0, k)
+
+	switch strategy {
+	case StrategySpread:
+		step := 0.0
+		if k > 1 {
+			step = float64(len(commits)-1) / float64(k-1)
+		}
+
+		for i := 0; i < k; i++ {
+			picked = append(picked, int(float64(i)*step+0.5))
+		}
+	default:
+		picked = rand.New(rand.NewSource(seed)).Perm(len(commits))[:k] //nolint:gosec // not used for security
+
+		sort.Ints(picked)
+	}
+
+	samples := make([]Commit, 0, k)
+	for _, index := range picked {
+		samples = append(samples, commits[index])
+	}
+
+	return samples
+}
@@ -0,0 +1,61 @@
+package inclusion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generateHeights generates the commit heights of n transactions, 10 per block
+func generateHeights(n int) map[string]int64 {
+	heights := make(map[string]int64, n)
+
+	for i := 0; i < n; i++ {
+		heights[fmt.Sprintf("tx-%03d", i)] = int64(i/10 + 1)
+	}
+
+	return heights
+}
+
+func TestSample_Sample(t *testing.T) {
+	t.Parallel()
+
+	heights := generateHeights(100)
+
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			first  = Sample(heights, 5, StrategyRandom, 42)
+			second = Sample(heights, 5, StrategyRandom, 42)
+		)
+
+		assert.Len(t, first, 5)
+
+		// The samples only depend on the seed
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("spread", func(t *testing.T) {
+		t.Parallel()
+
+		samples := Sample(heights, 5, StrategySpread, 0)
+
+		if len(samples) != 5 {
+			t.Fatalf("invalid number of samples, %d", len(samples))
+		}
+
+		// The samples span the first to the last block
+		assert.Equal(t, "tx-000", string(samples[0].Hash))
+		assert.Equal(t, int64(1), samples[0].Height)
+		assert.Equal(t, "tx-099", string(samples[4].Hash))
+		assert.Equal(t, int64(10), samples[4].Height)
+	})
+
+	t.Run("fewer commits than samples", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Len(t, Sample(generateHeights(3), 5, StrategyRandom, 0), 3)
+	})
+}
@@ -0,0 +1,193 @@
+// Package inclusion samples committed run transactions, and verifies their
+// Merkle inclusion proofs against the validator signed block headers
+package inclusion
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+var (
+	errTxNotInBlock        = errors.New("transaction not found in the block")
+	errHeaderMismatch      = errors.New("block header does not match the signed header")
+	errValidatorsMismatch  = errors.New("validator set does not match the block header")
+	errInvalidInclusion    = errors.New("invalid inclusion proof")
+	errInvalidSignedHeader = errors.New("invalid signed header")
+)
+
+// Client fetches the blocks, and the data needed to verify them
+type Client interface {
+	GetBlock(height *int64) (*core_types.ResultBlock, error)
+	GetCommit(height *int64) (*core_types.ResultCommit, error)
+	GetValidators(height *int64) (*core_types.ResultValidators, error)
+}
+
+// Verifier verifies the inclusion of the sampled committed transactions.
+// The proofs are built from the fetched block transactions, and verified against
+// the block header data hash, with the header signed by +2/3 of the validator set
+type Verifier struct {
+	cli     Client
+	chainID string
+
+	blocks map[int64]*verifiedBlock // height -> verified block, fetched once per height
+}
+
+// verifiedBlock is a block with a verified signed header,
+// or the reason the header could not be verified
+type verifiedBlock struct {
+	block *types.Block
+	hash  []byte
+	err   error
+}
+
+// NewVerifier creates a new inclusion proof verifier
+func NewVerifier(cli Client, chainID string) *Verifier {
+	return &Verifier{
+		cli:     cli,
+		chainID: chainID,
+		blocks:  make(map[int64]*verifiedBlock),
+	}
+}
+
+// Verify verifies the inclusion of each sampled committed transaction
+func (v *Verifier) Verify(samples []Commit, strategy Strategy, requested int) *collector.InclusionResult {
+	result := &collector.InclusionResult{
+		Strategy:  string(strategy),
+		Requested: requested,
+		Samples:   make([]*collector.InclusionSample, 0, len(samples)),
+	}
+
+	for _, commit := range samples {
+		sample := v.verifySample(commit)
+
+		if sample.Verified {
+			result.Verified++
+		} else {
+			result.Failed++
+		}
+
+		result.Samples = append(result.Samples, sample)
+	}
+
+	result.Credible = result.Failed == 0
+
+	return result
+}
+
+// verifySample verifies the inclusion proof of a single committed transaction
+func (v *Verifier) verifySample(commit Commit) *collector.InclusionSample {
+	sample := &collector.InclusionSample{
+		Hash:   hex.EncodeToString(commit.Hash),
+		Height: commit.Height,
+	}
+
+	verified := v.verifiedBlock(commit.Height)
+	if verified.err != nil {
+		sample.Error = verified.err.Error()
+
+		return sample
+	}
+
+	var (
+		block = verified.block
+		index = block.Txs.IndexByHash(commit.Hash)
+	)
+
+	sample.DataHash = hex.EncodeToString(block.DataHash)
+	sample.BlockHash = hex.EncodeToString(verified.hash)
+
+	if index < 0 {
+		sample.Error = errTxNotInBlock.Error()
+
+		return sample
+	}
+
+	proof := block.Txs.Proof(index)
+
+	sample.Index = proof.Proof.Index
+	sample.Total = proof.Proof.Total
+	sample.LeafHash = hex.EncodeToString(proof.Leaf())
+
+	for _, aunt := range proof.Proof.Aunts {
+		sample.Aunts = append(sample.Aunts, hex.EncodeToString(aunt))
+	}
+
+	if err := proof.Validate(block.DataHash); err != nil {
+		sample.Error = fmt.Sprintf("%v, %v", errInvalidInclusion, err)
+
+		return sample
+	}
+
+	sample.Verified = true
+
+	return sample
+}
+
+// verifiedBlock fetches the block at the height, and verifies its header
+// is the one signed by the validator set. Blocks are fetched once
+func (v *Verifier) verifiedBlock(height int64) *verifiedBlock {
+	if verified, ok := v.blocks[height]; ok {
+		return verified
+	}
+
+	verified := &verifiedBlock{}
+	verified.block, verified.hash, verified.err = v.fetchBlock(height)
+
+	v.blocks[height] = verified
+
+	return verified
+}
+
+// fetchBlock fetches the block at the height, with its signed header and
+// validator set, and verifies the block header is signed by +2/3 of the set
+func (v *Verifier) fetchBlock(height int64) (*types.Block, []byte, error) {
+	blockResult, err := v.cli.GetBlock(&height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch block, %w", err)
+	}
+
+	commitResult, err := v.cli.GetCommit(&height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch commit, %w", err)
+	}
+
+	validatorsResult, err := v.cli.GetValidators(&height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch validators, %w", err)
+	}
+
+	var (
+		block  = blockResult.Block
+		signed = commitResult.SignedHeader
+	)
+
+	if block == nil || signed.Header == nil || signed.Commit == nil {
+		return nil, nil, errInvalidSignedHeader
+	}
+
+	if err := signed.ValidateBasic(v.chainID); err != nil {
+		return nil, nil, fmt.Errorf("%w, %v", errInvalidSignedHeader, err)
+	}
+
+	hash := block.Header.Hash()
+	if !bytes.Equal(hash, signed.Header.Hash()) {
+		return nil, nil, errHeaderMismatch
+	}
+
+	validators := types.NewValidatorSet(validatorsResult.Validators)
+	if !bytes.Equal(validators.Hash(), block.ValidatorsHash) {
+		return nil, nil, errValidatorsMismatch
+	}
+
+	if err := validators.VerifyCommit(v.chainID, signed.Commit.BlockID, height, signed.Commit); err != nil {
+		return nil, nil, fmt.Errorf("%w, %v", errInvalidSignedHeader, err)
+	}
+
+	return block, hash, nil
+}
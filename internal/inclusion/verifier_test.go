@@ -0,0 +1,164 @@
+package inclusion
+
+import (
+	"testing"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChainID = "dev"
+
+// signedChain is a single block, with its header signed by the validator set
+type signedChain struct {
+	block      *core_types.ResultBlock
+	commit     *core_types.ResultCommit
+	validators *core_types.ResultValidators
+}
+
+// newSignedChain creates a block with the given transactions,
+// and the commit of the block header by a random validator set
+func newSignedChain(t *testing.T, height int64, txs types.Txs) *signedChain {
+	t.Helper()
+
+	vals, privs := types.RandValidatorSet(4, 10)
+
+	block := types.MakeBlock(height, txs, nil)
+	block.ChainID = testChainID
+	block.ValidatorsHash = vals.Hash()
+
+	var (
+		blockID = types.BlockID{Hash: block.Header.Hash()}
+		voteSet = types.NewVoteSet(testChainID, height, 0, types.PrecommitType, vals)
+	)
+
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privs)
+	if err != nil {
+		t.Fatalf("unable to make commit, %v", err)
+	}
+
+	header := block.Header
+
+	return &signedChain{
+		block: &core_types.ResultBlock{
+			Block: block,
+		},
+		commit: &core_types.ResultCommit{
+			SignedHeader: types.SignedHeader{
+				Header: &header,
+				Commit: commit,
+			},
+		},
+		validators: &core_types.ResultValidators{
+			BlockHeight: height,
+			Validators:  vals.Validators,
+		},
+	}
+}
+
+// client returns the mock client serving the signed chain
+func (c *signedChain) client() *mockClient {
+	return &mockClient{
+		getBlockFn: func(_ *int64) (*core_types.ResultBlock, error) {
+			return c.block, nil
+		},
+		getCommitFn: func(_ *int64) (*core_types.ResultCommit, error) {
+			return c.commit, nil
+		},
+		getValidatorsFn: func(_ *int64) (*core_types.ResultValidators, error) {
+			return c.validators, nil
+		},
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	txs := types.Txs{
+		types.Tx("tx 1"),
+		types.Tx("tx 2"),
+		types.Tx("tx 3"),
+	}
+
+	t.Run("valid proofs", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain   = newSignedChain(t, 5, txs)
+			samples = []Commit{
+				{Hash: txs[0].Hash(), Height: 5},
+				{Hash: txs[2].Hash(), Height: 5},
+			}
+		)
+
+		result := NewVerifier(chain.client(), testChainID).Verify(samples, StrategyRandom, 2)
+
+		assert.True(t, result.Credible)
+		assert.Equal(t, 2, result.Verified)
+		assert.Equal(t, 0, result.Failed)
+
+		if len(result.Samples) != 2 {
+			t.Fatalf("invalid number of samples, %d", len(result.Samples))
+		}
+
+		assert.Equal(t, 2, result.Samples[1].Index)
+		assert.Equal(t, 3, result.Samples[1].Total)
+		assert.NotEmpty(t, result.Samples[1].Aunts)
+	})
+
+	t.Run("transaction not in block", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newSignedChain(t, 5, txs)
+
+		result := NewVerifier(chain.client(), testChainID).Verify(
+			[]Commit{{Hash: types.Tx("missing").Hash(), Height: 5}},
+			StrategyRandom,
+			1,
+		)
+
+		assert.False(t, result.Credible)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, errTxNotInBlock.Error(), result.Samples[0].Error)
+	})
+
+	t.Run("tampered transactions", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newSignedChain(t, 5, txs)
+
+		// The served block transactions no longer match the signed data hash
+		chain.block.Block.Txs = types.Txs{txs[0], txs[1], types.Tx("forged")}
+
+		result := NewVerifier(chain.client(), testChainID).Verify(
+			[]Commit{{Hash: txs[0].Hash(), Height: 5}},
+			StrategyRandom,
+			1,
+		)
+
+		assert.False(t, result.Credible)
+		assert.Contains(t, result.Samples[0].Error, errInvalidInclusion.Error())
+	})
+
+	t.Run("unsigned header", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain = newSignedChain(t, 5, txs)
+			other = newSignedChain(t, 5, txs)
+		)
+
+		// The header is signed by a different validator set
+		chain.validators = other.validators
+
+		result := NewVerifier(chain.client(), testChainID).Verify(
+			[]Commit{{Hash: txs[0].Hash(), Height: 5}},
+			StrategyRandom,
+			1,
+		)
+
+		assert.False(t, result.Credible)
+		assert.Equal(t, errValidatorsMismatch.Error(), result.Samples[0].Error)
+	})
+}
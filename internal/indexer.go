@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/preflight"
+)
+
+var errTxIndexDisabled = errors.New("the node tx indexer is disabled")
+
+// checkIndexer detects the tx indexer availability of the node, and disables the
+// features depending on it if it is off, instead of reporting the txs as not found.
+// Explicitly requested dependent features fail the run before anything is sent
+func (p *Pipeline) checkIndexer() error {
+	status, source := preflight.DetectIndexer(p.cli)
+
+	p.indexer = &collector.IndexerResult{
+		Status: string(status),
+		Source: source,
+	}
+
+	if status == preflight.IndexerUnknown {
+		fmt.Printf("⚠️ Unable to detect the node tx indexer, tx lookups may report committed txs as missing\n")
+
+		return nil
+	}
+
+	features := p.txIndexFeatures()
+	if status != preflight.IndexerDisabled || len(features) == 0 {
+		return nil
+	}
+
+	if p.cfg.RequireTxIndex {
+		return fmt.Errorf("%w, and required by the %s", errTxIndexDisabled, strings.Join(features, ", "))
+	}
+
+	fmt.Printf(
+		"⚠️ The node tx indexer is disabled (%s), disabling the %s\n",
+		source,
+		strings.Join(features, ", "),
+	)
+
+	p.indexer.Disabled = features
+
+	return nil
+}
+
+// txIndexFeatures returns the enabled run features that depend on the node tx indexer
+func (p *Pipeline) txIndexFeatures() []string {
+	features := make([]string, 0, 1)
+
+	if p.cfg.BackfillRate > 0 {
+		features = append(features, integrationBackfill)
+	}
+
+	return features
+}
+
+// txIndexDisabled returns a flag indicating if the given
+// feature was disabled, since the node tx indexer is off
+func (p *Pipeline) txIndexDisabled(feature string) bool {
+	if p.indexer == nil {
+		return false
+	}
+
+	for _, disabled := range p.indexer.Disabled {
+		if disabled == feature {
+			return true
+		}
+	}
+
+	return false
+}
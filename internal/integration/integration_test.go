@@ -0,0 +1,175 @@
+// Package integration contains the opt-in integration tests,
+// executed against a local (dockerized) gno node.
+// The tests are skipped unless SUPERNOVA_IT=1 is set
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/supernova/internal"
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/client"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/preflight"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/internal/signer"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateAccounts derives the genesis distributor (index 0),
+// and the given number of sub-accounts
+func generateAccounts(t *testing.T, kb keys.Keybase, count int) []keys.Info {
+	t.Helper()
+
+	accounts := make([]keys.Info, 0, count+1)
+
+	for i := 0; i <= count; i++ {
+		info, err := kb.CreateAccount(
+			fmt.Sprintf("%s%d", common.KeybasePrefix, i),
+			genesisMnemonic,
+			"",
+			common.EncryptPassword,
+			uint32(0),
+			uint32(i),
+		)
+		if err != nil {
+			t.Fatalf("unable to create account, %v", err)
+		}
+
+		accounts = append(accounts, info)
+	}
+
+	return accounts
+}
+
+func TestIntegration_Distribute(t *testing.T) {
+	skipUnlessEnabled(t)
+
+	var (
+		url = startNode(t)
+		cli = client.NewHTTPClient(url, 0)
+		kb  = keys.NewInMemory()
+
+		numAccounts  = 5
+		transactions = uint64(20)
+	)
+
+	accounts := generateAccounts(t, kb, numAccounts)
+
+	d := distributor.NewDistributor(cli, signer.NewKeybaseSigner(kb, chainID))
+
+	runAccounts, err := d.Distribute(accounts, transactions)
+	if err != nil {
+		t.Fatalf("unable to distribute funds, %v", err)
+	}
+
+	assert.Len(t, runAccounts, numAccounts)
+
+	// Make sure each sub-account holds enough funds for its share of the run
+	costs := d.CostReport()
+
+	for _, account := range runAccounts {
+		nodeAccount, err := cli.GetAccount(account.GetAddress().String())
+		if err != nil {
+			t.Fatalf("unable to fetch sub-account, %v", err)
+		}
+
+		assert.GreaterOrEqual(t, nodeAccount.Coins.AmountOf(common.Denomination), costs.AccountCost)
+	}
+
+	// Make sure a second distribution is a no-op
+	distributorBefore, err := cli.GetAccount(accounts[0].GetAddress().String())
+	if err != nil {
+		t.Fatalf("unable to fetch distributor, %v", err)
+	}
+
+	if _, err := d.Distribute(accounts, transactions); err != nil {
+		t.Fatalf("unable to distribute funds, %v", err)
+	}
+
+	distributorAfter, err := cli.GetAccount(accounts[0].GetAddress().String())
+	if err != nil {
+		t.Fatalf("unable to fetch distributor, %v", err)
+	}
+
+	assert.Equal(t, distributorBefore.Coins, distributorAfter.Coins)
+}
+
+func TestIntegration_RealmCallRun(t *testing.T) {
+	skipUnlessEnabled(t)
+	moveToRoot(t)
+
+	var (
+		url    = startNode(t)
+		output = filepath.Join(t.TempDir(), "results.json")
+
+		transactions = uint64(10)
+	)
+
+	cfg := &internal.Config{
+		URL:      url,
+		ChainID:  chainID,
+		Mnemonic: genesisMnemonic,
+		Mode:     runtime.RealmCall.String(),
+		Output:   output,
+
+		SubAccounts:  3,
+		Transactions: transactions,
+		BatchSize:    5,
+		FundingBatch: 100,
+
+		SubAccountOffset: 1,
+
+		CompletionThreshold: 1,
+		CompletionGrace:     30 * time.Second,
+
+		PendingTxPolicy:  string(preflight.PendingWait),
+		PendingTxWait:    time.Minute,
+		EndpointAffinity: string(batcher.AffinityRoundRobin),
+		SpoolDir:         t.TempDir(),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	if err := internal.NewPipeline(cfg).Execute(); err != nil {
+		t.Fatalf("unable to execute the run, %v", err)
+	}
+
+	// Make sure the results file holds the complete run
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("unable to read results, %v", err)
+	}
+
+	var result collector.RunResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("unable to unmarshal results, %v", err)
+	}
+
+	assert.NotEmpty(t, result.RunID)
+	assert.Equal(t, int(transactions), result.CommittedTxs)
+	assert.Equal(t, 0, result.LostTxs)
+	assert.NotEmpty(t, result.Blocks)
+	assert.NotEmpty(t, result.Phases)
+
+	if assert.NotNil(t, result.Costs) {
+		assert.Equal(t, uint32(0), result.Costs.DistributorIndex)
+	}
+
+	committed := int64(0)
+	for _, block := range result.Blocks {
+		committed += block.Transactions
+	}
+
+	assert.Equal(t, int64(transactions), committed)
+}
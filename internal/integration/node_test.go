@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/client"
+)
+
+const (
+	// gnoModule is the gno module the node image is built from.
+	// The node version is pinned to the module version in go.mod,
+	// so the suite always runs against the node supernova is built with
+	gnoModule = "github.com/gnolang/gno"
+
+	// imageTarget is the gno Dockerfile target, which contains
+	// the node binary and the genesis files
+	imageTarget = "all"
+
+	// genesisMnemonic is the mnemonic of the test1 account,
+	// which is funded in the local devnet genesis
+	genesisMnemonic = "source bonus chronic canvas draft south burst lottery vacant surface solve " +
+		"popular case indicate oppose farm nothing bullet exhibit title speed wink action roast"
+
+	chainID = "dev"
+
+	nodeStartTimeout = 2 * time.Minute
+)
+
+// nodeCommand initializes the node, exposes the RPC outside
+// the container, and starts the node
+const nodeCommand = `gnoland --skip-start && ` +
+	`sed -i 's#laddr = "tcp://127.0.0.1:26657"#laddr = "tcp://0.0.0.0:26657"#' testdir/config/config.toml && ` +
+	`gnoland`
+
+// skipUnlessEnabled skips the integration tests, unless explicitly enabled
+func skipUnlessEnabled(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("SUPERNOVA_IT") != "1" {
+		t.Skip("integration tests are disabled, set SUPERNOVA_IT=1 to enable them")
+	}
+}
+
+// moveToRoot sets the current working directory to the project root,
+// since the runtimes read the fixed .gno files in ./scripts
+func moveToRoot(t *testing.T) {
+	t.Helper()
+
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("unable to get caller information")
+	}
+
+	if err := os.Chdir(path.Join(path.Dir(filename), "../..")); err != nil {
+		t.Fatalf("unable to change to root dir, %v", err)
+	}
+}
+
+// run executes the command, and returns its trimmed output
+func run(t *testing.T, name string, args ...string) string {
+	t.Helper()
+
+	var (
+		stdout bytes.Buffer
+		stderr bytes.Buffer
+
+		cmd = exec.Command(name, args...)
+	)
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("unable to run %s %s, %v: %s", name, strings.Join(args, " "), err, stderr.String())
+	}
+
+	return strings.TrimSpace(stdout.String())
+}
+
+// buildNodeImage builds the node image from the pinned gno module source,
+// and returns the image name. Existing images are reused
+func buildNodeImage(t *testing.T) string {
+	t.Helper()
+
+	version := run(t, "go", "list", "-m", "-f", "{{.Version}}", gnoModule)
+	image := fmt.Sprintf("supernova-it-gnoland:%s", version)
+
+	if err := exec.Command("docker", "image", "inspect", image).Run(); err == nil {
+		return image
+	}
+
+	moduleDir := run(t, "go", "list", "-m", "-f", "{{.Dir}}", gnoModule)
+
+	t.Logf("building node image %s from %s", image, moduleDir)
+
+	run(t, "docker", "build", "--target", imageTarget, "-t", image, moduleDir)
+
+	return image
+}
+
+// startNode starts a fresh local node container,
+// and returns its JSON-RPC URL once it is ready
+func startNode(t *testing.T) string {
+	t.Helper()
+
+	image := buildNodeImage(t)
+
+	containerID := run(
+		t,
+		"docker", "run", "-d", "--rm",
+		"-p", "127.0.0.1::26657",
+		"--entrypoint", "sh",
+		image,
+		"-c", nodeCommand,
+	)
+
+	t.Cleanup(func() {
+		_ = exec.Command("docker", "rm", "-f", containerID).Run()
+	})
+
+	// Find the host port the RPC is mapped to
+	hostPort := run(t, "docker", "port", containerID, "26657/tcp")
+	url := fmt.Sprintf("http://%s", strings.Split(hostPort, "\n")[0])
+
+	// Wait for the node to start serving requests
+	var (
+		cli      = client.NewHTTPClient(url, 0)
+		deadline = time.Now().Add(nodeStartTimeout)
+	)
+
+	for {
+		if _, err := cli.GetLatestBlockHeight(); err == nil {
+			return url
+		}
+
+		if time.Now().After(deadline) {
+			logs, _ := exec.Command("docker", "logs", containerID).CombinedOutput()
+
+			t.Fatalf("node did not start in %s, logs:\n%s", nodeStartTimeout, logs)
+		}
+
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal/feature"
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+var errNoNodeMetrics = errors.New("every node metrics scrape failed")
+
+// The run integrations, by name
+const (
+	integrationResults     = "results file"
+	integrationInclusion   = "inclusion proofs"
+	integrationArtifacts   = "run artifacts"
+	integrationManifest    = "run manifest"
+	integrationHistory     = "run history"
+	integrationUpload      = "results upload"
+	integrationNodeMetrics = "node metrics"
+	integrationBackfill    = "tx backfill"
+	integrationMint        = "mint summary"
+	integrationAccounts    = "sub-account accounting"
+)
+
+// integrations are the run integrations, each registered with its criticality.
+// The failures of the optional integrations are recorded in the degraded
+// results section, and only fail the run with -strict-integrations
+type integrations struct {
+	registry *feature.Registry
+
+	results   *feature.Integration // the results file is the headline measurement
+	inclusion *feature.Integration // unverified commits are not credible measurements
+
+	artifacts   *feature.Integration
+	manifest    *feature.Integration
+	history     *feature.Integration
+	upload      *feature.Integration
+	nodeMetrics *feature.Integration
+	backfill    *feature.Integration
+	mint        *feature.Integration
+	accounts    *feature.Integration
+}
+
+// newIntegrations registers the run integrations
+func newIntegrations() (*integrations, error) {
+	var (
+		registry = feature.NewRegistry()
+		i        = &integrations{registry: registry}
+	)
+
+	registrations := []struct {
+		integration **feature.Integration
+		name        string
+		criticality feature.Criticality
+	}{
+		{&i.results, integrationResults, feature.Critical},
+		{&i.inclusion, integrationInclusion, feature.Critical},
+		{&i.artifacts, integrationArtifacts, feature.Optional},
+		{&i.manifest, integrationManifest, feature.Optional},
+		{&i.history, integrationHistory, feature.Optional},
+		{&i.upload, integrationUpload, feature.Optional},
+		{&i.nodeMetrics, integrationNodeMetrics, feature.Optional},
+		{&i.backfill, integrationBackfill, feature.Optional},
+		{&i.mint, integrationMint, feature.Optional},
+		{&i.accounts, integrationAccounts, feature.Optional},
+	}
+
+	for _, registration := range registrations {
+		integration, err := registry.Register(registration.name, registration.criticality)
+		if err != nil {
+			return nil, fmt.Errorf("unable to register integration, %w", err)
+		}
+
+		*registration.integration = integration
+	}
+
+	return i, nil
+}
+
+// degrade reports the integration failure, with a degraded run warning for
+// the optional integrations. Critical failures are returned
+func degrade(integration *feature.Integration, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if integration.Criticality() == feature.Optional {
+		fmt.Printf("⚠️ The %s integration failed, the run continues degraded: %v\n", integration.Name(), err)
+	}
+
+	return integration.Fail(err)
+}
+
+// nodeMetricsErr returns the node metrics scraping error, if none of the scrapes succeeded
+func nodeMetricsErr(node *metrics.NodeMetrics) error {
+	if node == nil || len(node.Samples) == 0 {
+		return nil
+	}
+
+	for _, sample := range node.Samples {
+		if !sample.Gap {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w, %s", errNoNodeMetrics, node.URL)
+}
@@ -0,0 +1,137 @@
+// Package lifecycle coordinates the shutdown of the
+// background components started during a run
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the default time a component has to stop
+const DefaultTimeout = 5 * time.Second
+
+var (
+	errShutdownTimeout = errors.New("components did not stop in time")
+	errShutdownFailed  = errors.New("components failed to stop")
+)
+
+// Closer stops a single component
+type Closer func() error
+
+// component is a registered component closer
+type component struct {
+	name    string
+	timeout time.Duration
+	close   Closer
+}
+
+// Manager keeps the closers of the run components,
+// and invokes them in the reverse registration order on shutdown
+type Manager struct {
+	timeout time.Duration
+
+	mux        sync.Mutex
+	components []component
+	shutdown   bool
+}
+
+// NewManager creates a new lifecycle manager, with
+// the given default component stop timeout
+func NewManager(timeout time.Duration) *Manager {
+	return &Manager{
+		timeout: timeout,
+	}
+}
+
+// Register registers the component closer, using the default timeout
+func (m *Manager) Register(name string, closer Closer) {
+	m.RegisterWithTimeout(name, m.timeout, closer)
+}
+
+// RegisterWithTimeout registers the component closer, using the given timeout.
+// Components registered after the shutdown are stopped right away
+func (m *Manager) RegisterWithTimeout(name string, timeout time.Duration, closer Closer) {
+	c := component{
+		name:    name,
+		timeout: timeout,
+		close:   closer,
+	}
+
+	m.mux.Lock()
+
+	if m.shutdown {
+		m.mux.Unlock()
+
+		_ = stop(c)
+
+		return
+	}
+
+	m.components = append(m.components, c)
+	m.mux.Unlock()
+}
+
+// Shutdown stops the registered components, in the reverse registration order.
+// Each component is given its own timeout, and the components that do not stop
+// in time are named in the shutdown log and the returned error.
+// It is safe to call multiple times
+func (m *Manager) Shutdown() error {
+	m.mux.Lock()
+
+	components := m.components
+	m.components = nil
+	m.shutdown = true
+
+	m.mux.Unlock()
+
+	var (
+		stuck  []string
+		failed []string
+	)
+
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+
+		err := stop(c)
+
+		switch {
+		case errors.Is(err, errShutdownTimeout):
+			fmt.Printf("⚠️ Component %s did not stop within %s\n", c.name, c.timeout)
+
+			stuck = append(stuck, c.name)
+		case err != nil:
+			fmt.Printf("⚠️ Component %s failed to stop, %v\n", c.name, err)
+
+			failed = append(failed, fmt.Sprintf("%s (%v)", c.name, err))
+		}
+	}
+
+	if len(stuck) > 0 {
+		return fmt.Errorf("%w: %s", errShutdownTimeout, strings.Join(stuck, ", "))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%w: %s", errShutdownFailed, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// stop invokes the component closer, bounded by the component timeout
+func stop(c component) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(c.timeout):
+		return errShutdownTimeout
+	}
+}
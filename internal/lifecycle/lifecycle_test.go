@@ -0,0 +1,106 @@
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reverse order", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			m     = NewManager(time.Second)
+			order []string
+		)
+
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+
+			m.Register(name, func() error {
+				order = append(order, name)
+
+				return nil
+			})
+		}
+
+		assert.NoError(t, m.Shutdown())
+		assert.Equal(t, []string{"third", "second", "first"}, order)
+
+		// Make sure the components are stopped only once
+		assert.NoError(t, m.Shutdown())
+		assert.Len(t, order, 3)
+	})
+
+	t.Run("stuck component", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			m       = NewManager(time.Second)
+			release = make(chan struct{})
+			stopped = false
+		)
+
+		defer close(release)
+
+		m.Register("healthy", func() error {
+			stopped = true
+
+			return nil
+		})
+
+		m.RegisterWithTimeout("stuck", 10*time.Millisecond, func() error {
+			<-release
+
+			return nil
+		})
+
+		err := m.Shutdown()
+
+		// Make sure the stuck component is named,
+		// and does not block the other components
+		assert.ErrorIs(t, err, errShutdownTimeout)
+		assert.Contains(t, err.Error(), "stuck")
+		assert.NotContains(t, err.Error(), "healthy")
+		assert.True(t, stopped)
+	})
+
+	t.Run("failed component", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewManager(time.Second)
+
+		m.Register("failing", func() error {
+			return errors.New("unable to close")
+		})
+
+		err := m.Shutdown()
+
+		assert.ErrorIs(t, err, errShutdownFailed)
+		assert.Contains(t, err.Error(), "failing")
+	})
+
+	t.Run("registered after shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			m       = NewManager(time.Second)
+			stopped = false
+		)
+
+		assert.NoError(t, m.Shutdown())
+
+		m.Register("late", func() error {
+			stopped = true
+
+			return nil
+		})
+
+		assert.True(t, stopped)
+	})
+}
@@ -0,0 +1,45 @@
+package live
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// metric is a single exposed Prometheus metric
+type metric struct {
+	name  string
+	kind  string // the Prometheus metric type
+	help  string
+	value float64
+}
+
+// Handler returns the Prometheus metrics endpoint handler,
+// exposing the latest live run progress in the text format
+func (m *Monitor) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		writeMetrics(w, m.Snapshot())
+	})
+}
+
+// writeMetrics writes the progress in the Prometheus text format
+func writeMetrics(w io.Writer, p Progress) {
+	metrics := []metric{
+		{"supernova_transactions", "gauge", "The number of run transactions", float64(p.Transactions)},
+		{"supernova_sent_transactions_total", "counter", "The broadcast run transactions", float64(p.Sent)},
+		{"supernova_committed_transactions_total", "counter", "The committed run transactions", float64(p.Committed)},
+		{"supernova_pending_transactions", "gauge", "The broadcast, uncommitted run transactions", float64(p.Pending())},
+		{"supernova_failed_broadcasts_total", "counter", "The failed broadcast requests", float64(p.Failed)},
+		{"supernova_send_rate", "gauge", "The broadcast rate (tx/s) over the latest interval", p.SendRate},
+		{"supernova_commit_rate", "gauge", "The commit rate (tx/s) over the latest interval", p.CommitRate},
+		{"supernova_elapsed_seconds", "gauge", "The time since the dispatch start", p.Elapsed.Seconds()},
+	}
+
+	for _, metric := range metrics {
+		_, _ = fmt.Fprintf(w, "# HELP %s %s\n", metric.name, metric.help)
+		_, _ = fmt.Fprintf(w, "# TYPE %s %s\n", metric.name, metric.kind)
+		_, _ = fmt.Fprintf(w, "%s %g\n", metric.name, metric.value)
+	}
+}
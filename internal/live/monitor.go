@@ -0,0 +1,302 @@
+// Package live reports the progress of the run while it is in progress,
+// as periodic console lines, and as a Prometheus metrics endpoint
+package live
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is the default interval the current rates are sampled at
+const DefaultInterval = 5 * time.Second
+
+// MetricsPath is the path of the Prometheus metrics endpoint
+const MetricsPath = "/metrics"
+
+// Progress is a single snapshot of the live run progress
+type Progress struct {
+	Elapsed time.Duration // the time since the dispatch start
+
+	Transactions int // the number of run transactions
+	Sent         int // the number of broadcast run transactions
+	Committed    int // the number of committed run transactions
+	Failed       int // the number of failed broadcast requests
+
+	SendRate   float64 // the broadcast rate (tx/s) over the latest interval
+	CommitRate float64 // the commit rate (tx/s) over the latest interval
+}
+
+// Pending returns the number of broadcast transactions, not yet committed
+func (p Progress) Pending() int {
+	if p.Committed > p.Sent {
+		return 0
+	}
+
+	return p.Sent - p.Committed
+}
+
+// Monitor keeps the live run progress, and samples the current rates every interval.
+// The updates only touch the in-memory progress, so they never block the broadcast.
+// A nil monitor discards all updates
+type Monitor struct {
+	interval time.Duration
+	console  bool // flag indicating if the progress is printed every interval
+
+	mux      sync.Mutex
+	progress Progress
+
+	dispatchStart time.Time // the start of the transaction dispatch
+	finished      bool      // flag indicating if the run collection is over
+
+	lastSample    time.Time // the time of the previous rate sample
+	lastSent      int       // the sent transactions at the previous rate sample
+	lastCommitted int       // the committed transactions at the previous rate sample
+
+	samples []Progress // the progress at each rate sample, saved with the results
+
+	server *http.Server // the metrics endpoint server, if any
+	addr   string       // the bound metrics endpoint address, if any
+
+	started  bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewMonitor creates a new live progress monitor, that samples the
+// current rates at the given interval, and prints them if console is set
+func NewMonitor(interval time.Duration, console bool) *Monitor {
+	return &Monitor{
+		interval: interval,
+		console:  console,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts the periodic rate sampling
+func (m *Monitor) Start() {
+	if m == nil {
+		return
+	}
+
+	m.started = true
+
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				m.sample(time.Now())
+			}
+		}
+	}()
+}
+
+// Serve serves the Prometheus metrics endpoint on the given address, in the background.
+// The address is bound right away, so an unavailable address is reported to the caller
+func (m *Monitor) Serve(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s, %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, m.Handler())
+
+	m.addr = listener.Addr().String()
+	m.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := m.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("\n⚠️ Live metrics endpoint stopped, %v\n", err)
+		}
+	}()
+
+	fmt.Printf("Serving the live metrics on http://%s%s\n", m.addr, MetricsPath)
+
+	return nil
+}
+
+// Addr returns the bound metrics endpoint address, if served
+func (m *Monitor) Addr() string {
+	return m.addr
+}
+
+// StartDispatch marks the start of the transaction dispatch,
+// the reference point of the elapsed time and rates
+func (m *Monitor) StartDispatch(transactions int) {
+	m.update(func(p *Progress) {
+		*p = Progress{Transactions: transactions}
+
+		now := time.Now()
+
+		m.dispatchStart = now
+		m.lastSample = now
+		m.lastSent = 0
+		m.lastCommitted = 0
+		m.samples = nil
+	})
+}
+
+// SetSent sets the number of broadcast run transactions
+func (m *Monitor) SetSent(sent int) {
+	m.update(func(p *Progress) {
+		p.Sent = sent
+	})
+}
+
+// SetCommitted sets the number of committed run transactions
+func (m *Monitor) SetCommitted(committed int) {
+	m.update(func(p *Progress) {
+		p.Committed = committed
+	})
+}
+
+// Fail records a failed broadcast request
+func (m *Monitor) Fail(_ error) {
+	m.update(func(p *Progress) {
+		p.Failed++
+	})
+}
+
+// Finish marks the run collection as over,
+// after which the progress is no longer printed
+func (m *Monitor) Finish() {
+	if m == nil {
+		return
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.finished = true
+}
+
+// Snapshot returns the latest live run progress
+func (m *Monitor) Snapshot() Progress {
+	if m == nil {
+		return Progress{}
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	snapshot := m.progress
+
+	if !m.dispatchStart.IsZero() {
+		snapshot.Elapsed = time.Since(m.dispatchStart)
+	}
+
+	return snapshot
+}
+
+// Samples returns the progress at each rate sample of the dispatch, in order
+func (m *Monitor) Samples() []Progress {
+	if m == nil {
+		return nil
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	samples := make([]Progress, len(m.samples))
+	copy(samples, m.samples)
+
+	return samples
+}
+
+// Stop stops the rate sampling, and the metrics endpoint, if any.
+// It is safe to call multiple times
+func (m *Monitor) Stop() error {
+	if m == nil {
+		return nil
+	}
+
+	var err error
+
+	m.stopOnce.Do(func() {
+		if m.started {
+			close(m.stop)
+			<-m.done
+		}
+
+		if m.server != nil {
+			err = m.server.Close()
+		}
+	})
+
+	return err
+}
+
+// update applies the change to the in-memory progress
+func (m *Monitor) update(change func(p *Progress)) {
+	if m == nil {
+		return
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	change(&m.progress)
+}
+
+// sample updates the current rates, over the window since the previous sample,
+// and prints the progress, if required. Nothing is sampled outside the dispatch
+func (m *Monitor) sample(now time.Time) {
+	m.mux.Lock()
+
+	if m.dispatchStart.IsZero() || m.finished {
+		m.mux.Unlock()
+
+		return
+	}
+
+	if window := now.Sub(m.lastSample).Seconds(); window > 0 {
+		m.progress.SendRate = float64(m.progress.Sent-m.lastSent) / window
+		m.progress.CommitRate = float64(m.progress.Committed-m.lastCommitted) / window
+	}
+
+	m.lastSample = now
+	m.lastSent = m.progress.Sent
+	m.lastCommitted = m.progress.Committed
+
+	snapshot := m.progress
+	snapshot.Elapsed = now.Sub(m.dispatchStart)
+
+	m.samples = append(m.samples, snapshot)
+
+	m.mux.Unlock()
+
+	if m.console {
+		fmt.Printf("\n%s\n", formatProgress(snapshot))
+	}
+}
+
+// formatProgress formats the progress as a single console line
+func formatProgress(p Progress) string {
+	return fmt.Sprintf(
+		"⏱️ %s | %.1f tx/s sent, %.1f tx/s committed | %d/%d sent, %d committed, %d pending, %d failed",
+		p.Elapsed.Round(time.Second),
+		p.SendRate,
+		p.CommitRate,
+		p.Sent,
+		p.Transactions,
+		p.Committed,
+		p.Pending(),
+		p.Failed,
+	)
+}
@@ -0,0 +1,133 @@
+package live
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitor_Sample(t *testing.T) {
+	t.Parallel()
+
+	m := NewMonitor(time.Second, false)
+
+	// Nothing is sampled before the dispatch
+	m.sample(time.Now())
+	assert.Zero(t, m.Snapshot().SendRate)
+
+	m.StartDispatch(100)
+	start := m.lastSample
+
+	m.SetSent(40)
+	m.SetCommitted(10)
+	m.Fail(nil)
+
+	m.sample(start.Add(2 * time.Second))
+
+	snapshot := m.Snapshot()
+
+	assert.Equal(t, 100, snapshot.Transactions)
+	assert.Equal(t, 30, snapshot.Pending())
+	assert.Equal(t, 1, snapshot.Failed)
+	assert.InDelta(t, 20, snapshot.SendRate, 0.001)
+	assert.InDelta(t, 5, snapshot.CommitRate, 0.001)
+
+	// The rates only cover the latest interval
+	m.SetSent(50)
+	m.SetCommitted(50)
+	m.sample(start.Add(3 * time.Second))
+
+	snapshot = m.Snapshot()
+
+	assert.Zero(t, snapshot.Pending())
+	assert.InDelta(t, 10, snapshot.SendRate, 0.001)
+	assert.InDelta(t, 40, snapshot.CommitRate, 0.001)
+
+	// Finished runs are no longer sampled
+	m.Finish()
+	m.SetSent(100)
+	m.sample(start.Add(4 * time.Second))
+
+	assert.InDelta(t, 10, m.Snapshot().SendRate, 0.001)
+
+	// Every dispatch sample is kept
+	samples := m.Samples()
+	require.Len(t, samples, 2)
+
+	assert.Equal(t, 2*time.Second, samples[0].Elapsed)
+	assert.Equal(t, 40, samples[0].Sent)
+	assert.Equal(t, 3*time.Second, samples[1].Elapsed)
+	assert.InDelta(t, 40, samples[1].CommitRate, 0.001)
+}
+
+func TestMonitor_Nil(t *testing.T) {
+	t.Parallel()
+
+	var m *Monitor
+
+	// A nil monitor discards all updates
+	m.Start()
+	m.StartDispatch(10)
+	m.SetSent(1)
+	m.SetCommitted(1)
+	m.Fail(nil)
+	m.Finish()
+
+	assert.Equal(t, Progress{}, m.Snapshot())
+	assert.Empty(t, m.Samples())
+	assert.NoError(t, m.Stop())
+}
+
+func TestMonitor_Handler(t *testing.T) {
+	t.Parallel()
+
+	m := NewMonitor(time.Second, false)
+
+	m.StartDispatch(100)
+	m.SetSent(40)
+	m.SetCommitted(10)
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+
+	body := recorder.Body.String()
+
+	assert.Contains(t, body, "# TYPE supernova_sent_transactions_total counter\n")
+	assert.Contains(t, body, "supernova_sent_transactions_total 40\n")
+	assert.Contains(t, body, "supernova_committed_transactions_total 10\n")
+	assert.Contains(t, body, "supernova_pending_transactions 30\n")
+	assert.Contains(t, body, "supernova_failed_broadcasts_total 0\n")
+}
+
+func TestMonitor_Serve(t *testing.T) {
+	t.Parallel()
+
+	m := NewMonitor(time.Second, false)
+	m.Start()
+
+	require.NoError(t, m.Serve("127.0.0.1:0"))
+
+	t.Cleanup(func() {
+		assert.NoError(t, m.Stop())
+	})
+
+	m.SetSent(5)
+
+	res, err := http.Get("http://" + m.Addr() + MetricsPath)
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), "supernova_sent_transactions_total 5\n")
+
+	// Taken addresses are reported
+	assert.Error(t, NewMonitor(time.Second, false).Serve(m.Addr()))
+}
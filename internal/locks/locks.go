@@ -0,0 +1,359 @@
+// Package locks keeps the registry of the account index ranges in active use
+// by live runs, so parallel runs never sign with overlapping accounts
+package locks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	fileLockSuffix   = ".lock"
+	fileLockTimeout  = 10 * time.Second
+	fileLockInterval = 10 * time.Millisecond
+)
+
+var (
+	errRangeInUse       = errors.New("account index range in use by a live run")
+	errRegistryLocked   = errors.New("timed out waiting for the lock registry")
+	errLockNotFound     = errors.New("run lock not found")
+	errInvalidLockRange = errors.New("invalid account index range")
+)
+
+// Range is a half-open [Start, End) account derivation index range
+type Range struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end"`
+}
+
+// Overlaps returns true if the ranges share an index
+func (r Range) Overlaps(other Range) bool {
+	return r.Start < other.End && other.Start < r.End
+}
+
+func (r Range) String() string {
+	if r.End-r.Start == 1 {
+		return fmt.Sprintf("%d", r.Start)
+	}
+
+	return fmt.Sprintf("%d-%d", r.Start, r.End-1)
+}
+
+// RangesOf merges the given indices into contiguous ranges
+func RangesOf(indices []uint32) []Range {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	sorted := make([]uint32, len(indices))
+	copy(sorted, indices)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	ranges := []Range{{Start: sorted[0], End: sorted[0] + 1}}
+
+	for _, index := range sorted[1:] {
+		last := &ranges[len(ranges)-1]
+
+		switch {
+		case index < last.End:
+			// Duplicate index
+		case index == last.End:
+			last.End++
+		default:
+			ranges = append(ranges, Range{Start: index, End: index + 1})
+		}
+	}
+
+	return ranges
+}
+
+// Fingerprint returns the mnemonic fingerprint the locks are keyed by,
+// so the registry never holds the mnemonic itself
+func Fingerprint(mnemonic string) string {
+	sum := sha256.Sum256([]byte(mnemonic))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// Lock is a single live run lock
+type Lock struct {
+	RunID       string  `json:"runID"`
+	Fingerprint string  `json:"fingerprint"` // the mnemonic fingerprint
+	Ranges      []Range `json:"ranges"`      // the locked account index ranges
+
+	Host string `json:"host"`
+	PID  int    `json:"pid"`
+
+	Acquired  time.Time     `json:"acquired"`
+	Refreshed time.Time     `json:"refreshed"` // the latest heartbeat of the run
+	TTL       time.Duration `json:"ttl"`       // the lock expiry, since the latest heartbeat
+}
+
+// Expired returns true if the run has not refreshed the lock within its TTL,
+// which is the case for crashed runs
+func (l *Lock) Expired(now time.Time) bool {
+	return now.Sub(l.Refreshed) > l.TTL
+}
+
+// conflicts returns true if the locks share a mnemonic, and an index
+func (l *Lock) conflicts(other *Lock) bool {
+	if l.Fingerprint != other.Fingerprint {
+		return false
+	}
+
+	for _, r := range l.Ranges {
+		for _, o := range other.Ranges {
+			if r.Overlaps(o) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// describe describes the lock, for conflict reporting
+func (l *Lock) describe() string {
+	ranges := make([]string, 0, len(l.Ranges))
+	for _, r := range l.Ranges {
+		ranges = append(ranges, r.String())
+	}
+
+	return fmt.Sprintf(
+		"run %s (indices %s, %s pid %d, since %s)",
+		l.RunID,
+		strings.Join(ranges, ", "),
+		l.Host,
+		l.PID,
+		l.Acquired.Format(time.RFC3339),
+	)
+}
+
+// NewLock creates a lock for the current process
+func NewLock(runID, fingerprint string, ranges []Range, ttl time.Duration) *Lock {
+	host, _ := os.Hostname()
+	now := time.Now()
+
+	return &Lock{
+		RunID:       runID,
+		Fingerprint: fingerprint,
+		Ranges:      ranges,
+		Host:        host,
+		PID:         os.Getpid(),
+		Acquired:    now,
+		Refreshed:   now,
+		TTL:         ttl,
+	}
+}
+
+// registry is the lock registry file content
+type registry struct {
+	Locks []*Lock `json:"locks"`
+}
+
+// Acquire adds the lock to the registry at the given path, creating it if needed.
+// Expired locks are pruned. If the lock overlaps a live run lock, it is refused,
+// unless forced. The overlapping locks are returned either way
+func Acquire(path string, lock *Lock, force bool) ([]*Lock, error) {
+	for _, r := range lock.Ranges {
+		if r.End <= r.Start {
+			return nil, fmt.Errorf("%w: [%d, %d)", errInvalidLockRange, r.Start, r.End)
+		}
+	}
+
+	var conflicts []*Lock
+
+	err := update(path, func(reg *registry) error {
+		live := make([]*Lock, 0, len(reg.Locks))
+		now := time.Now()
+
+		for _, existing := range reg.Locks {
+			if existing.Expired(now) || existing.RunID == lock.RunID {
+				continue
+			}
+
+			if existing.conflicts(lock) {
+				conflicts = append(conflicts, existing)
+			}
+
+			live = append(live, existing)
+		}
+
+		if len(conflicts) > 0 && !force {
+			descriptions := make([]string, 0, len(conflicts))
+			for _, conflict := range conflicts {
+				descriptions = append(descriptions, conflict.describe())
+			}
+
+			return fmt.Errorf("%w: %s", errRangeInUse, strings.Join(descriptions, "; "))
+		}
+
+		reg.Locks = append(live, lock)
+
+		return nil
+	})
+
+	return conflicts, err
+}
+
+// Refresh refreshes the heartbeat of the run lock, so it does not expire
+func Refresh(path, runID string) error {
+	return update(path, func(reg *registry) error {
+		for _, lock := range reg.Locks {
+			if lock.RunID == runID {
+				lock.Refreshed = time.Now()
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: %s", errLockNotFound, runID)
+	})
+}
+
+// Release removes the run lock from the registry
+func Release(path, runID string) error {
+	_, err := Unlock(path, func(lock *Lock) bool {
+		return lock.RunID == runID
+	})
+
+	return err
+}
+
+// Unlock removes the locks matching the predicate from the registry,
+// and returns the removed locks
+func Unlock(path string, matches func(*Lock) bool) ([]*Lock, error) {
+	var removed []*Lock
+
+	err := update(path, func(reg *registry) error {
+		kept := make([]*Lock, 0, len(reg.Locks))
+
+		for _, lock := range reg.Locks {
+			if matches(lock) {
+				removed = append(removed, lock)
+
+				continue
+			}
+
+			kept = append(kept, lock)
+		}
+
+		reg.Locks = kept
+
+		return nil
+	})
+
+	return removed, err
+}
+
+// List returns the locks in the registry at the given path, including expired ones
+func List(path string) ([]*Lock, error) {
+	reg, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return reg.Locks, nil
+}
+
+// load loads the registry at the given path.
+// A missing registry has no locks
+func load(path string) (*registry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &registry{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read lock registry, %w", err)
+	}
+
+	reg := &registry{}
+	if err := json.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("unable to parse lock registry, %w", err)
+	}
+
+	return reg, nil
+}
+
+// update applies the change to the registry at the given path.
+// Concurrent updates are serialized using a lock file next to the registry
+func update(path string, change func(*registry) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("unable to create lock registry directory, %w", err)
+	}
+
+	release, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	reg, err := load(path)
+	if err != nil {
+		return err
+	}
+
+	if err := change(reg); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal lock registry, %w", err)
+	}
+
+	// The registry is renamed into place, so readers never observe a partial write
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("unable to write lock registry, %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("unable to replace lock registry, %w", err)
+	}
+
+	return nil
+}
+
+// lockFile acquires the registry lock file, and returns the release function
+func lockFile(path string) (func(), error) {
+	var (
+		lockPath = path + fileLockSuffix
+		deadline = time.Now().Add(fileLockTimeout)
+	)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = f.Close()
+
+			return func() {
+				_ = os.Remove(lockPath)
+			}, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("unable to create lock registry lock, %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", errRegistryLocked, lockPath)
+		}
+
+		time.Sleep(fileLockInterval)
+	}
+}
@@ -0,0 +1,150 @@
+package locks
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocks_RangesOf(t *testing.T) {
+	t.Parallel()
+
+	ranges := RangesOf([]uint32{7, 1, 2, 3, 3, 10, 8})
+
+	assert.Equal(
+		t,
+		[]Range{
+			{Start: 1, End: 4},
+			{Start: 7, End: 9},
+			{Start: 10, End: 11},
+		},
+		ranges,
+	)
+}
+
+func TestLocks_Acquire(t *testing.T) {
+	t.Parallel()
+
+	fingerprint := Fingerprint("mnemonic")
+
+	t.Run("overlapping ranges", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "locks.json")
+
+		first := NewLock("run-1", fingerprint, []Range{{Start: 0, End: 1}, {Start: 1, End: 11}}, time.Minute)
+		if _, err := Acquire(path, first, false); err != nil {
+			t.Fatalf("unable to acquire lock, %v", err)
+		}
+
+		// Make sure the overlapping range is refused
+		second := NewLock("run-2", fingerprint, []Range{{Start: 10, End: 20}}, time.Minute)
+
+		conflicts, err := Acquire(path, second, false)
+		assert.ErrorIs(t, err, errRangeInUse)
+		assert.Len(t, conflicts, 1)
+
+		// Make sure a disjoint range, or a different mnemonic, is allowed
+		disjoint := NewLock("run-3", fingerprint, []Range{{Start: 11, End: 20}}, time.Minute)
+		other := NewLock("run-4", Fingerprint("other"), []Range{{Start: 1, End: 11}}, time.Minute)
+
+		for _, lock := range []*Lock{disjoint, other} {
+			conflicts, err := Acquire(path, lock, false)
+			if err != nil {
+				t.Fatalf("unable to acquire lock, %v", err)
+			}
+
+			assert.Empty(t, conflicts)
+		}
+
+		// Make sure the overlapping range can be forced
+		conflicts, err = Acquire(path, second, true)
+		if err != nil {
+			t.Fatalf("unable to force lock, %v", err)
+		}
+
+		assert.Len(t, conflicts, 2)
+
+		registered, err := List(path)
+		if err != nil {
+			t.Fatalf("unable to list locks, %v", err)
+		}
+
+		assert.Len(t, registered, 4)
+	})
+
+	t.Run("expired locks", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "locks.json")
+
+		// The crashed run stopped refreshing its lock
+		crashed := NewLock("crashed", fingerprint, []Range{{Start: 1, End: 11}}, time.Minute)
+		crashed.Refreshed = time.Now().Add(-2 * time.Minute)
+
+		if _, err := Acquire(path, crashed, true); err != nil {
+			t.Fatalf("unable to acquire lock, %v", err)
+		}
+
+		lock := NewLock("run", fingerprint, []Range{{Start: 1, End: 11}}, time.Minute)
+
+		conflicts, err := Acquire(path, lock, false)
+		if err != nil {
+			t.Fatalf("unable to acquire lock, %v", err)
+		}
+
+		assert.Empty(t, conflicts)
+
+		// Make sure the expired lock is pruned
+		registered, err := List(path)
+		if err != nil {
+			t.Fatalf("unable to list locks, %v", err)
+		}
+
+		if assert.Len(t, registered, 1) {
+			assert.Equal(t, "run", registered[0].RunID)
+		}
+	})
+}
+
+func TestLocks_RefreshRelease(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path = filepath.Join(t.TempDir(), "locks.json")
+		lock = NewLock("run", Fingerprint("mnemonic"), []Range{{Start: 1, End: 11}}, time.Minute)
+	)
+
+	lock.Refreshed = time.Now().Add(-time.Hour)
+
+	if _, err := Acquire(path, lock, false); err != nil {
+		t.Fatalf("unable to acquire lock, %v", err)
+	}
+
+	// Make sure the refresh keeps the lock live
+	if err := Refresh(path, "run"); err != nil {
+		t.Fatalf("unable to refresh lock, %v", err)
+	}
+
+	registered, err := List(path)
+	if err != nil {
+		t.Fatalf("unable to list locks, %v", err)
+	}
+
+	assert.False(t, registered[0].Expired(time.Now()))
+
+	// Make sure the released lock is removed
+	if err := Release(path, "run"); err != nil {
+		t.Fatalf("unable to release lock, %v", err)
+	}
+
+	registered, err = List(path)
+	if err != nil {
+		t.Fatalf("unable to list locks, %v", err)
+	}
+
+	assert.Empty(t, registered)
+	assert.ErrorIs(t, Refresh(path, "run"), errLockNotFound)
+}
@@ -0,0 +1,53 @@
+package locks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// DisplayLocks displays the locks as a table
+func DisplayLocks(w io.Writer, locks []*Lock) {
+	if len(locks) == 0 {
+		_, _ = fmt.Fprintln(w, "No account locks")
+
+		return
+	}
+
+	var (
+		tw  = tabwriter.NewWriter(w, 10, 20, 2, ' ', 0)
+		now = time.Now()
+	)
+
+	_, _ = fmt.Fprintln(tw, "Run ID\tFingerprint\tIndices\tHost\tPID\tRefreshed\tState")
+
+	for _, lock := range locks {
+		ranges := make([]string, 0, len(lock.Ranges))
+		for _, r := range lock.Ranges {
+			ranges = append(ranges, r.String())
+		}
+
+		state := "live"
+		if lock.Expired(now) {
+			state = "expired"
+		}
+
+		_, _ = fmt.Fprintln(
+			tw,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s\t%d\t%s\t%s",
+				lock.RunID,
+				lock.Fingerprint,
+				strings.Join(ranges, ", "),
+				lock.Host,
+				lock.PID,
+				lock.Refreshed.Format(time.RFC3339),
+				state,
+			),
+		)
+	}
+
+	_ = tw.Flush()
+}
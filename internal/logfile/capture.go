@@ -0,0 +1,61 @@
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// captureBufferSize is the size of the standard output capture reads
+const captureBufferSize = 32 * 1024
+
+// Capture tees the process standard output into the log writer, leaving the
+// console output as-is. The returned release function restores the standard
+// output, and writes out the captured output. The log writer is left open,
+// so the lines that never made it to the console (run errors) can follow.
+// The standard output is swapped, so Capture needs to be called (and released)
+// while nothing else is writing to it
+func Capture(w *Writer) (func(), error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create output pipe, %w", err)
+	}
+
+	var (
+		console = os.Stdout
+		done    = make(chan struct{})
+	)
+
+	os.Stdout = writer
+
+	go func() {
+		defer close(done)
+
+		buf := make([]byte, captureBufferSize)
+
+		for {
+			n, readErr := reader.Read(buf)
+			if n > 0 {
+				_, _ = console.Write(buf[:n])
+				_, _ = w.Write(buf[:n])
+			}
+
+			if readErr != nil {
+				return
+			}
+		}
+	}()
+
+	var releaseOnce sync.Once
+
+	return func() {
+		releaseOnce.Do(func() {
+			os.Stdout = console
+
+			// Drain the captured output still in the pipe
+			_ = writer.Close()
+			<-done
+			_ = reader.Close()
+		})
+	}, nil
+}
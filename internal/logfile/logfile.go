@@ -0,0 +1,329 @@
+// Package logfile implements the rolling run log file, with size and age
+// based rotation, kept alongside (and independent of) the console output
+package logfile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxSize is the default size of the active log file, above which it is rotated
+	DefaultMaxSize = 100 * 1024 * 1024
+
+	// DefaultKeep is the default number of rotated log files kept
+	DefaultKeep = 5
+
+	// flushInterval is the interval at which the buffered log lines are flushed
+	flushInterval = time.Second
+)
+
+var errClosed = errors.New("log file is closed")
+
+// errorMarkers are the markers of the error lines, which are flushed right away,
+// so the lines leading up to a crash are on disk before the process goes down
+var errorMarkers = [][]byte{
+	[]byte("❌"),
+	[]byte("panic:"),
+}
+
+// Rotation is the log file rotation policy
+type Rotation struct {
+	MaxSize int64         // the size of the active log file above which it is rotated, 0 if unbounded
+	MaxAge  time.Duration // the age of the active log file above which it is rotated, 0 if unbounded
+	Keep    int           // the number of rotated log files kept
+}
+
+// Writer is the rolling log file writer.
+// Lines are buffered, and flushed periodically, on error lines and on close.
+// The log is only ever rotated between whole lines, after the buffered lines are flushed,
+// so no line is split across files, or lost in the rotation.
+// The active log file path never changes, the rotated files are suffixed (.1 being the latest)
+type Writer struct {
+	mux sync.Mutex
+
+	path     string
+	rotation Rotation
+
+	file    *os.File
+	buf     *bufio.Writer
+	size    int64     // the size of the active log file, including the buffered lines
+	opened  time.Time // the time the active log file was opened
+	partial []byte    // the trailing line without a newline, if any
+	closed  bool
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Open opens the log file at the given path for appending, creating its directory if needed.
+// The buffered lines are flushed in the background, until the writer is closed
+func Open(path string, rotation Rotation) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create log directory, %w", err)
+	}
+
+	w := &Writer{
+		path:     path,
+		rotation: rotation,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	go w.flushLoop()
+
+	return w, nil
+}
+
+// Path returns the active log file path
+func (w *Writer) Path() string {
+	return w.path
+}
+
+// open opens the active log file
+func (w *Writer) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open log file, %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("unable to stat log file, %w", err)
+	}
+
+	w.file = file
+	w.buf = bufio.NewWriter(file)
+	w.size = info.Size()
+	w.opened = time.Now()
+
+	return nil
+}
+
+// Write writes the log output. Whole lines are written out,
+// while the trailing partial line is held until it is completed
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.closed {
+		return 0, errClosed
+	}
+
+	data := append(w.partial, p...)
+
+	for {
+		index := bytes.IndexByte(data, '\n')
+		if index < 0 {
+			break
+		}
+
+		if err := w.writeLine(data[:index+1]); err != nil {
+			return 0, err
+		}
+
+		data = data[index+1:]
+	}
+
+	w.partial = append([]byte(nil), data...)
+
+	return len(p), nil
+}
+
+// writeLine writes a single whole line, rotating the log file beforehand, if needed
+func (w *Writer) writeLine(line []byte) error {
+	if w.shouldRotate(int64(len(line))) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.buf.Write(line); err != nil {
+		return fmt.Errorf("unable to write log line, %w", err)
+	}
+
+	w.size += int64(len(line))
+
+	if isErrorLine(line) {
+		return w.flush()
+	}
+
+	return nil
+}
+
+// shouldRotate returns true if the line does not fit the active log file.
+// Empty log files are never rotated, so an oversized line is still written
+func (w *Writer) shouldRotate(lineSize int64) bool {
+	if w.size == 0 {
+		return false
+	}
+
+	if w.rotation.MaxSize > 0 && w.size+lineSize > w.rotation.MaxSize {
+		return true
+	}
+
+	return w.rotation.MaxAge > 0 && time.Since(w.opened) >= w.rotation.MaxAge
+}
+
+// rotate flushes and closes the active log file, shifts the rotated
+// log files (dropping the oldest above the kept count), and opens a new active log file
+func (w *Writer) rotate() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("unable to close log file, %w", err)
+	}
+
+	if w.rotation.Keep < 1 {
+		if err := os.Remove(w.path); err != nil {
+			return fmt.Errorf("unable to remove log file, %w", err)
+		}
+
+		return w.open()
+	}
+
+	// The oldest rotated file is dropped, if any
+	_ = os.Remove(rotatedPath(w.path, w.rotation.Keep))
+
+	for index := w.rotation.Keep - 1; index > 0; index-- {
+		if err := os.Rename(rotatedPath(w.path, index), rotatedPath(w.path, index+1)); err != nil &&
+			!errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to rotate log file, %w", err)
+		}
+	}
+
+	if err := os.Rename(w.path, rotatedPath(w.path, 1)); err != nil {
+		return fmt.Errorf("unable to rotate log file, %w", err)
+	}
+
+	return w.open()
+}
+
+// Flush writes out the buffered log lines
+func (w *Writer) Flush() error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	return w.flush()
+}
+
+// flush writes out the buffered log lines, and syncs the log file
+func (w *Writer) flush() error {
+	if w.buf.Buffered() == 0 {
+		return nil
+	}
+
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("unable to flush log file, %w", err)
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync log file, %w", err)
+	}
+
+	return nil
+}
+
+// flushLoop periodically flushes the buffered log lines, until the writer is closed
+func (w *Writer) flushLoop() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			_ = w.Flush()
+		}
+	}
+}
+
+// Close writes out the partial line and the buffered log lines, and closes the log file
+func (w *Writer) Close() error {
+	var err error
+
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+
+		w.mux.Lock()
+		defer w.mux.Unlock()
+
+		err = w.close()
+	})
+
+	return err
+}
+
+// close writes out the partial line and the buffered log lines, and closes the log file
+func (w *Writer) close() error {
+	w.closed = true
+
+	if len(w.partial) > 0 {
+		if err := w.writeLine(append(w.partial, '\n')); err != nil {
+			_ = w.file.Close()
+
+			return err
+		}
+
+		w.partial = nil
+	}
+
+	if err := w.flush(); err != nil {
+		_ = w.file.Close()
+
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("unable to close log file, %w", err)
+	}
+
+	return nil
+}
+
+// rotatedPath returns the path of the rotated log file with the given index
+func rotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// isErrorLine returns true if the line is an error line
+func isErrorLine(line []byte) bool {
+	for _, marker := range errorMarkers {
+		if bytes.Contains(line, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LogPanic writes out the panic, with the stack of the panicking goroutine,
+// and flushes the log right away, so the crash makes it to disk
+func (w *Writer) LogPanic(value interface{}, stack []byte) {
+	_, _ = fmt.Fprintf(w, "❌ panic: %v\n%s\n", value, stack)
+
+	_ = w.Flush()
+}
@@ -0,0 +1,183 @@
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readLog reads the log file at the given path
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read log file, %v", err)
+	}
+
+	return string(data)
+}
+
+func TestWriter_Rotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("size rotation", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "logs", "run.log")
+
+		w, err := Open(path, Rotation{MaxSize: 64, Keep: 2})
+		if err != nil {
+			t.Fatalf("unable to open log, %v", err)
+		}
+
+		// Lines are written in chunks, so they are split across writes
+		for i := 0; i < 20; i++ {
+			line := fmt.Sprintf("line %02d of the run log\n", i)
+
+			_, _ = w.Write([]byte(line[:5]))
+			_, _ = w.Write([]byte(line[5:]))
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close log, %v", err)
+		}
+
+		// Only the kept rotated files remain
+		_, err = os.Stat(rotatedPath(path, 3))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		logs := []string{readLog(t, rotatedPath(path, 2)), readLog(t, rotatedPath(path, 1)), readLog(t, path)}
+
+		for _, log := range logs {
+			assert.LessOrEqual(t, len(log), 64)
+
+			// No line is split across files
+			for _, line := range strings.Split(strings.TrimSuffix(log, "\n"), "\n") {
+				assert.True(t, strings.HasPrefix(line, "line ") && strings.HasSuffix(line, "run log"), line)
+			}
+		}
+
+		// The active log file has the latest lines
+		assert.True(t, strings.HasSuffix(logs[2], "line 19 of the run log\n"))
+	})
+
+	t.Run("age rotation", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "run.log")
+
+		w, err := Open(path, Rotation{MaxAge: 50 * time.Millisecond, Keep: 1})
+		if err != nil {
+			t.Fatalf("unable to open log, %v", err)
+		}
+
+		_, _ = w.Write([]byte("first\n"))
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, _ = w.Write([]byte("second\n"))
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close log, %v", err)
+		}
+
+		assert.Equal(t, "first\n", readLog(t, rotatedPath(path, 1)))
+		assert.Equal(t, "second\n", readLog(t, path))
+	})
+}
+
+func TestWriter_Flush(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error lines flushed", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "run.log")
+
+		w, err := Open(path, Rotation{})
+		if err != nil {
+			t.Fatalf("unable to open log, %v", err)
+		}
+
+		defer w.Close()
+
+		_, _ = w.Write([]byte("buffered\n"))
+		assert.Empty(t, readLog(t, path))
+
+		// The error line, and the lines before it, are on disk right away
+		_, _ = w.Write([]byte("❌ unable to broadcast\n"))
+		assert.Equal(t, "buffered\n❌ unable to broadcast\n", readLog(t, path))
+	})
+
+	t.Run("partial line written on close", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "run.log")
+
+		w, err := Open(path, Rotation{})
+		if err != nil {
+			t.Fatalf("unable to open log, %v", err)
+		}
+
+		_, _ = w.Write([]byte("no newline"))
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close log, %v", err)
+		}
+
+		assert.Equal(t, "no newline\n", readLog(t, path))
+
+		_, err = w.Write([]byte("closed\n"))
+		assert.ErrorIs(t, err, errClosed)
+	})
+
+	t.Run("panic logged", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "run.log")
+
+		w, err := Open(path, Rotation{})
+		if err != nil {
+			t.Fatalf("unable to open log, %v", err)
+		}
+
+		defer w.Close()
+
+		w.LogPanic("boom", []byte("goroutine 1 [running]:"))
+
+		assert.Contains(t, readLog(t, path), "❌ panic: boom\ngoroutine 1 [running]:")
+	})
+}
+
+func TestCapture(t *testing.T) {
+	// The standard output is swapped, so the test is not parallel
+	path := filepath.Join(t.TempDir(), "run.log")
+
+	w, err := Open(path, Rotation{})
+	if err != nil {
+		t.Fatalf("unable to open log, %v", err)
+	}
+
+	release, err := Capture(w)
+	if err != nil {
+		t.Fatalf("unable to capture output, %v", err)
+	}
+
+	fmt.Printf("captured line\n")
+
+	release()
+
+	_, _ = fmt.Fprintf(w, "after the capture\n")
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unable to close log, %v", err)
+	}
+
+	assert.Equal(t, "captured line\nafter the capture\n", readLog(t, path))
+}
@@ -0,0 +1,309 @@
+// Package manifest links all the artifacts produced by a single run
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Artifact types
+const (
+	TypeResults       = "results"
+	TypeSegment       = "results-segment"
+	TypeDump          = "dump"
+	TypeUploadReceipt = "upload-receipt"
+	TypeSizeProbe     = "size-probe"
+)
+
+const (
+	filePrefix = "manifest-"
+	fileSuffix = ".json"
+
+	lockSuffix   = ".lock"
+	lockTimeout  = 10 * time.Second
+	lockInterval = 10 * time.Millisecond
+)
+
+var (
+	errLockTimeout      = errors.New("timed out waiting for the manifest lock")
+	errRunMismatch      = errors.New("manifest belongs to a different run")
+	errArtifactNotFound = errors.New("no artifacts of the type found in the manifest")
+	errArtifactModified = errors.New("artifact does not match its manifest hash")
+)
+
+// Manifest lists every artifact produced by a single run
+type Manifest struct {
+	RunID     string     `json:"runId"`
+	LogFile   string     `json:"logFile,omitempty"` // the active run log file, if any
+	Artifacts []Artifact `json:"artifacts"`
+
+	dir string // the manifest directory, for resolving the artifact paths
+}
+
+// Artifact is a single run artifact
+type Artifact struct {
+	Type    string    `json:"type"`
+	Path    string    `json:"path"` // relative to the manifest directory, if within it
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// FileName returns the manifest file name for the run
+func FileName(runID string) string {
+	return filePrefix + runID + fileSuffix
+}
+
+// IsManifest returns true if the path is a manifest file
+func IsManifest(path string) bool {
+	name := filepath.Base(path)
+
+	return strings.HasPrefix(name, filePrefix) && strings.HasSuffix(name, fileSuffix)
+}
+
+// NewArtifact creates the manifest artifact for the file at the given path
+func NewArtifact(kind, path string) (Artifact, error) {
+	hash, size, err := hashFile(path)
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	return Artifact{
+		Type:    kind,
+		Path:    path,
+		Size:    size,
+		SHA256:  hash,
+		AddedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Load loads the manifest at the given path
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest, %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal manifest, %w", err)
+	}
+
+	m.dir = filepath.Dir(path)
+
+	return &m, nil
+}
+
+// Locate returns the paths of the artifacts of the given type, in the order
+// they were added. Each artifact is verified against its manifest hash
+func (m *Manifest) Locate(kind string) ([]string, error) {
+	paths := make([]string, 0, len(m.Artifacts))
+
+	for _, artifact := range m.Artifacts {
+		if artifact.Type != kind {
+			continue
+		}
+
+		path := artifact.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(m.dir, path)
+		}
+
+		hash, _, err := hashFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if hash != artifact.SHA256 {
+			return nil, fmt.Errorf("%w: %s", errArtifactModified, path)
+		}
+
+		paths = append(paths, path)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: %s", errArtifactNotFound, kind)
+	}
+
+	return paths, nil
+}
+
+// Append atomically appends the artifacts to the run manifest at the given path,
+// creating it if needed. Artifacts already in the manifest (by path) are replaced.
+// Concurrent appends are serialized using a lock file next to the manifest
+func Append(path, runID string, artifacts ...Artifact) error {
+	return update(path, runID, func(m *Manifest) {
+		dir := filepath.Dir(path)
+
+		for _, artifact := range artifacts {
+			artifact.Path = relativePath(dir, artifact.Path)
+
+			m.add(artifact)
+		}
+	})
+}
+
+// SetLogFile atomically records the active run log file in the run manifest
+// at the given path, creating it if needed. The log file is not an artifact,
+// since it keeps changing (and rotating) while the run is live
+func SetLogFile(path, runID, logFile string) error {
+	return update(path, runID, func(m *Manifest) {
+		m.LogFile = relativePath(filepath.Dir(path), logFile)
+	})
+}
+
+// update atomically applies the change to the run manifest
+// at the given path, under the manifest lock, creating it if needed
+func update(path, runID string, change func(m *Manifest)) error {
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	m := &Manifest{
+		RunID: runID,
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if m, err = Load(path); err != nil {
+			return err
+		}
+
+		if m.RunID != runID {
+			return fmt.Errorf("%w: %s", errRunMismatch, m.RunID)
+		}
+	}
+
+	change(m)
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal manifest, %w", err)
+	}
+
+	return writeAtomic(path, data)
+}
+
+// add adds the artifact to the manifest, replacing the artifact at the same path
+func (m *Manifest) add(artifact Artifact) {
+	for index, existing := range m.Artifacts {
+		if existing.Path == artifact.Path {
+			m.Artifacts[index] = artifact
+
+			return
+		}
+	}
+
+	m.Artifacts = append(m.Artifacts, artifact)
+}
+
+// relativePath returns the path relative to the directory,
+// if it is within the directory. Otherwise, the absolute path is returned
+func relativePath(dir, path string) string {
+	absDir, dirErr := filepath.Abs(dir)
+	absPath, pathErr := filepath.Abs(path)
+
+	if dirErr != nil || pathErr != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return absPath
+	}
+
+	return rel
+}
+
+// lock acquires the manifest lock file, and returns the release function
+func lock(path string) (func(), error) {
+	var (
+		lockPath = path + lockSuffix
+		deadline = time.Now().Add(lockTimeout)
+	)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = f.Close()
+
+			return func() {
+				_ = os.Remove(lockPath)
+			}, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("unable to create manifest lock, %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", errLockTimeout, lockPath)
+		}
+
+		time.Sleep(lockInterval)
+	}
+}
+
+// writeAtomic writes the data to a temporary file, and renames
+// it to the path, so readers never observe a partial manifest
+func writeAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".manifest-*")
+	if err != nil {
+		return fmt.Errorf("unable to create manifest file, %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("unable to write manifest, %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("unable to sync manifest, %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to close manifest, %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("unable to replace manifest, %w", err)
+	}
+
+	return nil
+}
+
+// hashFile returns the SHA-256 hash and size of the file
+func hashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to open artifact, %w", err)
+	}
+
+	defer f.Close()
+
+	hasher := sha256.New()
+
+	size, err := io.Copy(hasher, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to hash artifact, %w", err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
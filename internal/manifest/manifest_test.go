@@ -0,0 +1,222 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeArtifact writes a test artifact file, and returns its path
+func writeArtifact(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write artifact, %v", err)
+	}
+
+	return path
+}
+
+func TestManifest_IsManifest(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsManifest(filepath.Join("out", FileName("run-1"))))
+	assert.False(t, IsManifest("results.json"))
+	assert.False(t, IsManifest("manifest-run-1.csv"))
+}
+
+func TestManifest_Append(t *testing.T) {
+	t.Parallel()
+
+	t.Run("artifacts linked by hash", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir          = t.TempDir()
+			manifestPath = filepath.Join(dir, FileName("run-1"))
+			results      = writeArtifact(t, dir, "results.json", `{"averageTPS":10}`)
+			dump         = writeArtifact(t, dir, "dump.json", `{}`)
+		)
+
+		for _, a := range []struct{ kind, path string }{
+			{TypeResults, results},
+			{TypeDump, dump},
+		} {
+			artifact, err := NewArtifact(a.kind, a.path)
+			if err != nil {
+				t.Fatalf("unable to create artifact, %v", err)
+			}
+
+			if err := Append(manifestPath, "run-1", artifact); err != nil {
+				t.Fatalf("unable to append artifact, %v", err)
+			}
+		}
+
+		m, err := Load(manifestPath)
+		if err != nil {
+			t.Fatalf("unable to load manifest, %v", err)
+		}
+
+		assert.Equal(t, "run-1", m.RunID)
+
+		if !assert.Len(t, m.Artifacts, 2) {
+			return
+		}
+
+		// Paths within the manifest directory are relative
+		assert.Equal(t, "results.json", m.Artifacts[0].Path)
+		assert.Equal(t, int64(len(`{"averageTPS":10}`)), m.Artifacts[0].Size)
+
+		paths, err := m.Locate(TypeResults)
+		if err != nil {
+			t.Fatalf("unable to locate results, %v", err)
+		}
+
+		assert.Equal(t, []string{results}, paths)
+
+		_, err = m.Locate(TypeUploadReceipt)
+		assert.ErrorIs(t, err, errArtifactNotFound)
+
+		// Make sure modified artifacts are detected
+		writeArtifact(t, dir, "results.json", `{"averageTPS":20}`)
+
+		_, err = m.Locate(TypeResults)
+		assert.ErrorIs(t, err, errArtifactModified)
+	})
+
+	t.Run("re-appended artifact replaced", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir          = t.TempDir()
+			manifestPath = filepath.Join(dir, FileName("run-1"))
+			path         = writeArtifact(t, dir, "results.json", "a")
+		)
+
+		first, err := NewArtifact(TypeResults, path)
+		if err != nil {
+			t.Fatalf("unable to create artifact, %v", err)
+		}
+
+		writeArtifact(t, dir, "results.json", "bb")
+
+		second, err := NewArtifact(TypeResults, path)
+		if err != nil {
+			t.Fatalf("unable to create artifact, %v", err)
+		}
+
+		if err := Append(manifestPath, "run-1", first, second); err != nil {
+			t.Fatalf("unable to append artifacts, %v", err)
+		}
+
+		m, err := Load(manifestPath)
+		if err != nil {
+			t.Fatalf("unable to load manifest, %v", err)
+		}
+
+		if !assert.Len(t, m.Artifacts, 1) {
+			return
+		}
+
+		assert.Equal(t, second.SHA256, m.Artifacts[0].SHA256)
+	})
+
+	t.Run("concurrent appends", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir          = t.TempDir()
+			manifestPath = filepath.Join(dir, FileName("run-1"))
+			count        = 20
+			wg           sync.WaitGroup
+		)
+
+		for i := 0; i < count; i++ {
+			artifact, err := NewArtifact(
+				TypeSegment,
+				writeArtifact(t, dir, "segment-"+string(rune('a'+i))+".json", "{}"),
+			)
+			if err != nil {
+				t.Fatalf("unable to create artifact, %v", err)
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				assert.NoError(t, Append(manifestPath, "run-1", artifact))
+			}()
+		}
+
+		wg.Wait()
+
+		m, err := Load(manifestPath)
+		if err != nil {
+			t.Fatalf("unable to load manifest, %v", err)
+		}
+
+		assert.Len(t, m.Artifacts, count)
+
+		// Make sure the lock is released
+		_, err = os.Stat(manifestPath + lockSuffix)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("different run", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir          = t.TempDir()
+			manifestPath = filepath.Join(dir, FileName("run-1"))
+		)
+
+		artifact, err := NewArtifact(TypeResults, writeArtifact(t, dir, "results.json", "{}"))
+		if err != nil {
+			t.Fatalf("unable to create artifact, %v", err)
+		}
+
+		if err := Append(manifestPath, "run-1", artifact); err != nil {
+			t.Fatalf("unable to append artifact, %v", err)
+		}
+
+		assert.ErrorIs(t, Append(manifestPath, "run-2", artifact), errRunMismatch)
+	})
+}
+
+func TestManifest_SetLogFile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir          = t.TempDir()
+		manifestPath = filepath.Join(dir, FileName("run-1"))
+	)
+
+	artifact, err := NewArtifact(TypeResults, writeArtifact(t, dir, "results.json", "{}"))
+	if err != nil {
+		t.Fatalf("unable to create artifact, %v", err)
+	}
+
+	if err := SetLogFile(manifestPath, "run-1", filepath.Join(dir, "run-1", "supernova.log")); err != nil {
+		t.Fatalf("unable to set log file, %v", err)
+	}
+
+	if err := Append(manifestPath, "run-1", artifact); err != nil {
+		t.Fatalf("unable to append artifact, %v", err)
+	}
+
+	m, err := Load(manifestPath)
+	if err != nil {
+		t.Fatalf("unable to load manifest, %v", err)
+	}
+
+	// The log file is kept alongside the artifacts, relative to the manifest
+	assert.Equal(t, filepath.Join("run-1", "supernova.log"), m.LogFile)
+	assert.Len(t, m.Artifacts, 1)
+
+	assert.ErrorIs(t, SetLogFile(manifestPath, "run-2", "supernova.log"), errRunMismatch)
+}
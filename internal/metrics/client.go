@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+)
+
+// The client-side bottleneck heuristics, evaluated over the broadcast
+const (
+	// cpuThreshold is the mean process CPU usage (percent of the available cores)
+	// above which the client is suspected to be CPU bound
+	cpuThreshold = 90.0
+
+	// gcPauseThreshold is the share of the broadcast spent in GC pauses,
+	// above which the client is suspected to be GC bound
+	gcPauseThreshold = 0.05
+
+	// goroutineThreshold is the goroutine count above which
+	// the client is suspected to be scheduling bound
+	goroutineThreshold = 10000
+
+	// starvationThreshold is the share of the broadcast the dispatch loop spent
+	// idle between batches (outside the pacing waits), above which
+	// the batches are suspected to be produced slower than they are sent
+	starvationThreshold = 0.25
+)
+
+// ClientMetrics is the supernova process resource timeline, sampled during the run,
+// with the client-side bottleneck verdict for the broadcast
+type ClientMetrics struct {
+	Interval time.Duration   `json:"interval"`
+	CPUs     int             `json:"cpus"` // the number of cores the CPU usage is relative to
+	Samples  []*ClientSample `json:"samples"`
+
+	BroadcastStart time.Time `json:"broadcastStart"`
+	BroadcastEnd   time.Time `json:"broadcastEnd"`
+
+	AvgCPU         *float64      `json:"avgCPU,omitempty"` // the mean CPU usage during the broadcast, if sampled
+	PeakGoroutines int           `json:"peakGoroutines"`   // the peak goroutine count during the broadcast
+	GCPause        time.Duration `json:"gcPause"`          // the total GC pause during the broadcast
+	GCCycles       uint32        `json:"gcCycles"`         // the number of GC cycles during the broadcast
+	Starvation     time.Duration `json:"starvation"`       // the dispatch loop idle time between batches
+
+	Suspected bool     `json:"suspected"`          // flag indicating if a client-side bottleneck is suspected
+	Evidence  []string `json:"evidence,omitempty"` // the exceeded heuristics, if any
+}
+
+// ClientSample is a single supernova process resource sample
+type ClientSample struct {
+	Time         time.Time     `json:"time"`
+	CPU          *float64      `json:"cpu,omitempty"` // the CPU usage since the previous sample, if supported
+	Goroutines   int           `json:"goroutines"`
+	GCPauseTotal time.Duration `json:"gcPauseTotal"` // the cumulative GC pause of the process
+	GCCycles     uint32        `json:"gcCycles"`     // the cumulative number of GC cycles of the process
+}
+
+// NewClientMetrics creates the client resource timeline, and evaluates the
+// client-side bottleneck heuristics over the samples within the broadcast
+func NewClientMetrics(
+	interval time.Duration,
+	cpus int,
+	samples []*ClientSample,
+	broadcastStart,
+	broadcastEnd time.Time,
+	starvation time.Duration,
+) *ClientMetrics {
+	m := &ClientMetrics{
+		Interval:       interval,
+		CPUs:           cpus,
+		Samples:        samples,
+		BroadcastStart: broadcastStart,
+		BroadcastEnd:   broadcastEnd,
+		Starvation:     starvation,
+	}
+
+	var (
+		cpuTotal   float64
+		cpuSamples int
+
+		first, last *ClientSample
+	)
+
+	for _, sample := range samples {
+		// The samples preceding the broadcast are the baseline of the GC totals
+		if sample.Time.Before(broadcastStart) {
+			first = sample
+
+			continue
+		}
+
+		if sample.Time.After(broadcastEnd) {
+			break
+		}
+
+		if first == nil {
+			first = sample
+		}
+
+		last = sample
+
+		if sample.CPU != nil {
+			cpuTotal += *sample.CPU
+			cpuSamples++
+		}
+
+		if sample.Goroutines > m.PeakGoroutines {
+			m.PeakGoroutines = sample.Goroutines
+		}
+	}
+
+	if cpuSamples > 0 {
+		avgCPU := cpuTotal / float64(cpuSamples)
+		m.AvgCPU = &avgCPU
+	}
+
+	if first != nil && last != nil {
+		m.GCPause = last.GCPauseTotal - first.GCPauseTotal
+		m.GCCycles = last.GCCycles - first.GCCycles
+	}
+
+	m.Evidence = m.evidence(broadcastEnd.Sub(broadcastStart))
+	m.Suspected = len(m.Evidence) > 0
+
+	return m
+}
+
+// evidence returns the client-side bottleneck heuristics
+// exceeded during the broadcast of the given duration
+func (m *ClientMetrics) evidence(broadcast time.Duration) []string {
+	var evidence []string
+
+	if m.AvgCPU != nil && *m.AvgCPU > cpuThreshold {
+		evidence = append(
+			evidence,
+			fmt.Sprintf("CPU usage averaged %.1f%% of %d cores during the broadcast", *m.AvgCPU, m.CPUs),
+		)
+	}
+
+	if m.PeakGoroutines > goroutineThreshold {
+		evidence = append(
+			evidence,
+			fmt.Sprintf("goroutine count peaked at %d during the broadcast", m.PeakGoroutines),
+		)
+	}
+
+	if broadcast <= 0 {
+		return evidence
+	}
+
+	if ratio := float64(m.GCPause) / float64(broadcast); ratio > gcPauseThreshold {
+		evidence = append(
+			evidence,
+			fmt.Sprintf(
+				"GC paused the process for %s over %d cycles (%.1f%% of the broadcast)",
+				m.GCPause.Round(time.Millisecond),
+				m.GCCycles,
+				ratio*100,
+			),
+		)
+	}
+
+	if ratio := float64(m.Starvation) / float64(broadcast); ratio > starvationThreshold {
+		evidence = append(
+			evidence,
+			fmt.Sprintf(
+				"the dispatch loop sat idle between batches for %s (%.1f%% of the broadcast)",
+				m.Starvation.Round(time.Millisecond),
+				ratio*100,
+			),
+		)
+	}
+
+	return evidence
+}
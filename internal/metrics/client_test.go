@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientMetrics(t *testing.T) {
+	t.Parallel()
+
+	var (
+		start = time.Unix(1000, 0)
+		end   = start.Add(10 * time.Second)
+	)
+
+	// newSample creates a sample, the given number of seconds after the start
+	newSample := func(seconds int, cpu float64, goroutines int, pause time.Duration, cycles uint32) *ClientSample {
+		return &ClientSample{
+			Time:         start.Add(time.Duration(seconds) * time.Second),
+			CPU:          &cpu,
+			Goroutines:   goroutines,
+			GCPauseTotal: pause,
+			GCCycles:     cycles,
+		}
+	}
+
+	t.Run("healthy client", func(t *testing.T) {
+		t.Parallel()
+
+		samples := []*ClientSample{
+			newSample(-1, 99, 50, 0, 0),
+			newSample(2, 40, 120, time.Millisecond, 2),
+			newSample(8, 50, 100, 2*time.Millisecond, 3),
+			newSample(12, 99, 20000, time.Second, 10),
+		}
+
+		m := NewClientMetrics(time.Second, 4, samples, start, end, time.Second)
+
+		// Only the broadcast samples are evaluated
+		if assert.NotNil(t, m.AvgCPU) {
+			assert.Equal(t, 45.0, *m.AvgCPU)
+		}
+
+		assert.Equal(t, 120, m.PeakGoroutines)
+		assert.Equal(t, 2*time.Millisecond, m.GCPause)
+		assert.Equal(t, uint32(3), m.GCCycles)
+		assert.False(t, m.Suspected)
+		assert.Empty(t, m.Evidence)
+	})
+
+	t.Run("client bottleneck", func(t *testing.T) {
+		t.Parallel()
+
+		samples := []*ClientSample{
+			newSample(1, 95, 100, 0, 0),
+			newSample(5, 96, 12000, 800*time.Millisecond, 40),
+		}
+
+		m := NewClientMetrics(time.Second, 8, samples, start, end, 4*time.Second)
+
+		assert.True(t, m.Suspected)
+
+		if assert.Len(t, m.Evidence, 4) {
+			assert.Contains(t, m.Evidence[0], "CPU usage averaged 95.5% of 8 cores")
+			assert.Contains(t, m.Evidence[1], "goroutine count peaked at 12000")
+			assert.Contains(t, m.Evidence[2], "GC paused the process for 800ms over 40 cycles")
+			assert.Contains(t, m.Evidence[3], "the dispatch loop sat idle between batches for 4s")
+		}
+	})
+}
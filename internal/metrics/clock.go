@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// ClockDriftBudget is the wall clock drift from the monotonic clock,
+// throughout the broadcast, above which the drift is reported
+const ClockDriftBudget = 100 * time.Millisecond
+
+// Clock is the single monotonic clock reader the broadcast timestamps are sourced from.
+// Its readings are the anchor wall time, advanced by the monotonic time since the anchor,
+// so they never go backwards, even if the wall clock is stepped (NTP adjustments),
+// and they stay ordered once serialized, when the monotonic readings are stripped
+type Clock struct {
+	mux sync.Mutex
+
+	anchor time.Time // the wall time anchor, with its monotonic reading
+	last   time.Time // the latest reading
+}
+
+// ClockAnchor is the wall-to-monotonic anchor of the broadcast timestamps,
+// so traces from different machines can be aligned
+type ClockAnchor struct {
+	Host string    `json:"host"`
+	Wall time.Time `json:"wall"` // the wall time the monotonic offsets are anchored to
+
+	// Drift is the wall clock drift from the monotonic clock, since the anchor.
+	// Positive values mean the wall clock ran ahead of the timestamps
+	Drift time.Duration `json:"drift"`
+}
+
+// Drifted returns true if the wall clock drifted beyond the budget
+func (a *ClockAnchor) Drifted() bool {
+	return a.Drift > ClockDriftBudget || a.Drift < -ClockDriftBudget
+}
+
+// NewClock creates a new clock, anchored to the current wall time
+func NewClock() *Clock {
+	now := time.Now()
+
+	return &Clock{
+		anchor: now,
+		last:   now.Round(0),
+	}
+}
+
+// Now returns the current clock reading, with no monotonic reading of its own.
+// The readings are non-decreasing, regardless of the calling goroutine
+func (c *Clock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := c.anchor.Round(0).Add(time.Since(c.anchor))
+
+	if now.Before(c.last) {
+		now = c.last
+	}
+
+	c.last = now
+
+	return now
+}
+
+// Anchor returns the clock anchor, along with the wall clock drift observed so far
+func (c *Clock) Anchor() *ClockAnchor {
+	host, _ := os.Hostname()
+
+	reading := c.Now()
+
+	return &ClockAnchor{
+		Host:  host,
+		Wall:  c.anchor.Round(0),
+		Drift: time.Now().Round(0).Sub(reading),
+	}
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock_Now(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concurrent readings ordered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			clock = NewClock()
+
+			workers  = 64
+			readings = 1000
+
+			wg    sync.WaitGroup
+			mux   sync.Mutex
+			order = make([]time.Time, 0, workers*readings)
+		)
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				previous := time.Time{}
+
+				for j := 0; j < readings; j++ {
+					// The shared order is the order the readings are taken in
+					mux.Lock()
+					now := clock.Now()
+					order = append(order, now)
+					mux.Unlock()
+
+					// Each worker (as each account) never observes the clock going backwards
+					if now.Before(previous) {
+						t.Errorf("clock went backwards, %s before %s", now, previous)
+					}
+
+					previous = now
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		for i := 1; i < len(order); i++ {
+			if order[i].Before(order[i-1]) {
+				t.Fatalf("reading %d went backwards, %s before %s", i, order[i], order[i-1])
+			}
+		}
+	})
+
+	t.Run("readings survive serialization", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			clock = NewClock()
+			first = clock.Now()
+		)
+
+		// The readings carry no monotonic clock, so they are compared by their wall times
+		assert.Equal(t, first.Round(0), first)
+		assert.False(t, first.Before(clock.Anchor().Wall))
+	})
+}
+
+func TestClock_Anchor(t *testing.T) {
+	t.Parallel()
+
+	anchor := NewClock().Anchor()
+
+	assert.NotEmpty(t, anchor.Host)
+	assert.False(t, anchor.Drifted())
+
+	assert.True(t, (&ClockAnchor{Drift: ClockDriftBudget + 1}).Drifted())
+	assert.True(t, (&ClockAnchor{Drift: -ClockDriftBudget - 1}).Drifted())
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import "time"
+
+// AccountDispatch is the dispatch window of a single account
+type AccountDispatch struct {
+	First time.Time `json:"first"` // the time the first account tx was sent
+	Last  time.Time `json:"last"`  // the time the last account tx was sent
+	Txs   int       `json:"txs"`
+}
+
+// DispatchResult is the per-account dispatch fairness of the broadcast
+type DispatchResult struct {
+	Order    string                      `json:"order"`
+	Accounts map[string]*AccountDispatch `json:"accounts"`
+
+	// FirstSpread is the time between the first and the last account starting to dispatch.
+	// It is the longest an account nonce sits idle before its first broadcast
+	FirstSpread time.Duration `json:"firstSpread"`
+
+	// LastSpread is the time between the first and the last account finishing to dispatch
+	LastSpread time.Duration `json:"lastSpread"`
+
+	// MeanWindow is the mean time between the first and last dispatch of an account
+	MeanWindow time.Duration `json:"meanWindow"`
+}
+
+// NewDispatchResult summarizes the account dispatch windows.
+// Returns nil if there are no accounts
+func NewDispatchResult(order string, accounts map[string]*AccountDispatch) *DispatchResult {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	var (
+		result = &DispatchResult{
+			Order:    order,
+			Accounts: accounts,
+		}
+
+		earliestFirst, latestFirst time.Time
+		earliestLast, latestLast   time.Time
+		total                      time.Duration
+	)
+
+	for _, account := range accounts {
+		if earliestFirst.IsZero() || account.First.Before(earliestFirst) {
+			earliestFirst = account.First
+		}
+
+		if latestFirst.IsZero() || account.First.After(latestFirst) {
+			latestFirst = account.First
+		}
+
+		if earliestLast.IsZero() || account.Last.Before(earliestLast) {
+			earliestLast = account.Last
+		}
+
+		if latestLast.IsZero() || account.Last.After(latestLast) {
+			latestLast = account.Last
+		}
+
+		total += account.Last.Sub(account.First)
+	}
+
+	result.FirstSpread = latestFirst.Sub(earliestFirst)
+	result.LastSpread = latestLast.Sub(earliestLast)
+	result.MeanWindow = total / time.Duration(len(accounts))
+
+	return result
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatch_NewDispatchResult(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	result := NewDispatchResult("sequential", map[string]*AccountDispatch{
+		"g1a": {First: start, Last: start.Add(2 * time.Second), Txs: 2},
+		"g1b": {First: start.Add(3 * time.Second), Last: start.Add(4 * time.Second), Txs: 2},
+	})
+
+	assert.Equal(t, "sequential", result.Order)
+	assert.Equal(t, 3*time.Second, result.FirstSpread)
+	assert.Equal(t, 2*time.Second, result.LastSpread)
+	assert.Equal(t, 1500*time.Millisecond, result.MeanWindow)
+
+	assert.Nil(t, NewDispatchResult("interleaved", nil))
+}
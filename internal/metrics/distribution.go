@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Distribution is the summary of a duration sample distribution
+type Distribution struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// NewDistribution summarizes the given samples.
+// Returns nil if there are no samples
+func NewDistribution(samples []time.Duration) *Distribution {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var total time.Duration
+	for _, sample := range sorted {
+		total += sample
+	}
+
+	return &Distribution{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P95:   percentile(sorted, 95),
+		P99:   percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistribution_NewDistribution(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no samples", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, NewDistribution(nil))
+	})
+
+	t.Run("valid samples", func(t *testing.T) {
+		t.Parallel()
+
+		samples := make([]time.Duration, 0, 100)
+
+		// Add the samples in reverse order
+		for i := 100; i > 0; i-- {
+			samples = append(samples, time.Duration(i)*time.Millisecond)
+		}
+
+		distribution := NewDistribution(samples)
+
+		assert.Equal(t, 100, distribution.Count)
+		assert.Equal(t, time.Millisecond, distribution.Min)
+		assert.Equal(t, 100*time.Millisecond, distribution.Max)
+		assert.Equal(t, 50500*time.Microsecond, distribution.Mean)
+		assert.Equal(t, 50*time.Millisecond, distribution.P50)
+		assert.Equal(t, 90*time.Millisecond, distribution.P90)
+		assert.Equal(t, 95*time.Millisecond, distribution.P95)
+		assert.Equal(t, 99*time.Millisecond, distribution.P99)
+
+		// Make sure the original samples are not sorted
+		assert.Equal(t, 100*time.Millisecond, samples[0])
+	})
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import "time"
+
+// TxTiming is the lifecycle timing of a single committed transaction.
+// The commit time is the block time reported by the node, so the
+// attribution is only as exact as the clock sync between the hosts
+type TxTiming struct {
+	Sent      time.Time // the time the broadcast request was sent
+	Accepted  time.Time // the time the broadcast response was received
+	LastSeen  time.Time // the time the tx was last sampled in the mempool, if ever
+	Committed time.Time // the time of the block the tx was included in
+}
+
+// LatencyAttribution is the decomposition of the commit latency into
+// the time to enter the mempool (broadcast response time), the mempool wait,
+// and the block interval remainder. The attribution is approximate,
+// since the mempool residency is sampled
+type LatencyAttribution struct {
+	Approximate    bool          `json:"approximate"`
+	SampleInterval time.Duration `json:"sampleInterval"`
+	Transactions   int           `json:"numTransactions"`
+	Sampled        int           `json:"sampledTransactions"` // txs observed in the mempool
+
+	EnterMempool   *Distribution `json:"enterMempool,omitempty"`
+	MempoolWait    *Distribution `json:"mempoolWait,omitempty"`
+	BlockRemainder *Distribution `json:"blockRemainder,omitempty"`
+	Commit         *Distribution `json:"commit,omitempty"`
+}
+
+// NewLatencyAttribution decomposes the commit latency of the given transactions
+func NewLatencyAttribution(timings []TxTiming, sampleInterval time.Duration) *LatencyAttribution {
+	var (
+		enter     = make([]time.Duration, 0, len(timings))
+		wait      = make([]time.Duration, 0, len(timings))
+		remainder = make([]time.Duration, 0, len(timings))
+		commit    = make([]time.Duration, 0, len(timings))
+
+		sampled = 0
+	)
+
+	for _, timing := range timings {
+		enter = append(enter, nonNegative(timing.Accepted.Sub(timing.Sent)))
+		commit = append(commit, nonNegative(timing.Committed.Sub(timing.Sent)))
+
+		// Transactions that were never sampled in the mempool
+		// are attributed entirely to the block interval
+		leftMempool := timing.Accepted
+		if !timing.LastSeen.IsZero() {
+			sampled++
+
+			leftMempool = timing.LastSeen
+		}
+
+		wait = append(wait, nonNegative(leftMempool.Sub(timing.Accepted)))
+		remainder = append(remainder, nonNegative(timing.Committed.Sub(leftMempool)))
+	}
+
+	return &LatencyAttribution{
+		Approximate:    true,
+		SampleInterval: sampleInterval,
+		Transactions:   len(timings),
+		Sampled:        sampled,
+		EnterMempool:   NewDistribution(enter),
+		MempoolWait:    NewDistribution(wait),
+		BlockRemainder: NewDistribution(remainder),
+		Commit:         NewDistribution(commit),
+	}
+}
+
+// nonNegative clamps the duration to 0, to account for clock skew
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatency_NewLatencyAttribution(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sent     = time.Now()
+		accepted = sent.Add(100 * time.Millisecond)
+
+		timings = []TxTiming{
+			// Sampled in the mempool
+			{
+				Sent:      sent,
+				Accepted:  accepted,
+				LastSeen:  accepted.Add(time.Second),
+				Committed: accepted.Add(3 * time.Second),
+			},
+			// Never sampled in the mempool
+			{
+				Sent:      sent,
+				Accepted:  accepted,
+				Committed: accepted.Add(2 * time.Second),
+			},
+		}
+	)
+
+	attribution := NewLatencyAttribution(timings, time.Second)
+
+	assert.True(t, attribution.Approximate)
+	assert.Equal(t, len(timings), attribution.Transactions)
+	assert.Equal(t, 1, attribution.Sampled)
+
+	assert.Equal(t, 100*time.Millisecond, attribution.EnterMempool.Max)
+
+	// The unsampled transaction has no mempool wait
+	assert.Equal(t, time.Duration(0), attribution.MempoolWait.Min)
+	assert.Equal(t, time.Second, attribution.MempoolWait.Max)
+
+	// The block remainder is measured from the last mempool observation
+	assert.Equal(t, 2*time.Second, attribution.BlockRemainder.Min)
+	assert.Equal(t, 2*time.Second, attribution.BlockRemainder.Max)
+
+	assert.Equal(t, 3100*time.Millisecond, attribution.Commit.Max)
+}
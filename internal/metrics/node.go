@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	mempoolSizeSuffix     = "mempool_size"
+	consensusRoundsSuffix = "consensus_rounds"
+)
+
+// NodeMetrics is the node resource metrics timeline, scraped during the run
+type NodeMetrics struct {
+	URL      string        `json:"url"`
+	Interval time.Duration `json:"interval"`
+	Samples  []*NodeSample `json:"samples"`
+	Gaps     int           `json:"gaps"` // the number of failed scrapes
+
+	PeakMempoolSize    *float64 `json:"peakMempoolSize,omitempty"`
+	AvgConsensusRounds *float64 `json:"avgConsensusRounds,omitempty"`
+}
+
+// NodeSample is a single node metrics scrape.
+// Failed scrapes are recorded as gaps in the timeline
+type NodeSample struct {
+	Time   time.Time          `json:"time"`
+	Values map[string]float64 `json:"values,omitempty"`
+	Gap    bool               `json:"gap,omitempty"`
+}
+
+// NewNodeMetrics creates the node metrics timeline, and summarizes
+// the peak mempool size and average consensus round count, if scraped
+func NewNodeMetrics(url string, interval time.Duration, samples []*NodeSample) *NodeMetrics {
+	m := &NodeMetrics{
+		URL:      url,
+		Interval: interval,
+		Samples:  samples,
+	}
+
+	var (
+		peakMempool float64
+		rounds      float64
+
+		mempoolSamples int
+		roundSamples   int
+	)
+
+	for _, sample := range samples {
+		if sample.Gap {
+			m.Gaps++
+
+			continue
+		}
+
+		for name, value := range sample.Values {
+			switch {
+			case strings.HasSuffix(name, mempoolSizeSuffix):
+				if mempoolSamples == 0 || value > peakMempool {
+					peakMempool = value
+				}
+
+				mempoolSamples++
+			case strings.HasSuffix(name, consensusRoundsSuffix):
+				rounds += value
+				roundSamples++
+			}
+		}
+	}
+
+	if mempoolSamples > 0 {
+		m.PeakMempoolSize = &peakMempool
+	}
+
+	if roundSamples > 0 {
+		avgRounds := rounds / float64(roundSamples)
+		m.AvgConsensusRounds = &avgRounds
+	}
+
+	return m
+}
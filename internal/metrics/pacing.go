@@ -0,0 +1,83 @@
+package metrics
+
+import "time"
+
+// Rate controller actions, taken at the end of each window
+const (
+	RateIncrease = "increase"
+	RateBackoff  = "backoff"
+	RateHold     = "hold"
+)
+
+// PacingResult is the latency SLO-driven send rate trajectory of the run
+type PacingResult struct {
+	SLO        time.Duration `json:"slo"`
+	Window     time.Duration `json:"window"`
+	Trajectory []*RatePoint  `json:"trajectory"`
+
+	// SustainableRate is the send rate (tx/s) the controller converged on.
+	// If the SLO was never violated, the rate is only a lower bound
+	SustainableRate float64 `json:"sustainableRate"`
+	Converged       bool    `json:"converged"`
+}
+
+// RatePoint is the outcome of a single rate controller window
+type RatePoint struct {
+	Time    time.Time     `json:"time"`
+	Rate    float64       `json:"rate"` // the send rate (tx/s) held during the window
+	P95     time.Duration `json:"p95"`  // the rolling p95 commit latency
+	Samples int           `json:"samples"`
+	Overdue int           `json:"overdue"` // the uncommitted txs older than the SLO
+	Action  string        `json:"action"`
+}
+
+// Met returns true if the window commit latency was within the SLO
+func (p *RatePoint) Met() bool {
+	return p.Samples > 0 && p.Action != RateBackoff
+}
+
+// NewPacingResult creates the pacing result from the rate trajectory.
+// The sustainable rate is the average rate of the windows that met the SLO
+// after the first backoff, when the controller started oscillating around the
+// sustainable rate. If there was no backoff, it is the highest rate that met the SLO
+func NewPacingResult(slo, window time.Duration, trajectory []*RatePoint) *PacingResult {
+	result := &PacingResult{
+		SLO:        slo,
+		Window:     window,
+		Trajectory: trajectory,
+	}
+
+	var (
+		total float64
+		met   int
+	)
+
+	for _, point := range trajectory {
+		if point.Action == RateBackoff {
+			result.Converged = true
+
+			continue
+		}
+
+		if !point.Met() {
+			continue
+		}
+
+		if !result.Converged {
+			if point.Rate > result.SustainableRate {
+				result.SustainableRate = point.Rate
+			}
+
+			continue
+		}
+
+		total += point.Rate
+		met++
+	}
+
+	if met > 0 {
+		result.SustainableRate = total / float64(met)
+	}
+
+	return result
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacing_NewPacingResult(t *testing.T) {
+	t.Parallel()
+
+	newPoint := func(rate float64, action string) *RatePoint {
+		return &RatePoint{
+			Rate:    rate,
+			Samples: 10,
+			Action:  action,
+		}
+	}
+
+	t.Run("converged", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewPacingResult(time.Second, time.Second, []*RatePoint{
+			newPoint(100, RateIncrease),
+			newPoint(110, RateIncrease),
+			newPoint(120, RateBackoff),
+			newPoint(90, RateIncrease),
+			newPoint(100, RateHold),
+			newPoint(110, RateBackoff),
+		})
+
+		// Only the windows after the first backoff are averaged
+		assert.True(t, result.Converged)
+		assert.Equal(t, float64(95), result.SustainableRate)
+	})
+
+	t.Run("never violated", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewPacingResult(time.Second, time.Second, []*RatePoint{
+			{Rate: 100, Action: RateHold}, // no samples
+			newPoint(100, RateIncrease),
+			newPoint(110, RateIncrease),
+		})
+
+		assert.False(t, result.Converged)
+		assert.Equal(t, float64(110), result.SustainableRate)
+	})
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParsePrometheus parses the Prometheus text exposition format,
+// and returns the values of the given metrics. The samples of a metric
+// with multiple label sets are summed up. Missing metrics are omitted
+func ParsePrometheus(r io.Reader, names []string) (map[string]float64, error) {
+	wanted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+
+	var (
+		values  = make(map[string]float64)
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip the comments (HELP, TYPE) and empty lines
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, err := parseSample(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := wanted[name]; !ok {
+			continue
+		}
+
+		values[name] += value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read metrics, %w", err)
+	}
+
+	return values, nil
+}
+
+// parseSample parses a single sample line,
+// in the form of: name{labels} value [timestamp]
+func parseSample(line string) (string, float64, error) {
+	var (
+		name = line
+		rest string
+	)
+
+	if index := strings.IndexAny(line, "{ "); index != -1 {
+		name = line[:index]
+		rest = line[index:]
+	}
+
+	// Skip the labels, which can contain spaces
+	if strings.HasPrefix(rest, "{") {
+		end := strings.LastIndex(rest, "}")
+		if end == -1 {
+			return "", 0, fmt.Errorf("malformed metric labels: %q", line)
+		}
+
+		rest = rest[end+1:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", 0, fmt.Errorf("missing metric value: %q", line)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed metric value: %q", line)
+	}
+
+	return name, value, nil
+}
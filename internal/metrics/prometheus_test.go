@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheus_ParsePrometheus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid metrics", func(t *testing.T) {
+		t.Parallel()
+
+		input := `# HELP tendermint_mempool_size Size of the mempool
+# TYPE tendermint_mempool_size gauge
+tendermint_mempool_size{chain_id="dev"} 42
+process_cpu_seconds_total 12.5 1680000000000
+tendermint_consensus_rounds{chain_id="dev",label="with space"} 1
+tendermint_consensus_rounds{chain_id="other"} 2
+unwanted_metric 7
+`
+
+		values, err := ParsePrometheus(
+			strings.NewReader(input),
+			[]string{"tendermint_mempool_size", "process_cpu_seconds_total", "tendermint_consensus_rounds", "missing"},
+		)
+		if err != nil {
+			t.Fatalf("unable to parse metrics, %v", err)
+		}
+
+		assert.Equal(t, map[string]float64{
+			"tendermint_mempool_size":     42,
+			"process_cpu_seconds_total":   12.5,
+			"tendermint_consensus_rounds": 3,
+		}, values)
+	})
+
+	t.Run("malformed value", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParsePrometheus(strings.NewReader("metric abc\n"), []string{"metric"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNode_NewNodeMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("summary", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+
+		samples := []*NodeSample{
+			{
+				Time: now,
+				Values: map[string]float64{
+					"tendermint_mempool_size":     10,
+					"tendermint_consensus_rounds": 1,
+				},
+			},
+			{
+				Time: now.Add(time.Second),
+				Gap:  true,
+			},
+			{
+				Time: now.Add(2 * time.Second),
+				Values: map[string]float64{
+					"tendermint_mempool_size":     30,
+					"tendermint_consensus_rounds": 2,
+				},
+			},
+		}
+
+		node := NewNodeMetrics("http://node:26660/metrics", time.Second, samples)
+
+		assert.Equal(t, 1, node.Gaps)
+
+		if assert.NotNil(t, node.PeakMempoolSize) {
+			assert.Equal(t, float64(30), *node.PeakMempoolSize)
+		}
+
+		if assert.NotNil(t, node.AvgConsensusRounds) {
+			assert.Equal(t, 1.5, *node.AvgConsensusRounds)
+		}
+	})
+
+	t.Run("missing summary metrics", func(t *testing.T) {
+		t.Parallel()
+
+		node := NewNodeMetrics("http://node:26660/metrics", time.Second, []*NodeSample{
+			{
+				Time: time.Now(),
+				Values: map[string]float64{
+					"process_resident_memory_bytes": 1024,
+				},
+			},
+		})
+
+		assert.Nil(t, node.PeakMempoolSize)
+		assert.Nil(t, node.AvgConsensusRounds)
+	})
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import "time"
+
+// ReadResult is the outcome of the reads interleaved with the broadcasts
+type ReadResult struct {
+	Ratio            float64 `json:"ratio"`            // the reads issued per broadcast transaction
+	Queries          int     `json:"queries"`          // the number of distinct read queries
+	CountsAgainstTPS bool    `json:"countsAgainstTPS"` // flag indicating if the reads shared the send rate budget
+
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+
+	Latency *Distribution `json:"latency,omitempty"` // the read latency, including failed reads
+}
+
+// NewReadResult creates the read result from the read latencies
+func NewReadResult(
+	ratio float64,
+	queries int,
+	countsAgainstTPS bool,
+	latencies []time.Duration,
+	errors int,
+) *ReadResult {
+	result := &ReadResult{
+		Ratio:            ratio,
+		Queries:          queries,
+		CountsAgainstTPS: countsAgainstTPS,
+		Requests:         len(latencies),
+		Errors:           errors,
+		Latency:          NewDistribution(latencies),
+	}
+
+	if result.Requests > 0 {
+		result.ErrorRate = float64(errors) / float64(result.Requests)
+	}
+
+	return result
+}
@@ -0,0 +1,227 @@
+package metrics
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RPCMetrics are the HTTP request metrics,
+// grouped by the (traced) pipeline phase
+type RPCMetrics struct {
+	Phases map[string]*RPCPhaseMetrics `json:"phases"`
+}
+
+// RPCPhaseMetrics are the HTTP request metrics of a single pipeline phase
+type RPCPhaseMetrics struct {
+	Requests    int `json:"requests"`
+	Errors      int `json:"errors"`
+	ReusedConns int `json:"reusedConnections"`
+
+	DNS     *Distribution `json:"dns,omitempty"`
+	Connect *Distribution `json:"connect,omitempty"`
+	TLS     *Distribution `json:"tls,omitempty"`
+	TTFB    *Distribution `json:"ttfb,omitempty"`
+	Total   *Distribution `json:"total,omitempty"`
+}
+
+// phaseSamples are the raw request samples of a single phase
+type phaseSamples struct {
+	requests    int
+	errors      int
+	reusedConns int
+
+	dns     []time.Duration
+	connect []time.Duration
+	tls     []time.Duration
+	ttfb    []time.Duration
+	total   []time.Duration
+}
+
+// TracingTransport is an HTTP transport that captures the DNS, connect,
+// TLS handshake and time-to-first-byte timing for each request,
+// while a trace phase is active
+type TracingTransport struct {
+	base   http.RoundTripper
+	shared *TracingTransport // the transport the phase and samples are shared with, if any
+
+	mux     sync.Mutex
+	phase   string                   // the active trace phase, if any
+	samples map[string]*phaseSamples // phase -> samples
+}
+
+// NewTracingTransport creates a new tracing transport, wrapping the base transport
+func NewTracingTransport(base http.RoundTripper) *TracingTransport {
+	return &TracingTransport{
+		base:    base,
+		samples: make(map[string]*phaseSamples),
+	}
+}
+
+// Fork creates a tracing transport wrapping another base transport,
+// which shares the active trace phase and the samples of the transport
+func (t *TracingTransport) Fork(base http.RoundTripper) *TracingTransport {
+	return &TracingTransport{
+		base:   base,
+		shared: t.root(),
+	}
+}
+
+// root returns the transport holding the phase and samples
+func (t *TracingTransport) root() *TracingTransport {
+	if t.shared != nil {
+		return t.shared
+	}
+
+	return t
+}
+
+// SetPhase sets the active trace phase.
+// An empty phase disables tracing
+func (t *TracingTransport) SetPhase(phase string) {
+	t = t.root()
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.phase = phase
+}
+
+// activePhase returns the active trace phase, if any
+func (t *TracingTransport) activePhase() string {
+	t = t.root()
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	return t.phase
+}
+
+// RoundTrip executes the HTTP request, tracing it if a phase is active
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	phase := t.activePhase()
+	if phase == "" {
+		return t.base.RoundTrip(req)
+	}
+
+	var (
+		sample = &phaseSamples{}
+
+		dnsStart, connectStart, tlsStart, wroteRequest time.Time
+	)
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(_ httptrace.DNSStartInfo) {
+			dnsStart = time.Now()
+		},
+		DNSDone: func(_ httptrace.DNSDoneInfo) {
+			sample.dns = append(sample.dns, time.Since(dnsStart))
+		},
+		ConnectStart: func(_, _ string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				sample.connect = append(sample.connect, time.Since(connectStart))
+			}
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil {
+				sample.tls = append(sample.tls, time.Since(tlsStart))
+			}
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				sample.reusedConns++
+			}
+		},
+		WroteRequest: func(_ httptrace.WroteRequestInfo) {
+			wroteRequest = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			sample.ttfb = append(sample.ttfb, time.Since(wroteRequest))
+		},
+	}
+
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+
+	sample.total = append(sample.total, time.Since(start))
+	sample.requests = 1
+
+	if err != nil {
+		sample.errors = 1
+	}
+
+	t.record(phase, sample)
+
+	return resp, err
+}
+
+// CloseIdleConnections closes the idle connections of the base transport
+func (t *TracingTransport) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+
+	if base, ok := t.base.(closeIdler); ok {
+		base.CloseIdleConnections()
+	}
+}
+
+// record merges the request sample into the phase samples
+func (t *TracingTransport) record(phase string, sample *phaseSamples) {
+	t = t.root()
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	samples, ok := t.samples[phase]
+	if !ok {
+		samples = &phaseSamples{}
+		t.samples[phase] = samples
+	}
+
+	samples.requests += sample.requests
+	samples.errors += sample.errors
+	samples.reusedConns += sample.reusedConns
+
+	samples.dns = append(samples.dns, sample.dns...)
+	samples.connect = append(samples.connect, sample.connect...)
+	samples.tls = append(samples.tls, sample.tls...)
+	samples.ttfb = append(samples.ttfb, sample.ttfb...)
+	samples.total = append(samples.total, sample.total...)
+}
+
+// Metrics returns the aggregated request metrics for all traced phases
+func (t *TracingTransport) Metrics() *RPCMetrics {
+	t = t.root()
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	rpcMetrics := &RPCMetrics{
+		Phases: make(map[string]*RPCPhaseMetrics, len(t.samples)),
+	}
+
+	for phase, samples := range t.samples {
+		rpcMetrics.Phases[phase] = &RPCPhaseMetrics{
+			Requests:    samples.requests,
+			Errors:      samples.errors,
+			ReusedConns: samples.reusedConns,
+			DNS:         NewDistribution(samples.dns),
+			Connect:     NewDistribution(samples.connect),
+			TLS:         NewDistribution(samples.tls),
+			TTFB:        NewDistribution(samples.ttfb),
+			Total:       NewDistribution(samples.total),
+		}
+	}
+
+	return rpcMetrics
+}
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracingTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var (
+		numRequests = 5
+		phase       = "funding"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var (
+		transport = NewTracingTransport(http.DefaultTransport.(*http.Transport).Clone())
+		client    = &http.Client{Transport: transport}
+	)
+
+	defer transport.CloseIdleConnections()
+
+	execute := func() {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unable to execute request, %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	// Execute an untraced request
+	execute()
+
+	assert.Empty(t, transport.Metrics().Phases)
+
+	// Execute the traced requests
+	transport.SetPhase(phase)
+
+	for i := 0; i < numRequests; i++ {
+		execute()
+	}
+
+	transport.SetPhase("")
+
+	// Execute another untraced request
+	execute()
+
+	rpcMetrics := transport.Metrics()
+	assert.Len(t, rpcMetrics.Phases, 1)
+
+	phaseMetrics, ok := rpcMetrics.Phases[phase]
+	if !ok {
+		t.Fatal("traced phase metrics not found")
+	}
+
+	assert.Equal(t, numRequests, phaseMetrics.Requests)
+	assert.Equal(t, 0, phaseMetrics.Errors)
+
+	// The connection was opened by the untraced request
+	assert.Equal(t, numRequests, phaseMetrics.ReusedConns)
+	assert.Nil(t, phaseMetrics.Connect)
+	assert.Nil(t, phaseMetrics.TLS)
+
+	if phaseMetrics.TTFB == nil || phaseMetrics.Total == nil {
+		t.Fatal("request timings not recorded")
+	}
+
+	assert.Equal(t, numRequests, phaseMetrics.TTFB.Count)
+	assert.Equal(t, numRequests, phaseMetrics.Total.Count)
+}
+
+func TestTracingTransport_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Close the server right away, so requests fail
+	url := server.URL
+	server.Close()
+
+	var (
+		transport = NewTracingTransport(http.DefaultTransport.(*http.Transport).Clone())
+		client    = &http.Client{Transport: transport}
+	)
+
+	transport.SetPhase("broadcast")
+
+	if _, err := client.Get(url); err == nil {
+		t.Fatal("expected request error")
+	}
+
+	phaseMetrics, ok := transport.Metrics().Phases["broadcast"]
+	if !ok {
+		t.Fatal("traced phase metrics not found")
+	}
+
+	assert.Equal(t, 1, phaseMetrics.Requests)
+	assert.Equal(t, 1, phaseMetrics.Errors)
+	assert.Nil(t, phaseMetrics.Connect)
+}
+
+func TestTracingTransport_Fork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		phase = "broadcast"
+
+		servers = []*httptest.Server{
+			httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})),
+			httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})),
+		}
+	)
+
+	for _, server := range servers {
+		defer server.Close()
+	}
+
+	var (
+		transport = NewTracingTransport(http.DefaultTransport.(*http.Transport).Clone())
+		fork      = transport.Fork(http.DefaultTransport.(*http.Transport).Clone())
+		nested    = fork.Fork(http.DefaultTransport.(*http.Transport).Clone())
+	)
+
+	for _, tracer := range []*TracingTransport{transport, fork, nested} {
+		defer tracer.CloseIdleConnections()
+	}
+
+	// The fork follows the phase of the transport
+	transport.SetPhase(phase)
+
+	for index, tracer := range []*TracingTransport{transport, fork, nested} {
+		resp, err := (&http.Client{Transport: tracer}).Get(servers[index%len(servers)].URL)
+		if err != nil {
+			t.Fatalf("unable to execute request, %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	// Make sure the fork requests are recorded with the transport requests
+	for _, tracer := range []*TracingTransport{transport, fork} {
+		phaseMetrics, ok := tracer.Metrics().Phases[phase]
+		if !ok {
+			t.Fatal("traced phase metrics not found")
+		}
+
+		assert.Equal(t, 3, phaseMetrics.Requests)
+	}
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/runtime"
+)
+
+// newMintResult summarizes the storage growth of the committed token mints,
+// and their commit latency against the cumulative mints
+func newMintResult(
+	txs []*std.Tx,
+	batchResult *batcher.TxBatchResult,
+	commitTimes map[string]time.Time,
+	commitHeights map[string]int64,
+	metadataSize int,
+) (*collector.MintResult, error) {
+	var (
+		realm   string
+		sent    = make(map[string]time.Time, len(batchResult.TxHashes))
+		samples = make([]collector.MintSample, 0, len(commitTimes))
+	)
+
+	for index, txHash := range batchResult.TxHashes {
+		sent[string(txHash)] = batchResult.Timings[index].Sent
+	}
+
+	for _, tx := range txs {
+		if len(tx.Msgs) == 0 {
+			continue
+		}
+
+		// Substituted transactions are not mints
+		footprint, ok := runtime.MintFootprint(tx.Msgs[0])
+		if !ok {
+			continue
+		}
+
+		realm = tx.Msgs[0].(vm.MsgCall).PkgPath
+
+		txBin, err := amino.Marshal(tx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal transaction, %w", err)
+		}
+
+		txHash := string(types.Tx(txBin).Hash())
+
+		committed, ok := commitTimes[txHash]
+		if !ok {
+			// Lost mints never reach the storage
+			continue
+		}
+
+		// The block time is the commit time of the previous block,
+		// so the first block after an idle chain can predate the broadcast
+		latency := committed.Sub(sent[txHash])
+		if latency < 0 {
+			latency = 0
+		}
+
+		samples = append(samples, collector.MintSample{
+			Bytes:   footprint,
+			Height:  commitHeights[txHash],
+			Latency: latency,
+		})
+	}
+
+	return collector.NewMintResult(realm, metadataSize, samples), nil
+}
+
+// mintMetadataSize returns the metadata size of each minted token
+func (p *Pipeline) mintMetadataSize() int {
+	if p.cfg.MintMetadataSize == 0 {
+		return runtime.DefaultMintMetadataSize
+	}
+
+	return int(p.cfg.MintMetadataSize)
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMint_NewMintResult(t *testing.T) {
+	t.Parallel()
+
+	var (
+		caller = crypto.AddressFromPreimage([]byte("minter"))
+		sent   = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		txs           = make([]*std.Tx, 0)
+		batchResult   = &batcher.TxBatchResult{}
+		commitTimes   = make(map[string]time.Time)
+		commitHeights = make(map[string]int64)
+	)
+
+	// addTx adds the run transaction, committed after the given latency, if any
+	addTx := func(msg std.Msg, latency time.Duration, height int64) {
+		tx := &std.Tx{
+			Msgs: []std.Msg{msg},
+			Fee:  std.NewFee(400000, common.DefaultGasFee),
+		}
+
+		txBin, err := amino.Marshal(tx)
+		if err != nil {
+			t.Fatalf("unable to marshal tx, %v", err)
+		}
+
+		txHash := types.Tx(txBin).Hash()
+
+		txs = append(txs, tx)
+		batchResult.TxHashes = append(batchResult.TxHashes, txHash)
+		batchResult.Timings = append(batchResult.Timings, batcher.TxTiming{Sent: sent})
+
+		if height > 0 {
+			commitTimes[string(txHash)] = sent.Add(latency)
+			commitHeights[string(txHash)] = height
+		}
+	}
+
+	// newMint creates the mint call with the given id
+	newMint := func(id string) std.Msg {
+		return vm.MsgCall{
+			Caller:  caller,
+			PkgPath: "gno.land/r/demo/mint_1",
+			Func:    "Mint",
+			Args:    []string{id, "metadata"},
+		}
+	}
+
+	addTx(newMint("a"), time.Second, 1)
+	addTx(newMint("b"), 2*time.Second, 2)
+	addTx(newMint("c"), 0, 0)
+
+	// The block time lags the broadcast after an idle chain
+	addTx(newMint("d"), -time.Minute, 1)
+
+	// Substituted transactions are not mints
+	addTx(bank.MsgSend{FromAddress: caller, ToAddress: caller}, time.Second, 1)
+
+	result, err := newMintResult(txs, batchResult, commitTimes, commitHeights, 8)
+	if err != nil {
+		t.Fatalf("unable to summarize mints, %v", err)
+	}
+
+	assert.Equal(t, "gno.land/r/demo/mint_1", result.Realm)
+	assert.Equal(t, 8, result.MetadataSize)
+
+	// Lost mints never reach the storage
+	assert.Equal(t, 3, result.Mints)
+	assert.Equal(t, int64(27), result.EstimatedBytes)
+
+	if len(result.Trend) != 3 {
+		t.Fatalf("invalid number of trend points, %d", len(result.Trend))
+	}
+
+	assert.Equal(t, time.Second, result.Trend[0].Latency.P50)
+	assert.Equal(t, time.Duration(0), result.Trend[1].Latency.P50)
+	assert.Equal(t, 2*time.Second, result.Trend[2].Latency.P50)
+}
@@ -0,0 +1,245 @@
+package internal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/p2p"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+// mockChain is a pipeline client that simulates the chain.
+// Broadcast transactions are committed in a new block
+// every time the latest block height is fetched
+type mockChain struct {
+	mux sync.Mutex
+
+	balance std.Coins
+	pending []types.Tx
+	blocks  [][]types.Tx
+	times   []time.Time
+
+	closed bool
+
+	idleCloses int    // the number of idle connection teardowns
+	prewarms   int    // the number of connection pre-warms
+	txIndex    string // the tx indexer flag of the node status
+	gasUsed    int64  // the simulated gas used of each transaction
+
+	funcs map[string]string // the exported function signatures (JSON) of each deployed realm
+}
+
+func newMockChain(balance std.Coins) *mockChain {
+	return &mockChain{
+		balance: balance,
+		blocks:  [][]types.Tx{nil},
+		times:   []time.Time{time.Now()},
+	}
+}
+
+func (m *mockChain) GetAccount(address string) (*gnoland.GnoAccount, error) {
+	addr, err := crypto.AddressFromBech32(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gnoland.GnoAccount{
+		BaseAccount: *std.NewBaseAccount(addr, m.balance, nil, 0, 0),
+	}, nil
+}
+
+func (m *mockChain) SimulateTransaction(_ *std.Tx) (int64, error) {
+	return m.gasUsed, nil
+}
+
+func (m *mockChain) ExecuteABCIQuery(_ string, data []byte) (*core_types.ResultABCIQuery, error) {
+	res := &core_types.ResultABCIQuery{}
+
+	signatures, ok := m.funcs[string(data)]
+	if !ok {
+		res.Response.Error = abci.StringError("package not found")
+
+		return res, nil
+	}
+
+	res.Response.Data = []byte(signatures)
+
+	return res, nil
+}
+
+func (m *mockChain) BroadcastTransaction(_ *std.Tx) error {
+	return nil
+}
+
+func (m *mockChain) BroadcastTransactionSync(_ *std.Tx) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *mockChain) BroadcastRawTransactionSync(tx []byte) (*core_types.ResultBroadcastTx, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.pending = append(m.pending, tx)
+
+	return &core_types.ResultBroadcastTx{
+		Hash: types.Tx(tx).Hash(),
+	}, nil
+}
+
+func (m *mockChain) CreateBatch() common.Batch {
+	return &mockBatch{
+		chain: m,
+	}
+}
+
+func (m *mockChain) GetLatestBlockHeight() (int64, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if len(m.pending) > 0 {
+		m.blocks = append(m.blocks, m.pending)
+		m.times = append(m.times, time.Now())
+		m.pending = nil
+	}
+
+	return int64(len(m.blocks)), nil
+}
+
+func (m *mockChain) GetStatus() (*core_types.ResultStatus, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return &core_types.ResultStatus{
+		NodeInfo: p2p.NodeInfo{
+			Other: p2p.NodeInfoOther{TxIndex: m.txIndex},
+		},
+		SyncInfo: core_types.SyncInfo{
+			LatestBlockHeight: int64(len(m.blocks)),
+		},
+	}, nil
+}
+
+func (m *mockChain) GetBlock(height *int64) (*core_types.ResultBlock, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	txs := m.blocks[*height-1]
+
+	return &core_types.ResultBlock{
+		BlockMeta: &types.BlockMeta{
+			Header: types.Header{
+				Height: *height,
+				Time:   m.times[*height-1],
+				NumTxs: int64(len(txs)),
+			},
+		},
+		Block: &types.Block{
+			Data: types.Data{
+				Txs: txs,
+			},
+		},
+	}, nil
+}
+
+func (m *mockChain) GetCommit(_ *int64) (*core_types.ResultCommit, error) {
+	return &core_types.ResultCommit{}, nil
+}
+
+func (m *mockChain) GetValidators(height *int64) (*core_types.ResultValidators, error) {
+	return &core_types.ResultValidators{
+		BlockHeight: *height,
+	}, nil
+}
+
+func (m *mockChain) GetBlockResults(height *int64) (*core_types.ResultBlockResults, error) {
+	return &core_types.ResultBlockResults{
+		Height: *height,
+	}, nil
+}
+
+func (m *mockChain) GetBlockGasUsed(_ int64) (int64, error) {
+	return 1000, nil
+}
+
+func (m *mockChain) GetBlockGasLimit(_ int64) (int64, error) {
+	return 10000, nil
+}
+
+func (m *mockChain) GetTx(hash []byte) (*core_types.ResultTx, error) {
+	return &core_types.ResultTx{
+		Hash: hash,
+	}, nil
+}
+
+func (m *mockChain) GetUnconfirmedTxs(_ int) ([]types.Tx, error) {
+	return nil, nil
+}
+
+func (m *mockChain) Prewarm(_ int) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.prewarms++
+
+	return nil
+}
+
+func (m *mockChain) SetTracePhase(_ string) {}
+
+func (m *mockChain) Tracer() *metrics.TracingTransport {
+	return nil
+}
+
+func (m *mockChain) RPCMetrics() *metrics.RPCMetrics {
+	return nil
+}
+
+func (m *mockChain) CloseIdleConnections() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.idleCloses++
+}
+
+func (m *mockChain) Close() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.closed = true
+
+	return nil
+}
+
+// mockBatch is a batch broadcast to the simulated chain
+type mockBatch struct {
+	chain *mockChain
+	txs   [][]byte
+}
+
+func (b *mockBatch) AddTxBroadcast(tx []byte) error {
+	b.txs = append(b.txs, tx)
+
+	return nil
+}
+
+func (b *mockBatch) Execute() ([]interface{}, error) {
+	results := make([]interface{}, 0, len(b.txs))
+
+	for _, tx := range b.txs {
+		result, err := b.chain.BroadcastRawTransactionSync(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
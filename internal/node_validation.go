@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/gnolang/gno/pkgs/crypto/bip39"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/supernova/internal/client"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/nodecheck"
+	"github.com/gnolang/supernova/internal/signer"
+)
+
+var (
+	errNodeNotReady    = errors.New("node is not ready")
+	errInvalidKeyIndex = errors.New("invalid probe key index specified")
+)
+
+// NodeValidationConfig is the node validation configuration
+type NodeValidationConfig struct {
+	URL     string // the URL of the validated node
+	ChainID string // the chain ID used for signing the probe transaction
+
+	Mnemonic string // the mnemonic of the probe account, if any
+	KeyIndex uint64 // the derivation index of the probe account
+
+	Output string // the capability matrix JSON path, if any
+}
+
+// Validate validates the node validation configuration
+func (cfg *NodeValidationConfig) Validate() error {
+	// Make sure the URL is valid
+	if !urlRegex.MatchString(cfg.URL) {
+		return errInvalidURL
+	}
+
+	// Make sure the mnemonic is valid, if any
+	if cfg.Mnemonic != "" && !bip39.IsMnemonicValid(cfg.Mnemonic) {
+		return errInvalidMnemonic
+	}
+
+	// Make sure the probe account derivation index is valid
+	if cfg.KeyIndex > math.MaxUint32 {
+		return errInvalidKeyIndex
+	}
+
+	return nil
+}
+
+// ValidateNode exercises each dependency of the runs on the node, displays the
+// check matrix, and saves it as JSON, if set. Nodes failing any required check
+// are reported with an error, so orchestration can gate on the exit code
+func ValidateNode(cfg *NodeValidationConfig) error {
+	cli := client.NewHTTPClient(cfg.URL, 0)
+	defer cli.Close()
+
+	opts := make([]nodecheck.Option, 0, 1)
+
+	// The probe transaction is only broadcast with a key
+	if cfg.Mnemonic != "" {
+		kb := keys.NewInMemory()
+
+		info, err := kb.CreateAccount(
+			fmt.Sprintf("%s%d", common.KeybasePrefix, cfg.KeyIndex),
+			cfg.Mnemonic,
+			"",
+			common.EncryptPassword,
+			uint32(0),
+			uint32(cfg.KeyIndex),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create account with keybase, %w", err)
+		}
+
+		opts = append(
+			opts,
+			nodecheck.WithProbeAccount(
+				signer.NewKeybaseSigner(kb, cfg.ChainID, signer.WithVerification()),
+				info.GetAddress(),
+			),
+		)
+	}
+
+	report := nodecheck.NewValidator(cli, cfg.URL, opts...).Validate()
+
+	nodecheck.DisplayReport(os.Stdout, report)
+
+	if cfg.Output != "" {
+		if err := saveJSON(report, cfg.Output); err != nil {
+			return fmt.Errorf("unable to save the capability matrix, %w", err)
+		}
+
+		fmt.Printf("\n💾 Saved the capability matrix to %s\n", cfg.Output)
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%w, failed checks: %s", errNodeNotReady, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
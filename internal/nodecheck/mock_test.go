@@ -0,0 +1,97 @@
+package nodecheck
+
+import (
+	"github.com/gnolang/gno/gnoland"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+type (
+	getStatusDelegate                   func() (*core_types.ResultStatus, error)
+	getAccountDelegate                  func(string) (*gnoland.GnoAccount, error)
+	executeABCIQueryDelegate            func(string, []byte) (*core_types.ResultABCIQuery, error)
+	broadcastRawTransactionSyncDelegate func([]byte) (*core_types.ResultBroadcastTx, error)
+	getBlockDelegate                    func(*int64) (*core_types.ResultBlock, error)
+	getBlockResultsDelegate             func(*int64) (*core_types.ResultBlockResults, error)
+	pingBatchDelegate                   func() error
+)
+
+type mockClient struct {
+	getStatusFn                   getStatusDelegate
+	getAccountFn                  getAccountDelegate
+	executeABCIQueryFn            executeABCIQueryDelegate
+	broadcastRawTransactionSyncFn broadcastRawTransactionSyncDelegate
+	getBlockFn                    getBlockDelegate
+	getBlockResultsFn             getBlockResultsDelegate
+	pingBatchFn                   pingBatchDelegate
+}
+
+func (m *mockClient) GetStatus() (*core_types.ResultStatus, error) {
+	if m.getStatusFn != nil {
+		return m.getStatusFn()
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
+	if m.getAccountFn != nil {
+		return m.getAccountFn(address)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error) {
+	if m.executeABCIQueryFn != nil {
+		return m.executeABCIQueryFn(path, data)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) BroadcastRawTransactionSync(tx []byte) (*core_types.ResultBroadcastTx, error) {
+	if m.broadcastRawTransactionSyncFn != nil {
+		return m.broadcastRawTransactionSyncFn(tx)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetBlock(height *int64) (*core_types.ResultBlock, error) {
+	if m.getBlockFn != nil {
+		return m.getBlockFn(height)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetBlockResults(height *int64) (*core_types.ResultBlockResults, error) {
+	if m.getBlockResultsFn != nil {
+		return m.getBlockResultsFn(height)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) PingBatch() error {
+	if m.pingBatchFn != nil {
+		return m.pingBatchFn()
+	}
+
+	return nil
+}
+
+type signTxDelegate func(*std.Tx, *gnoland.GnoAccount, uint64, string) error
+
+type mockSigner struct {
+	signTxFn signTxDelegate
+}
+
+func (m *mockSigner) SignTx(tx *std.Tx, account *gnoland.GnoAccount, nonce uint64, passphrase string) error {
+	if m.signTxFn != nil {
+		return m.signTxFn(tx, account, nonce, passphrase)
+	}
+
+	return nil
+}
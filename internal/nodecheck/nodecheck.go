@@ -0,0 +1,416 @@
+// Package nodecheck validates a node is ready for stress test runs, by exercising
+// each dependency the run has on the node, and detecting the node capabilities
+package nodecheck
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/sdk"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+const (
+	// DefaultTimeout is the default timeout of the WebSocket check
+	DefaultTimeout = 10 * time.Second
+
+	// probeGasWanted is the gas wanted by the probe transaction
+	probeGasWanted = 100000
+
+	// signModeAminoJSON is the sign mode of the run transactions,
+	// the amino JSON encoding of the sign document
+	signModeAminoJSON = "amino-json"
+)
+
+// The node checks, in their run order
+const (
+	CheckStatus       = "status"
+	CheckAccount      = "account query"
+	CheckSimulation   = "simulation"
+	CheckBroadcast    = "broadcast"
+	CheckBlock        = "block fetch"
+	CheckBlockResults = "block results fetch"
+	CheckBatch        = "batch RPC"
+	CheckWebSocket    = "websocket subscribe"
+)
+
+var (
+	errNoProbeAccount   = errors.New("no probe account to use")
+	errSimulationFailed = errors.New("simulation query failed")
+)
+
+// Result is the outcome of a single node check
+type Result string
+
+const (
+	ResultPass Result = "pass" // the node passed the check
+	ResultFail Result = "fail" // the node failed the check
+	ResultSkip Result = "skip" // the check was not executed
+)
+
+// Check is a single node check
+type Check struct {
+	Name     string        `json:"name"`
+	Result   Result        `json:"result"`
+	Required bool          `json:"required"` // flag indicating if the runs depend on the check passing
+	Latency  time.Duration `json:"latency"`
+	Detail   string        `json:"detail,omitempty"`
+}
+
+// GasPriceBound is the bound on the node minimum gas price, detected
+// from the probe transaction. The exact minimum is not exposed by the node
+type GasPriceBound struct {
+	Price    string `json:"price"`    // the gas price of the probe transaction (fee / gas wanted)
+	Accepted bool   `json:"accepted"` // flag indicating if the minimum gas price is at most the price
+}
+
+// Capabilities are the detected node capabilities
+type Capabilities struct {
+	ChainID      string `json:"chainID,omitempty"`
+	NodeVersion  string `json:"nodeVersion,omitempty"`
+	LatestHeight int64  `json:"latestHeight"`
+
+	BatchRPC      bool `json:"batchRPC"`      // flag indicating if JSON-RPC batch requests are accepted
+	Subscriptions bool `json:"subscriptions"` // flag indicating if WebSocket event subscriptions are accepted
+
+	SignMode    string         `json:"signMode,omitempty"`    // the verified sign mode, if a probe was broadcast
+	MinGasPrice *GasPriceBound `json:"minGasPrice,omitempty"` // the minimum gas price bound, if a probe was broadcast
+}
+
+// Report is the node validation report
+type Report struct {
+	URL       string    `json:"url"`
+	CheckedAt time.Time `json:"checkedAt"`
+
+	Ready        bool          `json:"ready"` // flag indicating if all required checks passed
+	Checks       []*Check      `json:"checks"`
+	Capabilities *Capabilities `json:"capabilities"`
+}
+
+// Failed returns the names of the failed required checks
+func (r *Report) Failed() []string {
+	failed := make([]string, 0)
+
+	for _, check := range r.Checks {
+		if check.Required && check.Result == ResultFail {
+			failed = append(failed, check.Name)
+		}
+	}
+
+	return failed
+}
+
+type Client interface {
+	GetStatus() (*core_types.ResultStatus, error)
+	GetAccount(address string) (*gnoland.GnoAccount, error)
+	ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error)
+	BroadcastRawTransactionSync(tx []byte) (*core_types.ResultBroadcastTx, error)
+	GetBlock(height *int64) (*core_types.ResultBlock, error)
+	GetBlockResults(height *int64) (*core_types.ResultBlockResults, error)
+	PingBatch() error
+}
+
+type Signer interface {
+	SignTx(tx *std.Tx, account *gnoland.GnoAccount, nonce uint64, passphrase string) error
+}
+
+// Validator validates the node, by running each node check
+type Validator struct {
+	cli Client
+	url string
+
+	signer  Signer          // the probe transaction signer, if any
+	address *crypto.Address // the probe account address, if any
+
+	subscribe func() (bool, error) // the WebSocket subscription check
+}
+
+// NewValidator creates a new node validator for the given node URL
+func NewValidator(cli Client, url string, opts ...Option) *Validator {
+	v := &Validator{
+		cli: cli,
+		url: url,
+		subscribe: func() (bool, error) {
+			return subscribe(url, DefaultTimeout)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Validate runs the node checks, and returns the validation report.
+// Failing checks don't stop the validation, so the report covers the entire node
+func (v *Validator) Validate() *Report {
+	var (
+		report = &Report{
+			URL:          v.url,
+			CheckedAt:    time.Now(),
+			Checks:       make([]*Check, 0),
+			Capabilities: &Capabilities{},
+		}
+
+		caps = report.Capabilities
+
+		height  *int64              // the latest block height, if known
+		address = v.address         // the queried account address, if known
+		account *gnoland.GnoAccount // the queried account, if on chain
+	)
+
+	add := func(name string, required bool, run func() (string, error)) {
+		check := &Check{
+			Name:     name,
+			Required: required,
+		}
+
+		report.Checks = append(report.Checks, check)
+
+		start := time.Now()
+		detail, err := run()
+		check.Latency = time.Since(start)
+
+		switch {
+		case err == nil:
+			check.Result = ResultPass
+			check.Detail = detail
+		case errors.Is(err, errNoProbeAccount):
+			check.Result = ResultSkip
+			check.Latency = 0
+			check.Detail = detail
+		default:
+			check.Result = ResultFail
+			check.Detail = err.Error()
+		}
+	}
+
+	add(CheckStatus, true, func() (string, error) {
+		status, err := v.cli.GetStatus()
+		if err != nil {
+			return "", fmt.Errorf("unable to fetch status, %w", err)
+		}
+
+		latest := status.SyncInfo.LatestBlockHeight
+		height = &latest
+
+		caps.ChainID = status.NodeInfo.Network
+		caps.NodeVersion = status.NodeInfo.Version
+		caps.LatestHeight = latest
+
+		// Without a probe account, the validator account is queried
+		if address == nil {
+			validator := status.ValidatorInfo.Address
+			address = &validator
+		}
+
+		if status.SyncInfo.CatchingUp {
+			return fmt.Sprintf("chain %s, height %d, catching up", caps.ChainID, latest), nil
+		}
+
+		return fmt.Sprintf("chain %s, height %d", caps.ChainID, latest), nil
+	})
+
+	add(CheckAccount, true, func() (string, error) {
+		if address == nil {
+			return "no account to query", errNoProbeAccount
+		}
+
+		fetched, err := v.cli.GetAccount(address.String())
+		if err != nil {
+			return "", err
+		}
+
+		account = fetched
+
+		if fetched.Coins.IsZero() {
+			return fmt.Sprintf("%s, sequence %d, no balance", address, fetched.Sequence), nil
+		}
+
+		return fmt.Sprintf("%s, sequence %d, balance %s", address, fetched.Sequence, fetched.Coins), nil
+	})
+
+	add(CheckSimulation, false, func() (string, error) {
+		if address == nil {
+			return "no account to simulate with", errNoProbeAccount
+		}
+
+		tx, err := v.newProbeTx(*address, account)
+		if err != nil {
+			return "", err
+		}
+
+		return v.simulate(tx)
+	})
+
+	add(CheckBroadcast, true, func() (string, error) {
+		if v.signer == nil {
+			return "no key provided", errNoProbeAccount
+		}
+
+		if account == nil {
+			return "probe account not on chain", errNoProbeAccount
+		}
+
+		tx, err := v.newProbeTx(account.Address, account)
+		if err != nil {
+			return "", err
+		}
+
+		return v.broadcast(tx, caps)
+	})
+
+	add(CheckBlock, true, func() (string, error) {
+		block, err := v.cli.GetBlock(height)
+		if err != nil {
+			return "", fmt.Errorf("unable to fetch block, %w", err)
+		}
+
+		return fmt.Sprintf("height %d, %d txs", block.Block.Height, len(block.Block.Data.Txs)), nil
+	})
+
+	add(CheckBlockResults, true, func() (string, error) {
+		results, err := v.cli.GetBlockResults(height)
+		if err != nil {
+			return "", fmt.Errorf("unable to fetch block results, %w", err)
+		}
+
+		return fmt.Sprintf("height %d, %d tx results", results.Height, len(results.Results.DeliverTxs)), nil
+	})
+
+	// The batcher falls back to single requests if batches are rejected
+	add(CheckBatch, false, func() (string, error) {
+		if err := v.cli.PingBatch(); err != nil {
+			return "", err
+		}
+
+		caps.BatchRPC = true
+
+		return "batch requests accepted", nil
+	})
+
+	// The runs don't subscribe to events, the check is informational
+	add(CheckWebSocket, false, func() (string, error) {
+		subscribed, err := v.subscribe()
+		if err != nil {
+			return "", err
+		}
+
+		caps.Subscriptions = subscribed
+
+		if !subscribed {
+			return "connected, event subscriptions not supported", nil
+		}
+
+		return "connected, event subscriptions supported", nil
+	})
+
+	report.Ready = len(report.Failed()) == 0
+
+	return report
+}
+
+// newProbeTx creates the no-op probe transaction, a minimal transfer to self.
+// The transaction is only signed if there is a probe account, otherwise
+// it carries an empty signature, and is only fit for simulation
+func (v *Validator) newProbeTx(address crypto.Address, account *gnoland.GnoAccount) (*std.Tx, error) {
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: address,
+				ToAddress:   address,
+				Amount:      std.NewCoins(std.NewCoin(common.Denomination, 1)),
+			},
+		},
+		Fee: std.NewFee(probeGasWanted, common.DefaultGasFee),
+	}
+
+	if v.signer == nil || account == nil {
+		tx.Signatures = make([]std.Signature, 1)
+
+		return tx, nil
+	}
+
+	if err := v.signer.SignTx(tx, account, account.Sequence, common.EncryptPassword); err != nil {
+		return nil, fmt.Errorf("unable to sign probe transaction, %w", err)
+	}
+
+	return tx, nil
+}
+
+// simulate simulates the probe transaction. The check only verifies
+// the simulation endpoint, the simulated execution itself can fail
+func (v *Validator) simulate(tx *std.Tx) (string, error) {
+	txBin, err := amino.Marshal(tx)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal probe transaction, %w", err)
+	}
+
+	res, err := v.cli.ExecuteABCIQuery(".app/simulate", txBin)
+	if err != nil {
+		return "", fmt.Errorf("unable to simulate transaction, %w", err)
+	}
+
+	if res.Response.Error != nil {
+		return "", fmt.Errorf("%w, %v", errSimulationFailed, res.Response.Error)
+	}
+
+	var result sdk.Result
+	if err := amino.Unmarshal(res.Response.Value, &result); err != nil {
+		return "", fmt.Errorf("unable to unmarshal simulation result, %w", err)
+	}
+
+	if result.Error != nil {
+		return fmt.Sprintf("endpoint answered, probe execution failed (%v)", result.Error), nil
+	}
+
+	return fmt.Sprintf("probe gas used %d", result.GasUsed), nil
+}
+
+// broadcast broadcasts the probe transaction, and detects the sign mode
+// and minimum gas price bound from the node response
+func (v *Validator) broadcast(tx *std.Tx, caps *Capabilities) (string, error) {
+	txBin, err := amino.Marshal(tx)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal probe transaction, %w", err)
+	}
+
+	res, err := v.cli.BroadcastRawTransactionSync(txBin)
+	if err != nil {
+		return "", err
+	}
+
+	price := fmt.Sprintf("%s/%dgas", tx.Fee.GasFee, tx.Fee.GasWanted)
+
+	switch res.Error.(type) {
+	case nil:
+	case std.InsufficientFeeError:
+		caps.MinGasPrice = &GasPriceBound{
+			Price:    price,
+			Accepted: false,
+		}
+
+		return "", fmt.Errorf("probe rejected, minimum gas price above %s", price)
+	case std.UnauthorizedError:
+		return "", fmt.Errorf("probe signature rejected, check the chain ID, %v", res.Error)
+	default:
+		return "", fmt.Errorf("probe rejected, %v", res.Error)
+	}
+
+	// The node verified the probe signature
+	caps.SignMode = signModeAminoJSON
+	caps.MinGasPrice = &GasPriceBound{
+		Price:    price,
+		Accepted: true,
+	}
+
+	return fmt.Sprintf("probe accepted, hash %X", res.Hash), nil
+}
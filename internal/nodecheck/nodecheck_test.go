@@ -0,0 +1,255 @@
+package nodecheck
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/state"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/p2p"
+	"github.com/gnolang/gno/pkgs/sdk"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// newHealthyClient creates a client of a healthy node, at the given height
+func newHealthyClient(t *testing.T, height int64) *mockClient {
+	t.Helper()
+
+	simulation, err := amino.Marshal(sdk.Result{GasUsed: 1000})
+	if err != nil {
+		t.Fatalf("unable to marshal simulation result, %v", err)
+	}
+
+	return &mockClient{
+		getStatusFn: func() (*core_types.ResultStatus, error) {
+			return &core_types.ResultStatus{
+				NodeInfo: p2p.NodeInfo{
+					Network: "dev",
+					Version: "v1.0.0",
+				},
+				SyncInfo: core_types.SyncInfo{
+					LatestBlockHeight: height,
+				},
+			}, nil
+		},
+		getAccountFn: func(address string) (*gnoland.GnoAccount, error) {
+			addr, err := crypto.AddressFromString(address)
+			if err != nil {
+				return nil, err
+			}
+
+			return &gnoland.GnoAccount{
+				BaseAccount: *std.NewBaseAccount(
+					addr,
+					std.NewCoins(std.NewCoin(common.Denomination, 1000)),
+					nil,
+					0,
+					3,
+				),
+			}, nil
+		},
+		executeABCIQueryFn: func(_ string, _ []byte) (*core_types.ResultABCIQuery, error) {
+			return &core_types.ResultABCIQuery{
+				Response: abci.ResponseQuery{
+					Value: simulation,
+				},
+			}, nil
+		},
+		broadcastRawTransactionSyncFn: func(_ []byte) (*core_types.ResultBroadcastTx, error) {
+			return &core_types.ResultBroadcastTx{
+				Hash: []byte{0x1},
+			}, nil
+		},
+		getBlockFn: func(height *int64) (*core_types.ResultBlock, error) {
+			return &core_types.ResultBlock{
+				Block: &types.Block{
+					Header: types.Header{
+						Height: *height,
+					},
+				},
+			}, nil
+		},
+		getBlockResultsFn: func(height *int64) (*core_types.ResultBlockResults, error) {
+			return &core_types.ResultBlockResults{
+				Height:  *height,
+				Results: &state.ABCIResponses{},
+			}, nil
+		},
+	}
+}
+
+// getCheck returns the check with the given name
+func getCheck(t *testing.T, report *Report, name string) *Check {
+	t.Helper()
+
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+
+	t.Fatalf("check %s not found", name)
+
+	return nil
+}
+
+func TestValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		probeAddress = crypto.AddressFromPreimage([]byte("probe"))
+
+		noSubscriptions = func() (bool, error) {
+			return false, nil
+		}
+	)
+
+	t.Run("healthy node without a key", func(t *testing.T) {
+		t.Parallel()
+
+		v := NewValidator(newHealthyClient(t, 10), "http://127.0.0.1:26657")
+		v.subscribe = noSubscriptions
+
+		report := v.Validate()
+
+		assert.True(t, report.Ready)
+		assert.Len(t, report.Checks, 8)
+		assert.Equal(t, ResultSkip, getCheck(t, report, CheckBroadcast).Result)
+		assert.Equal(t, ResultPass, getCheck(t, report, CheckSimulation).Result)
+		assert.Equal(t, ResultPass, getCheck(t, report, CheckWebSocket).Result)
+
+		assert.Equal(t, "dev", report.Capabilities.ChainID)
+		assert.Equal(t, int64(10), report.Capabilities.LatestHeight)
+		assert.True(t, report.Capabilities.BatchRPC)
+		assert.False(t, report.Capabilities.Subscriptions)
+		assert.Empty(t, report.Capabilities.SignMode)
+		assert.Nil(t, report.Capabilities.MinGasPrice)
+	})
+
+	t.Run("probe transaction broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			cli    = newHealthyClient(t, 10)
+			signed = 0
+		)
+
+		signer := &mockSigner{
+			signTxFn: func(tx *std.Tx, account *gnoland.GnoAccount, nonce uint64, _ string) error {
+				signed++
+
+				assert.Equal(t, probeAddress, account.Address)
+				assert.Equal(t, uint64(3), nonce)
+
+				tx.Signatures = []std.Signature{{}}
+
+				return nil
+			},
+		}
+
+		v := NewValidator(cli, "http://127.0.0.1:26657", WithProbeAccount(signer, probeAddress))
+		v.subscribe = noSubscriptions
+
+		report := v.Validate()
+
+		assert.True(t, report.Ready)
+		assert.Equal(t, ResultPass, getCheck(t, report, CheckBroadcast).Result)
+
+		// Both the simulation and the broadcast probes are signed
+		assert.Equal(t, 2, signed)
+
+		assert.Equal(t, signModeAminoJSON, report.Capabilities.SignMode)
+		assert.Equal(t, &GasPriceBound{Price: "1ugnot/100000gas", Accepted: true}, report.Capabilities.MinGasPrice)
+	})
+
+	t.Run("probe rejected for fees", func(t *testing.T) {
+		t.Parallel()
+
+		cli := newHealthyClient(t, 10)
+		cli.broadcastRawTransactionSyncFn = func(_ []byte) (*core_types.ResultBroadcastTx, error) {
+			return &core_types.ResultBroadcastTx{
+				Error: std.InsufficientFeeError{},
+			}, nil
+		}
+
+		v := NewValidator(cli, "http://127.0.0.1:26657", WithProbeAccount(&mockSigner{}, probeAddress))
+		v.subscribe = noSubscriptions
+
+		report := v.Validate()
+
+		assert.False(t, report.Ready)
+		assert.Equal(t, []string{CheckBroadcast}, report.Failed())
+		assert.Empty(t, report.Capabilities.SignMode)
+		assert.Equal(t, &GasPriceBound{Price: "1ugnot/100000gas", Accepted: false}, report.Capabilities.MinGasPrice)
+	})
+
+	t.Run("optional check failures", func(t *testing.T) {
+		t.Parallel()
+
+		cli := newHealthyClient(t, 10)
+		cli.pingBatchFn = func() error {
+			return common.ErrBatchRejected
+		}
+
+		v := NewValidator(cli, "http://127.0.0.1:26657")
+		v.subscribe = func() (bool, error) {
+			return false, errSubscribeTimeout
+		}
+
+		report := v.Validate()
+
+		// The runs don't depend on batches or the WebSocket
+		assert.True(t, report.Ready)
+		assert.Equal(t, ResultFail, getCheck(t, report, CheckBatch).Result)
+		assert.Equal(t, ResultFail, getCheck(t, report, CheckWebSocket).Result)
+		assert.False(t, report.Capabilities.BatchRPC)
+	})
+
+	t.Run("unreachable node", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			errUnreachable = errors.New("connection refused")
+			heights        = make([]*int64, 0)
+		)
+
+		cli := newHealthyClient(t, 10)
+		cli.getStatusFn = func() (*core_types.ResultStatus, error) {
+			return nil, errUnreachable
+		}
+		cli.getBlockFn = func(height *int64) (*core_types.ResultBlock, error) {
+			heights = append(heights, height)
+
+			return nil, errUnreachable
+		}
+		cli.getBlockResultsFn = func(_ *int64) (*core_types.ResultBlockResults, error) {
+			return nil, errUnreachable
+		}
+
+		v := NewValidator(cli, "http://127.0.0.1:26657")
+		v.subscribe = noSubscriptions
+
+		report := v.Validate()
+
+		assert.False(t, report.Ready)
+		assert.Equal(t, []string{CheckStatus, CheckBlock, CheckBlockResults}, report.Failed())
+		assert.Equal(t, ResultSkip, getCheck(t, report, CheckAccount).Result)
+
+		// Without the status, the latest block is fetched
+		assert.Equal(t, []*int64{nil}, heights)
+
+		var buf bytes.Buffer
+
+		DisplayReport(&buf, report)
+
+		assert.Contains(t, buf.String(), "Node is not ready, failed required checks: status, block fetch, block results fetch")
+	})
+}
@@ -0,0 +1,14 @@
+package nodecheck
+
+import "github.com/gnolang/gno/pkgs/crypto"
+
+type Option func(v *Validator)
+
+// WithProbeAccount sets the account that signs and broadcasts
+// the no-op probe transaction, paying for its fee
+func WithProbeAccount(signer Signer, address crypto.Address) Option {
+	return func(v *Validator) {
+		v.signer = signer
+		v.address = &address
+	}
+}
@@ -0,0 +1,91 @@
+package nodecheck
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// DisplayReport displays the node check matrix, and the detected capabilities
+func DisplayReport(w io.Writer, report *Report) {
+	_, _ = fmt.Fprintf(w, "\n🩺 Node Validation (%s) 🩺\n\n", report.URL)
+
+	tw := tabwriter.NewWriter(w, 10, 20, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "Check\tResult\tRequired\tLatency\tDetail")
+
+	for _, check := range report.Checks {
+		latency := "-"
+		if check.Result != ResultSkip {
+			latency = check.Latency.Round(time.Microsecond).String()
+		}
+
+		_, _ = fmt.Fprintln(
+			tw,
+			fmt.Sprintf(
+				"%s\t%s\t%t\t%s\t%s",
+				check.Name,
+				displayResult(check.Result),
+				check.Required,
+				latency,
+				check.Detail,
+			),
+		)
+	}
+
+	_ = tw.Flush()
+
+	caps := report.Capabilities
+
+	_, _ = fmt.Fprintf(w, "\nCapabilities:\n")
+	_, _ = fmt.Fprintf(w, "  Chain ID: %s\n", displayValue(caps.ChainID))
+	_, _ = fmt.Fprintf(w, "  Node version: %s\n", displayValue(caps.NodeVersion))
+	_, _ = fmt.Fprintf(w, "  Batch RPC: %t\n", caps.BatchRPC)
+	_, _ = fmt.Fprintf(w, "  Event subscriptions: %t\n", caps.Subscriptions)
+	_, _ = fmt.Fprintf(w, "  Sign mode: %s\n", displayValue(caps.SignMode))
+	_, _ = fmt.Fprintf(w, "  Min gas price: %s\n", displayGasPrice(caps.MinGasPrice))
+
+	if failed := report.Failed(); len(failed) > 0 {
+		_, _ = fmt.Fprintf(w, "\n❌ Node is not ready, failed required checks: %s\n", strings.Join(failed, ", "))
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\n✅ Node is ready for runs\n")
+}
+
+// displayResult formats the check result
+func displayResult(result Result) string {
+	switch result {
+	case ResultPass:
+		return "✅ pass"
+	case ResultFail:
+		return "❌ fail"
+	default:
+		return "skip"
+	}
+}
+
+// displayValue formats the detected value, if any
+func displayValue(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+
+	return value
+}
+
+// displayGasPrice formats the minimum gas price bound, if any
+func displayGasPrice(bound *GasPriceBound) string {
+	if bound == nil {
+		return "unknown (no probe broadcast)"
+	}
+
+	if bound.Accepted {
+		return fmt.Sprintf("at most %s", bound.Price)
+	}
+
+	return fmt.Sprintf("above %s", bound.Price)
+}
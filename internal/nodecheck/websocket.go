@@ -0,0 +1,67 @@
+package nodecheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	rpcclient "github.com/gnolang/gno/pkgs/bft/rpc/lib/client"
+)
+
+const (
+	// websocketEndpoint is the node WebSocket endpoint
+	websocketEndpoint = "/websocket"
+
+	// subscribeQuery is the event query of the subscription check
+	subscribeQuery = "tm.event = 'NewBlock'"
+
+	// methodNotFoundCode is the JSON-RPC error code of unknown methods
+	methodNotFoundCode = -32601
+)
+
+var (
+	errSubscribeTimeout = errors.New("timed out waiting for the subscription response")
+	errWebSocketClosed  = errors.New("websocket closed before the subscription response")
+)
+
+// subscribe connects to the node WebSocket endpoint, and requests a block event
+// subscription. Nodes without event subscriptions still pass the check,
+// as long as the WebSocket connection answers
+func subscribe(url string, timeout time.Duration) (bool, error) {
+	ws := rpcclient.NewWSClient(url, websocketEndpoint, rpcclient.MaxReconnectAttempts(0))
+
+	if err := ws.Start(); err != nil {
+		return false, fmt.Errorf("unable to connect to the websocket, %w", err)
+	}
+
+	defer func() {
+		_ = ws.Stop()
+	}()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+	defer cancelFn()
+
+	if err := ws.Call(ctx, "subscribe", map[string]interface{}{"query": subscribeQuery}); err != nil {
+		return false, fmt.Errorf("unable to send the subscription request, %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return false, errSubscribeTimeout
+	case res, ok := <-ws.ResponsesCh:
+		if !ok {
+			return false, errWebSocketClosed
+		}
+
+		if res.Error == nil {
+			return true, nil
+		}
+
+		if res.Error.Code == methodNotFoundCode {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("subscription rejected, %w", res.Error)
+	}
+}
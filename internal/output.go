@@ -3,18 +3,179 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/compare"
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/gnolang/supernova/internal/runtime"
 )
 
-// displayResults displays the runtime result in the terminal
-func displayResults(result *collector.RunResult) {
-	w := tabwriter.NewWriter(os.Stdout, 10, 20, 2, ' ', 0)
+// displayResults displays the runtime result in the terminal,
+// with the summary values humanized, if necessary
+func displayResults(result *collector.RunResult, f summaryFormat) {
+	writeResults(os.Stdout, result, f)
+}
+
+// writeResults writes the runtime result summary
+func writeResults(out io.Writer, result *collector.RunResult, f summaryFormat) {
+	w := tabwriter.NewWriter(out, 10, 20, 2, ' ', 0)
+
+	// Run start //
+	if !result.StartTime.IsZero() {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"\nRun started at %s, took %s",
+				f.time(result.StartTime),
+				f.duration(result.EndTime.Sub(result.StartTime)),
+			),
+		)
+	}
+
+	// Sustainable rate //
+	if pacing := result.Pacing; pacing != nil {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"\nSustainable rate: %s tx/s (p95 commit latency SLO %s)",
+				f.rate(pacing.SustainableRate),
+				f.duration(pacing.SLO),
+			),
+		)
+
+		if !pacing.Converged {
+			_, _ = fmt.Fprintln(w, "⚠️ The SLO was never violated, the sustainable rate is only a lower bound")
+		}
+	}
+
+	// Duration run //
+	if duration := result.Duration; duration != nil {
+		displayDuration(w, f, duration)
+	}
 
 	// TPS //
-	_, _ = fmt.Fprintln(w, fmt.Sprintf("\nTPS: %d", result.AverageTPS))
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"\nTPS: %s%s",
+			f.count(int64(result.AverageTPS)),
+			baselineDelta(result.Baseline, compare.MetricAverageTPS),
+		),
+	)
+
+	if client := result.Client; client != nil && client.Suspected {
+		displayClientBottleneck(w, client)
+	}
+
+	if throughput := result.Throughput; throughput != nil {
+		displayThroughput(w, f, throughput, result.Baseline)
+	}
+
+	if drain := result.Drain; drain != nil {
+		displayDrain(w, f, drain)
+	}
+
+	if gas := result.GasWanted; gas != nil {
+		displayGasWanted(w, f, gas, result.Ceiling)
+	}
+
+	if result.Inclusion != nil {
+		displayInclusion(w, f, result.Inclusion)
+	}
+
+	if result.Mint != nil {
+		displayMint(w, f, result.Mint)
+	}
+
+	if result.Scenario != nil {
+		displayScenario(w, f, result.Scenario)
+	}
+
+	// Completion //
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"Committed: %s (lost %s, completion threshold %.2f%%)%s",
+			f.count(int64(result.CommittedTxs)),
+			f.count(int64(result.LostTxs)),
+			result.CompletionThreshold*100,
+			baselineDelta(result.Baseline, compare.MetricLostRatio),
+		),
+	)
+
+	if result.Baseline != nil {
+		displayBaseline(w, result.Baseline)
+	}
+
+	if len(result.Stalls) > 0 {
+		displayStalls(w, f, result.Stalls)
+	}
+
+	if result.CompletionThreshold < 1 {
+		_, _ = fmt.Fprintln(w, "⚠️ Partial completion allowed, results may not cover all transactions")
+	}
+
+	if result.BatchFallback {
+		_, _ = fmt.Fprintln(w, "⚠️ Batch requests were rejected, transactions were broadcast one by one")
+	}
+
+	if clock := result.Clock; clock != nil && clock.Drifted() {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"⚠️ The wall clock drifted %s during the broadcast, the broadcast timestamps follow the monotonic clock",
+				f.duration(clock.Drift.Round(time.Millisecond)),
+			),
+		)
+	}
+
+	for _, endpoint := range result.FailedEndpoints {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("⚠️ Endpoint %s failed, its broadcasts were reassigned", endpoint))
+	}
+
+	for _, endpoint := range result.Endpoints {
+		if endpoint.Lagging {
+			_, _ = fmt.Fprintln(w, fmt.Sprintf("⚠️ Endpoint %s lags behind the other endpoints", endpoint.URL))
+		}
+	}
+
+	for _, failure := range result.Degraded {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf("⚠️ Degraded: the %s integration failed (%s)", failure.Integration, failure.Error),
+		)
+	}
+
+	if indexer := result.Indexer; indexer != nil && len(indexer.Disabled) > 0 {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"⚠️ The node tx indexer is disabled (%s), the %s was skipped",
+				indexer.Source,
+				strings.Join(indexer.Disabled, ", "),
+			),
+		)
+	}
+
+	for _, failure := range result.ConstructionFailures {
+		action := "skipped"
+		if failure.Policy == string(runtime.ConstructionSubstitute) {
+			action = "substituted with a minimal transfer"
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf("⚠️ Transaction %d failed to be constructed (%s), %s", failure.Index, failure.Error, action),
+		)
+	}
 
 	// Block info //
 	_, _ = fmt.Fprintln(w, "\nBlock #\tGas Used\tGas Limit\tTransactions\tUtilization")
@@ -22,44 +183,1003 @@ func displayResults(result *collector.RunResult) {
 		_, _ = fmt.Fprintln(
 			w,
 			fmt.Sprintf(
-				"Block #%d\t%d\t%d\t%d\t%.2f%%",
+				"Block #%d\t%s\t%s\t%s\t%.2f%%",
 				block.Number,
-				block.GasUsed,
-				block.GasLimit,
-				block.Transactions,
+				blockGasUsed(f, block),
+				f.count(block.GasLimit),
+				f.count(block.Transactions),
 				(float64(block.GasUsed)/float64(block.GasLimit))*100,
 			),
 		)
 	}
 
+	if result.GasSource != "" && result.GasSource != collector.GasSourceBlockResults {
+		displayGasSource(w, f, result.GasSource, result.Backfill)
+	}
+
+	// Costs //
+	if costs := result.Costs; costs != nil {
+		_, _ = fmt.Fprintln(w, "\nCost\tAmount")
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf("Distributor\t%s (index %d)", costs.DistributorAddress, costs.DistributorIndex),
+		)
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Transaction cost\t%s %s", f.count(costs.TxCost), costs.Denom))
+
+		if costs.GenesisFunded {
+			_, _ = fmt.Fprintln(w, "Funding\tgenesis (no distribution)")
+		}
+
+		if costs.PlannedTransfers > 0 {
+			_, _ = fmt.Fprintln(
+				w,
+				fmt.Sprintf(
+					"Planned funding\t%s %s (%s transfers)",
+					f.count(costs.PlannedFunds),
+					costs.Denom,
+					f.count(int64(costs.PlannedTransfers)),
+				),
+			)
+		} else if costs.DepositDenom != "" {
+			_, _ = fmt.Fprintln(
+				w,
+				fmt.Sprintf(
+					"Sub-account cost\t%s %s + %s %s",
+					f.count(costs.AccountCost),
+					costs.Denom,
+					f.count(costs.AccountDeposit),
+					costs.DepositDenom,
+				),
+			)
+		} else {
+			_, _ = fmt.Fprintln(w, fmt.Sprintf("Sub-account cost\t%s %s", f.count(costs.AccountCost), costs.Denom))
+		}
+
+		if costs.StorageDeposit > 0 {
+			depositDenom := costs.Denom
+			if costs.DepositDenom != "" {
+				depositDenom = costs.DepositDenom
+			}
+
+			_, _ = fmt.Fprintln(w, fmt.Sprintf("Storage deposit\t%s %s", f.count(costs.StorageDeposit), depositDenom))
+			_, _ = fmt.Fprintln(w, fmt.Sprintf("Total deposits\t%s %s", f.count(costs.TotalDeposits), depositDenom))
+		}
+
+		if costs.PrimingTxs > 0 {
+			_, _ = fmt.Fprintln(
+				w,
+				fmt.Sprintf(
+					"Priming cost\t%s %s (%s transactions)",
+					f.count(costs.PrimingCost),
+					costs.Denom,
+					f.count(int64(costs.PrimingTxs)),
+				),
+			)
+		}
+
+		if costs.EstimatedTotal > 0 {
+			_, _ = fmt.Fprintln(w, fmt.Sprintf("Estimated balance\t%s %s", f.count(costs.EstimatedTotal), costs.Denom))
+		}
+
+		if costs.Spend != nil {
+			displaySpend(w, f, costs.Spend, costs.Denom)
+		}
+	}
+
+	// The recovered funds close out the costs table
+	if sweep := result.Sweep; sweep != nil {
+		displaySweep(w, f, sweep)
+	}
+
+	// Segments //
+	if len(result.Segments) > 0 {
+		_, _ = fmt.Fprintln(w, "\nSegment #\tStart\tEnd\tTransactions\tTPS")
+		for _, segment := range result.Segments {
+			_, _ = fmt.Fprintln(
+				w,
+				fmt.Sprintf(
+					"Segment #%d\t%s\t%s\t%s\t%s",
+					segment.Index,
+					segment.StartTime.Format(time.RFC3339),
+					segment.EndTime.Format(time.RFC3339),
+					f.count(int64(segment.Transactions)),
+					f.count(int64(segment.AverageTPS)),
+				),
+			)
+		}
+	}
+
+	// Dispatch fairness //
+	if result.Dispatch != nil {
+		displayDispatch(w, f, result.Dispatch)
+	}
+
+	// Signer backends //
+	if len(result.Signers) > 0 {
+		displaySigners(w, f, result.Signers)
+	}
+
+	// Interleaved reads //
+	if result.Reads != nil {
+		displayReads(w, f, result.Reads)
+	}
+
+	// Batch latency //
+	if len(result.BatchLatency) > 0 {
+		displayBatchLatency(w, f, result.BatchLatency)
+	}
+
+	// Endpoint assignments //
+	if len(result.EndpointAssignments) > 0 {
+		displayEndpointAssignments(w, f, result.EndpointAssignments)
+	}
+
+	// Endpoint breakdown //
+	if len(result.Endpoints) > 0 {
+		displayEndpoints(w, f, result.Endpoints)
+	}
+
+	// Latency attribution //
+	if result.Latency != nil {
+		displayLatencyAttribution(w, f, result.Latency)
+	}
+
+	// Send rate trajectory //
+	if result.Pacing != nil && len(result.Pacing.Trajectory) > 0 {
+		displayPacing(w, f, result.Pacing)
+	}
+
+	// Node metrics //
+	if result.Node != nil {
+		displayNodeMetrics(w, f, result.Node)
+	}
+
+	// Client metrics //
+	if result.Client != nil {
+		displayClientMetrics(w, f, result.Client)
+	}
+
+	// RPC metrics //
+	if result.RPC != nil && len(result.RPC.Phases) > 0 {
+		displayRPCMetrics(w, f, result.RPC)
+	}
+
+	// Phase breakdown //
+	_, _ = fmt.Fprintln(w, "\nPhase\tStart\tEnd\tDuration")
+	for _, phase := range result.Phases {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s",
+				phase.Name,
+				f.time(phase.StartTime),
+				f.time(phase.EndTime),
+				f.duration(phase.Duration),
+			),
+		)
+	}
+
 	_, _ = fmt.Fprintln(w, "")
 
 	_ = w.Flush()
 }
 
+// displayThroughput displays the peak, steady-state and end-to-end TPS,
+// with the window boundaries used for each
+func displayThroughput(
+	w io.Writer,
+	f summaryFormat,
+	throughput *collector.ThroughputResult,
+	baseline *collector.BaselineResult,
+) {
+	formatWindow := func(name string, window *collector.TPSWindow, delta string) string {
+		return fmt.Sprintf(
+			"%s TPS: %s (blocks #%d-#%d, %s)%s",
+			name,
+			f.rate(window.TPS),
+			window.StartBlock,
+			window.EndBlock,
+			f.duration(window.End.Sub(window.Start).Round(time.Millisecond)),
+			delta,
+		)
+	}
+
+	_, _ = fmt.Fprintln(w, formatWindow("Peak", throughput.Peak, baselineDelta(baseline, compare.MetricPeakTPS)))
+
+	if throughput.SteadyState != nil {
+		_, _ = fmt.Fprintln(
+			w,
+			formatWindow(
+				"Steady-state",
+				throughput.SteadyState,
+				baselineDelta(baseline, compare.MetricSteadyStateTPS),
+			),
+		)
+	} else {
+		_, _ = fmt.Fprintln(w, "⚠️ No steady state detected, the run is too short")
+	}
+
+	_, _ = fmt.Fprintln(w, formatWindow("End-to-end", throughput.EndToEnd, ""))
+}
+
+// unchangedDelta is the relative change (in percent) considered unchanged
+const unchangedDelta = 0.05
+
+// baselineDelta formats the delta of the metric against the baseline, if any
+func baselineDelta(baseline *collector.BaselineResult, name string) string {
+	if baseline == nil {
+		return ""
+	}
+
+	for _, m := range baseline.Metrics {
+		if m.Name != name {
+			continue
+		}
+
+		if math.Abs(m.DeltaPercent) < unchangedDelta {
+			return " (unchanged vs baseline)"
+		}
+
+		return fmt.Sprintf(" (%+.1f%% vs baseline)", m.DeltaPercent)
+	}
+
+	return ""
+}
+
+// displayBaseline displays the headline metrics against the baseline run
+func displayBaseline(w io.Writer, baseline *collector.BaselineResult) {
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("\nBaseline: run %s", baseline.RunID))
+	_, _ = fmt.Fprintln(w, "Metric\tBaseline\tCurrent\tDelta")
+
+	for _, m := range baseline.Metrics {
+		delta := fmt.Sprintf("%+.1f%%", m.DeltaPercent)
+		if math.Abs(m.DeltaPercent) < unchangedDelta {
+			delta = "unchanged"
+		}
+
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("%s\t%.4g\t%.4g\t%s", m.Name, m.Baseline, m.Current, delta))
+	}
+
+	for _, name := range baseline.Skipped {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("%s\t-\t-\tskipped", name))
+	}
+}
+
+// displayBatchLatency displays the batch latency, per message type
+func displayBatchLatency(w io.Writer, f summaryFormat, latencies map[string]*metrics.Distribution) {
+	msgTypes := make([]string, 0, len(latencies))
+	for msgType := range latencies {
+		msgTypes = append(msgTypes, msgType)
+	}
+
+	sort.Strings(msgTypes)
+
+	_, _ = fmt.Fprintln(w, "\nBatch Type\tBatches\tP50\tP90\tP99\tMax")
+	for _, msgType := range msgTypes {
+		latency := latencies[msgType]
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s\t%s\t%s",
+				msgType,
+				f.count(int64(latency.Count)),
+				f.duration(latency.P50),
+				f.duration(latency.P90),
+				f.duration(latency.P99),
+				f.duration(latency.Max),
+			),
+		)
+	}
+}
+
+// displayStalls displays the block production stalls observed during the collection
+func displayStalls(w io.Writer, f summaryFormat, stalls []*collector.StallResult) {
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("\n⚠️ Block production stalls: %d", len(stalls)))
+	_, _ = fmt.Fprintln(w, "Height\tStart\tDuration\tAvg. interval\tOutcome")
+
+	for _, stall := range stalls {
+		outcome := "resumed"
+		if !stall.Resumed {
+			outcome = "not resumed"
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%d\t%s\t%s\t%s\t%s",
+				stall.Height,
+				stall.Start.Format(time.RFC3339),
+				f.duration(stall.Duration.Round(time.Millisecond)),
+				f.duration(stall.AverageInterval.Round(time.Millisecond)),
+				outcome,
+			),
+		)
+	}
+}
+
+// blockGasUsed returns the block gas used, marked with its source if not the block results
+func blockGasUsed(f summaryFormat, block *collector.BlockResult) string {
+	switch block.GasSource {
+	case "", collector.GasSourceBlockResults:
+		return f.count(block.GasUsed)
+	case collector.GasSourceUnavailable:
+		return "n/a"
+	default:
+		return fmt.Sprintf("%s (%s)", f.count(block.GasUsed), block.GasSource)
+	}
+}
+
+// displayGasSource displays the source of the gas stats, when the block results were
+// unavailable, with the result codes of the backfilled transactions, if any
+func displayGasSource(w io.Writer, f summaryFormat, source string, backfill *collector.BackfillResult) {
+	if backfill == nil {
+		_, _ = fmt.Fprintln(w, "⚠️ Gas stats incomplete, the block results were unavailable (no tx backfill)")
+
+		return
+	}
+
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"⚠️ Gas stats from %s, %s of %s txs backfilled (%s resumed, %s failed queries)",
+			source,
+			f.count(int64(backfill.Queried)),
+			f.count(int64(backfill.Matched)),
+			f.count(int64(backfill.Resumed)),
+			f.count(int64(backfill.Failed)),
+		),
+	)
+
+	if backfill.Aborted {
+		_, _ = fmt.Fprintln(w, "⚠️ Tx backfill aborted, the tx endpoint was unavailable")
+	}
+
+	if len(backfill.Codes) == 0 {
+		return
+	}
+
+	codes := make([]string, 0, len(backfill.Codes))
+	for code := range backfill.Codes {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+
+	_, _ = fmt.Fprintln(w, "\nResult code\tTransactions")
+
+	for _, code := range codes {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("%s\t%s", code, f.count(int64(backfill.Codes[code]))))
+	}
+}
+
+// displaySigners displays the signing outcomes of each signer backend
+func displaySigners(w io.Writer, f summaryFormat, signers []*collector.SignerResult) {
+	_, _ = fmt.Fprintln(w, "\nSigner\tAccounts\tSigned\tFailed")
+
+	failed := 0
+
+	for _, signer := range signers {
+		failed += signer.Failed
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s",
+				signer.Backend,
+				f.count(int64(signer.Accounts)),
+				f.count(int64(signer.Signed)),
+				f.count(int64(signer.Failed)),
+			),
+		)
+	}
+
+	if failed > 0 {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("⚠️ %s signing requests failed", f.count(int64(failed))))
+	}
+}
+
+// displayDuration displays the broadcast outcome of the duration run
+func displayDuration(w io.Writer, f summaryFormat, duration *collector.DurationResult) {
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"\nDuration run: %s at %s tx/s, sent %s of %s txs",
+			f.duration(duration.Duration),
+			f.rate(duration.SendRate),
+			f.count(int64(duration.Sent)),
+			f.count(int64(duration.Target)),
+		),
+	)
+
+	if duration.TopUps > 0 {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("The sub-accounts were topped up %s times", f.count(int64(duration.TopUps))))
+	}
+
+	if duration.Recycles > 0 {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("♻️ Idle sub-accounts were recycled %s times", f.count(int64(duration.Recycles))))
+	}
+
+	if duration.Unsent > 0 {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"⚠️ %s of the %s generated txs were not sent before the deadline",
+				f.count(int64(duration.Unsent)),
+				f.count(int64(duration.Generated)),
+			),
+		)
+	}
+
+	if duration.DeadlineReached {
+		_, _ = fmt.Fprintln(w, "⚠️ The collection was finalized by the run deadline, uncommitted txs are counted as lost")
+	}
+}
+
+// displaySweep displays the leftover sub-account funds returned to the distributor
+func displaySweep(w io.Writer, f summaryFormat, sweep *collector.SweepResult) {
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"Recovered\t%s %s (%s sub-accounts swept, %s skipped, %s failed)",
+			f.count(sweep.Recovered),
+			sweep.Denom,
+			f.count(int64(sweep.Swept)),
+			f.count(int64(sweep.Skipped)),
+			f.count(int64(sweep.Failed)),
+		),
+	)
+
+	// Other denominations (such as storage deposit coins) are swept as well
+	if sweep.Coins != "" && sweep.Coins != fmt.Sprintf("%d%s", sweep.Recovered, sweep.Denom) {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Recovered coins\t%s", sweep.Coins))
+	}
+
+	if sweep.Failed > 0 {
+		_, _ = fmt.Fprintln(w, "⚠️ Some sub-accounts were not swept, run the sweep subcommand to recover their funds")
+	}
+}
+
+// displayScenario displays the constructed transactions of each scenario template
+func displayScenario(w io.Writer, f summaryFormat, scenario *collector.ScenarioResult) {
+	name := scenario.Name
+	if name == "" {
+		name = "unnamed"
+	}
+
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("\nScenario: %s (%d templates)", name, len(scenario.Templates)))
+	_, _ = fmt.Fprintln(w, "Template\tCall\tWeight\tTransactions")
+
+	for _, t := range scenario.Templates {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s.%s\t%d\t%s",
+				t.Name,
+				t.PkgPath,
+				t.Func,
+				t.Weight,
+				f.count(int64(t.Transactions)),
+			),
+		)
+	}
+}
+
+// displayDispatch displays the per-account dispatch fairness of the broadcast
+func displayDispatch(w io.Writer, f summaryFormat, dispatch *metrics.DispatchResult) {
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("\nDispatch order: %s (%d accounts)", dispatch.Order, len(dispatch.Accounts)))
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("First dispatch spread\t%s", f.duration(dispatch.FirstSpread.Round(time.Millisecond))))
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Last dispatch spread\t%s", f.duration(dispatch.LastSpread.Round(time.Millisecond))))
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Mean account window\t%s", f.duration(dispatch.MeanWindow.Round(time.Millisecond))))
+}
+
+// displayDrain displays the end-of-run mempool drain
+func displayDrain(w io.Writer, f summaryFormat, drain *collector.DrainResult) {
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"Drain: %s over %s blocks (%s txs committed after the broadcast, %s never committed)",
+			f.duration(drain.Duration.Round(time.Millisecond)),
+			f.count(int64(drain.Blocks)),
+			f.count(int64(drain.Transactions)),
+			f.count(int64(drain.Residual)),
+		),
+	)
+}
+
+// displayInclusion displays the inclusion proof verification,
+// with every failed sample, since failures discredit the run
+func displayInclusion(w io.Writer, f summaryFormat, inclusion *collector.InclusionResult) {
+	if inclusion.Credible {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"Inclusion proofs: %s/%s sampled commits verified (%s sampling)",
+				f.count(int64(inclusion.Verified)),
+				f.count(int64(len(inclusion.Samples))),
+				inclusion.Strategy,
+			),
+		)
+
+		return
+	}
+
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"❌ INCLUSION PROOFS FAILED: %s/%s sampled commits could not be verified, the reported commits are not credible",
+			f.count(int64(inclusion.Failed)),
+			f.count(int64(len(inclusion.Samples))),
+		),
+	)
+
+	for _, sample := range inclusion.Samples {
+		if sample.Verified {
+			continue
+		}
+
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("❌ tx %s at height %d: %s", sample.Hash, sample.Height, sample.Error))
+	}
+}
+
+// displayMint displays the realm storage growth of the token mints,
+// with the commit latency trend against the cumulative mints
+func displayMint(w io.Writer, f summaryFormat, mint *collector.MintResult) {
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"Mints: %s committed to %s (%s bytes of estimated storage, %s byte metadata)",
+			f.count(int64(mint.Mints)),
+			mint.Realm,
+			f.count(mint.EstimatedBytes),
+			f.count(int64(mint.MetadataSize)),
+		),
+	)
+
+	if len(mint.Trend) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "Cumulative Mints\tEstimated Storage\tCommit Latency (p50)\tCommit Latency (p95)")
+
+	for _, point := range mint.Trend {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s bytes\t%s\t%s",
+				f.count(int64(point.CumulativeMints)),
+				f.count(point.CumulativeBytes),
+				f.duration(point.Latency.P50.Round(time.Millisecond)),
+				f.duration(point.Latency.P95.Round(time.Millisecond)),
+			),
+		)
+	}
+
+	if mint.Slowdown > 0 {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Mint commit latency slowdown: %.2fx (last / first p50)", mint.Slowdown))
+	}
+}
+
+// displayGasWanted displays the run transaction gas wanted,
+// and the theoretical TPS ceiling it allows for, if known
+func displayGasWanted(w io.Writer, f summaryFormat, gas *collector.GasWantedResult, ceiling *collector.CeilingResult) {
+	source := "override"
+
+	switch {
+	case gas.Default:
+		source = "mode default"
+	case gas.Simulated > 0:
+		source = fmt.Sprintf("estimated from %s simulated, %.0f%% margin", f.count(gas.Simulated), gas.Margin*100)
+	}
+
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Gas wanted: %s per %s transaction (%s)", f.count(gas.Run), gas.Mode, source))
+
+	if ceiling == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"TPS ceiling: %s (%s txs per %s gas block, every %s)",
+			f.rate(ceiling.TPS),
+			f.count(ceiling.TxsPerBlock),
+			f.count(ceiling.BlockGasLimit),
+			f.duration(ceiling.BlockInterval.Round(time.Millisecond)),
+		),
+	)
+}
+
+// displaySpend displays the cumulative distributor spend against the cap,
+// with the spend timeline aggregated by spending site, in spend order
+func displaySpend(w io.Writer, f summaryFormat, spend *collector.SpendResult, denom string) {
+	if spend.Cap > 0 {
+		capped := ""
+		if spend.Capped {
+			capped = ", cap reached"
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"Spent\t%s %s of %s %s (%.1f%%%s)",
+				f.count(spend.Spent),
+				denom,
+				f.count(spend.Cap),
+				denom,
+				float64(spend.Spent)/float64(spend.Cap)*100,
+				capped,
+			),
+		)
+	} else {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Spent\t%s %s (uncapped)", f.count(spend.Spent), denom))
+	}
+
+	if len(spend.Events) == 0 {
+		return
+	}
+
+	type siteSpend struct {
+		site       string
+		first      time.Time
+		spends     int
+		amount     int64
+		cumulative int64
+	}
+
+	var (
+		sites  = make([]*siteSpend, 0)
+		lookup = make(map[string]*siteSpend)
+	)
+
+	for _, event := range spend.Events {
+		site, ok := lookup[event.Site]
+		if !ok {
+			site = &siteSpend{
+				site:  event.Site,
+				first: event.Time,
+			}
+
+			lookup[event.Site] = site
+			sites = append(sites, site)
+		}
+
+		site.spends++
+		site.amount += event.Amount
+		site.cumulative = event.Cumulative
+	}
+
+	_, _ = fmt.Fprintln(w, "\nSpend site\tStart\tSpends\tAmount\tCumulative")
+
+	for _, site := range sites {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s %s\t%s %s",
+				site.site,
+				site.first.Format(time.RFC3339),
+				f.count(int64(site.spends)),
+				f.count(site.amount),
+				denom,
+				f.count(site.cumulative),
+				denom,
+			),
+		)
+	}
+}
+
+// displayReads displays the interleaved read latency and error rate
+func displayReads(w io.Writer, f summaryFormat, reads *metrics.ReadResult) {
+	budget := "on top of the TPS"
+	if reads.CountsAgainstTPS {
+		budget = "counted against the TPS"
+	}
+
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"\nReads: %s (%.2f per tx, %s queries, %s)",
+			f.count(int64(reads.Requests)),
+			reads.Ratio,
+			f.count(int64(reads.Queries)),
+			budget,
+		),
+	)
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Read errors	%s (%.2f%%)", f.count(int64(reads.Errors)), reads.ErrorRate*100))
+
+	if latency := reads.Latency; latency != nil {
+		_, _ = fmt.Fprintln(w, "Read latency	P50	P90	P95	P99	Max")
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"	%s	%s	%s	%s	%s",
+				f.duration(latency.P50),
+				f.duration(latency.P90),
+				f.duration(latency.P95),
+				f.duration(latency.P99),
+				f.duration(latency.Max),
+			),
+		)
+	}
+}
+
+// displayEndpointAssignments displays the number of accounts assigned to each endpoint
+func displayEndpointAssignments(w io.Writer, f summaryFormat, assignments map[string]string) {
+	counts := make(map[string]int)
+	for _, endpoint := range assignments {
+		counts[endpoint]++
+	}
+
+	endpoints := make([]string, 0, len(counts))
+	for endpoint := range counts {
+		endpoints = append(endpoints, endpoint)
+	}
+
+	sort.Strings(endpoints)
+
+	_, _ = fmt.Fprintln(w, "\nEndpoint\tAccounts")
+	for _, endpoint := range endpoints {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("%s\t%s", endpoint, f.count(int64(counts[endpoint]))))
+	}
+}
+
+// displayEndpoints displays the broadcast outcome of each endpoint
+func displayEndpoints(w io.Writer, f summaryFormat, endpoints []*collector.EndpointResult) {
+	_, _ = fmt.Fprintln(w, "\nEndpoint\tTransactions\tCommitted\tLost\tCommit Latency (p50)\tCommit Latency (p95)")
+
+	for _, endpoint := range endpoints {
+		var (
+			url      = endpoint.URL
+			p50, p95 = "-", "-"
+		)
+
+		if latency := endpoint.CommitLatency; latency != nil {
+			p50 = f.duration(latency.P50.Round(time.Millisecond))
+			p95 = f.duration(latency.P95.Round(time.Millisecond))
+		}
+
+		switch {
+		case endpoint.Failed:
+			url += " (failed)"
+		case endpoint.Lagging:
+			url += " (lagging)"
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s\t%s\t%s",
+				url,
+				f.count(int64(endpoint.Transactions)),
+				f.count(int64(endpoint.Committed)),
+				f.count(int64(endpoint.Lost)),
+				p50,
+				p95,
+			),
+		)
+	}
+}
+
+// displayNodeMetrics displays the node resource metrics summary
+func displayNodeMetrics(w io.Writer, f summaryFormat, node *metrics.NodeMetrics) {
+	_, _ = fmt.Fprintln(w, "\nNode Metrics\tValue")
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Samples\t%s (%s gaps)", f.count(int64(len(node.Samples))), f.count(int64(node.Gaps))))
+
+	if node.PeakMempoolSize != nil {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Peak mempool size\t%s", f.count(int64(*node.PeakMempoolSize))))
+	}
+
+	if node.AvgConsensusRounds != nil {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Avg consensus rounds\t%.2f", *node.AvgConsensusRounds))
+	}
+}
+
+// displayClientBottleneck displays the client-side bottleneck warning, with its evidence
+func displayClientBottleneck(w io.Writer, client *metrics.ClientMetrics) {
+	_, _ = fmt.Fprintln(w, "⚠️ Client-side bottleneck suspected, the TPS may be limited by supernova itself:")
+
+	for _, evidence := range client.Evidence {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("  - %s", evidence))
+	}
+}
+
+// displayClientMetrics displays the supernova process resource summary, over the broadcast
+func displayClientMetrics(w io.Writer, f summaryFormat, client *metrics.ClientMetrics) {
+	_, _ = fmt.Fprintln(w, "\nClient Metrics\tValue")
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Samples\t%s", f.count(int64(len(client.Samples)))))
+
+	if client.AvgCPU != nil {
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Avg CPU (broadcast)\t%.1f%% of %d cores", *client.AvgCPU, client.CPUs))
+	}
+
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Peak goroutines\t%s", f.count(int64(client.PeakGoroutines))))
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"GC pause (broadcast)\t%s (%s cycles)",
+			f.duration(client.GCPause.Round(time.Microsecond)),
+			f.count(int64(client.GCCycles)),
+		),
+	)
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Dispatch starvation\t%s", f.duration(client.Starvation.Round(time.Millisecond))))
+}
+
+// displayPacing displays the send rate trajectory of the latency SLO controller
+func displayPacing(w io.Writer, f summaryFormat, pacing *metrics.PacingResult) {
+	_, _ = fmt.Fprintln(w, "\nWindow #\tRate (tx/s)\tP95\tSamples\tOverdue\tAction")
+
+	for index, point := range pacing.Trajectory {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"Window #%d\t%s\t%s\t%s\t%s\t%s",
+				index+1,
+				f.rate(point.Rate),
+				f.duration(point.P95.Round(time.Millisecond)),
+				f.count(int64(point.Samples)),
+				f.count(int64(point.Overdue)),
+				point.Action,
+			),
+		)
+	}
+}
+
+// displayLatencyAttribution displays the commit latency decomposition
+func displayLatencyAttribution(w io.Writer, f summaryFormat, latency *metrics.LatencyAttribution) {
+	_, _ = fmt.Fprintln(
+		w,
+		fmt.Sprintf(
+			"\n⚠️ Approximate latency attribution (mempool sampled every %s, %s/%s txs observed in the mempool)",
+			f.duration(latency.SampleInterval),
+			f.count(int64(latency.Sampled)),
+			f.count(int64(latency.Transactions)),
+		),
+	)
+
+	_, _ = fmt.Fprintln(w, "Latency\tP50\tP90\tP95\tMax")
+
+	stages := []struct {
+		name         string
+		distribution *metrics.Distribution
+	}{
+		{"enter mempool", latency.EnterMempool},
+		{"mempool wait", latency.MempoolWait},
+		{"block remainder", latency.BlockRemainder},
+		{"commit", latency.Commit},
+	}
+
+	for _, stage := range stages {
+		if stage.distribution == nil {
+			continue
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\t%s\t%s\t%s\t%s",
+				stage.name,
+				f.duration(stage.distribution.P50),
+				f.duration(stage.distribution.P90),
+				f.duration(stage.distribution.P95),
+				f.duration(stage.distribution.Max),
+			),
+		)
+	}
+}
+
+// displayRPCMetrics displays the traced request timings, per phase
+func displayRPCMetrics(w io.Writer, f summaryFormat, rpcMetrics *metrics.RPCMetrics) {
+	phases := make([]string, 0, len(rpcMetrics.Phases))
+	for phase := range rpcMetrics.Phases {
+		phases = append(phases, phase)
+	}
+
+	sort.Strings(phases)
+
+	_, _ = fmt.Fprintln(w, "\nRPC Phase\tTiming\tCount\tP50\tP90\tP99\tMax")
+	for _, phase := range phases {
+		phaseMetrics := rpcMetrics.Phases[phase]
+
+		timings := []struct {
+			name         string
+			distribution *metrics.Distribution
+		}{
+			{"dns", phaseMetrics.DNS},
+			{"connect", phaseMetrics.Connect},
+			{"tls", phaseMetrics.TLS},
+			{"ttfb", phaseMetrics.TTFB},
+			{"total", phaseMetrics.Total},
+		}
+
+		for _, timing := range timings {
+			if timing.distribution == nil {
+				continue
+			}
+
+			_, _ = fmt.Fprintln(
+				w,
+				fmt.Sprintf(
+					"%s\t%s\t%s\t%s\t%s\t%s\t%s",
+					phase,
+					timing.name,
+					f.count(int64(timing.distribution.Count)),
+					f.duration(timing.distribution.P50),
+					f.duration(timing.distribution.P90),
+					f.duration(timing.distribution.P99),
+					f.duration(timing.distribution.Max),
+				),
+			)
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%s\trequests\t%s (errors %s, reused connections %s)",
+				phase,
+				f.count(int64(phaseMetrics.Requests)),
+				f.count(int64(phaseMetrics.Errors)),
+				f.count(int64(phaseMetrics.ReusedConns)),
+			),
+		)
+	}
+}
+
 // saveResults saves the runtime results to a file
 func saveResults(result *collector.RunResult, path string) error {
+	return saveJSON(result, path)
+}
+
+// saveSegment saves the intermediate results segment to a file,
+// in the given run directory, and returns its path
+func saveSegment(segment *collector.SegmentResult, dir string) (string, error) {
+	path := filepath.Join(
+		dir,
+		fmt.Sprintf("results-segment-%04d.json", segment.Index),
+	)
+
+	if err := saveJSON(segment, path); err != nil {
+		return "", err
+	}
+
+	fmt.Printf("\n💾 Saved results segment %d to %s\n", segment.Index, path)
+
+	return path, nil
+}
+
+// saveJSON saves the JSON representation of the value to a file.
+// The file is written to a unique temporary file and renamed into place,
+// so concurrent runs saving to the same path never interleave their writes
+func saveJSON(v any, path string) error {
 	// Marshal the results
-	resultJSON, err := json.Marshal(result)
+	resultJSON, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("unable to marshal result, %w", err)
 	}
 
-	// Create the file
-	f, err := os.Create(path)
+	// Create the temporary file
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
 	if err != nil {
 		return fmt.Errorf("unable to create file, %w", err)
 	}
 
 	defer func() {
-		_ = f.Close()
+		_ = os.Remove(f.Name())
 	}()
 
 	// Write to file
-	_, err = f.Write(resultJSON)
-	if err != nil {
+	if _, err = f.Write(resultJSON); err != nil {
+		_ = f.Close()
+
 		return fmt.Errorf("unable to write to file, %w", err)
 	}
 
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to close file, %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("unable to replace file, %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,178 @@
+// Package pacing paces the transaction broadcasts,
+// to hold a commit latency service level objective
+package pacing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+const (
+	// minRate is the lowest send rate (tx/s) the controller backs off to
+	minRate = 1
+
+	// overdueRatio is the share of overdue transactions in a window
+	// that violates the SLO, even if the committed transactions do not
+	overdueRatio = 0.05
+)
+
+// LatencySource is the rolling commit latency source of the controller
+type LatencySource interface {
+	// Track tracks the commit latency of the given transactions, sent at the given time
+	Track(txs [][]byte, sent time.Time)
+
+	// P95 returns the rolling p95 commit latency, along with the sample count
+	P95() (time.Duration, int)
+
+	// Overdue returns the number of uncommitted transactions older than the bound
+	Overdue(bound time.Duration) int
+}
+
+// Config is the rate controller configuration
+type Config struct {
+	SLO    time.Duration // the p95 commit latency bound
+	Window time.Duration // the interval between rate adjustments
+
+	InitialRate   float64 // the starting send rate (tx/s)
+	IncreaseStep  float64 // the rate increase (tx/s) when comfortably under the SLO
+	BackoffFactor float64 // the rate multiplier when the SLO is violated
+	Headroom      float64 // the share of the SLO the p95 needs to be under, to increase the rate
+}
+
+// Controller is an additive-increase, multiplicative-decrease send rate controller.
+// The rate is increased while the rolling p95 commit latency is comfortably under the SLO,
+// and backed off when the SLO is violated, converging on a sustainable rate
+type Controller struct {
+	cfg    Config
+	source LatencySource
+
+	mux        sync.Mutex
+	rate       float64   // the current send rate (tx/s)
+	next       time.Time // the earliest time the next transactions can be sent
+	trajectory []*metrics.RatePoint
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewController creates a new rate controller, using the given latency source
+func NewController(cfg Config, source LatencySource) *Controller {
+	return &Controller{
+		cfg:    cfg,
+		source: source,
+		rate:   cfg.InitialRate,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts adjusting the send rate in the background, once every window
+func (c *Controller) Start() {
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.cfg.Window)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+				c.adjust()
+			}
+		}
+	}()
+}
+
+// Stop stops adjusting the send rate, and returns the rate trajectory.
+// It is safe to call multiple times
+func (c *Controller) Stop() *metrics.PacingResult {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+
+	<-c.done
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return metrics.NewPacingResult(c.cfg.SLO, c.cfg.Window, c.trajectory)
+}
+
+// Wait blocks until the given number of transactions can be sent at the current rate
+func (c *Controller) Wait(txs int) {
+	c.mux.Lock()
+
+	now := time.Now()
+	if c.next.Before(now) {
+		c.next = now
+	}
+
+	wait := c.next.Sub(now)
+	c.next = c.next.Add(time.Duration(float64(txs) / c.rate * float64(time.Second)))
+
+	c.mux.Unlock()
+
+	time.Sleep(wait)
+}
+
+// Track tracks the commit latency of the sent transactions
+func (c *Controller) Track(txs [][]byte, sent time.Time) {
+	c.source.Track(txs, sent)
+}
+
+// adjust adjusts the send rate based on the rolling p95 commit latency
+func (c *Controller) adjust() {
+	var (
+		p95, samples = c.source.P95()
+		overdue      = c.source.Overdue(c.cfg.SLO)
+	)
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	point := &metrics.RatePoint{
+		Time:    time.Now(),
+		Rate:    c.rate,
+		P95:     p95,
+		Samples: samples,
+		Overdue: overdue,
+		Action:  metrics.RateHold,
+	}
+
+	c.trajectory = append(c.trajectory, point)
+
+	switch {
+	case p95 > c.cfg.SLO || float64(overdue) > overdueRatio*float64(samples+overdue):
+		point.Action = metrics.RateBackoff
+
+		c.rate *= c.cfg.BackoffFactor
+		if c.rate < minRate {
+			c.rate = minRate
+		}
+
+		fmt.Printf(
+			"\n⚠️ Commit latency over the SLO (p95 %s, %d overdue), backing off to %.1f tx/s\n",
+			p95.Round(time.Millisecond),
+			overdue,
+			c.rate,
+		)
+	case samples == 0:
+		// Nothing committed yet, there is no signal to act on
+	case float64(p95) < float64(c.cfg.SLO)*(1-c.cfg.Headroom):
+		point.Action = metrics.RateIncrease
+
+		c.rate += c.cfg.IncreaseStep
+
+		fmt.Printf(
+			"\nCommit latency under the SLO (p95 %s), increasing to %.1f tx/s\n",
+			p95.Round(time.Millisecond),
+			c.rate,
+		)
+	}
+}
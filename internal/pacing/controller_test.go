@@ -0,0 +1,166 @@
+package pacing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestConfig creates a controller config with a 1s SLO
+func newTestConfig() Config {
+	return Config{
+		SLO:           time.Second,
+		Window:        time.Hour, // adjusted manually
+		InitialRate:   100,
+		IncreaseStep:  10,
+		BackoffFactor: 0.5,
+		Headroom:      0.2,
+	}
+}
+
+func TestController_Adjust(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name           string
+		p95            time.Duration
+		samples        int
+		overdue        int
+		expectedRate   float64
+		expectedAction string
+	}{
+		{
+			"comfortably under the SLO",
+			500 * time.Millisecond,
+			100,
+			0,
+			110,
+			metrics.RateIncrease,
+		},
+		{
+			"within the headroom",
+			900 * time.Millisecond,
+			100,
+			0,
+			100,
+			metrics.RateHold,
+		},
+		{
+			"SLO violated",
+			2 * time.Second,
+			100,
+			0,
+			50,
+			metrics.RateBackoff,
+		},
+		{
+			"overdue transactions",
+			500 * time.Millisecond,
+			10,
+			10,
+			50,
+			metrics.RateBackoff,
+		},
+		{
+			"no commits yet",
+			0,
+			0,
+			0,
+			100,
+			metrics.RateHold,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			source := &mockSource{
+				p95Fn: func() (time.Duration, int) {
+					return testCase.p95, testCase.samples
+				},
+				overdueFn: func(bound time.Duration) int {
+					assert.Equal(t, time.Second, bound)
+
+					return testCase.overdue
+				},
+			}
+
+			c := NewController(newTestConfig(), source)
+			c.Start()
+
+			c.adjust()
+
+			result := c.Stop()
+
+			if !assert.Len(t, result.Trajectory, 1) {
+				return
+			}
+
+			point := result.Trajectory[0]
+
+			// The recorded rate is the rate held during the window
+			assert.Equal(t, float64(100), point.Rate)
+			assert.Equal(t, testCase.expectedAction, point.Action)
+			assert.Equal(t, testCase.expectedRate, c.rate)
+		})
+	}
+}
+
+func TestController_Converge(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// The simulated chain holds the SLO up to 150 tx/s
+		capacity = float64(150)
+
+		c *Controller
+	)
+
+	source := &mockSource{
+		p95Fn: func() (time.Duration, int) {
+			if c.rate > capacity {
+				return 2 * time.Second, 100
+			}
+
+			return 500 * time.Millisecond, 100
+		},
+	}
+
+	c = NewController(newTestConfig(), source)
+	c.Start()
+
+	for i := 0; i < 50; i++ {
+		c.adjust()
+	}
+
+	result := c.Stop()
+
+	// Make sure the rate oscillates under the capacity
+	assert.True(t, result.Converged)
+	assert.LessOrEqual(t, result.SustainableRate, capacity)
+	assert.Greater(t, result.SustainableRate, capacity/2)
+}
+
+func TestController_Wait(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTestConfig()
+	cfg.InitialRate = 1000
+
+	c := NewController(cfg, &mockSource{})
+
+	start := time.Now()
+
+	// 5 batches of 20 txs at 1000 tx/s take at least 80ms,
+	// since the first batch is sent right away
+	for i := 0; i < 5; i++ {
+		c.Wait(20)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
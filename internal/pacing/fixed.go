@@ -0,0 +1,43 @@
+package pacing
+
+import (
+	"sync"
+	"time"
+)
+
+// FixedRate paces the transaction broadcasts at a constant send rate.
+// Unlike the controller it does not adapt, so it is used for measurement windows
+// where the offered load needs to be known upfront
+type FixedRate struct {
+	rate float64 // the send rate (tx/s)
+
+	mux  sync.Mutex
+	next time.Time // the earliest time the next transactions can be sent
+}
+
+// NewFixedRate creates a new fixed rate pacer, sending at the given rate (tx/s)
+func NewFixedRate(rate float64) *FixedRate {
+	return &FixedRate{
+		rate: rate,
+	}
+}
+
+// Wait blocks until the given number of transactions can be sent at the fixed rate
+func (f *FixedRate) Wait(txs int) {
+	f.mux.Lock()
+
+	now := time.Now()
+	if f.next.Before(now) {
+		f.next = now
+	}
+
+	wait := f.next.Sub(now)
+	f.next = f.next.Add(time.Duration(float64(txs) / f.rate * float64(time.Second)))
+
+	f.mux.Unlock()
+
+	time.Sleep(wait)
+}
+
+// Track is a no-op, since the fixed rate does not depend on the commit latency
+func (f *FixedRate) Track(_ [][]byte, _ time.Time) {}
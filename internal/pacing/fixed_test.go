@@ -0,0 +1,24 @@
+package pacing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedRate_Wait(t *testing.T) {
+	t.Parallel()
+
+	f := NewFixedRate(500)
+
+	start := time.Now()
+
+	// 4 batches of 25 txs at 500 tx/s take at least 150ms,
+	// since the first batch is sent right away
+	for i := 0; i < 4; i++ {
+		f.Wait(25)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
@@ -0,0 +1,37 @@
+package pacing
+
+import "time"
+
+type (
+	trackDelegate   func([][]byte, time.Time)
+	p95Delegate     func() (time.Duration, int)
+	overdueDelegate func(time.Duration) int
+)
+
+type mockSource struct {
+	trackFn   trackDelegate
+	p95Fn     p95Delegate
+	overdueFn overdueDelegate
+}
+
+func (m *mockSource) Track(txs [][]byte, sent time.Time) {
+	if m.trackFn != nil {
+		m.trackFn(txs, sent)
+	}
+}
+
+func (m *mockSource) P95() (time.Duration, int) {
+	if m.p95Fn != nil {
+		return m.p95Fn()
+	}
+
+	return 0, 0
+}
+
+func (m *mockSource) Overdue(bound time.Duration) int {
+	if m.overdueFn != nil {
+		return m.overdueFn(bound)
+	}
+
+	return 0
+}
@@ -1,24 +1,109 @@
 package internal
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
 	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/artifact"
 	"github.com/gnolang/supernova/internal/batcher"
 	"github.com/gnolang/supernova/internal/client"
 	"github.com/gnolang/supernova/internal/collector"
 	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/compare"
 	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/embedded"
+	"github.com/gnolang/supernova/internal/estimator"
+	"github.com/gnolang/supernova/internal/fees"
+	"github.com/gnolang/supernova/internal/history"
+	"github.com/gnolang/supernova/internal/inclusion"
+	"github.com/gnolang/supernova/internal/lifecycle"
+	"github.com/gnolang/supernova/internal/live"
+	"github.com/gnolang/supernova/internal/locks"
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/gnolang/supernova/internal/metrics"
+	"github.com/gnolang/supernova/internal/pacing"
+	"github.com/gnolang/supernova/internal/preflight"
+	"github.com/gnolang/supernova/internal/reads"
 	"github.com/gnolang/supernova/internal/runtime"
 	"github.com/gnolang/supernova/internal/signer"
+	"github.com/gnolang/supernova/internal/statelock"
+	"github.com/gnolang/supernova/internal/status"
+	"github.com/gnolang/supernova/internal/upload"
 	"github.com/schollz/progressbar/v3"
 )
 
+const (
+	resultsArtifact = "results.json"
+)
+
+var errNoTransactions = errors.New("no transactions were constructed")
+
+const (
+	phaseInitialize = "initialize"
+	phasePredeploy  = "predeploy"
+	phaseDistribute = "distribute"
+	phaseConstruct  = "construct"
+	phasePrime      = "prime"
+	phasePrewarm    = "prewarm"
+	phaseBatch      = "batch"
+	phaseCollect    = "collect"
+	phaseSweep      = "sweep"
+)
+
+// latencyPollInterval is the chain polling interval of the latency SLO monitor
+const latencyPollInterval = time.Second
+
+// readWorkers is the number of concurrent interleaved read workers
+const readWorkers = 16
+
+// lockRefreshes is the number of account lock refreshes within the lock TTL
+const lockRefreshes = 3
+
+const (
+	traceFunding   = "funding"
+	traceBroadcast = "broadcast"
+)
+
 type pipelineClient interface {
 	distributor.Client
 	batcher.Client
 	collector.Client
+	collector.StatusClient
+	collector.TxClient
+	preflight.Client
+	preflight.IndexerClient
+	preflight.QueryClient
+	inclusion.Client
+	estimator.Client
+
+	Prewarm(connections int) error
+	SetTracePhase(phase string)
+	Tracer() *metrics.TracingTransport
+	RPCMetrics() *metrics.RPCMetrics
+	CloseIdleConnections()
+	Close() error
+}
+
+// endpointClient is the client of a single broadcast endpoint, other than the cluster URL
+type endpointClient interface {
+	batcher.Client
+
+	Prewarm(connections int) error
+	CloseIdleConnections()
+	Close() error
 }
 
 type pipelineSigner interface {
@@ -28,81 +113,1460 @@ type pipelineSigner interface {
 // Pipeline is the central run point
 // for the stress test
 type Pipeline struct {
-	cfg *Config // the run configuration
+	cfg   *Config // the run configuration
+	runID string  // the unique run identifier
+
+	started time.Time // the wall time the run started
+
+	keybase keys.Keybase     // relevant keybase
+	cli     pipelineClient   // HTTP client connection
+	peers   []endpointClient // the broadcast endpoint clients, besides the cluster client
+	network string           // the chain ID discovered from the node status
+	node    *embedded.Node   // the in-process node, on embedded runs
+	signer  pipelineSigner   // the transaction signer
+
+	// fundingSigner is the funding transaction signer. The funding signatures
+	// are always verified locally, since the funding transactions are few
+	fundingSigner pipelineSigner
+
+	signers  *signer.Pool     // the per-account signer pool, shared by the run and funding signers
+	backends *signer.Backends // the externally managed account signer backends, if any
+
+	phases   []*collector.PhaseResult // the pipeline phase breakdown
+	excluded []crypto.Address         // the sub-accounts filtered out of the run
+	indices  map[string]uint32        // the derivation index of each account
+
+	txSetHash string // the constructed transaction set hash, for reproducible runs
+
+	constructionFailures []*collector.ConstructionFailure // the skipped / substituted transactions
+
+	queries []reads.Query // the interleaved read queries, if any
+
+	runAccounts []*gnoland.GnoAccount // the run sub-accounts, in their state after the distribution
+
+	lifecycle *lifecycle.Manager // the background component closers
+
+	integrations *integrations // the run integrations, by criticality
+
+	budget *distributor.Budget // the distributor spend of the invocation, against the cap
+
+	status *status.Writer // the run status file writer, if any
+	live   *live.Monitor  // the live progress monitor, if any
+
+	artifacts *artifact.Writer // the run artifact writer, if any
+
+	indexer *collector.IndexerResult // the detected node tx indexer availability
+
+	gasEstimate *estimator.Estimate // the simulated run gas estimate, if estimated
+
+	mixer runtime.Mixer // the scenario runtime, reporting the constructed template mix, if any
 
-	keybase keys.Keybase   // relevant keybase
-	cli     pipelineClient // HTTP client connection
-	signer  pipelineSigner // the transaction signer
+	sweep func() *collector.SweepResult // the sub-account sweep, executed once, if required
 }
 
 // NewPipeline creates a new pipeline instance
 func NewPipeline(cfg *Config) *Pipeline {
-	kb := keys.NewInMemory()
+	var (
+		kb = keys.NewInMemory()
+
+		// Each account is signed by its own backend,
+		// the mnemonic derived accounts by the keybase
+		signers = signer.NewPool(signer.NewKeybaseSigner(kb, cfg.ChainID, signerOptions(cfg.VerifySignatures)...))
+	)
+
+	p := &Pipeline{
+		cfg:           cfg,
+		runID:         shardRunID(newRunID(), cfg.shard),
+		keybase:       kb,
+		signer:        signers,
+		fundingSigner: signers.WithFallback(signer.NewKeybaseSigner(kb, cfg.ChainID, signer.WithVerification())),
+		signers:       signers,
+		indices:       make(map[string]uint32),
+		lifecycle:     lifecycle.NewManager(lifecycle.DefaultTimeout),
+		budget:        distributor.NewBudget(cfg.spendCap),
+	}
 
-	return &Pipeline{
-		cfg:     cfg,
-		keybase: kb,
-		cli:     client.NewHTTPClient(cfg.URL),
-		signer:  signer.NewKeybaseSigner(kb, cfg.ChainID),
+	// Embedded runs connect to the in-process node once it is started
+	if !cfg.Embedded {
+		p.cli = client.NewHTTPClient(cfg.URL, int(cfg.PrewarmConnections))
 	}
+
+	p.lifecycle.Register("rpc client", func() error {
+		if p.cli == nil {
+			return nil
+		}
+
+		return p.cli.Close()
+	})
+
+	return p
+}
+
+// Shutdown stops the background pipeline components,
+// in the reverse order they were started in.
+// It is safe to call multiple times, and from a different goroutine
+func (p *Pipeline) Shutdown() error {
+	return p.lifecycle.Shutdown()
 }
 
 // Execute runs the entire pipeline process
-func (p *Pipeline) Execute() error {
+func (p *Pipeline) Execute() (err error) {
+	p.started = time.Now()
+
+	// The output probe is no longer needed
+	// once the run is over, regardless of the outcome
+	defer p.cfg.Cleanup()
+
+	// The integration failures are handled by their criticality
+	p.integrations, err = newIntegrations()
+	if err != nil {
+		return err
+	}
+
+	// The background components are stopped once the run is over.
+	// Components that do not stop in time are reported, but do not fail the run
+	defer func() {
+		_ = p.Shutdown()
+	}()
+
+	// The mutable run artifacts are kept in the run working directory
+	if err := p.prepareRunDir(); err != nil {
+		return err
+	}
+
+	p.recordLogFile()
+
+	// Keep the run status file up to date, for external watchdogs.
+	// The final status is written once the background components are stopped
+	p.startStatus()
+
+	defer func() {
+		p.status.Finish(err)
+	}()
+
+	// Report the live progress, if required
+	if err := p.startLive(); err != nil {
+		return err
+	}
+
+	// The artifacts are written off the collection path
+	p.startArtifacts()
+
+	if shard := p.cfg.shard; shard != nil {
+		fmt.Printf(
+			"Running shard %d of %d, sub-accounts [%d, %d), %d of %d transactions\n",
+			shard.Index,
+			shard.Count,
+			shard.SubAccountOffset,
+			shard.SubAccountOffset+shard.SubAccounts,
+			shard.Transactions,
+			shard.TotalTxs,
+		)
+	}
+
+	// Embedded runs start the in-process node, targeted by all clients
+	if p.cfg.Embedded {
+		if err := p.startEmbedded(); err != nil {
+			return err
+		}
+	}
+
+	// Make sure the run does not target a production chain by accident
+	if err := p.checkProduction(); err != nil {
+		return err
+	}
+
+	// Make sure the collection features can rely on the node tx indexer
+	if err := p.checkIndexer(); err != nil {
+		return err
+	}
+
+	// Size probes run the probing procedure, instead of the stress test
+	if runtime.Type(p.cfg.Mode) == runtime.ProbeSize {
+		return p.executeSizeProbe()
+	}
+
+	// Replays broadcast previously prepared transactions
+	if p.cfg.ReplayDump != "" {
+		return p.executeReplay()
+	}
+
+	// Make sure the scenario realms can be called, before anything is sent
+	if p.cfg.scenario != nil {
+		if err := p.checkScenario(); err != nil {
+			return err
+		}
+	}
+
 	var (
-		mode = runtime.Type(p.cfg.Mode)
+		mode      = runtime.Type(p.cfg.Mode)
+		txRuntime = p.newRuntime(p.signer)
+	)
+
+	// Make sure no live run signs with the same accounts
+	ranges := []locks.Range{
+		{Start: uint32(p.cfg.DistributorIndex), End: uint32(p.cfg.DistributorIndex) + 1},
+		{Start: uint32(p.cfg.SubAccountOffset), End: uint32(p.cfg.SubAccountOffset + p.cfg.SubAccounts)},
+	}
+
+	if err := p.lockAccounts(ranges); err != nil {
+		return err
+	}
+
+	// Initialize the accounts for the runtime
+	phaseStart := p.startPhase(phaseInitialize)
+
+	accounts, err := p.initializeAccounts()
+	if err != nil {
+		return err
+	}
+
+	p.trackPhase(phaseInitialize, phaseStart)
+
+	// Predeploy any pending transactions
+	phaseStart = p.startPhase(phasePredeploy)
+
+	if err := prepareRuntime(mode, accounts, p.cli, txRuntime, p.budget); err != nil {
+		return err
+	}
+
+	p.trackPhase(phasePredeploy, phaseStart)
+
+	// Estimate the run gas once the runtime targets are deployed,
+	// so the sub-accounts are funded for the estimated fees
+	if p.cfg.EstimateGas {
+		if err := p.estimateGas(txRuntime, accounts); err != nil {
+			return err
+		}
+
+		// The estimated gas reprices the run, before anything is funded
+		if err := p.checkProductionSpend(); err != nil {
+			return err
+		}
+	}
+
+	// Distribute the funds to sub-accounts
+	phaseStart = p.startPhase(phaseDistribute)
+
+	distributorOpts := []distributor.Option{
+		distributor.WithRefusedAccounts(p.excluded),
+		distributor.WithDistributorIndex(uint32(p.cfg.DistributorIndex)),
+		distributor.WithBudget(p.budget),
+		distributor.WithFundingBatchSize(int(p.cfg.FundingBatch)),
+	}
+
+	if p.cfg.PipelinedFunding {
+		distributorOpts = append(distributorOpts, distributor.WithPipelinedFunding())
+	}
+
+	if len(p.cfg.plan) > 0 {
+		distributorOpts = append(distributorOpts, distributor.WithFundingPlan(p.cfg.plan))
+	}
+
+	if p.cfg.AssumeGenesisFunded {
+		distributorOpts = append(distributorOpts, distributor.WithGenesisFunding())
+	}
+
+	// The shards share the distributor, so concurrent funding would race on its sequence
+	if p.cfg.shard != nil {
+		distributorOpts = append(distributorOpts, distributor.WithoutFunding())
+	}
+
+	// Seed the sub-account states from the previous run, if any
+	if p.cfg.ReuseResults != "" {
+		predictionOpt, err := p.predictionOption()
+		if err != nil {
+			return err
+		}
+
+		distributorOpts = append(distributorOpts, predictionOpt)
+	}
+
+	// The distribution is funded by the same cost model inputs as the offline estimate
+	costs := p.runCosts()
+
+	distributorOpts = append(
+		distributorOpts,
+		distributor.WithGasFees(costs.GasFee, costs.PrimingFee),
+		distributor.WithFundingFee(p.cfg.fees.GasWanted(fees.Send, distributor.DefaultFundingGas), costs.FundingFee),
+	)
+
+	if costs.StorageDeposit.IsPositive() {
+		distributorOpts = append(distributorOpts, distributor.WithStorageDeposit(costs.StorageDeposit))
+	}
+
+	primer, canPrime := txRuntime.(runtime.Primer)
+	if canPrime && costs.PrimingTxs > 0 {
+		distributorOpts = append(distributorOpts, distributor.WithPrimingTransactions(costs.PrimingTxs))
+	}
+
+	txDistributor := distributor.NewDistributor(p.cli, p.fundingSigner, distributorOpts...)
+
+	// Duration runs fund the sub-accounts a round at a time, topped up during the broadcast.
+	// The rounds are sized once, for the sub-accounts being funded
+	var (
+		fundedTransactions = p.cfg.Transactions
+
+		fundingWindow, fundingLimit uint64
+	)
+
+	if p.cfg.Duration > 0 {
+		fundingWindow, fundingLimit = p.durationFunding(len(accounts) - 1)
+		fundedTransactions = fundingWindow
+	}
+
+	// The funding requests are always traced
+	p.cli.SetTracePhase(traceFunding)
+
+	runAccounts, err := txDistributor.Distribute(
+		accounts,
+		fundedTransactions,
+	)
+
+	p.cli.SetTracePhase("")
+
+	if err != nil {
+		return fmt.Errorf("unable to distribute funds, %w", err)
+	}
+
+	// Make sure no sub-account has pending transactions
+	// that would invalidate the nonce assignment
+	runAccounts, err = preflight.NewMempoolChecker(
+		p.cli,
+		preflight.PendingPolicy(p.cfg.PendingTxPolicy),
+		p.cfg.PendingTxWait,
+	).ResolvePending(runAccounts)
+	if err != nil {
+		return fmt.Errorf("unable to resolve pending transactions, %w", err)
+	}
+
+	// The transaction set needs to be independent of the funding state
+	if p.cfg.Reproducible {
+		if runAccounts, err = canonicalAccounts(accounts, runAccounts); err != nil {
+			return err
+		}
+	}
+
+	p.runAccounts = runAccounts
+
+	p.trackPhase(phaseDistribute, phaseStart)
+
+	if mixer, ok := txRuntime.(runtime.Mixer); ok {
+		p.mixer = mixer
+	}
+
+	// Duration runs generate the transactions during the broadcast, instead
+	var (
+		txs    []*std.Tx
+		stream *durationStream
+	)
+
+	if p.cfg.Duration > 0 {
+		stream, err = p.newDurationStream(
+			txRuntime,
+			txDistributor,
+			accounts[0],
+			runAccounts,
+			fundingWindow,
+			fundingLimit,
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Construct the transactions using the runtime
+		phaseStart = p.startPhase(phaseConstruct)
+
+		if txs, err = txRuntime.ConstructTransactions(runAccounts, p.cfg.Transactions); err != nil {
+			return fmt.Errorf("unable to construct transactions, %w", err)
+		}
+
+		// Skipped transactions can leave nothing to broadcast
+		if len(txs) == 0 {
+			return errNoTransactions
+		}
+
+		p.trackPhase(phaseConstruct, phaseStart)
+	}
+
+	// Record the transaction set hash, so the run can be verified
+	if p.cfg.Reproducible {
+		if p.txSetHash, err = runtime.HashTransactions(txs); err != nil {
+			return fmt.Errorf("unable to hash transactions, %w", err)
+		}
+
+		fmt.Printf("Transaction set hash: %s\n", p.txSetHash)
+	}
+
+	// Dump the transactions for a later replay, instead of broadcasting them
+	if p.cfg.PrepareDump != "" {
+		return p.prepareDump(runAccounts, txs)
+	}
+
+	// Prime the runtime targets, so the measured
+	// dispatch does not pay the node warm-up costs
+	if canPrime && p.cfg.PrimingCalls > 0 {
+		phaseStart = p.startPhase(phasePrime)
+
+		if err := p.primeRuntime(accounts, primer); err != nil {
+			return err
+		}
+
+		p.trackPhase(phasePrime, phaseStart)
+	}
+
+	if p.cfg.ReadRatio > 0 {
+		addresses := make([]string, 0, len(runAccounts))
+		for _, account := range runAccounts {
+			addresses = append(addresses, account.GetAddress().String())
+		}
+
+		p.queries = p.readQueries(txRuntime, addresses)
+	}
+
+	// Return the leftover sub-account funds to the distributor, if required.
+	// The sweep runs once the results are collected, so the recovered funds are reported
+	if p.cfg.Sweep {
+		p.sweep = p.sweepOnce(txDistributor, accounts)
+	}
+
+	dispatchErr := p.dispatch(txs, stream, txDistributor.CostReport())
+
+	// The funds are stranded regardless of the run outcome,
+	// so the sub-accounts of failed dispatches are swept as well
+	if p.sweep != nil {
+		p.sweep()
+	}
+
+	return dispatchErr
+}
+
+// sweepOnce returns the sweep of the leftover sub-account funds,
+// which is only executed on the first invocation
+func (p *Pipeline) sweepOnce(
+	txDistributor *distributor.Distributor,
+	accounts []keys.Info,
+) func() *collector.SweepResult {
+	var (
+		once   sync.Once
+		result *collector.SweepResult
+	)
+
+	return func() *collector.SweepResult {
+		once.Do(func() {
+			result = p.sweepAccounts(txDistributor, accounts)
+		})
+
+		return result
+	}
+}
+
+// sweepAccounts returns the leftover sub-account funds to the distributor.
+// Sweep failures never fail the run, since the run is already over
+func (p *Pipeline) sweepAccounts(txDistributor *distributor.Distributor, accounts []keys.Info) *collector.SweepResult {
+	p.status.SetPhase(phaseSweep)
+
+	result, err := txDistributor.CollectWithSummary(accounts)
+	if err != nil {
+		fmt.Printf("⚠️ Unable to sweep the sub-accounts, %v\n", err)
+	}
+
+	return result
+}
+
+// lockAccounts registers the account index ranges of the run in the lock registry,
+// refusing to start if a live run uses overlapping ranges, unless forced.
+// The lock is refreshed throughout the run, and released once it is over
+func (p *Pipeline) lockAccounts(ranges []locks.Range) error {
+	if p.cfg.LockRegistry == "" {
+		return nil
+	}
+
+	lock := locks.NewLock(p.runID, locks.Fingerprint(p.cfg.Mnemonic), ranges, p.cfg.LockTTL)
+
+	release, err := p.lockState(p.cfg.LockRegistry)
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := locks.Acquire(p.cfg.LockRegistry, lock, p.cfg.ForceRange)
+
+	release()
+
+	if err != nil {
+		fmt.Printf("❌ The run accounts are locked, use -force-range to start anyway, or state unlock to clear\n")
+
+		return fmt.Errorf("unable to lock accounts, %w", err)
+	}
+
+	for _, conflict := range conflicts {
+		fmt.Printf("⚠️ Forcing the account range, overlapping live run %s\n", conflict.RunID)
+	}
+
+	var (
+		stop = make(chan struct{})
+		done = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(p.cfg.LockTTL / lockRefreshes)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := p.withStateLock(p.cfg.LockRegistry, func() error {
+					return locks.Refresh(p.cfg.LockRegistry, p.runID)
+				})
+				if err != nil {
+					fmt.Printf("⚠️ Unable to refresh the account lock, %v\n", err)
+				}
+			}
+		}
+	}()
+
+	p.lifecycle.Register("account lock", func() error {
+		close(stop)
+		<-done
+
+		return p.withStateLock(p.cfg.LockRegistry, func() error {
+			return locks.Release(p.cfg.LockRegistry, p.runID)
+		})
+	})
+
+	return nil
+}
+
+// dispatchOrder returns the order the account transactions are dispatched in
+func (p *Pipeline) dispatchOrder() string {
+	if p.cfg.DispatchOrder == "" {
+		return string(batcher.DispatchInterleaved)
+	}
+
+	return p.cfg.DispatchOrder
+}
+
+// annotateBaseline annotates the run results with the headline metric deltas
+// against the baseline. Schema mismatches only skip the incomparable metrics
+func (p *Pipeline) annotateBaseline(runResult *collector.RunResult) {
+	baseline := compare.Annotate(p.cfg.baseline, runResult)
+
+	if baseline.SchemaMismatch {
+		fmt.Printf(
+			"\n⚠️ The baseline results schema (v%d) differs from the current one (v%d), "+
+				"only the comparable metrics are annotated\n",
+			baseline.SchemaVersion,
+			collector.SchemaVersion,
+		)
+	}
+
+	if len(baseline.Skipped) > 0 {
+		fmt.Printf("⚠️ Skipped the baseline metrics missing from either run: %s\n", strings.Join(baseline.Skipped, ", "))
+	}
+
+	runResult.Baseline = baseline
+}
+
+// readQueries resolves the interleaved read queries. The configured query set
+// takes precedence over the runtime target, which takes precedence over
+// the run account lookups
+func (p *Pipeline) readQueries(txRuntime runtime.Runtime, addresses []string) []reads.Query {
+	if len(p.cfg.queries) > 0 {
+		return p.cfg.queries
+	}
+
+	if querier, ok := txRuntime.(runtime.Querier); ok {
+		path, data := querier.ReadQuery()
+
+		return []reads.Query{{Path: path, Data: data}}
+	}
+
+	return reads.AccountQueries(addresses)
+}
+
+// dispatch broadcasts the signed transactions, or the transactions generated by the
+// duration run stream, if any, collects their results, and displays [+ saves] the run results
+func (p *Pipeline) dispatch(txs []*std.Tx, stream *durationStream, costs *collector.CostResult) error {
+	var (
+		batcherOpts = p.batcherOptions()
+
+		monitor    *collector.LatencyMonitor
+		controller *pacing.Controller
+	)
+
+	// Pace the broadcasts to hold the commit latency SLO, if any.
+	// The monitor uses a separate client, so it does not skew the request traces
+	if p.cfg.LatencySLO > 0 {
+		monitorClient := p.newClient(p.cfg.URL, 0)
+		p.lifecycle.Register("latency monitor client", monitorClient.Close)
+
+		monitor = collector.NewLatencyMonitor(monitorClient, latencyPollInterval, p.cfg.SLOWindow)
+		controller = pacing.NewController(
+			pacing.Config{
+				SLO:           p.cfg.LatencySLO,
+				Window:        p.cfg.SLOWindow,
+				InitialRate:   p.cfg.SLOInitialRate,
+				IncreaseStep:  p.cfg.SLOIncreaseStep,
+				BackoffFactor: p.cfg.SLOBackoffFactor,
+				Headroom:      p.cfg.SLOHeadroom,
+			},
+			monitor,
+		)
+
+		batcherOpts = append(batcherOpts, batcher.WithPacer(controller))
+	}
+
+	// Pace the broadcasts at the fixed send rate, if any
+	if p.cfg.SendRate > 0 {
+		batcherOpts = append(batcherOpts, batcher.WithPacer(pacing.NewFixedRate(p.cfg.SendRate)))
+	}
+
+	// Interleave the reads with the broadcasts, if any.
+	// The reader uses a separate client, so it does not skew the request traces
+	var reader *reads.Reader
+
+	if p.cfg.ReadRatio > 0 {
+		readClient := p.newClient(p.cfg.URL, readWorkers)
+		p.lifecycle.Register("read client", readClient.Close)
+
+		readerOpts := []reads.Option{reads.WithWorkers(readWorkers)}
+
+		// The reads are only paced if they share the broadcast budget
+		if p.cfg.ReadsCountAgainstTPS && controller != nil {
+			readerOpts = append(readerOpts, reads.WithLimiter(controller))
+		}
+
+		reader = reads.NewReader(readClient, p.queries, p.cfg.ReadRatio, readerOpts...)
+		batcherOpts = append(batcherOpts, batcher.WithReader(reader))
+	}
+
+	var (
+		txBatcher   = batcher.NewBatcher(p.cli, batcherOpts...)
+		txCollector = collector.NewCollector(p.cli, p.collectorOptions()...)
+	)
+
+	// Send the signed transactions in batches
+	// Sample the mempool throughout the broadcast and collection.
+	// The sampler uses a separate client, so it does not skew the request traces
+	var sampler *collector.MempoolSampler
+
+	if p.cfg.MempoolSampleInterval > 0 {
+		samplerClient := p.newClient(p.cfg.URL, 0)
+		p.lifecycle.Register("mempool sampler client", samplerClient.Close)
+
+		sampler = collector.NewMempoolSampler(samplerClient, p.cfg.MempoolSampleInterval)
+
+		sampler.Start()
+		p.lifecycle.Register("mempool sampler", func() error {
+			sampler.Stop()
+
+			return nil
+		})
+	}
+
+	// Scrape the node resource metrics throughout the broadcast and collection
+	var scraper *collector.NodeScraper
+
+	if p.cfg.NodeMetricsURL != "" {
+		scraper = collector.NewNodeScraper(
+			p.cfg.NodeMetricsURL,
+			strings.Split(p.cfg.NodeMetrics, ","),
+			p.cfg.NodeMetricsInterval,
+		)
+
+		scraper.Start()
+		p.lifecycle.Register("node metrics scraper", func() error {
+			scraper.Stop()
+
+			return nil
+		})
+	}
+
+	// Sample the supernova process itself throughout the broadcast and collection
+	var clientSampler *collector.ClientSampler
+
+	if p.cfg.ClientSampleInterval > 0 {
+		clientSampler = collector.NewClientSampler(p.cfg.ClientSampleInterval)
+
+		clientSampler.Start()
+		p.lifecycle.Register("client sampler", func() error {
+			clientSampler.Stop()
+
+			return nil
+		})
+	}
+
+	if controller != nil {
+		monitor.Start()
+		p.lifecycle.Register("latency monitor", func() error {
+			monitor.Stop()
+
+			return nil
+		})
+
+		controller.Start()
+		p.lifecycle.Register("rate controller", func() error {
+			controller.Stop()
+
+			return nil
+		})
+	}
+
+	if reader != nil {
+		reader.Start()
+		p.lifecycle.Register("reader", func() error {
+			reader.Stop()
+
+			return nil
+		})
+	}
+
+	// Pre-warm the connections right before the broadcast,
+	// so the measured dispatch starts with fresh, verified connections,
+	// and does not pay the handshake costs
+	if p.cfg.PrewarmConnections > 0 {
+		phaseStart := p.startPhase(phasePrewarm)
+
+		if err := p.prewarmConnections(); err != nil {
+			return err
+		}
+
+		p.trackPhase(phasePrewarm, phaseStart)
+	}
+
+	if p.cfg.TraceHTTP {
+		p.cli.SetTracePhase(traceBroadcast)
+	}
+
+	batchStart := p.startPhase(phaseBatch)
+
+	// The duration run progress is reported against the transactions at the send rate
+	transactions := len(txs)
+	if stream != nil {
+		transactions = int(p.cfg.runTransactions())
+	}
+
+	p.status.StartDispatch(transactions)
+	p.live.StartDispatch(transactions)
+
+	var (
+		batchResult *batcher.TxBatchResult
+		err         error
+	)
+
+	if stream != nil {
+		batchResult, err = p.streamTransactions(txBatcher, stream)
+	} else {
+		batchResult, err = txBatcher.BatchTransactions(txs, int(p.cfg.BatchSize))
+	}
+
+	batchEnd := time.Now()
+
+	p.cli.SetTracePhase("")
+
+	// The send rate trajectory ends with the broadcast
+	var pacingResult *metrics.PacingResult
+
+	if controller != nil {
+		pacingResult = controller.Stop()
+		monitor.Stop()
+	}
+
+	// The reads are interleaved with the broadcast only
+	var readResult *metrics.ReadResult
+
+	if reader != nil {
+		readResult = reader.Stop()
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to batch transactions %w", err)
+	}
+
+	p.trackPhase(phaseBatch, batchStart)
+
+	// The streamed transactions are only known once the broadcast is over
+	if stream != nil {
+		txs = batchResult.Generated
+	}
+
+	// Collect the transaction results
+	phaseStart := p.startPhase(phaseCollect)
+
+	runResult, err := txCollector.GetRunResult(
+		batchResult.TxHashes,
+		batchResult.StartBlock,
+		batchStart,
+	)
+	// The live progress ends with the collection
+	p.live.Finish()
+
+	if err != nil {
+		return fmt.Errorf("unable to collect transactions, %w", err)
+	}
+
+	// Backfill the blocks observed without block results, if any.
+	// The backfill queries the tx endpoint, so it relies on the node tx indexer
+	if p.cfg.BackfillRate > 0 && !p.txIndexDisabled(integrationBackfill) {
+		backfiller := collector.NewBackfiller(
+			p.cli,
+			int(p.cfg.BackfillSample),
+			p.cfg.BackfillRate,
+			p.cfg.BackfillJournal,
+		)
+
+		runResult.Backfill, err = backfiller.Backfill(runResult, txCollector.CommitHeights())
+		if err := degrade(p.integrations.backfill, err); err != nil {
+			return fmt.Errorf("unable to backfill tx results, %w", err)
+		}
+	}
+
+	// Wait for the queued artifacts to be written out
+	if err := p.artifacts.Close(); err != nil {
+		if err := degrade(p.integrations.artifacts, err); err != nil {
+			return fmt.Errorf("unable to write run artifacts, %w", err)
+		}
+	}
+
+	p.trackPhase(phaseCollect, phaseStart)
+
+	// The collection starts once the broadcast ends
+	runResult.Throughput = collector.NewThroughputResult(
+		runResult.Blocks,
+		runResult.CommittedTxs,
+		batchStart,
+		phaseStart,
+	)
+
+	runResult.Drain = collector.NewDrainResult(
+		runResult.Blocks,
+		txCollector.CommitTimes(),
+		phaseStart,
+		runResult.LostTxs,
+	)
+
+	// Verify the inclusion of the sampled committed transactions, if necessary
+	if p.cfg.ProofSamples > 0 {
+		runResult.Inclusion = p.verifyInclusion(txCollector.CommitHeights())
+	}
+
+	if sampler != nil {
+		runResult.Latency = attributeLatency(
+			batchResult,
+			sampler.Stop(),
+			txCollector.CommitTimes(),
+			p.cfg.MempoolSampleInterval,
+		)
+	}
+
+	if scraper != nil {
+		runResult.Node = scraper.Stop()
+
+		if err := degrade(p.integrations.nodeMetrics, nodeMetricsErr(runResult.Node)); err != nil {
+			return err
+		}
+	}
+
+	if clientSampler != nil {
+		runResult.Client = clientSampler.Result(batchStart, batchEnd, batchResult.Starvation)
+	}
+
+	runResult.SchemaVersion = collector.SchemaVersion
+	runResult.RunID = p.runID
+	runResult.Label = p.cfg.Label
+	runResult.Preset = p.cfg.Preset
+	runResult.Shard = p.cfg.shard
+	runResult.Pacing = pacingResult
+	runResult.Reads = readResult
+	runResult.TxSetHash = p.txSetHash
+	runResult.CorpusHash = p.corpusHash()
+	runResult.Scenario = p.scenarioResult()
+	runResult.Duration = p.durationResult(len(txs), stream, batchResult, txCollector.DeadlineReached())
+	runResult.Progress = p.progressSamples()
+	runResult.GasWanted = p.gasWantedResult()
+	runResult.Fees = p.feesResult()
+	runResult.Signers = p.signersResult()
+	runResult.Ceiling = collector.NewCeilingResult(runResult.Blocks, runResult.GasWanted.Run)
+	runResult.Embedded = p.cfg.Embedded
+	runResult.Indexer = p.indexer
+	runResult.Artifacts = p.artifacts.Result()
+
+	if p.cfg.Reproducible {
+		runResult.Seed = p.cfg.Seed
+	}
+	runResult.Phases = p.phases
+	runResult.Costs = costs
+
+	if costs != nil {
+		costs.Spend = p.budget.Report()
+	}
+	runResult.RPC = p.cli.RPCMetrics()
+	runResult.BatchLatency = batchResult.Latencies
+	runResult.BatchFallback = batchResult.Fallback
+	runResult.EndpointAssignments = batchResult.Assignments
+	runResult.Dispatch = metrics.NewDispatchResult(p.dispatchOrder(), batchResult.Dispatches)
+	runResult.Clock = batchResult.Clock
+	runResult.FailedEndpoints = batchResult.FailedEndpoints
+
+	if len(p.cfg.endpoints) > 0 {
+		runResult.EndpointAffinity = p.cfg.EndpointAffinity
+		runResult.Endpoints = newEndpointResults(batchResult, txCollector.CommitTimes())
+	}
+
+	for _, address := range p.excluded {
+		runResult.ExcludedAccounts = append(runResult.ExcludedAccounts, address.String())
+	}
+
+	runResult.ConstructionFailures = p.constructionFailures
+
+	// Track the realm storage growth of the mints
+	if runtime.Type(p.cfg.Mode) == runtime.Mint {
+		runResult.Mint, err = newMintResult(
+			txs,
+			batchResult,
+			txCollector.CommitTimes(),
+			txCollector.CommitHeights(),
+			p.mintMetadataSize(),
+		)
+		if err != nil {
+			if err := degrade(p.integrations.mint, fmt.Errorf("unable to summarize mints, %w", err)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Account for the sub-account spend, so a consecutive run can reuse it
+	if len(p.runAccounts) > 0 {
+		runResult.Accounts, err = newAccountResults(p.runAccounts, txs, txCollector.CommitTimes())
+		if err != nil {
+			if err := degrade(p.integrations.accounts, fmt.Errorf("unable to account for sub-accounts, %w", err)); err != nil {
+				return err
+			}
+		}
+
+		stream.applyRecycles(runResult.Accounts)
+	}
+
+	// Sweep the sub-accounts before the results are displayed, so the recovered funds are reported
+	if p.sweep != nil {
+		runResult.Sweep = p.sweep()
+	}
+
+	// Display [+ save the results]
+	if err := p.handleResults(runResult); err != nil {
+		return err
+	}
+
+	// Unverified commits can't be trusted, so the run fails,
+	// once the results (with the failed samples) are kept
+	if runResult.Inclusion != nil && !runResult.Inclusion.Credible {
+		return p.integrations.inclusion.Fail(errUnverifiedCommits)
+	}
+
+	// Degraded runs only fail in strict mode
+	return p.integrations.registry.Check(p.cfg.StrictIntegrations)
+}
+
+// collectorOptions returns the collector options for the run
+func (p *Pipeline) collectorOptions() []collector.Option {
+	opts := []collector.Option{
+		collector.WithCompletionThreshold(p.cfg.CompletionThreshold, p.cfg.CompletionGrace),
+	}
+
+	if p.cfg.StallFactor > 0 {
+		opts = append(opts, collector.WithStallDetection(p.cfg.StallFactor, p.cli))
+	}
+
+	if p.status != nil || p.live != nil {
+		opts = append(opts, collector.WithProgress(p.reportCommitted))
+	}
+
+	// The last broadcasts of duration runs are given the completion grace to commit
+	if p.cfg.Duration > 0 {
+		opts = append(opts, collector.WithDeadline(p.cfg.Duration+p.cfg.CompletionGrace))
+	}
+
+	if p.cfg.ReportInterval > 0 {
+		opts = append(opts, collector.WithResultsSegments(
+			p.cfg.ReportInterval,
+			func(segment *collector.SegmentResult) error {
+				// Segments are written in the background, off the collection path
+				p.artifacts.Write(manifest.TypeSegment, func() error {
+					path, err := saveSegment(segment, p.runDir())
+					if err != nil {
+						return err
+					}
+
+					p.recordArtifact(manifest.TypeSegment, path)
+
+					return nil
+				})
+
+				return nil
+			},
+		))
+	}
+
+	return opts
+}
+
+// batcherOptions returns the batcher options for the run
+func (p *Pipeline) batcherOptions() []batcher.Option {
+	opts := make([]batcher.Option, 0, 2)
+
+	if p.cfg.GroupBatches {
+		opts = append(opts, batcher.WithTypeGrouping())
+	}
+
+	if p.cfg.ForceBatch {
+		opts = append(opts, batcher.WithForceBatch())
+	}
+
+	if order := batcher.DispatchOrder(p.cfg.DispatchOrder); order != "" {
+		opts = append(opts, batcher.WithDispatchOrder(order))
+	}
+
+	if p.status != nil || p.live != nil {
+		opts = append(opts, batcher.WithProgress(p.reportSent))
+	}
+
+	if p.live != nil {
+		opts = append(opts, batcher.WithFailureReport(p.live.Fail))
+	}
+
+	if p.cfg.Duration > 0 {
+		opts = append(opts, batcher.WithDuration(p.cfg.Duration))
+	}
+
+	if len(p.cfg.endpoints) > 0 {
+		endpoints := make([]batcher.Endpoint, 0, len(p.cfg.endpoints))
+
+		for _, url := range p.cfg.endpoints {
+			endpoint := batcher.Endpoint{
+				URL:    url,
+				Client: p.cli,
+			}
+
+			// The cluster client is reused for the cluster URL.
+			// The endpoint requests are traced with the cluster requests
+			if url != p.cfg.URL {
+				peer := client.NewPeerHTTPClient(url, int(p.cfg.PrewarmConnections), p.cli.Tracer())
+				p.lifecycle.Register(fmt.Sprintf("broadcast client %s", url), peer.Close)
+
+				p.peers = append(p.peers, peer)
+				endpoint.Client = peer
+			}
+
+			endpoints = append(endpoints, endpoint)
+		}
+
+		opts = append(opts, batcher.WithEndpoints(endpoints, batcher.Affinity(p.cfg.EndpointAffinity)))
+	}
+
+	return opts
+}
+
+// signerOptions returns the run transaction signer options
+func signerOptions(verify bool) []signer.Option {
+	if !verify {
+		return nil
+	}
+
+	return []signer.Option{
+		signer.WithVerification(),
+	}
+}
+
+// newRuntime creates the runtime for the run mode, using the given signer
+func (p *Pipeline) newRuntime(txSigner runtime.Signer) runtime.Runtime {
+	opts := runtimeOptions(p.storageDeposit())
+
+	if p.cfg.Seed != 0 {
+		opts = append(opts, runtime.WithSeed(p.cfg.Seed))
+	}
+
+	if policy := runtime.ConstructionPolicy(p.cfg.ConstructionErrorPolicy); policy != "" {
+		opts = append(opts, runtime.WithConstructionPolicy(policy, p.recordConstructionFailure))
+	}
+
+	if p.cfg.callArg != nil {
+		opts = append(opts, runtime.WithCallArgument(p.cfg.callArg))
+	}
+
+	if p.cfg.corpus != nil {
+		opts = append(opts, runtime.WithCorpus(p.cfg.corpus, p.corpusMode()))
+	}
+
+	if p.cfg.MintRealm != "" {
+		opts = append(opts, runtime.WithMintRealm(p.cfg.MintRealm))
+	}
+
+	if runtime.Type(p.cfg.Mode) == runtime.Mint {
+		opts = append(opts, runtime.WithMintMetadataSize(p.mintMetadataSize()))
+	}
+
+	if p.cfg.scenario != nil {
+		opts = append(opts, runtime.WithScenario(p.cfg.scenario))
+	}
+
+	runGas, _ := p.gasWanted(runtime.Type(p.cfg.Mode))
+
+	opts = append(
+		opts,
+		runtime.WithGasWanted(runGas),
+		runtime.WithPredeployGasWanted(p.predeployGasWanted(runtime.Type(p.cfg.Mode))),
+		runtime.WithGasFee(p.gasFee(feeMsgType(runtime.Type(p.cfg.Mode)))),
+		runtime.WithPredeployGasFee(p.gasFee(fees.AddPackage)),
+	)
+
+	return runtime.GetRuntime(runtime.Type(p.cfg.Mode), txSigner, opts...)
+}
+
+// gasWanted returns the gas wanted of the mode transactions,
+// and true if it is the mode default
+func (p *Pipeline) gasWanted(mode runtime.Type) (int64, bool) {
+	var override int64
+
+	switch mode {
+	case runtime.RealmCall, runtime.CustomScenario:
+		override = p.cfg.GasWantedCall
+	case runtime.PackageDeployment:
+		override = p.cfg.GasWantedPackage
+	case runtime.Mint:
+		override = p.cfg.GasWantedMint
+	default:
+		override = p.cfg.GasWantedDeploy
+	}
+
+	defaultGas := runtime.DefaultGasWanted(mode)
+
+	// The fee overrides only apply to the gas left at the mode default
+	if override == 0 || override == defaultGas {
+		override = p.cfg.fees.GasWanted(feeMsgType(mode), defaultGas)
+	}
+
+	// The estimate only replaces the run gas left at the mode default
+	if override == defaultGas && p.gasEstimate != nil && mode == runtime.Type(p.cfg.Mode) {
+		return p.gasEstimate.GasWanted, false
+	}
+
+	return override, override == defaultGas
+}
+
+// gasFee returns the gas fee of the message type transactions.
+// The run transactions are priced by their gas wanted, if a gas price is set
+func (p *Pipeline) gasFee(msgType string) std.Coin {
+	fee := common.DefaultGasFee
+
+	if mode := runtime.Type(p.cfg.Mode); p.cfg.gasPrice != nil && msgType == feeMsgType(mode) {
+		gas, _ := p.gasWanted(mode)
+		fee = estimator.Fee(gas, *p.cfg.gasPrice)
+	}
+
+	return p.cfg.fees.GasFee(msgType, fee)
+}
+
+// runCosts returns the cost model inputs the distribution funds the run with.
+// The priced run transactions pay for their (estimated) gas wanted,
+// and the priming calls are constructed with the run fee
+func (p *Pipeline) runCosts() distributor.CostParams {
+	mode := runtime.Type(p.cfg.Mode)
+
+	costs := costParams(mode, p.storageDeposit(), p.cfg.PrimingCalls, p.cfg.MintRealm, p.cfg.fees, common.DefaultGasFee)
+
+	if p.cfg.gasPrice != nil {
+		costs.GasFee = p.gasFee(feeMsgType(mode))
+		costs.PrimingFee = costs.GasFee
+	}
+
+	return costs
+}
+
+// predeployGasWanted returns the gas wanted of the mode predeployment.
+// The realm deployment gas is used, unless it is left at its default
+func (p *Pipeline) predeployGasWanted(mode runtime.Type) int64 {
+	gas, isDefault := p.gasWanted(runtime.RealmDeployment)
+	if isDefault {
+		return runtime.DefaultPredeployGasWanted(mode)
+	}
+
+	return gas
+}
+
+// gasWantedResult returns the gas wanted of the run transactions, for the results
+func (p *Pipeline) gasWantedResult() *collector.GasWantedResult {
+	var (
+		mode = runtime.Type(p.cfg.Mode)
+
+		run, isDefault = p.gasWanted(mode)
+	)
+
+	result := &collector.GasWantedResult{
+		Mode:    mode.String(),
+		Run:     run,
+		Default: isDefault,
+	}
+
+	if estimate := p.gasEstimate; estimate != nil {
+		result.Simulated = estimate.GasUsed
+		result.Margin = estimate.Margin
+	}
+
+	// Only realm calls and (untargeted) mints predeploy their target
+	if mode == runtime.RealmCall || (mode == runtime.Mint && p.cfg.MintRealm == "") {
+		result.Predeploy = p.predeployGasWanted(mode)
+	}
+
+	return result
+}
+
+// feesResult returns the effective fees of the message types the run sends, for the results
+func (p *Pipeline) feesResult() fees.Map {
+	var (
+		mode = runtime.Type(p.cfg.Mode)
+
+		run, _ = p.gasWanted(mode)
+		result = fees.Map{
+			feeMsgType(mode): {
+				GasWanted: run,
+				GasFee:    p.gasFee(feeMsgType(mode)).String(),
+			},
+		}
+	)
+
+	// Genesis funded runs send no funding transfers
+	if !p.cfg.AssumeGenesisFunded {
+		result[fees.Send] = fees.Fee{
+			GasWanted: p.cfg.fees.GasWanted(fees.Send, distributor.DefaultFundingGas),
+			GasFee:    p.gasFee(fees.Send).String(),
+		}
+	}
+
+	// Only realm calls and (untargeted) mints predeploy their target
+	if mode == runtime.RealmCall || (mode == runtime.Mint && p.cfg.MintRealm == "") {
+		result[fees.AddPackage] = fees.Fee{
+			GasWanted: p.predeployGasWanted(mode),
+			GasFee:    p.gasFee(fees.AddPackage).String(),
+		}
+	}
+
+	return result
+}
+
+// signersResult returns the signing outcomes of each signer backend,
+// for the results. Runs without externally managed signers report none
+func (p *Pipeline) signersResult() []*collector.SignerResult {
+	if !p.signers.Mixed() {
+		return nil
+	}
+
+	var (
+		stats   = p.signers.Stats()
+		results = make([]*collector.SignerResult, 0, len(stats))
+	)
+
+	for name, backendStats := range stats {
+		results = append(results, &collector.SignerResult{
+			Backend:  name,
+			Accounts: backendStats.Accounts,
+			Signed:   backendStats.Signed,
+			Failed:   backendStats.Failed,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Backend < results[j].Backend
+	})
+
+	return results
+}
+
+// corpusMode returns the argument corpus sampling mode of the run
+func (p *Pipeline) corpusMode() runtime.CorpusMode {
+	if p.cfg.CorpusMode == "" {
+		return runtime.CorpusWithReplacement
+	}
+
+	return runtime.CorpusMode(p.cfg.CorpusMode)
+}
+
+// corpusHash returns the hash of the argument corpus file, if any
+func (p *Pipeline) corpusHash() string {
+	if p.cfg.corpus == nil {
+		return ""
+	}
+
+	return p.cfg.corpus.Hash()
+}
+
+// recordConstructionFailure records the skipped / substituted transaction,
+// so the measurement caveats are listed in the results
+func (p *Pipeline) recordConstructionFailure(failure runtime.ConstructionFailure) {
+	p.constructionFailures = append(p.constructionFailures, &collector.ConstructionFailure{
+		Index:  failure.Index,
+		Policy: string(failure.Policy),
+		Error:  failure.Err.Error(),
+	})
+}
+
+// storageDeposit returns the storage deposit paid by each run transaction
+func (p *Pipeline) storageDeposit() std.Coin {
+	denom := p.cfg.StorageDepositDenom
+	if denom == "" {
+		denom = common.Denomination
+	}
+
+	return std.NewCoin(denom, int64(p.cfg.StorageDeposit))
+}
+
+// runtimeOptions returns the runtime options
+// for the given storage deposit
+func runtimeOptions(deposit std.Coin) []runtime.Option {
+	if !deposit.IsPositive() {
+		return nil
+	}
+
+	return []runtime.Option{
+		runtime.WithStorageDeposit(std.NewCoins(deposit)),
+	}
+}
+
+// attributeLatency decomposes the commit latency of the committed transactions,
+// using the sampled mempool residency. Returns nil if the mempool was not sampled
+func attributeLatency(
+	batchResult *batcher.TxBatchResult,
+	lastSeen map[string]time.Time,
+	commitTimes map[string]time.Time,
+	sampleInterval time.Duration,
+) *metrics.LatencyAttribution {
+	if lastSeen == nil {
+		return nil
+	}
+
+	timings := make([]metrics.TxTiming, 0, len(commitTimes))
+
+	for index, txHash := range batchResult.TxHashes {
+		committed, ok := commitTimes[string(txHash)]
+		if !ok {
+			// Lost transactions have no commit latency
+			continue
+		}
+
+		timings = append(timings, metrics.TxTiming{
+			Sent:      batchResult.Timings[index].Sent,
+			Accepted:  batchResult.Timings[index].Accepted,
+			LastSeen:  lastSeen[string(txHash)],
+			Committed: committed,
+		})
+	}
+
+	return metrics.NewLatencyAttribution(timings, sampleInterval)
+}
+
+// startPhase marks the start of the given pipeline phase in the run status,
+// and returns the phase start time
+func (p *Pipeline) startPhase(name string) time.Time {
+	p.status.SetPhase(name)
+
+	return time.Now()
+}
+
+// trackPhase records the duration of the given pipeline phase,
+// and tears down the connections the phase left idle.
+// The node may drop them in the meantime, and reusing a stale socket in the
+// next phase surfaces as a reconnect error. The pre-warmed connections are kept
+func (p *Pipeline) trackPhase(name string, start time.Time) {
+	end := time.Now()
+
+	p.phases = append(p.phases, &collector.PhaseResult{
+		Name:      name,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+		Duration:  end.Sub(start),
+	})
+
+	if name == phasePrewarm {
+		return
+	}
+
+	if p.cli != nil {
+		p.cli.CloseIdleConnections()
+	}
+
+	for _, peer := range p.peers {
+		peer.CloseIdleConnections()
+	}
+}
 
-		txBatcher   = batcher.NewBatcher(p.cli)
-		txCollector = collector.NewCollector(p.cli)
-		txRuntime   = runtime.GetRuntime(mode, p.signer)
-	)
+// prewarmConnections opens and exercises the configured number
+// of connections before the measured dispatch starts
+func (p *Pipeline) prewarmConnections() error {
+	fmt.Printf("\n🔥 Pre-warming Connections 🔥\n\n")
 
-	// Initialize the accounts for the runtime
-	accounts, err := p.initializeAccounts()
-	if err != nil {
-		return err
+	if err := p.cli.Prewarm(int(p.cfg.PrewarmConnections)); err != nil {
+		return fmt.Errorf("unable to pre-warm connections, %w", err)
 	}
 
-	// Predeploy any pending transactions
-	if err := prepareRuntime(mode, accounts, p.cli, txRuntime); err != nil {
-		return err
+	// Each broadcast endpoint gets its own pre-warmed connections
+	for _, peer := range p.peers {
+		if err := peer.Prewarm(int(p.cfg.PrewarmConnections)); err != nil {
+			return fmt.Errorf("unable to pre-warm endpoint connections, %w", err)
+		}
 	}
 
-	// Distribute the funds to sub-accounts
-	runAccounts, err := distributor.NewDistributor(p.cli, p.signer).Distribute(
-		accounts,
-		p.cfg.Transactions,
+	fmt.Printf(
+		"✅ Successfully pre-warmed %d connections, to %d endpoints\n",
+		p.cfg.PrewarmConnections,
+		len(p.peers)+1,
 	)
+
+	return nil
+}
+
+// primeRuntime executes the unmeasured priming transactions
+// from the distributor account, and waits for them to be committed
+func (p *Pipeline) primeRuntime(accounts []keys.Info, primer runtime.Primer) error {
+	fmt.Printf("\n🧯 Priming Runtime Targets 🧯\n\n")
+
+	// Get the distributor account, with the fresh sequence
+	distributorAccount, err := p.cli.GetAccount(accounts[0].GetAddress().String())
 	if err != nil {
-		return fmt.Errorf("unable to distribute funds, %w", err)
+		return fmt.Errorf("unable to fetch distributor account, %w", err)
 	}
 
-	// Construct the transactions using the runtime
-	txs, err := txRuntime.ConstructTransactions(runAccounts, p.cfg.Transactions)
+	primingTxs, err := primer.ConstructPrimingTransactions(distributorAccount, p.cfg.PrimingCalls)
 	if err != nil {
-		return fmt.Errorf("unable to construct transactions, %w", err)
+		return fmt.Errorf("unable to construct priming transactions, %w", err)
 	}
 
-	// Send the signed transactions in batches
-	batchStart := time.Now()
+	bar := progressbar.Default(int64(len(primingTxs)), "priming txs")
 
-	batchResult, err := txBatcher.BatchTransactions(txs, int(p.cfg.BatchSize))
-	if err != nil {
-		return fmt.Errorf("unable to batch transactions %w", err)
-	}
+	primed := 0
 
-	// Collect the transaction results
-	runResult, err := txCollector.GetRunResult(
-		batchResult.TxHashes,
-		batchResult.StartBlock,
-		batchStart,
-	)
-	if err != nil {
-		return fmt.Errorf("unable to collect transactions, %w", err)
+	for _, tx := range primingTxs {
+		// Priming is optional, so the run goes on unprimed past the spend cap
+		if err := p.budget.SpendTx(distributor.SpendPriming, tx); err != nil {
+			fmt.Printf("\n⚠️ %v, skipping %d priming transactions\n", err, len(primingTxs)-primed)
+
+			break
+		}
+
+		if err := p.cli.BroadcastTransaction(tx); err != nil {
+			return fmt.Errorf("unable to broadcast priming tx, %w", err)
+		}
+
+		primed++
+
+		_ = bar.Add(1)
 	}
 
-	// Display [+ save the results]
-	return p.handleResults(runResult)
+	fmt.Printf("✅ Successfully executed %d priming transactions\n", primed)
+
+	return nil
 }
 
 // initializeAccounts initializes the accounts needed for the stress test run
@@ -111,39 +1575,148 @@ func (p *Pipeline) initializeAccounts() ([]keys.Info, error) {
 
 	fmt.Printf("Generating sub-accounts...\n")
 
+	// Set up the externally managed account signers, if any
+	backends, err := p.cfg.signers.Backends(p.cfg.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set up account signers, %w", err)
+	}
+
+	p.backends = backends
+
 	var (
-		accounts = make([]keys.Info, p.cfg.SubAccounts+1)
+		accounts = make([]keys.Info, 0, p.cfg.SubAccounts+1)
 		bar      = progressbar.Default(int64(p.cfg.SubAccounts+1), "accounts initialized")
 	)
 
-	// Register the accounts with the keybase
-	for i := 0; i < int(p.cfg.SubAccounts)+1; i++ {
-		info, err := p.keybase.CreateAccount(
-			fmt.Sprintf("%s%d", common.KeybasePrefix, i),
-			p.cfg.Mnemonic,
-			"",
-			common.EncryptPassword,
-			uint32(0),
-			uint32(i),
-		)
+	// createAccount registers the account at the derivation index with the keybase
+	createAccount := func(index uint32) (keys.Info, error) {
+		info, err := p.registerAccount(index)
 		if err != nil {
-			return nil, fmt.Errorf("unable to create account with keybase, %w", err)
+			return nil, err
 		}
 
-		accounts[i] = info
 		_ = bar.Add(1)
+
+		return info, nil
+	}
+
+	// The distributor account is always the first account
+	distributor, err := createAccount(uint32(p.cfg.DistributorIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cfg.accounts.checkDistributor(distributor.GetAddress()); err != nil {
+		return nil, err
+	}
+
+	accounts = append(accounts, distributor)
+
+	// Register the sub-accounts with the keybase
+	for i := p.cfg.SubAccountOffset; i < p.cfg.SubAccountOffset+p.cfg.SubAccounts; i++ {
+		info, err := createAccount(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+
+		// Make sure the sub-account can be used
+		if !p.cfg.accounts.allows(uint32(i), info.GetAddress()) {
+			p.excluded = append(p.excluded, info.GetAddress())
+
+			continue
+		}
+
+		accounts = append(accounts, info)
+	}
+
+	// Make sure there are sub-accounts left for the run
+	if len(accounts) < 2 {
+		return nil, errNoSubAccounts
 	}
 
 	fmt.Printf("✅ Successfully generated %d accounts\n", len(accounts))
 
+	if len(p.excluded) > 0 {
+		fmt.Printf("⚠️ Excluded %d sub-accounts from the run\n", len(p.excluded))
+	}
+
 	return accounts, nil
 }
 
+// registerAccount registers the account at the derivation index with the keybase.
+// Externally managed accounts are registered by their public key, and assigned to their signer
+func (p *Pipeline) registerAccount(index uint32) (keys.Info, error) {
+	if backend, ok := p.backends.For(index); ok {
+		return p.registerExternalAccount(index, backend)
+	}
+
+	info, err := p.keybase.CreateAccount(
+		fmt.Sprintf("%s%d", common.KeybasePrefix, index),
+		p.cfg.Mnemonic,
+		"",
+		common.EncryptPassword,
+		uint32(0),
+		index,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create account with keybase, %w", err)
+	}
+
+	p.indices[info.GetAddress().String()] = index
+	p.signers.AssignDerived()
+
+	return info, nil
+}
+
+// registerExternalAccount registers the account at the derivation index,
+// managed by the signer backend, with the keybase
+func (p *Pipeline) registerExternalAccount(index uint32, backend signer.Backend) (keys.Info, error) {
+	pubKey, err := backend.PubKey(index)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %s account %d key, %w", backend.Name(), index, err)
+	}
+
+	info, err := p.keybase.CreateOffline(fmt.Sprintf("%s%d", common.KeybasePrefix, index), pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create account with keybase, %w", err)
+	}
+
+	p.indices[info.GetAddress().String()] = index
+	p.signers.Assign(info.GetAddress(), backend)
+
+	return info, nil
+}
+
 // handleResults displays the results in the terminal,
 // and saves them to disk if an output path was specified
 func (p *Pipeline) handleResults(runResult *collector.RunResult) error {
+	// The run timeline is kept in UTC, so it joins the node logs on the timestamp
+	runResult.StartTime = p.started
+	runResult.EndTime = time.Now()
+	runResult.NormalizeTimeline()
+
+	// Annotate the headline metrics against the baseline, if any
+	if p.cfg.baseline != nil {
+		p.annotateBaseline(runResult)
+	}
+
+	// Index the run in the local history, if necessary.
+	// History failures degrade the run
+	if p.cfg.History {
+		p.recordHistory(runResult)
+	}
+
+	// Upload the results, if necessary.
+	// Upload failures degrade the run
+	if p.cfg.ResultsURL != "" {
+		p.uploadResults(runResult)
+	}
+
+	// The optional integrations failed so far are kept with the results
+	runResult.Degraded = p.integrations.registry.Failures()
+
 	// Display the results in the terminal
-	displayResults(runResult)
+	displayResults(runResult, newSummaryFormat(!p.cfg.NoHumanize).in(p.cfg.location))
 
 	// Check if the results need to be saved to disk
 	if p.cfg.Output == "" {
@@ -154,14 +1727,423 @@ func (p *Pipeline) handleResults(runResult *collector.RunResult) error {
 	fmt.Printf("\n💾 Saving Results 💾\n\n")
 
 	if err := saveResults(runResult, p.cfg.Output); err != nil {
-		return fmt.Errorf("unable to save results, %w", err)
+		return p.integrations.results.Fail(fmt.Errorf("unable to save results, %w", err))
 	}
 
 	fmt.Printf("✅ Successfully saved results to %s\n", p.cfg.Output)
 
+	p.recordArtifact(manifest.TypeResults, p.cfg.Output)
+
+	return nil
+}
+
+// recordHistory appends the run summary to the local run history
+func (p *Pipeline) recordHistory(runResult *collector.RunResult) {
+	// The results are referenced from outside the run directory
+	results := p.cfg.Output
+	if abs, err := filepath.Abs(results); results != "" && err == nil {
+		results = abs
+	}
+
+	// Duration runs are recorded with the generated transactions
+	transactions := p.cfg.Transactions
+	if duration := runResult.Duration; duration != nil {
+		transactions = uint64(duration.Generated)
+	}
+
+	entry := &history.Entry{
+		RunID:        p.runID,
+		Time:         time.Now().UTC(),
+		Mode:         p.cfg.Mode,
+		ChainID:      p.cfg.ChainID,
+		Label:        p.cfg.Label,
+		Results:      results,
+		Transactions: transactions,
+		AverageTPS:   runResult.AverageTPS,
+		CommittedTxs: runResult.CommittedTxs,
+		LostTxs:      runResult.LostTxs,
+	}
+
+	// Concurrent runs share the history database
+	err := p.withStateLock(p.cfg.HistoryDB, func() error {
+		db, err := history.Open(p.cfg.HistoryDB)
+		if err != nil {
+			return err
+		}
+
+		defer db.Close()
+
+		return db.Append(entry)
+	})
+	if err != nil {
+		fmt.Printf("⚠️ Unable to record the run history, %v\n", err)
+
+		_ = p.integrations.history.Fail(err)
+	}
+}
+
+// lockState takes the lock of the shared state directory of the given file,
+// so concurrent runs never interleave their updates of the global state files,
+// and returns the release function
+func (p *Pipeline) lockState(path string) (func(), error) {
+	release, err := statelock.Acquire(filepath.Dir(path), p.cfg.StateLockTimeout)
+	if err != nil {
+		fmt.Printf("❌ The state directory is locked, raise -state-lock-timeout, or remove the stale lock file\n")
+
+		return nil, fmt.Errorf("unable to lock the state directory, %w", err)
+	}
+
+	return release, nil
+}
+
+// withStateLock applies the change while holding the shared state directory lock
+func (p *Pipeline) withStateLock(path string, change func() error) error {
+	release, err := p.lockState(path)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	return change()
+}
+
+// manifestPath returns the run manifest path, next to the
+// run output (or prepare dump). Runs without any artifacts
+// on disk have no manifest
+func (p *Pipeline) manifestPath() string {
+	switch {
+	case p.cfg.Output != "":
+		return filepath.Join(filepath.Dir(p.cfg.Output), manifest.FileName(p.runID))
+	case p.cfg.PrepareDump != "":
+		return filepath.Join(filepath.Dir(p.cfg.PrepareDump), manifest.FileName(p.runID))
+	default:
+		return ""
+	}
+}
+
+// runDir returns the run working directory, named by the run ID, next to
+// the run output (or prepare dump). The mutable run artifacts are kept in it,
+// so concurrent runs sharing the output directory never overwrite each other.
+// Runs without any artifacts on disk have no working directory
+func (p *Pipeline) runDir() string {
+	switch {
+	case p.cfg.Output != "":
+		return filepath.Join(filepath.Dir(p.cfg.Output), p.runID)
+	case p.cfg.PrepareDump != "":
+		return filepath.Join(filepath.Dir(p.cfg.PrepareDump), p.runID)
+	default:
+		return ""
+	}
+}
+
+// LogPath returns the rolling log file path of the run, if any.
+// Relative log file paths are placed in the run working directory
+func (p *Pipeline) LogPath() string {
+	if p.cfg.LogFile == "" || filepath.IsAbs(p.cfg.LogFile) {
+		return p.cfg.LogFile
+	}
+
+	return filepath.Join(p.runDir(), p.cfg.LogFile)
+}
+
+// recordLogFile records the active log file in the run manifest, if any.
+// Manifest failures never fail the run, since the log is written regardless
+func (p *Pipeline) recordLogFile() {
+	manifestPath := p.manifestPath()
+	if manifestPath == "" || p.cfg.LogFile == "" {
+		return
+	}
+
+	if err := manifest.SetLogFile(manifestPath, p.runID, p.LogPath()); err != nil {
+		fmt.Printf("⚠️ Unable to record the log file in the run manifest, %v\n", err)
+
+		_ = p.integrations.manifest.Fail(err)
+	}
+}
+
+// prepareRunDir creates the run working directory, if the run has one
+func (p *Pipeline) prepareRunDir() error {
+	dir := p.runDir()
+	if dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("unable to create run directory, %w", err)
+	}
+
+	// Make sure the run artifacts can be written to the run directory
+	probe, err := newDirProbe(dir, p.cfg.runDirSize())
+	if err != nil {
+		return fmt.Errorf("invalid run directory, %w", err)
+	}
+
+	probe.cleanup()
+
+	return nil
+}
+
+// statusPath returns the run status file path, in the run
+// working directory. Runs without any artifacts on disk
+// have no status file
+func (p *Pipeline) statusPath() string {
+	dir := p.runDir()
+	if dir == "" {
+		return ""
+	}
+
+	return filepath.Join(dir, status.FileName)
+}
+
+// startStatus starts the periodic run status file rewrites, if enabled.
+// The final status is written when the writer is stopped, bounded by the
+// component stop timeout, so a slow disk never holds up the exit
+func (p *Pipeline) startStatus() {
+	path := p.statusPath()
+	if path == "" || p.cfg.StatusInterval == 0 {
+		return
+	}
+
+	p.status = status.NewWriter(path, p.runID, p.cfg.StatusInterval)
+	p.status.Start()
+
+	p.lifecycle.Register("status writer", p.status.Close)
+}
+
+// startLive starts the live progress monitor, if the live
+// progress is printed, or served on the metrics endpoint
+func (p *Pipeline) startLive() error {
+	if p.cfg.ProgressInterval == 0 && p.cfg.MetricsAddr == "" {
+		return nil
+	}
+
+	interval := p.cfg.ProgressInterval
+	if interval == 0 {
+		interval = live.DefaultInterval
+	}
+
+	p.live = live.NewMonitor(interval, p.cfg.ProgressInterval > 0)
+
+	if p.cfg.MetricsAddr != "" {
+		if err := p.live.Serve(p.cfg.MetricsAddr); err != nil {
+			return fmt.Errorf("unable to serve the live metrics, %w", err)
+		}
+	}
+
+	p.live.Start()
+	p.lifecycle.Register("live monitor", p.live.Stop)
+
 	return nil
 }
 
+// reportSent reports the broadcast progress to the status file, and the live monitor
+func (p *Pipeline) reportSent(sent int) {
+	p.status.SetSent(sent)
+	p.live.SetSent(sent)
+}
+
+// reportCommitted reports the collection progress to the status file, and the live monitor
+func (p *Pipeline) reportCommitted(committed int) {
+	p.status.SetCommitted(committed)
+	p.live.SetCommitted(committed)
+}
+
+// durationResult returns the outcome of the duration run, if any
+func (p *Pipeline) durationResult(
+	generated int,
+	stream *durationStream,
+	batchResult *batcher.TxBatchResult,
+	deadlineReached bool,
+) *collector.DurationResult {
+	if stream == nil {
+		return nil
+	}
+
+	return &collector.DurationResult{
+		Duration:        p.cfg.Duration,
+		SendRate:        p.cfg.SendRate,
+		Target:          int(p.cfg.runTransactions()),
+		Generated:       generated,
+		Sent:            len(batchResult.TxHashes),
+		Unsent:          batchResult.Unsent,
+		TopUps:          stream.topUps,
+		Recycles:        stream.recycles(),
+		DeadlineReached: deadlineReached,
+	}
+}
+
+// progressSamples returns the live progress samples of the run, if monitored
+func (p *Pipeline) progressSamples() []*collector.ProgressSample {
+	samples := p.live.Samples()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	result := make([]*collector.ProgressSample, 0, len(samples))
+
+	for _, sample := range samples {
+		result = append(result, &collector.ProgressSample{
+			Elapsed:    sample.Elapsed,
+			Sent:       sample.Sent,
+			Committed:  sample.Committed,
+			Failed:     sample.Failed,
+			SendRate:   sample.SendRate,
+			CommitRate: sample.CommitRate,
+		})
+	}
+
+	return result
+}
+
+// newDurationStream creates the transaction stream of the duration run, signed by the
+// funded sub-accounts, and topped up by the distributor during the broadcast.
+// The window and the limit are the sub-account funding the distribution was sized with,
+// the sub-accounts dropped from the run afterwards don't change the distributed funds
+func (p *Pipeline) newDurationStream(
+	txRuntime runtime.Runtime,
+	txDistributor *distributor.Distributor,
+	distributorAccount keys.Info,
+	runAccounts []*gnoland.GnoAccount,
+	window,
+	limit uint64,
+) (*durationStream, error) {
+	txStream, err := runtime.NewStream(txRuntime, runAccounts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stream transactions, %w", err)
+	}
+
+	var topUp func(transactions uint64) (std.Coins, error)
+
+	if !p.cfg.AssumeGenesisFunded {
+		topUp = func(transactions uint64) (std.Coins, error) {
+			return txDistributor.TopUp(distributorAccount.GetAddress(), runAccounts, transactions)
+		}
+	}
+
+	stream := newDurationStream(txStream.Next, topUp, runAccounts, window, limit)
+
+	if p.cfg.RecycleIdle > 0 {
+		stream.recycler = newAccountRecycler(p.cli.GetAccount, txStream, runAccounts, p.cfg.RecycleIdle)
+	}
+
+	return stream, nil
+}
+
+// streamTransactions broadcasts the duration run transactions, generated on the fly
+func (p *Pipeline) streamTransactions(
+	txBatcher *batcher.Batcher,
+	stream *durationStream,
+) (*batcher.TxBatchResult, error) {
+	batchResult, err := txBatcher.BatchStream(stream.next, int(p.cfg.BatchSize))
+
+	// The in-flight top-up is funded regardless of the broadcast outcome
+	if closeErr := stream.close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil && len(batchResult.TxHashes) == 0 {
+		return nil, errNothingStreamed
+	}
+
+	return batchResult, err
+}
+
+// scenarioResult returns the constructed scenario template mix, if any
+func (p *Pipeline) scenarioResult() *collector.ScenarioResult {
+	if p.mixer == nil {
+		return nil
+	}
+
+	return newScenarioResult(p.cfg.scenario, p.mixer.TemplateCounts())
+}
+
+// startArtifacts starts the background artifact writes, if the run
+// has any artifacts on disk. Queued artifacts are written out when the
+// writer is stopped, bounded by the component stop timeout
+func (p *Pipeline) startArtifacts() {
+	if p.cfg.Output == "" {
+		return
+	}
+
+	p.artifacts = artifact.NewWriter()
+	p.artifacts.Start()
+
+	p.lifecycle.Register("artifact writer", p.artifacts.Close)
+}
+
+// recordArtifact appends the artifact to the run manifest.
+// Manifest failures never fail the run, since the artifact itself is saved
+func (p *Pipeline) recordArtifact(kind, path string) {
+	manifestPath := p.manifestPath()
+	if manifestPath == "" {
+		return
+	}
+
+	artifact, err := manifest.NewArtifact(kind, path)
+	if err == nil {
+		err = manifest.Append(manifestPath, p.runID, artifact)
+	}
+
+	if err != nil {
+		fmt.Printf("⚠️ Unable to record %s in the run manifest, %v\n", path, err)
+
+		_ = p.integrations.manifest.Fail(err)
+	}
+}
+
+// uploadResults uploads the results to the configured URL.
+// Failed uploads remain spooled, and can be flushed later
+func (p *Pipeline) uploadResults(runResult *collector.RunResult) {
+	fmt.Printf("\n📤 Uploading Results 📤\n\n")
+
+	resultJSON, err := json.Marshal(runResult)
+	if err != nil {
+		fmt.Printf("❌ Unable to marshal results for upload, %v\n", err)
+
+		_ = p.integrations.upload.Fail(err)
+
+		return
+	}
+
+	artifacts := []upload.Artifact{
+		{
+			Name: resultsArtifact,
+			Data: resultJSON,
+		},
+	}
+
+	uploader := upload.NewUploader(
+		upload.NewSpool(p.cfg.SpoolDir, upload.WithPassword(p.cfg.StatePassword)),
+	)
+	opts := make([]upload.UploadOption, 0, 1)
+	if manifestPath := p.manifestPath(); manifestPath != "" {
+		opts = append(opts, upload.WithManifest(manifestPath))
+	}
+
+	if err := uploader.Upload(p.runID, p.cfg.ResultsURL, artifacts, opts...); err != nil {
+		fmt.Printf(
+			"❌ Unable to upload results, %v\nThe results are spooled in %s, and can be uploaded later\n",
+			err,
+			p.cfg.SpoolDir,
+		)
+
+		_ = p.integrations.upload.Fail(err)
+
+		return
+	}
+
+	fmt.Printf("✅ Successfully uploaded results to %s\n", p.cfg.ResultsURL)
+}
+
+// newRunID generates a new unique run identifier
+func newRunID() string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405"), hex.EncodeToString(suffix))
+}
+
 // prepareRuntime prepares the runtime by pre-deploying
 // any pending transactions
 func prepareRuntime(
@@ -169,13 +2151,12 @@ func prepareRuntime(
 	accounts []keys.Info,
 	cli pipelineClient,
 	txRuntime runtime.Runtime,
+	budget *distributor.Budget,
 ) error {
-	if mode != runtime.RealmCall {
+	if !runtime.Predeploys(mode) {
 		return nil
 	}
 
-	fmt.Printf("\n✨ Starting Predeployment Procedure ✨\n\n")
-
 	// Get the deployer account
 	deployer, err := cli.GetAccount(accounts[0].GetAddress().String())
 	if err != nil {
@@ -188,10 +2169,22 @@ func prepareRuntime(
 		return fmt.Errorf("unable to initialize runtime, %w", err)
 	}
 
+	// Targeted realms are already deployed
+	if len(predeployTxs) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n✨ Starting Predeployment Procedure ✨\n\n")
+
 	bar := progressbar.Default(int64(len(predeployTxs)), "predeployed txs")
 
 	// Execute the predeploy transactions
 	for _, tx := range predeployTxs {
+		// The run can't go on without the predeployment
+		if err := budget.SpendTx(distributor.SpendPredeploy, tx); err != nil {
+			return err
+		}
+
 		if err := cli.BroadcastTransaction(tx); err != nil {
 			return fmt.Errorf("unable to broadcast predeploy tx, %w", err)
 		}
@@ -0,0 +1,817 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/batcher"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/feature"
+	"github.com/gnolang/supernova/internal/fees"
+	"github.com/gnolang/supernova/internal/history"
+	"github.com/gnolang/supernova/internal/locks"
+	"github.com/gnolang/supernova/internal/preflight"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/internal/statelock"
+	"github.com/gnolang/supernova/internal/status"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "source bonus chronic canvas draft south burst lottery vacant surface solve popular case indicate oppose farm nothing bullet exhibit title speed wink action roast"
+
+// moveToRoot changes the working directory to the repository root
+func moveToRoot(t *testing.T) {
+	t.Helper()
+
+	_, filename, _, ok := goruntime.Caller(0)
+	if !ok {
+		t.Fatal("unable to get caller information")
+	}
+
+	dir := path.Join(path.Dir(filename), "..")
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("unable to change to root dir, %v", err)
+	}
+}
+
+// testConfig returns the base run configuration against the given URL,
+// which the tests only override the relevant fields of
+func testConfig(t *testing.T, url string) *Config {
+	t.Helper()
+
+	return &Config{
+		URL:      url,
+		ChainID:  "dev",
+		Mnemonic: testMnemonic,
+		Mode:     runtime.RealmDeployment.String(),
+
+		SubAccounts:  1,
+		Transactions: 5,
+		BatchSize:    5,
+		FundingBatch: 100,
+
+		SubAccountOffset: 1,
+
+		CompletionThreshold: 1,
+		CompletionGrace:     time.Second,
+
+		PendingTxPolicy:  string(preflight.PendingWait),
+		PendingTxWait:    time.Second,
+		EndpointAffinity: string(batcher.AffinityRoundRobin),
+		SpoolDir:         t.TempDir(),
+	}
+}
+
+// verifyNoLeaks makes sure the goroutine count settles back to the baseline,
+// and dumps the running goroutines if it does not
+func verifyNoLeaks(t *testing.T, baseline int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		current := goruntime.NumGoroutine()
+		if current <= baseline {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			stacks := make([]byte, 1<<20)
+			stacks = stacks[:goruntime.Stack(stacks, true)]
+
+			t.Fatalf("leaked %d goroutines:\n%s", current-baseline, stacks)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestPipeline_NoLeaks(t *testing.T) {
+	moveToRoot(t)
+
+	baseline := goruntime.NumGoroutine()
+
+	// Create the node metrics endpoint, so the scraper runs throughout the run
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintln(w, "tendermint_mempool_size 1")
+	}))
+
+	var (
+		output = filepath.Join(t.TempDir(), "results.json")
+		chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Output = output
+	cfg.SubAccounts = 3
+	cfg.Transactions = 10
+	cfg.History = true
+	cfg.HistoryDB = filepath.Join(t.TempDir(), "history.db")
+	cfg.NodeMetricsURL = node.URL
+	cfg.NodeMetrics = "tendermint_mempool_size"
+	cfg.NodeMetricsInterval = 10 * time.Millisecond
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	p := NewPipeline(cfg)
+	p.cli = chain
+
+	if err := p.Execute(); err != nil {
+		t.Fatalf("unable to execute the run, %v", err)
+	}
+
+	node.Close()
+
+	// Make sure the run went through, and the components were stopped
+	_, err := os.Stat(output)
+	assert.NoError(t, err)
+	assert.True(t, chain.closed)
+
+	// Make sure the idle connections were torn down after each phase
+	assert.Equal(t, len(p.phases), chain.idleCloses)
+
+	// Make sure the run was indexed in the history
+	db, err := history.Open(cfg.HistoryDB)
+	if err != nil {
+		t.Fatalf("unable to open history, %v", err)
+	}
+
+	entries, err := db.Query(history.Filter{Mode: cfg.Mode})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.NoError(t, db.Close())
+
+	verifyNoLeaks(t, baseline)
+}
+
+func TestPipeline_DegradedIntegrations(t *testing.T) {
+	moveToRoot(t)
+
+	// The node metrics endpoint is down for the whole run
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer node.Close()
+
+	// execute runs the pipeline, and returns the saved results
+	execute := func(t *testing.T, strict bool) (*collector.RunResult, error) {
+		t.Helper()
+
+		var (
+			output = filepath.Join(t.TempDir(), "results.json")
+			chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+		)
+
+		cfg := testConfig(t, "http://127.0.0.1:26657")
+
+		cfg.Output = output
+		cfg.NodeMetricsURL = node.URL
+		cfg.NodeMetrics = "tendermint_mempool_size"
+		cfg.NodeMetricsInterval = 10 * time.Millisecond
+		cfg.StrictIntegrations = strict
+
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("invalid configuration, %v", err)
+		}
+
+		p := NewPipeline(cfg)
+		p.cli = chain
+
+		runErr := p.Execute()
+
+		result, err := loadRunResult(output)
+		if err != nil {
+			t.Fatalf("unable to load results, %v", err)
+		}
+
+		return result, runErr
+	}
+
+	t.Run("degraded run", func(t *testing.T) {
+		result, err := execute(t, false)
+
+		// The headline measurement is kept, with the degraded integration
+		assert.NoError(t, err)
+		assert.Equal(t, 5, result.CommittedTxs)
+
+		if assert.Len(t, result.Degraded, 1) {
+			assert.Equal(t, integrationNodeMetrics, result.Degraded[0].Integration)
+		}
+	})
+
+	t.Run("strict integrations", func(t *testing.T) {
+		result, err := execute(t, true)
+
+		assert.ErrorIs(t, err, feature.ErrDegraded)
+		assert.Len(t, result.Degraded, 1)
+	})
+}
+
+func TestPipeline_TxIndexDisabled(t *testing.T) {
+	moveToRoot(t)
+
+	// execute runs the pipeline against a node without the tx indexer
+	execute := func(t *testing.T, required bool) (*collector.RunResult, error) {
+		t.Helper()
+
+		var (
+			output = filepath.Join(t.TempDir(), "results.json")
+			chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+		)
+
+		chain.txIndex = "off"
+
+		cfg := testConfig(t, "http://127.0.0.1:26657")
+
+		cfg.Output = output
+		cfg.BackfillRate = 20
+		cfg.RequireTxIndex = required
+
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("invalid configuration, %v", err)
+		}
+
+		p := NewPipeline(cfg)
+		p.cli = chain
+
+		if err := p.Execute(); err != nil {
+			return nil, err
+		}
+
+		result, err := loadRunResult(output)
+		if err != nil {
+			t.Fatalf("unable to load results, %v", err)
+		}
+
+		return result, nil
+	}
+
+	t.Run("dependent features disabled", func(t *testing.T) {
+		result, err := execute(t, false)
+		if err != nil {
+			t.Fatalf("unable to execute pipeline, %v", err)
+		}
+
+		assert.Equal(t, 5, result.CommittedTxs)
+
+		if assert.NotNil(t, result.Indexer) {
+			assert.Equal(t, string(preflight.IndexerDisabled), result.Indexer.Status)
+			assert.Equal(t, []string{integrationBackfill}, result.Indexer.Disabled)
+		}
+	})
+
+	t.Run("dependent features required", func(t *testing.T) {
+		_, err := execute(t, true)
+
+		assert.ErrorIs(t, err, errTxIndexDisabled)
+	})
+}
+
+func TestPipeline_EstimateGas(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		output = filepath.Join(t.TempDir(), "results.json")
+		chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	chain.gasUsed = 200_000
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Output = output
+	cfg.EstimateGas = true
+	cfg.GasMargin = 0.5
+	cfg.GasPrice = "1ugnot/1000gas"
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	p := NewPipeline(cfg)
+	p.cli = chain
+
+	if err := p.Execute(); err != nil {
+		t.Fatalf("unable to execute the run, %v", err)
+	}
+
+	result, err := loadRunResult(output)
+	if err != nil {
+		t.Fatalf("unable to load results, %v", err)
+	}
+
+	// The run gas wanted is the simulated gas used, with the margin
+	assert.Equal(t, int64(300_000), result.GasWanted.Run)
+	assert.Equal(t, int64(200_000), result.GasWanted.Simulated)
+	assert.False(t, result.GasWanted.Default)
+
+	// The sub-accounts are funded for the priced fees
+	assert.Equal(t, "300ugnot", result.Fees[fees.AddPackage].GasFee)
+	assert.Equal(t, common.InitialTxCost.Amount+300, result.Costs.TxCost)
+}
+
+func TestPipeline_Duration(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		output = filepath.Join(t.TempDir(), "results.json")
+		chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Output = output
+	cfg.SubAccounts = 2
+	cfg.SendRate = 20
+	cfg.Duration = time.Second
+	cfg.ProgressInterval = 100 * time.Millisecond
+	cfg.MetricsAddr = "127.0.0.1:0"
+
+	// The transactions are generated until the deadline
+	cfg.Transactions = 0
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	p := NewPipeline(cfg)
+	p.cli = chain
+
+	if err := p.Execute(); err != nil {
+		t.Fatalf("unable to execute the run, %v", err)
+	}
+
+	result, err := loadRunResult(output)
+	if err != nil {
+		t.Fatalf("unable to load results, %v", err)
+	}
+
+	if result.Duration == nil {
+		t.Fatal("duration result not reported")
+	}
+
+	assert.Equal(t, time.Second, result.Duration.Duration)
+	assert.Equal(t, 20, result.Duration.Target)
+	assert.Equal(t, result.Duration.Generated, result.Duration.Sent+result.Duration.Unsent)
+	assert.LessOrEqual(t, result.Duration.Sent, 20+int(cfg.BatchSize))
+	assert.Equal(t, result.Duration.Sent, result.CommittedTxs+result.LostTxs)
+
+	// The live progress samples are saved with the results
+	assert.NotEmpty(t, result.Progress)
+
+	// The live progress reflects the collected run
+	progress := p.live.Snapshot()
+
+	assert.Equal(t, result.Duration.Sent, progress.Sent)
+	assert.Equal(t, result.CommittedTxs, progress.Committed)
+}
+
+func TestPipeline_DurationExcluded(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		output = filepath.Join(t.TempDir(), "results.json")
+		chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Output = output
+	cfg.SubAccounts = 3
+	cfg.ExcludeAccounts = "2"
+	cfg.SendRate = 20
+	cfg.Duration = time.Second
+	cfg.Transactions = 0
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	p := NewPipeline(cfg)
+	p.cli = chain
+
+	if err := p.Execute(); err != nil {
+		t.Fatalf("unable to execute the run, %v", err)
+	}
+
+	result, err := loadRunResult(output)
+	if err != nil {
+		t.Fatalf("unable to load results, %v", err)
+	}
+
+	if result.Costs == nil || result.Duration == nil {
+		t.Fatal("run costs not reported")
+	}
+
+	assert.Len(t, p.runAccounts, 2)
+
+	// The round is funded for the remaining sub-accounts
+	window, _ := p.durationFunding(len(p.runAccounts))
+
+	assert.Equal(t, result.Costs.TxCost*int64(window), result.Costs.AccountCost)
+	assert.LessOrEqual(t, accountShare(result.Duration.Generated, len(p.runAccounts)), window)
+}
+
+func TestConfig_Duration(t *testing.T) {
+	t.Parallel()
+
+	newDurationConfig := func(t *testing.T) *Config {
+		t.Helper()
+
+		cfg := testConfig(t, "http://127.0.0.1:26657")
+
+		cfg.SendRate = 20
+		cfg.Duration = time.Second
+		cfg.Transactions = 0
+
+		return cfg
+	}
+
+	t.Run("generated transactions", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newDurationConfig(t)
+
+		assert.NoError(t, cfg.Validate())
+
+		// The transactions are not sized up front
+		assert.Zero(t, cfg.Transactions)
+		assert.Equal(t, uint64(20), cfg.runTransactions())
+	})
+
+	t.Run("fixed number of transactions", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newDurationConfig(t)
+		cfg.Transactions = 10
+
+		assert.ErrorIs(t, cfg.Validate(), errDurationTxCount)
+	})
+
+	t.Run("reproducible run", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newDurationConfig(t)
+		cfg.Reproducible = true
+
+		assert.ErrorIs(t, cfg.Validate(), errDurationConflict)
+	})
+
+	t.Run("recycled sub-accounts", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newDurationConfig(t)
+		cfg.RecycleIdle = 30 * time.Second
+
+		assert.NoError(t, cfg.Validate())
+
+		// Fixed transaction sets are constructed up front, and can't be reassigned
+		fixed := testConfig(t, "http://127.0.0.1:26657")
+		fixed.RecycleIdle = 30 * time.Second
+
+		assert.ErrorIs(t, fixed.Validate(), errRecycleDuration)
+
+		cfg.RecycleIdle = -time.Second
+
+		assert.ErrorIs(t, cfg.Validate(), errInvalidRecycleIdle)
+	})
+}
+
+func TestConfig_ResultsSize(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Transactions = 100
+	cfg.SubAccounts = 10
+
+	// The results, the account accounting and the manifest
+	base := cfg.resultsSize()
+	assert.Equal(
+		t,
+		uint64(baseResultsSize+100*blockResultSize+10*accountResultSize+
+			manifestBaseSize+manifestArtifacts*manifestArtifactSize),
+		base,
+	)
+	assert.Equal(t, uint64(statusFileSize), cfg.runDirSize())
+
+	// The enabled detail outputs are part of the estimate
+	cfg.endpoints = []string{"http://127.0.0.1:26657", "http://127.0.0.1:26658"}
+	cfg.Sweep = true
+	cfg.SendRate = 10
+	cfg.ProgressInterval = time.Second
+	cfg.MempoolSampleInterval = 100 * time.Millisecond
+	cfg.ReportInterval = 5 * time.Second
+
+	// 10s of broadcast, and the completion grace, sampled every second,
+	// and split into 3 segments, each with a manifest entry
+	assert.Equal(
+		t,
+		base+2*endpointResultSize+10*sweepAccountSize+12*progressSampleSize+latencyAttributionSize+
+			3*segmentResultSize+3*manifestArtifactSize,
+		cfg.resultsSize(),
+	)
+
+	// The segment files hold the blocks of their windows
+	assert.Equal(t, uint64(statusFileSize+3*segmentResultSize+100*blockResultSize), cfg.runDirSize())
+}
+
+func TestPipeline_Sweep(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		output = filepath.Join(t.TempDir(), "results.json")
+		chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Output = output
+	cfg.SubAccounts = 2
+	cfg.Transactions = 4
+	cfg.BatchSize = 4
+	cfg.Sweep = true
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	p := NewPipeline(cfg)
+	p.cli = chain
+
+	if err := p.Execute(); err != nil {
+		t.Fatalf("unable to execute the run, %v", err)
+	}
+
+	result, err := loadRunResult(output)
+	if err != nil {
+		t.Fatalf("unable to load results, %v", err)
+	}
+
+	// The sweep is reported with the run results
+	if result.Sweep == nil {
+		t.Fatal("sweep result not reported")
+	}
+
+	assert.Equal(t, common.Denomination, result.Sweep.Denom)
+	assert.Equal(t, result.Costs.DistributorAddress, result.Sweep.Distributor)
+	assert.Equal(t, 2, result.Sweep.Swept)
+	assert.Positive(t, result.Sweep.Recovered)
+}
+
+func TestPipeline_Scenario(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		dir    = t.TempDir()
+		output = filepath.Join(dir, "results.json")
+		chain  = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	scenario := filepath.Join(dir, "scenario.yaml")
+
+	if err := os.WriteFile(scenario, []byte(`
+name: boards
+templates:
+  - name: post
+    pkgPath: gno.land/r/demo/boards
+    func: CreatePost
+    args: ["post-{{.Counter}}"]
+    weight: 2
+  - name: render
+    pkgPath: gno.land/r/demo/boards
+    func: Render
+    args: [""]
+`), 0o600); err != nil {
+		t.Fatalf("unable to write scenario, %v", err)
+	}
+
+	newConfig := func() *Config {
+		cfg := testConfig(t, "http://127.0.0.1:26657")
+
+		cfg.Mode = runtime.CustomScenario.String()
+		cfg.Output = output
+		cfg.Scenario = scenario
+		cfg.SubAccounts = 2
+		cfg.Transactions = 10
+		cfg.BatchSize = 10
+
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("invalid configuration, %v", err)
+		}
+
+		return cfg
+	}
+
+	t.Run("undeployed realm", func(t *testing.T) {
+		p := NewPipeline(newConfig())
+		p.cli = chain
+
+		// The realm is checked before the accounts are funded
+		assert.ErrorContains(t, p.Execute(), "realm is not deployed")
+	})
+
+	t.Run("mixed run", func(t *testing.T) {
+		chain.funcs = map[string]string{
+			"gno.land/r/demo/boards": `[{"FuncName":"CreatePost","Params":[{"Name":"title","Type":"string"}]},` +
+				`{"FuncName":"Render","Params":[{"Name":"path","Type":"string"}]}]`,
+		}
+
+		p := NewPipeline(newConfig())
+		p.cli = chain
+
+		if err := p.Execute(); err != nil {
+			t.Fatalf("unable to execute the run, %v", err)
+		}
+
+		result, err := loadRunResult(output)
+		if err != nil {
+			t.Fatalf("unable to load results, %v", err)
+		}
+
+		if result.Scenario == nil || len(result.Scenario.Templates) != 2 {
+			t.Fatalf("invalid scenario result, %v", result.Scenario)
+		}
+
+		assert.Equal(t, "boards", result.Scenario.Name)
+		assert.Equal(t, p.scenarioHash(), result.Scenario.Hash)
+		assert.Equal(
+			t,
+			10,
+			result.Scenario.Templates[0].Transactions+result.Scenario.Templates[1].Transactions,
+		)
+
+		// The scenario calls use the realm call gas
+		assert.Equal(t, runtime.DefaultCallGasWanted, result.GasWanted.Run)
+	})
+}
+
+func TestPipeline_ConstructionSkipped(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		dir   = t.TempDir()
+		chain = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+	)
+
+	chain.funcs = map[string]string{
+		"gno.land/r/demo/boards": `[{"FuncName":"CreatePost","Params":[{"Name":"title","Type":"string"}]}]`,
+	}
+
+	// The argument passes the dry run, but fails for every run transaction
+	scenario := filepath.Join(dir, "scenario.yaml")
+
+	if err := os.WriteFile(scenario, []byte(`
+name: broken
+templates:
+  - name: post
+    pkgPath: gno.land/r/demo/boards
+    func: CreatePost
+    args: ["{{if .Address}}{{.RandomRange 2 1}}{{end}}"]
+`), 0o600); err != nil {
+		t.Fatalf("unable to write scenario, %v", err)
+	}
+
+	cfg := testConfig(t, "http://127.0.0.1:26657")
+
+	cfg.Mode = runtime.CustomScenario.String()
+	cfg.Output = filepath.Join(dir, "results.json")
+	cfg.Scenario = scenario
+	cfg.ConstructionErrorPolicy = string(runtime.ConstructionSkip)
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid configuration, %v", err)
+	}
+
+	p := NewPipeline(cfg)
+	p.cli = chain
+
+	// The run fails before anything is broadcast
+	assert.ErrorIs(t, p.Execute(), errNoTransactions)
+	assert.NoFileExists(t, cfg.Output)
+}
+
+func TestPipeline_ConcurrentRuns(t *testing.T) {
+	moveToRoot(t)
+
+	var (
+		dir      = t.TempDir()
+		stateDir = filepath.Join(t.TempDir(), ".supernova")
+
+		// The runs share the distributor index, so they use separate mnemonics
+		mnemonics = []string{
+			testMnemonic,
+			strings.Repeat("abandon ", 23) + "art",
+		}
+
+		pipelines = make([]*Pipeline, 0, len(mnemonics))
+		outputs   = make([]string, 0, len(mnemonics))
+	)
+
+	for i, mnemonic := range mnemonics {
+		output := filepath.Join(dir, fmt.Sprintf("results-%d.json", i))
+
+		cfg := testConfig(t, "http://127.0.0.1:26657")
+
+		cfg.Mnemonic = mnemonic
+		cfg.Output = output
+		cfg.SubAccounts = 3
+		cfg.Transactions = 10
+		cfg.SpoolDir = filepath.Join(stateDir, "spool")
+		cfg.StatusInterval = time.Millisecond
+		cfg.History = true
+		cfg.HistoryDB = filepath.Join(stateDir, "history.db")
+		cfg.LockRegistry = filepath.Join(stateDir, "locks.json")
+		cfg.LockTTL = time.Minute
+		cfg.StateLockTimeout = 10 * time.Second
+
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("invalid configuration, %v", err)
+		}
+
+		p := NewPipeline(cfg)
+		p.cli = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000_000_000)))
+
+		pipelines = append(pipelines, p)
+		outputs = append(outputs, output)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(pipelines))
+	)
+
+	for i, p := range pipelines {
+		wg.Add(1)
+
+		go func(i int, p *Pipeline) {
+			defer wg.Done()
+
+			errs[i] = p.Execute()
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	for i, p := range pipelines {
+		if errs[i] != nil {
+			t.Fatalf("unable to execute run %d, %v", i, errs[i])
+		}
+
+		// Make sure the results are intact
+		data, err := os.ReadFile(outputs[i])
+		if err != nil {
+			t.Fatalf("unable to read results, %v", err)
+		}
+
+		var result collector.RunResult
+
+		assert.NoError(t, json.Unmarshal(data, &result))
+
+		// Make sure each run kept its own status file
+		data, err = os.ReadFile(filepath.Join(dir, p.runID, status.FileName))
+		if err != nil {
+			t.Fatalf("unable to read status, %v", err)
+		}
+
+		var runStatus status.Status
+
+		assert.NoError(t, json.Unmarshal(data, &runStatus))
+		assert.Equal(t, p.runID, runStatus.RunID)
+		assert.Equal(t, status.StateCompleted, runStatus.State)
+	}
+
+	// Make sure both runs were indexed in the shared history
+	db, err := history.Open(filepath.Join(stateDir, "history.db"))
+	if err != nil {
+		t.Fatalf("unable to open history, %v", err)
+	}
+
+	entries, err := db.Query(history.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.NoError(t, db.Close())
+
+	// Make sure the account locks were released, along with the state lock
+	runLocks, err := locks.List(filepath.Join(stateDir, "locks.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, runLocks)
+
+	_, err = os.Stat(filepath.Join(stateDir, statelock.FileName))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
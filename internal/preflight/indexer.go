@@ -0,0 +1,70 @@
+package preflight
+
+import (
+	"strings"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/crypto/tmhash"
+)
+
+// IndexerStatus is the tx indexer availability of the node
+type IndexerStatus string
+
+const (
+	// IndexerEnabled means the node indexes the committed transactions
+	IndexerEnabled IndexerStatus = "enabled"
+
+	// IndexerDisabled means the node runs without the tx indexer,
+	// so the tx endpoint does not find any transaction
+	IndexerDisabled IndexerStatus = "disabled"
+
+	// IndexerUnknown means the indexer availability could not be detected
+	IndexerUnknown IndexerStatus = "unknown"
+)
+
+// The tx indexer availability sources
+const (
+	IndexerSourceStatus = "node status" // the indexer flag of the node info
+	IndexerSourceProbe  = "tx probe"    // the tx endpoint error of an unknown hash
+)
+
+// The tx endpoint errors, distinguishing a disabled indexer from a missing transaction
+const (
+	indexerDisabledErr = "indexing is disabled"
+	txNotFoundErr      = "not found"
+)
+
+// IndexerClient fetches the node status, and the committed transactions
+type IndexerClient interface {
+	GetStatus() (*core_types.ResultStatus, error)
+	GetTx(hash []byte) (*core_types.ResultTx, error)
+}
+
+// DetectIndexer detects the tx indexer availability of the node, along with its source.
+// The node status exposes the indexer flag, and nodes that leave it out are probed
+// with an unknown hash on the tx endpoint, which is only reported as not found
+// if the node indexes transactions
+func DetectIndexer(cli IndexerClient) (IndexerStatus, string) {
+	if status, err := cli.GetStatus(); err == nil {
+		switch status.NodeInfo.Other.TxIndex {
+		case "on":
+			return IndexerEnabled, IndexerSourceStatus
+		case "off":
+			return IndexerDisabled, IndexerSourceStatus
+		}
+	}
+
+	// No run transaction hashes to the all-zero hash
+	_, err := cli.GetTx(make([]byte, tmhash.Size))
+
+	switch {
+	case err == nil:
+		return IndexerEnabled, IndexerSourceProbe
+	case strings.Contains(err.Error(), indexerDisabledErr):
+		return IndexerDisabled, IndexerSourceProbe
+	case strings.Contains(err.Error(), txNotFoundErr):
+		return IndexerEnabled, IndexerSourceProbe
+	default:
+		return IndexerUnknown, IndexerSourceProbe
+	}
+}
@@ -0,0 +1,106 @@
+package preflight
+
+import (
+	"errors"
+	"testing"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectIndexer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errStatus = errors.New("status unavailable")
+
+		status = func(txIndex string) getStatusDelegate {
+			return func() (*core_types.ResultStatus, error) {
+				return &core_types.ResultStatus{
+					NodeInfo: p2p.NodeInfo{
+						Other: p2p.NodeInfoOther{TxIndex: txIndex},
+					},
+				}, nil
+			}
+		}
+
+		probe = func(err error) getTxDelegate {
+			return func(_ []byte) (*core_types.ResultTx, error) {
+				return nil, err
+			}
+		}
+	)
+
+	testTable := []struct {
+		name           string
+		getStatusFn    getStatusDelegate
+		getTxFn        getTxDelegate
+		expectedStatus IndexerStatus
+		expectedSource string
+	}{
+		{
+			"indexer on in the status",
+			status("on"),
+			nil,
+			IndexerEnabled,
+			IndexerSourceStatus,
+		},
+		{
+			"indexer off in the status",
+			status("off"),
+			nil,
+			IndexerDisabled,
+			IndexerSourceStatus,
+		},
+		{
+			"indexer disabled on the tx endpoint",
+			status(""),
+			probe(errors.New("Transaction indexing is disabled")),
+			IndexerDisabled,
+			IndexerSourceProbe,
+		},
+		{
+			"probe tx not found",
+			func() (*core_types.ResultStatus, error) {
+				return nil, errStatus
+			},
+			probe(errors.New("Tx (00) not found")),
+			IndexerEnabled,
+			IndexerSourceProbe,
+		},
+		{
+			"probe failed",
+			status(""),
+			probe(errors.New("connection refused")),
+			IndexerUnknown,
+			IndexerSourceProbe,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, source := DetectIndexer(&mockClient{
+				getStatusFn: testCase.getStatusFn,
+				getTxFn: func(hash []byte) (*core_types.ResultTx, error) {
+					// The probe hash is not a run transaction hash
+					assert.Len(t, hash, len(types.Tx(nil).Hash()))
+
+					if testCase.getTxFn == nil {
+						t.Fatalf("tx endpoint probed, despite the status")
+					}
+
+					return testCase.getTxFn(hash)
+				},
+			})
+
+			assert.Equal(t, testCase.expectedStatus, status)
+			assert.Equal(t, testCase.expectedSource, source)
+		})
+	}
+}
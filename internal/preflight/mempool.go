@@ -0,0 +1,225 @@
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+// maxUnconfirmedTxs is the maximum number of unconfirmed
+// transactions the node returns in a single query
+const maxUnconfirmedTxs = 100
+
+var (
+	errPendingTimeout = errors.New("timed out waiting for pending transactions")
+	errNoAccountsLeft = errors.New("all accounts have pending transactions")
+	errUnknownPolicy  = errors.New("unknown pending transaction policy")
+)
+
+// PendingPolicy is the resolution policy for accounts
+// that have transactions pending in the mempool
+type PendingPolicy string
+
+const (
+	// PendingWait waits for the pending transactions to leave the mempool
+	PendingWait PendingPolicy = "wait"
+
+	// PendingBump bumps the starting nonce past the pending transactions
+	PendingBump PendingPolicy = "bump"
+
+	// PendingExclude excludes the accounts from the run
+	PendingExclude PendingPolicy = "exclude"
+)
+
+// IsPendingPolicy checks if the passed in policy is supported
+func IsPendingPolicy(policy PendingPolicy) bool {
+	return policy == PendingWait ||
+		policy == PendingBump ||
+		policy == PendingExclude
+}
+
+type Client interface {
+	GetAccount(address string) (*gnoland.GnoAccount, error)
+	GetUnconfirmedTxs(limit int) ([]types.Tx, error)
+}
+
+// MempoolChecker detects run accounts that already have
+// transactions pending in the node mempool (for example, from
+// a previously crashed run), and resolves them using the set policy
+type MempoolChecker struct {
+	cli    Client
+	policy PendingPolicy
+
+	pollInterval time.Duration
+	waitTimeout  time.Duration
+}
+
+// NewMempoolChecker creates a new instance of the mempool checker
+func NewMempoolChecker(cli Client, policy PendingPolicy, waitTimeout time.Duration) *MempoolChecker {
+	return &MempoolChecker{
+		cli:          cli,
+		policy:       policy,
+		pollInterval: time.Second,
+		waitTimeout:  waitTimeout,
+	}
+}
+
+// ResolvePending checks the mempool for pending transactions of the given accounts,
+// and returns the accounts that can safely participate in the run
+func (m *MempoolChecker) ResolvePending(accounts []*gnoland.GnoAccount) ([]*gnoland.GnoAccount, error) {
+	fmt.Printf("\n🔍 Checking Pending Transactions 🔍\n\n")
+
+	pending, err := m.pendingTxs(accounts)
+	if err != nil {
+		// Not all nodes expose the mempool contents
+		fmt.Printf("⚠️ Unable to check the mempool for pending transactions, %v\n", err)
+
+		return accounts, nil
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("✅ No accounts have pending transactions\n")
+
+		return accounts, nil
+	}
+
+	switch m.policy {
+	case PendingWait:
+		return m.waitPending(accounts, pending)
+	case PendingBump:
+		return bumpPending(accounts, pending), nil
+	case PendingExclude:
+		return excludePending(accounts, pending)
+	default:
+		return nil, fmt.Errorf("%w, %s", errUnknownPolicy, m.policy)
+	}
+}
+
+// pendingTxs returns the number of pending mempool
+// transactions for each of the given accounts, if any
+func (m *MempoolChecker) pendingTxs(accounts []*gnoland.GnoAccount) (map[string]uint64, error) {
+	txs, err := m.cli.GetUnconfirmedTxs(maxUnconfirmedTxs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch unconfirmed transactions, %w", err)
+	}
+
+	lookup := make(map[string]struct{}, len(accounts))
+	for _, account := range accounts {
+		lookup[account.GetAddress().String()] = struct{}{}
+	}
+
+	pending := make(map[string]uint64)
+
+	for _, txBin := range txs {
+		var tx std.Tx
+
+		if err := amino.Unmarshal(txBin, &tx); err != nil {
+			// Unknown transaction type, cannot belong to the run accounts
+			continue
+		}
+
+		for _, signer := range tx.GetSigners() {
+			if _, ok := lookup[signer.String()]; ok {
+				pending[signer.String()]++
+			}
+		}
+	}
+
+	return pending, nil
+}
+
+// waitPending waits for the pending transactions to leave the mempool,
+// and re-fetches the affected accounts for their up-to-date sequence
+func (m *MempoolChecker) waitPending(
+	accounts []*gnoland.GnoAccount,
+	pending map[string]uint64,
+) ([]*gnoland.GnoAccount, error) {
+	for address, count := range pending {
+		fmt.Printf("Account %s has %d pending txs, waiting for the mempool to drain them\n", address, count)
+	}
+
+	timeout := time.After(m.waitTimeout)
+
+	for len(pending) != 0 {
+		select {
+		case <-timeout:
+			return nil, fmt.Errorf("%w, %d accounts still have pending txs", errPendingTimeout, len(pending))
+		case <-time.After(m.pollInterval):
+			stillPending, err := m.pendingTxs(accounts)
+			if err != nil {
+				return nil, err
+			}
+
+			pending = stillPending
+		}
+	}
+
+	// Re-fetch the accounts, since their sequence changed
+	refreshed := make([]*gnoland.GnoAccount, 0, len(accounts))
+
+	for _, account := range accounts {
+		nodeAccount, err := m.cli.GetAccount(account.GetAddress().String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch account, %w", err)
+		}
+
+		refreshed = append(refreshed, nodeAccount)
+	}
+
+	fmt.Printf("✅ Pending transactions were drained from the mempool\n")
+
+	return refreshed, nil
+}
+
+// bumpPending bumps the starting nonce of the
+// affected accounts past their pending transactions
+func bumpPending(accounts []*gnoland.GnoAccount, pending map[string]uint64) []*gnoland.GnoAccount {
+	for _, account := range accounts {
+		count, ok := pending[account.GetAddress().String()]
+		if !ok {
+			continue
+		}
+
+		fmt.Printf(
+			"Account %s has %d pending txs, bumping the starting nonce from %d to %d\n",
+			account.GetAddress(),
+			count,
+			account.Sequence,
+			account.Sequence+count,
+		)
+
+		account.Sequence += count
+	}
+
+	return accounts
+}
+
+// excludePending excludes the affected accounts from the run
+func excludePending(
+	accounts []*gnoland.GnoAccount,
+	pending map[string]uint64,
+) ([]*gnoland.GnoAccount, error) {
+	remaining := make([]*gnoland.GnoAccount, 0, len(accounts))
+
+	for _, account := range accounts {
+		count, ok := pending[account.GetAddress().String()]
+		if !ok {
+			remaining = append(remaining, account)
+
+			continue
+		}
+
+		fmt.Printf("⚠️ Account %s has %d pending txs, excluding it from the run\n", account.GetAddress(), count)
+	}
+
+	if len(remaining) == 0 {
+		return nil, errNoAccountsLeft
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,227 @@
+package preflight
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/bft/types"
+	"github.com/gnolang/gno/pkgs/crypto/secp256k1"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateAccounts generates random gno accounts
+func generateAccounts(count int) []*gnoland.GnoAccount {
+	accounts := make([]*gnoland.GnoAccount, count)
+
+	for i := 0; i < count; i++ {
+		accounts[i] = &gnoland.GnoAccount{
+			BaseAccount: std.BaseAccount{
+				Address:       secp256k1.GenPrivKey().PubKey().Address(),
+				AccountNumber: uint64(i),
+				Sequence:      10,
+			},
+		}
+	}
+
+	return accounts
+}
+
+// generatePendingTxs generates pending transfer txs from the given account
+func generatePendingTxs(t *testing.T, account *gnoland.GnoAccount, count int) []types.Tx {
+	t.Helper()
+
+	txs := make([]types.Tx, count)
+
+	for i := 0; i < count; i++ {
+		tx := std.Tx{
+			Msgs: []std.Msg{
+				bank.MsgSend{
+					FromAddress: account.GetAddress(),
+					ToAddress:   account.GetAddress(),
+					Amount:      std.NewCoins(std.NewCoin("ugnot", int64(i+1))),
+				},
+			},
+		}
+
+		txBin, err := amino.Marshal(tx)
+		if err != nil {
+			t.Fatalf("unable to marshal tx, %v", err)
+		}
+
+		txs[i] = txBin
+	}
+
+	return txs
+}
+
+func TestMempoolChecker_ResolvePending(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := generateAccounts(5)
+
+		c := NewMempoolChecker(&mockClient{}, PendingExclude, time.Second)
+
+		resolved, err := c.ResolvePending(accounts)
+		if err != nil {
+			t.Fatalf("unable to resolve pending txs, %v", err)
+		}
+
+		assert.Equal(t, accounts, resolved)
+	})
+
+	t.Run("mempool not exposed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts   = generateAccounts(5)
+			mockClient = &mockClient{
+				getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+					return nil, errors.New("method not found")
+				},
+			}
+		)
+
+		c := NewMempoolChecker(mockClient, PendingExclude, time.Second)
+
+		resolved, err := c.ResolvePending(accounts)
+		if err != nil {
+			t.Fatalf("unable to resolve pending txs, %v", err)
+		}
+
+		assert.Equal(t, accounts, resolved)
+	})
+
+	t.Run("bump policy", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts   = generateAccounts(5)
+			pendingTxs = generatePendingTxs(t, accounts[1], 3)
+			mockClient = &mockClient{
+				getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+					return pendingTxs, nil
+				},
+			}
+		)
+
+		c := NewMempoolChecker(mockClient, PendingBump, time.Second)
+
+		resolved, err := c.ResolvePending(accounts)
+		if err != nil {
+			t.Fatalf("unable to resolve pending txs, %v", err)
+		}
+
+		assert.Len(t, resolved, len(accounts))
+
+		for index, account := range resolved {
+			expectedSequence := uint64(10)
+			if index == 1 {
+				expectedSequence += 3
+			}
+
+			assert.Equal(t, expectedSequence, account.Sequence)
+		}
+	})
+
+	t.Run("exclude policy", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts   = generateAccounts(5)
+			pendingTxs = generatePendingTxs(t, accounts[1], 3)
+			mockClient = &mockClient{
+				getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+					return pendingTxs, nil
+				},
+			}
+		)
+
+		c := NewMempoolChecker(mockClient, PendingExclude, time.Second)
+
+		resolved, err := c.ResolvePending(accounts)
+		if err != nil {
+			t.Fatalf("unable to resolve pending txs, %v", err)
+		}
+
+		assert.Len(t, resolved, len(accounts)-1)
+		assert.NotContains(t, resolved, accounts[1])
+	})
+
+	t.Run("wait policy", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mux sync.Mutex
+
+			accounts   = generateAccounts(5)
+			pendingTxs = generatePendingTxs(t, accounts[1], 3)
+			mockClient = &mockClient{
+				getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+					mux.Lock()
+					defer mux.Unlock()
+
+					// Drain a single transaction per query
+					txs := pendingTxs
+					if len(pendingTxs) > 0 {
+						pendingTxs = pendingTxs[1:]
+					}
+
+					return txs, nil
+				},
+				getAccountFn: func(_ string) (*gnoland.GnoAccount, error) {
+					return &gnoland.GnoAccount{
+						BaseAccount: std.BaseAccount{
+							Sequence: 13,
+						},
+					}, nil
+				},
+			}
+		)
+
+		c := NewMempoolChecker(mockClient, PendingWait, time.Second*5)
+		c.pollInterval = time.Millisecond
+
+		resolved, err := c.ResolvePending(accounts)
+		if err != nil {
+			t.Fatalf("unable to resolve pending txs, %v", err)
+		}
+
+		assert.Len(t, resolved, len(accounts))
+
+		// Make sure the accounts were re-fetched
+		for _, account := range resolved {
+			assert.Equal(t, uint64(13), account.Sequence)
+		}
+	})
+
+	t.Run("wait policy timeout", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts   = generateAccounts(5)
+			pendingTxs = generatePendingTxs(t, accounts[1], 3)
+			mockClient = &mockClient{
+				getUnconfirmedTxsFn: func(_ int) ([]types.Tx, error) {
+					return pendingTxs, nil
+				},
+			}
+		)
+
+		c := NewMempoolChecker(mockClient, PendingWait, time.Millisecond*50)
+		c.pollInterval = time.Millisecond
+
+		resolved, err := c.ResolvePending(accounts)
+
+		assert.Nil(t, resolved)
+		assert.ErrorIs(t, err, errPendingTimeout)
+	})
+}
@@ -0,0 +1,63 @@
+package preflight
+
+import (
+	"github.com/gnolang/gno/gnoland"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/gno/pkgs/bft/types"
+)
+
+type (
+	getAccountDelegate        func(string) (*gnoland.GnoAccount, error)
+	getUnconfirmedTxsDelegate func(int) ([]types.Tx, error)
+	getStatusDelegate         func() (*core_types.ResultStatus, error)
+	getTxDelegate             func([]byte) (*core_types.ResultTx, error)
+	executeABCIQueryDelegate  func(string, []byte) (*core_types.ResultABCIQuery, error)
+)
+
+type mockClient struct {
+	getAccountFn        getAccountDelegate
+	getUnconfirmedTxsFn getUnconfirmedTxsDelegate
+	getStatusFn         getStatusDelegate
+	getTxFn             getTxDelegate
+	executeABCIQueryFn  executeABCIQueryDelegate
+}
+
+func (m *mockClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
+	if m.getAccountFn != nil {
+		return m.getAccountFn(address)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetUnconfirmedTxs(limit int) ([]types.Tx, error) {
+	if m.getUnconfirmedTxsFn != nil {
+		return m.getUnconfirmedTxsFn(limit)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetStatus() (*core_types.ResultStatus, error) {
+	if m.getStatusFn != nil {
+		return m.getStatusFn()
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) GetTx(hash []byte) (*core_types.ResultTx, error) {
+	if m.getTxFn != nil {
+		return m.getTxFn(hash)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error) {
+	if m.executeABCIQueryFn != nil {
+		return m.executeABCIQueryFn(path, data)
+	}
+
+	return &core_types.ResultABCIQuery{}, nil
+}
@@ -0,0 +1,103 @@
+package preflight
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+)
+
+// funcsQuery is the ABCI query path of the exported realm function signatures
+const funcsQuery = "vm/qfuncs"
+
+var (
+	errUnknownRealm = errors.New("realm is not deployed")
+	errUnknownFunc  = errors.New("realm function does not exist")
+	errArgsMismatch = errors.New("realm function argument count mismatch")
+)
+
+// QueryClient executes the ABCI queries on the node
+type QueryClient interface {
+	ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error)
+}
+
+// RealmCall is a realm function call, checked against the realm deployed on the node
+type RealmCall struct {
+	PkgPath string // the called realm path
+	Func    string // the called realm function
+	Args    int    // the number of call arguments
+}
+
+// funcSignature is the exported realm function signature, returned by the node
+type funcSignature struct {
+	FuncName string
+	Params   []struct {
+		Name string
+		Type string
+	}
+}
+
+// CheckCalls checks the called realms are deployed on the node,
+// and export the called functions with a matching number of parameters.
+// Each realm is only queried once
+func CheckCalls(cli QueryClient, calls []RealmCall) error {
+	signatures := make(map[string]map[string]funcSignature)
+
+	for _, call := range calls {
+		funcs, ok := signatures[call.PkgPath]
+		if !ok {
+			fetched, err := fetchSignatures(cli, call.PkgPath)
+			if err != nil {
+				return err
+			}
+
+			signatures[call.PkgPath] = fetched
+			funcs = fetched
+		}
+
+		signature, ok := funcs[call.Func]
+		if !ok {
+			return fmt.Errorf("%w, %s.%s", errUnknownFunc, call.PkgPath, call.Func)
+		}
+
+		if len(signature.Params) != call.Args {
+			return fmt.Errorf(
+				"%w, %s.%s takes %d arguments, %d provided",
+				errArgsMismatch,
+				call.PkgPath,
+				call.Func,
+				len(signature.Params),
+				call.Args,
+			)
+		}
+	}
+
+	return nil
+}
+
+// fetchSignatures fetches the exported function signatures of the realm, by name
+func fetchSignatures(cli QueryClient, pkgPath string) (map[string]funcSignature, error) {
+	res, err := cli.ExecuteABCIQuery(funcsQuery, []byte(pkgPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to query realm functions, %w", err)
+	}
+
+	if res.Response.IsErr() {
+		return nil, fmt.Errorf("%w, %s (%v)", errUnknownRealm, pkgPath, res.Response.Error)
+	}
+
+	var fetched []funcSignature
+
+	if err := json.Unmarshal(res.Response.Data, &fetched); err != nil {
+		return nil, fmt.Errorf("unable to parse realm functions, %w", err)
+	}
+
+	funcs := make(map[string]funcSignature, len(fetched))
+
+	for _, signature := range fetched {
+		funcs[signature.FuncName] = signature
+	}
+
+	return funcs, nil
+}
@@ -0,0 +1,88 @@
+package preflight
+
+import (
+	"testing"
+
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCalls(t *testing.T) {
+	t.Parallel()
+
+	const boards = "gno.land/r/demo/boards"
+
+	var (
+		queried = 0
+
+		mockQuery = func(path string, data []byte) (*core_types.ResultABCIQuery, error) {
+			assert.Equal(t, funcsQuery, path)
+
+			res := &core_types.ResultABCIQuery{}
+
+			switch string(data) {
+			case boards:
+				res.Response.Data = []byte(
+					`[{"FuncName":"CreatePost","Params":[{"Name":"title","Type":"string"},{"Name":"body","Type":"string"}]},` +
+						`{"FuncName":"Render","Params":[{"Name":"path","Type":"string"}]}]`,
+				)
+			default:
+				res.Response.Error = abci.StringError("package not found")
+			}
+
+			return res, nil
+		}
+
+		cli = &mockClient{
+			executeABCIQueryFn: func(path string, data []byte) (*core_types.ResultABCIQuery, error) {
+				queried++
+
+				return mockQuery(path, data)
+			},
+		}
+	)
+
+	// Valid calls query each realm once
+	assert.NoError(t, CheckCalls(cli, []RealmCall{
+		{PkgPath: boards, Func: "CreatePost", Args: 2},
+		{PkgPath: boards, Func: "Render", Args: 1},
+	}))
+	assert.Equal(t, 1, queried)
+
+	testTable := []struct {
+		name     string
+		call     RealmCall
+		expected error
+	}{
+		{
+			"unknown realm",
+			RealmCall{PkgPath: "gno.land/r/demo/missing", Func: "Render", Args: 1},
+			errUnknownRealm,
+		},
+		{
+			"unknown function",
+			RealmCall{PkgPath: boards, Func: "DeletePost", Args: 1},
+			errUnknownFunc,
+		},
+		{
+			"argument count mismatch",
+			RealmCall{PkgPath: boards, Func: "CreatePost", Args: 1},
+			errArgsMismatch,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cli := &mockClient{
+				executeABCIQueryFn: mockQuery,
+			}
+
+			assert.ErrorIs(t, CheckCalls(cli, []RealmCall{testCase.call}), testCase.expected)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package preset
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// DisplayPresets displays the presets, with their flag values
+func DisplayPresets(w io.Writer, presets []*Preset) {
+	tw := tabwriter.NewWriter(w, 10, 20, 2, ' ', 0)
+
+	for index, p := range presets {
+		if index > 0 {
+			_, _ = fmt.Fprintln(tw)
+		}
+
+		_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s - %s", p.Name, p.Description))
+
+		for _, setting := range p.Settings {
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("  -%s\t%s", setting.Flag, setting.Value))
+		}
+	}
+
+	_, _ = fmt.Fprintln(tw, "\nAll other flags keep their defaults, and explicitly set flags override the preset")
+
+	_ = tw.Flush()
+}
@@ -0,0 +1,120 @@
+// Package preset bundles the curated named run configurations,
+// layered under the explicitly set flags
+package preset
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+var errUnknownPreset = errors.New("unknown preset")
+
+// Setting is a single preset flag value
+type Setting struct {
+	Flag  string `json:"flag"`
+	Value string `json:"value"`
+}
+
+// Preset is a named run configuration
+type Preset struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Settings    []Setting `json:"settings"`
+}
+
+// presets are the bundled presets, in listing order
+var presets = []*Preset{
+	{
+		Name:        "smoke",
+		Description: "a quick sanity check, each transaction sent alone and committed (run broadcasts have no commit mode)",
+		Settings: []Setting{
+			{Flag: "sub-accounts", Value: "2"},
+			{Flag: "transactions", Value: "100"},
+			{Flag: "batch", Value: "1"},
+			{Flag: "completion-threshold", Value: "1"},
+		},
+	},
+	{
+		Name:        "throughput",
+		Description: "a sustained high throughput run, with large batches",
+		Settings: []Setting{
+			{Flag: "sub-accounts", Value: "50"},
+			{Flag: "transactions", Value: "50000"},
+			{Flag: "batch", Value: "100"},
+		},
+	},
+	{
+		Name:        "latency",
+		Description: "a low send rate run, with the commit latency of each transaction attributed",
+		Settings: []Setting{
+			{Flag: "sub-accounts", Value: "10"},
+			{Flag: "transactions", Value: "1000"},
+			{Flag: "batch", Value: "1"},
+			{Flag: "latency-slo", Value: "5s"},
+			{Flag: "slo-initial-rate", Value: "5"},
+			{Flag: "slo-increase-step", Value: "1"},
+			{Flag: "mempool-sample-interval", Value: "100ms"},
+		},
+	},
+	{
+		Name:        "soak-1h",
+		Description: "an hour long run at a steady send rate, with the sub-accounts topped up during the broadcast",
+		Settings: []Setting{
+			{Flag: "sub-accounts", Value: "20"},
+			{Flag: "duration", Value: "1h"},
+			{Flag: "send-rate", Value: "20"},
+			{Flag: "batch", Value: "20"},
+			{Flag: "progress-interval", Value: "10s"},
+		},
+	},
+}
+
+// List returns the bundled presets
+func List() []*Preset {
+	return presets
+}
+
+// Get returns the bundled preset with the given name
+func Get(name string) (*Preset, error) {
+	for _, p := range presets {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnknownPreset, name)
+}
+
+// Names returns the names of the bundled presets
+func Names() []string {
+	names := make([]string, 0, len(presets))
+
+	for _, p := range presets {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+// Apply sets the preset values of the flags that were not explicitly set,
+// so explicit flags always override the preset
+func (p *Preset) Apply(fs *flag.FlagSet) error {
+	explicit := make(map[string]struct{})
+
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = struct{}{}
+	})
+
+	for _, setting := range p.Settings {
+		if _, ok := explicit[setting.Flag]; ok {
+			continue
+		}
+
+		if err := fs.Set(setting.Flag, setting.Value); err != nil {
+			return fmt.Errorf("unable to apply preset %s, %w", p.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package preset
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreset_Apply(t *testing.T) {
+	t.Parallel()
+
+	var (
+		subAccounts  uint64
+		transactions uint64
+
+		fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	)
+
+	fs.Uint64Var(&subAccounts, "sub-accounts", 10, "")
+	fs.Uint64Var(&transactions, "transactions", 100, "")
+
+	if err := fs.Parse([]string{"-transactions", "42"}); err != nil {
+		t.Fatalf("unable to parse flags, %v", err)
+	}
+
+	p := &Preset{
+		Name: "test",
+		Settings: []Setting{
+			{Flag: "sub-accounts", Value: "2"},
+			{Flag: "transactions", Value: "7"},
+		},
+	}
+
+	if err := p.Apply(fs); err != nil {
+		t.Fatalf("unable to apply preset, %v", err)
+	}
+
+	// The explicit flag overrides the preset
+	assert.Equal(t, uint64(2), subAccounts)
+	assert.Equal(t, uint64(42), transactions)
+}
+
+func TestPreset_Get(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range Names() {
+		p, err := Get(name)
+		if err != nil {
+			t.Fatalf("unable to get preset, %v", err)
+		}
+
+		assert.Equal(t, name, p.Name)
+	}
+
+	_, err := Get("unknown")
+
+	assert.ErrorIs(t, err, errUnknownPreset)
+}
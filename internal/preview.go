@@ -0,0 +1,246 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/crypto/bip39"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/client"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/internal/signer"
+)
+
+var errInvalidCount = errors.New("invalid number of preview transactions specified")
+
+// PreviewConfig is the transaction construction preview configuration
+type PreviewConfig struct {
+	Mode     string // the stress test mode
+	Mnemonic string // the mnemonic for the keyring, if any
+	ChainID  string // the chain ID used for signing
+	URL      string // the URL of the cluster, used for gas estimation
+
+	Count            uint64 // the number of previewed transactions
+	SubAccountOffset uint64 // the derivation index of the first sub-account
+	StorageDeposit   uint64 // the storage deposit for package deployments
+
+	Sign        bool // flag indicating if the previewed transactions are signed
+	EstimateGas bool // flag indicating if the gas is estimated using the node simulation
+
+	Scenario string // the workload scenario file the transactions are constructed from (SCENARIO), if any
+
+	scenario *runtime.Scenario // the loaded workload scenario, if any
+}
+
+// Validate validates the preview configuration
+func (cfg *PreviewConfig) Validate() error {
+	// Make sure the mode is valid
+	if !runtime.IsRuntime(runtime.Type(cfg.Mode)) {
+		return errInvalidMode
+	}
+
+	// Make sure the mnemonic is valid, if any
+	if cfg.Mnemonic != "" && !bip39.IsMnemonicValid(cfg.Mnemonic) {
+		return errInvalidMnemonic
+	}
+
+	// Make sure the number of transactions is valid
+	if cfg.Count < 1 {
+		return errInvalidCount
+	}
+
+	// Make sure the URL is valid, if the node is used
+	if cfg.EstimateGas && !urlRegex.MatchString(cfg.URL) {
+		return errInvalidURL
+	}
+
+	// Make sure the workload scenario is valid, if any
+	switch {
+	case cfg.Scenario == "" && runtime.Type(cfg.Mode) == runtime.CustomScenario:
+		return errMissingScenario
+	case cfg.Scenario == "":
+		return nil
+	case runtime.Type(cfg.Mode) != runtime.CustomScenario:
+		return errScenarioMode
+	}
+
+	scenario, err := runtime.LoadScenario(cfg.Scenario)
+	if err != nil {
+		return err
+	}
+
+	cfg.scenario = scenario
+
+	return nil
+}
+
+// previewClient is the node client used for the gas estimation of the previewed transactions
+type previewClient interface {
+	GetAccount(address string) (*gnoland.GnoAccount, error)
+	SimulateTransaction(tx *std.Tx) (int64, error)
+}
+
+// previewSigner is a signer that leaves the transactions unsigned
+type previewSigner struct{}
+
+func (previewSigner) SignTx(_ *std.Tx, _ *gnoland.GnoAccount, _ uint64, _ string) error {
+	return nil
+}
+
+// Preview constructs sample transactions for the configured mode,
+// and prints them out with their encoded size and estimated fees.
+// The node is only contacted if gas estimation is enabled
+func Preview(cfg *PreviewConfig) error {
+	var cli previewClient
+
+	if cfg.EstimateGas {
+		cli = client.NewHTTPClient(cfg.URL, 0)
+	}
+
+	return preview(os.Stdout, cfg, cli)
+}
+
+// preview constructs and prints out the previewed transactions,
+// estimating their gas with the given client, if any
+func preview(w io.Writer, cfg *PreviewConfig, cli previewClient) error {
+	_, _ = fmt.Fprintf(w, "\n🔍 Previewing Transactions 🔍\n\n")
+
+	mnemonic := cfg.Mnemonic
+	if mnemonic == "" {
+		generated, err := generateMnemonic()
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(w, "⚠️ No mnemonic specified, using a randomly generated one\n")
+
+		mnemonic = generated
+	}
+
+	// Derive the first sub-account
+	kb := keys.NewInMemory()
+
+	info, err := kb.CreateAccount(
+		fmt.Sprintf("%s%d", common.KeybasePrefix, cfg.SubAccountOffset),
+		mnemonic,
+		"",
+		common.EncryptPassword,
+		uint32(0),
+		uint32(cfg.SubAccountOffset),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create account with keybase, %w", err)
+	}
+
+	account := &gnoland.GnoAccount{
+		BaseAccount: *std.NewBaseAccount(info.GetAddress(), std.Coins{}, nil, 0, 0),
+	}
+
+	// Fetch the real account data, if the node is used
+	if cli != nil {
+		if account, err = cli.GetAccount(info.GetAddress().String()); err != nil {
+			return fmt.Errorf("unable to fetch sub-account, %w", err)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Sub-account %d: %s\n", cfg.SubAccountOffset, info.GetAddress().String())
+
+	// Simulation requires the signatures to be present
+	var txSigner runtime.Signer = previewSigner{}
+	if cfg.Sign || cli != nil {
+		txSigner = signer.NewKeybaseSigner(kb, cfg.ChainID)
+	}
+
+	var (
+		mode    = runtime.Type(cfg.Mode)
+		deposit = std.NewCoin(common.Denomination, int64(cfg.StorageDeposit))
+	)
+
+	if runtime.Predeploys(mode) {
+		deposit = std.NewCoin(common.Denomination, 0)
+	}
+
+	opts := runtimeOptions(deposit)
+
+	if cfg.scenario != nil {
+		opts = append(opts, runtime.WithScenario(cfg.scenario))
+	}
+
+	txRuntime := runtime.GetRuntime(mode, txSigner, opts...)
+
+	// Initialize the runtime, so the transactions
+	// reference the (future) deployment.
+	// The initialization transactions are not previewed
+	if _, err := txRuntime.Initialize(account); err != nil {
+		return fmt.Errorf("unable to initialize runtime, %w", err)
+	}
+
+	txs, err := txRuntime.ConstructTransactions([]*gnoland.GnoAccount{account}, cfg.Count)
+	if err != nil {
+		return fmt.Errorf("unable to construct transactions, %w", err)
+	}
+
+	for index, tx := range txs {
+		if err := previewTransaction(w, index, tx, deposit, cli); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// previewTransaction prints out the transaction, its encoded size and fees
+func previewTransaction(w io.Writer, index int, tx *std.Tx, deposit std.Coin, cli previewClient) error {
+	txJSON, err := amino.MarshalJSONIndent(tx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	txBin, err := amino.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("unable to encode transaction, %w", err)
+	}
+
+	runCost := common.DefaultGasFee.Add(common.InitialTxCost).Add(deposit)
+
+	_, _ = fmt.Fprintf(w, "\nTransaction #%d\n%s\n", index, txJSON)
+	_, _ = fmt.Fprintf(w, "Encoded size: %d bytes\n", len(txBin))
+	_, _ = fmt.Fprintf(w, "Gas wanted: %d, gas fee: %s\n", tx.Fee.GasWanted, tx.Fee.GasFee.String())
+	_, _ = fmt.Fprintf(w, "Estimated run cost: %d %s\n", runCost.Amount, runCost.Denom)
+
+	if cli == nil {
+		return nil
+	}
+
+	gasUsed, err := cli.SimulateTransaction(tx)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "❌ Unable to estimate gas, %v\n", err)
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Simulated gas used: %d\n", gasUsed)
+
+	return nil
+}
+
+// generateMnemonic generates a new random BIP39 mnemonic
+func generateMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate entropy, %w", err)
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate mnemonic, %w", err)
+	}
+
+	return mnemonic, nil
+}
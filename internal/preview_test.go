@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+	moveToRoot(t)
+
+	newPreviewConfig := func(t *testing.T, mode runtime.Type) *PreviewConfig {
+		t.Helper()
+
+		cfg := &PreviewConfig{
+			Mode:             mode.String(),
+			Mnemonic:         testMnemonic,
+			ChainID:          "dev",
+			Count:            3,
+			SubAccountOffset: 1,
+		}
+
+		require.NoError(t, cfg.Validate())
+
+		return cfg
+	}
+
+	t.Run("unsigned package deployments", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf bytes.Buffer
+			cfg = newPreviewConfig(t, runtime.PackageDeployment)
+		)
+
+		require.NoError(t, preview(&buf, cfg, nil))
+
+		output := buf.String()
+
+		// Only the run transactions are previewed, without the runtime initialization
+		assert.Equal(t, 3, strings.Count(output, "\nTransaction #"))
+		assert.Contains(t, output, "Transaction #2")
+		assert.Equal(t, 3, strings.Count(output, `"@type": "/vm.m_addpkg"`))
+		assert.NotContains(t, output, `"/vm.m_call"`)
+
+		assert.Contains(t, output, "Encoded size: ")
+		assert.Contains(t, output, "Estimated run cost: ")
+		assert.NotContains(t, output, "Simulated gas used")
+
+		// The transactions are left unsigned
+		assert.Contains(t, output, `"signatures": null`)
+	})
+
+	t.Run("realm calls estimated with the node", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf bytes.Buffer
+			cfg = newPreviewConfig(t, runtime.RealmCall)
+			cli = newMockChain(std.NewCoins(std.NewCoin(common.Denomination, 1_000_000)))
+		)
+
+		cfg.Count = 2
+		cli.gasUsed = 54321
+
+		require.NoError(t, preview(&buf, cfg, cli))
+
+		output := buf.String()
+
+		assert.Equal(t, 2, strings.Count(output, "\nTransaction #"))
+		assert.Equal(t, 2, strings.Count(output, `"@type": "/vm.m_call"`))
+		assert.Equal(t, 2, strings.Count(output, "Simulated gas used: 54321"))
+
+		// The simulated transactions are signed
+		assert.NotContains(t, output, `"signatures": null`)
+	})
+}
@@ -0,0 +1,224 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/supernova/internal/live"
+)
+
+const (
+	// baseResultsSize is the estimated size of the fixed
+	// part of the results JSON, in bytes
+	baseResultsSize = 4 * 1024
+
+	// blockResultSize is the estimated size of a single
+	// per-block entry in the results JSON, in bytes
+	blockResultSize = 256
+
+	// endpointResultSize is the estimated size of a single
+	// per-endpoint breakdown in the results JSON, in bytes
+	endpointResultSize = 512
+
+	// progressSampleSize is the estimated size of a single
+	// live progress sample in the results JSON, in bytes
+	progressSampleSize = 160
+
+	// sweepAccountSize is the estimated size of a single
+	// swept sub-account entry in the results JSON, in bytes
+	sweepAccountSize = 128
+
+	// accountResultSize is the estimated size of a single per-account
+	// balance accounting (and endpoint assignment) in the results JSON, in bytes
+	accountResultSize = 384
+
+	// latencyAttributionSize is the estimated size of the mempool
+	// latency attribution distributions in the results JSON, in bytes
+	latencyAttributionSize = 1024
+
+	// segmentResultSize is the estimated size of a single results segment summary,
+	// in the results JSON and in the segment file, without its blocks, in bytes
+	segmentResultSize = 256
+
+	// statusFileSize is the estimated size of the run status file, in bytes
+	statusFileSize = 1024
+
+	// manifestBaseSize is the estimated size of the
+	// fixed part of the run manifest, in bytes
+	manifestBaseSize = 512
+
+	// manifestArtifactSize is the estimated size of a
+	// single artifact entry in the run manifest, in bytes
+	manifestArtifactSize = 256
+
+	// manifestArtifacts is the number of artifacts recorded in the run manifest,
+	// besides the results segments (the results, the log file and the upload receipt)
+	manifestArtifacts = 3
+
+	// minBlockTime is the block time assumed for the length of the unpaced runs
+	minBlockTime = time.Second
+
+	// diskSpaceMargin is the multiplier applied to the estimate
+	// under which a low disk space warning is displayed
+	diskSpaceMargin = 2
+)
+
+var errInsufficientDiskSpace = errors.New("insufficient disk space for results")
+
+// outputProbe is a placeholder file in the output directory,
+// created during validation to make sure the results can be written.
+// It is removed once the results are saved, or the run is aborted
+type outputProbe struct {
+	path string
+}
+
+// newOutputProbe verifies the output directory is writable, and that it has enough
+// disk space for the estimated results size (in bytes), by creating a probe file
+func newOutputProbe(output string, estimate uint64) (*outputProbe, error) {
+	// Make sure the output path is not a directory
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		return nil, fmt.Errorf("output path %s is a directory", output)
+	}
+
+	return newDirProbe(filepath.Dir(output), estimate)
+}
+
+// newDirProbe verifies the directory is writable, and that it has enough disk
+// space for the estimated size (in bytes) of the artifacts written to it
+func newDirProbe(dir string, estimate uint64) (*outputProbe, error) {
+	// Create and truncate the probe file
+	f, err := os.CreateTemp(dir, ".supernova-probe-*")
+	if err != nil {
+		return nil, fmt.Errorf("output directory %s is not writable, %w", dir, err)
+	}
+
+	probe := &outputProbe{
+		path: f.Name(),
+	}
+
+	if err := f.Close(); err != nil {
+		probe.cleanup()
+
+		return nil, fmt.Errorf("unable to close probe file, %w", err)
+	}
+
+	// Check the available disk space against the estimate
+	available, err := availableDiskSpace(dir)
+	if err != nil {
+		fmt.Printf("⚠️ Unable to determine available disk space in %s, %v\n", dir, err)
+
+		return probe, nil
+	}
+
+	if available < estimate {
+		probe.cleanup()
+
+		return nil, fmt.Errorf(
+			"%w, %d bytes available, %d bytes estimated",
+			errInsufficientDiskSpace,
+			available,
+			estimate,
+		)
+	}
+
+	if available < estimate*diskSpaceMargin {
+		fmt.Printf(
+			"⚠️ Low disk space in %s, %d bytes available, %d bytes estimated\n",
+			dir,
+			available,
+			estimate,
+		)
+	}
+
+	return probe, nil
+}
+
+// resultsSize returns the estimated size of the results JSON and the run manifest next to it, in bytes,
+// with the enabled detail outputs. In the worst case, each transaction ends up in a separate block
+func (cfg *Config) resultsSize() uint64 {
+	transactions := cfg.runTransactions()
+
+	size := uint64(baseResultsSize) + transactions*blockResultSize
+
+	// The broadcast outcome of each endpoint
+	size += uint64(len(cfg.endpoints)) * endpointResultSize
+
+	// The balance accounting of each sub-account
+	size += cfg.SubAccounts * accountResultSize
+
+	// The mempool latency attribution
+	if cfg.MempoolSampleInterval > 0 {
+		size += latencyAttributionSize
+	}
+
+	// The live progress samples, over the broadcast and the collection
+	if cfg.ProgressInterval > 0 || cfg.MetricsAddr != "" {
+		interval := cfg.ProgressInterval
+		if interval == 0 {
+			interval = live.DefaultInterval
+		}
+
+		size += uint64(cfg.runLength()/interval+1) * progressSampleSize
+	}
+
+	// The intermediate results segment summaries
+	segments := cfg.resultsSegments()
+	size += segments * segmentResultSize
+
+	// The funds recovered from each sub-account
+	if cfg.Sweep {
+		size += cfg.SubAccounts * sweepAccountSize
+	}
+
+	// The run manifest, with an artifact entry for each segment
+	size += manifestBaseSize + (segments+manifestArtifacts)*manifestArtifactSize
+
+	return size
+}
+
+// runDirSize returns the estimated size of the artifacts written to the run working directory
+// (the results segment files and the status file), in bytes. Each segment file holds the blocks
+// of its window, so in the worst case, each transaction ends up in a separate block again
+func (cfg *Config) runDirSize() uint64 {
+	size := uint64(statusFileSize)
+
+	if segments := cfg.resultsSegments(); segments > 0 {
+		size += segments*segmentResultSize + cfg.runTransactions()*blockResultSize
+	}
+
+	return size
+}
+
+// resultsSegments returns the estimated number of intermediate results segments
+func (cfg *Config) resultsSegments() uint64 {
+	if cfg.ReportInterval <= 0 {
+		return 0
+	}
+
+	return uint64(cfg.runLength()/cfg.ReportInterval + 1)
+}
+
+// runLength returns the estimated length of the broadcast and the collection
+func (cfg *Config) runLength() time.Duration {
+	switch {
+	case cfg.Duration > 0:
+		return cfg.Duration + cfg.CompletionGrace
+	case cfg.SendRate > 0:
+		return time.Duration(float64(cfg.runTransactions())/cfg.SendRate*float64(time.Second)) + cfg.CompletionGrace
+	default:
+		// The unpaced runs are bounded by a block per transaction
+		return time.Duration(cfg.runTransactions()) * minBlockTime
+	}
+}
+
+// cleanup removes the probe file, if present
+func (p *outputProbe) cleanup() {
+	if p == nil {
+		return
+	}
+
+	_ = os.Remove(p.path)
+}
@@ -0,0 +1,32 @@
+package reads
+
+import (
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+)
+
+type (
+	executeABCIQueryDelegate func(string, []byte) (*core_types.ResultABCIQuery, error)
+	waitDelegate             func(int)
+)
+
+type mockClient struct {
+	executeABCIQueryFn executeABCIQueryDelegate
+}
+
+func (m *mockClient) ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error) {
+	if m.executeABCIQueryFn != nil {
+		return m.executeABCIQueryFn(path, data)
+	}
+
+	return &core_types.ResultABCIQuery{}, nil
+}
+
+type mockLimiter struct {
+	waitFn waitDelegate
+}
+
+func (m *mockLimiter) Wait(requests int) {
+	if m.waitFn != nil {
+		m.waitFn(requests)
+	}
+}
@@ -0,0 +1,20 @@
+package reads
+
+type Option func(r *Reader)
+
+// WithWorkers sets the number of concurrent read workers
+func WithWorkers(workers int) Option {
+	return func(r *Reader) {
+		if workers > 0 {
+			r.workers = workers
+		}
+	}
+}
+
+// WithLimiter makes the reads share the given send rate budget
+// with the broadcasts, instead of being issued on top of it
+func WithLimiter(limiter Limiter) Option {
+	return func(r *Reader) {
+		r.limiter = limiter
+	}
+}
@@ -0,0 +1,90 @@
+package reads
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const accountQueryPath = "auth/accounts/"
+
+var (
+	errEmptyQuerySet   = errors.New("query set has no queries")
+	errInvalidQueryRow = errors.New("invalid query set row")
+)
+
+// Query is a single ABCI read query
+type Query struct {
+	Path string // the ABCI query path, ex. vm/qeval
+	Data []byte // the query data
+}
+
+// LoadQueries loads the query set file at the given path
+func LoadQueries(path string) ([]Query, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open query set, %w", err)
+	}
+
+	defer file.Close()
+
+	return ParseQueries(file)
+}
+
+// ParseQueries parses the query set, where each line is a query path,
+// followed by the query data. Literal \n sequences in the data are
+// unescaped, ex. vm/qeval gno.land/r/demo/foo\nRender(""). Empty lines
+// and lines starting with # are skipped
+func ParseQueries(r io.Reader) ([]Query, error) {
+	var (
+		queries = make([]Query, 0)
+		scanner = bufio.NewScanner(r)
+		line    = 0
+	)
+
+	for scanner.Scan() {
+		line++
+
+		row := strings.TrimSpace(scanner.Text())
+		if row == "" || strings.HasPrefix(row, "#") {
+			continue
+		}
+
+		path, data, _ := strings.Cut(row, " ")
+		if path == "" {
+			return nil, fmt.Errorf("%w at line %d", errInvalidQueryRow, line)
+		}
+
+		queries = append(queries, Query{
+			Path: path,
+			Data: []byte(strings.ReplaceAll(strings.TrimSpace(data), `\n`, "\n")),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read query set, %w", err)
+	}
+
+	if len(queries) == 0 {
+		return nil, errEmptyQuerySet
+	}
+
+	return queries, nil
+}
+
+// AccountQueries creates the account lookup queries
+// for the given addresses, usable with any run target
+func AccountQueries(addresses []string) []Query {
+	queries := make([]Query, 0, len(addresses))
+
+	for _, address := range addresses {
+		queries = append(queries, Query{
+			Path: accountQueryPath + address,
+		})
+	}
+
+	return queries
+}
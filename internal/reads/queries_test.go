@@ -0,0 +1,61 @@
+package reads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueries_ParseQueries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid query set", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.Join([]string{
+			"# realm reads",
+			`vm/qeval gno.land/r/demo/foo\nRender("")`,
+			"",
+			"auth/accounts/g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5",
+		}, "\n")
+
+		queries, err := ParseQueries(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unable to parse queries, %v", err)
+		}
+
+		assert.Equal(
+			t,
+			[]Query{
+				{Path: "vm/qeval", Data: []byte("gno.land/r/demo/foo\nRender(\"\")")},
+				{Path: "auth/accounts/g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5", Data: []byte{}},
+			},
+			queries,
+		)
+	})
+
+	t.Run("empty query set", func(t *testing.T) {
+		t.Parallel()
+
+		queries, err := ParseQueries(strings.NewReader("# no queries\n\n"))
+
+		assert.Nil(t, queries)
+		assert.ErrorIs(t, err, errEmptyQuerySet)
+	})
+}
+
+func TestQueries_AccountQueries(t *testing.T) {
+	t.Parallel()
+
+	queries := AccountQueries([]string{"g1a", "g1b"})
+
+	assert.Equal(
+		t,
+		[]Query{
+			{Path: "auth/accounts/g1a"},
+			{Path: "auth/accounts/g1b"},
+		},
+		queries,
+	)
+}
@@ -0,0 +1,192 @@
+// Package reads issues read queries interleaved with the
+// transaction broadcasts, to model read-heavy workloads
+package reads
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/gnolang/supernova/internal/metrics"
+)
+
+const (
+	defaultWorkers = 16
+
+	// ratioEpsilon absorbs the float rounding of the read ratio
+	ratioEpsilon = 1e-9
+)
+
+// Client is the read query client
+type Client interface {
+	ExecuteABCIQuery(path string, data []byte) (*core_types.ResultABCIQuery, error)
+}
+
+// Limiter is the send rate budget shared with the broadcasts
+type Limiter interface {
+	// Wait blocks until the given number of requests can be sent
+	Wait(requests int)
+}
+
+// Reader issues a configurable ratio of read queries for each broadcast
+// transaction. The reads are executed by a worker pool, concurrently with
+// the broadcasts, so a slow read never holds back the broadcast stream
+type Reader struct {
+	cli     Client
+	queries []Query
+	ratio   float64
+
+	workers int
+	limiter Limiter // the shared send rate budget, if any
+
+	mux       sync.Mutex
+	cond      *sync.Cond
+	pending   int // the reads waiting for a worker
+	txs       int // the broadcast transactions so far
+	scheduled int // the reads scheduled so far
+	next      int // the index of the next query, used in round robin
+	stopped   bool
+
+	latencies []time.Duration
+	errors    int
+
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+	result   *metrics.ReadResult
+}
+
+// NewReader creates a new reader, issuing the given ratio
+// of reads for each broadcast transaction, cycling through the queries
+func NewReader(cli Client, queries []Query, ratio float64, opts ...Option) *Reader {
+	r := &Reader{
+		cli:     cli,
+		queries: queries,
+		ratio:   ratio,
+		workers: defaultWorkers,
+	}
+
+	r.cond = sync.NewCond(&r.mux)
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Start starts the read workers
+func (r *Reader) Start() {
+	r.wg.Add(r.workers)
+
+	for i := 0; i < r.workers; i++ {
+		go func() {
+			defer r.wg.Done()
+
+			for {
+				query, ok := r.take()
+				if !ok {
+					return
+				}
+
+				r.execute(query)
+			}
+		}()
+	}
+}
+
+// Read schedules the reads for the given number of broadcast transactions.
+// It never blocks, the reads are picked up by the workers
+func (r *Reader) Read(txs int) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.stopped || len(r.queries) == 0 {
+		return
+	}
+
+	// The reads are scheduled based on the running totals,
+	// so the fractional ratios carry over between broadcasts
+	r.txs += txs
+
+	reads := int(float64(r.txs)*r.ratio+ratioEpsilon) - r.scheduled
+	if reads <= 0 {
+		return
+	}
+
+	r.scheduled += reads
+	r.pending += reads
+	r.cond.Broadcast()
+}
+
+// Stop waits for the scheduled reads to finish, and returns the read results.
+// It is safe to call multiple times
+func (r *Reader) Stop() *metrics.ReadResult {
+	r.stopOnce.Do(func() {
+		r.mux.Lock()
+		r.stopped = true
+		r.cond.Broadcast()
+		r.mux.Unlock()
+
+		r.wg.Wait()
+
+		if r.errors > 0 {
+			fmt.Printf("⚠️ %d of %d reads failed\n", r.errors, len(r.latencies))
+		}
+
+		r.result = metrics.NewReadResult(
+			r.ratio,
+			len(r.queries),
+			r.limiter != nil,
+			r.latencies,
+			r.errors,
+		)
+	})
+
+	return r.result
+}
+
+// take blocks until a read is scheduled, and returns its query.
+// Returns false once the reader is stopped and the scheduled reads are drained
+func (r *Reader) take() (Query, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for r.pending == 0 {
+		if r.stopped {
+			return Query{}, false
+		}
+
+		r.cond.Wait()
+	}
+
+	r.pending--
+
+	query := r.queries[r.next%len(r.queries)]
+	r.next++
+
+	return query, true
+}
+
+// execute executes the read query, and records its latency
+func (r *Reader) execute(query Query) {
+	if r.limiter != nil {
+		r.limiter.Wait(1)
+	}
+
+	start := time.Now()
+
+	res, err := r.cli.ExecuteABCIQuery(query.Path, query.Data)
+
+	latency := time.Since(start)
+	failed := err != nil || res == nil || res.Response.IsErr()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.latencies = append(r.latencies, latency)
+
+	if failed {
+		r.errors++
+	}
+}
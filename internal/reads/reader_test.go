@@ -0,0 +1,140 @@
+package reads
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	abci "github.com/gnolang/gno/pkgs/bft/abci/types"
+	core_types "github.com/gnolang/gno/pkgs/bft/rpc/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader_Read(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads per transaction", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mux   sync.Mutex
+			paths = make(map[string]int)
+
+			queries = []Query{
+				{Path: "vm/qeval", Data: []byte("gno.land/r/demo/a\nRender(\"\")")},
+				{Path: "auth/accounts/g1"},
+			}
+
+			mockClient = &mockClient{
+				executeABCIQueryFn: func(path string, _ []byte) (*core_types.ResultABCIQuery, error) {
+					mux.Lock()
+					defer mux.Unlock()
+
+					paths[path]++
+
+					return &core_types.ResultABCIQuery{}, nil
+				},
+			}
+		)
+
+		r := NewReader(mockClient, queries, 2)
+		r.Start()
+
+		// 10 batches of 5 transactions, with 2 reads each
+		for i := 0; i < 10; i++ {
+			r.Read(5)
+		}
+
+		result := r.Stop()
+
+		assert.Equal(t, 100, result.Requests)
+		assert.Equal(t, 0, result.Errors)
+		assert.Equal(t, 2, result.Queries)
+		assert.False(t, result.CountsAgainstTPS)
+		assert.NotNil(t, result.Latency)
+
+		// Make sure the queries are cycled through
+		assert.Equal(t, 50, paths["vm/qeval"])
+		assert.Equal(t, 50, paths["auth/accounts/g1"])
+
+		// Make sure no reads are scheduled after the stop
+		r.Read(5)
+		assert.Equal(t, 100, r.Stop().Requests)
+	})
+
+	t.Run("fractional ratio", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(&mockClient{}, []Query{{Path: "auth/accounts/g1"}}, 0.3)
+		r.Start()
+
+		// The fractional reads are carried over between batches
+		for i := 0; i < 10; i++ {
+			r.Read(1)
+		}
+
+		assert.Equal(t, 3, r.Stop().Requests)
+	})
+
+	t.Run("read errors", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls atomic.Int64
+
+			mockClient = &mockClient{
+				executeABCIQueryFn: func(_ string, _ []byte) (*core_types.ResultABCIQuery, error) {
+					switch calls.Add(1) % 4 {
+					case 0:
+						return nil, errors.New("connection refused")
+					case 1:
+						return &core_types.ResultABCIQuery{
+							Response: abci.ResponseQuery{
+								ResponseBase: abci.ResponseBase{
+									Error: abci.StringError("unknown realm"),
+								},
+							},
+						}, nil
+					default:
+						return &core_types.ResultABCIQuery{}, nil
+					}
+				},
+			}
+		)
+
+		r := NewReader(mockClient, []Query{{Path: "vm/qeval"}}, 1, WithWorkers(1))
+		r.Start()
+		r.Read(8)
+
+		result := r.Stop()
+
+		assert.Equal(t, 8, result.Requests)
+		assert.Equal(t, 4, result.Errors)
+		assert.InDelta(t, 0.5, result.ErrorRate, 0.001)
+	})
+
+	t.Run("shared budget", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			waits atomic.Int64
+
+			limiter = &mockLimiter{
+				waitFn: func(requests int) {
+					waits.Add(int64(requests))
+				},
+			}
+		)
+
+		r := NewReader(&mockClient{}, []Query{{Path: "vm/qeval"}}, 3, WithLimiter(limiter))
+		r.Start()
+		r.Read(10)
+
+		result := r.Stop()
+
+		assert.Equal(t, 30, result.Requests)
+		assert.Equal(t, int64(30), waits.Load())
+		assert.True(t, result.CountsAgainstTPS)
+	})
+}
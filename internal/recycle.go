@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+)
+
+// nonceStream is the duration run transaction stream
+// the recycled sub-account nonces are resynced in
+type nonceStream interface {
+	// Nonce returns the nonce the next transaction of the account is signed with
+	Nonce(account *gnoland.GnoAccount) uint64
+
+	// Resync continues the account transactions from the given sequence
+	Resync(account *gnoland.GnoAccount, sequence uint64)
+}
+
+// accountRecycler recycles the duration run sub-accounts that stopped committing.
+// A sub-account is idle if its on-chain sequence didn't advance for the idle period,
+// while it has signed transactions past it (the transactions were dropped, or are stuck
+// behind a nonce gap). The recycled sub-account continues from its on-chain sequence,
+// so the next transactions it is assigned fill the gap, and it commits again
+type accountRecycler struct {
+	getAccount func(address string) (*gnoland.GnoAccount, error)
+	stream     nonceStream
+
+	accounts []*gnoland.GnoAccount
+	idle     time.Duration // the on-chain sequence stall after which a sub-account is recycled
+
+	lastCheck time.Time                  // the last sub-account check, if any
+	progress  map[string]accountProgress // address -> the last on-chain sequence advance
+	recycles  map[string]int             // address -> the number of recycles
+}
+
+// accountProgress is the last observed on-chain sequence advance of a sub-account
+type accountProgress struct {
+	sequence uint64
+	since    time.Time
+}
+
+// newAccountRecycler creates a new sub-account recycler, for the given idle period
+func newAccountRecycler(
+	getAccount func(address string) (*gnoland.GnoAccount, error),
+	stream nonceStream,
+	accounts []*gnoland.GnoAccount,
+	idle time.Duration,
+) *accountRecycler {
+	return &accountRecycler{
+		getAccount: getAccount,
+		stream:     stream,
+		accounts:   accounts,
+		idle:       idle,
+		progress:   make(map[string]accountProgress, len(accounts)),
+		recycles:   make(map[string]int),
+	}
+}
+
+// recycle checks the sub-accounts once per idle period, and recycles the idle ones.
+// It is called between the stream chunks, so the resynced nonces apply to the next chunk
+func (r *accountRecycler) recycle(now time.Time) {
+	// The first call starts the idle periods of all sub-accounts
+	if r.lastCheck.IsZero() {
+		for _, account := range r.accounts {
+			r.progress[account.GetAddress().String()] = accountProgress{
+				sequence: account.Sequence,
+				since:    now,
+			}
+		}
+
+		r.lastCheck = now
+
+		return
+	}
+
+	if now.Sub(r.lastCheck) < r.idle {
+		return
+	}
+
+	r.lastCheck = now
+
+	for _, account := range r.accounts {
+		r.check(account, now)
+	}
+}
+
+// check verifies the on-chain sequence of the sub-account, and recycles it if idle
+func (r *accountRecycler) check(account *gnoland.GnoAccount, now time.Time) {
+	address := account.GetAddress().String()
+
+	onChain, err := r.getAccount(address)
+	if err != nil {
+		// The account is checked again in the next period
+		fmt.Printf("\n⚠️ Unable to check sub-account %s for recycling, %v\n", address, err)
+
+		return
+	}
+
+	progress := r.progress[address]
+
+	// The sub-account is committing, or has no transactions past its sequence
+	if onChain.Sequence > progress.sequence || r.stream.Nonce(account) <= onChain.Sequence {
+		r.progress[address] = accountProgress{
+			sequence: onChain.Sequence,
+			since:    now,
+		}
+
+		return
+	}
+
+	idle := now.Sub(progress.since)
+	if idle < r.idle {
+		return
+	}
+
+	fmt.Printf(
+		"\n♻️ Recycled sub-account %s, idle for %s, continuing from the on-chain sequence %d instead of %d\n",
+		address,
+		idle,
+		onChain.Sequence,
+		r.stream.Nonce(account),
+	)
+
+	r.stream.Resync(account, onChain.Sequence)
+
+	r.recycles[address]++
+	r.progress[address] = accountProgress{
+		sequence: onChain.Sequence,
+		since:    now,
+	}
+}
+
+// total returns the number of recycles of all sub-accounts
+func (r *accountRecycler) total() int {
+	total := 0
+
+	for _, recycles := range r.recycles {
+		total += recycles
+	}
+
+	return total
+}
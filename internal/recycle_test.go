@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockNonceStream is the recycler stream, with the next nonce of each sub-account
+type mockNonceStream map[uint64]uint64
+
+func (m mockNonceStream) Nonce(account *gnoland.GnoAccount) uint64 {
+	return m[account.AccountNumber]
+}
+
+func (m mockNonceStream) Resync(account *gnoland.GnoAccount, sequence uint64) {
+	m[account.AccountNumber] = sequence
+}
+
+// newSequenceLookup returns the sub-account lookup of the given on-chain sequences,
+// and the number of lookups done
+func newSequenceLookup(
+	accounts []*gnoland.GnoAccount,
+	sequences map[uint64]uint64,
+) (func(address string) (*gnoland.GnoAccount, error), *int) {
+	lookups := 0
+
+	return func(address string) (*gnoland.GnoAccount, error) {
+		lookups++
+
+		for _, account := range accounts {
+			if account.GetAddress().String() != address {
+				continue
+			}
+
+			onChain := *account
+			onChain.Sequence = sequences[account.AccountNumber]
+
+			return &onChain, nil
+		}
+
+		return nil, errors.New("unknown account")
+	}, &lookups
+}
+
+func TestAccountRecycler_Recycle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		idle  = 10 * time.Second
+		start = time.Now()
+	)
+
+	t.Run("stalled sub-account recycled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts  = newStreamAccounts(2)
+			stream    = mockNonceStream{0: 5, 1: 5}
+			sequences = map[uint64]uint64{0: 2, 1: 2}
+
+			getAccount, _ = newSequenceLookup(accounts, sequences)
+		)
+
+		recycler := newAccountRecycler(getAccount, stream, accounts, idle)
+
+		// Both sub-accounts commit in the first period
+		recycler.recycle(start)
+		recycler.recycle(start.Add(idle))
+
+		assert.Equal(t, 0, recycler.total())
+
+		// Only the second sub-account commits in the next period
+		sequences[1] = 4
+
+		recycler.recycle(start.Add(2 * idle))
+
+		assert.Equal(t, 1, recycler.total())
+		assert.Equal(t, 1, recycler.recycles[accounts[0].GetAddress().String()])
+
+		// The stalled sub-account continues from its on-chain sequence
+		assert.Equal(t, uint64(2), stream[0])
+		assert.Equal(t, uint64(5), stream[1])
+
+		results := []*collector.AccountResult{
+			{Address: accounts[0].GetAddress().String()},
+			{Address: accounts[1].GetAddress().String()},
+		}
+
+		(&durationStream{recycler: recycler}).applyRecycles(results)
+
+		assert.Equal(t, 1, results[0].Recycles)
+		assert.Equal(t, 0, results[1].Recycles)
+	})
+
+	t.Run("sub-accounts without pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts  = newStreamAccounts(1)
+			stream    = mockNonceStream{0: 3}
+			sequences = map[uint64]uint64{0: 3}
+
+			getAccount, _ = newSequenceLookup(accounts, sequences)
+		)
+
+		recycler := newAccountRecycler(getAccount, stream, accounts, idle)
+
+		// Everything signed is committed, so the sub-account is not idle
+		for i := 0; i < 4; i++ {
+			recycler.recycle(start.Add(time.Duration(i) * idle))
+		}
+
+		assert.Equal(t, 0, recycler.total())
+		assert.Equal(t, uint64(3), stream[0])
+	})
+
+	t.Run("checked once per idle period", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts  = newStreamAccounts(2)
+			stream    = mockNonceStream{0: 5, 1: 5}
+			sequences = map[uint64]uint64{0: 0, 1: 0}
+
+			getAccount, lookups = newSequenceLookup(accounts, sequences)
+		)
+
+		recycler := newAccountRecycler(getAccount, stream, accounts, idle)
+
+		recycler.recycle(start)
+		recycler.recycle(start.Add(idle / 2))
+
+		assert.Equal(t, 0, *lookups)
+
+		// The sub-accounts didn't commit since the start
+		recycler.recycle(start.Add(idle))
+
+		assert.Equal(t, 2, *lookups)
+		assert.Equal(t, 2, recycler.total())
+	})
+
+	t.Run("failed sequence checks", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			accounts = newStreamAccounts(1)
+			stream   = mockNonceStream{0: 5}
+		)
+
+		getAccount := func(_ string) (*gnoland.GnoAccount, error) {
+			return nil, errors.New("unavailable")
+		}
+
+		recycler := newAccountRecycler(getAccount, stream, accounts, idle)
+
+		recycler.recycle(start)
+		recycler.recycle(start.Add(idle))
+
+		// Unverified sub-accounts are not recycled
+		assert.Equal(t, 0, recycler.total())
+		assert.Equal(t, uint64(5), stream[0])
+	})
+}
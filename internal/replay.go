@@ -0,0 +1,209 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/dump"
+	"github.com/gnolang/supernova/internal/locks"
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/gnolang/supernova/internal/signer"
+)
+
+const phaseReplay = "replay"
+
+var (
+	errDumpDrift     = errors.New("dump accounts drifted since the prepare")
+	errNoReplayTxs   = errors.New("no dump transactions left to replay")
+	errUnknownSigner = errors.New("unknown dump account")
+)
+
+// prepareDump dumps the signed run transactions, along with the
+// source account sequences and chain height, for a later replay
+func (p *Pipeline) prepareDump(accounts []*gnoland.GnoAccount, txs []*std.Tx) error {
+	fmt.Printf("\n💾 Preparing Transaction Dump 💾\n\n")
+
+	height, err := p.cli.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("unable to fetch latest block, %w", err)
+	}
+
+	dumpAccounts := make([]dump.Account, 0, len(accounts))
+
+	for _, account := range accounts {
+		address := account.GetAddress().String()
+
+		index, ok := p.indices[address]
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnknownSigner, address)
+		}
+
+		dumpAccounts = append(dumpAccounts, dump.Account{
+			Address:       address,
+			Index:         index,
+			AccountNumber: account.AccountNumber,
+			Sequence:      account.Sequence,
+		})
+	}
+
+	if p.cfg.StatePassword == "" {
+		fmt.Printf(
+			"⚠️ The dump holds signed transactions, and is written unencrypted. " +
+				"Set -state-password to encrypt it ⚠️\n",
+		)
+	}
+
+	d := dump.New(p.cfg.ChainID, p.cfg.Mode, height, dumpAccounts, txs)
+	if err := dump.Save(d, p.cfg.PrepareDump, p.cfg.StatePassword); err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"✅ Successfully dumped %d transactions from %d accounts (height %d) to %s\n",
+		len(txs),
+		len(dumpAccounts),
+		height,
+		p.cfg.PrepareDump,
+	)
+
+	p.recordArtifact(manifest.TypeDump, p.cfg.PrepareDump)
+
+	return nil
+}
+
+// executeReplay broadcasts the transactions of a prepared dump.
+// The dump accounts that drifted since the prepare are either
+// re-signed with fresh nonces, or left out of the replay
+func (p *Pipeline) executeReplay() error {
+	fmt.Printf("\n🔁 Replaying Transaction Dump 🔁\n\n")
+
+	phaseStart := p.startPhase(phaseReplay)
+
+	d, err := dump.Load(p.cfg.ReplayDump, p.cfg.StatePassword)
+	if err != nil {
+		return err
+	}
+
+	// Make sure no live run signs with the same accounts
+	indices := make([]uint32, 0, len(d.Header.Accounts))
+	for _, account := range d.Header.Accounts {
+		indices = append(indices, account.Index)
+	}
+
+	if err := p.lockAccounts(locks.RangesOf(indices)); err != nil {
+		return err
+	}
+
+	height, err := p.cli.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("unable to fetch latest block, %w", err)
+	}
+
+	fmt.Printf(
+		"Loaded %d %s transactions, prepared %s ago at height %d (%d blocks ago)\n",
+		len(d.Txs),
+		d.Header.Mode,
+		d.Age().Round(time.Second),
+		d.Header.Height,
+		height-d.Header.Height,
+	)
+
+	// Make sure the dump nonces are still valid
+	drifts, err := d.CheckDrift(p.cli)
+	if err != nil {
+		return fmt.Errorf("unable to check account drift, %w", err)
+	}
+
+	txs := d.Txs
+
+	if len(drifts) > 0 {
+		for _, drift := range drifts {
+			fmt.Printf(
+				"⚠️ Account %s drifted by %+d (dump sequence %d, current sequence %d)\n",
+				drift.Account.Address,
+				drift.Delta(),
+				drift.Account.Sequence,
+				drift.Current.Sequence,
+			)
+		}
+
+		switch {
+		case p.cfg.ReSign:
+			if err := p.resignDump(d, drifts); err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Successfully re-signed the transactions of %d drifted accounts\n", len(drifts))
+		case p.cfg.AllowPartialReplay:
+			txs = d.Without(drifts)
+			if len(txs) == 0 {
+				return errNoReplayTxs
+			}
+
+			fmt.Printf(
+				"⚠️ Replaying %d transactions from %d of %d accounts\n",
+				len(txs),
+				len(d.Header.Accounts)-len(drifts),
+				len(d.Header.Accounts),
+			)
+		default:
+			return fmt.Errorf(
+				"%w: %d of %d accounts, use -re-sign or -allow-partial-replay",
+				errDumpDrift,
+				len(drifts),
+				len(d.Header.Accounts),
+			)
+		}
+	}
+
+	p.trackPhase(phaseReplay, phaseStart)
+
+	// There is no runtime target, so the reads default to account lookups
+	if p.cfg.ReadRatio > 0 {
+		addresses := make([]string, 0, len(d.Header.Accounts))
+		for _, account := range d.Header.Accounts {
+			addresses = append(addresses, account.Address)
+		}
+
+		p.queries = p.readQueries(nil, addresses)
+	}
+
+	return p.dispatch(txs, nil, nil)
+}
+
+// resignDump re-signs the transactions of the drifted accounts,
+// using the keys derived from the mnemonic
+func (p *Pipeline) resignDump(d *dump.Dump, drifts []dump.Drift) error {
+	for _, drift := range drifts {
+		info, err := p.registerAccount(drift.Account.Index)
+		if err != nil {
+			return err
+		}
+
+		// Make sure the mnemonic derives the dump account
+		if info.GetAddress().String() != drift.Account.Address {
+			return fmt.Errorf(
+				"%w: %s is not derived from the mnemonic at index %d",
+				errUnknownSigner,
+				drift.Account.Address,
+				drift.Account.Index,
+			)
+		}
+	}
+
+	// The transactions are re-signed for the dump chain
+	dumpSigner := signer.NewKeybaseSigner(
+		p.keybase,
+		d.Header.ChainID,
+		signerOptions(p.cfg.VerifySignatures)...,
+	)
+
+	if err := d.Resign(dumpSigner, drifts); err != nil {
+		return fmt.Errorf("unable to re-sign dump, %w", err)
+	}
+
+	return nil
+}
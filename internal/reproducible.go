@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/results"
+)
+
+var (
+	errMissingTxSetHash = errors.New("results have no transaction set hash, run with -reproducible")
+	errHashMismatch     = errors.New("transaction set hash mismatch")
+	errUnfundedAccounts = errors.New("reproducible runs require all sub-accounts to participate")
+	errCorpusMismatch   = errors.New("argument corpus does not match the run corpus")
+	errScenarioMismatch = errors.New("scenario does not match the run scenario")
+)
+
+// VerifyReproducibility re-constructs the run transactions from the configuration,
+// without broadcasting them, and verifies the transaction set hash matches
+// the hash recorded in the results of the reproducible run
+func VerifyReproducibility(cfg *Config, resultsPath string) error {
+	fmt.Printf("\n🔁 Verifying Reproducibility 🔁\n\n")
+
+	// The verification is only meaningful for reproducible configurations
+	cfg.Reproducible = true
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration, %w", err)
+	}
+
+	defer cfg.Cleanup()
+
+	result, err := loadRunResult(resultsPath)
+	if err != nil {
+		return err
+	}
+
+	if result.TxSetHash == "" {
+		return errMissingTxSetHash
+	}
+
+	p := NewPipeline(cfg)
+
+	// The sampled call arguments depend on the corpus content
+	if actual := p.corpusHash(); actual != result.CorpusHash {
+		return fmt.Errorf("%w, recorded %q, provided %q", errCorpusMismatch, result.CorpusHash, actual)
+	}
+
+	// The picked templates and rendered arguments depend on the scenario content
+	var recorded string
+	if result.Scenario != nil {
+		recorded = result.Scenario.Hash
+	}
+
+	if actual := p.scenarioHash(); actual != recorded {
+		return fmt.Errorf("%w, recorded %q, provided %q", errScenarioMismatch, recorded, actual)
+	}
+
+	txs, err := p.constructOffline()
+	if err != nil {
+		return err
+	}
+
+	actual, err := runtime.HashTransactions(txs)
+	if err != nil {
+		return fmt.Errorf("unable to hash transactions, %w", err)
+	}
+
+	fmt.Printf("\nRecorded hash:      %s\nReconstructed hash: %s\n", result.TxSetHash, actual)
+
+	if actual != result.TxSetHash {
+		return errHashMismatch
+	}
+
+	fmt.Printf("✅ Successfully verified the %d constructed transactions match the run\n", len(txs))
+
+	return nil
+}
+
+// constructOffline constructs the run transactions, without contacting the node.
+// The transactions are left unsigned, since the signatures are not hashed
+func (p *Pipeline) constructOffline() ([]*std.Tx, error) {
+	accounts, err := p.initializeAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	runAccounts := make([]*gnoland.GnoAccount, 0, len(accounts))
+
+	for index, info := range accounts {
+		runAccounts = append(runAccounts, &gnoland.GnoAccount{
+			BaseAccount: *std.NewBaseAccount(info.GetAddress(), std.Coins{}, nil, uint64(index), 0),
+		})
+	}
+
+	txRuntime := p.newRuntime(previewSigner{})
+
+	// Initialize the runtime, so the transactions
+	// reference the deployment made by the distributor
+	if _, err := txRuntime.Initialize(runAccounts[0]); err != nil {
+		return nil, fmt.Errorf("unable to initialize runtime, %w", err)
+	}
+
+	txs, err := txRuntime.ConstructTransactions(runAccounts[1:], p.cfg.Transactions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to construct transactions, %w", err)
+	}
+
+	return txs, nil
+}
+
+// canonicalAccounts orders the run accounts by their derivation order.
+// The distributor orders the accounts by their funding state, which
+// depends on the chain, so the order is restored for reproducible runs
+func canonicalAccounts(accounts []keys.Info, runAccounts []*gnoland.GnoAccount) ([]*gnoland.GnoAccount, error) {
+	lookup := make(map[string]*gnoland.GnoAccount, len(runAccounts))
+	for _, account := range runAccounts {
+		lookup[account.GetAddress().String()] = account
+	}
+
+	ordered := make([]*gnoland.GnoAccount, 0, len(runAccounts))
+
+	for _, info := range accounts[1:] {
+		account, ok := lookup[info.GetAddress().String()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s was not funded", errUnfundedAccounts, info.GetAddress())
+		}
+
+		ordered = append(ordered, account)
+	}
+
+	return ordered, nil
+}
+
+// loadRunResult loads the run results, from the results file or run manifest
+func loadRunResult(path string) (*collector.RunResult, error) {
+	if manifest.IsManifest(path) {
+		m, err := manifest.Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		results, err := m.Locate(manifest.TypeResults)
+		if err != nil {
+			return nil, fmt.Errorf("unable to locate results, %w", err)
+		}
+
+		path = results[len(results)-1]
+	}
+
+	r, err := results.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Raw(), nil
+}
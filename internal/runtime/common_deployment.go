@@ -3,7 +3,6 @@ package runtime
 import (
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"github.com/gnolang/gno/gnoland"
 	"github.com/gnolang/gno/pkgs/gnolang"
@@ -16,13 +15,29 @@ type commonDeployment struct {
 
 	deployDir        string
 	deployPathPrefix string
+	deposit          std.Coins
+	seed             uint64
+	construction     construction
+	gas              txGas
 }
 
-func newCommonDeployment(signer Signer, deployDir, deployPrefix string) *commonDeployment {
+func newCommonDeployment(
+	signer Signer,
+	deployDir,
+	deployPrefix string,
+	deposit std.Coins,
+	seed uint64,
+	construction construction,
+	gas txGas,
+) *commonDeployment {
 	return &commonDeployment{
 		signer:           signer,
 		deployDir:        deployDir,
 		deployPathPrefix: deployPrefix,
+		deposit:          deposit,
+		seed:             seed,
+		construction:     construction,
+		gas:              gas,
 	}
 }
 
@@ -35,6 +50,16 @@ func (c *commonDeployment) ConstructTransactions(
 	accounts []*gnoland.GnoAccount,
 	transactions uint64,
 ) ([]*std.Tx, error) {
+	set, err := c.transactionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return set.construct(accounts, transactions)
+}
+
+// transactionSet creates the construction state of the run transaction set
+func (c *commonDeployment) transactionSet() (*txSet, error) {
 	// Get absolute path to folder
 	deployPathAbs, err := filepath.Abs(c.deployDir)
 	if err != nil {
@@ -42,24 +67,25 @@ func (c *commonDeployment) ConstructTransactions(
 	}
 
 	var (
-		timestamp = time.Now().Unix()
-		getMsgFn  = func(creator *gnoland.GnoAccount, index int) std.Msg {
+		suffix   = pathSuffix(c.seed)
+		getMsgFn = func(creator *gnoland.GnoAccount, index int) std.Msg {
 			memPkg := gnolang.ReadMemPackage(
 				deployPathAbs,
-				fmt.Sprintf("%s/stress_%d_%d", c.deployPathPrefix, timestamp, index),
+				fmt.Sprintf("%s/stress_%d_%d", c.deployPathPrefix, suffix, index),
 			)
 
 			return vm.MsgAddPackage{
 				Creator: creator.GetAddress(),
 				Package: memPkg,
+				Deposit: c.deposit,
 			}
 		}
 	)
 
-	return constructTransactions(
-		c.signer,
-		accounts,
-		transactions,
-		getMsgFn,
-	)
+	return newTxSet(c.signer, getMsgFn, newTxFee(c.gas.run, c.gas.runFee), c.construction), nil
+}
+
+func (c *commonDeployment) SetRunFee(fee std.Fee) {
+	c.gas.run = fee.GasWanted
+	c.gas.runFee = fee.GasFee
 }
@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+// ConstructionPolicy is the handling policy for
+// transactions that fail to be constructed
+type ConstructionPolicy string
+
+const (
+	// ConstructionAbort aborts the entire transaction set
+	ConstructionAbort ConstructionPolicy = "abort"
+
+	// ConstructionSkip drops the failing transaction from the set
+	ConstructionSkip ConstructionPolicy = "skip"
+
+	// ConstructionSubstitute replaces the failing transaction with a
+	// minimal transfer, so the transaction count is kept exact
+	ConstructionSubstitute ConstructionPolicy = "substitute"
+)
+
+// IsConstructionPolicy checks if the passed in policy is supported
+func IsConstructionPolicy(policy ConstructionPolicy) bool {
+	return policy == ConstructionAbort ||
+		policy == ConstructionSkip ||
+		policy == ConstructionSubstitute
+}
+
+// ConstructionFailure is a single transaction that failed to be constructed,
+// and was skipped or substituted according to the construction policy
+type ConstructionFailure struct {
+	Index  int                // the index of the transaction in the set
+	Policy ConstructionPolicy // the applied policy (skip / substitute)
+	Err    error              // the construction error
+}
+
+// maxTxSize is the maximum size of an encoded transaction
+// accepted by the node (the default consensus max tx bytes)
+const maxTxSize = 1024 * 1024
+
+var errOversizedTx = errors.New("transaction exceeds the maximum size")
+
+// buildMsg generates the transaction message, converting any generator panics
+// (for example, unreadable package templates) into construction errors
+func buildMsg(getMsg msgFn, creator *gnoland.GnoAccount, index int) (msg std.Msg, err error) {
+	defer func() {
+		r := recover()
+
+		switch cause := r.(type) {
+		case nil:
+		case error:
+			err = fmt.Errorf("unable to generate message, %w", cause)
+		default:
+			err = fmt.Errorf("unable to generate message, %v", r)
+		}
+	}()
+
+	return getMsg(creator, index), nil
+}
+
+// newSubstituteTx creates the minimal transfer (to self)
+// that replaces a transaction that failed to be constructed.
+// It pays the run transaction fee, so it is priced and funded as the transaction it replaces
+func newSubstituteTx(creator *gnoland.GnoAccount, fee std.Fee) *std.Tx {
+	return &std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: creator.Address,
+				ToAddress:   creator.Address,
+				Amount:      std.NewCoins(std.NewCoin(common.Denomination, 1)),
+			},
+		},
+		Fee: fee,
+	}
+}
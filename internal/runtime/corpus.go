@@ -0,0 +1,218 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// CorpusMode is the sampling mode of the argument corpus
+type CorpusMode string
+
+const (
+	// CorpusWithReplacement samples the corpus values at random,
+	// so a value can be used by multiple transactions
+	CorpusWithReplacement CorpusMode = "with-replacement"
+
+	// CorpusWithoutReplacement uses each corpus value at most once.
+	// Running out of values is a construction error
+	CorpusWithoutReplacement CorpusMode = "without-replacement"
+)
+
+// DefaultCallArgument is the realm call argument template,
+// unique for each transaction
+const DefaultCallArgument = "Account-{{.Index}}"
+
+var (
+	errEmptyCorpus     = errors.New("argument corpus has no values")
+	errCorpusExhausted = errors.New("argument corpus exhausted")
+	errMissingCorpus   = errors.New("call argument uses the corpus, but no corpus is set")
+)
+
+// IsCorpusMode checks if the passed in corpus mode is supported
+func IsCorpusMode(mode CorpusMode) bool {
+	return mode == CorpusWithReplacement || mode == CorpusWithoutReplacement
+}
+
+// Corpus is the set of candidate call argument values
+type Corpus struct {
+	values []string
+	hash   string // the sha256 of the corpus file
+}
+
+// LoadCorpus loads the argument corpus file at the given path,
+// where each non-empty line is a candidate argument value
+func LoadCorpus(path string) (*Corpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read argument corpus, %w", err)
+	}
+
+	var (
+		values  = make([]string, 0)
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+	)
+
+	for scanner.Scan() {
+		value := strings.TrimSuffix(scanner.Text(), "\r")
+		if value == "" {
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to parse argument corpus, %w", err)
+	}
+
+	if len(values) == 0 {
+		return nil, errEmptyCorpus
+	}
+
+	sum := sha256.Sum256(data)
+
+	return &Corpus{
+		values: values,
+		hash:   hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Hash returns the sha256 of the corpus file, recorded for reproducibility
+func (c *Corpus) Hash() string {
+	return c.hash
+}
+
+// Len returns the number of corpus values
+func (c *Corpus) Len() int {
+	return len(c.values)
+}
+
+// corpusSampler samples the corpus values for the constructed transactions
+type corpusSampler struct {
+	corpus *Corpus
+	mode   CorpusMode
+	rng    *rand.Rand
+
+	order []int // the shuffled value order, without replacement
+	next  int   // the index of the next value in the order
+}
+
+// newCorpusSampler creates a corpus sampler. The seed is used if set,
+// otherwise the current time, mirroring the package path suffix
+func newCorpusSampler(corpus *Corpus, mode CorpusMode, seed uint64) *corpusSampler {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
+	s := &corpusSampler{
+		corpus: corpus,
+		mode:   mode,
+		rng:    rand.New(rand.NewSource(int64(seed))), //nolint:gosec // not used for security
+	}
+
+	if mode == CorpusWithoutReplacement {
+		s.order = s.rng.Perm(len(corpus.values))
+	}
+
+	return s
+}
+
+// sample returns the next corpus value
+func (s *corpusSampler) sample() (string, error) {
+	if s.mode != CorpusWithoutReplacement {
+		return s.corpus.values[s.rng.Intn(len(s.corpus.values))], nil
+	}
+
+	if s.next >= len(s.order) {
+		return "", fmt.Errorf("%w after %d values", errCorpusExhausted, len(s.order))
+	}
+
+	value := s.corpus.values[s.order[s.next]]
+	s.next++
+
+	return value, nil
+}
+
+// CallArgument is the parsed realm call argument template.
+// The {{.Index}} placeholder is the transaction index,
+// and the {{.Corpus}} placeholder samples the argument corpus
+type CallArgument struct {
+	tmpl       *template.Template
+	usesCorpus bool
+}
+
+// ParseCallArgument parses the realm call argument template
+func ParseCallArgument(text string) (*CallArgument, error) {
+	tmpl, err := template.New("call-argument").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse call argument, %w", err)
+	}
+
+	// Dry run the template, to find out if it samples the corpus
+	probe := &argumentData{
+		sample: func() (string, error) {
+			return "", nil
+		},
+	}
+
+	if err := tmpl.Execute(&strings.Builder{}, probe); err != nil {
+		return nil, fmt.Errorf("unable to execute call argument, %w", err)
+	}
+
+	return &CallArgument{
+		tmpl:       tmpl,
+		usesCorpus: probe.sampled,
+	}, nil
+}
+
+// UsesCorpus returns true if the argument samples the corpus
+func (a *CallArgument) UsesCorpus() bool {
+	return a.usesCorpus
+}
+
+// render renders the argument for the transaction at the given index
+func (a *CallArgument) render(index int, sampler *corpusSampler) (string, error) {
+	data := &argumentData{
+		Index: index,
+		sample: func() (string, error) {
+			if sampler == nil {
+				return "", errMissingCorpus
+			}
+
+			return sampler.sample()
+		},
+	}
+
+	var b strings.Builder
+
+	// The sampling errors are wrapped by the template execution error
+	if err := a.tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("unable to execute call argument, %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// argumentData is the call argument template data
+type argumentData struct {
+	Index int
+
+	sample  func() (string, error)
+	sampled bool
+}
+
+// Corpus samples the next corpus value
+func (d *argumentData) Corpus() (string, error) {
+	d.sampled = true
+
+	return d.sample()
+}
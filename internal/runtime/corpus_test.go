@@ -0,0 +1,237 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCorpus writes the corpus file with the given values
+func writeCorpus(t *testing.T, values ...string) *Corpus {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "corpus.txt")
+
+	if err := os.WriteFile(path, []byte(strings.Join(values, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("unable to write corpus, %v", err)
+	}
+
+	corpus, err := LoadCorpus(path)
+	if err != nil {
+		t.Fatalf("unable to load corpus, %v", err)
+	}
+
+	return corpus
+}
+
+// callArgs extracts the call arguments of the realm call transactions
+func callArgs(t *testing.T, txs []*std.Tx) []string {
+	t.Helper()
+
+	args := make([]string, 0, len(txs))
+
+	for _, tx := range txs {
+		msg, ok := tx.Msgs[0].(vm.MsgCall)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		args = append(args, msg.Args[0])
+	}
+
+	return args
+}
+
+func TestCorpus_LoadCorpus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("values and hash", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			first  = writeCorpus(t, "alice", "", `{"name": "bob"}`)
+			second = writeCorpus(t, "alice", `{"name": "bob"}`)
+		)
+
+		assert.Equal(t, []string{"alice", `{"name": "bob"}`}, first.values)
+		assert.Len(t, first.Hash(), 64)
+
+		// The hash is the file hash, not the values hash
+		assert.NotEqual(t, first.Hash(), second.Hash())
+	})
+
+	t.Run("empty corpus", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "corpus.txt")
+
+		if err := os.WriteFile(path, []byte("\n\n"), 0o600); err != nil {
+			t.Fatalf("unable to write corpus, %v", err)
+		}
+
+		_, err := LoadCorpus(path)
+
+		assert.ErrorIs(t, err, errEmptyCorpus)
+	})
+}
+
+func TestCorpus_ParseCallArgument(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name       string
+		text       string
+		usesCorpus bool
+	}{
+		{
+			"default argument",
+			DefaultCallArgument,
+			false,
+		},
+		{
+			"corpus argument",
+			"user-{{.Corpus}}",
+			true,
+		},
+		{
+			"spaced corpus argument",
+			"{{ .Corpus }}/{{ .Index }}",
+			true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			arg, err := ParseCallArgument(testCase.text)
+			if err != nil {
+				t.Fatalf("unable to parse call argument, %v", err)
+			}
+
+			assert.Equal(t, testCase.usesCorpus, arg.UsesCorpus())
+		})
+	}
+
+	t.Run("unknown placeholder", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseCallArgument("{{.Unknown}}")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCorpus_RealmCallArguments(t *testing.T) {
+	t.Parallel()
+
+	values := []string{"alice", "bob", "carol", "dave"}
+
+	// construct constructs the realm call transactions
+	// with the corpus call argument
+	construct := func(
+		t *testing.T,
+		mode CorpusMode,
+		transactions uint64,
+		opts ...Option,
+	) ([]*std.Tx, error) {
+		t.Helper()
+
+		arg, err := ParseCallArgument("user-{{.Corpus}}")
+		if err != nil {
+			t.Fatalf("unable to parse call argument, %v", err)
+		}
+
+		opts = append(
+			opts,
+			WithSeed(42),
+			WithCallArgument(arg),
+			WithCorpus(writeCorpus(t, values...), mode),
+		)
+
+		return GetRuntime(RealmCall, &mockSigner{}, opts...).
+			ConstructTransactions(generateAccounts(2), transactions)
+	}
+
+	t.Run("with replacement", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := construct(t, CorpusWithReplacement, 20)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		second, err := construct(t, CorpusWithReplacement, 20)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		args := callArgs(t, first)
+
+		for _, arg := range args {
+			assert.Contains(t, values, strings.TrimPrefix(arg, "user-"))
+		}
+
+		// The sampled values only depend on the seed
+		assert.Equal(t, args, callArgs(t, second))
+	})
+
+	t.Run("without replacement", func(t *testing.T) {
+		t.Parallel()
+
+		txs, err := construct(t, CorpusWithoutReplacement, uint64(len(values)))
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		sampled := make([]string, 0, len(values))
+		for _, arg := range callArgs(t, txs) {
+			sampled = append(sampled, strings.TrimPrefix(arg, "user-"))
+		}
+
+		assert.ElementsMatch(t, values, sampled)
+	})
+
+	t.Run("exhausted corpus", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := construct(t, CorpusWithoutReplacement, uint64(len(values))+1)
+
+		assert.ErrorIs(t, err, errCorpusExhausted)
+	})
+
+	t.Run("exhausted corpus substituted", func(t *testing.T) {
+		t.Parallel()
+
+		failures := make([]ConstructionFailure, 0)
+
+		txs, err := construct(
+			t,
+			CorpusWithoutReplacement,
+			uint64(len(values))+2,
+			WithConstructionPolicy(ConstructionSubstitute, func(failure ConstructionFailure) {
+				failures = append(failures, failure)
+			}),
+		)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		assert.Len(t, txs, len(values)+2)
+
+		if len(failures) != 2 {
+			t.Fatalf("invalid number of construction failures, %d", len(failures))
+		}
+
+		for _, failure := range failures {
+			assert.ErrorIs(t, failure.Err, errCorpusExhausted)
+		}
+	})
+}
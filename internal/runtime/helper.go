@@ -1,9 +1,14 @@
 package runtime
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
 	"github.com/gnolang/gno/pkgs/std"
 	"github.com/gnolang/supernova/internal/common"
 	"github.com/schollz/progressbar/v3"
@@ -12,57 +17,207 @@ import (
 // msgFn defines the transaction message constructor
 type msgFn func(creator *gnoland.GnoAccount, index int) std.Msg
 
-// constructTransactions constructs and signs the transactions
+// txSet is the construction state of a single transaction set, signed with the
+// passed in message generator and signer. Consecutive constructions continue
+// the transaction indices and the account nonces of the previous ones
+type txSet struct {
+	signer       Signer
+	getMsg       msgFn
+	fee          std.Fee
+	construction construction
+
+	quiet bool // flag indicating if the construction output is suppressed
+
+	next int // the index of the next transaction
+
+	// A local nonce map is updated to avoid unnecessary calls
+	// for fetching the fresh info from the chain every time
+	// an account is used
+	nonceMap map[uint64]uint64 // accountNumber -> nonce
+}
+
+// newTxSet creates a new transaction set construction state
+func newTxSet(signer Signer, getMsg msgFn, fee std.Fee, construction construction) *txSet {
+	return &txSet{
+		signer:       signer,
+		getMsg:       getMsg,
+		fee:          fee,
+		construction: construction,
+		nonceMap:     make(map[uint64]uint64),
+	}
+}
+
+// constructTransactions constructs and signs a single transaction set
 // using the passed in message generator and signer
 func constructTransactions(
 	signer Signer,
 	accounts []*gnoland.GnoAccount,
 	transactions uint64,
 	getMsg msgFn,
+	fee std.Fee,
+	construction construction,
 ) ([]*std.Tx, error) {
+	return newTxSet(signer, getMsg, fee, construction).construct(accounts, transactions)
+}
+
+// construct constructs and signs the next transactions of the set.
+// Transactions that fail to be constructed are handled
+// according to the construction policy
+func (s *txSet) construct(accounts []*gnoland.GnoAccount, transactions uint64) ([]*std.Tx, error) {
 	var (
-		txs = make([]*std.Tx, transactions)
+		txs = make([]*std.Tx, 0, transactions)
+
+		skipped     = 0
+		substituted = 0
 
-		// A local nonce map is updated to avoid unnecessary calls
-		// for fetching the fresh info from the chain every time
-		// an account is used
-		nonceMap = make(map[uint64]uint64) // accountNumber -> nonce
+		bar *progressbar.ProgressBar
 	)
 
-	fmt.Printf("\n🔨 Constructing Transactions 🔨\n\n")
+	if s.quiet {
+		bar = progressbar.DefaultSilent(int64(transactions))
+	} else {
+		fmt.Printf("\n🔨 Constructing Transactions 🔨\n\n")
+
+		bar = progressbar.Default(int64(transactions), "constructing txs")
+	}
 
-	bar := progressbar.Default(int64(transactions), "constructing txs")
+	for n := uint64(0); n < transactions; n++ {
+		i := s.next
+		s.next++
 
-	for i := 0; i < int(transactions); i++ {
 		// Generate the transaction
 		creator := accounts[i%len(accounts)]
 
-		tx := &std.Tx{
-			Msgs: []std.Msg{getMsg(creator, i)},
-			Fee:  defaultDeployTxFee,
+		tx, err := buildTx(s.getMsg, creator, i, s.fee)
+		if err != nil {
+			switch s.construction.policy {
+			case ConstructionSkip:
+				// Skipped transactions don't use up a nonce
+				s.construction.fail(i, ConstructionSkip, err)
+				skipped++
+
+				_ = bar.Add(1)
+
+				continue
+			case ConstructionSubstitute:
+				s.construction.fail(i, ConstructionSubstitute, err)
+				substituted++
+
+				tx = newSubstituteTx(creator, s.fee)
+			default:
+				return nil, fmt.Errorf("unable to construct transaction %d, %w", i, err)
+			}
 		}
 
 		// Fetch the next account nonce
-		nonce, found := nonceMap[creator.AccountNumber]
+		nonce, found := s.nonceMap[creator.AccountNumber]
 		if !found {
 			nonce = creator.Sequence
-			nonceMap[creator.AccountNumber] = nonce
+			s.nonceMap[creator.AccountNumber] = nonce
 		}
 
 		// Sign the transaction
-		if err := signer.SignTx(tx, creator, nonce, common.EncryptPassword); err != nil {
+		if err := s.signer.SignTx(tx, creator, nonce, common.EncryptPassword); err != nil {
 			return nil, fmt.Errorf("unable to sign transaction, %w", err)
 		}
 
 		// Increase the creator nonce locally
-		nonceMap[creator.AccountNumber] = nonce + 1
+		s.nonceMap[creator.AccountNumber] = nonce + 1
 
 		// Mark the transaction as ready
-		txs[i] = tx
+		txs = append(txs, tx)
 		_ = bar.Add(1)
 	}
 
-	fmt.Printf("✅ Successfully constructed %d transactions\n", transactions)
+	if !s.quiet {
+		fmt.Printf("✅ Successfully constructed %d transactions\n", len(txs))
+	}
+
+	if skipped > 0 || substituted > 0 {
+		fmt.Printf(
+			"⚠️ %d transactions failed to be constructed (%d skipped, %d substituted)\n",
+			skipped+substituted,
+			skipped,
+			substituted,
+		)
+	}
 
 	return txs, nil
 }
+
+// buildTx generates the (unsigned) transaction for the given index,
+// and makes sure it fits the maximum transaction size
+func buildTx(getMsg msgFn, creator *gnoland.GnoAccount, index int, fee std.Fee) (*std.Tx, error) {
+	msg, err := buildMsg(getMsg, creator, index)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &std.Tx{
+		Msgs: []std.Msg{msg},
+		Fee:  fee,
+	}
+
+	encoded, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	if len(encoded) > maxTxSize {
+		return nil, fmt.Errorf("%w: %d bytes", errOversizedTx, len(encoded))
+	}
+
+	return tx, nil
+}
+
+// fail reports the construction failure, if a report callback is set
+func (c construction) fail(index int, policy ConstructionPolicy, err error) {
+	if c.report == nil {
+		return
+	}
+
+	c.report(ConstructionFailure{
+		Index:  index,
+		Policy: policy,
+		Err:    err,
+	})
+}
+
+// pathSuffix returns the unique suffix of the deployed package paths.
+// The seed is used if set, otherwise the current time
+func pathSuffix(seed uint64) uint64 {
+	if seed != 0 {
+		return seed
+	}
+
+	return uint64(time.Now().Unix())
+}
+
+// HashTransactions returns the content hash of the transaction set.
+// The signatures are left out, since they depend on the chain state
+// (account numbers and sequences), and not only on the run inputs
+func HashTransactions(txs []*std.Tx) (string, error) {
+	var (
+		h      = sha256.New()
+		length = make([]byte, 8)
+	)
+
+	for _, tx := range txs {
+		unsigned := *tx
+		unsigned.Signatures = nil
+
+		encoded, err := amino.Marshal(unsigned)
+		if err != nil {
+			return "", fmt.Errorf("unable to marshal transaction, %w", err)
+		}
+
+		// Each transaction is length-prefixed, so the
+		// transaction boundaries are part of the hash
+		binary.BigEndian.PutUint64(length, uint64(len(encoded)))
+
+		_, _ = h.Write(length)
+		_, _ = h.Write(encoded)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
 	"github.com/gnolang/gno/pkgs/sdk/vm"
 	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,7 +60,14 @@ func TestHelper_ConstructTransactions(t *testing.T) {
 		}
 	)
 
-	txs, err := constructTransactions(mockSigner, accounts, transactions, getMsgFn)
+	txs, err := constructTransactions(
+		mockSigner,
+		accounts,
+		transactions,
+		getMsgFn,
+		newTxFee(DefaultRealmGasWanted, common.DefaultGasFee),
+		construction{},
+	)
 	if err != nil {
 		t.Fatalf("unable to construct transactions, %v", err)
 	}
@@ -75,7 +84,7 @@ func TestHelper_ConstructTransactions(t *testing.T) {
 	// Make sure the constructed transactions are valid
 	for index, tx := range txs {
 		// Make sure the fee is valid
-		assert.Equal(t, defaultDeployTxFee, tx.Fee)
+		assert.Equal(t, newTxFee(DefaultRealmGasWanted, common.DefaultGasFee), tx.Fee)
 
 		// Make sure the message is valid
 		if len(tx.Msgs) != 1 {
@@ -87,3 +96,139 @@ func TestHelper_ConstructTransactions(t *testing.T) {
 		assert.Equal(t, capturedSigns[index], tx)
 	}
 }
+
+func TestHelper_ConstructionPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		transactions = uint64(10)
+		failingIndex = 4
+
+		// The run fee is above the default gas price
+		runFee = newTxFee(DefaultRealmGasWanted, std.NewCoin(common.Denomination, 10*common.DefaultGasFee.Amount))
+
+		getMsgFn = func(creator *gnoland.GnoAccount, index int) std.Msg {
+			if index == failingIndex {
+				panic("template expansion failed")
+			}
+
+			return vm.MsgAddPackage{
+				Creator: creator.Address,
+			}
+		}
+	)
+
+	// construct constructs the transactions using the given policy,
+	// and returns the signed nonces and the reported failures
+	construct := func(policy ConstructionPolicy) ([]*std.Tx, []uint64, []ConstructionFailure, error) {
+		var (
+			nonces   = make([]uint64, 0, transactions)
+			failures = make([]ConstructionFailure, 0)
+
+			mockSigner = &mockSigner{
+				signTxFn: func(_ *std.Tx, _ *gnoland.GnoAccount, nonce uint64, _ string) error {
+					nonces = append(nonces, nonce)
+
+					return nil
+				},
+			}
+		)
+
+		txs, err := constructTransactions(
+			mockSigner,
+			generateAccounts(1),
+			transactions,
+			getMsgFn,
+			runFee,
+			construction{
+				policy: policy,
+				report: func(failure ConstructionFailure) {
+					failures = append(failures, failure)
+				},
+			},
+		)
+
+		return txs, nonces, failures, err
+	}
+
+	t.Run("abort", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, failures, err := construct(ConstructionAbort)
+
+		assert.ErrorContains(t, err, "template expansion failed")
+		assert.Empty(t, failures)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		t.Parallel()
+
+		txs, nonces, failures, err := construct(ConstructionSkip)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		assert.Len(t, txs, int(transactions)-1)
+
+		// Make sure the skipped transaction didn't use up a nonce
+		assert.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8}, nonces)
+
+		if !assert.Len(t, failures, 1) {
+			return
+		}
+
+		assert.Equal(t, failingIndex, failures[0].Index)
+		assert.Equal(t, ConstructionSkip, failures[0].Policy)
+		assert.ErrorContains(t, failures[0].Err, "template expansion failed")
+	})
+
+	t.Run("substitute", func(t *testing.T) {
+		t.Parallel()
+
+		txs, nonces, failures, err := construct(ConstructionSubstitute)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		if !assert.Len(t, txs, int(transactions)) {
+			return
+		}
+
+		assert.Len(t, nonces, int(transactions))
+
+		// Make sure the failing transaction was replaced with a transfer
+		_, ok := txs[failingIndex].Msgs[0].(bank.MsgSend)
+		assert.True(t, ok)
+
+		// The substitute pays the run fee
+		assert.Equal(t, runFee, txs[failingIndex].Fee)
+
+		if !assert.Len(t, failures, 1) {
+			return
+		}
+
+		assert.Equal(t, ConstructionSubstitute, failures[0].Policy)
+	})
+
+	t.Run("oversized transaction", func(t *testing.T) {
+		t.Parallel()
+
+		oversizedMsgFn := func(creator *gnoland.GnoAccount, _ int) std.Msg {
+			return vm.MsgCall{
+				Caller: creator.Address,
+				Args:   []string{string(make([]byte, maxTxSize))},
+			}
+		}
+
+		_, err := constructTransactions(
+			&mockSigner{},
+			generateAccounts(1),
+			1,
+			oversizedMsgFn,
+			newTxFee(DefaultRealmGasWanted, common.DefaultGasFee),
+			construction{},
+		)
+
+		assert.ErrorIs(t, err, errOversizedTx)
+	})
+}
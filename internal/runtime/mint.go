@@ -0,0 +1,158 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/gnolang"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+const (
+	mintLocation = "./scripts/m"
+
+	mintMethod   = "Mint"
+	mintedMethod = "Minted"
+
+	// DefaultMintMetadataSize is the default metadata size of each minted token, in bytes
+	DefaultMintMetadataSize = 256
+)
+
+type mint struct {
+	signer Signer
+
+	realmPath string
+	suffix    uint64
+
+	opts         mintOptions
+	construction construction
+	gas          txGas
+}
+
+func newMint(
+	signer Signer,
+	seed uint64,
+	opts mintOptions,
+	construction construction,
+	gas txGas,
+) *mint {
+	return &mint{
+		signer:       signer,
+		realmPath:    opts.realm,
+		suffix:       pathSuffix(seed),
+		opts:         opts,
+		construction: construction,
+		gas:          gas,
+	}
+}
+
+func (m *mint) Initialize(account *gnoland.GnoAccount) ([]*std.Tx, error) {
+	// Targeted realms are already deployed
+	if m.opts.realm != "" {
+		return nil, nil
+	}
+
+	// Get absolute path to folder
+	deployPathAbs, err := filepath.Abs(mintLocation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve absolute path, %w", err)
+	}
+
+	// The mint realm needs to be deployed before
+	// the tokens can be minted
+	m.realmPath = fmt.Sprintf("%s/mint_%d", realmPathPrefix, m.suffix)
+
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			vm.MsgAddPackage{
+				Creator: account.GetAddress(),
+				Package: gnolang.ReadMemPackage(
+					deployPathAbs,
+					m.realmPath,
+				),
+			},
+		},
+		Fee: newTxFee(m.gas.predeploy, m.gas.predeployFee),
+	}
+
+	// Sign it
+	if err := m.signer.SignTx(tx, account, account.Sequence, common.EncryptPassword); err != nil {
+		return nil, fmt.Errorf("unable to sign initialize transaction, %w", err)
+	}
+
+	return []*std.Tx{tx}, nil
+}
+
+func (m *mint) ConstructTransactions(
+	accounts []*gnoland.GnoAccount,
+	transactions uint64,
+) ([]*std.Tx, error) {
+	set, err := m.transactionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return set.construct(accounts, transactions)
+}
+
+// transactionSet creates the construction state of the run transaction set
+func (m *mint) transactionSet() (*txSet, error) {
+	getMsgFn := func(creator *gnoland.GnoAccount, index int) std.Msg {
+		id := mintID(m.suffix, index)
+
+		return vm.MsgCall{
+			Caller:  creator.Address,
+			PkgPath: m.realmPath,
+			Func:    mintMethod,
+			Args:    []string{id, mintMetadata(id, m.opts.metadataSize)},
+		}
+	}
+
+	return newTxSet(m.signer, getMsgFn, newTxFee(m.gas.run, m.gas.runFee), m.construction), nil
+}
+
+func (m *mint) SetRunFee(fee std.Fee) {
+	m.gas.run = fee.GasWanted
+	m.gas.runFee = fee.GasFee
+}
+
+func (m *mint) ReadQuery() (string, []byte) {
+	// Minted has no side effects, so it can be evaluated
+	return readQuery, []byte(fmt.Sprintf("%s\n%s()", m.realmPath, mintedMethod))
+}
+
+// mintID returns the unique token id of the transaction.
+// The ids are hashes, so the realm token tree stays shallow,
+// and they are unique across runs with different path suffixes
+func mintID(suffix uint64, index int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d-%d", suffix, index)))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// mintMetadata returns the token metadata of the given size,
+// derived from the id, so each token stores distinct bytes
+func mintMetadata(id string, size int) string {
+	if size <= 0 {
+		return ""
+	}
+
+	return strings.Repeat(id, size/len(id)+1)[:size]
+}
+
+// MintFootprint returns the estimated storage bytes written by the
+// mint message (the token id and metadata), and true if it is a mint
+func MintFootprint(msg std.Msg) (int, bool) {
+	call, ok := msg.(vm.MsgCall)
+	if !ok || call.Func != mintMethod || len(call.Args) != 2 {
+		return 0, false
+	}
+
+	return len(call.Args[0]) + len(call.Args[1]), true
+}
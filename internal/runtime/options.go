@@ -0,0 +1,146 @@
+package runtime
+
+import "github.com/gnolang/gno/pkgs/std"
+
+type Option func(o *options)
+
+// options are the common runtime options
+type options struct {
+	deposit std.Coins // the storage deposit for package deployments
+	seed    uint64    // the seed for the package paths, if any
+
+	construction construction // the construction failure handling
+	arguments    arguments    // the realm call argument generation
+	gas          txGas        // the transaction gas wanted and fees
+	mint         mintOptions  // the token mints (MINT)
+	scenario     *Scenario    // the custom workload scenario (SCENARIO)
+}
+
+// txGas is the gas wanted and gas fees of the runtime transactions
+type txGas struct {
+	run       int64 // the run (and priming) transactions
+	predeploy int64 // the predeployed realm (REALM_CALL, MINT)
+
+	runFee       std.Coin // the gas fee of the run (and priming) transactions
+	predeployFee std.Coin // the gas fee of the predeployed realm
+}
+
+// construction is the construction failure handling
+type construction struct {
+	policy ConstructionPolicy        // the construction failure policy
+	report func(ConstructionFailure) // the callback for skipped / substituted transactions
+}
+
+// arguments is the realm call argument generation
+type arguments struct {
+	template *CallArgument // the call argument template, if any
+	corpus   *Corpus       // the argument corpus, if any
+	mode     CorpusMode    // the corpus sampling mode
+}
+
+// mintOptions are the token mint options
+type mintOptions struct {
+	realm        string // the targeted mint realm, if any
+	metadataSize int    // the metadata size of each token, in bytes
+}
+
+// WithStorageDeposit sets the storage deposit
+// attached to each package deployment message
+func WithStorageDeposit(deposit std.Coins) Option {
+	return func(o *options) {
+		o.deposit = deposit
+	}
+}
+
+// WithSeed sets the seed used for the deployed package paths,
+// instead of the current time, so the constructed transactions
+// are identical across runs with the same inputs
+func WithSeed(seed uint64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithConstructionPolicy sets the handling policy for transactions that fail
+// to be constructed. Each skipped or substituted transaction is passed to the report callback
+func WithConstructionPolicy(policy ConstructionPolicy, report func(ConstructionFailure)) Option {
+	return func(o *options) {
+		o.construction = construction{
+			policy: policy,
+			report: report,
+		}
+	}
+}
+
+// WithCallArgument sets the realm call argument template,
+// instead of the default unique argument per transaction
+func WithCallArgument(template *CallArgument) Option {
+	return func(o *options) {
+		o.arguments.template = template
+	}
+}
+
+// WithCorpus sets the corpus sampled by the {{.Corpus}}
+// call argument placeholder, using the given sampling mode
+func WithCorpus(corpus *Corpus, mode CorpusMode) Option {
+	return func(o *options) {
+		o.arguments.corpus = corpus
+		o.arguments.mode = mode
+	}
+}
+
+// WithGasWanted sets the gas wanted of the run (and priming) transactions,
+// instead of the runtime default
+func WithGasWanted(gas int64) Option {
+	return func(o *options) {
+		o.gas.run = gas
+	}
+}
+
+// WithPredeployGasWanted sets the gas wanted of the predeployment
+// transactions, instead of the realm deployment default
+func WithPredeployGasWanted(gas int64) Option {
+	return func(o *options) {
+		o.gas.predeploy = gas
+	}
+}
+
+// WithGasFee sets the gas fee of the run (and priming) transactions,
+// instead of the chain default
+func WithGasFee(fee std.Coin) Option {
+	return func(o *options) {
+		o.gas.runFee = fee
+	}
+}
+
+// WithPredeployGasFee sets the gas fee of the predeployment
+// transactions, instead of the chain default
+func WithPredeployGasFee(fee std.Coin) Option {
+	return func(o *options) {
+		o.gas.predeployFee = fee
+	}
+}
+
+// WithMintRealm sets the realm targeted by the token mints,
+// instead of deploying a fresh mint realm
+func WithMintRealm(realmPath string) Option {
+	return func(o *options) {
+		o.mint.realm = realmPath
+	}
+}
+
+// WithMintMetadataSize sets the metadata size of each minted token, in bytes,
+// instead of the default
+func WithMintMetadataSize(size int) Option {
+	return func(o *options) {
+		o.mint.metadataSize = size
+	}
+}
+
+// WithScenario sets the custom workload scenario
+// the transactions are constructed from (SCENARIO)
+func WithScenario(scenario *Scenario) Option {
+	return func(o *options) {
+		o.scenario = scenario
+	}
+}
@@ -0,0 +1,91 @@
+package runtime
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/gnolang"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+)
+
+// paddingFile is the package file holding the deployment padding
+const paddingFile = "padding.gno"
+
+// Padder is implemented by runtimes whose transaction payload
+// can be padded to a given size, for transaction size probing
+type Padder interface {
+	// ConstructPaddedTransaction generates and signs a single transaction
+	// with the given nonce, with its payload padded by the given number of bytes.
+	// The runtime needs to be initialized, if it has any infrastructure
+	ConstructPaddedTransaction(account *gnoland.GnoAccount, nonce uint64, padding int, fee std.Fee) (*std.Tx, error)
+}
+
+func (r *realmCall) ConstructPaddedTransaction(
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	padding int,
+	fee std.Fee,
+) (*std.Tx, error) {
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			vm.MsgCall{
+				Caller:  account.Address,
+				PkgPath: r.realmPath,
+				Func:    methodName,
+				Args:    []string{strings.Repeat("x", padding)},
+			},
+		},
+		Fee: fee,
+	}
+
+	if err := r.signer.SignTx(tx, account, nonce, common.EncryptPassword); err != nil {
+		return nil, fmt.Errorf("unable to sign padded transaction, %w", err)
+	}
+
+	return tx, nil
+}
+
+func (c *commonDeployment) ConstructPaddedTransaction(
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	padding int,
+	fee std.Fee,
+) (*std.Tx, error) {
+	deployPathAbs, err := filepath.Abs(c.deployDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve absolute path, %w", err)
+	}
+
+	// Each padded deployment has a unique path
+	memPkg := gnolang.ReadMemPackage(
+		deployPathAbs,
+		fmt.Sprintf("%s/probe_%d_%d", c.deployPathPrefix, pathSuffix(c.seed), nonce),
+	)
+
+	// The padding is a single comment, so the package is unchanged
+	memPkg.Files = append(memPkg.Files, &std.MemFile{
+		Name: paddingFile,
+		Body: fmt.Sprintf("package %s\n\n// %s\n", memPkg.Name, strings.Repeat("x", padding)),
+	})
+
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			vm.MsgAddPackage{
+				Creator: account.GetAddress(),
+				Package: memPkg,
+				Deposit: c.deposit,
+			},
+		},
+		Fee: fee,
+	}
+
+	if err := c.signer.SignTx(tx, account, nonce, common.EncryptPassword); err != nil {
+		return nil, fmt.Errorf("unable to sign padded transaction, %w", err)
+	}
+
+	return tx, nil
+}
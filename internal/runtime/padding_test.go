@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPadding_ConstructPaddedTransaction(t *testing.T) {
+	t.Parallel()
+
+	// Change the working directory to root
+	moveToRoot(t)
+
+	var (
+		padding = 4096
+		fee     = std.NewFee(10_000_000, common.DefaultGasFee)
+		account = generateAccounts(1)[0]
+	)
+
+	t.Run("package deployment", func(t *testing.T) {
+		t.Parallel()
+
+		padder, ok := GetRuntime(PackageDeployment, &mockSigner{}).(Padder)
+		if !ok {
+			t.Fatal("runtime does not support padding")
+		}
+
+		small, err := padder.ConstructPaddedTransaction(account, 0, 0, fee)
+		if err != nil {
+			t.Fatalf("unable to construct padded transaction, %v", err)
+		}
+
+		padded, err := padder.ConstructPaddedTransaction(account, 1, padding, fee)
+		if err != nil {
+			t.Fatalf("unable to construct padded transaction, %v", err)
+		}
+
+		smallMsg, ok := small.Msgs[0].(vm.MsgAddPackage)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		paddedMsg, ok := padded.Msgs[0].(vm.MsgAddPackage)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		// Each padded deployment has a unique path
+		assert.NotEqual(t, smallMsg.Package.Path, paddedMsg.Package.Path)
+		assert.Equal(t, fee, padded.Fee)
+
+		last := paddedMsg.Package.Files[len(paddedMsg.Package.Files)-1]
+
+		assert.Equal(t, paddingFile, last.Name)
+		assert.Greater(t, len(last.Body), padding)
+	})
+
+	t.Run("realm call", func(t *testing.T) {
+		t.Parallel()
+
+		padder, ok := GetRuntime(RealmCall, &mockSigner{}).(Padder)
+		if !ok {
+			t.Fatal("runtime does not support padding")
+		}
+
+		tx, err := padder.ConstructPaddedTransaction(account, 0, padding, fee)
+		if err != nil {
+			t.Fatalf("unable to construct padded transaction, %v", err)
+		}
+
+		msg, ok := tx.Msgs[0].(vm.MsgCall)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		assert.Len(t, msg.Args[0], padding)
+	})
+}
@@ -3,7 +3,6 @@ package runtime
 import (
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"github.com/gnolang/gno/gnoland"
 	"github.com/gnolang/gno/pkgs/gnolang"
@@ -14,17 +13,41 @@ import (
 
 const (
 	methodName = "SayHello"
+	readQuery  = "vm/qeval"
 )
 
 type realmCall struct {
 	signer Signer
 
 	realmPath string
+	deposit   std.Coins
+	seed      uint64
+
+	construction construction
+	arguments    arguments
+	gas          txGas
 }
 
-func newRealmCall(signer Signer) *realmCall {
+func newRealmCall(
+	signer Signer,
+	deposit std.Coins,
+	seed uint64,
+	construction construction,
+	arguments arguments,
+	gas txGas,
+) *realmCall {
+	if arguments.template == nil {
+		// The default template is always valid
+		arguments.template, _ = ParseCallArgument(DefaultCallArgument)
+	}
+
 	return &realmCall{
-		signer: signer,
+		signer:       signer,
+		deposit:      deposit,
+		seed:         seed,
+		construction: construction,
+		arguments:    arguments,
+		gas:          gas,
 	}
 }
 
@@ -37,7 +60,7 @@ func (r *realmCall) Initialize(account *gnoland.GnoAccount) ([]*std.Tx, error) {
 
 	// The Realm needs to be deployed before
 	// it can be interacted with
-	r.realmPath = fmt.Sprintf("%s/stress_%d", realmPathPrefix, time.Now().Unix())
+	r.realmPath = fmt.Sprintf("%s/stress_%d", realmPathPrefix, pathSuffix(r.seed))
 
 	// Construct the transaction
 	msg := vm.MsgAddPackage{
@@ -46,11 +69,12 @@ func (r *realmCall) Initialize(account *gnoland.GnoAccount) ([]*std.Tx, error) {
 			deployPathAbs,
 			r.realmPath,
 		),
+		Deposit: r.deposit,
 	}
 
 	tx := &std.Tx{
 		Msgs: []std.Msg{msg},
-		Fee:  defaultDeployTxFee,
+		Fee:  newTxFee(r.gas.predeploy, r.gas.predeployFee),
 	}
 
 	// Sign it
@@ -61,23 +85,82 @@ func (r *realmCall) Initialize(account *gnoland.GnoAccount) ([]*std.Tx, error) {
 	return []*std.Tx{tx}, nil
 }
 
+func (r *realmCall) ConstructPrimingTransactions(
+	account *gnoland.GnoAccount,
+	calls uint64,
+) ([]*std.Tx, error) {
+	txs := make([]*std.Tx, calls)
+
+	for i := 0; i < int(calls); i++ {
+		tx := &std.Tx{
+			Msgs: []std.Msg{
+				vm.MsgCall{
+					Caller:  account.Address,
+					PkgPath: r.realmPath,
+					Func:    methodName,
+					Args:    []string{fmt.Sprintf("Priming-%d", i)},
+				},
+			},
+			Fee: newTxFee(r.gas.run, r.gas.runFee),
+		}
+
+		// Sign it
+		if err := r.signer.SignTx(tx, account, account.Sequence+uint64(i), common.EncryptPassword); err != nil {
+			return nil, fmt.Errorf("unable to sign priming transaction, %w", err)
+		}
+
+		txs[i] = tx
+	}
+
+	return txs, nil
+}
+
 func (r *realmCall) ConstructTransactions(
 	accounts []*gnoland.GnoAccount,
 	transactions uint64,
 ) ([]*std.Tx, error) {
+	set, err := r.transactionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return set.construct(accounts, transactions)
+}
+
+// transactionSet creates the construction state of the run transaction set
+func (r *realmCall) transactionSet() (*txSet, error) {
+	var sampler *corpusSampler
+
+	// The corpus is sampled in the transaction order,
+	// so the sampled values only depend on the seed
+	if r.arguments.corpus != nil {
+		sampler = newCorpusSampler(r.arguments.corpus, r.arguments.mode, r.seed)
+	}
+
 	getMsgFn := func(creator *gnoland.GnoAccount, index int) std.Msg {
+		arg, err := r.arguments.template.render(index, sampler)
+		if err != nil {
+			// Handled as a construction failure
+			panic(err)
+		}
+
 		return vm.MsgCall{
 			Caller:  creator.Address,
 			PkgPath: r.realmPath,
 			Func:    methodName,
-			Args:    []string{fmt.Sprintf("Account-%d", index)},
+			Args:    []string{arg},
 		}
 	}
 
-	return constructTransactions(
-		r.signer,
-		accounts,
-		transactions,
-		getMsgFn,
-	)
+	return newTxSet(r.signer, getMsgFn, newTxFee(r.gas.run, r.gas.runFee), r.construction), nil
+}
+
+func (r *realmCall) SetRunFee(fee std.Fee) {
+	r.gas.run = fee.GasWanted
+	r.gas.runFee = fee.GasFee
+}
+
+func (r *realmCall) ReadQuery() (string, []byte) {
+	// SayHello has no side effects, so it can be evaluated
+	return readQuery, []byte(fmt.Sprintf("%s\n%s(%q)", r.realmPath, methodName, "Reader"))
 }
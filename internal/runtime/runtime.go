@@ -14,10 +14,47 @@ const (
 	packagePathPrefix = "gno.land/p/demo"
 )
 
-var (
-	defaultDeployTxFee = std.NewFee(165000, common.DefaultGasFee)
+// The default gas wanted of each runtime transaction type. Realm calls
+// use far less gas than deployments, so they reserve less gas per block
+const (
+	DefaultCallGasWanted    int64 = 100_000
+	DefaultMintGasWanted    int64 = 400_000
+	DefaultRealmGasWanted   int64 = 165_000
+	DefaultPackageGasWanted int64 = 165_000
 )
 
+// DefaultMintDeployGasWanted is the default gas wanted of the mint realm
+// predeployment, which is larger than the default realm
+const DefaultMintDeployGasWanted int64 = 300_000
+
+// DefaultGasWanted returns the default gas wanted of the runtime transactions
+func DefaultGasWanted(runtimeType Type) int64 {
+	switch runtimeType {
+	case RealmCall, CustomScenario:
+		return DefaultCallGasWanted
+	case Mint:
+		return DefaultMintGasWanted
+	case PackageDeployment:
+		return DefaultPackageGasWanted
+	default:
+		return DefaultRealmGasWanted
+	}
+}
+
+// DefaultPredeployGasWanted returns the default gas wanted of the runtime predeployment
+func DefaultPredeployGasWanted(runtimeType Type) int64 {
+	if runtimeType == Mint {
+		return DefaultMintDeployGasWanted
+	}
+
+	return DefaultRealmGasWanted
+}
+
+// newTxFee creates the runtime transaction fee, with the given gas wanted and gas fee
+func newTxFee(gasWanted int64, gasFee std.Coin) std.Fee {
+	return std.NewFee(gasWanted, gasFee)
+}
+
 // Runtime is the base interface for all runtime
 // implementations.
 //
@@ -31,6 +68,32 @@ type Runtime interface {
 	// ConstructTransactions generates and signs the required transactions
 	// that will be used in the stress test
 	ConstructTransactions(accounts []*gnoland.GnoAccount, transactions uint64) ([]*std.Tx, error)
+
+	// SetRunFee sets the fee of the run (and priming) transactions that are constructed
+	// afterwards, once the run gas is estimated on the node
+	SetRunFee(fee std.Fee)
+}
+
+// Primer is implemented by runtimes whose targets pay a warm-up cost on the node
+// (package loading, compilation), and can be primed before the measured run
+type Primer interface {
+	// ConstructPrimingTransactions generates and signs the unmeasured priming transactions,
+	// that are executed against every target of the runtime
+	ConstructPrimingTransactions(account *gnoland.GnoAccount, calls uint64) ([]*std.Tx, error)
+}
+
+// Querier is implemented by runtimes whose targets can be read during the run
+type Querier interface {
+	// ReadQuery returns the ABCI query path and data that read the runtime target.
+	// It is only valid once the runtime is initialized
+	ReadQuery() (string, []byte)
+}
+
+// Mixer is implemented by runtimes that mix multiple transaction templates
+type Mixer interface {
+	// TemplateCounts returns the number of constructed transactions of each template, by name.
+	// It is only valid once the transactions are constructed
+	TemplateCounts() map[string]int
 }
 
 type Signer interface {
@@ -38,14 +101,46 @@ type Signer interface {
 }
 
 // GetRuntime fetches the specified runtime, if any
-func GetRuntime(runtimeType Type, signer Signer) Runtime {
+func GetRuntime(runtimeType Type, signer Signer, opts ...Option) Runtime {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// Unset gas wanted values use the runtime defaults
+	if o.gas.run == 0 {
+		o.gas.run = DefaultGasWanted(runtimeType)
+	}
+
+	if o.gas.predeploy == 0 {
+		o.gas.predeploy = DefaultPredeployGasWanted(runtimeType)
+	}
+
+	// Unset gas fees use the chain default
+	if o.gas.runFee.Denom == "" {
+		o.gas.runFee = common.DefaultGasFee
+	}
+
+	if o.gas.predeployFee.Denom == "" {
+		o.gas.predeployFee = common.DefaultGasFee
+	}
+
+	if o.mint.metadataSize == 0 {
+		o.mint.metadataSize = DefaultMintMetadataSize
+	}
+
 	switch runtimeType {
 	case RealmCall:
-		return newRealmCall(signer)
+		return newRealmCall(signer, o.deposit, o.seed, o.construction, o.arguments, o.gas)
+	case Mint:
+		return newMint(signer, o.seed, o.mint, o.construction, o.gas)
 	case RealmDeployment:
-		return newCommonDeployment(signer, realmLocation, realmPathPrefix)
+		return newCommonDeployment(signer, realmLocation, realmPathPrefix, o.deposit, o.seed, o.construction, o.gas)
 	case PackageDeployment:
-		return newCommonDeployment(signer, packageLocation, packagePathPrefix)
+		return newCommonDeployment(signer, packageLocation, packagePathPrefix, o.deposit, o.seed, o.construction, o.gas)
+	case CustomScenario:
+		return newScenario(signer, o.scenario, o.seed, o.construction, o.gas)
 	default:
 		return nil
 	}
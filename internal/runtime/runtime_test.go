@@ -6,8 +6,10 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/gnolang/gno/gnoland"
 	"github.com/gnolang/gno/pkgs/sdk/vm"
 	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,7 +35,7 @@ func verifyDeployTxCommon(t *testing.T, tx *std.Tx, expectedPrefix string) {
 	assert.Nil(t, vmMsg.Deposit)
 
 	// Make sure the fee is valid
-	assert.Equal(t, tx.Fee, defaultDeployTxFee)
+	assert.Equal(t, tx.Fee, newTxFee(DefaultRealmGasWanted, common.DefaultGasFee))
 }
 
 // moveToRoot sets the current working
@@ -178,6 +180,318 @@ func TestRuntime_RealmCall(t *testing.T) {
 		assert.Contains(t, vmMsg.Args[0], "Account")
 
 		// Make sure the fee is valid
-		assert.Equal(t, tx.Fee, defaultDeployTxFee)
+		assert.Equal(t, tx.Fee, newTxFee(DefaultCallGasWanted, common.DefaultGasFee))
 	}
 }
+
+func TestRuntime_RealmCallPriming(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls    = uint64(5)
+		accounts = generateAccounts(1)
+		nonces   = make([]uint64, 0, calls)
+
+		signer = &mockSigner{
+			signTxFn: func(_ *std.Tx, _ *gnoland.GnoAccount, nonce uint64, _ string) error {
+				nonces = append(nonces, nonce)
+
+				return nil
+			},
+		}
+	)
+
+	// Get the runtime
+	r := GetRuntime(RealmCall, signer)
+
+	primer, ok := r.(Primer)
+	if !ok {
+		t.Fatal("realm call runtime is not a primer")
+	}
+
+	// Construct the priming transactions
+	txs, err := primer.ConstructPrimingTransactions(accounts[0], calls)
+	if err != nil {
+		t.Fatalf("unable to construct priming transactions, %v", err)
+	}
+
+	if len(txs) != int(calls) {
+		t.Fatalf("invalid number of priming transactions, %d", len(txs))
+	}
+
+	for index, tx := range txs {
+		vmMsg, ok := tx.Msgs[0].(vm.MsgCall)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		assert.Equal(t, methodName, vmMsg.Func)
+		assert.Contains(t, vmMsg.Args[0], "Priming")
+
+		// Make sure the nonces are sequential
+		assert.Equal(t, accounts[0].Sequence+uint64(index), nonces[index])
+	}
+}
+
+func TestRuntime_StorageDeposit(t *testing.T) {
+	t.Parallel()
+
+	// Change the working directory to root
+	moveToRoot(t)
+
+	var (
+		transactions = uint64(10)
+		accounts     = generateAccounts(10)
+		deposit      = std.NewCoins(std.NewCoin(common.Denomination, 500))
+	)
+
+	// Get the runtime
+	r := GetRuntime(RealmDeployment, &mockSigner{}, WithStorageDeposit(deposit))
+
+	// Construct the transactions
+	txs, err := r.ConstructTransactions(accounts, transactions)
+	if err != nil {
+		t.Fatalf("unable to construct transactions, %v", err)
+	}
+
+	for _, tx := range txs {
+		vmMsg, ok := tx.Msgs[0].(vm.MsgAddPackage)
+		if !ok {
+			t.Fatal("invalid tx message type")
+		}
+
+		// Make sure the deposit is attached
+		assert.Equal(t, deposit, vmMsg.Deposit)
+	}
+}
+
+func TestRuntime_Mint(t *testing.T) {
+	t.Parallel()
+
+	// Change the working directory to root
+	moveToRoot(t)
+
+	var (
+		transactions = uint64(50)
+		accounts     = generateAccounts(5)
+	)
+
+	t.Run("deployed mint realm", func(t *testing.T) {
+		t.Parallel()
+
+		r := GetRuntime(Mint, &mockSigner{}, WithSeed(42), WithMintMetadataSize(100))
+
+		initialTxs, err := r.Initialize(accounts[0])
+		if err != nil {
+			t.Fatalf("unable to generate init transactions, %v", err)
+		}
+
+		if len(initialTxs) != 1 {
+			t.Fatalf("invalid number of initial transactions, %d", len(initialTxs))
+		}
+
+		deployMsg, ok := initialTxs[0].Msgs[0].(vm.MsgAddPackage)
+		if !ok {
+			t.Fatal("invalid init tx message type")
+		}
+
+		// The mint realm is larger than the default realm
+		assert.Equal(t, realmPathPrefix+"/mint_42", deployMsg.Package.Path)
+		assert.Equal(t, newTxFee(DefaultMintDeployGasWanted, common.DefaultGasFee), initialTxs[0].Fee)
+
+		txs, err := r.ConstructTransactions(accounts, transactions)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		if len(txs) != int(transactions) {
+			t.Fatalf("invalid number of transactions constructed, %d", len(txs))
+		}
+
+		ids := make(map[string]struct{}, len(txs))
+
+		for _, tx := range txs {
+			vmMsg, ok := tx.Msgs[0].(vm.MsgCall)
+			if !ok {
+				t.Fatal("invalid tx message type")
+			}
+
+			assert.Equal(t, mintMethod, vmMsg.Func)
+			assert.Equal(t, realmPathPrefix+"/mint_42", vmMsg.PkgPath)
+
+			if len(vmMsg.Args) != 2 {
+				t.Fatalf("invalid number of arguments provided for mint")
+			}
+
+			// Each mint has a unique id, and the configured metadata size
+			ids[vmMsg.Args[0]] = struct{}{}
+
+			assert.Len(t, vmMsg.Args[1], 100)
+
+			footprint, isMint := MintFootprint(vmMsg)
+
+			assert.True(t, isMint)
+			assert.Equal(t, len(vmMsg.Args[0])+100, footprint)
+
+			assert.Equal(t, tx.Fee, newTxFee(DefaultMintGasWanted, common.DefaultGasFee))
+		}
+
+		assert.Len(t, ids, len(txs))
+	})
+
+	t.Run("targeted mint realm", func(t *testing.T) {
+		t.Parallel()
+
+		r := GetRuntime(Mint, &mockSigner{}, WithMintRealm("gno.land/r/demo/nft"))
+
+		initialTxs, err := r.Initialize(accounts[0])
+		if err != nil {
+			t.Fatalf("unable to initialize runtime, %v", err)
+		}
+
+		// Targeted realms are not deployed
+		assert.Empty(t, initialTxs)
+
+		txs, err := r.ConstructTransactions(accounts, 1)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		vmMsg := txs[0].Msgs[0].(vm.MsgCall)
+
+		assert.Equal(t, "gno.land/r/demo/nft", vmMsg.PkgPath)
+		assert.Len(t, vmMsg.Args[1], DefaultMintMetadataSize)
+	})
+}
+
+func TestRuntime_GasWanted(t *testing.T) {
+	t.Parallel()
+
+	// Change the working directory to root
+	moveToRoot(t)
+
+	accounts := generateAccounts(2)
+
+	// Get the runtime
+	r := GetRuntime(
+		RealmCall,
+		&mockSigner{},
+		WithGasWanted(75_000),
+		WithPredeployGasWanted(200_000),
+		WithGasFee(std.NewCoin(common.Denomination, 5)),
+	)
+
+	initialTxs, err := r.Initialize(accounts[0])
+	if err != nil {
+		t.Fatalf("unable to initialize runtime, %v", err)
+	}
+
+	txs, err := r.ConstructTransactions(accounts, 4)
+	if err != nil {
+		t.Fatalf("unable to construct transactions, %v", err)
+	}
+
+	// Make sure the overrides are used for the run and predeploy transactions
+	assert.Equal(t, int64(200_000), initialTxs[0].Fee.GasWanted)
+	assert.Equal(t, common.DefaultGasFee, initialTxs[0].Fee.GasFee)
+
+	for _, tx := range txs {
+		assert.Equal(t, int64(75_000), tx.Fee.GasWanted)
+		assert.Equal(t, int64(5), tx.Fee.GasFee.Amount)
+	}
+
+	// Make sure each mode has its own default
+	assert.Equal(t, DefaultCallGasWanted, DefaultGasWanted(RealmCall))
+	assert.Equal(t, DefaultRealmGasWanted, DefaultGasWanted(RealmDeployment))
+	assert.Equal(t, DefaultPackageGasWanted, DefaultGasWanted(PackageDeployment))
+	assert.Equal(t, DefaultMintGasWanted, DefaultGasWanted(Mint))
+}
+
+func TestRuntime_SetRunFee(t *testing.T) {
+	t.Parallel()
+
+	// Change the working directory to root
+	moveToRoot(t)
+
+	accounts := generateAccounts(2)
+
+	for _, mode := range []Type{RealmCall, RealmDeployment, PackageDeployment, Mint} {
+		r := GetRuntime(mode, &mockSigner{})
+
+		if _, err := r.Initialize(accounts[0]); err != nil {
+			t.Fatalf("unable to initialize runtime, %v", err)
+		}
+
+		// The estimated fee replaces the configured one
+		estimated := std.NewFee(123_456, std.NewCoin(common.Denomination, 124))
+		r.SetRunFee(estimated)
+
+		txs, err := r.ConstructTransactions(accounts, 2)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		for _, tx := range txs {
+			assert.Equal(t, estimated, tx.Fee, mode)
+		}
+	}
+}
+
+func TestRuntime_Reproducible(t *testing.T) {
+	t.Parallel()
+	moveToRoot(t)
+
+	var (
+		transactions = uint64(10)
+		accounts     = generateAccounts(5)
+	)
+
+	// constructHash constructs the transactions with the given seed, and hashes them
+	constructHash := func(t *testing.T, seed uint64) (string, []*std.Tx) {
+		t.Helper()
+
+		r := GetRuntime(PackageDeployment, &mockSigner{}, WithSeed(seed))
+
+		txs, err := r.ConstructTransactions(accounts, transactions)
+		if err != nil {
+			t.Fatalf("unable to construct transactions, %v", err)
+		}
+
+		hash, err := HashTransactions(txs)
+		if err != nil {
+			t.Fatalf("unable to hash transactions, %v", err)
+		}
+
+		return hash, txs
+	}
+
+	hash, txs := constructHash(t, 42)
+
+	// Make sure the same seed constructs the same transaction set
+	sameHash, _ := constructHash(t, 42)
+	assert.Equal(t, hash, sameHash)
+
+	// Make sure a different seed constructs a different transaction set
+	otherHash, _ := constructHash(t, 43)
+	assert.NotEqual(t, hash, otherHash)
+
+	// Make sure the signatures are not part of the hash
+	txs[0].Signatures = []std.Signature{{Signature: []byte("signature")}}
+
+	signedHash, err := HashTransactions(txs)
+	if err != nil {
+		t.Fatalf("unable to hash transactions, %v", err)
+	}
+
+	assert.Equal(t, hash, signedHash)
+
+	// Make sure the transaction order is part of the hash
+	txs[0], txs[1] = txs[1], txs[0]
+
+	reorderedHash, err := HashTransactions(txs)
+	if err != nil {
+		t.Fatalf("unable to hash transactions, %v", err)
+	}
+
+	assert.NotEqual(t, hash, reorderedHash)
+}
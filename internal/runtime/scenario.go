@@ -0,0 +1,325 @@
+package runtime
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/gnolang/gno/pkgs/std"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTemplateWeight is the weight of the scenario templates that leave it out
+const DefaultTemplateWeight uint64 = 1
+
+var (
+	errEmptyScenario      = errors.New("scenario has no templates")
+	errMissingName        = errors.New("scenario template has no name")
+	errDuplicateTemplate  = errors.New("duplicate scenario template name")
+	errInvalidTemplatePkg = errors.New("scenario template realm path is not a realm")
+	errInvalidTemplateFn  = errors.New("scenario template function is not an exported identifier")
+	errInvalidRandomRange = errors.New("invalid random range")
+)
+
+// funcRegex matches the exported realm function names, callable by a transaction
+var funcRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+
+// Scenario is a custom workload, mixing weighted realm call templates
+type Scenario struct {
+	Name      string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Templates []*Template `json:"templates" yaml:"templates"`
+
+	hash string // the sha256 of the scenario file
+}
+
+// Template is a single realm call template of the scenario.
+// The argument templates can use the {{.Index}} (run transaction index),
+// {{.Counter}} (template transaction index), {{.Address}} (caller address),
+// {{.Random}} and {{.RandomRange min max}} (inclusive) placeholders
+type Template struct {
+	Name    string   `json:"name" yaml:"name"`                         // the template name, unique in the scenario
+	PkgPath string   `json:"pkgPath" yaml:"pkgPath"`                   // the called realm path
+	Func    string   `json:"func" yaml:"func"`                         // the called realm function
+	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`     // the call argument templates
+	Weight  uint64   `json:"weight,omitempty" yaml:"weight,omitempty"` // the relative weight, 1 if unset
+
+	args []*template.Template // the parsed argument templates
+}
+
+// LoadScenario loads and validates the scenario file at the given path.
+// YAML files (.yaml, .yml) are decoded as YAML, and any other file as JSON
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read scenario, %w", err)
+	}
+
+	s := &Scenario{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		err = decoder.Decode(s)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		err = decoder.Decode(s)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse scenario, %w", err)
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario, %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	s.hash = hex.EncodeToString(sum[:])
+
+	return s, nil
+}
+
+// Hash returns the sha256 of the scenario file, recorded for reproducibility
+func (s *Scenario) Hash() string {
+	return s.hash
+}
+
+// validate validates the scenario templates, and parses their arguments
+func (s *Scenario) validate() error {
+	if len(s.Templates) == 0 {
+		return errEmptyScenario
+	}
+
+	names := make(map[string]struct{}, len(s.Templates))
+
+	for index, t := range s.Templates {
+		if t == nil || t.Name == "" {
+			return fmt.Errorf("%w, template %d", errMissingName, index)
+		}
+
+		if _, exists := names[t.Name]; exists {
+			return fmt.Errorf("%w, %q", errDuplicateTemplate, t.Name)
+		}
+
+		names[t.Name] = struct{}{}
+
+		if !strings.HasPrefix(t.PkgPath, "gno.land/r/") {
+			return fmt.Errorf("%w, template %q path %q", errInvalidTemplatePkg, t.Name, t.PkgPath)
+		}
+
+		if !funcRegex.MatchString(t.Func) {
+			return fmt.Errorf("%w, template %q function %q", errInvalidTemplateFn, t.Name, t.Func)
+		}
+
+		if t.Weight == 0 {
+			t.Weight = DefaultTemplateWeight
+		}
+
+		if err := t.parseArgs(); err != nil {
+			return fmt.Errorf("template %q, %w", t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// parseArgs parses the argument templates, and dry runs them
+// so the invalid placeholders fail before the run starts
+func (t *Template) parseArgs() error {
+	t.args = make([]*template.Template, 0, len(t.Args))
+
+	probe := &templateData{
+		rng: rand.New(rand.NewSource(0)), //nolint:gosec // not used for security
+	}
+
+	for index, text := range t.Args {
+		tmpl, err := template.New(fmt.Sprintf("arg-%d", index)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("unable to parse argument %d, %w", index, err)
+		}
+
+		if err := tmpl.Execute(&strings.Builder{}, probe); err != nil {
+			return fmt.Errorf("unable to execute argument %d, %w", index, err)
+		}
+
+		t.args = append(t.args, tmpl)
+	}
+
+	return nil
+}
+
+// render renders the call arguments of the template
+func (t *Template) render(data *templateData) ([]string, error) {
+	args := make([]string, 0, len(t.args))
+
+	for index, tmpl := range t.args {
+		var b strings.Builder
+
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("unable to execute template %q argument %d, %w", t.Name, index, err)
+		}
+
+		args = append(args, b.String())
+	}
+
+	return args, nil
+}
+
+// templateData is the scenario argument template data
+type templateData struct {
+	Index   int    // the run transaction index
+	Counter int    // the number of previous transactions of the template
+	Address string // the caller address
+
+	rng *rand.Rand
+}
+
+// Random returns a random non-negative value
+func (d *templateData) Random() int64 {
+	return d.rng.Int63()
+}
+
+// RandomRange returns a random value in [low, high]
+func (d *templateData) RandomRange(low, high int64) (int64, error) {
+	if high < low {
+		return 0, fmt.Errorf("%w, [%d, %d]", errInvalidRandomRange, low, high)
+	}
+
+	return low + d.rng.Int63n(high-low+1), nil
+}
+
+type scenario struct {
+	signer Signer
+
+	scenario *Scenario
+	seed     uint64
+
+	construction construction
+	gas          txGas
+
+	counts []int // the constructed transactions of each template
+}
+
+func newScenario(
+	signer Signer,
+	s *Scenario,
+	seed uint64,
+	construction construction,
+	gas txGas,
+) *scenario {
+	return &scenario{
+		signer:       signer,
+		scenario:     s,
+		seed:         seed,
+		construction: construction,
+		gas:          gas,
+	}
+}
+
+func (s *scenario) Initialize(_ *gnoland.GnoAccount) ([]*std.Tx, error) {
+	// The scenario realms are expected to be deployed
+	return nil, nil
+}
+
+func (s *scenario) ConstructTransactions(
+	accounts []*gnoland.GnoAccount,
+	transactions uint64,
+) ([]*std.Tx, error) {
+	set, err := s.transactionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return set.construct(accounts, transactions)
+}
+
+// transactionSet creates the construction state of the run transaction set.
+// The template counts are reset for the set
+func (s *scenario) transactionSet() (*txSet, error) {
+	var (
+		seed = s.seed
+
+		totalWeight uint64
+	)
+
+	// The templates are picked in the transaction order,
+	// so the picked templates only depend on the seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
+	rng := rand.New(rand.NewSource(int64(seed))) //nolint:gosec // not used for security
+
+	for _, t := range s.scenario.Templates {
+		totalWeight += t.Weight
+	}
+
+	s.counts = make([]int, len(s.scenario.Templates))
+
+	getMsgFn := func(creator *gnoland.GnoAccount, index int) std.Msg {
+		var (
+			pick     = uint64(rng.Int63n(int64(totalWeight)))
+			selected = 0
+		)
+
+		for selected < len(s.scenario.Templates)-1 && pick >= s.scenario.Templates[selected].Weight {
+			pick -= s.scenario.Templates[selected].Weight
+			selected++
+		}
+
+		t := s.scenario.Templates[selected]
+
+		args, err := t.render(&templateData{
+			Index:   index,
+			Counter: s.counts[selected],
+			Address: creator.Address.String(),
+			rng:     rng,
+		})
+		if err != nil {
+			// Handled as a construction failure
+			panic(err)
+		}
+
+		s.counts[selected]++
+
+		return vm.MsgCall{
+			Caller:  creator.Address,
+			PkgPath: t.PkgPath,
+			Func:    t.Func,
+			Args:    args,
+		}
+	}
+
+	return newTxSet(s.signer, getMsgFn, newTxFee(s.gas.run, s.gas.runFee), s.construction), nil
+}
+
+func (s *scenario) SetRunFee(fee std.Fee) {
+	s.gas.run = fee.GasWanted
+	s.gas.runFee = fee.GasFee
+}
+
+func (s *scenario) TemplateCounts() map[string]int {
+	counts := make(map[string]int, len(s.counts))
+
+	for index, count := range s.counts {
+		counts[s.scenario.Templates[index].Name] = count
+	}
+
+	return counts
+}
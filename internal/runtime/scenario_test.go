@@ -0,0 +1,210 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/sdk/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScenario = `
+name: mixed
+templates:
+  - name: post
+    pkgPath: gno.land/r/demo/boards
+    func: CreatePost
+    args: ["post-{{.Counter}}", "{{.Address}}"]
+    weight: 3
+  - name: vote
+    pkgPath: gno.land/r/demo/boards
+    func: Vote
+    args: ["{{.RandomRange 1 5}}"]
+`
+
+// writeScenario writes the scenario file with the given name and content
+func writeScenario(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write scenario, %v", err)
+	}
+
+	return path
+}
+
+func TestScenario_LoadScenario(t *testing.T) {
+	t.Parallel()
+
+	t.Run("yaml scenario", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := LoadScenario(writeScenario(t, "scenario.yaml", testScenario))
+		require.NoError(t, err)
+
+		assert.Equal(t, "mixed", s.Name)
+		require.Len(t, s.Templates, 2)
+
+		assert.Equal(t, uint64(3), s.Templates[0].Weight)
+		assert.Equal(t, DefaultTemplateWeight, s.Templates[1].Weight)
+		assert.Len(t, s.Hash(), 64)
+	})
+
+	t.Run("json scenario", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := LoadScenario(writeScenario(
+			t,
+			"scenario.json",
+			`{"templates": [{"name": "hello", "pkgPath": "gno.land/r/demo/hello", "func": "SayHello"}]}`,
+		))
+		require.NoError(t, err)
+
+		require.Len(t, s.Templates, 1)
+		assert.Empty(t, s.Templates[0].Args)
+	})
+
+	testTable := []struct {
+		name     string
+		file     string
+		content  string
+		expected error
+	}{
+		{
+			"no templates",
+			"scenario.yaml",
+			"name: empty\n",
+			errEmptyScenario,
+		},
+		{
+			"duplicate template",
+			"scenario.yaml",
+			`
+templates:
+  - {name: call, pkgPath: gno.land/r/demo/a, func: A}
+  - {name: call, pkgPath: gno.land/r/demo/b, func: B}
+`,
+			errDuplicateTemplate,
+		},
+		{
+			"package path",
+			"scenario.yaml",
+			"templates: [{name: call, pkgPath: gno.land/p/demo/avl, func: Get}]\n",
+			errInvalidTemplatePkg,
+		},
+		{
+			"unexported function",
+			"scenario.yaml",
+			"templates: [{name: call, pkgPath: gno.land/r/demo/a, func: render}]\n",
+			errInvalidTemplateFn,
+		},
+		{
+			"reversed random range",
+			"scenario.json",
+			`{"templates": [{"name": "call", "pkgPath": "gno.land/r/demo/a", "func": "A", "args": ["{{.RandomRange 5 1}}"]}]}`,
+			errInvalidRandomRange,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := LoadScenario(writeScenario(t, testCase.file, testCase.content))
+
+			assert.ErrorIs(t, err, testCase.expected)
+		})
+	}
+
+	t.Run("unknown placeholder", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadScenario(writeScenario(
+			t,
+			"scenario.yaml",
+			"templates: [{name: call, pkgPath: gno.land/r/demo/a, func: A, args: ['{{.Missing}}']}]\n",
+		))
+
+		assert.ErrorContains(t, err, "unable to execute argument 0")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadScenario(writeScenario(
+			t,
+			"scenario.yaml",
+			"templates: [{name: call, pkgPath: gno.land/r/demo/a, func: A, gas: 10}]\n",
+		))
+
+		assert.ErrorContains(t, err, "unable to parse scenario")
+	})
+}
+
+func TestScenario_ConstructTransactions(t *testing.T) {
+	t.Parallel()
+
+	s, err := LoadScenario(writeScenario(t, "scenario.yaml", testScenario))
+	require.NoError(t, err)
+
+	var (
+		accounts     = generateAccounts(2)
+		transactions = uint64(400)
+	)
+
+	r := GetRuntime(CustomScenario, &mockSigner{}, WithScenario(s), WithSeed(42))
+
+	txs, err := r.ConstructTransactions(accounts, transactions)
+	require.NoError(t, err)
+	require.Len(t, txs, int(transactions))
+
+	posts := 0
+
+	for index, tx := range txs {
+		msg, ok := tx.Msgs[0].(vm.MsgCall)
+		require.True(t, ok)
+
+		assert.Equal(t, "gno.land/r/demo/boards", msg.PkgPath)
+		assert.Equal(t, accounts[index%len(accounts)].Address, msg.Caller)
+
+		switch msg.Func {
+		case "CreatePost":
+			// The counter is the template transaction index
+			assert.Equal(t, []string{"post-" + strconv.Itoa(posts), msg.Caller.String()}, msg.Args)
+
+			posts++
+		case "Vote":
+			require.Len(t, msg.Args, 1)
+
+			vote, err := strconv.Atoi(msg.Args[0])
+			require.NoError(t, err)
+
+			assert.GreaterOrEqual(t, vote, 1)
+			assert.LessOrEqual(t, vote, 5)
+		default:
+			t.Fatalf("unexpected function %q", msg.Func)
+		}
+	}
+
+	counts := r.(Mixer).TemplateCounts()
+
+	assert.Equal(t, posts, counts["post"])
+	assert.Equal(t, int(transactions), counts["post"]+counts["vote"])
+
+	// The posts are weighted 3:1 against the votes
+	assert.InDelta(t, 300, counts["post"], 40)
+
+	// The picked templates and values only depend on the seed
+	again, err := GetRuntime(CustomScenario, &mockSigner{}, WithScenario(s), WithSeed(42)).
+		ConstructTransactions(accounts, transactions)
+	require.NoError(t, err)
+
+	assert.Equal(t, txs, again)
+}
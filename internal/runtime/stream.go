@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"errors"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+var errStreamUnsupported = errors.New("runtime does not support streamed construction")
+
+// setConstructor is implemented by the runtimes whose transaction set
+// can be constructed in consecutive chunks
+type setConstructor interface {
+	// transactionSet creates the construction state of the run transaction set
+	transactionSet() (*txSet, error)
+}
+
+// Stream constructs the run transactions on the fly, in consecutive chunks,
+// for runs whose transaction count is not known up front (duration runs).
+// The chunks continue the transaction indices, the account sequences and
+// the message generation of the previous chunks, so the streamed transactions
+// are the same as a single construction of the same count
+type Stream struct {
+	set      *txSet
+	accounts []*gnoland.GnoAccount
+}
+
+// NewStream creates the transaction stream of the runtime, signed by the given accounts
+func NewStream(r Runtime, accounts []*gnoland.GnoAccount) (*Stream, error) {
+	constructor, ok := r.(setConstructor)
+	if !ok {
+		return nil, errStreamUnsupported
+	}
+
+	set, err := constructor.transactionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	// The chunks are constructed between the broadcasts
+	set.quiet = true
+
+	return &Stream{
+		set:      set,
+		accounts: accounts,
+	}, nil
+}
+
+// Next constructs and signs the next transactions of the stream
+func (s *Stream) Next(transactions uint64) ([]*std.Tx, error) {
+	return s.set.construct(s.accounts, transactions)
+}
+
+// Constructed returns the number of transactions constructed by the stream so far,
+// including the transactions that failed to be constructed
+func (s *Stream) Constructed() int {
+	return s.set.next
+}
+
+// Nonce returns the nonce the next transaction of the account is signed with
+func (s *Stream) Nonce(account *gnoland.GnoAccount) uint64 {
+	if nonce, ok := s.set.nonceMap[account.AccountNumber]; ok {
+		return nonce
+	}
+
+	return account.Sequence
+}
+
+// Resync continues the account transactions from the given sequence,
+// so the next transactions of the account are signed with it
+func (s *Stream) Resync(account *gnoland.GnoAccount, sequence uint64) {
+	s.set.nonceMap[account.AccountNumber] = sequence
+}
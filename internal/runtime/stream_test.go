@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_Next(t *testing.T) {
+	t.Parallel()
+	moveToRoot(t)
+
+	var (
+		transactions = uint64(10)
+		accounts     = generateAccounts(3)
+	)
+
+	single, err := GetRuntime(PackageDeployment, &mockSigner{}, WithSeed(42)).
+		ConstructTransactions(accounts, transactions)
+	require.NoError(t, err)
+
+	var (
+		nonces = make(map[uint64][]uint64)
+		signer = &mockSigner{
+			signTxFn: func(_ *std.Tx, account *gnoland.GnoAccount, nonce uint64, _ string) error {
+				nonces[account.AccountNumber] = append(nonces[account.AccountNumber], nonce)
+
+				return nil
+			},
+		}
+	)
+
+	stream, err := NewStream(GetRuntime(PackageDeployment, signer, WithSeed(42)), accounts)
+	require.NoError(t, err)
+
+	// Construct the same count in uneven chunks
+	streamed := make([]*std.Tx, 0, transactions)
+
+	for _, chunk := range []uint64{4, 1, 5} {
+		txs, err := stream.Next(chunk)
+		require.NoError(t, err)
+
+		streamed = append(streamed, txs...)
+	}
+
+	assert.Equal(t, int(transactions), stream.Constructed())
+
+	// Make sure the chunks continue the transaction indices
+	singleHash, err := HashTransactions(single)
+	require.NoError(t, err)
+
+	streamedHash, err := HashTransactions(streamed)
+	require.NoError(t, err)
+
+	assert.Equal(t, singleHash, streamedHash)
+
+	// Make sure the chunks continue the account sequences
+	assert.Equal(t, []uint64{0, 1, 2, 3}, nonces[0])
+	assert.Equal(t, []uint64{0, 1, 2}, nonces[1])
+	assert.Equal(t, []uint64{0, 1, 2}, nonces[2])
+}
+
+func TestStream_Resync(t *testing.T) {
+	t.Parallel()
+	moveToRoot(t)
+
+	var (
+		accounts = generateAccounts(2)
+		nonces   = make(map[uint64][]uint64)
+		signer   = &mockSigner{
+			signTxFn: func(_ *std.Tx, account *gnoland.GnoAccount, nonce uint64, _ string) error {
+				nonces[account.AccountNumber] = append(nonces[account.AccountNumber], nonce)
+
+				return nil
+			},
+		}
+	)
+
+	stream, err := NewStream(GetRuntime(PackageDeployment, signer, WithSeed(42)), accounts)
+	require.NoError(t, err)
+
+	// Unsigned accounts continue from their sequence
+	assert.Equal(t, accounts[0].Sequence, stream.Nonce(accounts[0]))
+
+	_, err = stream.Next(6)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3), stream.Nonce(accounts[0]))
+
+	// Continue the first account from an earlier sequence
+	stream.Resync(accounts[0], 1)
+
+	assert.Equal(t, uint64(1), stream.Nonce(accounts[0]))
+	assert.Equal(t, uint64(3), stream.Nonce(accounts[1]))
+
+	_, err = stream.Next(4)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint64{0, 1, 2, 1, 2}, nonces[0])
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4}, nonces[1])
+}
@@ -6,13 +6,21 @@ const (
 	RealmDeployment   Type = "REALM_DEPLOYMENT"
 	PackageDeployment Type = "PACKAGE_DEPLOYMENT"
 	RealmCall         Type = "REALM_CALL"
+	Mint              Type = "MINT"
+	CustomScenario    Type = "SCENARIO"
 	unknown           Type = "UNKNOWN"
+
+	// ProbeSize probes the maximum transaction size,
+	// instead of running the stress test
+	ProbeSize Type = "PROBE_SIZE"
 )
 
 // IsRuntime checks if the passed in runtime
 // is a supported runtime type
 func IsRuntime(runtime Type) bool {
 	return runtime == RealmCall ||
+		runtime == Mint ||
+		runtime == CustomScenario ||
 		runtime == RealmDeployment ||
 		runtime == PackageDeployment
 }
@@ -27,7 +35,19 @@ func (r Type) String() string {
 		return string(PackageDeployment)
 	case RealmCall:
 		return string(RealmCall)
+	case Mint:
+		return string(Mint)
+	case CustomScenario:
+		return string(CustomScenario)
+	case ProbeSize:
+		return string(ProbeSize)
 	default:
 		return string(unknown)
 	}
 }
+
+// Predeploys checks if the passed in runtime
+// deploys its target realm before the run
+func Predeploys(runtime Type) bool {
+	return runtime == RealmCall || runtime == Mint
+}
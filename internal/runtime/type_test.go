@@ -29,6 +29,21 @@ func TestType_IsRuntime(t *testing.T) {
 			RealmCall,
 			true,
 		},
+		{
+			"Mint",
+			Mint,
+			true,
+		},
+		{
+			"Scenario",
+			CustomScenario,
+			true,
+		},
+		{
+			"Size Probe",
+			ProbeSize,
+			false,
+		},
 		{
 			"Dummy mode",
 			Type("Dummy mode"),
@@ -70,6 +85,21 @@ func TestType_String(t *testing.T) {
 			RealmCall,
 			string(RealmCall),
 		},
+		{
+			"Mint",
+			Mint,
+			string(Mint),
+		},
+		{
+			"Scenario",
+			CustomScenario,
+			string(CustomScenario),
+		},
+		{
+			"Size Probe",
+			ProbeSize,
+			string(ProbeSize),
+		},
 		{
 			"Dummy mode",
 			Type("Dummy mode"),
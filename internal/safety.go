@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+)
+
+// DefaultProductionChains are the production chain IDs guarded by default (gno.land mainnet)
+const DefaultProductionChains = "gnoland1"
+
+// productionSpendCap is the hard cap (ugnot) on the estimated spend of production chain runs.
+// It can't be lifted by any flag, so a benchmark mnemonic with real funds can't be drained
+const productionSpendCap = 10 * ugnotPerGNOT
+
+var errProductionChain = errors.New("refusing to run against a production chain")
+
+// parseChainIDs parses the comma separated chain IDs
+func parseChainIDs(raw string) map[string]struct{} {
+	chains := make(map[string]struct{})
+
+	for _, chainID := range strings.Split(raw, ",") {
+		if chainID = strings.TrimSpace(chainID); chainID != "" {
+			chains[chainID] = struct{}{}
+		}
+	}
+
+	return chains
+}
+
+// checkProduction discovers the chain ID of the node, and refuses runs against
+// production chains, before anything is signed. Production runs need to be explicitly
+// confirmed, and are refused past the hard spend cap regardless of the confirmation
+func (p *Pipeline) checkProduction() error {
+	status, err := p.cli.GetStatus()
+	if err != nil {
+		return fmt.Errorf("unable to discover the node chain ID, %w", err)
+	}
+
+	p.network = status.NodeInfo.Network
+
+	return productionRefusal(
+		p.cfg.chains,
+		p.cfg.ChainID,
+		p.network,
+		p.cfg.ConfirmedProduction,
+		p.estimatedSpend(),
+	)
+}
+
+// checkProductionSpend checks the production spend cap again, once
+// the run gas is estimated, since the estimate reprices the run transactions
+func (p *Pipeline) checkProductionSpend() error {
+	return productionRefusal(
+		p.cfg.chains,
+		p.cfg.ChainID,
+		p.network,
+		p.cfg.ConfirmedProduction,
+		p.estimatedSpend(),
+	)
+}
+
+// productionRefusal returns the refusal of the run, naming the triggered rule, if any.
+// Both the configured and the discovered chain IDs are checked, since the node
+// behind the URL is the one the funds are spent on
+func productionRefusal(
+	chains map[string]struct{},
+	configured, discovered string,
+	confirmed bool,
+	spend int64,
+) error {
+	var chainID, source string
+
+	if _, ok := chains[discovered]; ok {
+		chainID, source = discovered, "discovered from the node status"
+	} else if _, ok := chains[configured]; ok {
+		chainID, source = configured, "set with -chain-id"
+	}
+
+	if chainID == "" {
+		return nil
+	}
+
+	if !confirmed {
+		return fmt.Errorf(
+			"%w: chain %s (%s) is listed in -production-chains, "+
+				"and the run was not confirmed with -i-know-this-is-production",
+			errProductionChain,
+			chainID,
+			source,
+		)
+	}
+
+	if spend > productionSpendCap {
+		return fmt.Errorf(
+			"%w: the run spends an estimated %d%s on production chain %s (%s), "+
+				"above the hard production spend cap of %d%s, which no flag lifts",
+			errProductionChain,
+			spend,
+			common.Denomination,
+			chainID,
+			source,
+			int64(productionSpendCap),
+			common.Denomination,
+		)
+	}
+
+	fmt.Printf(
+		"⚠️ Running against production chain %s (%s), with an estimated spend of %d%s\n",
+		chainID,
+		source,
+		spend,
+		common.Denomination,
+	)
+
+	return nil
+}
+
+// estimatedSpend returns the estimated total spend (ugnot) of the run, using the same
+// cost model inputs as the distribution: the gas price, the estimated gas, and the funding plan
+func (p *Pipeline) estimatedSpend() int64 {
+	params := p.runCosts()
+
+	params.Transactions = p.cfg.Transactions
+	params.SubAccounts = p.cfg.SubAccounts
+
+	// The duration run sub-accounts are funded incrementally, for their share of the run
+	if p.cfg.Duration > 0 {
+		_, params.Transactions = p.durationFunding(int(p.cfg.SubAccounts))
+	}
+	params.FundingBatch = int(p.cfg.FundingBatch)
+
+	// The funding plan transfers replace the sub-account funding
+	if len(p.cfg.plan) == 0 {
+		return distributor.EstimateCosts(params).Total.AmountOf(common.Denomination)
+	}
+
+	params.SubAccounts = uint64(len(p.cfg.plan))
+
+	estimate := distributor.EstimateCosts(params)
+
+	return estimate.Total.AmountOf(common.Denomination) -
+		estimate.AccountsCost.AmountOf(common.Denomination) +
+		p.cfg.plan.Total().Amount
+}
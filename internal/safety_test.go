@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/estimator"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionRefusal(t *testing.T) {
+	t.Parallel()
+
+	chains := parseChainIDs(" gnoland1, ,staging ")
+
+	testTable := []struct {
+		name       string
+		configured string
+		discovered string
+		confirmed  bool
+		spend      int64
+		refusal    string
+	}{
+		{
+			"non-production chain",
+			"dev",
+			"dev",
+			false,
+			productionSpendCap * 10,
+			"",
+		},
+		{
+			"discovered production chain",
+			"dev",
+			"gnoland1",
+			false,
+			1,
+			"chain gnoland1 (discovered from the node status) is listed in -production-chains",
+		},
+		{
+			"configured production chain",
+			"staging",
+			"",
+			false,
+			1,
+			"chain staging (set with -chain-id) is listed in -production-chains",
+		},
+		{
+			"confirmed production run",
+			"gnoland1",
+			"gnoland1",
+			true,
+			productionSpendCap,
+			"",
+		},
+		{
+			"confirmed production run above the spend cap",
+			"gnoland1",
+			"gnoland1",
+			true,
+			productionSpendCap + 1,
+			"above the hard production spend cap of 10000000ugnot",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := productionRefusal(
+				chains,
+				testCase.configured,
+				testCase.discovered,
+				testCase.confirmed,
+				testCase.spend,
+			)
+
+			if testCase.refusal == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.True(t, errors.Is(err, errProductionChain))
+			assert.ErrorContains(t, err, testCase.refusal)
+		})
+	}
+}
+
+func TestPipeline_EstimatedSpend(t *testing.T) {
+	t.Parallel()
+
+	// newProductionPipeline creates a confirmed production run pipeline
+	newProductionPipeline := func(t *testing.T, gasPrice string) *Pipeline {
+		t.Helper()
+
+		cfg := testConfig(t, "http://127.0.0.1:26657")
+		cfg.ChainID = DefaultProductionChains
+		cfg.ConfirmedProduction = true
+		cfg.GasPrice = gasPrice
+		cfg.chains = parseChainIDs(DefaultProductionChains)
+
+		require.NoError(t, cfg.validateGas())
+
+		return &Pipeline{
+			cfg:     cfg,
+			network: DefaultProductionChains,
+		}
+	}
+
+	t.Run("fixed gas fee", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProductionPipeline(t, "")
+
+		assert.NoError(t, p.checkProductionSpend())
+	})
+
+	t.Run("high gas price", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProductionPipeline(t, "10ugnot/1gas")
+
+		// Make sure the run is priced by the gas price, not the fixed fee
+		gas, _ := p.gasWanted(runtime.Type(p.cfg.Mode))
+		assert.GreaterOrEqual(t, p.estimatedSpend(), int64(p.cfg.Transactions)*gas)
+
+		err := p.checkProductionSpend()
+
+		assert.ErrorIs(t, err, errProductionChain)
+		assert.ErrorContains(t, err, "above the hard production spend cap")
+	})
+
+	t.Run("estimated gas", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProductionPipeline(t, "1ugnot/1000gas")
+
+		require.NoError(t, p.checkProductionSpend())
+
+		// The simulated gas reprices the run transactions
+		p.gasEstimate = &estimator.Estimate{GasWanted: productionSpendCap * 1000}
+
+		assert.ErrorIs(t, p.checkProductionSpend(), errProductionChain)
+	})
+
+	t.Run("funding plan", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProductionPipeline(t, "")
+
+		p.cfg.plan = distributor.FundingPlan{
+			{Amount: std.NewCoin(common.Denomination, productionSpendCap)},
+		}
+
+		// The planned transfers replace the sub-account funding, and are paid on top of the fees
+		assert.Greater(t, p.estimatedSpend(), int64(productionSpendCap))
+		assert.ErrorIs(t, p.checkProductionSpend(), errProductionChain)
+	})
+}
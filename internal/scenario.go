@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/gnolang/supernova/internal/preflight"
+	"github.com/gnolang/supernova/internal/runtime"
+)
+
+// checkScenario checks the scenario templates against the realms deployed on the node,
+// so a typo in a realm path or function fails the run before the accounts are funded
+func (p *Pipeline) checkScenario() error {
+	calls := make([]preflight.RealmCall, 0, len(p.cfg.scenario.Templates))
+
+	for _, t := range p.cfg.scenario.Templates {
+		calls = append(calls, preflight.RealmCall{
+			PkgPath: t.PkgPath,
+			Func:    t.Func,
+			Args:    len(t.Args),
+		})
+	}
+
+	if err := preflight.CheckCalls(p.cli, calls); err != nil {
+		return fmt.Errorf("invalid scenario, %w", err)
+	}
+
+	fmt.Printf("✅ Scenario templates match the deployed realms (%d templates)\n", len(calls))
+
+	return nil
+}
+
+// newScenarioResult summarizes the constructed transactions of each scenario template
+func newScenarioResult(scenario *runtime.Scenario, counts map[string]int) *collector.ScenarioResult {
+	result := &collector.ScenarioResult{
+		Name:      scenario.Name,
+		Hash:      scenario.Hash(),
+		Templates: make([]*collector.ScenarioTemplateResult, 0, len(scenario.Templates)),
+	}
+
+	for _, t := range scenario.Templates {
+		result.Templates = append(result.Templates, &collector.ScenarioTemplateResult{
+			Name:         t.Name,
+			PkgPath:      t.PkgPath,
+			Func:         t.Func,
+			Weight:       t.Weight,
+			Transactions: counts[t.Name],
+		})
+	}
+
+	return result
+}
+
+// scenarioHash returns the hash of the scenario file, if any
+func (p *Pipeline) scenarioHash() string {
+	if p.cfg.scenario == nil {
+		return ""
+	}
+
+	return p.cfg.scenario.Hash()
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+// ShardIndexEnv is the environment variable the shard index is read from, if
+// not explicitly set. It is the completion index of indexed Kubernetes Job pods
+const ShardIndexEnv = "JOB_COMPLETION_INDEX"
+
+var (
+	errInvalidShardIndex = errors.New("invalid shard index specified")
+	errShardTooSmall     = errors.New("each shard needs at least one sub-account and transaction")
+)
+
+// applyShard narrows the run to the slice of the shard. The sub-accounts and transactions
+// of the configuration are the totals of all shards, and are split evenly between them,
+// so shards sharing the mnemonic and configuration never use the same sub-accounts.
+// A shard count of 0 is the same as 1, an unsharded run
+func (cfg *Config) applyShard() error {
+	count := cfg.ShardCount
+	if count == 0 {
+		count = 1
+	}
+
+	if cfg.ShardIndex >= count {
+		return fmt.Errorf("%w, %d is not in [0, %d)", errInvalidShardIndex, cfg.ShardIndex, count)
+	}
+
+	// Unsharded runs, and already applied shards, are left as is
+	if count == 1 || cfg.shard != nil {
+		return nil
+	}
+
+	if cfg.SubAccounts < cfg.ShardCount || cfg.Transactions < cfg.ShardCount {
+		return errShardTooSmall
+	}
+
+	accountOffset, subAccounts := shardSlice(cfg.SubAccounts, cfg.ShardCount, cfg.ShardIndex)
+	_, transactions := shardSlice(cfg.Transactions, cfg.ShardCount, cfg.ShardIndex)
+
+	cfg.shard = &collector.ShardResult{
+		Index:            cfg.ShardIndex,
+		Count:            cfg.ShardCount,
+		SubAccountOffset: cfg.SubAccountOffset + accountOffset,
+		SubAccounts:      subAccounts,
+		Transactions:     transactions,
+		TotalAccounts:    cfg.SubAccounts,
+		TotalTxs:         cfg.Transactions,
+	}
+
+	cfg.SubAccountOffset = cfg.shard.SubAccountOffset
+	cfg.SubAccounts = subAccounts
+	cfg.Transactions = transactions
+
+	return nil
+}
+
+// shardSlice returns the offset and size of the shard slice of the total.
+// The remainder of the split goes to the first shards, one each
+func shardSlice(total, count, index uint64) (uint64, uint64) {
+	var (
+		size      = total / count
+		remainder = total % count
+	)
+
+	if index < remainder {
+		return index * (size + 1), size + 1
+	}
+
+	return remainder*(size+1) + (index-remainder)*size, size
+}
+
+// shardRunID suffixes the run ID with the shard identifiers, if the run is sharded
+func shardRunID(runID string, shard *collector.ShardResult) string {
+	if shard == nil {
+		return runID
+	}
+
+	return fmt.Sprintf("%s-shard-%d-of-%d", runID, shard.Index, shard.Count)
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ApplyShard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shards split the totals", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			count        = uint64(3)
+			nextOffset   = uint64(5)
+			transactions = uint64(0)
+		)
+
+		for index := uint64(0); index < count; index++ {
+			cfg := &Config{
+				SubAccounts:      10,
+				SubAccountOffset: 5,
+				Transactions:     100,
+				ShardIndex:       index,
+				ShardCount:       count,
+			}
+
+			if err := cfg.applyShard(); err != nil {
+				t.Fatalf("unable to apply shard %d, %v", index, err)
+			}
+
+			// The shard sub-account ranges are contiguous, and never overlap
+			assert.Equal(t, nextOffset, cfg.SubAccountOffset)
+
+			nextOffset += cfg.SubAccounts
+			transactions += cfg.Transactions
+
+			assert.Equal(t, index, cfg.shard.Index)
+			assert.Equal(t, uint64(10), cfg.shard.TotalAccounts)
+			assert.Equal(t, fmt.Sprintf("run-shard-%d-of-3", index), shardRunID("run", cfg.shard))
+
+			// Applying the shard again has no effect
+			if err := cfg.applyShard(); err != nil {
+				t.Fatalf("unable to reapply shard %d, %v", index, err)
+			}
+
+			assert.Equal(t, cfg.shard.SubAccountOffset, cfg.SubAccountOffset)
+		}
+
+		assert.Equal(t, uint64(15), nextOffset)
+		assert.Equal(t, uint64(100), transactions)
+	})
+
+	t.Run("remainder goes to the first shards", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			index          uint64
+			expectedOffset uint64
+			expectedSize   uint64
+		}{
+			{0, 0, 4},
+			{1, 4, 3},
+			{2, 7, 3},
+		}
+
+		for _, testCase := range testTable {
+			offset, size := shardSlice(10, 3, testCase.index)
+
+			assert.Equal(t, testCase.expectedOffset, offset)
+			assert.Equal(t, testCase.expectedSize, size)
+		}
+	})
+
+	t.Run("unsharded run", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			SubAccounts:      10,
+			SubAccountOffset: 1,
+			Transactions:     100,
+			ShardCount:       1,
+		}
+
+		if err := cfg.applyShard(); err != nil {
+			t.Fatalf("unable to apply shard, %v", err)
+		}
+
+		assert.Nil(t, cfg.shard)
+		assert.Equal(t, uint64(10), cfg.SubAccounts)
+		assert.Equal(t, "run", shardRunID("run", cfg.shard))
+	})
+
+	t.Run("invalid shards", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name        string
+			cfg         *Config
+			expectedErr error
+		}{
+			{
+				"index of an unsharded run",
+				&Config{SubAccounts: 10, Transactions: 10, ShardIndex: 1},
+				errInvalidShardIndex,
+			},
+			{
+				"index out of range",
+				&Config{SubAccounts: 10, Transactions: 10, ShardIndex: 3, ShardCount: 3},
+				errInvalidShardIndex,
+			},
+			{
+				"fewer sub-accounts than shards",
+				&Config{SubAccounts: 2, Transactions: 10, ShardCount: 3},
+				errShardTooSmall,
+			},
+		}
+
+		for _, testCase := range testTable {
+			assert.ErrorIs(t, testCase.cfg.applyShard(), testCase.expectedErr, testCase.name)
+		}
+	})
+}
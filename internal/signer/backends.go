@@ -0,0 +1,86 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+var errNotEnoughKeys = errors.New("not enough keybase keys for the signer range")
+
+// keybaseBackend signs with the local keys of an on-disk keybase.
+// The keys, sorted by name, are assigned to the range indices in order
+type keybaseBackend struct {
+	name   string
+	from   uint32
+	keys   []keys.Info
+	signer *KeybaseSigner
+}
+
+// newKeybaseBackend creates the keybase backend of the signer range
+func newKeybaseBackend(cfg RangeConfig, chainID string) (*keybaseBackend, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open keybase, %w", err)
+	}
+
+	infos, err := kb.List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list keybase keys, %w", err)
+	}
+
+	// Only the local keys can sign
+	local := make([]keys.Info, 0, len(infos))
+
+	for _, info := range infos {
+		if info.GetType() == keys.TypeLocal {
+			local = append(local, info)
+		}
+	}
+
+	sort.Slice(local, func(i, j int) bool {
+		return local[i].GetName() < local[j].GetName()
+	})
+
+	if size := int(cfg.To-cfg.From) + 1; len(local) < size {
+		return nil, fmt.Errorf("%w, %d keys for %d accounts", errNotEnoughKeys, len(local), size)
+	}
+
+	return &keybaseBackend{
+		name: cfg.name(),
+		from: cfg.From,
+		keys: local,
+		// The externally managed signatures are always verified locally
+		signer: NewKeybaseSigner(kb, chainID, WithPassphrase(cfg.Passphrase), WithVerification()),
+	}, nil
+}
+
+// Name returns the backend name
+func (b *keybaseBackend) Name() string {
+	return b.name
+}
+
+// PubKey returns the public key of the keybase key assigned to the derivation index
+func (b *keybaseBackend) PubKey(index uint32) (crypto.PubKey, error) {
+	position := int(index - b.from)
+	if index < b.from || position >= len(b.keys) {
+		return nil, fmt.Errorf("no keybase key for index %d", index)
+	}
+
+	return b.keys[position].GetPubKey(), nil
+}
+
+// SignTx signs the transaction with the keybase key of the account
+func (b *keybaseBackend) SignTx(
+	tx *std.Tx,
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	passphrase string,
+) error {
+	return b.signer.SignTx(tx, account, nonce, passphrase)
+}
@@ -0,0 +1,219 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+)
+
+// The signer backend types
+const (
+	TypeHD      = "hd"      // the accounts derived from the run mnemonic
+	TypeKeybase = "keybase" // the keys of an on-disk keybase
+	TypeHTTP    = "http"    // the keys of an external wallet service
+)
+
+// defaultHTTPTimeout is the default timeout of the external signing requests
+const defaultHTTPTimeout = 10 * time.Second
+
+var (
+	errInvalidRange   = errors.New("invalid signer range")
+	errOverlap        = errors.New("overlapping signer ranges")
+	errUnknownType    = errors.New("unknown signer type")
+	errMissingKeybase = errors.New("keybase signer has no keybase directory")
+	errInvalidURL     = errors.New("http signer has an invalid URL")
+)
+
+// RangeConfig is the signer of a range of account derivation indices
+type RangeConfig struct {
+	From uint32 `json:"from"`           // the first derivation index of the range
+	To   uint32 `json:"to"`             // the last derivation index of the range, inclusive
+	Type string `json:"type"`           // the signer type (hd, keybase or http)
+	Name string `json:"name,omitempty"` // the backend name, for the per-backend stats, defaults to the type
+
+	Dir        string `json:"dir,omitempty"`        // the keybase directory (keybase)
+	Passphrase string `json:"passphrase,omitempty"` // the keybase key passphrase (keybase)
+
+	URL     string `json:"url,omitempty"`     // the wallet service base URL (http)
+	Timeout string `json:"timeout,omitempty"` // the signing request timeout (http), defaults to 10s
+}
+
+// Config is the account signer configuration, as non-overlapping index ranges.
+// The accounts outside every range are derived from the run mnemonic
+type Config []RangeConfig
+
+// LoadConfig loads the signer configuration JSON file at the given path
+func LoadConfig(path string) (Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open signers file, %w", err)
+	}
+
+	defer file.Close()
+
+	return ParseConfig(file)
+}
+
+// ParseConfig parses the signer configuration JSON, an array of signer ranges.
+// Unknown fields fail the parse
+func ParseConfig(r io.Reader) (Config, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var cfg Config
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode signers, %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate makes sure the signer ranges are valid, and do not overlap
+func (c Config) Validate() error {
+	ranges := make([]RangeConfig, len(c))
+	copy(ranges, c)
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].From < ranges[j].From
+	})
+
+	for index, rangeCfg := range ranges {
+		if err := rangeCfg.validate(); err != nil {
+			return err
+		}
+
+		if index > 0 && rangeCfg.From <= ranges[index-1].To {
+			return fmt.Errorf(
+				"%w, [%d, %d] and [%d, %d]",
+				errOverlap,
+				ranges[index-1].From,
+				ranges[index-1].To,
+				rangeCfg.From,
+				rangeCfg.To,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validate makes sure the signer range is valid
+func (r RangeConfig) validate() error {
+	if r.From > r.To {
+		return fmt.Errorf("%w, [%d, %d]", errInvalidRange, r.From, r.To)
+	}
+
+	switch r.Type {
+	case TypeHD:
+	case TypeKeybase:
+		if r.Dir == "" {
+			return errMissingKeybase
+		}
+	case TypeHTTP:
+		parsed, err := url.Parse(r.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w, %q", errInvalidURL, r.URL)
+		}
+
+		if _, err := r.timeout(); err != nil {
+			return fmt.Errorf("%w [%d, %d], invalid timeout %q", errInvalidRange, r.From, r.To, r.Timeout)
+		}
+	default:
+		return fmt.Errorf("%w %q, expected one of: %s, %s, %s", errUnknownType, r.Type, TypeHD, TypeKeybase, TypeHTTP)
+	}
+
+	return nil
+}
+
+// name returns the backend name of the range
+func (r RangeConfig) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+
+	return r.Type
+}
+
+// timeout returns the signing request timeout of the range
+func (r RangeConfig) timeout() (time.Duration, error) {
+	if r.Timeout == "" {
+		return defaultHTTPTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(r.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q", r.Timeout)
+	}
+
+	return timeout, nil
+}
+
+// Backends creates the backends of the signer ranges. The ranges of
+// mnemonic derived (hd) accounts have no backend, and are left out
+func (c Config) Backends(chainID string) (*Backends, error) {
+	backends := &Backends{}
+
+	for _, rangeCfg := range c {
+		var (
+			backend Backend
+			err     error
+		)
+
+		switch rangeCfg.Type {
+		case TypeKeybase:
+			backend, err = newKeybaseBackend(rangeCfg, chainID)
+		case TypeHTTP:
+			backend, err = newHTTPBackend(rangeCfg, chainID)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to create %s signer [%d, %d], %w", rangeCfg.name(), rangeCfg.From, rangeCfg.To, err)
+		}
+
+		backends.ranges = append(backends.ranges, backendRange{
+			from:    rangeCfg.From,
+			to:      rangeCfg.To,
+			backend: backend,
+		})
+	}
+
+	return backends, nil
+}
+
+// backendRange is the backend of a range of account derivation indices
+type backendRange struct {
+	from, to uint32
+	backend  Backend
+}
+
+// Backends are the signer backends, by account derivation index range
+type Backends struct {
+	ranges []backendRange
+}
+
+// For returns the backend of the account at the derivation index,
+// if it is not derived from the run mnemonic
+func (b *Backends) For(index uint32) (Backend, bool) {
+	if b == nil {
+		return nil, false
+	}
+
+	for _, r := range b.ranges {
+		if index >= r.from && index <= r.to {
+			return r.backend, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,113 @@
+package signer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name        string
+		config      string
+		expectedErr error
+	}{
+		{
+			"valid ranges",
+			`[
+				{"from": 0, "to": 9, "type": "hd"},
+				{"from": 10, "to": 19, "type": "keybase", "dir": "/tmp/keys"},
+				{"from": 20, "to": 29, "type": "http", "url": "https://wallet.local", "timeout": "5s"}
+			]`,
+			nil,
+		},
+		{
+			"overlapping ranges",
+			`[
+				{"from": 10, "to": 19, "type": "hd"},
+				{"from": 0, "to": 10, "type": "hd"}
+			]`,
+			errOverlap,
+		},
+		{
+			"inverted range",
+			`[{"from": 10, "to": 0, "type": "hd"}]`,
+			errInvalidRange,
+		},
+		{
+			"unknown type",
+			`[{"from": 0, "to": 9, "type": "ledger"}]`,
+			errUnknownType,
+		},
+		{
+			"keybase without directory",
+			`[{"from": 0, "to": 9, "type": "keybase"}]`,
+			errMissingKeybase,
+		},
+		{
+			"http without scheme",
+			`[{"from": 0, "to": 9, "type": "http", "url": "wallet.local"}]`,
+			errInvalidURL,
+		},
+		{
+			"http with invalid timeout",
+			`[{"from": 0, "to": 9, "type": "http", "url": "http://wallet.local", "timeout": "-1s"}]`,
+			errInvalidRange,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseConfig(strings.NewReader(testCase.config))
+
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseConfig(strings.NewReader(`[{"from": 0, "to": 9, "type": "hd", "mnemonic": "x"}]`))
+
+		assert.ErrorContains(t, err, "unknown field")
+	})
+}
+
+func TestConfig_Backends(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		{From: 0, To: 9, Type: TypeHD},
+		{From: 10, To: 19, Type: TypeHTTP, Name: "wallet", URL: "http://wallet.local"},
+	}
+
+	backends, err := cfg.Backends("dev")
+	if err != nil {
+		t.Fatalf("unable to create backends, %v", err)
+	}
+
+	// The mnemonic derived accounts have no backend
+	_, ok := backends.For(5)
+	assert.False(t, ok)
+
+	backend, ok := backends.For(19)
+	if assert.True(t, ok) {
+		assert.Equal(t, "wallet", backend.Name())
+	}
+
+	_, ok = backends.For(20)
+	assert.False(t, ok)
+}
@@ -0,0 +1,199 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+// maxErrorBody is the maximum size of the wallet service error body kept in the errors
+const maxErrorBody = 256
+
+var (
+	errUnknownAccount = errors.New("account is not managed by the http signer")
+	errKeyMismatch    = errors.New("wallet service key does not match the address")
+	errServiceFailed  = errors.New("wallet service request failed")
+)
+
+// accountResponse is the wallet service account key response
+type accountResponse struct {
+	Address string `json:"address"` // the bech32 account address
+	PubKey  string `json:"pubKey"`  // the bech32 account public key
+}
+
+// signRequest is the wallet service signing request
+type signRequest struct {
+	Index         uint32 `json:"index"`
+	Address       string `json:"address"`
+	ChainID       string `json:"chainId"`
+	AccountNumber uint64 `json:"accountNumber"`
+	Sequence      uint64 `json:"sequence"`
+	SignBytes     string `json:"signBytes"` // the base64 encoded sign bytes
+}
+
+// signResponse is the wallet service signing response
+type signResponse struct {
+	Signature string `json:"signature"` // the base64 encoded signature
+}
+
+// httpAccount is an account managed by the wallet service
+type httpAccount struct {
+	index  uint32
+	pubKey crypto.PubKey
+}
+
+// httpBackend signs with the keys of an external wallet service:
+//   - GET {url}/accounts/{index} returns the address and public key of the account
+//   - POST {url}/sign signs the sign bytes of the account transaction
+type httpBackend struct {
+	name    string
+	url     string
+	chainID string
+	client  *http.Client
+
+	mux      sync.Mutex
+	accounts map[string]httpAccount // the fetched accounts, by address
+}
+
+// newHTTPBackend creates the wallet service backend of the signer range
+func newHTTPBackend(cfg RangeConfig, chainID string) (*httpBackend, error) {
+	timeout, err := cfg.timeout()
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpBackend{
+		name:     cfg.name(),
+		url:      strings.TrimSuffix(cfg.URL, "/"),
+		chainID:  chainID,
+		client:   &http.Client{Timeout: timeout},
+		accounts: make(map[string]httpAccount),
+	}, nil
+}
+
+// Name returns the backend name
+func (b *httpBackend) Name() string {
+	return b.name
+}
+
+// PubKey fetches the public key of the account at the derivation index from the wallet service
+func (b *httpBackend) PubKey(index uint32) (crypto.PubKey, error) {
+	resp, err := b.client.Get(fmt.Sprintf("%s/accounts/%d", b.url, index))
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch account key, %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	var account accountResponse
+	if err := decodeResponse(resp, &account); err != nil {
+		return nil, fmt.Errorf("unable to fetch account key, %w", err)
+	}
+
+	pubKey, err := crypto.PubKeyFromBech32(account.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode account key, %w", err)
+	}
+
+	// Make sure the service key derives the reported address
+	if pubKey.Address().String() != account.Address {
+		return nil, fmt.Errorf("%w, index %d (%s)", errKeyMismatch, index, account.Address)
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.accounts[account.Address] = httpAccount{
+		index:  index,
+		pubKey: pubKey,
+	}
+
+	return pubKey, nil
+}
+
+// SignTx signs the transaction with the wallet service key of the account.
+// The signature is always verified locally, before it is appended
+func (b *httpBackend) SignTx(
+	tx *std.Tx,
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	_ string,
+) error {
+	address := account.GetAddress().String()
+
+	b.mux.Lock()
+	managed, ok := b.accounts[address]
+	b.mux.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w, %s", errUnknownAccount, address)
+	}
+
+	signBytes := tx.GetSignBytes(b.chainID, account.AccountNumber, nonce)
+
+	body, err := json.Marshal(signRequest{
+		Index:         managed.index,
+		Address:       address,
+		ChainID:       b.chainID,
+		AccountNumber: account.AccountNumber,
+		Sequence:      nonce,
+		SignBytes:     base64.StdEncoding.EncodeToString(signBytes),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to encode signing request, %w", err)
+	}
+
+	resp, err := b.client.Post(b.url+"/sign", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to sign transaction, %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	var signed signResponse
+	if err := decodeResponse(resp, &signed); err != nil {
+		return fmt.Errorf("unable to sign transaction, %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature, %w", err)
+	}
+
+	if err := verifySignature(b.chainID, account, nonce, managed.pubKey, signBytes, signature); err != nil {
+		return err
+	}
+
+	return appendSignature(tx, account, managed.pubKey, signature)
+}
+
+// decodeResponse decodes the JSON wallet service response,
+// or returns the service error with the start of its body
+func decodeResponse(resp *http.Response, v any) error {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+		return fmt.Errorf(
+			"%w, status %d: %s",
+			errServiceFailed,
+			resp.StatusCode,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("unable to decode response, %w", err)
+	}
+
+	return nil
+}
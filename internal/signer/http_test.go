@@ -0,0 +1,152 @@
+package signer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWalletService creates a wallet service managing the keybase accounts,
+// with the given signature tampering
+func newWalletService(t *testing.T, kb keys.Keybase, accounts []keys.Info, tamper bool) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		var index int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/accounts/"), "%d", &index); err != nil ||
+			index >= len(accounts) {
+			http.Error(w, "unknown account", http.StatusNotFound)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(accountResponse{
+			Address: accounts[index].GetAddress().String(),
+			PubKey:  crypto.PubKeyToBech32(accounts[index].GetPubKey()),
+		})
+	})
+
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		signBytes, _ := base64.StdEncoding.DecodeString(req.SignBytes)
+		if tamper {
+			signBytes = append(signBytes, 0)
+		}
+
+		signature, _, err := kb.Sign(accounts[req.Index].GetName(), common.EncryptPassword, signBytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(signResponse{
+			Signature: base64.StdEncoding.EncodeToString(signature),
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPBackend_SignTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid signature", func(t *testing.T) {
+		t.Parallel()
+
+		kb, accounts := generateKeybase(t, 2)
+		server := newWalletService(t, kb, accounts, false)
+
+		backend, err := newHTTPBackend(RangeConfig{Type: TypeHTTP, URL: server.URL + "/"}, "dev")
+		if err != nil {
+			t.Fatalf("unable to create backend, %v", err)
+		}
+
+		pubKey, err := backend.PubKey(1)
+		if err != nil {
+			t.Fatalf("unable to fetch key, %v", err)
+		}
+
+		assert.Equal(t, accounts[1].GetPubKey(), pubKey)
+
+		tx, account := newTransferTx(accounts[1])
+
+		if err := backend.SignTx(tx, account, 3, ""); err != nil {
+			t.Fatalf("unable to sign transaction, %v", err)
+		}
+
+		if !assert.Len(t, tx.Signatures, 1) {
+			return
+		}
+
+		assert.True(
+			t,
+			pubKey.VerifyBytes(
+				tx.GetSignBytes("dev", account.AccountNumber, 3),
+				tx.Signatures[0].Signature,
+			),
+		)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		kb, accounts := generateKeybase(t, 1)
+		server := newWalletService(t, kb, accounts, true)
+
+		backend, err := newHTTPBackend(RangeConfig{Type: TypeHTTP, URL: server.URL}, "dev")
+		if err != nil {
+			t.Fatalf("unable to create backend, %v", err)
+		}
+
+		if _, err := backend.PubKey(0); err != nil {
+			t.Fatalf("unable to fetch key, %v", err)
+		}
+
+		tx, account := newTransferTx(accounts[0])
+
+		assert.ErrorIs(t, backend.SignTx(tx, account, 3, ""), errInvalidSignature)
+		assert.Empty(t, tx.Signatures)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		t.Parallel()
+
+		kb, accounts := generateKeybase(t, 1)
+		server := newWalletService(t, kb, accounts, false)
+
+		backend, err := newHTTPBackend(RangeConfig{Type: TypeHTTP, URL: server.URL}, "dev")
+		if err != nil {
+			t.Fatalf("unable to create backend, %v", err)
+		}
+
+		_, err = backend.PubKey(5)
+		assert.ErrorIs(t, err, errServiceFailed)
+		assert.ErrorContains(t, err, "unknown account")
+
+		// The account key was never fetched
+		tx, account := newTransferTx(accounts[0])
+
+		assert.ErrorIs(t, backend.SignTx(tx, account, 3, ""), errUnknownAccount)
+	})
+}
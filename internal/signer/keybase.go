@@ -1,24 +1,58 @@
 package signer
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 
 	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
 	"github.com/gnolang/gno/pkgs/crypto/keys"
 	"github.com/gnolang/gno/pkgs/std"
 )
 
+var errInvalidSignature = errors.New("signature self-verification failed")
+
 type KeybaseSigner struct {
 	chainID string
 	keybase keys.Keybase
+
+	verify     bool   // flag indicating if the produced signatures are verified locally
+	passphrase string // the key passphrase, overriding the caller passphrase, if any
+}
+
+type Option func(s *KeybaseSigner)
+
+// WithVerification verifies each produced signature locally, against the
+// account public key and the exact sign bytes, before the transaction is returned.
+// Verification failures carry the signing details, instead of surfacing as opaque node rejections
+func WithVerification() Option {
+	return func(s *KeybaseSigner) {
+		s.verify = true
+	}
+}
+
+// WithPassphrase sets the passphrase the keybase keys are decrypted with,
+// instead of the passphrase of each signing request
+func WithPassphrase(passphrase string) Option {
+	return func(s *KeybaseSigner) {
+		s.passphrase = passphrase
+	}
 }
 
 // NewKeybaseSigner creates a new signer instance
-func NewKeybaseSigner(keybase keys.Keybase, chainID string) *KeybaseSigner {
-	return &KeybaseSigner{
+func NewKeybaseSigner(keybase keys.Keybase, chainID string, opts ...Option) *KeybaseSigner {
+	s := &KeybaseSigner{
 		keybase: keybase,
 		chainID: chainID,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // SignTx signs the given transaction by appending the
@@ -29,27 +63,44 @@ func (s *KeybaseSigner) SignTx(
 	nonce uint64,
 	passphrase string,
 ) error {
-	// Fetch existing signers
-	signers := tx.GetSigners()
-	if tx.Signatures == nil {
-		for range signers {
-			tx.Signatures = append(tx.Signatures, std.Signature{
-				PubKey:    nil, // zero signature
-				Signature: nil, // zero signature
-			})
-		}
+	if s.passphrase != "" {
+		passphrase = s.passphrase
 	}
 
 	// Generate the signature
+	signBytes := tx.GetSignBytes(s.chainID, account.AccountNumber, nonce)
+
 	signature, pub, err := s.keybase.Sign(
 		account.GetAddress().String(),
 		passphrase,
-		tx.GetSignBytes(s.chainID, account.AccountNumber, nonce),
+		signBytes,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to sign transaction, %w", err)
 	}
 
+	if s.verify {
+		if err := verifySignature(s.chainID, account, nonce, pub, signBytes, signature); err != nil {
+			return err
+		}
+	}
+
+	return appendSignature(tx, account, pub, signature)
+}
+
+// appendSignature appends the signature to the slot of the signing account
+func appendSignature(tx *std.Tx, account *gnoland.GnoAccount, pub crypto.PubKey, signature []byte) error {
+	// Fetch existing signers
+	signers := tx.GetSigners()
+	if tx.Signatures == nil {
+		for range signers {
+			tx.Signatures = append(tx.Signatures, std.Signature{
+				PubKey:    nil, // zero signature
+				Signature: nil, // zero signature
+			})
+		}
+	}
+
 	addr := pub.Address()
 	found := false
 
@@ -70,3 +121,40 @@ func (s *KeybaseSigner) SignTx(
 
 	return nil
 }
+
+// verifySignature verifies the signature against the account public key and the sign bytes,
+// and returns the signing details (chain ID, account number, sequence, sign bytes hash) if it fails
+func verifySignature(
+	chainID string,
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	pub crypto.PubKey,
+	signBytes,
+	signature []byte,
+) error {
+	var reason string
+
+	switch {
+	case account.GetPubKey() != nil && !account.GetPubKey().Equals(pub):
+		reason = "signing key does not match the account public key"
+	case pub.Address() != account.GetAddress():
+		reason = "signing key does not match the account address"
+	case !pub.VerifyBytes(signBytes, signature):
+		reason = "signature does not match the sign bytes"
+	default:
+		return nil
+	}
+
+	signBytesHash := sha256.Sum256(signBytes)
+
+	return fmt.Errorf(
+		"%w: %s (address %s, chain ID %q, account number %d, sequence %d, sign bytes SHA-256 %s)",
+		errInvalidSignature,
+		reason,
+		account.GetAddress(),
+		chainID,
+		account.AccountNumber,
+		nonce,
+		hex.EncodeToString(signBytesHash[:]),
+	)
+}
@@ -0,0 +1,122 @@
+package signer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto/bip39"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/gno/pkgs/sdk/bank"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateKeybase generates a keybase with the given number of accounts
+func generateKeybase(t *testing.T, count int) (keys.Keybase, []keys.Info) {
+	t.Helper()
+
+	entropySeed, err := bip39.NewEntropy(256)
+	if err != nil {
+		t.Fatalf("unable to generate entropy seed, %v", err)
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropySeed[:])
+	if err != nil {
+		t.Fatalf("unable to generate mnemonic, %v", err)
+	}
+
+	var (
+		kb       = keys.NewInMemory()
+		accounts = make([]keys.Info, count)
+	)
+
+	for i := 0; i < count; i++ {
+		info, err := kb.CreateAccount(
+			fmt.Sprintf("%s%d", common.KeybasePrefix, i),
+			mnemonic,
+			"",
+			common.EncryptPassword,
+			uint32(0),
+			uint32(i),
+		)
+		if err != nil {
+			t.Fatalf("unable to create account with keybase, %v", err)
+		}
+
+		accounts[i] = info
+	}
+
+	return kb, accounts
+}
+
+// newTransferTx creates a transfer (to self) from the given account
+func newTransferTx(info keys.Info) (*std.Tx, *gnoland.GnoAccount) {
+	account := &gnoland.GnoAccount{
+		BaseAccount: std.BaseAccount{
+			Address:       info.GetAddress(),
+			AccountNumber: 10,
+		},
+	}
+
+	tx := &std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: info.GetAddress(),
+				ToAddress:   info.GetAddress(),
+				Amount:      std.NewCoins(std.NewCoin(common.Denomination, 1)),
+			},
+		},
+		Fee: std.NewFee(100000, common.DefaultGasFee),
+	}
+
+	return tx, account
+}
+
+func TestKeybaseSigner_Verification(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid signature", func(t *testing.T) {
+		t.Parallel()
+
+		kb, accounts := generateKeybase(t, 1)
+		tx, account := newTransferTx(accounts[0])
+
+		s := NewKeybaseSigner(kb, "dev", WithVerification())
+
+		if err := s.SignTx(tx, account, 5, common.EncryptPassword); err != nil {
+			t.Fatalf("unable to sign transaction, %v", err)
+		}
+
+		if !assert.Len(t, tx.Signatures, 1) {
+			return
+		}
+
+		assert.True(
+			t,
+			tx.Signatures[0].PubKey.VerifyBytes(
+				tx.GetSignBytes("dev", account.AccountNumber, 5),
+				tx.Signatures[0].Signature,
+			),
+		)
+	})
+
+	t.Run("mismatched account key", func(t *testing.T) {
+		t.Parallel()
+
+		kb, accounts := generateKeybase(t, 2)
+		tx, account := newTransferTx(accounts[0])
+
+		// The on-chain account key differs from the signing key
+		account.PubKey = accounts[1].GetPubKey()
+
+		err := NewKeybaseSigner(kb, "dev", WithVerification()).SignTx(tx, account, 5, common.EncryptPassword)
+
+		assert.ErrorIs(t, err, errInvalidSignature)
+		assert.ErrorContains(t, err, `chain ID "dev", account number 10, sequence 5`)
+
+		// Make sure the check is skipped without verification
+		assert.NoError(t, NewKeybaseSigner(kb, "dev").SignTx(tx, account, 5, common.EncryptPassword))
+	})
+}
@@ -0,0 +1,157 @@
+package signer
+
+import (
+	"sync"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+// BackendHD is the backend name of the mnemonic (HD) derived accounts
+const BackendHD = "hd"
+
+// TxSigner signs transactions on behalf of an account
+type TxSigner interface {
+	SignTx(tx *std.Tx, account *gnoland.GnoAccount, nonce uint64, passphrase string) error
+}
+
+// Backend is a signer backend that manages the keys of its accounts,
+// instead of them being derived from the run mnemonic
+type Backend interface {
+	TxSigner
+
+	// Name returns the backend name, for the per-backend stats
+	Name() string
+
+	// PubKey returns the public key of the account at the derivation index
+	PubKey(index uint32) (crypto.PubKey, error)
+}
+
+// BackendStats are the signing outcomes of a single backend
+type BackendStats struct {
+	Accounts int // the number of accounts assigned to the backend
+	Signed   int // the number of successfully signed transactions
+	Failed   int // the number of failed signing requests
+}
+
+// poolState is the account assignment and signing stats,
+// shared by the pools with different fallback signers
+type poolState struct {
+	mux sync.Mutex
+
+	backends map[string]Backend       // the backend of each assigned account, by address
+	stats    map[string]*BackendStats // the signing stats, by backend name
+}
+
+// Pool is the account signer pool. Each account is signed by the backend it is assigned to,
+// and the unassigned (mnemonic derived) accounts are signed by the fallback signer
+type Pool struct {
+	fallback TxSigner
+	state    *poolState
+}
+
+// NewPool creates a new signer pool, with the fallback signer for the unassigned accounts
+func NewPool(fallback TxSigner) *Pool {
+	return &Pool{
+		fallback: fallback,
+		state: &poolState{
+			backends: make(map[string]Backend),
+			stats:    make(map[string]*BackendStats),
+		},
+	}
+}
+
+// WithFallback returns a pool sharing the account assignments and signing stats,
+// with a different fallback signer for the unassigned accounts
+func (p *Pool) WithFallback(fallback TxSigner) *Pool {
+	return &Pool{
+		fallback: fallback,
+		state:    p.state,
+	}
+}
+
+// Assign assigns the account to the backend
+func (p *Pool) Assign(address crypto.Address, backend Backend) {
+	p.state.mux.Lock()
+	defer p.state.mux.Unlock()
+
+	p.state.backends[address.String()] = backend
+	p.state.statsFor(backend.Name()).Accounts++
+}
+
+// AssignDerived records a mnemonic derived account, signed by the fallback signer
+func (p *Pool) AssignDerived() {
+	p.state.mux.Lock()
+	defer p.state.mux.Unlock()
+
+	p.state.statsFor(BackendHD).Accounts++
+}
+
+// Mixed returns true if any account is assigned to a backend
+func (p *Pool) Mixed() bool {
+	p.state.mux.Lock()
+	defer p.state.mux.Unlock()
+
+	return len(p.state.backends) > 0
+}
+
+// SignTx signs the transaction with the signer of the account,
+// and records the outcome with the signer backend
+func (p *Pool) SignTx(
+	tx *std.Tx,
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	passphrase string,
+) error {
+	var (
+		name   = BackendHD
+		signer = p.fallback
+	)
+
+	p.state.mux.Lock()
+	if backend, ok := p.state.backends[account.GetAddress().String()]; ok {
+		name = backend.Name()
+		signer = backend
+	}
+	p.state.mux.Unlock()
+
+	err := signer.SignTx(tx, account, nonce, passphrase)
+
+	p.state.mux.Lock()
+	defer p.state.mux.Unlock()
+
+	stats := p.state.statsFor(name)
+	if err != nil {
+		stats.Failed++
+	} else {
+		stats.Signed++
+	}
+
+	return err
+}
+
+// Stats returns the signing stats of each backend, by backend name
+func (p *Pool) Stats() map[string]BackendStats {
+	p.state.mux.Lock()
+	defer p.state.mux.Unlock()
+
+	stats := make(map[string]BackendStats, len(p.state.stats))
+
+	for name, backendStats := range p.state.stats {
+		stats[name] = *backendStats
+	}
+
+	return stats
+}
+
+// statsFor returns the signing stats of the backend, creating them if needed
+func (s *poolState) statsFor(name string) *BackendStats {
+	stats, ok := s.stats[name]
+	if !ok {
+		stats = &BackendStats{}
+		s.stats[name] = stats
+	}
+
+	return stats
+}
@@ -0,0 +1,102 @@
+package signer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBackend is a named signer backend, with a configurable signing outcome
+type mockBackend struct {
+	name   string
+	signFn func(tx *std.Tx, account *gnoland.GnoAccount) error
+}
+
+func (m *mockBackend) Name() string {
+	return m.name
+}
+
+func (m *mockBackend) PubKey(uint32) (crypto.PubKey, error) {
+	return nil, nil
+}
+
+func (m *mockBackend) SignTx(tx *std.Tx, account *gnoland.GnoAccount, _ uint64, _ string) error {
+	if m.signFn != nil {
+		return m.signFn(tx, account)
+	}
+
+	return nil
+}
+
+func TestPool_SignTx(t *testing.T) {
+	t.Parallel()
+
+	var (
+		kb, accounts = generateKeybase(t, 3)
+		signErr      = errors.New("wallet unavailable")
+
+		wallet = &mockBackend{name: "wallet"}
+		broken = &mockBackend{
+			name: "broken",
+			signFn: func(*std.Tx, *gnoland.GnoAccount) error {
+				return signErr
+			},
+		}
+
+		pool = NewPool(NewKeybaseSigner(kb, "dev"))
+	)
+
+	pool.AssignDerived()
+	pool.Assign(accounts[1].GetAddress(), wallet)
+	pool.Assign(accounts[2].GetAddress(), broken)
+
+	assert.True(t, pool.Mixed())
+
+	for _, info := range accounts {
+		tx, account := newTransferTx(info)
+
+		err := pool.SignTx(tx, account, 0, common.EncryptPassword)
+		if info.GetAddress() == accounts[2].GetAddress() {
+			assert.ErrorIs(t, err, signErr)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+	}
+
+	// Make sure the derived account was signed by the fallback signer
+	tx, account := newTransferTx(accounts[0])
+	if err := pool.WithFallback(NewKeybaseSigner(kb, "dev")).SignTx(tx, account, 0, common.EncryptPassword); err != nil {
+		t.Fatalf("unable to sign transaction, %v", err)
+	}
+
+	assert.Len(t, tx.Signatures, 1)
+
+	// The pools with a different fallback share the stats
+	assert.Equal(
+		t,
+		map[string]BackendStats{
+			BackendHD: {Accounts: 1, Signed: 2},
+			"wallet":  {Accounts: 1, Signed: 1},
+			"broken":  {Accounts: 1, Failed: 1},
+		},
+		pool.Stats(),
+	)
+}
+
+func TestPool_Mixed(t *testing.T) {
+	t.Parallel()
+
+	kb, _ := generateKeybase(t, 1)
+
+	pool := NewPool(NewKeybaseSigner(kb, "dev"))
+	pool.AssignDerived()
+
+	assert.False(t, pool.Mixed())
+}
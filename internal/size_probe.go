@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/locks"
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/gnolang/supernova/internal/runtime"
+	"github.com/gnolang/supernova/internal/sizeprobe"
+)
+
+// probeTransactions is the number of probe steps the probing account is funded for,
+// which covers the doubling and binary search steps over the whole padding range
+const probeTransactions = 64
+
+var errNoPadding = errors.New("message type does not support padded transactions")
+
+// executeSizeProbe probes the maximum transaction size the chain accepts end-to-end,
+// instead of running the stress test. Only the first sub-account is used
+func (p *Pipeline) executeSizeProbe() error {
+	var (
+		msgType   = runtime.Type(p.cfg.ProbeMsg)
+		deposit   = p.storageDeposit()
+		txRuntime = runtime.GetRuntime(msgType, p.signer, runtimeOptions(deposit)...)
+	)
+
+	padder, ok := txRuntime.(runtime.Padder)
+	if !ok {
+		return fmt.Errorf("%w: %s", errNoPadding, msgType)
+	}
+
+	// Make sure no live run signs with the same accounts
+	ranges := []locks.Range{
+		{Start: uint32(p.cfg.DistributorIndex), End: uint32(p.cfg.DistributorIndex) + 1},
+		{Start: uint32(p.cfg.SubAccountOffset), End: uint32(p.cfg.SubAccountOffset) + 1},
+	}
+
+	if err := p.lockAccounts(ranges); err != nil {
+		return err
+	}
+
+	phaseStart := time.Now()
+
+	accounts, err := p.initializeAccounts()
+	if err != nil {
+		return err
+	}
+
+	p.trackPhase(phaseInitialize, phaseStart)
+
+	// Deploy the probed realm, if any
+	if err := prepareRuntime(msgType, accounts, p.cli, txRuntime, p.budget); err != nil {
+		return err
+	}
+
+	// Fund the probing account
+	phaseStart = time.Now()
+
+	distributorOpts := []distributor.Option{
+		distributor.WithDistributorIndex(uint32(p.cfg.DistributorIndex)),
+		distributor.WithBudget(p.budget),
+	}
+
+	if msgType == runtime.RealmDeployment || msgType == runtime.PackageDeployment {
+		distributorOpts = append(distributorOpts, distributor.WithStorageDeposit(deposit))
+	}
+
+	runAccounts, err := distributor.NewDistributor(p.cli, p.fundingSigner, distributorOpts...).
+		Distribute(accounts[:2], probeTransactions)
+	if err != nil {
+		return fmt.Errorf("unable to distribute funds, %w", err)
+	}
+
+	p.trackPhase(phaseDistribute, phaseStart)
+
+	result, err := sizeprobe.NewProber(
+		p.cli,
+		padder,
+		sizeprobe.WithResolution(int(p.cfg.ProbeResolution)),
+		sizeprobe.WithGasWanted(p.cfg.ProbeGasWanted),
+	).Probe(runAccounts[0].GetAddress().String())
+	if err != nil {
+		return fmt.Errorf("unable to probe transaction size, %w", err)
+	}
+
+	result.RunID = p.runID
+	result.MsgType = msgType.String()
+
+	displaySizeProbe(result)
+
+	if p.cfg.Output == "" {
+		return nil
+	}
+
+	if err := saveJSON(result, p.cfg.Output); err != nil {
+		return fmt.Errorf("unable to save size probe results, %w", err)
+	}
+
+	fmt.Printf("✅ Successfully saved size probe results to %s\n", p.cfg.Output)
+
+	p.recordArtifact(manifest.TypeSizeProbe, p.cfg.Output)
+
+	return nil
+}
+
+// displaySizeProbe displays the transaction size probe result in the terminal
+func displaySizeProbe(result *sizeprobe.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 10, 20, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("\nProbed message type: %s (gas wanted %d)", result.MsgType, result.GasWanted))
+	_, _ = fmt.Fprintln(w, fmt.Sprintf("Largest committed transaction: %d bytes", result.LargestCommitted))
+
+	if result.Unbounded {
+		_, _ = fmt.Fprintln(w, "⚠️ No transaction size was rejected")
+	} else {
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"Smallest rejected transaction: %d bytes (%s, resolution %d bytes)",
+				result.SmallestRejected,
+				result.Reason,
+				result.Resolution,
+			),
+		)
+		_, _ = fmt.Fprintln(w, fmt.Sprintf("Rejection error: %s", result.Error))
+	}
+
+	_, _ = fmt.Fprintln(w, "\nPadding\tTx bytes\tOutcome\tDuration")
+
+	for _, step := range result.Steps {
+		outcome := "committed"
+		if !step.Committed {
+			outcome = fmt.Sprintf("rejected (%s)", step.Reason)
+		}
+
+		_, _ = fmt.Fprintln(
+			w,
+			fmt.Sprintf(
+				"%d\t%d\t%s\t%s",
+				step.Padding,
+				step.TxBytes,
+				outcome,
+				step.Duration.Round(time.Millisecond),
+			),
+		)
+	}
+
+	_ = w.Flush()
+}
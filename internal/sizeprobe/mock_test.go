@@ -0,0 +1,51 @@
+package sizeprobe
+
+import (
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/std"
+)
+
+type (
+	getAccountDelegate           func(address string) (*gnoland.GnoAccount, error)
+	broadcastTransactionDelegate func(tx *std.Tx) error
+)
+
+type mockClient struct {
+	getAccountFn           getAccountDelegate
+	broadcastTransactionFn broadcastTransactionDelegate
+}
+
+func (m *mockClient) GetAccount(address string) (*gnoland.GnoAccount, error) {
+	if m.getAccountFn != nil {
+		return m.getAccountFn(address)
+	}
+
+	return nil, nil
+}
+
+func (m *mockClient) BroadcastTransaction(tx *std.Tx) error {
+	if m.broadcastTransactionFn != nil {
+		return m.broadcastTransactionFn(tx)
+	}
+
+	return nil
+}
+
+type constructPaddedTransactionDelegate func(*gnoland.GnoAccount, uint64, int, std.Fee) (*std.Tx, error)
+
+type mockPadder struct {
+	constructPaddedTransactionFn constructPaddedTransactionDelegate
+}
+
+func (m *mockPadder) ConstructPaddedTransaction(
+	account *gnoland.GnoAccount,
+	nonce uint64,
+	padding int,
+	fee std.Fee,
+) (*std.Tx, error) {
+	if m.constructPaddedTransactionFn != nil {
+		return m.constructPaddedTransactionFn(account, nonce, padding, fee)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package sizeprobe
+
+type Option func(p *Prober)
+
+// WithResolution sets the binary search resolution, in bytes
+func WithResolution(resolution int) Option {
+	return func(p *Prober) {
+		if resolution > 0 {
+			p.resolution = resolution
+		}
+	}
+}
+
+// WithGasWanted sets the gas wanted of each probed transaction.
+// The transaction size gas cost grows with the payload
+func WithGasWanted(gasWanted int64) Option {
+	return func(p *Prober) {
+		if gasWanted > 0 {
+			p.gasWanted = gasWanted
+		}
+	}
+}
+
+// WithPaddingRange sets the initial and the maximum payload padding, in bytes
+func WithPaddingRange(initial, maximum int) Option {
+	return func(p *Prober) {
+		if initial > 0 && maximum >= initial {
+			p.initialPadding = initial
+			p.maxPadding = maximum
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Package sizeprobe finds the largest transaction the chain accepts end-to-end,
+// by binary searching the payload size of committed transactions
+package sizeprobe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/runtime"
+)
+
+const (
+	defaultInitialPadding = 1024             // 1 KiB
+	defaultMaxPadding     = 64 * 1024 * 1024 // 64 MiB, over any sane block size
+	defaultResolution     = 1024             // 1 KiB
+	defaultGasWanted      = 10_000_000       // the default gno.land block max gas
+)
+
+var errPaddingFailed = errors.New("unable to construct padded transaction")
+
+// Client is the probing client
+type Client interface {
+	GetAccount(address string) (*gnoland.GnoAccount, error)
+
+	// BroadcastTransaction broadcasts the transaction,
+	// and waits for it to be committed
+	BroadcastTransaction(tx *std.Tx) error
+}
+
+// Reason is the rejection reason of a probed transaction
+type Reason string
+
+const (
+	// ReasonMempoolBytes is a transaction over the mempool maximum transaction size
+	ReasonMempoolBytes Reason = "mempool bytes limit"
+
+	// ReasonBlockBytes is a transaction accepted by the mempool,
+	// but never included in a block (over the block data size)
+	ReasonBlockBytes Reason = "block bytes limit"
+
+	// ReasonGas is a transaction over the gas limits, which grow with the transaction size
+	ReasonGas Reason = "gas"
+
+	// ReasonOther is any other rejection
+	ReasonOther Reason = "other"
+)
+
+// Step is a single probed transaction
+type Step struct {
+	Padding   int           `json:"padding"` // the payload padding, in bytes
+	TxBytes   int           `json:"txBytes"` // the encoded (signed) transaction size
+	Committed bool          `json:"committed"`
+	Reason    Reason        `json:"reason,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Result is the transaction size probe result
+type Result struct {
+	RunID   string `json:"runId"`
+	MsgType string `json:"msgType"` // the probed message type
+
+	LargestCommitted int     `json:"largestCommittedBytes"` // the largest committed transaction size, if any
+	SmallestRejected int     `json:"smallestRejectedBytes"` // the smallest rejected transaction size, if any
+	Reason           Reason  `json:"reason,omitempty"`      // the rejection reason of the smallest rejected size
+	Error            string  `json:"error,omitempty"`       // the rejection error of the smallest rejected size
+	Resolution       int     `json:"resolutionBytes"`       // the binary search resolution
+	GasWanted        int64   `json:"gasWanted"`             // the gas wanted of each probed transaction
+	Unbounded        bool    `json:"unbounded,omitempty"`   // flag indicating if no size was rejected
+	Steps            []*Step `json:"steps"`
+}
+
+// Prober probes the maximum transaction size of the chain
+type Prober struct {
+	cli    Client
+	padder runtime.Padder
+
+	initialPadding int
+	maxPadding     int
+	resolution     int
+	gasWanted      int64
+}
+
+// NewProber creates a new transaction size prober,
+// using the padded transactions of the given runtime
+func NewProber(cli Client, padder runtime.Padder, opts ...Option) *Prober {
+	p := &Prober{
+		cli:            cli,
+		padder:         padder,
+		initialPadding: defaultInitialPadding,
+		maxPadding:     defaultMaxPadding,
+		resolution:     defaultResolution,
+		gasWanted:      defaultGasWanted,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Probe finds the largest committed transaction size, using the given account.
+// The padding is doubled until the first rejection, and then binary searched
+// between the largest committed and the smallest rejected padding
+func (p *Prober) Probe(address string) (*Result, error) {
+	var (
+		result = &Result{
+			Resolution: p.resolution,
+			GasWanted:  p.gasWanted,
+			Steps:      make([]*Step, 0),
+		}
+
+		committed = -1 // the largest committed padding
+		rejected  = -1 // the smallest rejected padding
+	)
+
+	fmt.Printf("\n📏 Probing Transaction Size 📏\n\n")
+
+	// Grow the padding until the first rejection
+	for padding := p.initialPadding; padding <= p.maxPadding; padding *= 2 {
+		step, err := p.probe(address, padding)
+		if err != nil {
+			return nil, err
+		}
+
+		result.record(step)
+
+		if !step.Committed {
+			rejected = padding
+
+			break
+		}
+
+		committed = padding
+	}
+
+	if rejected < 0 {
+		fmt.Printf("⚠️ No transaction was rejected, up to a %d byte padding\n", p.maxPadding)
+
+		result.Unbounded = true
+
+		return result, nil
+	}
+
+	// Binary search the padding between the bounds
+	low := committed
+	if low < 0 {
+		low = 0
+	}
+
+	for rejected-low > p.resolution {
+		padding := low + (rejected-low)/2
+
+		step, err := p.probe(address, padding)
+		if err != nil {
+			return nil, err
+		}
+
+		result.record(step)
+
+		if step.Committed {
+			low = padding
+		} else {
+			rejected = padding
+		}
+	}
+
+	return result, nil
+}
+
+// probe constructs, broadcasts and confirms a single padded transaction
+func (p *Prober) probe(address string, padding int) (*Step, error) {
+	// The sequence is fetched for every step,
+	// since rejected transactions may not use it up
+	account, err := p.cli.GetAccount(address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch probing account, %w", err)
+	}
+
+	tx, err := p.padder.ConstructPaddedTransaction(
+		account,
+		account.Sequence,
+		padding,
+		std.NewFee(p.gasWanted, common.DefaultGasFee),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %v", errPaddingFailed, err)
+	}
+
+	encoded, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	step := &Step{
+		Padding: padding,
+		TxBytes: len(encoded),
+	}
+
+	start := time.Now()
+	err = p.cli.BroadcastTransaction(tx)
+	step.Duration = time.Since(start)
+
+	if err != nil {
+		step.Reason = classify(err)
+		step.Error = err.Error()
+
+		fmt.Printf("❌ %d bytes rejected (%s)\n", step.TxBytes, step.Reason)
+
+		return step, nil
+	}
+
+	step.Committed = true
+
+	fmt.Printf("✅ %d bytes committed\n", step.TxBytes)
+
+	return step, nil
+}
+
+// record records the probe step, and updates the size bounds
+func (r *Result) record(step *Step) {
+	r.Steps = append(r.Steps, step)
+
+	if step.Committed {
+		if step.TxBytes > r.LargestCommitted {
+			r.LargestCommitted = step.TxBytes
+		}
+
+		return
+	}
+
+	if r.SmallestRejected == 0 || step.TxBytes < r.SmallestRejected {
+		r.SmallestRejected = step.TxBytes
+		r.Reason = step.Reason
+		r.Error = step.Error
+	}
+}
+
+// classify classifies the rejection reason, from the node error
+func classify(err error) Reason {
+	message := strings.ToLower(err.Error())
+
+	switch {
+	case strings.Contains(message, "tx too large"):
+		return ReasonMempoolBytes
+	case strings.Contains(message, "timed out"), strings.Contains(message, "timeout"):
+		// Accepted by the mempool, but never committed
+		return ReasonBlockBytes
+	case strings.Contains(message, "gas"), strings.Contains(message, "insufficient fee"):
+		return ReasonGas
+	default:
+		return ReasonOther
+	}
+}
@@ -0,0 +1,161 @@
+package sizeprobe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/gnoland"
+	"github.com/gnolang/gno/pkgs/amino"
+	"github.com/gnolang/gno/pkgs/std"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMemoPadder creates a padder that pads the transaction memo
+func newMemoPadder() *mockPadder {
+	return &mockPadder{
+		constructPaddedTransactionFn: func(_ *gnoland.GnoAccount, _ uint64, padding int, fee std.Fee) (*std.Tx, error) {
+			return &std.Tx{
+				Fee:  fee,
+				Memo: strings.Repeat("x", padding),
+			}, nil
+		},
+	}
+}
+
+// newLimitedClient creates a client that rejects the transactions over the size limit
+func newLimitedClient(t *testing.T, limit int, rejection error) *mockClient {
+	t.Helper()
+
+	return &mockClient{
+		getAccountFn: func(_ string) (*gnoland.GnoAccount, error) {
+			return &gnoland.GnoAccount{}, nil
+		},
+		broadcastTransactionFn: func(tx *std.Tx) error {
+			encoded, err := amino.Marshal(tx)
+			if err != nil {
+				t.Fatalf("unable to marshal transaction, %v", err)
+			}
+
+			if len(encoded) > limit {
+				return rejection
+			}
+
+			return nil
+		},
+	}
+}
+
+func TestProber_Probe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("binary search", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			limit      = 100_000
+			resolution = 256
+			rejection  = fmt.Errorf("broadcast transaction check failed, Tx too large. Max size is %d", limit)
+		)
+
+		result, err := NewProber(
+			newLimitedClient(t, limit, rejection),
+			newMemoPadder(),
+			WithResolution(resolution),
+		).Probe("address")
+		if err != nil {
+			t.Fatalf("unable to probe transaction size, %v", err)
+		}
+
+		assert.False(t, result.Unbounded)
+		assert.LessOrEqual(t, result.LargestCommitted, limit)
+		assert.Greater(t, result.SmallestRejected, limit)
+		assert.LessOrEqual(t, result.SmallestRejected-result.LargestCommitted, resolution)
+
+		assert.Equal(t, ReasonMempoolBytes, result.Reason)
+		assert.Equal(t, rejection.Error(), result.Error)
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := NewProber(
+			newLimitedClient(t, 1_000_000, errors.New("rejected")),
+			newMemoPadder(),
+			WithPaddingRange(1024, 8192),
+		).Probe("address")
+		if err != nil {
+			t.Fatalf("unable to probe transaction size, %v", err)
+		}
+
+		assert.True(t, result.Unbounded)
+		assert.Zero(t, result.SmallestRejected)
+
+		// 1, 2, 4 and 8 KiB paddings
+		assert.Len(t, result.Steps, 4)
+	})
+
+	t.Run("account fetch failure", func(t *testing.T) {
+		t.Parallel()
+
+		fetchErr := errors.New("node unavailable")
+
+		_, err := NewProber(
+			&mockClient{
+				getAccountFn: func(_ string) (*gnoland.GnoAccount, error) {
+					return nil, fetchErr
+				},
+			},
+			newMemoPadder(),
+		).Probe("address")
+
+		assert.ErrorIs(t, err, fetchErr)
+	})
+}
+
+func TestProber_Classify(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		err      error
+		expected Reason
+	}{
+		{
+			"mempool bytes",
+			errors.New("broadcast transaction check failed, Tx too large. Max size is 1000000, but got 1048576"),
+			ReasonMempoolBytes,
+		},
+		{
+			"block bytes",
+			errors.New("unable to broadcast transaction, timed out waiting for tx to be included in a block"),
+			ReasonBlockBytes,
+		},
+		{
+			"gas wanted",
+			errors.New("broadcast transaction check failed, invalid gas-wanted; got: 20000000 block-max-gas: 10000000"),
+			ReasonGas,
+		},
+		{
+			"out of gas",
+			errors.New("broadcast transaction delivery failed, out of gas error"),
+			ReasonGas,
+		},
+		{
+			"other",
+			errors.New("broadcast transaction check failed, insufficient funds error"),
+			ReasonOther,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, classify(testCase.err))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package state implements the optional encryption of the
+// state artifacts supernova leaves on disk (spooled uploads,
+// checkpoints, transaction dumps), using a password-derived key
+package state
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PasswordEnv is the environment variable holding the state password,
+// used when the password is not set explicitly
+const PasswordEnv = "SUPERNOVA_STATE_PASSWORD"
+
+const (
+	saltSize = 16
+
+	// scrypt parameters, as recommended for interactive use
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	ErrWrongPassword    = errors.New("unable to decrypt state, wrong password or corrupted file")
+	ErrPasswordRequired = errors.New("state is encrypted, password required")
+	errMalformed        = errors.New("malformed encrypted state")
+)
+
+// magic is the header that marks encrypted state
+var magic = []byte("SUPERNOVA-ENC-V1\n")
+
+// IsEncrypted returns a flag indicating if the data is encrypted state
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// Seal encrypts the data with a key derived from the password,
+// using XChaCha20-Poly1305. The random salt and nonce are prepended
+func Seal(data []byte, password string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("unable to generate salt, %w", err)
+	}
+
+	aead, err := newAEAD(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("unable to generate nonce, %w", err)
+	}
+
+	sealed := make([]byte, 0, len(magic)+saltSize+len(nonce)+len(data)+aead.Overhead())
+
+	sealed = append(sealed, magic...)
+	sealed = append(sealed, salt...)
+	sealed = append(sealed, nonce...)
+
+	// The header is authenticated as well
+	return aead.Seal(sealed, nonce, data, sealed[:len(magic)]), nil
+}
+
+// Open decrypts the data sealed with the password.
+// Unencrypted data is returned as-is
+func Open(data []byte, password string) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	if password == "" {
+		return nil, ErrPasswordRequired
+	}
+
+	if len(data) < len(magic)+saltSize+chacha20poly1305.NonceSizeX {
+		return nil, errMalformed
+	}
+
+	var (
+		salt  = data[len(magic) : len(magic)+saltSize]
+		nonce = data[len(magic)+saltSize : len(magic)+saltSize+chacha20poly1305.NonceSizeX]
+		box   = data[len(magic)+saltSize+chacha20poly1305.NonceSizeX:]
+	)
+
+	aead, err := newAEAD(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := aead.Open(nil, nonce, box, data[:len(magic)])
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	return plain, nil
+}
+
+// WriteFile writes the state file, encrypting it if a password is set.
+// State files are only readable by the owner
+func WriteFile(path string, data []byte, password string) error {
+	if password != "" {
+		sealed, err := Seal(data, password)
+		if err != nil {
+			return fmt.Errorf("unable to encrypt state, %w", err)
+		}
+
+		data = sealed
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// ReadFile reads the state file, transparently decrypting it if needed
+func ReadFile(path, password string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(data, password)
+}
+
+// Password returns the explicitly set state password,
+// or the password from the environment, if any
+func Password(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+
+	return os.Getenv(PasswordEnv)
+}
+
+// newAEAD derives the key from the password, and creates the cipher
+func newAEAD(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key, %w", err)
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher, %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,114 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_SealOpen(t *testing.T) {
+	t.Parallel()
+
+	var (
+		data     = []byte(`{"runId":"run-1"}`)
+		password = "secret"
+	)
+
+	t.Run("valid password", func(t *testing.T) {
+		t.Parallel()
+
+		sealed, err := Seal(data, password)
+		if err != nil {
+			t.Fatalf("unable to seal data, %v", err)
+		}
+
+		assert.True(t, IsEncrypted(sealed))
+		assert.NotContains(t, string(sealed), string(data))
+
+		opened, err := Open(sealed, password)
+		if err != nil {
+			t.Fatalf("unable to open data, %v", err)
+		}
+
+		assert.Equal(t, data, opened)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		t.Parallel()
+
+		sealed, err := Seal(data, password)
+		if err != nil {
+			t.Fatalf("unable to seal data, %v", err)
+		}
+
+		_, err = Open(sealed, "wrong")
+		assert.ErrorIs(t, err, ErrWrongPassword)
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		t.Parallel()
+
+		sealed, err := Seal(data, password)
+		if err != nil {
+			t.Fatalf("unable to seal data, %v", err)
+		}
+
+		_, err = Open(sealed, "")
+		assert.ErrorIs(t, err, ErrPasswordRequired)
+	})
+
+	t.Run("tampered data", func(t *testing.T) {
+		t.Parallel()
+
+		sealed, err := Seal(data, password)
+		if err != nil {
+			t.Fatalf("unable to seal data, %v", err)
+		}
+
+		sealed[len(sealed)-1] ^= 0xff
+
+		_, err = Open(sealed, password)
+		assert.ErrorIs(t, err, ErrWrongPassword)
+	})
+
+	t.Run("unencrypted data", func(t *testing.T) {
+		t.Parallel()
+
+		opened, err := Open(data, password)
+		if err != nil {
+			t.Fatalf("unable to open data, %v", err)
+		}
+
+		assert.Equal(t, data, opened)
+	})
+}
+
+func TestState_WriteReadFile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		data = []byte("state")
+		path = filepath.Join(t.TempDir(), "state.json")
+	)
+
+	if err := WriteFile(path, data, "secret"); err != nil {
+		t.Fatalf("unable to write state file, %v", err)
+	}
+
+	// Make sure the file is only readable by the owner
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat state file, %v", err)
+	}
+
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	read, err := ReadFile(path, "secret")
+	if err != nil {
+		t.Fatalf("unable to read state file, %v", err)
+	}
+
+	assert.Equal(t, data, read)
+}
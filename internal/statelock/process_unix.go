@@ -0,0 +1,16 @@
+//go:build !windows
+
+package statelock
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive returns a flag indicating if the process with the given pid is running on this host
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+
+	// Processes of other users can't be signaled, but are running
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
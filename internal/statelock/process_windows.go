@@ -0,0 +1,9 @@
+//go:build windows
+
+package statelock
+
+// processAlive is not supported on Windows, the holder is assumed to be running.
+// Stale locks are only detected by their age
+func processAlive(_ int) bool {
+	return true
+}
@@ -0,0 +1,205 @@
+// Package statelock serializes the access of concurrent runs to a shared
+// state directory, for the brief moments its global files are touched
+package statelock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FileName is the advisory lock file name, in the state directory
+	FileName = ".supernova.lock"
+
+	// DefaultTimeout is the default wait for the state directory lock
+	DefaultTimeout = 10 * time.Second
+
+	// StaleAge is the age after which a lock is considered stale. The lock is only
+	// held while the global state files are touched, which never takes this long
+	StaleAge = 2 * time.Minute
+
+	retryInterval = 10 * time.Millisecond
+)
+
+var errLocked = errors.New("timed out waiting for the state directory lock")
+
+var (
+	heldMux  sync.Mutex
+	held     = make(map[string]uint64) // the lock files held by the process, by acquisition
+	acquired uint64                    // the number of taken locks
+)
+
+// Acquire takes the advisory lock on the given state directory, creating it if needed,
+// and returns the release function. Stale locks, whose holder process is gone or that
+// are older than StaleAge, are taken over. If the lock is held by another run for longer
+// than the timeout, the lock holder is reported instead
+func Acquire(dir string, timeout time.Duration) (func(), error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create state directory, %w", err)
+	}
+
+	var (
+		lockPath = filepath.Join(dir, FileName)
+		deadline = time.Now().Add(timeout)
+	)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			// The holder is recorded, so stuck locks can be traced back
+			host, _ := os.Hostname()
+			_, _ = fmt.Fprintf(f, "%s pid %d", host, os.Getpid())
+			_ = f.Close()
+
+			id := hold(lockPath)
+
+			return func() {
+				release(lockPath, id)
+			}, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("unable to create state directory lock, %w", err)
+		}
+
+		// Take over the lock left behind by a crashed run, and retry right away
+		if takeOver(lockPath) {
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf(
+				"%w: %s is held by %s, remove it if no other run is active",
+				errLocked,
+				lockPath,
+				holder(lockPath),
+			)
+		}
+
+		time.Sleep(retryInterval)
+	}
+}
+
+// ReleaseAll releases the locks held by the process, for exits that skip the release functions
+func ReleaseAll() {
+	heldMux.Lock()
+	defer heldMux.Unlock()
+
+	for lockPath := range held {
+		_ = os.Remove(lockPath)
+
+		delete(held, lockPath)
+	}
+}
+
+// hold records the lock file as held by the process, and returns the acquisition ID
+func hold(lockPath string) uint64 {
+	heldMux.Lock()
+	defer heldMux.Unlock()
+
+	acquired++
+	held[lockPath] = acquired
+
+	return acquired
+}
+
+// release removes the lock file held by the given acquisition.
+// Locks already released by ReleaseAll, and possibly taken again since, are left alone
+func release(lockPath string, id uint64) {
+	heldMux.Lock()
+	defer heldMux.Unlock()
+
+	if held[lockPath] != id {
+		return
+	}
+
+	_ = os.Remove(lockPath)
+
+	delete(held, lockPath)
+}
+
+// takeOver removes the lock file if it is stale, and returns a flag indicating if it was removed.
+// The stale lock is first moved aside, so a lock freshly taken by another run in the
+// meantime is detected (by its content), and put back instead of being removed
+func takeOver(lockPath string) bool {
+	data, ok := stale(lockPath)
+	if !ok {
+		return false
+	}
+
+	aside := fmt.Sprintf("%s.stale-%d", lockPath, os.Getpid())
+
+	if err := os.Rename(lockPath, aside); err != nil {
+		return false
+	}
+
+	defer os.Remove(aside)
+
+	if moved, err := os.ReadFile(aside); err != nil || !bytes.Equal(moved, data) {
+		// The link fails if yet another run took the lock since
+		_ = os.Link(aside, lockPath)
+
+		return false
+	}
+
+	fmt.Printf("⚠️ Took over the stale state directory lock %s, held by %s\n", lockPath, holderName(data))
+
+	return true
+}
+
+// stale returns the content of the lock file, and a flag indicating if the lock is stale:
+// it is older than StaleAge, or its holder process is no longer running on this host
+func stale(lockPath string) ([]byte, bool) {
+	info, err := os.Stat(lockPath)
+	if err != nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, false
+	}
+
+	if time.Since(info.ModTime()) > StaleAge {
+		return data, true
+	}
+
+	var (
+		host string
+		pid  int
+	)
+
+	// Holders on other hosts can't be checked, and locks being written have no holder yet
+	if _, err := fmt.Sscanf(string(data), "%s pid %d", &host, &pid); err != nil {
+		return nil, false
+	}
+
+	current, _ := os.Hostname()
+
+	return data, host == current && pid != os.Getpid() && !processAlive(pid)
+}
+
+// holder returns the lock holder recorded in the lock file, if any
+func holder(lockPath string) string {
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return holderName(nil)
+	}
+
+	return holderName(data)
+}
+
+// holderName returns the lock holder recorded in the lock file content, if any
+func holderName(data []byte) string {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return "an unknown process"
+	}
+
+	return strings.TrimSpace(string(data))
+}
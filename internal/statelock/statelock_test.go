@@ -0,0 +1,169 @@
+package statelock
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateLock_Acquire(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concurrent holders serialized", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir = t.TempDir()
+
+			wg      sync.WaitGroup
+			mux     sync.Mutex
+			holders = 0
+			maxHeld = 0
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				release, err := Acquire(dir, 5*time.Second)
+				if err != nil {
+					t.Errorf("unable to acquire lock, %v", err)
+
+					return
+				}
+
+				mux.Lock()
+				holders++
+				if holders > maxHeld {
+					maxHeld = holders
+				}
+				mux.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mux.Lock()
+				holders--
+				mux.Unlock()
+
+				release()
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 1, maxHeld)
+
+		// The lock file is removed on release
+		_, err := os.Stat(filepath.Join(dir, FileName))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("held lock times out", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "state")
+
+		release, err := Acquire(dir, time.Second)
+		if err != nil {
+			t.Fatalf("unable to acquire lock, %v", err)
+		}
+
+		defer release()
+
+		_, err = Acquire(dir, 50*time.Millisecond)
+		assert.ErrorIs(t, err, errLocked)
+
+		// The holder is reported
+		host, _ := os.Hostname()
+		assert.Contains(t, err.Error(), host)
+	})
+}
+
+func TestStateLock_Stale(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dead holder taken over", func(t *testing.T) {
+		t.Parallel()
+
+		// Use the pid of an exited process
+		cmd := exec.Command("go", "version")
+		if err := cmd.Run(); err != nil {
+			t.Skipf("unable to run a process, %v", err)
+		}
+
+		var (
+			dir      = t.TempDir()
+			lockPath = filepath.Join(dir, FileName)
+			host, _  = os.Hostname()
+		)
+
+		holder := fmt.Sprintf("%s pid %d", host, cmd.ProcessState.Pid())
+		require.NoError(t, os.WriteFile(lockPath, []byte(holder), 0o600))
+
+		release, err := Acquire(dir, 50*time.Millisecond)
+		require.NoError(t, err)
+
+		defer release()
+
+		data, err := os.ReadFile(lockPath)
+		require.NoError(t, err)
+
+		assert.Equal(t, fmt.Sprintf("%s pid %d", host, os.Getpid()), string(data))
+	})
+
+	t.Run("old lock taken over", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir      = t.TempDir()
+			lockPath = filepath.Join(dir, FileName)
+		)
+
+		// Holders on other hosts are only judged by the lock age
+		require.NoError(t, os.WriteFile(lockPath, []byte("other-host pid 1"), 0o600))
+
+		_, err := Acquire(dir, 50*time.Millisecond)
+		assert.ErrorIs(t, err, errLocked)
+
+		old := time.Now().Add(-2 * StaleAge)
+		require.NoError(t, os.Chtimes(lockPath, old, old))
+
+		release, err := Acquire(dir, 50*time.Millisecond)
+		require.NoError(t, err)
+
+		release()
+	})
+}
+
+// TestStateLock_ReleaseAll is not parallel, as it releases every held lock
+func TestStateLock_ReleaseAll(t *testing.T) {
+	dir := t.TempDir()
+
+	release, err := Acquire(dir, time.Second)
+	require.NoError(t, err)
+
+	ReleaseAll()
+
+	_, err = os.Stat(filepath.Join(dir, FileName))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// A lock taken after the release is left alone by the stale release function
+	other, err := Acquire(dir, time.Second)
+	require.NoError(t, err)
+
+	release()
+
+	_, err = os.Stat(filepath.Join(dir, FileName))
+	assert.NoError(t, err)
+
+	other()
+}
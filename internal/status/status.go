@@ -0,0 +1,281 @@
+// Package status maintains the run status file, a small JSON snapshot of the
+// live run that external watchdogs can poll, without attaching to the process
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// FileName is the status file name, in the run output directory
+	FileName = "status.json"
+
+	// DefaultInterval is the default status file rewrite interval
+	DefaultInterval = 5 * time.Second
+)
+
+// State is the run state
+type State string
+
+const (
+	StateRunning     State = "running"     // the run is in progress
+	StateCompleted   State = "completed"   // the run finished successfully
+	StateFailed      State = "failed"      // the run finished with an error
+	StateInterrupted State = "interrupted" // the run was stopped before finishing
+)
+
+// Status is a single snapshot of the live run
+type Status struct {
+	RunID string `json:"runID"`
+	PID   int    `json:"pid"`
+	State State  `json:"state"`
+	Phase string `json:"phase"` // the current pipeline phase
+
+	StartedAt  time.Time  `json:"startedAt"`
+	Heartbeat  time.Time  `json:"heartbeat"`            // the time of the snapshot, refreshed every write
+	FinishedAt *time.Time `json:"finishedAt,omitempty"` // the time the run finished, if it did
+
+	Transactions int     `json:"transactions"` // the number of run transactions
+	Sent         int     `json:"sent"`         // the number of broadcast run transactions
+	Committed    int     `json:"committed"`    // the number of committed run transactions
+	SendRate     float64 `json:"sendRate"`     // the broadcast rate (tx/s) since the dispatch start
+	CommitRate   float64 `json:"commitRate"`   // the commit rate (tx/s) since the dispatch start
+
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Writer periodically rewrites the status file with the latest run status.
+// The updates only touch the in-memory status, and the file is written
+// in the background, so a slow disk never blocks the pipeline.
+// A nil writer discards all updates
+type Writer struct {
+	path     string
+	interval time.Duration
+
+	mux    sync.Mutex
+	status Status
+
+	dispatchStart time.Time // the start of the transaction dispatch
+	lastSent      time.Time // the time of the latest broadcast progress
+	lastCommit    time.Time // the time of the latest commit progress
+
+	warned bool // flag indicating if a write failure was reported
+
+	started   bool
+	closeOnce sync.Once
+	closeErr  error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewWriter creates a new status file writer
+func NewWriter(path, runID string, interval time.Duration) *Writer {
+	return &Writer{
+		path:     path,
+		interval: interval,
+		status: Status{
+			RunID:     runID,
+			PID:       os.Getpid(),
+			State:     StateRunning,
+			StartedAt: time.Now(),
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Start starts the periodic status file rewrites
+func (w *Writer) Start() {
+	if w == nil {
+		return
+	}
+
+	w.started = true
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.write()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.write()
+			}
+		}
+	}()
+}
+
+// SetPhase sets the current pipeline phase
+func (w *Writer) SetPhase(phase string) {
+	w.update(func(s *Status) {
+		s.Phase = phase
+	})
+}
+
+// StartDispatch marks the start of the transaction dispatch,
+// the reference point of the send and commit rates
+func (w *Writer) StartDispatch(transactions int) {
+	w.update(func(s *Status) {
+		s.Transactions = transactions
+		s.Sent = 0
+		s.Committed = 0
+
+		w.dispatchStart = time.Now()
+	})
+}
+
+// SetSent sets the number of broadcast run transactions
+func (w *Writer) SetSent(sent int) {
+	w.update(func(s *Status) {
+		s.Sent = sent
+
+		w.lastSent = time.Now()
+	})
+}
+
+// SetCommitted sets the number of committed run transactions
+func (w *Writer) SetCommitted(committed int) {
+	w.update(func(s *Status) {
+		s.Committed = committed
+
+		w.lastCommit = time.Now()
+	})
+}
+
+// Finish marks the run as finished, with the given outcome
+func (w *Writer) Finish(err error) {
+	w.update(func(s *Status) {
+		finishedAt := time.Now()
+		s.FinishedAt = &finishedAt
+
+		if err != nil {
+			s.State = StateFailed
+			s.LastError = err.Error()
+
+			return
+		}
+
+		s.State = StateCompleted
+	})
+}
+
+// Close stops the periodic rewrites, and writes the final status.
+// Runs that never finished are marked as interrupted.
+// It is safe to call multiple times
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+
+	w.closeOnce.Do(func() {
+		if w.started {
+			close(w.stop)
+			<-w.done
+		}
+
+		w.update(func(s *Status) {
+			if s.State != StateRunning {
+				return
+			}
+
+			finishedAt := time.Now()
+
+			s.State = StateInterrupted
+			s.FinishedAt = &finishedAt
+		})
+
+		w.closeErr = writeAtomic(w.path, w.snapshot())
+	})
+
+	return w.closeErr
+}
+
+// update applies the change to the in-memory status
+func (w *Writer) update(change func(s *Status)) {
+	if w == nil {
+		return
+	}
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	change(&w.status)
+}
+
+// snapshot returns the encoded status, with a fresh heartbeat
+func (w *Writer) snapshot() []byte {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	snapshot := w.status
+	snapshot.Heartbeat = time.Now()
+	snapshot.SendRate = rate(snapshot.Sent, w.dispatchStart, w.lastSent)
+	snapshot.CommitRate = rate(snapshot.Committed, w.dispatchStart, w.lastCommit)
+
+	encoded, _ := json.MarshalIndent(snapshot, "", "  ")
+
+	return encoded
+}
+
+// write rewrites the status file. Failed writes never fail
+// the run, and are only reported once
+func (w *Writer) write() {
+	err := writeAtomic(w.path, w.snapshot())
+	if err == nil || w.warned {
+		return
+	}
+
+	w.warned = true
+
+	fmt.Printf("\n⚠️ Unable to write the run status to %s, %v\n", w.path, err)
+}
+
+// rate calculates the rate (per second) of the count over the given window
+func rate(count int, start, end time.Time) float64 {
+	if start.IsZero() || !end.After(start) {
+		return 0
+	}
+
+	return float64(count) / end.Sub(start).Seconds()
+}
+
+// writeAtomic writes the data to a temporary file, and renames
+// it to the path, so readers never observe a partial status
+func writeAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".status-*")
+	if err != nil {
+		return fmt.Errorf("unable to create status file, %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("unable to write status, %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to close status file, %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("unable to replace status file, %w", err)
+	}
+
+	return nil
+}
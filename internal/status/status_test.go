@@ -0,0 +1,162 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readStatus reads the status file
+func readStatus(t *testing.T, path string) Status {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read status file, %v", err)
+	}
+
+	var s Status
+	if err := json.Unmarshal(content, &s); err != nil {
+		t.Fatalf("unable to parse status file, %v", err)
+	}
+
+	return s
+}
+
+func TestWriter_Status(t *testing.T) {
+	t.Parallel()
+
+	t.Run("live status rewritten", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir  = t.TempDir()
+			path = filepath.Join(dir, FileName)
+		)
+
+		w := NewWriter(path, "run-id", 10*time.Millisecond)
+		w.Start()
+
+		w.SetPhase("batch")
+		w.StartDispatch(100)
+		w.SetSent(60)
+		w.SetCommitted(20)
+
+		// Wait for the status to be rewritten
+		assert.Eventually(t, func() bool {
+			content, err := os.ReadFile(path)
+
+			return err == nil && json.Valid(content) && readStatus(t, path).Sent == 60
+		}, time.Second, 5*time.Millisecond)
+
+		s := readStatus(t, path)
+
+		assert.Equal(t, "run-id", s.RunID)
+		assert.Equal(t, os.Getpid(), s.PID)
+		assert.Equal(t, StateRunning, s.State)
+		assert.Equal(t, "batch", s.Phase)
+		assert.Equal(t, 100, s.Transactions)
+		assert.Equal(t, 20, s.Committed)
+		assert.Greater(t, s.SendRate, 0.0)
+		assert.Nil(t, s.FinishedAt)
+
+		// Make sure the heartbeat is refreshed
+		heartbeat := s.Heartbeat
+
+		assert.Eventually(t, func() bool {
+			return readStatus(t, path).Heartbeat.After(heartbeat)
+		}, time.Second, 5*time.Millisecond)
+
+		w.Finish(nil)
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close status writer, %v", err)
+		}
+
+		s = readStatus(t, path)
+
+		assert.Equal(t, StateCompleted, s.State)
+		assert.NotNil(t, s.FinishedAt)
+
+		// Make sure no temporary files are left behind
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("unable to read status dir, %v", err)
+		}
+
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("failed run", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), FileName)
+
+		w := NewWriter(path, "run-id", time.Minute)
+		w.Start()
+
+		w.Finish(errors.New("unable to batch transactions"))
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("unable to close status writer, %v", err)
+		}
+
+		s := readStatus(t, path)
+
+		assert.Equal(t, StateFailed, s.State)
+		assert.Equal(t, "unable to batch transactions", s.LastError)
+	})
+
+	t.Run("unfinished run interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), FileName)
+
+		w := NewWriter(path, "run-id", time.Minute)
+		w.Start()
+
+		// Make sure closing is safe to repeat
+		for i := 0; i < 2; i++ {
+			if err := w.Close(); err != nil {
+				t.Fatalf("unable to close status writer, %v", err)
+			}
+		}
+
+		s := readStatus(t, path)
+
+		assert.Equal(t, StateInterrupted, s.State)
+		assert.NotNil(t, s.FinishedAt)
+	})
+
+	t.Run("unwritable status does not block", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "missing", FileName)
+
+		w := NewWriter(path, "run-id", 10*time.Millisecond)
+		w.Start()
+
+		w.SetPhase("batch")
+		w.SetSent(10)
+
+		assert.Error(t, w.Close())
+	})
+
+	t.Run("nil writer", func(t *testing.T) {
+		t.Parallel()
+
+		var w *Writer
+
+		w.Start()
+		w.SetPhase("batch")
+		w.SetSent(10)
+		w.Finish(nil)
+
+		assert.NoError(t, w.Close())
+	})
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gnolang/gno/pkgs/crypto"
+	"github.com/gnolang/gno/pkgs/crypto/bip39"
+	"github.com/gnolang/gno/pkgs/crypto/keys"
+	"github.com/gnolang/supernova/internal/client"
+	"github.com/gnolang/supernova/internal/common"
+	"github.com/gnolang/supernova/internal/distributor"
+	"github.com/gnolang/supernova/internal/signer"
+)
+
+// SweepConfig is the sub-account sweep configuration
+type SweepConfig struct {
+	URL      string // the URL of the cluster
+	ChainID  string // the chain ID used for signing
+	Mnemonic string // the mnemonic for the keyring
+
+	SubAccounts      uint64 // the number of swept sub-accounts
+	SubAccountOffset uint64 // the derivation index of the first sub-account
+	DistributorIndex uint64 // the derivation index of the distributor account
+
+	ExcludeAccounts string // the comma separated sub-account indices or addresses never swept
+	OnlyAccounts    string // the comma separated sub-account indices or addresses swept, if any
+
+	accounts *accountFilter // the parsed sub-account filter
+}
+
+// Validate validates the sweep configuration
+func (cfg *SweepConfig) Validate() error {
+	// Make sure the URL is valid
+	if !urlRegex.MatchString(cfg.URL) {
+		return errInvalidURL
+	}
+
+	// Make sure the mnemonic is valid
+	if !bip39.IsMnemonicValid(cfg.Mnemonic) {
+		return errInvalidMnemonic
+	}
+
+	// Make sure the number of subaccounts is valid
+	if cfg.SubAccounts < 1 {
+		return errInvalidSubaccounts
+	}
+
+	// Make sure the account derivation indices are valid
+	if cfg.DistributorIndex > math.MaxUint32 || cfg.SubAccountOffset+cfg.SubAccounts > math.MaxUint32 {
+		return errInvalidDistributor
+	}
+
+	// Make sure the distributor is not one of the sub-accounts
+	if cfg.DistributorIndex >= cfg.SubAccountOffset &&
+		cfg.DistributorIndex < cfg.SubAccountOffset+cfg.SubAccounts {
+		return errDistributorOverlap
+	}
+
+	// Make sure the sub-account filters are valid
+	accounts, err := newAccountFilter(cfg.ExcludeAccounts, cfg.OnlyAccounts, uint32(cfg.DistributorIndex))
+	if err != nil {
+		return err
+	}
+
+	cfg.accounts = accounts
+
+	return nil
+}
+
+// Sweep returns the leftover sub-account balances to the distributor
+func Sweep(cfg *SweepConfig) error {
+	var (
+		kb       = keys.NewInMemory()
+		accounts = make([]keys.Info, 0, cfg.SubAccounts+1)
+		refused  = make([]crypto.Address, 0)
+	)
+
+	// The distributor account is always the first account
+	indices := []uint32{uint32(cfg.DistributorIndex)}
+	for i := cfg.SubAccountOffset; i < cfg.SubAccountOffset+cfg.SubAccounts; i++ {
+		indices = append(indices, uint32(i))
+	}
+
+	for _, index := range indices {
+		info, err := kb.CreateAccount(
+			fmt.Sprintf("%s%d", common.KeybasePrefix, index),
+			cfg.Mnemonic,
+			"",
+			common.EncryptPassword,
+			uint32(0),
+			index,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create account with keybase, %w", err)
+		}
+
+		accounts = append(accounts, info)
+
+		// The distributor is always the first account
+		if len(accounts) == 1 {
+			if err := cfg.accounts.checkDistributor(info.GetAddress()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Filtered out sub-accounts are refused by the sweep
+		if !cfg.accounts.allows(index, info.GetAddress()) {
+			refused = append(refused, info.GetAddress())
+		}
+	}
+
+	cli := client.NewHTTPClient(cfg.URL, 0)
+	defer cli.Close()
+
+	sweeper := distributor.NewDistributor(
+		cli,
+		signer.NewKeybaseSigner(kb, cfg.ChainID, signer.WithVerification()),
+		distributor.WithRefusedAccounts(refused),
+	)
+
+	if _, err := sweeper.Collect(accounts); err != nil {
+		return fmt.Errorf("unable to sweep sub-accounts, %w", err)
+	}
+
+	return nil
+}
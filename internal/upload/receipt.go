@@ -0,0 +1,53 @@
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/supernova/internal/manifest"
+)
+
+// receipt is the record of a completed run upload,
+// linked from the run manifest
+type receipt struct {
+	RunID      string    `json:"runId"`
+	URL        string    `json:"url"`
+	Artifacts  []string  `json:"artifacts"`
+	UploadedAt time.Time `json:"uploadedAt"`
+}
+
+// receiptFileName returns the upload receipt file name for the run
+func receiptFileName(runID string) string {
+	return fmt.Sprintf("upload-receipt-%s.json", runID)
+}
+
+// recordReceipt writes the upload receipt next to the run manifest,
+// and appends it to the manifest
+func (e *entry) recordReceipt() error {
+	r := receipt{
+		RunID:      e.RunID,
+		URL:        e.URL,
+		Artifacts:  e.Artifacts,
+		UploadedAt: time.Now().UTC(),
+	}
+
+	receiptJSON, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("unable to marshal upload receipt, %w", err)
+	}
+
+	path := filepath.Join(filepath.Dir(e.Manifest), receiptFileName(e.RunID))
+	if err := os.WriteFile(path, receiptJSON, 0o600); err != nil {
+		return fmt.Errorf("unable to write upload receipt, %w", err)
+	}
+
+	artifact, err := manifest.NewArtifact(manifest.TypeUploadReceipt, path)
+	if err != nil {
+		return err
+	}
+
+	return manifest.Append(e.Manifest, e.RunID, artifact)
+}
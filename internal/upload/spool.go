@@ -0,0 +1,187 @@
+package upload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gnolang/supernova/internal/state"
+)
+
+const (
+	entryFile = "entry.json"
+	doneFile  = "done"
+)
+
+var errInvalidEntry = errors.New("invalid spool entry")
+
+// Artifact is a single run artifact that needs to be uploaded
+type Artifact struct {
+	Name string // the name of the artifact (file name)
+	Data []byte // the raw artifact data
+}
+
+// entry is a single spooled upload, stored in
+// its own directory (named by the run ID)
+type entry struct {
+	RunID     string   `json:"runId"`
+	URL       string   `json:"url"`
+	Artifacts []string `json:"artifacts"`
+	Manifest  string   `json:"manifest,omitempty"` // the run manifest, if any
+
+	dir      string // the entry directory
+	password string // the state password, if the entry is encrypted
+}
+
+// Spool is the local upload queue. Each run is spooled to
+// a separate directory, and is only marked as done once all
+// of its artifacts have been successfully uploaded
+type Spool struct {
+	dir      string
+	password string // the state password, if any
+}
+
+type SpoolOption func(s *Spool)
+
+// WithPassword encrypts the spooled entries and artifacts
+// with the given password. Encrypted entries are transparently
+// decrypted when read
+func WithPassword(password string) SpoolOption {
+	return func(s *Spool) {
+		s.password = password
+	}
+}
+
+// NewSpool creates a new spool instance, backed by the given directory
+func NewSpool(dir string, opts ...SpoolOption) *Spool {
+	s := &Spool{
+		dir: dir,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// enqueue spools the run artifacts for an upload to the given URL
+func (s *Spool) enqueue(runID, url, manifestPath string, artifacts []Artifact) (*entry, error) {
+	dir := filepath.Join(s.dir, runID)
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create spool directory, %w", err)
+	}
+
+	e := &entry{
+		RunID:     runID,
+		URL:       url,
+		Artifacts: make([]string, 0, len(artifacts)),
+		Manifest:  manifestPath,
+		dir:       dir,
+		password:  s.password,
+	}
+
+	for _, artifact := range artifacts {
+		if err := state.WriteFile(filepath.Join(dir, artifact.Name), artifact.Data, s.password); err != nil {
+			return nil, fmt.Errorf("unable to spool artifact %s, %w", artifact.Name, err)
+		}
+
+		e.Artifacts = append(e.Artifacts, artifact.Name)
+	}
+
+	entryJSON, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal spool entry, %w", err)
+	}
+
+	// The entry file is written last, so partially
+	// spooled runs are never picked up
+	if err := state.WriteFile(filepath.Join(dir, entryFile), entryJSON, s.password); err != nil {
+		return nil, fmt.Errorf("unable to write spool entry, %w", err)
+	}
+
+	return e, nil
+}
+
+// pending returns all spooled entries that are not yet uploaded
+func (s *Spool) pending() ([]*entry, error) {
+	dirs, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read spool directory, %w", err)
+	}
+
+	entries := make([]*entry, 0, len(dirs))
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		entryDir := filepath.Join(s.dir, dir.Name())
+
+		// Skip entries that are already uploaded
+		if _, err := os.Stat(filepath.Join(entryDir, doneFile)); err == nil {
+			continue
+		}
+
+		e, err := readEntry(entryDir, s.password)
+		if err != nil {
+			// Encrypted entries need the right password
+			if errors.Is(err, state.ErrPasswordRequired) || errors.Is(err, state.ErrWrongPassword) {
+				return nil, fmt.Errorf("unable to read spool entry %s, %w", dir.Name(), err)
+			}
+
+			// Partially spooled entry
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	// Keep the upload order deterministic
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RunID < entries[j].RunID
+	})
+
+	return entries, nil
+}
+
+// readEntry reads the spooled entry from the given directory
+func readEntry(dir, password string) (*entry, error) {
+	entryJSON, err := state.ReadFile(filepath.Join(dir, entryFile), password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spool entry, %w", err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(entryJSON, &e); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal spool entry, %w", err)
+	}
+
+	if e.RunID == "" || e.URL == "" {
+		return nil, errInvalidEntry
+	}
+
+	e.dir = dir
+	e.password = password
+
+	return &e, nil
+}
+
+// readArtifact reads the spooled entry artifact
+func (e *entry) readArtifact(name string) ([]byte, error) {
+	return state.ReadFile(filepath.Join(e.dir, name), e.password)
+}
+
+// markDone marks the entry as uploaded
+func (e *entry) markDone() error {
+	return os.WriteFile(filepath.Join(e.dir, doneFile), nil, 0o600)
+}
@@ -0,0 +1,182 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// IdempotencyHeader is the header carrying the run ID,
+	// used by the receiver to discard duplicate uploads
+	IdempotencyHeader = "Idempotency-Key"
+
+	// ArtifactHeader is the header carrying the artifact name
+	ArtifactHeader = "X-Supernova-Artifact"
+)
+
+// Uploader uploads spooled run artifacts, with retries
+type Uploader struct {
+	spool  *Spool
+	client *http.Client
+
+	retries    int           // the number of retries per artifact
+	retryDelay time.Duration // the initial delay between retries
+}
+
+// NewUploader creates a new uploader instance for the given spool
+func NewUploader(spool *Spool) *Uploader {
+	return &Uploader{
+		spool: spool,
+		client: &http.Client{
+			Timeout: time.Second * 30,
+		},
+		retries:    5,
+		retryDelay: time.Second,
+	}
+}
+
+type UploadOption func(o *uploadOptions)
+
+type uploadOptions struct {
+	manifest string
+}
+
+// WithManifest records the upload receipt in the given run manifest,
+// once the upload completes (possibly later, on a flush)
+func WithManifest(path string) UploadOption {
+	return func(o *uploadOptions) {
+		o.manifest = path
+	}
+}
+
+// Upload spools the run artifacts, and attempts to upload them to the given URL.
+// The artifacts remain spooled if the upload fails, so they can be flushed later
+func (u *Uploader) Upload(runID, url string, artifacts []Artifact, opts ...UploadOption) error {
+	var o uploadOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	e, err := u.spool.enqueue(runID, url, o.manifest, artifacts)
+	if err != nil {
+		return err
+	}
+
+	return u.upload(e)
+}
+
+// Flush attempts to upload all previously spooled (failed) uploads,
+// and returns the number of successfully uploaded runs
+func (u *Uploader) Flush() (int, error) {
+	entries, err := u.spool.pending()
+	if err != nil {
+		return 0, err
+	}
+
+	uploaded := 0
+
+	for _, e := range entries {
+		if err := u.upload(e); err != nil {
+			fmt.Printf("❌ Unable to upload run %s, %v\n", e.RunID, err)
+
+			continue
+		}
+
+		fmt.Printf("✅ Uploaded run %s\n", e.RunID)
+
+		uploaded++
+	}
+
+	if uploaded != len(entries) {
+		return uploaded, fmt.Errorf("unable to upload %d runs", len(entries)-uploaded)
+	}
+
+	return uploaded, nil
+}
+
+// upload uploads all the entry artifacts,
+// and marks the entry as done
+func (u *Uploader) upload(e *entry) error {
+	for _, artifact := range e.Artifacts {
+		data, err := e.readArtifact(artifact)
+		if err != nil {
+			return fmt.Errorf("unable to read spooled artifact %s, %w", artifact, err)
+		}
+
+		if err := u.uploadWithRetry(e.URL, e.RunID, artifact, data); err != nil {
+			return fmt.Errorf("unable to upload artifact %s, %w", artifact, err)
+		}
+	}
+
+	// The entry is only marked as done
+	// once all artifacts are uploaded
+	if err := e.markDone(); err != nil {
+		return fmt.Errorf("unable to mark upload as done, %w", err)
+	}
+
+	// The upload already completed, so a failed
+	// receipt never fails the upload
+	if e.Manifest != "" {
+		if err := e.recordReceipt(); err != nil {
+			fmt.Printf("⚠️ Unable to record upload receipt for run %s, %v\n", e.RunID, err)
+		}
+	}
+
+	return nil
+}
+
+// uploadWithRetry uploads the artifact, retrying with exponential backoff
+func (u *Uploader) uploadWithRetry(url, runID, name string, data []byte) error {
+	var (
+		delay = u.retryDelay
+		err   error
+	)
+
+	for attempt := 0; attempt <= u.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+
+			delay *= 2
+		}
+
+		if err = u.post(url, runID, name, data); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// post executes a single artifact upload request
+func (u *Uploader) post(url, runID, name string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	contentType := "application/octet-stream"
+	if filepath.Ext(name) == ".json" {
+		contentType = "application/json"
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(IdempotencyHeader, runID)
+	req.Header.Set(ArtifactHeader, name)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to execute request, %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("upload rejected with status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package upload
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/manifest"
+	"github.com/gnolang/supernova/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockReceiver is a simple upload receiver that
+// rejects the first N upload requests
+type mockReceiver struct {
+	mux sync.Mutex
+
+	failures int
+	uploads  map[string][]byte // idempotency key + artifact -> data
+}
+
+func (m *mockReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.failures > 0 {
+		m.failures--
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	data, _ := io.ReadAll(r.Body)
+	m.uploads[r.Header.Get(IdempotencyHeader)+"/"+r.Header.Get(ArtifactHeader)] = data
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// newTestUploader creates an uploader with no retry delay
+func newTestUploader(t *testing.T, dir string, opts ...SpoolOption) *Uploader {
+	t.Helper()
+
+	u := NewUploader(NewSpool(dir, opts...))
+	u.retryDelay = time.Millisecond
+
+	return u
+}
+
+func TestUploader_Upload(t *testing.T) {
+	t.Parallel()
+
+	artifacts := []Artifact{
+		{
+			Name: "results.json",
+			Data: []byte(`{"averageTPS":10}`),
+		},
+	}
+
+	t.Run("upload with retries", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir      = t.TempDir()
+			receiver = &mockReceiver{
+				failures: 2,
+				uploads:  make(map[string][]byte),
+			}
+			server = httptest.NewServer(receiver)
+		)
+
+		defer server.Close()
+
+		u := newTestUploader(t, dir)
+
+		if err := u.Upload("run-1", server.URL, artifacts); err != nil {
+			t.Fatalf("unable to upload, %v", err)
+		}
+
+		assert.Equal(t, artifacts[0].Data, receiver.uploads["run-1/results.json"])
+
+		// Make sure the entry is marked as done
+		assert.FileExists(t, filepath.Join(dir, "run-1", doneFile))
+	})
+
+	t.Run("flush previously failed uploads", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir      = t.TempDir()
+			receiver = &mockReceiver{
+				failures: 100,
+				uploads:  make(map[string][]byte),
+			}
+			server = httptest.NewServer(receiver)
+		)
+
+		defer server.Close()
+
+		u := newTestUploader(t, dir)
+		u.retries = 1
+
+		// Make sure the failed upload remains spooled
+		assert.Error(t, u.Upload("run-1", server.URL, artifacts))
+
+		_, err := os.Stat(filepath.Join(dir, "run-1", doneFile))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		// Flush the spool, once the receiver is available
+		receiver.mux.Lock()
+		receiver.failures = 0
+		receiver.mux.Unlock()
+
+		uploaded, err := u.Flush()
+		if err != nil {
+			t.Fatalf("unable to flush spool, %v", err)
+		}
+
+		assert.Equal(t, 1, uploaded)
+		assert.Equal(t, artifacts[0].Data, receiver.uploads["run-1/results.json"])
+
+		// Make sure uploaded entries are not flushed again
+		uploaded, err = u.Flush()
+		if err != nil {
+			t.Fatalf("unable to flush spool, %v", err)
+		}
+
+		assert.Equal(t, 0, uploaded)
+	})
+
+	t.Run("delayed receipt recorded in manifest", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir          = t.TempDir()
+			manifestPath = filepath.Join(t.TempDir(), manifest.FileName("run-1"))
+			receiver     = &mockReceiver{
+				failures: 100,
+				uploads:  make(map[string][]byte),
+			}
+			server = httptest.NewServer(receiver)
+		)
+
+		defer server.Close()
+
+		u := newTestUploader(t, dir)
+		u.retries = 1
+
+		assert.Error(t, u.Upload("run-1", server.URL, artifacts, WithManifest(manifestPath)))
+
+		// Make sure no receipt is recorded for failed uploads
+		_, err := os.Stat(manifestPath)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		receiver.mux.Lock()
+		receiver.failures = 0
+		receiver.mux.Unlock()
+
+		if _, err := u.Flush(); err != nil {
+			t.Fatalf("unable to flush spool, %v", err)
+		}
+
+		m, err := manifest.Load(manifestPath)
+		if err != nil {
+			t.Fatalf("unable to load manifest, %v", err)
+		}
+
+		receipts, err := m.Locate(manifest.TypeUploadReceipt)
+		if err != nil {
+			t.Fatalf("unable to locate receipt, %v", err)
+		}
+
+		assert.Equal(t, []string{filepath.Join(filepath.Dir(manifestPath), receiptFileName("run-1"))}, receipts)
+	})
+
+	t.Run("encrypted spool", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir      = t.TempDir()
+			password = "secret"
+			receiver = &mockReceiver{
+				failures: 100,
+				uploads:  make(map[string][]byte),
+			}
+			server = httptest.NewServer(receiver)
+		)
+
+		defer server.Close()
+
+		u := newTestUploader(t, dir, WithPassword(password))
+		u.retries = 1
+
+		assert.Error(t, u.Upload("run-1", server.URL, artifacts))
+
+		// Make sure the spooled files are encrypted
+		for _, name := range []string{entryFile, artifacts[0].Name} {
+			data, err := os.ReadFile(filepath.Join(dir, "run-1", name))
+			if err != nil {
+				t.Fatalf("unable to read spooled file, %v", err)
+			}
+
+			assert.True(t, state.IsEncrypted(data))
+		}
+
+		receiver.mux.Lock()
+		receiver.failures = 0
+		receiver.mux.Unlock()
+
+		// Make sure the spool can't be flushed without the password
+		_, err := newTestUploader(t, dir).Flush()
+		assert.ErrorIs(t, err, state.ErrPasswordRequired)
+
+		_, err = newTestUploader(t, dir, WithPassword("wrong")).Flush()
+		assert.ErrorIs(t, err, state.ErrWrongPassword)
+
+		// Flush the spool with the right password
+		uploaded, err := u.Flush()
+		if err != nil {
+			t.Fatalf("unable to flush spool, %v", err)
+		}
+
+		assert.Equal(t, 1, uploaded)
+		assert.Equal(t, artifacts[0].Data, receiver.uploads["run-1/results.json"])
+	})
+}
@@ -0,0 +1,196 @@
+// Package results reads the supernova run results (saved with -output),
+// for tooling outside of supernova. The reader loads the results of any
+// shipped schema version, and fields missing from older results are
+// read as their documented zero values, so tools don't break when fields are added
+package results
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+)
+
+// The shipped results schema versions
+const (
+	// VersionUnversioned are the results predating the schema versioning,
+	// which leave out the schema version
+	VersionUnversioned = 0
+
+	// VersionCurrent is the schema version supernova writes
+	VersionCurrent = collector.SchemaVersion
+)
+
+// GasSourceBlockResults is the gas source of the results
+// predating the tx results backfill, which leave it out
+const GasSourceBlockResults = collector.GasSourceBlockResults
+
+// ErrUnsupportedVersion is returned for results written by a newer supernova
+var ErrUnsupportedVersion = errors.New("unsupported results schema version")
+
+// Results are the run results of a single run
+type Results struct {
+	raw *collector.RunResult
+}
+
+// Block is a single block of the run
+type Block struct {
+	Number       int64
+	Time         time.Time // the block time, as reported by the node
+	Transactions int64     // the number of block transactions, including the ones not sent by the run
+	GasUsed      int64
+	GasLimit     int64
+	GasSource    string // GasSourceBlockResults, if left out
+}
+
+// Phase is a single pipeline phase of the run
+type Phase struct {
+	Name string
+
+	// StartTime and EndTime are the wall-clock bounds of the phase, in UTC.
+	// They are zero for the results predating the wall-clock timeline
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+}
+
+// Load loads the run results from the given results file
+func Load(path string) (*Results, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read results file %s, %w", path, err)
+	}
+
+	r, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load results file %s, %w", path, err)
+	}
+
+	return r, nil
+}
+
+// Parse parses the JSON run results
+func Parse(data []byte) (*Results, error) {
+	var raw collector.RunResult
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal results, %w", err)
+	}
+
+	if raw.SchemaVersion < VersionUnversioned || raw.SchemaVersion > VersionCurrent {
+		return nil, fmt.Errorf("%w %d, expected at most %d", ErrUnsupportedVersion, raw.SchemaVersion, VersionCurrent)
+	}
+
+	return &Results{raw: &raw}, nil
+}
+
+// Raw returns the decoded run results, as written by supernova.
+// The raw fields are not covered by the compatibility guarantees
+// of the accessors, and are meant for supernova itself
+func (r *Results) Raw() *collector.RunResult {
+	return r.raw
+}
+
+// Version returns the results schema version,
+// VersionUnversioned for the results predating the versioning
+func (r *Results) Version() int {
+	return r.raw.SchemaVersion
+}
+
+// RunID returns the run ID
+func (r *Results) RunID() string {
+	return r.raw.RunID
+}
+
+// Label returns the run label, empty if the run was not labeled
+func (r *Results) Label() string {
+	return r.raw.Label
+}
+
+// Preset returns the workload preset of the run, empty if none was used
+func (r *Results) Preset() string {
+	return r.raw.Preset
+}
+
+// StartTime returns the wall-clock start of the run, in UTC.
+// It is zero for the results predating the wall-clock timeline
+func (r *Results) StartTime() time.Time {
+	return r.raw.StartTime
+}
+
+// EndTime returns the wall-clock end of the run, in UTC.
+// It is zero for the results predating the wall-clock timeline
+func (r *Results) EndTime() time.Time {
+	return r.raw.EndTime
+}
+
+// AverageTPS returns the average TPS of the run
+func (r *Results) AverageTPS() int {
+	return r.raw.AverageTPS
+}
+
+// CommittedTxs returns the number of committed run transactions
+func (r *Results) CommittedTxs() int {
+	return r.raw.CommittedTxs
+}
+
+// LostTxs returns the number of run transactions that were never committed
+func (r *Results) LostTxs() int {
+	return r.raw.LostTxs
+}
+
+// CompletionThreshold returns the committed transaction ratio the collection waited for
+func (r *Results) CompletionThreshold() float64 {
+	return r.raw.CompletionThreshold
+}
+
+// GasSource returns the least precise source of the block gas used,
+// GasSourceBlockResults for the results predating the tx results backfill
+func (r *Results) GasSource() string {
+	return gasSource(r.raw.GasSource)
+}
+
+// Blocks returns the blocks holding the run transactions, in order
+func (r *Results) Blocks() []Block {
+	blocks := make([]Block, 0, len(r.raw.Blocks))
+
+	for _, block := range r.raw.Blocks {
+		blocks = append(blocks, Block{
+			Number:       block.Number,
+			Time:         block.Time,
+			Transactions: block.Transactions,
+			GasUsed:      block.GasUsed,
+			GasLimit:     block.GasLimit,
+			GasSource:    gasSource(block.GasSource),
+		})
+	}
+
+	return blocks
+}
+
+// Phases returns the pipeline phases of the run, in order
+func (r *Results) Phases() []Phase {
+	phases := make([]Phase, 0, len(r.raw.Phases))
+
+	for _, phase := range r.raw.Phases {
+		phases = append(phases, Phase{
+			Name:      phase.Name,
+			StartTime: phase.StartTime,
+			EndTime:   phase.EndTime,
+			Duration:  phase.Duration,
+		})
+	}
+
+	return phases
+}
+
+// gasSource returns the gas source, defaulting to the block results
+func gasSource(source string) string {
+	if source == "" {
+		return GasSourceBlockResults
+	}
+
+	return source
+}
@@ -0,0 +1,86 @@
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unversioned results", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := Load(filepath.Join("testdata", "v0.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, VersionUnversioned, r.Version())
+		assert.Equal(t, "20240102T150405-1a2b3c4d", r.RunID())
+		assert.Equal(t, 120, r.CommittedTxs())
+
+		// The fields left out are read as their zero values
+		assert.Empty(t, r.Label())
+		assert.True(t, r.StartTime().IsZero())
+		assert.True(t, r.EndTime().IsZero())
+		assert.Equal(t, GasSourceBlockResults, r.GasSource())
+
+		phases := r.Phases()
+		require.Len(t, phases, 2)
+
+		assert.Equal(t, 2*time.Second, phases[1].Duration)
+		assert.True(t, phases[1].StartTime.IsZero())
+
+		for _, block := range r.Blocks() {
+			assert.Equal(t, GasSourceBlockResults, block.GasSource)
+		}
+	})
+
+	t.Run("current results", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := Load(filepath.Join("testdata", "v1.json"))
+		require.NoError(t, err)
+
+		assert.Equal(t, VersionCurrent, r.Version())
+		assert.Equal(t, "nightly", r.Label())
+		assert.Equal(t, 10, r.LostTxs())
+		assert.Equal(t, time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), r.StartTime())
+		assert.Equal(t, "unavailable", r.GasSource())
+
+		blocks := r.Blocks()
+		require.Len(t, blocks, 2)
+
+		assert.Equal(t, GasSourceBlockResults, blocks[0].GasSource)
+		assert.Equal(t, "unavailable", blocks[1].GasSource)
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := Parse([]byte(`{"schemaVersion": 1, "runId": "run", "futureField": {"value": 1}}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "run", r.RunID())
+	})
+
+	t.Run("newer schema version", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Parse([]byte(`{"schemaVersion": 99}`))
+
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,126 @@
+package results
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInconsistent is returned for results that are not internally consistent
+var ErrInconsistent = errors.New("inconsistent results")
+
+// Validate checks the internal consistency of the results: the transaction
+// counts add up, and the timeline is ordered. The timeline bounds left out
+// by older results are not checked
+func (r *Results) Validate() error {
+	var issues []string
+
+	issues = append(issues, r.countIssues()...)
+	issues = append(issues, r.timelineIssues()...)
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w, %s", ErrInconsistent, strings.Join(issues, "; "))
+}
+
+// countIssues returns the transaction count inconsistencies
+func (r *Results) countIssues() []string {
+	var (
+		raw    = r.raw
+		issues []string
+	)
+
+	if raw.CommittedTxs < 0 || raw.LostTxs < 0 {
+		issues = append(
+			issues,
+			fmt.Sprintf("negative transaction counts (%d committed, %d lost)", raw.CommittedTxs, raw.LostTxs),
+		)
+	}
+
+	// The blocks also hold the transactions not sent by the run
+	if len(raw.Blocks) > 0 {
+		var blockTxs int64
+
+		for _, block := range raw.Blocks {
+			blockTxs += block.Transactions
+		}
+
+		if blockTxs < int64(raw.CommittedTxs) {
+			issues = append(
+				issues,
+				fmt.Sprintf("the blocks hold %d transactions, fewer than the %d committed", blockTxs, raw.CommittedTxs),
+			)
+		}
+	}
+
+	// The segments only count the run transactions
+	if len(raw.Segments) > 0 {
+		segmentTxs := 0
+
+		for _, segment := range raw.Segments {
+			segmentTxs += segment.Transactions
+		}
+
+		if segmentTxs != raw.CommittedTxs {
+			issues = append(
+				issues,
+				fmt.Sprintf("the segments hold %d transactions, instead of the %d committed", segmentTxs, raw.CommittedTxs),
+			)
+		}
+	}
+
+	return issues
+}
+
+// timelineIssues returns the timeline ordering inconsistencies
+func (r *Results) timelineIssues() []string {
+	var (
+		raw    = r.raw
+		issues []string
+	)
+
+	if outOfOrder(raw.StartTime, raw.EndTime) {
+		issues = append(issues, "the run ends before it starts")
+	}
+
+	var previous time.Time
+
+	for _, phase := range raw.Phases {
+		switch {
+		case outOfOrder(phase.StartTime, phase.EndTime):
+			issues = append(issues, fmt.Sprintf("the %s phase ends before it starts", phase.Name))
+		case outOfOrder(previous, phase.StartTime):
+			issues = append(issues, fmt.Sprintf("the %s phase starts before the previous phase ends", phase.Name))
+		case outOfOrder(raw.StartTime, phase.StartTime) || outOfOrder(phase.EndTime, raw.EndTime):
+			issues = append(issues, fmt.Sprintf("the %s phase is outside of the run bounds", phase.Name))
+		}
+
+		if !phase.EndTime.IsZero() {
+			previous = phase.EndTime
+		}
+	}
+
+	for i := 1; i < len(raw.Blocks); i++ {
+		previous, block := raw.Blocks[i-1], raw.Blocks[i]
+
+		if block.Number <= previous.Number || outOfOrder(previous.Time, block.Time) {
+			issues = append(issues, fmt.Sprintf("block #%d is out of order", block.Number))
+		}
+	}
+
+	for _, segment := range raw.Segments {
+		if outOfOrder(segment.StartTime, segment.EndTime) {
+			issues = append(issues, fmt.Sprintf("segment %d ends before it starts", segment.Index))
+		}
+	}
+
+	return issues
+}
+
+// outOfOrder returns true if both times are set, and the end precedes the start
+func outOfOrder(start, end time.Time) bool {
+	return !start.IsZero() && !end.IsZero() && end.Before(start)
+}
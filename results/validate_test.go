@@ -0,0 +1,116 @@
+package results
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/supernova/internal/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_Validate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	t.Run("shipped results", func(t *testing.T) {
+		t.Parallel()
+
+		for _, file := range []string{"v0.json", "v1.json"} {
+			r, err := Load(filepath.Join("testdata", file))
+			require.NoError(t, err)
+
+			assert.NoError(t, r.Validate(), file)
+		}
+	})
+
+	testTable := []struct {
+		name     string
+		result   *collector.RunResult
+		expected string
+	}{
+		{
+			"negative counts",
+			&collector.RunResult{
+				CommittedTxs: 10,
+				LostTxs:      -1,
+			},
+			"negative transaction counts",
+		},
+		{
+			"blocks short of the committed count",
+			&collector.RunResult{
+				CommittedTxs: 10,
+				Blocks: []*collector.BlockResult{
+					{Number: 1, Transactions: 5},
+				},
+			},
+			"the blocks hold 5 transactions, fewer than the 10 committed",
+		},
+		{
+			"segments not adding up",
+			&collector.RunResult{
+				CommittedTxs: 10,
+				Segments: []*collector.SegmentResult{
+					{Index: 0, Transactions: 4},
+					{Index: 1, Transactions: 4},
+				},
+			},
+			"the segments hold 8 transactions, instead of the 10 committed",
+		},
+		{
+			"reversed run bounds",
+			&collector.RunResult{
+				StartTime: start,
+				EndTime:   start.Add(-time.Second),
+			},
+			"the run ends before it starts",
+		},
+		{
+			"overlapping phases",
+			&collector.RunResult{
+				Phases: []*collector.PhaseResult{
+					{Name: "initialize", StartTime: start, EndTime: start.Add(2 * time.Second)},
+					{Name: "collect", StartTime: start.Add(time.Second), EndTime: start.Add(3 * time.Second)},
+				},
+			},
+			"the collect phase starts before the previous phase ends",
+		},
+		{
+			"phase outside of the run",
+			&collector.RunResult{
+				StartTime: start,
+				EndTime:   start.Add(time.Second),
+				Phases: []*collector.PhaseResult{
+					{Name: "collect", StartTime: start, EndTime: start.Add(2 * time.Second)},
+				},
+			},
+			"the collect phase is outside of the run bounds",
+		},
+		{
+			"blocks out of order",
+			&collector.RunResult{
+				Blocks: []*collector.BlockResult{
+					{Number: 2, Time: start},
+					{Number: 1, Time: start.Add(time.Second)},
+				},
+			},
+			"block #1 is out of order",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := (&Results{raw: testCase.result}).Validate()
+
+			assert.ErrorIs(t, err, ErrInconsistent)
+			assert.ErrorContains(t, err, testCase.expected)
+		})
+	}
+}